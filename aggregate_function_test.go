@@ -0,0 +1,58 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestAggregateFunction(t *testing.T) {
+	ctx := context.Background()
+	conn := Conn(t)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_agg (k UInt8, s AggregateFunction(sum, UInt64), m SimpleAggregateFunction(max, Int32)) " +
+			"ENGINE = AggregatingMergeTree ORDER BY k",
+	}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_agg SELECT number % 2, sumState(number), max(toInt32(number)) FROM numbers(10) GROUP BY number % 2",
+	}))
+
+	var (
+		keys   proto.ColUInt8
+		states proto.ColAggregateFunction
+		maxes  proto.ColInt32
+	)
+	selectStates := Query{
+		Body: "SELECT k, s, m FROM test_agg ORDER BY k",
+		Result: proto.Results{
+			{Name: "k", Data: &keys},
+			{Name: "s", Data: &states},
+			{Name: "m", Data: &maxes},
+		},
+	}
+	require.NoError(t, conn.Do(ctx, selectStates))
+	require.Equal(t, 2, states.Rows())
+	require.Equal(t, []int32{8, 9}, []int32(maxes))
+
+	// Re-inserting states.
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_agg VALUES",
+		Input: proto.Input{
+			{Name: "k", Data: keys},
+			{Name: "s", Data: states},
+			{Name: "m", Data: proto.Alias(&maxes, "SimpleAggregateFunction(max, Int32)")},
+		},
+	}))
+
+	var sums proto.ColUInt64
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT sumMerge(s) AS v FROM test_agg GROUP BY k ORDER BY k",
+		Result: proto.Results{
+			{Name: "v", Data: &sums},
+		},
+	}))
+	require.Equal(t, []uint64{2 * 20, 2 * 25}, []uint64(sums))
+}
@@ -0,0 +1,44 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestBFloat16(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		Settings: []Setting{
+			SettingInt("allow_experimental_bfloat16_type", 1),
+		},
+	})
+	if v := conn.ServerInfo(); (v.Major < 24) || (v.Major == 24 && v.Minor < 11) {
+		t.Skip("Skipping (not supported)")
+	}
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_bfloat16 (v BFloat16) ENGINE = Memory",
+	}))
+	values := []float32{1, -2.5, 0.125, 1024}
+	var data proto.ColBFloat16
+	data.AppendFloat32s(values)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_bfloat16 VALUES",
+		Input: proto.Input{
+			{Name: "v", Data: data},
+		},
+	}))
+
+	var got proto.ColBFloat16
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT v FROM test_bfloat16",
+		Result: proto.Results{
+			{Name: "v", Data: &got},
+		},
+	}))
+	require.Equal(t, values, got.Float32s(nil))
+}
@@ -0,0 +1,121 @@
+// Package chparquet writes ClickHouse query results to Parquet files.
+package chparquet
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/charrow"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Options for Writer. Zero value is valid.
+type Options struct {
+	// Compression of column chunks, no compression by default.
+	Compression compress.Compression
+	// RowGroupRows is maximum number of rows in row group, 1M by default.
+	RowGroupRows int64
+	// Properties are additional writer properties, applied after
+	// Compression and RowGroupRows.
+	Properties []parquet.WriterProperty
+}
+
+// DefaultRowGroupRows is default maximum number of rows in row group.
+const DefaultRowGroupRows = 1 << 20
+
+func (o *Options) setDefaults() {
+	if o.RowGroupRows == 0 {
+		o.RowGroupRows = DefaultRowGroupRows
+	}
+}
+
+// Writer streams result blocks into Parquet file.
+//
+// Schema is derived from first written block, so blocks with zero rows
+// that server sends on query start are enough to write empty file.
+type Writer struct {
+	w    io.Writer
+	opt  Options
+	file *pqarrow.FileWriter
+	rows int
+}
+
+// NewWriter initializes and returns new Writer that writes to w.
+//
+// Writer does not close w.
+func NewWriter(w io.Writer, opt Options) *Writer {
+	opt.setDefaults()
+	return &Writer{w: w, opt: opt}
+}
+
+// writerOnly hides io.Closer of writer, because parquet closes it.
+type writerOnly struct {
+	io.Writer
+}
+
+func (w *Writer) init(schema *arrow.Schema) error {
+	props := append([]parquet.WriterProperty{
+		parquet.WithCompression(w.opt.Compression),
+		parquet.WithMaxRowGroupLength(w.opt.RowGroupRows),
+	}, w.opt.Properties...)
+	file, err := pqarrow.NewFileWriter(schema, writerOnly{Writer: w.w},
+		parquet.NewWriterProperties(props...),
+		pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()),
+	)
+	if err != nil {
+		return errors.Wrap(err, "new file writer")
+	}
+	w.file = file
+	return nil
+}
+
+// Write writes current block of results, e.g. from OnResult of ch.Query.
+//
+// All blocks must have same columns.
+func (w *Writer) Write(results proto.Results) error {
+	input, err := results.Input()
+	if err != nil {
+		return errors.Wrap(err, "input")
+	}
+	rec, err := charrow.Record(input)
+	if err != nil {
+		return errors.Wrap(err, "record")
+	}
+	defer rec.Release()
+	if w.file == nil {
+		if err := w.init(rec.Schema()); err != nil {
+			return err
+		}
+	}
+	if rec.NumRows() == 0 {
+		return nil
+	}
+	if err := w.file.WriteBuffered(rec); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	w.rows += int(rec.NumRows())
+	return nil
+}
+
+// Rows returns number of written rows.
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Close flushes buffered row group and writes file footer.
+//
+// Returns error if no blocks were written, because schema is unknown.
+func (w *Writer) Close() error {
+	if w.file == nil {
+		return errors.New("no blocks written")
+	}
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "close")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package chparquet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/compress"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestWriter(t *testing.T) {
+	var (
+		ids   proto.ColUInt64
+		names proto.ColStr
+		tags  = new(proto.ColStr).Array()
+	)
+	results := proto.Results{
+		{Name: "id", Data: &ids},
+		{Name: "name", Data: &names},
+		{Name: "tags", Data: tags},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, Options{
+		Compression:  compress.Codecs.Zstd,
+		RowGroupRows: 3,
+	})
+	// Header block.
+	require.NoError(t, w.Write(results))
+	for i := 0; i < 2; i++ {
+		for _, c := range results {
+			c.Data.Reset()
+		}
+		for j := 0; j < 2; j++ {
+			ids.Append(uint64(i*2 + j))
+			names.Append("name")
+			tags.Append([]string{"a", "b"}[:j])
+		}
+		require.NoError(t, w.Write(results))
+	}
+	require.NoError(t, w.Close())
+	require.Equal(t, 4, w.Rows())
+
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	require.Equal(t, int64(4), tbl.NumRows())
+	var fields []string
+	for _, f := range tbl.Schema().Fields() {
+		fields = append(fields, f.Name)
+	}
+	require.Equal(t, []string{"id", "name", "tags"}, fields)
+
+	pr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, 2, pr.NumRowGroups())
+	require.NoError(t, pr.Close())
+
+	tr := array.NewTableReader(tbl, 4)
+	defer tr.Release()
+	require.True(t, tr.Next())
+	rec := tr.Record()
+	require.Equal(t, []uint64{0, 1, 2, 3}, rec.Column(0).(*array.Uint64).Uint64Values())
+	require.Equal(t, `[[] ["a"] [] ["a"]]`, rec.Column(2).String())
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, Options{})
+	require.Error(t, w.Close())
+
+	var ids proto.ColInt32
+	require.NoError(t, w.Write(proto.Results{{Name: "id", Data: &ids}}))
+	require.NoError(t, w.Close())
+
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+	require.Equal(t, int64(0), tbl.NumRows())
+	require.Equal(t, "id", tbl.Schema().Field(0).Name)
+}
@@ -0,0 +1,13 @@
+// Package charrow converts between ClickHouse columns and Apache Arrow
+// arrays.
+//
+// Supported types are numeric ones, Bool, String, FixedString, Date,
+// Date32, DateTime, DateTime64 and Nullable, Array and Map of them.
+// LowCardinality(String) is converted to String.
+//
+// Fixed-width and String columns are converted without copying values
+// where layouts allow it, so resulting arrays and columns share memory
+// with source ones and are valid only until source is reset or released.
+//
+// This package is separate module to keep Arrow dependencies out of ch-go.
+package charrow
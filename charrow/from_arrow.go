@@ -0,0 +1,255 @@
+package charrow
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Input converts arrow.Record to proto.Input, inferring column types
+// with ColumnType.
+//
+// Resulting columns may share memory with record.
+func Input(rec arrow.Record) (proto.Input, error) {
+	var input proto.Input
+	for i, f := range rec.Schema().Fields() {
+		t, err := ColumnType(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", f.Name)
+		}
+		col, err := Column(rec.Column(i), t)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", f.Name)
+		}
+		input = append(input, proto.InputColumn{Name: f.Name, Data: col})
+	}
+	return input, nil
+}
+
+// Column converts arrow.Array to column of type t.
+//
+// Resulting column may share memory with array.
+func Column(arr arrow.Array, t proto.ColumnType) (proto.Column, error) {
+	if col := directColumn(arr, t); col != nil {
+		return col, nil
+	}
+	col, err := proto.NewColumn(t)
+	if err != nil {
+		return nil, err
+	}
+	if err := Decode(col, arr); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// Decode resets col and decodes values of arr into it.
+//
+// Useful for types that are not supported by proto.NewColumn, e.g.
+// Map(String, Int64) can be decoded to proto.NewMap[string, int64].
+func Decode(col proto.Column, arr arrow.Array) error {
+	t, err := col.Type().Parse()
+	if err != nil {
+		return err
+	}
+	if containsLowCardinality(t) {
+		return errors.Errorf("type %s is not supported", t)
+	}
+	var b proto.Buffer
+	if err := writeNative(&b, t, arr); err != nil {
+		return errors.Wrapf(err, "%s", t)
+	}
+	col.Reset()
+	r := proto.NewReader(bytes.NewReader(b.Buf))
+	if err := col.DecodeColumn(r, arr.Len()); err != nil {
+		return errors.Wrap(err, "decode")
+	}
+	return nil
+}
+
+// directColumn returns column that shares memory with array, or nil if
+// array layout differs from ClickHouse one.
+func directColumn(arr arrow.Array, t proto.ColumnType) proto.Column {
+	if arr.NullN() > 0 {
+		return nil
+	}
+	if v, err := columnType(arr.DataType()); err != nil || v != t {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.Int8:
+		c := proto.ColInt8(a.Int8Values())
+		return &c
+	case *array.Int16:
+		c := proto.ColInt16(a.Int16Values())
+		return &c
+	case *array.Int32:
+		c := proto.ColInt32(a.Int32Values())
+		return &c
+	case *array.Int64:
+		c := proto.ColInt64(a.Int64Values())
+		return &c
+	case *array.Uint8:
+		c := proto.ColUInt8(a.Uint8Values())
+		return &c
+	case *array.Uint16:
+		c := proto.ColUInt16(a.Uint16Values())
+		return &c
+	case *array.Uint32:
+		c := proto.ColUInt32(a.Uint32Values())
+		return &c
+	case *array.Uint64:
+		c := proto.ColUInt64(a.Uint64Values())
+		return &c
+	case *array.Float32:
+		c := proto.ColFloat32(a.Float32Values())
+		return &c
+	case *array.Float64:
+		c := proto.ColFloat64(a.Float64Values())
+		return &c
+	case *array.String:
+		offsets := a.ValueOffsets()
+		c := &proto.ColStr{
+			Buf: a.ValueBytes(),
+			Pos: make([]proto.Position, a.Len()),
+		}
+		for i := range c.Pos {
+			c.Pos[i] = proto.Position{
+				Start: int(offsets[i] - offsets[0]),
+				End:   int(offsets[i+1] - offsets[0]),
+			}
+		}
+		return c
+	default:
+		return nil
+	}
+}
+
+// writeNative writes values of arr as values of type t in Native format.
+func writeNative(b *proto.Buffer, t *proto.ColumnTypeNode, arr arrow.Array) error {
+	if t.Base == proto.ColumnTypeNullable {
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsNull(i) {
+				b.PutByte(1)
+			} else {
+				b.PutByte(0)
+			}
+		}
+		// Values of null rows are written as is, ClickHouse ignores them.
+		return writeValues(b, t.Elem(0), arr)
+	}
+	if n := arr.NullN(); n > 0 {
+		return errors.Errorf("%d unexpected nulls", n)
+	}
+	return writeValues(b, t, arr)
+}
+
+func writeValues(b *proto.Buffer, t *proto.ColumnTypeNode, arr arrow.Array) error {
+	if t == nil {
+		return errors.New("no element type")
+	}
+	switch t.Base {
+	case proto.ColumnTypeString:
+		switch a := arr.(type) {
+		case *array.String:
+			for i := 0; i < a.Len(); i++ {
+				b.PutString(a.Value(i))
+			}
+		case *array.LargeString:
+			for i := 0; i < a.Len(); i++ {
+				b.PutString(a.Value(i))
+			}
+		case *array.Binary:
+			for i := 0; i < a.Len(); i++ {
+				v := a.Value(i)
+				b.PutUVarInt(uint64(len(v)))
+				b.PutRaw(v)
+			}
+		case *array.LargeBinary:
+			for i := 0; i < a.Len(); i++ {
+				v := a.Value(i)
+				b.PutUVarInt(uint64(len(v)))
+				b.PutRaw(v)
+			}
+		default:
+			return errors.Errorf("unexpected %s", arr.DataType())
+		}
+		return nil
+	case proto.ColumnTypeArray, proto.ColumnTypeMap:
+		if t.Base == proto.ColumnTypeMap && arr.DataType().ID() != arrow.MAP {
+			return errors.Errorf("unexpected %s", arr.DataType())
+		}
+		a, ok := arr.(array.ListLike)
+		if !ok {
+			return errors.Errorf("unexpected %s", arr.DataType())
+		}
+		var start, end int64
+		if a.Len() > 0 {
+			start, _ = a.ValueOffsets(0)
+			end = start
+		}
+		for i := 0; i < a.Len(); i++ {
+			_, end = a.ValueOffsets(i)
+			b.PutUInt64(uint64(end - start))
+		}
+		values := array.NewSlice(a.ListValues(), start, end)
+		defer values.Release()
+		if t.Base == proto.ColumnTypeArray {
+			if err := writeNative(b, t.Elem(0), values); err != nil {
+				return errors.Wrap(err, "elem")
+			}
+			return nil
+		}
+		entries := values.(*array.Struct)
+		if err := writeNative(b, t.Elem(0), entries.Field(0)); err != nil {
+			return errors.Wrap(err, "keys")
+		}
+		if err := writeNative(b, t.Elem(1), entries.Field(1)); err != nil {
+			return errors.Wrap(err, "values")
+		}
+		return nil
+	}
+	dt, _, err := dataType(t)
+	if err != nil {
+		return err
+	}
+	if !arrow.TypeEqual(dt, arr.DataType()) {
+		return errors.Errorf("unexpected %s", arr.DataType())
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		for i := 0; i < a.Len(); i++ {
+			b.PutBool(a.Value(i))
+		}
+		return nil
+	case *array.Date32:
+		if t.Base == proto.ColumnTypeDate {
+			for _, v := range a.Date32Values() {
+				b.PutUInt16(uint16(v))
+			}
+			return nil
+		}
+	case *array.Timestamp:
+		if t.Base == proto.ColumnTypeDateTime {
+			for _, v := range a.TimestampValues() {
+				b.PutUInt32(uint32(v))
+			}
+			return nil
+		}
+	}
+	size, ok := t.Type().FixedWidth()
+	if !ok {
+		return errors.Errorf("type %s is not supported", t)
+	}
+	data := arr.Data()
+	if data.Len() == 0 {
+		return nil
+	}
+	buf := data.Buffers()[1].Bytes()
+	b.PutRaw(buf[data.Offset()*size : (data.Offset()+data.Len())*size])
+	return nil
+}
@@ -0,0 +1,322 @@
+package charrow
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/bitutil"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Record converts input to arrow.Record.
+//
+// Resulting record may share memory with input columns.
+func Record(input proto.Input) (arrow.Record, error) {
+	var (
+		fields []arrow.Field
+		arrays []arrow.Array
+		rows   = -1
+	)
+	defer func() {
+		for _, a := range arrays {
+			a.Release()
+		}
+	}()
+	for _, c := range input {
+		f, err := Field(c.Name, c.Data.Type())
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", c.Name)
+		}
+		a, err := Array(c.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", c.Name)
+		}
+		arrays = append(arrays, a)
+		fields = append(fields, f)
+		if rows != -1 && a.Len() != rows {
+			return nil, errors.Errorf("column %q: rows %d != %d", c.Name, a.Len(), rows)
+		}
+		rows = a.Len()
+	}
+	if rows == -1 {
+		rows = 0
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), arrays, int64(rows)), nil
+}
+
+// Array converts column to arrow.Array.
+//
+// Resulting array may share memory with column.
+func Array(col proto.ColInput) (arrow.Array, error) {
+	data, err := arrayData(col)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+func arrayData(col proto.ColInput) (arrow.ArrayData, error) {
+	switch c := col.(type) {
+	case *proto.ColAuto:
+		return arrayData(c.Data)
+	case proto.ColAuto:
+		return arrayData(c.Data)
+	}
+	if data := directData(col); data != nil {
+		return data, nil
+	}
+	t, err := col.Type().Parse()
+	if err != nil {
+		return nil, err
+	}
+	if containsLowCardinality(t) {
+		return nil, errors.Errorf("type %s is not supported", t)
+	}
+	var b proto.Buffer
+	col.EncodeColumn(&b)
+	data, rest, err := readNative(t, b.Buf, col.Rows())
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", t)
+	}
+	if len(rest) != 0 {
+		data.Release()
+		return nil, errors.Errorf("%s: %d bytes left", t, len(rest))
+	}
+	return data, nil
+}
+
+func containsLowCardinality(t *proto.ColumnTypeNode) bool {
+	if t.Base == proto.ColumnTypeLowCardinality {
+		return true
+	}
+	for _, p := range t.Params {
+		if containsLowCardinality(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func fixedData[T arrow.FixedWidthType](dt arrow.DataType, v []T) arrow.ArrayData {
+	buf := memory.NewBufferBytes(arrow.GetBytes(v))
+	return array.NewData(dt, len(v), []*memory.Buffer{nil, buf}, nil, 0, 0)
+}
+
+// directData returns data that shares memory with column, or nil if
+// column layout differs from Arrow one.
+func directData(col proto.ColInput) arrow.ArrayData {
+	switch c := col.(type) {
+	case *proto.ColInt8:
+		return fixedData(arrow.PrimitiveTypes.Int8, []int8(*c))
+	case *proto.ColInt16:
+		return fixedData(arrow.PrimitiveTypes.Int16, []int16(*c))
+	case *proto.ColInt32:
+		return fixedData(arrow.PrimitiveTypes.Int32, []int32(*c))
+	case *proto.ColInt64:
+		return fixedData(arrow.PrimitiveTypes.Int64, []int64(*c))
+	case *proto.ColUInt8:
+		return fixedData(arrow.PrimitiveTypes.Uint8, []uint8(*c))
+	case *proto.ColUInt16:
+		return fixedData(arrow.PrimitiveTypes.Uint16, []uint16(*c))
+	case *proto.ColUInt32:
+		return fixedData(arrow.PrimitiveTypes.Uint32, []uint32(*c))
+	case *proto.ColUInt64:
+		return fixedData(arrow.PrimitiveTypes.Uint64, []uint64(*c))
+	case *proto.ColFloat32:
+		return fixedData(arrow.PrimitiveTypes.Float32, []float32(*c))
+	case *proto.ColFloat64:
+		return fixedData(arrow.PrimitiveTypes.Float64, []float64(*c))
+	case *proto.ColStr:
+		return strData(c)
+	case *proto.ColLowCardinality[string]:
+		offsets := make([]int32, 0, len(c.Values)+1)
+		var data []byte
+		offsets = append(offsets, 0)
+		for _, v := range c.Values {
+			data = append(data, v...)
+			offsets = append(offsets, int32(len(data)))
+		}
+		if len(data) > math.MaxInt32 {
+			return nil
+		}
+		return stringData(offsets, data)
+	default:
+		return nil
+	}
+}
+
+// strData returns data of ColStr that shares its buffer, which is possible
+// if values are stored contiguously, e.g. after decoding or appending.
+func strData(c *proto.ColStr) arrow.ArrayData {
+	offsets := make([]int32, 0, len(c.Pos)+1)
+	start := 0
+	if len(c.Pos) > 0 {
+		start = c.Pos[0].Start
+	}
+	if start > math.MaxInt32 {
+		return nil
+	}
+	offsets = append(offsets, int32(start))
+	for _, p := range c.Pos {
+		if p.Start != start || p.End > math.MaxInt32 {
+			// Not contiguous, e.g. after AppendNoCopy, or too large.
+			return nil
+		}
+		offsets = append(offsets, int32(p.End))
+		start = p.End
+	}
+	return stringData(offsets, c.Buf)
+}
+
+func stringData(offsets []int32, data []byte) arrow.ArrayData {
+	return array.NewData(arrow.BinaryTypes.String, len(offsets)-1, []*memory.Buffer{
+		nil,
+		memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(offsets)),
+		memory.NewBufferBytes(data),
+	}, nil, 0, 0)
+}
+
+// readNative reads rows values of type t from data in Native format,
+// returning remaining data.
+//
+// Fixed-width values share memory with data.
+func readNative(t *proto.ColumnTypeNode, data []byte, rows int) (arrow.ArrayData, []byte, error) {
+	dt, _, err := dataType(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	need := func(size int) error {
+		if size < 0 || len(data) < size {
+			return errors.Errorf("not enough data: %d < %d", len(data), size)
+		}
+		return nil
+	}
+	switch t.Base {
+	case proto.ColumnTypeNullable:
+		if err := need(rows); err != nil {
+			return nil, nil, errors.Wrap(err, "nulls")
+		}
+		var (
+			valid = make([]byte, bitutil.BytesForBits(int64(rows)))
+			nulls int
+		)
+		for i, v := range data[:rows] {
+			if v == 0 {
+				bitutil.SetBit(valid, i)
+			} else {
+				nulls++
+			}
+		}
+		elem, rest, err := readNative(t.Elem(0), data[rows:], rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer elem.Release()
+		buffers := append([]*memory.Buffer{}, elem.Buffers()...)
+		buffers[0] = memory.NewBufferBytes(valid)
+		return array.NewData(dt, rows, buffers, elem.Children(), nulls, 0), rest, nil
+	case proto.ColumnTypeArray, proto.ColumnTypeMap:
+		if err := need(rows * 8); err != nil {
+			return nil, nil, errors.Wrap(err, "offsets")
+		}
+		offsets := make([]int32, rows+1)
+		var prev uint64
+		for i := 0; i < rows; i++ {
+			o := binary.LittleEndian.Uint64(data[i*8:])
+			if o < prev || o > math.MaxInt32 {
+				return nil, nil, errors.Errorf("row %d: invalid offset %d", i, o)
+			}
+			prev = o
+			offsets[i+1] = int32(o)
+		}
+		data = data[rows*8:]
+		buffers := []*memory.Buffer{nil, memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(offsets))}
+		if t.Base == proto.ColumnTypeArray {
+			elem, rest, err := readNative(t.Elem(0), data, int(prev))
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "elem")
+			}
+			defer elem.Release()
+			return array.NewData(dt, rows, buffers, []arrow.ArrayData{elem}, 0, 0), rest, nil
+		}
+		keys, data, err := readNative(t.Elem(0), data, int(prev))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "keys")
+		}
+		defer keys.Release()
+		values, rest, err := readNative(t.Elem(1), data, int(prev))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "values")
+		}
+		defer values.Release()
+		entries := array.NewData(dt.(*arrow.MapType).Elem(), int(prev), []*memory.Buffer{nil},
+			[]arrow.ArrayData{keys, values}, 0, 0,
+		)
+		defer entries.Release()
+		return array.NewData(dt, rows, buffers, []arrow.ArrayData{entries}, 0, 0), rest, nil
+	case proto.ColumnTypeString:
+		offsets := make([]int32, 1, rows+1)
+		var values []byte
+		for i := 0; i < rows; i++ {
+			l, size := binary.Uvarint(data)
+			if size <= 0 {
+				return nil, nil, errors.Errorf("row %d: invalid length", i)
+			}
+			data = data[size:]
+			if err := need(int(l)); err != nil || l > math.MaxInt32 {
+				return nil, nil, errors.Errorf("row %d: not enough data", i)
+			}
+			values = append(values, data[:l]...)
+			data = data[l:]
+			if len(values) > math.MaxInt32 {
+				return nil, nil, errors.New("string data is too large")
+			}
+			offsets = append(offsets, int32(len(values)))
+		}
+		return stringData(offsets, values), data, nil
+	case proto.ColumnTypeBool:
+		if err := need(rows); err != nil {
+			return nil, nil, err
+		}
+		values := make([]byte, bitutil.BytesForBits(int64(rows)))
+		for i, v := range data[:rows] {
+			if v != 0 {
+				bitutil.SetBit(values, i)
+			}
+		}
+		return array.NewData(dt, rows, []*memory.Buffer{nil, memory.NewBufferBytes(values)}, nil, 0, 0), data[rows:], nil
+	case proto.ColumnTypeDate:
+		if err := need(rows * 2); err != nil {
+			return nil, nil, err
+		}
+		values := make([]int32, rows)
+		for i := range values {
+			values[i] = int32(binary.LittleEndian.Uint16(data[i*2:]))
+		}
+		return fixedData(dt, values), data[rows*2:], nil
+	case proto.ColumnTypeDateTime:
+		if err := need(rows * 4); err != nil {
+			return nil, nil, err
+		}
+		values := make([]int64, rows)
+		for i := range values {
+			values[i] = int64(binary.LittleEndian.Uint32(data[i*4:]))
+		}
+		return fixedData(dt, values), data[rows*4:], nil
+	}
+	size, ok := t.Type().FixedWidth()
+	if !ok {
+		return nil, nil, errors.Errorf("type %s is not supported", t)
+	}
+	if err := need(rows * size); err != nil {
+		return nil, nil, err
+	}
+	buf := memory.NewBufferBytes(data[:rows*size])
+	return array.NewData(dt, rows, []*memory.Buffer{nil, buf}, nil, 0, 0), data[rows*size:], nil
+}
@@ -0,0 +1,233 @@
+package charrow
+
+import (
+	"strconv"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Field returns arrow.Field for column with name and type t.
+//
+// Nullable(T) is nullable field of T.
+func Field(name string, t proto.ColumnType) (arrow.Field, error) {
+	n, err := t.Parse()
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	dt, nullable, err := dataType(n)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	return arrow.Field{Name: name, Type: dt, Nullable: nullable}, nil
+}
+
+// ColumnType returns ClickHouse type for values of arrow.Field.
+//
+// Nullable fields are Nullable(T), except lists and maps, which can't be
+// Nullable in ClickHouse.
+func ColumnType(f arrow.Field) (proto.ColumnType, error) {
+	t, err := columnType(f.Type)
+	if err != nil {
+		return "", errors.Wrap(err, f.Name)
+	}
+	if !f.Nullable {
+		return t, nil
+	}
+	switch f.Type.ID() {
+	case arrow.LIST, arrow.LARGE_LIST, arrow.MAP:
+		return t, nil
+	default:
+		return proto.ColumnTypeNullable.Sub(t), nil
+	}
+}
+
+// timeUnits are arrow.TimeUnit values for DateTime64 precision.
+var timeUnits = map[string]arrow.TimeUnit{
+	"0": arrow.Second,
+	"3": arrow.Millisecond,
+	"6": arrow.Microsecond,
+	"9": arrow.Nanosecond,
+}
+
+// dataType returns arrow type for values of t and whether they are
+// nullable.
+func dataType(t *proto.ColumnTypeNode) (arrow.DataType, bool, error) {
+	if t.Kind != proto.ColumnTypeNodeType {
+		return nil, false, errors.Errorf("%s is not a type", t)
+	}
+	elem := func(i int) (arrow.DataType, bool, error) {
+		e := t.Elem(i)
+		if e == nil {
+			return nil, false, errors.Errorf("%s: no element %d", t, i)
+		}
+		return dataType(e)
+	}
+	switch t.Base {
+	case proto.ColumnTypeInt8:
+		return arrow.PrimitiveTypes.Int8, false, nil
+	case proto.ColumnTypeInt16:
+		return arrow.PrimitiveTypes.Int16, false, nil
+	case proto.ColumnTypeInt32:
+		return arrow.PrimitiveTypes.Int32, false, nil
+	case proto.ColumnTypeInt64:
+		return arrow.PrimitiveTypes.Int64, false, nil
+	case proto.ColumnTypeUInt8:
+		return arrow.PrimitiveTypes.Uint8, false, nil
+	case proto.ColumnTypeUInt16:
+		return arrow.PrimitiveTypes.Uint16, false, nil
+	case proto.ColumnTypeUInt32:
+		return arrow.PrimitiveTypes.Uint32, false, nil
+	case proto.ColumnTypeUInt64:
+		return arrow.PrimitiveTypes.Uint64, false, nil
+	case proto.ColumnTypeFloat32:
+		return arrow.PrimitiveTypes.Float32, false, nil
+	case proto.ColumnTypeFloat64:
+		return arrow.PrimitiveTypes.Float64, false, nil
+	case proto.ColumnTypeBool:
+		return arrow.FixedWidthTypes.Boolean, false, nil
+	case proto.ColumnTypeString:
+		return arrow.BinaryTypes.String, false, nil
+	case proto.ColumnTypeFixedString:
+		p := t.Elem(0)
+		if p == nil || p.Kind != proto.ColumnTypeNodeNumber {
+			return nil, false, errors.Errorf("%s: invalid size", t)
+		}
+		size, err := strconv.Atoi(p.Value)
+		if err != nil || size <= 0 {
+			return nil, false, errors.Errorf("%s: invalid size", t)
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: size}, false, nil
+	case proto.ColumnTypeDate, proto.ColumnTypeDate32:
+		return arrow.FixedWidthTypes.Date32, false, nil
+	case proto.ColumnTypeDateTime:
+		v := &arrow.TimestampType{Unit: arrow.Second}
+		if p := t.Elem(0); p != nil {
+			v.TimeZone = p.Value
+		}
+		return v, false, nil
+	case proto.ColumnTypeDateTime64:
+		p := t.Elem(0)
+		if p == nil {
+			return nil, false, errors.Errorf("%s: no precision", t)
+		}
+		unit, ok := timeUnits[p.Value]
+		if !ok {
+			return nil, false, errors.Errorf("%s: precision %s is not supported", t, p.Value)
+		}
+		v := &arrow.TimestampType{Unit: unit}
+		if p := t.Elem(1); p != nil {
+			v.TimeZone = p.Value
+		}
+		return v, false, nil
+	case proto.ColumnTypeLowCardinality:
+		return elem(0)
+	case proto.ColumnTypeNullable:
+		v, nullable, err := elem(0)
+		if err != nil {
+			return nil, false, err
+		}
+		if nullable {
+			return nil, false, errors.Errorf("%s: nested nullable", t)
+		}
+		return v, true, nil
+	case proto.ColumnTypeArray:
+		v, nullable, err := elem(0)
+		if err != nil {
+			return nil, false, err
+		}
+		if nullable {
+			return arrow.ListOf(v), false, nil
+		}
+		return arrow.ListOfNonNullable(v), false, nil
+	case proto.ColumnTypeMap:
+		k, nullable, err := elem(0)
+		if err != nil {
+			return nil, false, err
+		}
+		if nullable {
+			return nil, false, errors.Errorf("%s: nullable key", t)
+		}
+		v, nullable, err := elem(1)
+		if err != nil {
+			return nil, false, err
+		}
+		m := arrow.MapOf(k, v)
+		m.SetItemNullable(nullable)
+		return m, false, nil
+	default:
+		return nil, false, errors.Errorf("type %s is not supported", t)
+	}
+}
+
+// columnType returns ClickHouse type for non-null values of arrow type.
+func columnType(dt arrow.DataType) (proto.ColumnType, error) {
+	switch dt.ID() {
+	case arrow.INT8:
+		return proto.ColumnTypeInt8, nil
+	case arrow.INT16:
+		return proto.ColumnTypeInt16, nil
+	case arrow.INT32:
+		return proto.ColumnTypeInt32, nil
+	case arrow.INT64:
+		return proto.ColumnTypeInt64, nil
+	case arrow.UINT8:
+		return proto.ColumnTypeUInt8, nil
+	case arrow.UINT16:
+		return proto.ColumnTypeUInt16, nil
+	case arrow.UINT32:
+		return proto.ColumnTypeUInt32, nil
+	case arrow.UINT64:
+		return proto.ColumnTypeUInt64, nil
+	case arrow.FLOAT32:
+		return proto.ColumnTypeFloat32, nil
+	case arrow.FLOAT64:
+		return proto.ColumnTypeFloat64, nil
+	case arrow.BOOL:
+		return proto.ColumnTypeBool, nil
+	case arrow.STRING, arrow.LARGE_STRING, arrow.BINARY, arrow.LARGE_BINARY:
+		return proto.ColumnTypeString, nil
+	case arrow.FIXED_SIZE_BINARY:
+		size := dt.(*arrow.FixedSizeBinaryType).ByteWidth
+		return proto.ColumnTypeFixedString.With(strconv.Itoa(size)), nil
+	case arrow.DATE32:
+		return proto.ColumnTypeDate32, nil
+	case arrow.TIMESTAMP:
+		ts := dt.(*arrow.TimestampType)
+		var params []string
+		t := proto.ColumnTypeDateTime
+		if ts.Unit != arrow.Second {
+			t = proto.ColumnTypeDateTime64
+			params = append(params, strconv.Itoa(3*int(ts.Unit)))
+		}
+		if ts.TimeZone != "" {
+			params = append(params, "'"+ts.TimeZone+"'")
+		}
+		if len(params) == 0 {
+			return t, nil
+		}
+		return t.With(params...), nil
+	case arrow.LIST, arrow.LARGE_LIST:
+		f := dt.(arrow.ListLikeType).ElemField()
+		elem, err := ColumnType(f)
+		if err != nil {
+			return "", errors.Wrap(err, "elem")
+		}
+		return proto.ColumnTypeArray.Sub(elem), nil
+	case arrow.MAP:
+		m := dt.(*arrow.MapType)
+		k, err := columnType(m.KeyType())
+		if err != nil {
+			return "", errors.Wrap(err, "key")
+		}
+		v, err := ColumnType(m.ItemField())
+		if err != nil {
+			return "", errors.Wrap(err, "item")
+		}
+		return proto.ColumnTypeMap.Sub(k, v), nil
+	default:
+		return "", errors.Errorf("type %s is not supported", dt)
+	}
+}
@@ -0,0 +1,202 @@
+package chcluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/go-faster/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/chpool"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Mode selects nodes to query.
+type Mode byte
+
+// Possible modes.
+const (
+	// AllReplicas queries every replica of every shard.
+	AllReplicas Mode = iota
+	// OnePerShard queries single replica of each shard, trying next
+	// replica if previous is unavailable.
+	OnePerShard
+)
+
+// Node is replica of cluster shard that is queried.
+type Node struct {
+	ShardIndex   int // index in ch.ClusterInfo.Shards
+	ReplicaIndex int // index in ch.ClusterShard.Replicas
+	Replica      ch.ClusterReplica
+}
+
+func (n Node) String() string {
+	return fmt.Sprintf("shard %d replica %d (%s)", n.Replica.ShardNum, n.Replica.Num, n.Replica.Addr())
+}
+
+// Options for Client.
+type Options struct {
+	Mode Mode
+	// Concurrency limits count of nodes queried at once, no limit if zero.
+	Concurrency int
+	// FailFast cancels query on other nodes on first error.
+	FailFast bool
+}
+
+// Client executes queries on nodes of cluster.
+type Client struct {
+	cluster *chpool.Cluster
+	opt     Options
+	owned   bool // cluster is closed by Client
+}
+
+// New returns client for cluster pools.
+func New(cluster *chpool.Cluster, opt Options) *Client {
+	return &Client{cluster: cluster, opt: opt}
+}
+
+// Discover returns client for cluster with name from system.clusters of
+// server that c is connected to, see ch.Cluster and chpool.NewCluster.
+//
+// Pools are closed by Client.Close.
+func Discover(ctx context.Context, c *ch.Client, name string, poolOpt chpool.Options, opt Options) (*Client, error) {
+	info, err := ch.Cluster(ctx, c, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cluster")
+	}
+	cluster, err := chpool.NewCluster(ctx, info, poolOpt)
+	if err != nil {
+		return nil, errors.Wrap(err, "pools")
+	}
+	return &Client{cluster: cluster, opt: opt, owned: true}, nil
+}
+
+// Cluster returns pools of cluster.
+func (c *Client) Cluster() *chpool.Cluster { return c.cluster }
+
+// Close closes pools of cluster if client was created by Discover.
+func (c *Client) Close() {
+	if c.owned {
+		c.cluster.Close()
+	}
+}
+
+// Do executes query on nodes concurrently. Query for each node is returned
+// by newQuery, as result columns and handlers can't be shared between
+// concurrent queries.
+//
+// Returns *Error if query failed on some nodes.
+func (c *Client) Do(ctx context.Context, newQuery func(n Node) ch.Query) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	info := c.cluster.Info()
+	var (
+		g   errgroup.Group
+		mux sync.Mutex
+		res = &Error{}
+	)
+	if c.opt.Concurrency > 0 {
+		g.SetLimit(c.opt.Concurrency)
+	}
+	fail := func(n Node, err error) {
+		mux.Lock()
+		defer mux.Unlock()
+		if c.opt.FailFast && len(res.Nodes) > 0 && errors.Is(err, context.Canceled) {
+			// Canceled because of previous failure.
+			return
+		}
+		res.Nodes = append(res.Nodes, &NodeError{Node: n, Err: err})
+		if c.opt.FailFast {
+			cancel()
+		}
+	}
+	for i, s := range info.Shards {
+		i, s := i, s
+		if c.opt.Mode == OnePerShard {
+			res.Total++
+			g.Go(func() error {
+				if n, err := c.doShard(ctx, i, s, newQuery); err != nil {
+					fail(n, err)
+				}
+				return nil
+			})
+			continue
+		}
+		for j, r := range s.Replicas {
+			n := Node{ShardIndex: i, ReplicaIndex: j, Replica: r}
+			res.Total++
+			g.Go(func() error {
+				if err := c.cluster.Replica(n.ShardIndex, n.ReplicaIndex).Do(ctx, newQuery(n)); err != nil {
+					fail(n, err)
+				}
+				return nil
+			})
+		}
+	}
+	_ = g.Wait()
+	if len(res.Nodes) == 0 {
+		return nil
+	}
+	// Order is not deterministic due to concurrency.
+	sortNodeErrors(res.Nodes)
+	return res
+}
+
+// doShard executes query on first available replica of shard.
+func (c *Client) doShard(ctx context.Context, i int, s ch.ClusterShard, newQuery func(n Node) ch.Query) (Node, error) {
+	var (
+		n   Node
+		err error
+	)
+	for j, r := range s.Replicas {
+		n = Node{ShardIndex: i, ReplicaIndex: j, Replica: r}
+		q := newQuery(n)
+		// Failover is possible only if no results were received.
+		var received bool
+		if onResult := q.OnResult; onResult != nil {
+			q.OnResult = func(ctx context.Context, b proto.Block) error {
+				received = true
+				return onResult(ctx, b)
+			}
+		}
+		err = c.cluster.Replica(i, j).Do(ctx, q)
+		if err == nil || received || !unavailable(err) || ctx.Err() != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
+
+// unavailable reports whether err means that node is not available.
+func unavailable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, ch.ErrNetwork) || errors.As(err, &netErr)
+}
+
+// Stream executes query on nodes concurrently, calling onResult with
+// result of each block from any node. Calls of onResult are serialized, so
+// results of nodes are merged to single stream.
+//
+// Result columns are inferred from server, so q should not have Result or
+// OnResult. Results are valid only during onResult call.
+func (c *Client) Stream(ctx context.Context, q ch.Query, onResult func(ctx context.Context, n Node, r proto.Results) error) error {
+	if q.Result != nil || q.OnResult != nil {
+		return errors.New("query should not have Result or OnResult")
+	}
+	var mux sync.Mutex
+	return c.Do(ctx, func(n Node) ch.Query {
+		var results proto.Results
+		nq := q
+		nq.Result = results.Auto()
+		nq.OnResult = func(ctx context.Context, b proto.Block) error {
+			mux.Lock()
+			defer mux.Unlock()
+			return onResult(ctx, n, results)
+		}
+		return nq
+	})
+}
@@ -0,0 +1,148 @@
+package chcluster
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/chpool"
+	"github.com/ClickHouse/ch-go/cht"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func splitAddr(addr string) (string, uint16, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, err
+	}
+	v, err := strconv.ParseUint(port, 10, 16)
+	return host, uint16(v), err
+}
+
+// replica returns replica with address.
+func replica(t testing.TB, shard, num int, addr string) ch.ClusterReplica {
+	t.Helper()
+	host, port, err := splitAddr(addr)
+	require.NoError(t, err)
+	return ch.ClusterReplica{ShardNum: shard, Num: num, Host: host, Port: port}
+}
+
+func newClient(t testing.TB, info *ch.ClusterInfo, opt Options) *Client {
+	t.Helper()
+	cluster, err := chpool.NewCluster(context.Background(), info, chpool.Options{MaxConns: 2})
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return New(cluster, opt)
+}
+
+func TestClient_unavailable(t *testing.T) {
+	ctx := context.Background()
+	info := &ch.ClusterInfo{Shards: []ch.ClusterShard{
+		{Num: 1, Replicas: []ch.ClusterReplica{
+			replica(t, 1, 1, "127.0.0.1:1"),
+			replica(t, 1, 2, "127.0.0.1:2"),
+		}},
+		{Num: 2, Replicas: []ch.ClusterReplica{
+			replica(t, 2, 1, "127.0.0.1:3"),
+		}},
+	}}
+	query := func(n Node) ch.Query { return ch.Query{Body: "SELECT 1"} }
+
+	t.Run("AllReplicas", func(t *testing.T) {
+		err := newClient(t, info, Options{}).Do(ctx, query)
+		var clusterErr *Error
+		require.ErrorAs(t, err, &clusterErr)
+		require.Equal(t, 3, clusterErr.Total)
+		require.Len(t, clusterErr.Nodes, 3)
+		for i, idx := range [][2]int{{0, 0}, {0, 1}, {1, 0}} {
+			require.Equal(t, idx[0], clusterErr.Nodes[i].Node.ShardIndex)
+			require.Equal(t, idx[1], clusterErr.Nodes[i].Node.ReplicaIndex)
+		}
+		require.Contains(t, err.Error(), "query failed on 3 of 3 nodes; shard 1 replica 1 (127.0.0.1:1)")
+	})
+	t.Run("OnePerShard", func(t *testing.T) {
+		var (
+			mux   sync.Mutex
+			tried []string
+		)
+		err := newClient(t, info, Options{Mode: OnePerShard, Concurrency: 1}).Do(ctx, func(n Node) ch.Query {
+			mux.Lock()
+			tried = append(tried, n.Replica.Addr())
+			mux.Unlock()
+			return query(n)
+		})
+		var clusterErr *Error
+		require.ErrorAs(t, err, &clusterErr)
+		require.Equal(t, 2, clusterErr.Total)
+		require.Len(t, clusterErr.Nodes, 2)
+		// Last replica of shard is reported.
+		require.Equal(t, 1, clusterErr.Nodes[0].Node.ReplicaIndex)
+		require.Equal(t, []string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"}, tried)
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		err := newClient(t, info, Options{FailFast: true, Concurrency: 1}).Do(ctx, query)
+		var clusterErr *Error
+		require.ErrorAs(t, err, &clusterErr)
+		require.Len(t, clusterErr.Nodes, 1)
+	})
+	t.Run("Stream", func(t *testing.T) {
+		c := newClient(t, info, Options{})
+		require.Error(t, c.Stream(ctx, ch.Query{Body: "SELECT 1", OnResult: func(ctx context.Context, b proto.Block) error {
+			return nil
+		}}, nil))
+	})
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	server := cht.New(t)
+
+	// Same server as two shards with single replica.
+	info := &ch.ClusterInfo{Shards: []ch.ClusterShard{
+		{Num: 1, Replicas: []ch.ClusterReplica{replica(t, 1, 1, server.TCP)}},
+		{Num: 2, Replicas: []ch.ClusterReplica{replica(t, 2, 1, server.TCP)}},
+	}}
+	c := newClient(t, info, Options{})
+
+	var (
+		mux   sync.Mutex
+		total uint64
+	)
+	require.NoError(t, c.Do(ctx, func(n Node) ch.Query {
+		var data proto.ColUInt64
+		return ch.Query{
+			Body:   "SELECT number FROM system.numbers LIMIT 10",
+			Result: proto.Results{{Name: "number", Data: &data}},
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				mux.Lock()
+				defer mux.Unlock()
+				for _, v := range data {
+					total += v
+				}
+				return nil
+			},
+		}
+	}))
+	require.Equal(t, uint64(2*45), total)
+
+	shards := map[int]int{}
+	require.NoError(t, c.Stream(ctx, ch.Query{Body: "SELECT 1 AS one"}, func(ctx context.Context, n Node, r proto.Results) error {
+		shards[n.Replica.ShardNum] += r.Rows()
+		return nil
+	}))
+	require.Equal(t, map[int]int{1: 1, 2: 1}, shards)
+
+	err := c.Do(ctx, func(n Node) ch.Query {
+		return ch.Query{Body: "SELECT throwIf(1)"}
+	})
+	require.True(t, ch.HasCode(err, proto.ErrFunctionThrowIfValueIsNonZero))
+	var clusterErr *Error
+	require.True(t, errors.As(err, &clusterErr))
+	require.Len(t, clusterErr.Nodes, 2)
+}
@@ -0,0 +1,7 @@
+// Package chcluster executes queries on all nodes of ClickHouse cluster,
+// e.g. to inspect system tables of every replica.
+//
+// Nodes are queried concurrently with connections from pools of
+// chpool.Cluster, results are streamed to callbacks and errors of nodes
+// are aggregated to *Error.
+package chcluster
@@ -0,0 +1,54 @@
+package chcluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NodeError is error of query on node.
+type NodeError struct {
+	Node Node
+	Err  error
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Node, e.Err)
+}
+
+func (e *NodeError) Unwrap() error { return e.Err }
+
+// Error is aggregated error of query on cluster nodes.
+type Error struct {
+	Nodes []*NodeError // in order of nodes
+	Total int          // count of queried nodes
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "query failed on %d of %d nodes", len(e.Nodes), e.Total)
+	for _, n := range e.Nodes {
+		b.WriteString("; ")
+		b.WriteString(n.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns errors of nodes, so errors.Is and errors.As can be used.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Nodes))
+	for _, n := range e.Nodes {
+		errs = append(errs, n)
+	}
+	return errs
+}
+
+func sortNodeErrors(errs []*NodeError) {
+	sort.Slice(errs, func(i, j int) bool {
+		a, b := errs[i].Node, errs[j].Node
+		if a.ShardIndex != b.ShardIndex {
+			return a.ShardIndex < b.ShardIndex
+		}
+		return a.ReplicaIndex < b.ReplicaIndex
+	})
+}
@@ -0,0 +1,372 @@
+package chhttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Client is ClickHouse HTTP interface client.
+//
+// Client is safe for concurrent use, each query is separate HTTP request.
+type Client struct {
+	url      *url.URL
+	http     *http.Client
+	database string
+	user     string
+	password string
+	settings []ch.Setting
+	roles    []string
+	quotaKey string
+	limits   proto.Limits
+}
+
+// Options for Client.
+type Options struct {
+	// Address of HTTP interface, like "http://localhost:8123".
+	Address    string
+	Database   string
+	User       string
+	Password   string
+	HTTPClient *http.Client
+	// Settings are sent with each query.
+	Settings []ch.Setting
+	// Roles to activate for each query instead of default roles of User.
+	Roles []string
+	// QuotaKey is default for Query.QuotaKey.
+	QuotaKey string
+	// DecodeLimits are limits of sizes decoded from results, see
+	// ch.Options.DecodeLimits.
+	DecodeLimits proto.Limits
+}
+
+// DefaultAddress of ClickHouse HTTP interface.
+const DefaultAddress = "http://127.0.0.1:8123"
+
+func (o *Options) setDefaults() {
+	if o.Address == "" {
+		o.Address = DefaultAddress
+	}
+	if o.Database == "" {
+		o.Database = ch.DefaultDatabase
+	}
+	if o.User == "" {
+		o.User = ch.DefaultUser
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = http.DefaultClient
+	}
+}
+
+// New returns new Client. No requests are performed.
+func New(opt Options) (*Client, error) {
+	opt.setDefaults()
+	u, err := url.Parse(opt.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse address")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return &Client{
+		url:      u,
+		http:     opt.HTTPClient,
+		database: opt.Database,
+		user:     opt.User,
+		password: opt.Password,
+		settings: opt.Settings,
+		roles:    opt.Roles,
+		quotaKey: opt.QuotaKey,
+		limits:   opt.DecodeLimits,
+	}, nil
+}
+
+// Ping checks that server is available.
+func (c *Client) Ping(ctx context.Context) error {
+	u := *c.url
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ping"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "request")
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "do")
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// readerSize is not less than buffer size of proto.Reader, so it reuses
+// bufio.Reader and peeking for end of data is possible.
+const readerSize = 256 * 1024
+
+// Do performs Query on ClickHouse server.
+//
+// Query fields are handled same as in ch.Client, except that there are
+// no progress, profile and log packets over HTTP, so corresponding
+// handlers are never called. Raw blocks and ExternalData are not supported.
+//
+// Input columns are not inferred from table, so their types should
+// match table, e.g. enums should be set explicitly.
+func (c *Client) Do(ctx context.Context, q ch.Query) error {
+	if q.OnRawBlock != nil || q.OnRawInput != nil {
+		return errors.New("raw blocks are not supported over HTTP")
+	}
+	if len(q.ExternalData) > 0 {
+		return errors.New("external data is not supported over HTTP")
+	}
+	if q.QueryID == "" {
+		q.QueryID = uuid.New().String()
+	}
+
+	values := c.values(ctx, q)
+	var body io.Reader = strings.NewReader(q.Body)
+	if len(q.Input) > 0 {
+		values.Set("query", q.Body+" FORMAT Native")
+		pr, pw := io.Pipe()
+		defer func() { _ = pr.Close() }()
+		go func() {
+			_ = pw.CloseWithError(sendInput(ctx, pw, q))
+		}()
+		body = pr
+	}
+
+	u := *c.url
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return errors.Wrap(err, "request")
+	}
+	req.Header.Set("X-ClickHouse-User", c.user)
+	if c.password != "" {
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "do")
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return exception(res)
+	}
+	if err := c.decodeResult(ctx, res, q); err != nil {
+		return errors.Wrap(err, "decode result")
+	}
+	return nil
+}
+
+// values returns URL parameters of query.
+func (c *Client) values(ctx context.Context, q ch.Query) url.Values {
+	values := url.Values{}
+	values.Set("database", c.database)
+	values.Set("query_id", q.QueryID)
+	values.Set("default_format", "Native")
+	if v := q.QuotaKey; v != "" {
+		values.Set("quota_key", v)
+	} else if v := c.quotaKey; v != "" {
+		values.Set("quota_key", v)
+	}
+	for _, s := range c.settings {
+		values.Set(s.Key, s.Value)
+	}
+	if v := logComment(q); v != "" {
+		values.Set("log_comment", v)
+	}
+	for _, r := range c.roles {
+		values.Add("role", r)
+	}
+	for _, s := range ch.ContextSettings(ctx) {
+		values.Set(s.Key, s.Value)
+	}
+	for _, s := range q.Settings {
+		values.Set(s.Key, s.Value)
+	}
+	for _, p := range q.Parameters {
+		values.Set("param_"+p.Key, unquote(p.Value))
+	}
+	return values
+}
+
+// unquote returns raw value of parameter, as values of ch.Parameters are
+// quoted for native protocol, but not for HTTP.
+func unquote(v string) string {
+	if len(v) < 2 || v[0] != '\'' || v[len(v)-1] != '\'' {
+		return v
+	}
+	var b strings.Builder
+	v = v[1 : len(v)-1]
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' && i+1 < len(v) {
+			i++
+		}
+		b.WriteByte(v[i])
+	}
+	return b.String()
+}
+
+// logComment returns value of log_comment setting, same as for ch.Client.
+func logComment(q ch.Query) string {
+	if len(q.Tags) == 0 {
+		return q.Comment
+	}
+	tags := make(map[string]string, len(q.Tags)+1)
+	for k, v := range q.Tags {
+		tags[k] = v
+	}
+	if q.Comment != "" {
+		tags["comment"] = q.Comment
+	}
+	// Map keys are sorted by encoding/json, so output is deterministic.
+	data, err := json.Marshal(tags)
+	if err != nil {
+		// Not possible for map[string]string.
+		return q.Comment
+	}
+	return string(data)
+}
+
+func (c *Client) decodeResult(ctx context.Context, res *http.Response, q ch.Query) error {
+	br := bufio.NewReaderSize(res.Body, readerSize)
+	r := proto.NewReader(br)
+	r.SetLimits(c.limits)
+	if s, ok := q.Result.(proto.DefaultLocationSetter); ok {
+		if name := res.Header.Get("X-ClickHouse-Timezone"); name != "" {
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				return errors.Wrapf(err, "load location %q", name)
+			}
+			s.SetDefaultLocation(loc)
+		}
+	}
+	onResult := resultHandler(q)
+	for {
+		if _, err := br.Peek(1); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, "read")
+		}
+		var block proto.Block
+		// HTTP interface uses Native format of zero protocol version,
+		// without block info and custom serialization.
+		if err := block.DecodeRawBlock(r, 0, q.Result); err != nil {
+			return errors.Wrap(err, "decode block")
+		}
+		if block.End() {
+			continue
+		}
+		if err := onResult(ctx, block); err != nil {
+			return errors.Wrap(err, "handler")
+		}
+	}
+}
+
+func resultHandler(q ch.Query) func(ctx context.Context, b proto.Block) error {
+	if q.OnResult != nil {
+		return q.OnResult
+	}
+	if f := q.OnResultBlock; f != nil {
+		// Totals are not sent in Native format over HTTP.
+		return func(ctx context.Context, block proto.Block) error {
+			return f(ctx, proto.BlockMeta{Code: proto.ServerCodeData, Info: block.Info}, block)
+		}
+	}
+	first := true
+	return func(ctx context.Context, block proto.Block) error {
+		if !first {
+			return errors.New("no OnResult provided")
+		}
+		if block.Rows > 0 {
+			first = false
+		}
+		return nil
+	}
+}
+
+// sendInput writes Native blocks from q.Input to w.
+func sendInput(ctx context.Context, w io.Writer, q ch.Query) error {
+	var (
+		f = q.OnInput
+		b proto.Buffer
+	)
+	if f != nil && q.Input[0].Data.Rows() == 0 {
+		// Fetching initial input if no rows provided.
+		if err := f(ctx); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, "input")
+		}
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "context")
+		}
+		b.Reset()
+		block := proto.Block{
+			Columns: len(q.Input),
+			Rows:    q.Input[0].Data.Rows(),
+		}
+		if err := block.EncodeRawBlock(&b, 0, q.Input); err != nil {
+			return errors.Wrap(err, "encode block")
+		}
+		if _, err := w.Write(b.Buf); err != nil {
+			return errors.Wrap(err, "write block")
+		}
+		if f == nil {
+			return nil
+		}
+		if err := f(ctx); err != nil {
+			if errors.Is(err, io.EOF) {
+				if q.Input[0].Data.Rows() > 0 {
+					// Write data tail and stop.
+					f = nil
+					continue
+				}
+				return nil
+			}
+			return errors.Wrap(err, "next input (server already persisted previous blocks)")
+		}
+	}
+}
+
+// exception returns error from response with non-OK status.
+func exception(res *http.Response) error {
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1024*1024))
+	if err != nil {
+		return errors.Wrapf(err, "status %d", res.StatusCode)
+	}
+	code, err := strconv.Atoi(res.Header.Get("X-ClickHouse-Exception-Code"))
+	if err != nil {
+		return errors.Errorf("status %d: %s", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+	// Message is like "Code: 60. DB::Exception: Table x does not exist. (UNKNOWN_TABLE) (version 23.8.1.1)".
+	msg := strings.TrimSpace(string(data))
+	if _, after, ok := strings.Cut(msg, ". "); ok && strings.HasPrefix(msg, "Code: ") {
+		msg = after
+	}
+	e := &ch.Exception{
+		Code:    proto.Error(code),
+		Message: msg,
+	}
+	if name, _, ok := strings.Cut(msg, ": "); ok && strings.HasPrefix(name, "DB::") {
+		e.Name = name
+	}
+	return e
+}
@@ -0,0 +1,208 @@
+package chhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func encode(t *testing.T, input proto.Input) []byte {
+	t.Helper()
+	var b proto.Buffer
+	block := proto.Block{Columns: len(input), Rows: input[0].Data.Rows()}
+	require.NoError(t, block.EncodeRawBlock(&b, 0, input))
+	return b.Buf
+}
+
+func newClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	c, err := New(Options{
+		Address:  s.URL,
+		User:     "user",
+		Password: "secret",
+		Settings: []ch.Setting{{Key: "max_threads", Value: "1"}},
+		Roles:    []string{"reader", "writer"},
+		QuotaKey: "tenant",
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_Do(t *testing.T) {
+	ctx := ch.WithSettings(context.Background(), ch.SettingMaxResultRows(10))
+	t.Run("Select", func(t *testing.T) {
+		// Header block with zero rows, then two data blocks.
+		data := encode(t, proto.Input{
+			{Name: "v", Data: proto.ColUInt64{}},
+			{Name: "s", Data: new(proto.ColStr)},
+		})
+		str := new(proto.ColStr)
+		str.Append("foo")
+		str.Append("bar")
+		data = append(data, encode(t, proto.Input{
+			{Name: "v", Data: proto.ColUInt64{1, 2}},
+			{Name: "s", Data: str},
+		})...)
+		str.Reset()
+		str.Append("baz")
+		data = append(data, encode(t, proto.Input{
+			{Name: "v", Data: proto.ColUInt64{3}},
+			{Name: "s", Data: str},
+		})...)
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil || string(body) != "SELECT v, s FROM t WHERE v > {min:UInt64}" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			q := r.URL.Query()
+			if !reflect.DeepEqual(q["role"], []string{"reader", "writer"}) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for k, v := range map[string]string{
+				"database":        "default",
+				"default_format":  "Native",
+				"query_id":        "id",
+				"max_threads":     "1",
+				"log_comment":     "test",
+				"param_min":       "0",
+				"max_result_rows": "10",
+			} {
+				if q.Get(k) != v {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+			}
+			if r.Header.Get("X-ClickHouse-User") != "user" || r.Header.Get("X-ClickHouse-Key") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(data)
+		})
+
+		var (
+			v    proto.ColUInt64
+			s    proto.ColStr
+			rows []string
+		)
+		require.NoError(t, c.Do(ctx, ch.Query{
+			Body:       "SELECT v, s FROM t WHERE v > {min:UInt64}",
+			QueryID:    "id",
+			Comment:    "test",
+			Parameters: ch.Parameters(map[string]any{"min": 0}),
+			Result: proto.Results{
+				{Name: "v", Data: &v},
+				{Name: "s", Data: &s},
+			},
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				require.Equal(t, v.Rows(), block.Rows)
+				for i := 0; i < s.Rows(); i++ {
+					rows = append(rows, s.Row(i))
+				}
+				return nil
+			},
+		}))
+		require.Equal(t, []string{"foo", "bar", "baz"}, rows)
+	})
+	t.Run("Insert", func(t *testing.T) {
+		var (
+			got   []uint64
+			query string
+		)
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query().Get("query")
+			reader := proto.NewReader(r.Body)
+			for {
+				var (
+					v     proto.ColUInt64
+					block proto.Block
+				)
+				if err := block.DecodeRawBlock(reader, 0, proto.Results{{Name: "v", Data: &v}}); err != nil {
+					if !errors.Is(err, io.EOF) {
+						w.WriteHeader(http.StatusBadRequest)
+					}
+					return
+				}
+				got = append(got, v...)
+			}
+		})
+
+		data := proto.ColUInt64{1, 2}
+		var n int
+		require.NoError(t, c.Do(ctx, ch.Query{
+			Body:  "INSERT INTO t VALUES",
+			Input: proto.Input{{Name: "v", Data: &data}},
+			OnInput: func(ctx context.Context) error {
+				if n++; n > 2 {
+					data.Reset()
+					return io.EOF
+				}
+				data = append(data[:0], uint64(n*10))
+				return nil
+			},
+		}))
+		require.Equal(t, "INSERT INTO t VALUES FORMAT Native", query)
+		require.Equal(t, []uint64{1, 2, 10, 20}, got)
+	})
+	t.Run("Exception", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-ClickHouse-Exception-Code", "60")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Code: 60. DB::Exception: Table default.t does not exist. (UNKNOWN_TABLE) (version 23.8.1.1)\n"))
+		})
+		err := c.Do(ctx, ch.Query{Body: "SELECT 1 FROM t"})
+		require.True(t, ch.IsErr(err, proto.ErrUnknownTable))
+		e, ok := ch.AsException(err)
+		require.True(t, ok)
+		require.Equal(t, "DB::Exception", e.Name)
+	})
+	t.Run("NoOnResult", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			data := encode(t, proto.Input{{Name: "v", Data: proto.ColUInt8{1}}})
+			_, _ = w.Write(bytes.Repeat(data, 2))
+		})
+		var v proto.ColUInt8
+		require.Error(t, c.Do(ctx, ch.Query{
+			Body:   "SELECT 1 AS v",
+			Result: proto.Results{{Name: "v", Data: &v}},
+		}))
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		c, err := New(Options{})
+		require.NoError(t, err)
+		require.Error(t, c.Do(ctx, ch.Query{
+			Body: "SELECT 1",
+			OnRawBlock: func(ctx context.Context, block ch.RawBlock) error {
+				return nil
+			},
+		}))
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("Ok.\n"))
+	})
+	require.NoError(t, c.Ping(context.Background()))
+
+	_, err := New(Options{Address: "tcp://localhost:9000"})
+	require.Error(t, err)
+}
@@ -0,0 +1,6 @@
+// Package chhttp implements ClickHouse client over HTTP interface.
+//
+// It is intended for environments where only HTTP ports (8123 or 8443)
+// are reachable. Data is transferred in Native format, so same proto
+// columns and ch.Query are used as with native protocol client.
+package chhttp
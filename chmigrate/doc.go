@@ -0,0 +1,16 @@
+// Package chmigrate applies versioned schema migrations to ClickHouse.
+//
+// Migrations are loaded from files named like golang-migrate ones:
+//
+//	0001_create_events.up.sql
+//	0001_create_events.down.sql
+//
+// Each file is SQL script, see ch.ParseScript. Applied versions are
+// recorded in schema_migrations table managed by Migrator. Migration is
+// marked as dirty until all its statements are executed, so failed
+// migration should be fixed manually and resolved by Migrator.Force.
+//
+// If Options.Cluster is set, migrations table is created ON CLUSTER and
+// statements with ON CLUSTER clause are executed with ch.DoOnCluster,
+// waiting until they are finished on all hosts.
+package chmigrate
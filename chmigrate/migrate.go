@@ -0,0 +1,341 @@
+package chmigrate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Options for Migrator.
+type Options struct {
+	// Table of applied migrations, used as is in queries.
+	// Defaults to schema_migrations.
+	Table string
+	// Cluster to create migrations table on, and to wait for distributed
+	// DDL statements of migrations.
+	Cluster string
+	// Engine of migrations table. Defaults to MergeTree, or
+	// ReplicatedMergeTree if Cluster is set.
+	Engine string
+	// DryRun only logs statements instead of executing them, migrations
+	// table is not changed.
+	DryRun bool
+	Logger *zap.Logger // defaults to Nop.
+}
+
+func (o *Options) setDefaults() {
+	if o.Table == "" {
+		o.Table = "schema_migrations"
+	}
+	if o.Engine == "" {
+		o.Engine = "MergeTree"
+		if o.Cluster != "" {
+			o.Engine = "ReplicatedMergeTree"
+		}
+	}
+	if o.Logger == nil {
+		o.Logger = zap.NewNop()
+	}
+}
+
+// DirtyError is returned if migration was not finished, see Migrator.Force.
+type DirtyError struct {
+	Version uint64
+}
+
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("migration %d is dirty", e.Version)
+}
+
+// State of migration.
+type State struct {
+	Migration Migration
+	Applied   bool
+	Dirty     bool
+	Updated   time.Time // zero if never applied
+}
+
+// Migrator applies migrations.
+type Migrator struct {
+	client     *ch.Client
+	migrations []Migration
+	opt        Options
+}
+
+// New returns Migrator of migrations, which are sorted by version.
+func New(c *ch.Client, migrations []Migration, opt Options) (*Migrator, error) {
+	opt.setDefaults()
+	for i, m := range migrations {
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			return nil, errors.Errorf("migration %s: not sorted by version", m)
+		}
+	}
+	return &Migrator{
+		client:     c,
+		migrations: migrations,
+		opt:        opt,
+	}, nil
+}
+
+// onClusterRegexp matches ON CLUSTER clause.
+var onClusterRegexp = regexp.MustCompile(`(?i)\bON\s+CLUSTER\b`)
+
+// exec executes single statement.
+func (m *Migrator) exec(ctx context.Context, body string) error {
+	if m.opt.DryRun {
+		m.opt.Logger.Info("Dry run", zap.String("query", body))
+		return nil
+	}
+	q := ch.Query{Body: body}
+	if m.opt.Cluster != "" && onClusterRegexp.MatchString(body) {
+		return ch.DoOnCluster(ctx, m.client, q)
+	}
+	return m.client.Do(ctx, q)
+}
+
+func (m *Migrator) onCluster() string {
+	if m.opt.Cluster == "" {
+		return ""
+	}
+	return " ON CLUSTER " + string(proto.LitString(m.opt.Cluster))
+}
+
+// Init creates migrations table if not exists.
+func (m *Migrator) Init(ctx context.Context) error {
+	if err := m.exec(ctx, "CREATE TABLE IF NOT EXISTS "+m.opt.Table+m.onCluster()+" ("+
+		"version UInt64, name String, applied Bool, dirty Bool, updated DateTime64(9)"+
+		") ENGINE = "+m.opt.Engine+" ORDER BY version",
+	); err != nil {
+		return errors.Wrap(err, "create table")
+	}
+	return nil
+}
+
+// exists reports whether migrations table exists.
+func (m *Migrator) exists(ctx context.Context) (bool, error) {
+	var (
+		result proto.ColUInt8
+		exists bool
+	)
+	if err := m.client.Do(ctx, ch.Query{
+		Body:   "EXISTS TABLE " + m.opt.Table,
+		Result: proto.Results{{Name: "result", Data: &result}},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			exists = result.Rows() > 0 && result.Row(0) == 1
+			return nil
+		},
+	}); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Status returns states of migrations, sorted by version. Unknown applied
+// migrations are reported too, without Up and Down statements.
+//
+// Migrations table is not created, so all migrations are not applied
+// if it does not exist.
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	exists, err := m.exists(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "exists")
+	}
+	recorded := map[uint64]State{}
+	if exists {
+		var (
+			version proto.ColUInt64
+			name    proto.ColStr
+			applied proto.ColBool
+			dirty   proto.ColBool
+			updated = new(proto.ColDateTime64).WithPrecision(proto.PrecisionNano)
+		)
+		if err := m.client.Do(ctx, ch.Query{
+			Body: "SELECT version, argMax(name, updated), argMax(applied, updated), argMax(dirty, updated), max(updated) " +
+				"FROM " + m.opt.Table + " GROUP BY version",
+			Result: proto.Results{
+				{Name: "version", Data: &version},
+				{Name: "argMax(name, updated)", Data: &name},
+				{Name: "argMax(applied, updated)", Data: &applied},
+				{Name: "argMax(dirty, updated)", Data: &dirty},
+				{Name: "max(updated)", Data: updated},
+			},
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				for i := 0; i < version.Rows(); i++ {
+					recorded[version.Row(i)] = State{
+						Migration: Migration{Version: version.Row(i), Name: name.Row(i)},
+						Applied:   applied.Row(i),
+						Dirty:     dirty.Row(i),
+						Updated:   updated.Row(i),
+					}
+				}
+				return nil
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "query")
+		}
+	}
+	states := make([]State, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := recorded[migration.Version]
+		delete(recorded, migration.Version)
+		s.Migration = migration
+		states = append(states, s)
+	}
+	for _, s := range recorded {
+		if s.Applied || s.Dirty {
+			states = append(states, s)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Migration.Version < states[j].Migration.Version
+	})
+	return states, nil
+}
+
+// record inserts state of migration to migrations table.
+func (m *Migrator) record(ctx context.Context, migration Migration, applied, dirty bool) error {
+	if m.opt.DryRun {
+		return nil
+	}
+	var (
+		version  proto.ColUInt64
+		name     proto.ColStr
+		appliedC proto.ColBool
+		dirtyC   proto.ColBool
+		updated  = new(proto.ColDateTime64).WithPrecision(proto.PrecisionNano)
+	)
+	version.Append(migration.Version)
+	name.Append(migration.Name)
+	appliedC.Append(applied)
+	dirtyC.Append(dirty)
+	updated.Append(time.Now())
+	if err := m.client.Do(ctx, ch.Query{
+		Body: "INSERT INTO " + m.opt.Table + " VALUES",
+		Input: proto.Input{
+			{Name: "version", Data: &version},
+			{Name: "name", Data: &name},
+			{Name: "applied", Data: &appliedC},
+			{Name: "dirty", Data: &dirtyC},
+			{Name: "updated", Data: updated},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "insert")
+	}
+	return nil
+}
+
+// run executes script of migration, marking it dirty until finished.
+func (m *Migrator) run(ctx context.Context, migration Migration, sql string, up bool) error {
+	script, err := ch.ParseScript(sql)
+	if err != nil {
+		return errors.Wrap(err, "parse")
+	}
+	lg := m.opt.Logger.With(
+		zap.Uint64("version", migration.Version),
+		zap.String("name", migration.Name),
+		zap.Bool("up", up),
+	)
+	lg.Info("Migrating")
+	// Applied state of dirty migration is state after migration.
+	if err := m.record(ctx, migration, up, true); err != nil {
+		return errors.Wrap(err, "mark dirty")
+	}
+	for i, stmt := range script.Statements {
+		if err := m.exec(ctx, stmt.Body); err != nil {
+			return &ch.ScriptError{Index: i, Statement: stmt, Err: err}
+		}
+	}
+	if err := m.record(ctx, migration, up, false); err != nil {
+		return errors.Wrap(err, "mark clean")
+	}
+	return nil
+}
+
+// checkDirty returns *DirtyError if some migration is dirty.
+func checkDirty(states []State) error {
+	for _, s := range states {
+		if s.Dirty {
+			return &DirtyError{Version: s.Migration.Version}
+		}
+	}
+	return nil
+}
+
+// Up applies all pending migrations in order of versions, creating
+// migrations table if needed. Returns applied migrations, or migrations
+// that would be applied if DryRun is set.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.Init(ctx); err != nil {
+		return nil, errors.Wrap(err, "init")
+	}
+	states, err := m.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "status")
+	}
+	if err := checkDirty(states); err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	for _, s := range states {
+		if s.Applied || s.Migration.Up == "" {
+			continue
+		}
+		if err := m.run(ctx, s.Migration, s.Migration.Up, true); err != nil {
+			return applied, errors.Wrapf(err, "migration %s", s.Migration)
+		}
+		applied = append(applied, s.Migration)
+	}
+	return applied, nil
+}
+
+// Down reverts n last applied migrations, or all if n is negative.
+// Returns reverted migrations, or migrations that would be reverted if
+// DryRun is set.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	states, err := m.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "status")
+	}
+	if err := checkDirty(states); err != nil {
+		return nil, err
+	}
+	var reverted []Migration
+	for i := len(states) - 1; i >= 0 && n != 0; i-- {
+		s := states[i]
+		if !s.Applied {
+			continue
+		}
+		if s.Migration.Down == "" {
+			return reverted, errors.Errorf("migration %s: no down migration", s.Migration)
+		}
+		if err := m.run(ctx, s.Migration, s.Migration.Down, false); err != nil {
+			return reverted, errors.Wrapf(err, "migration %s", s.Migration)
+		}
+		reverted = append(reverted, s.Migration)
+		n--
+	}
+	return reverted, nil
+}
+
+// Force records migration with version as applied or not applied and
+// clears its dirty state, e.g. after failed migration is fixed manually.
+func (m *Migrator) Force(ctx context.Context, version uint64, applied bool) error {
+	if err := m.Init(ctx); err != nil {
+		return errors.Wrap(err, "init")
+	}
+	migration := Migration{Version: version}
+	for _, v := range m.migrations {
+		if v.Version == version {
+			migration = v
+		}
+	}
+	return m.record(ctx, migration, applied, false)
+}
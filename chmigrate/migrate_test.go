@@ -0,0 +1,114 @@
+package chmigrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/cht"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	server := cht.New(t)
+	client, err := ch.Dial(ctx, ch.Options{
+		Address: server.TCP,
+		Logger:  zaptest.NewLogger(t),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	migrations := []Migration{
+		{
+			Version: 1,
+			Name:    "create_table",
+			Up:      "CREATE TABLE events (id UInt64) ENGINE = MergeTree ORDER BY id",
+			Down:    "DROP TABLE events",
+		},
+		{
+			Version: 2,
+			Name:    "add_column",
+			Up:      "ALTER TABLE events ADD COLUMN name String;\nINSERT INTO events VALUES (1, 'a');",
+			Down:    "ALTER TABLE events DROP COLUMN name",
+		},
+	}
+	newMigrator := func(t *testing.T, migrations []Migration, opt Options) *Migrator {
+		opt.Logger = zaptest.NewLogger(t)
+		m, err := New(client, migrations, opt)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		m := newMigrator(t, migrations, Options{DryRun: true})
+		applied, err := m.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, applied, 2)
+
+		states, err := m.Status(ctx)
+		require.NoError(t, err)
+		for _, s := range states {
+			require.False(t, s.Applied)
+		}
+	})
+
+	m := newMigrator(t, migrations, Options{})
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrations, applied)
+
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	var count proto.ColUInt64
+	require.NoError(t, client.Do(ctx, ch.Query{
+		Body:   "SELECT count() FROM events WHERE name = 'a'",
+		Result: proto.Results{{Name: "count()", Data: &count}},
+	}))
+	require.Equal(t, uint64(1), count.Row(0))
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, migrations[1:], reverted)
+
+	states, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.True(t, states[0].Applied)
+	require.False(t, states[1].Applied)
+	require.False(t, states[1].Dirty)
+
+	t.Run("Dirty", func(t *testing.T) {
+		broken := append(migrations[:2:2], Migration{
+			Version: 3,
+			Name:    "broken",
+			Up:      "SELECT 1;\nSELECT unknown_column FROM events",
+		})
+		m := newMigrator(t, broken, Options{})
+		_, err := m.Up(ctx)
+		var scriptErr *ch.ScriptError
+		require.ErrorAs(t, err, &scriptErr)
+		require.Equal(t, 1, scriptErr.Index)
+
+		_, err = m.Up(ctx)
+		var dirtyErr *DirtyError
+		require.ErrorAs(t, err, &dirtyErr)
+		require.Equal(t, uint64(3), dirtyErr.Version)
+
+		require.NoError(t, m.Force(ctx, 3, false))
+		states, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, states, 3)
+		require.False(t, states[2].Dirty)
+		require.False(t, states[2].Applied)
+	})
+
+	reverted, err = m.Down(ctx, -1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 2)
+}
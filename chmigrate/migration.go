@@ -0,0 +1,73 @@
+package chmigrate
+
+import (
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/go-faster/errors"
+)
+
+// Migration is versioned schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string // optional, migration can't be reverted if blank
+}
+
+func (m Migration) String() string {
+	return strconv.FormatUint(m.Version, 10) + "_" + m.Name
+}
+
+// fileRegexp matches migration file name.
+var fileRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Load reads migrations from files of fsys root directory, sorted by
+// version. Files with other names are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "read dir")
+	}
+	byVersion := map[uint64]*Migration{}
+	for _, e := range entries {
+		match := fileRegexp.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: version", e.Name())
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, errors.Wrap(err, "read")
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, errors.Errorf("%s: version %d has other name %q", e.Name(), version, m.Name)
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, errors.Errorf("%s: no up migration", m)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
@@ -0,0 +1,43 @@
+package chmigrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE t ADD COLUMN s String")},
+		"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (id UInt64) ENGINE = Memory")},
+		"0001_create_table.down.sql": {Data: []byte("DROP TABLE t")},
+		"README.md":                  {Data: []byte("migrations")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Migration{
+		{Version: 1, Name: "create_table", Up: "CREATE TABLE t (id UInt64) ENGINE = Memory", Down: "DROP TABLE t"},
+		{Version: 2, Name: "add_column", Up: "ALTER TABLE t ADD COLUMN s String"},
+	}, migrations)
+	require.Equal(t, "1_create_table", migrations[0].String())
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"NoUp": {
+			"0001_create_table.down.sql": {Data: []byte("DROP TABLE t")},
+		},
+		"OtherName": {
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (id UInt64) ENGINE = Memory")},
+			"0001_drop_table.down.sql": {Data: []byte("DROP TABLE t")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(nil, []Migration{{Version: 2}, {Version: 1}}, Options{})
+	require.Error(t, err)
+}
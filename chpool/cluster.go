@@ -0,0 +1,64 @@
+package chpool
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+)
+
+// Cluster is set of pools of connections to replicas of cluster, e.g. for
+// client-side shard routing.
+type Cluster struct {
+	info  *ch.ClusterInfo
+	pools [][]*Pool // by shard and replica index
+}
+
+// NewCluster returns pools of connections to all replicas of cluster, see
+// ch.Cluster.
+//
+// Options are shared by pools, with client options of replica, see
+// ch.ClusterReplica.Options. Connections are not established before use,
+// so unavailable replicas do not fail construction.
+func NewCluster(ctx context.Context, info *ch.ClusterInfo, opt Options) (*Cluster, error) {
+	c := &Cluster{
+		info:  info,
+		pools: make([][]*Pool, len(info.Shards)),
+	}
+	for i, s := range info.Shards {
+		for _, r := range s.Replicas {
+			replicaOpt := opt
+			replicaOpt.ClientOptions = r.Options(opt.ClientOptions)
+			p, err := New(ctx, replicaOpt)
+			if err != nil {
+				c.Close()
+				return nil, errors.Wrapf(err, "shard %d replica %d", s.Num, r.Num)
+			}
+			c.pools[i] = append(c.pools[i], p)
+		}
+	}
+	return c, nil
+}
+
+// Info returns topology of cluster.
+func (c *Cluster) Info() *ch.ClusterInfo { return c.info }
+
+// Shards returns count of shards.
+func (c *Cluster) Shards() int { return len(c.pools) }
+
+// Shard returns pools of replicas of i-th shard, in order of
+// ch.ClusterShard.Replicas.
+func (c *Cluster) Shard(i int) []*Pool { return c.pools[i] }
+
+// Replica returns pool of replica of shard, by indexes.
+func (c *Cluster) Replica(shard, replica int) *Pool { return c.pools[shard][replica] }
+
+// Close all pools.
+func (c *Cluster) Close() {
+	for _, shard := range c.pools {
+		for _, p := range shard {
+			p.Close()
+		}
+	}
+}
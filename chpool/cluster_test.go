@@ -0,0 +1,35 @@
+package chpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+)
+
+func TestNewCluster(t *testing.T) {
+	info := &ch.ClusterInfo{
+		Name: "test",
+		Shards: []ch.ClusterShard{
+			{Num: 1, Weight: 1, Replicas: []ch.ClusterReplica{
+				{ShardNum: 1, Num: 1, Host: "127.0.0.1", Port: 1},
+				{ShardNum: 1, Num: 2, Host: "127.0.0.2", Port: 1},
+			}},
+			{Num: 2, Weight: 1, Replicas: []ch.ClusterReplica{
+				{ShardNum: 2, Num: 1, Host: "127.0.0.3", Port: 1},
+			}},
+		},
+	}
+	c, err := NewCluster(context.Background(), info, Options{MaxConns: 1})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.Equal(t, info, c.Info())
+	require.Equal(t, 2, c.Shards())
+	require.Len(t, c.Shard(0), 2)
+	require.Len(t, c.Shard(1), 1)
+	require.Equal(t, "127.0.0.2:1", c.Replica(0, 1).options.ClientOptions.Address)
+	require.Equal(t, "127.0.0.3:1", c.Replica(1, 0).options.ClientOptions.Address)
+}
@@ -1,2 +1,10 @@
 // Package chpool is a connection pool for ch.
+//
+// Connections are spread over ch.Options.Endpoints if set, e.g. resolved
+// from DNS SRV record of Kubernetes headless service. Idle connections to
+// servers that are no longer resolved are closed by health check. With
+// Options.MaxReplicaDelay, servers that lag behind are avoided the same way.
+//
+// Router spreads read queries over pools of replicas, e.g. of Cluster
+// shard, avoiding replicas that lag behind.
 package chpool
@@ -6,9 +6,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ClickHouse/ch-go"
-
+	"github.com/go-faster/errors"
 	"github.com/jackc/puddle/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ClickHouse/ch-go"
 )
 
 // Pool of connections to ClickHouse.
@@ -18,6 +20,11 @@ type Pool struct {
 
 	closeOnce sync.Once
 	closeChan chan struct{}
+
+	// statsMux guards stats of live and destroyed connections.
+	statsMux    sync.Mutex
+	conns       map[*ch.Client]struct{}
+	closedStats ch.Stats
 }
 
 // Options for Pool.
@@ -28,8 +35,27 @@ type Options struct {
 	MaxConns          int32
 	MinConns          int32
 	HealthCheckPeriod time.Duration
+
+	// AcquireTimeout limits time spent waiting for a free connection,
+	// independently of caller context deadline.
+	//
+	// If exceeded, ErrAcquireTimeout is returned. No limit if zero.
+	AcquireTimeout time.Duration
+
+	// MaxReplicaDelay is maximum replication delay of server from
+	// ch.Options.Endpoints that receives new connections.
+	//
+	// If set, delay of each endpoint is checked every HealthCheckPeriod,
+	// like Router does. Lagging endpoints are dialed only if others are
+	// unavailable and their idle connections are closed. If all endpoints
+	// lag, the least lagging one is preferred. Not checked if zero.
+	MaxReplicaDelay time.Duration
 }
 
+// ErrAcquireTimeout means that connection was not acquired
+// during Options.AcquireTimeout.
+var ErrAcquireTimeout = errors.New("acquire timeout")
+
 // Defaults for pool.
 const (
 	DefaultMaxConnLifetime   = time.Hour
@@ -68,6 +94,7 @@ func newPool(ctx context.Context, opt Options, dial bool) (*Pool, error) {
 	p := &Pool{
 		options:   opt,
 		closeChan: make(chan struct{}),
+		conns:     map[*ch.Client]struct{}{},
 	}
 	puddleConfig := &puddle.Config[*connResource]{
 		Constructor: func(ctx context.Context) (*connResource, error) {
@@ -75,6 +102,9 @@ func newPool(ctx context.Context, opt Options, dial bool) (*Pool, error) {
 			if err != nil {
 				return nil, err
 			}
+			p.statsMux.Lock()
+			p.conns[c] = struct{}{}
+			p.statsMux.Unlock()
 
 			return &connResource{
 				client:  c,
@@ -83,6 +113,11 @@ func newPool(ctx context.Context, opt Options, dial bool) (*Pool, error) {
 		},
 		Destructor: func(c *connResource) {
 			_ = c.client.Close()
+
+			p.statsMux.Lock()
+			delete(p.conns, c.client)
+			p.closedStats = p.closedStats.Add(c.client.Stats())
+			p.statsMux.Unlock()
 		},
 		MaxSize: opt.MaxConns,
 	}
@@ -113,8 +148,10 @@ func newPool(ctx context.Context, opt Options, dial bool) (*Pool, error) {
 }
 
 // Acquire connection from pool.
+//
+// Returns ErrAcquireTimeout if Options.AcquireTimeout is exceeded.
 func (p *Pool) Acquire(ctx context.Context) (*Client, error) {
-	res, err := p.pool.Acquire(ctx)
+	res, err := p.acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +159,25 @@ func (p *Pool) Acquire(ctx context.Context) (*Client, error) {
 	return res.Value().getConn(p, res), nil
 }
 
+func (p *Pool) acquire(ctx context.Context) (*puddle.Resource[*connResource], error) {
+	if p.options.AcquireTimeout <= 0 {
+		return p.pool.Acquire(ctx)
+	}
+	acquireCtx, cancel := context.WithTimeout(ctx, p.options.AcquireTimeout)
+	defer cancel()
+
+	res, err := p.pool.Acquire(acquireCtx)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(acquireCtx.Err(), context.DeadlineExceeded) {
+			// Parent context is still alive, so deadline is ours.
+			return nil, errors.Wrapf(ErrAcquireTimeout, "after %s", p.options.AcquireTimeout)
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (p *Pool) Do(ctx context.Context, q ch.Query) (err error) {
 	c, err := p.Acquire(ctx)
 	if err != nil {
@@ -151,21 +207,69 @@ func (p *Pool) backgroundHealthCheck() {
 			ticker.Stop()
 			return
 		case <-ticker.C:
+			p.refreshEndpoints()
 			p.checkIdleConnsHealth()
 			p.checkMinConns()
 		}
 	}
 }
 
+// refreshEndpoints resolves addresses of ch.Options.Endpoints, if any, so
+// connections to removed or lagging servers are closed by
+// checkIdleConnsHealth.
+func (p *Pool) refreshEndpoints() {
+	e := p.options.ClientOptions.Endpoints
+	if e == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	addrs, err := e.Addrs(ctx)
+	if err != nil || p.options.MaxReplicaDelay <= 0 {
+		return
+	}
+	e.SetStale(staleAddrs(addrs, p.endpointsStatus(ctx, addrs), p.options.MaxReplicaDelay))
+}
+
+// endpointsStatus checks replication delay of each address concurrently.
+func (p *Pool) endpointsStatus(ctx context.Context, addrs []string) []ReplicaStatus {
+	status := make([]ReplicaStatus, len(addrs))
+	var g errgroup.Group
+	for i, addr := range addrs {
+		i, addr := i, addr
+		g.Go(func() error {
+			opt := p.options.ClientOptions
+			opt.Address, opt.Endpoints = addr, nil
+			status[i] = ReplicaStatus{Addr: addr}
+			c, err := ch.Dial(ctx, opt)
+			if err != nil {
+				status[i].Err = err
+				return nil
+			}
+			defer func() { _ = c.Close() }()
+			status[i].Delay, status[i].Err = replicaDelay(ctx, c)
+			status[i].Checked = time.Now()
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return status
+}
+
 func (p *Pool) checkIdleConnsHealth() {
 	resources := p.pool.AcquireAllIdle()
 
 	now := time.Now()
+	endpoints := p.options.ClientOptions.Endpoints
 	for _, res := range resources {
 		if now.Sub(res.CreationTime()) > p.options.MaxConnLifetime {
 			res.Destroy()
 		} else if res.IdleDuration() > p.options.MaxConnIdleTime {
 			res.Destroy()
+		} else if endpoints != nil && !endpoints.Has(res.Value().client.Addr()) {
+			res.Destroy()
+		} else if endpoints != nil && endpoints.IsStale(res.Value().client.Addr()) {
+			res.Destroy()
 		} else {
 			res.ReleaseUnused()
 		}
@@ -198,6 +302,19 @@ func (p *Pool) Stat() *puddle.Stat {
 	return p.pool.Stat()
 }
 
+// ClientStats returns cumulative statistics of all connections,
+// including already closed ones.
+func (p *Pool) ClientStats() ch.Stats {
+	p.statsMux.Lock()
+	defer p.statsMux.Unlock()
+
+	s := p.closedStats
+	for c := range p.conns {
+		s = s.Add(c.Stats())
+	}
+	return s
+}
+
 // Close pool.
 func (p *Pool) Close() {
 	p.closeOnce.Do(func() {
@@ -2,11 +2,15 @@ package chpool
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/cht"
 )
 
 func TestDial(t *testing.T) {
@@ -78,3 +82,86 @@ func TestPool_Acquire(t *testing.T) {
 	waitForReleaseToComplete()
 	require.EqualValues(t, 2, p.Stat().AcquireCount())
 }
+
+func TestPool_AcquireTimeout(t *testing.T) {
+	t.Parallel()
+	p := PoolConnOpt(t, Options{
+		MaxConns:       1,
+		AcquireTimeout: time.Millisecond * 100,
+	})
+
+	conn, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	defer conn.Release()
+
+	_, err = p.Acquire(context.Background())
+	require.ErrorIs(t, err, ErrAcquireTimeout)
+
+	// Caller context deadline is reported as is.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err = p.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, ErrAcquireTimeout)
+}
+
+func TestPool_Endpoints(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := cht.New(t)
+
+	var (
+		mux   sync.Mutex
+		addrs = []string{"127.0.0.1:1", server.TCP}
+	)
+	e := ch.NewEndpoints(func(ctx context.Context) ([]string, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		return addrs, nil
+	}, ch.EndpointsOptions{})
+	p, err := Dial(ctx, Options{ClientOptions: ch.Options{Endpoints: e}})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	c, err := p.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, server.TCP, c.client().Addr())
+	c.Release()
+	waitForReleaseToComplete()
+
+	// Server is removed, so idle connection is closed.
+	mux.Lock()
+	addrs = []string{"127.0.0.1:1"}
+	mux.Unlock()
+	e.Invalidate()
+	p.refreshEndpoints()
+	p.checkIdleConnsHealth()
+	assert.EqualValues(t, 0, p.Stat().TotalResources())
+}
+
+func TestPool_MaxReplicaDelay(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := cht.New(t)
+
+	e := ch.NewEndpoints(func(ctx context.Context) ([]string, error) {
+		return []string{"127.0.0.1:1", server.TCP}, nil
+	}, ch.EndpointsOptions{})
+	p, err := Dial(ctx, Options{
+		ClientOptions:   ch.Options{Endpoints: e},
+		MaxReplicaDelay: time.Minute,
+	})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	status := p.endpointsStatus(ctx, []string{"127.0.0.1:1", server.TCP})
+	require.Error(t, status[0].Err)
+	require.NoError(t, status[1].Err)
+	require.Equal(t, server.TCP, status[1].Addr)
+
+	// Server is not replicated, so not lagging.
+	e.SetStale([]string{server.TCP})
+	p.refreshEndpoints()
+	require.False(t, e.IsStale(server.TCP))
+	require.NoError(t, p.Ping(ctx))
+}
@@ -0,0 +1,287 @@
+package chpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/otelch"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Route decisions, reported as otelch.RouteDecision of otelch.RouteMetric.
+const (
+	// RouteFresh means that replica with delay within MaxDelay was chosen.
+	RouteFresh = "fresh"
+	// RouteStale means that all available replicas lag beyond MaxDelay,
+	// so the least lagging one was chosen.
+	RouteStale = "stale"
+	// RouteUnavailable means that delay of no replica is known, so
+	// replica was chosen regardless of delay.
+	RouteUnavailable = "unavailable"
+)
+
+// RouterOptions for Router.
+type RouterOptions struct {
+	// MaxDelay is maximum replication delay of replica that receives read
+	// queries, defaults to 5m, like max_replica_delay_for_distributed_queries.
+	MaxDelay time.Duration
+	// CheckPeriod of replication delay, defaults to 10s. Also timeout of
+	// single check.
+	CheckPeriod time.Duration
+	// MeterProvider is used to record routing decisions and delays of
+	// replicas. Defaults to global MeterProvider.
+	MeterProvider metric.MeterProvider
+}
+
+// Defaults for Router.
+const (
+	DefaultRouterMaxDelay    = time.Minute * 5
+	DefaultRouterCheckPeriod = time.Second * 10
+)
+
+func (o *RouterOptions) setDefaults() {
+	if o.MaxDelay == 0 {
+		o.MaxDelay = DefaultRouterMaxDelay
+	}
+	if o.CheckPeriod == 0 {
+		o.CheckPeriod = DefaultRouterCheckPeriod
+	}
+	if o.MeterProvider == nil {
+		o.MeterProvider = otel.GetMeterProvider()
+	}
+}
+
+// ReplicaStatus is result of last replication delay check of replica.
+type ReplicaStatus struct {
+	Addr    string        // address of checked server, if dialed
+	Delay   time.Duration // max absolute_delay of replicated tables
+	Err     error         // replica is unavailable if not nil
+	Checked time.Time
+}
+
+// Router routes read queries to pools of replicas that are not lagging
+// behind, e.g. replicas of cluster shard, see Cluster.Shard.
+//
+// Delay of each replica is checked periodically as maximum
+// absolute_delay of system.replicas, i.e. age of oldest entry of
+// replication queue that is not processed yet.
+//
+// Write queries should not be routed, as any replica accepts them.
+type Router struct {
+	pools []*Pool
+	opt   RouterOptions
+
+	mux    sync.RWMutex
+	status []ReplicaStatus
+
+	// Round-robin counter over fresh replicas.
+	next atomic.Uint64
+
+	routes metric.Int64Counter
+	delay  metric.Float64Histogram
+
+	closeOnce sync.Once
+	closeChan chan struct{}
+}
+
+// NewRouter returns router over pools and checks their delay.
+//
+// Pools are not closed by Router.Close. Unavailable pools do not fail
+// construction.
+func NewRouter(ctx context.Context, pools []*Pool, opt RouterOptions) (*Router, error) {
+	if len(pools) == 0 {
+		return nil, errors.New("no pools")
+	}
+	opt.setDefaults()
+	r := &Router{
+		pools:     pools,
+		opt:       opt,
+		status:    make([]ReplicaStatus, len(pools)),
+		closeChan: make(chan struct{}),
+	}
+	m := opt.MeterProvider.Meter(otelch.Name)
+	var err error
+	if r.routes, err = m.Int64Counter(otelch.RouteMetric,
+		metric.WithDescription("Count of routed queries by decision"),
+	); err != nil {
+		return nil, errors.Wrap(err, "routes")
+	}
+	if r.delay, err = m.Float64Histogram(otelch.ReplicaDelayMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Replication delay of replica"),
+	); err != nil {
+		return nil, errors.Wrap(err, "delay")
+	}
+
+	r.check(ctx)
+	go r.backgroundCheck()
+
+	return r, nil
+}
+
+func (r *Router) backgroundCheck() {
+	ticker := time.NewTicker(r.opt.CheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.closeChan:
+			return
+		case <-ticker.C:
+			r.check(context.Background())
+		}
+	}
+}
+
+// check updates status of all replicas concurrently.
+func (r *Router) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.opt.CheckPeriod)
+	defer cancel()
+
+	status := make([]ReplicaStatus, len(r.pools))
+	var g errgroup.Group
+	for i, p := range r.pools {
+		i, p := i, p
+		g.Go(func() error {
+			status[i] = poolStatus(ctx, p)
+			if status[i].Err == nil {
+				r.delay.Record(ctx, status[i].Delay.Seconds(),
+					metric.WithAttributes(otelch.ServerAddress(status[i].Addr)),
+				)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	r.mux.Lock()
+	r.status = status
+	r.mux.Unlock()
+}
+
+// poolStatus checks replication delay of replica on connection of p, so
+// address that is actually dialed is known, e.g. from ch.Options.Endpoints.
+func poolStatus(ctx context.Context, p *Pool) ReplicaStatus {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return ReplicaStatus{Err: errors.Wrap(err, "acquire"), Checked: time.Now()}
+	}
+	defer c.Release()
+
+	delay, err := replicaDelay(ctx, c.client())
+	return ReplicaStatus{
+		Addr:    c.client().Addr(),
+		Delay:   delay,
+		Err:     err,
+		Checked: time.Now(),
+	}
+}
+
+// replicaDelay returns max replication delay of tables of replica.
+func replicaDelay(ctx context.Context, c *ch.Client) (time.Duration, error) {
+	var delay proto.ColUInt64
+	if err := c.Do(ctx, ch.Query{
+		Body:   "SELECT max(absolute_delay) AS delay FROM system.replicas",
+		Result: proto.Results{{Name: "delay", Data: &delay}},
+	}); err != nil {
+		return 0, errors.Wrap(err, "query")
+	}
+	if delay.Rows() == 0 {
+		return 0, nil
+	}
+	return time.Duration(delay.Row(0)) * time.Second, nil
+}
+
+// Status returns status of replicas, in order of pools.
+func (r *Router) Status() []ReplicaStatus {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return append([]ReplicaStatus(nil), r.status...)
+}
+
+// Pool returns pool of replica for read query.
+//
+// Fresh replicas are chosen in round-robin. If all available replicas lag
+// beyond MaxDelay, the least lagging one is chosen, like
+// fallback_to_stale_replicas_for_distributed_queries does.
+func (r *Router) Pool(ctx context.Context) *Pool {
+	r.mux.RLock()
+	i, decision := route(r.status, r.opt.MaxDelay, r.next.Inc()-1)
+	addr := r.status[i].Addr
+	r.mux.RUnlock()
+
+	p := r.pools[i]
+	if addr == "" {
+		// Not dialed yet.
+		addr = p.options.ClientOptions.Address
+	}
+	r.routes.Add(ctx, 1, metric.WithAttributes(
+		otelch.RouteDecision(decision),
+		otelch.ServerAddress(addr),
+	))
+	return p
+}
+
+// route returns index of replica to route query to and route decision.
+func route(status []ReplicaStatus, maxDelay time.Duration, n uint64) (int, string) {
+	var fresh []int
+	least := -1
+	for i, s := range status {
+		if s.Err != nil {
+			continue
+		}
+		if s.Delay <= maxDelay {
+			fresh = append(fresh, i)
+		}
+		if least < 0 || s.Delay < status[least].Delay {
+			least = i
+		}
+	}
+	switch {
+	case len(fresh) > 0:
+		return fresh[n%uint64(len(fresh))], RouteFresh
+	case least >= 0:
+		return least, RouteStale
+	default:
+		return int(n % uint64(len(status))), RouteUnavailable
+	}
+}
+
+// staleAddrs returns addresses of replicas that lag beyond maxDelay, in
+// order of addrs. If all available replicas lag, the least lagging one is
+// not stale, as it would be chosen by route. Unavailable replicas are not
+// stale, as they are skipped by dial anyway.
+func staleAddrs(addrs []string, status []ReplicaStatus, maxDelay time.Duration) []string {
+	least := -1
+	if i, decision := route(status, maxDelay, 0); decision == RouteStale {
+		least = i
+	}
+	var stale []string
+	for i, s := range status {
+		if s.Err != nil || s.Delay <= maxDelay || i == least {
+			continue
+		}
+		stale = append(stale, addrs[i])
+	}
+	return stale
+}
+
+// Do executes read query on replica, see Pool.
+func (r *Router) Do(ctx context.Context, q ch.Query) error {
+	return r.Pool(ctx).Do(ctx, q)
+}
+
+// Close stops checks of replicas.
+func (r *Router) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closeChan)
+	})
+}
@@ -0,0 +1,162 @@
+package chpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/cht"
+	"github.com/ClickHouse/ch-go/otelch"
+)
+
+func TestRoute(t *testing.T) {
+	const maxDelay = time.Minute
+	errUnavailable := errors.New("unavailable")
+	for _, tt := range []struct {
+		Name     string
+		Status   []ReplicaStatus
+		N        uint64
+		Index    int
+		Decision string
+	}{
+		{
+			Name:     "Fresh",
+			Status:   []ReplicaStatus{{Delay: time.Hour}, {Delay: time.Second}, {Err: errUnavailable}, {}},
+			N:        1,
+			Index:    3,
+			Decision: RouteFresh,
+		},
+		{
+			Name:     "Stale",
+			Status:   []ReplicaStatus{{Delay: time.Hour}, {Delay: time.Minute * 2}, {Err: errUnavailable}},
+			Index:    1,
+			Decision: RouteStale,
+		},
+		{
+			Name:     "Unavailable",
+			Status:   []ReplicaStatus{{Err: errUnavailable}, {Err: errUnavailable}},
+			N:        3,
+			Index:    1,
+			Decision: RouteUnavailable,
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			i, decision := route(tt.Status, maxDelay, tt.N)
+			require.Equal(t, tt.Index, i)
+			require.Equal(t, tt.Decision, decision)
+		})
+	}
+}
+
+func TestStaleAddrs(t *testing.T) {
+	const maxDelay = time.Minute
+	errUnavailable := errors.New("unavailable")
+	addrs := []string{"a", "b", "c"}
+	for _, tt := range []struct {
+		Name   string
+		Status []ReplicaStatus
+		Stale  []string
+	}{
+		{
+			Name:   "Fresh",
+			Status: []ReplicaStatus{{Delay: time.Hour}, {Delay: time.Second}, {Err: errUnavailable}},
+			Stale:  []string{"a"},
+		},
+		{
+			Name:   "Stale",
+			Status: []ReplicaStatus{{Delay: time.Hour}, {Delay: time.Minute * 2}, {Err: errUnavailable}},
+			Stale:  []string{"a"},
+		},
+		{
+			Name:   "Unavailable",
+			Status: []ReplicaStatus{{Err: errUnavailable}, {Err: errUnavailable}, {Err: errUnavailable}},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Stale, staleAddrs(addrs, tt.Status, maxDelay))
+		})
+	}
+}
+
+func routeDecisions(t testing.TB, reader sdkmetric.Reader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	out := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != otelch.RouteMetric {
+				continue
+			}
+			for _, p := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				v, _ := p.Attributes.Value(attribute.Key(otelch.RouteDecisionKey))
+				out[v.AsString()] += p.Value
+			}
+		}
+	}
+	return out
+}
+
+func TestRouter_unavailable(t *testing.T) {
+	ctx := context.Background()
+	p, err := New(ctx, Options{ClientOptions: ch.Options{Address: "127.0.0.1:1"}})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	reader := sdkmetric.NewManualReader()
+	r, err := NewRouter(ctx, []*Pool{p}, RouterOptions{
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	t.Cleanup(r.Close)
+
+	status := r.Status()
+	require.Len(t, status, 1)
+	require.Error(t, status[0].Err)
+	require.False(t, status[0].Checked.IsZero())
+
+	require.Equal(t, p, r.Pool(ctx))
+	require.Error(t, r.Do(ctx, ch.Query{Body: "SELECT 1"}))
+	require.Equal(t, map[string]int64{RouteUnavailable: 2}, routeDecisions(t, reader))
+
+	_, err = NewRouter(ctx, nil, RouterOptions{})
+	require.Error(t, err)
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := cht.New(t)
+
+	unavailable, err := New(ctx, Options{ClientOptions: ch.Options{Address: "127.0.0.1:1"}})
+	require.NoError(t, err)
+	t.Cleanup(unavailable.Close)
+	p, err := New(ctx, Options{ClientOptions: ch.Options{Address: server.TCP}})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	reader := sdkmetric.NewManualReader()
+	r, err := NewRouter(ctx, []*Pool{unavailable, p}, RouterOptions{
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	t.Cleanup(r.Close)
+
+	status := r.Status()
+	require.Error(t, status[0].Err)
+	require.NoError(t, status[1].Err)
+	require.Zero(t, status[1].Delay)
+	require.Equal(t, server.TCP, status[1].Addr)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, r.Do(ctx, ch.Query{Body: "SELECT 1"}))
+	}
+	require.Equal(t, map[string]int64{RouteFresh: 3}, routeDecisions(t, reader))
+}
@@ -0,0 +1,191 @@
+package chprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/chpool"
+)
+
+// Options for collectors. Zero value is valid.
+type Options struct {
+	Namespace   string            // "ch" by default
+	ConstLabels prometheus.Labels // none by default
+}
+
+// DefaultNamespace of metrics.
+const DefaultNamespace = "ch"
+
+func (o *Options) setDefaults() {
+	if o.Namespace == "" {
+		o.Namespace = DefaultNamespace
+	}
+}
+
+func (o Options) desc(subsystem, name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName(o.Namespace, subsystem, name),
+		help, nil, o.ConstLabels,
+	)
+}
+
+// statsDesc describes metrics derived from ch.Stats.
+type statsDesc struct {
+	queries               *prometheus.Desc
+	queryErrors           *prometheus.Desc
+	blocksSent            *prometheus.Desc
+	blocksReceived        *prometheus.Desc
+	rowsSent              *prometheus.Desc
+	rowsReceived          *prometheus.Desc
+	bytesSent             *prometheus.Desc
+	bytesReceived         *prometheus.Desc
+	uncompressedBytesSent *prometheus.Desc
+	compressedBytesSent   *prometheus.Desc
+	compressionRatio      *prometheus.Desc
+}
+
+func newStatsDesc(o Options) statsDesc {
+	const subsystem = "client"
+	return statsDesc{
+		queries:               o.desc(subsystem, "queries_total", "Total queries executed."),
+		queryErrors:           o.desc(subsystem, "query_errors_total", "Total queries failed."),
+		blocksSent:            o.desc(subsystem, "blocks_sent_total", "Total data blocks sent."),
+		blocksReceived:        o.desc(subsystem, "blocks_received_total", "Total data blocks received."),
+		rowsSent:              o.desc(subsystem, "rows_sent_total", "Total rows sent."),
+		rowsReceived:          o.desc(subsystem, "rows_received_total", "Total rows received."),
+		bytesSent:             o.desc(subsystem, "sent_bytes_total", "Total bytes written to connection."),
+		bytesReceived:         o.desc(subsystem, "received_bytes_total", "Total bytes read from connection."),
+		uncompressedBytesSent: o.desc(subsystem, "sent_uncompressed_bytes_total", "Total size of sent blocks before compression."),
+		compressedBytesSent:   o.desc(subsystem, "sent_compressed_bytes_total", "Total size of sent blocks after compression."),
+		compressionRatio:      o.desc(subsystem, "compression_ratio", "Ratio of uncompressed to compressed size of sent blocks."),
+	}
+}
+
+func (d statsDesc) Describe(out chan<- *prometheus.Desc) {
+	out <- d.queries
+	out <- d.queryErrors
+	out <- d.blocksSent
+	out <- d.blocksReceived
+	out <- d.rowsSent
+	out <- d.rowsReceived
+	out <- d.bytesSent
+	out <- d.bytesReceived
+	out <- d.uncompressedBytesSent
+	out <- d.compressedBytesSent
+	out <- d.compressionRatio
+}
+
+func (d statsDesc) Collect(out chan<- prometheus.Metric, s ch.Stats) {
+	counter := func(desc *prometheus.Desc, v uint64) {
+		out <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v))
+	}
+	counter(d.queries, s.Queries)
+	counter(d.queryErrors, s.QueryErrors)
+	counter(d.blocksSent, s.BlocksSent)
+	counter(d.blocksReceived, s.BlocksReceived)
+	counter(d.rowsSent, s.RowsSent)
+	counter(d.rowsReceived, s.RowsReceived)
+	counter(d.bytesSent, s.BytesSent)
+	counter(d.bytesReceived, s.BytesReceived)
+	counter(d.uncompressedBytesSent, s.UncompressedBytesSent)
+	counter(d.compressedBytesSent, s.CompressedBytesSent)
+	out <- prometheus.MustNewConstMetric(d.compressionRatio, prometheus.GaugeValue, s.CompressionRatio())
+}
+
+// ClientCollector collects statistics of single *ch.Client.
+type ClientCollector struct {
+	client *ch.Client
+	desc   statsDesc
+}
+
+var _ prometheus.Collector = (*ClientCollector)(nil)
+
+// NewClientCollector returns new collector for client.
+func NewClientCollector(client *ch.Client, opt Options) *ClientCollector {
+	opt.setDefaults()
+	return &ClientCollector{
+		client: client,
+		desc:   newStatsDesc(opt),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ClientCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.desc.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *ClientCollector) Collect(ch chan<- prometheus.Metric) {
+	c.desc.Collect(ch, c.client.Stats())
+}
+
+// PoolCollector collects statistics of *chpool.Pool and its connections.
+type PoolCollector struct {
+	pool  *chpool.Pool
+	stats statsDesc
+
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*PoolCollector)(nil)
+
+// NewPoolCollector returns new collector for pool.
+func NewPoolCollector(pool *chpool.Pool, opt Options) *PoolCollector {
+	opt.setDefaults()
+	const subsystem = "pool"
+	return &PoolCollector{
+		pool:  pool,
+		stats: newStatsDesc(opt),
+
+		acquireCount:         opt.desc(subsystem, "acquire_total", "Total successful acquires."),
+		acquireDuration:      opt.desc(subsystem, "acquire_duration_seconds_total", "Total duration of successful acquires."),
+		emptyAcquireCount:    opt.desc(subsystem, "empty_acquire_total", "Total acquires that waited for a connection."),
+		canceledAcquireCount: opt.desc(subsystem, "canceled_acquire_total", "Total acquires canceled by context."),
+		acquiredConns:        opt.desc(subsystem, "acquired_conns", "Count of currently acquired connections."),
+		idleConns:            opt.desc(subsystem, "idle_conns", "Count of currently idle connections."),
+		constructingConns:    opt.desc(subsystem, "constructing_conns", "Count of connections being established."),
+		totalConns:           opt.desc(subsystem, "total_conns", "Total count of connections."),
+		maxConns:             opt.desc(subsystem, "max_conns", "Maximum size of pool."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.stats.Describe(ch)
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.stats.Collect(ch, c.pool.ClientStats())
+
+	s := c.pool.Stat()
+	metric := func(desc *prometheus.Desc, t prometheus.ValueType, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, t, v)
+	}
+	metric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	metric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	metric(c.emptyAcquireCount, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	metric(c.canceledAcquireCount, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	metric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredResources()))
+	metric(c.idleConns, prometheus.GaugeValue, float64(s.IdleResources()))
+	metric(c.constructingConns, prometheus.GaugeValue, float64(s.ConstructingResources()))
+	metric(c.totalConns, prometheus.GaugeValue, float64(s.TotalResources()))
+	metric(c.maxConns, prometheus.GaugeValue, float64(s.MaxResources()))
+}
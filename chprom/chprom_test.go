@@ -0,0 +1,76 @@
+package chprom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/chpool"
+	"github.com/ClickHouse/ch-go/cht"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func gather(t *testing.T, c prometheus.Collector) map[string]*dto.Metric {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	out := map[string]*dto.Metric{}
+	for _, f := range families {
+		require.Len(t, f.Metric, 1)
+		out[f.GetName()] = f.Metric[0]
+	}
+	return out
+}
+
+func selectOne(t *testing.T, do func(ctx context.Context, q ch.Query) error) {
+	t.Helper()
+
+	var data proto.ColUInt8
+	require.NoError(t, do(context.Background(), ch.Query{
+		Body:   "SELECT 1 as v",
+		Result: proto.Results{{Name: "v", Data: &data}},
+	}))
+}
+
+func TestClientCollector(t *testing.T) {
+	ctx := context.Background()
+	server := cht.New(t)
+	client, err := ch.Dial(ctx, ch.Options{Address: server.TCP})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	selectOne(t, client.Do)
+
+	metrics := gather(t, NewClientCollector(client, Options{
+		ConstLabels: prometheus.Labels{"name": "test"},
+	}))
+	require.Equal(t, 1.0, metrics["ch_client_queries_total"].GetCounter().GetValue())
+	require.Equal(t, 0.0, metrics["ch_client_query_errors_total"].GetCounter().GetValue())
+	require.Positive(t, metrics["ch_client_received_bytes_total"].GetCounter().GetValue())
+}
+
+func TestPoolCollector(t *testing.T) {
+	ctx := context.Background()
+	server := cht.New(t)
+	pool, err := chpool.Dial(ctx, chpool.Options{
+		ClientOptions: ch.Options{Address: server.TCP},
+		MaxConns:      2,
+	})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	selectOne(t, pool.Do)
+
+	metrics := gather(t, NewPoolCollector(pool, Options{Namespace: "test"}))
+	require.Equal(t, 1.0, metrics["test_client_queries_total"].GetCounter().GetValue())
+	require.Equal(t, 2.0, metrics["test_pool_max_conns"].GetGauge().GetValue())
+	require.Equal(t, 0.0, metrics["test_pool_acquired_conns"].GetGauge().GetValue())
+}
@@ -0,0 +1,2 @@
+// Package chprom implements Prometheus collectors for ch clients and pools.
+package chprom
@@ -0,0 +1,246 @@
+package chschema
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Binding returns Go source of typed binding of table with schema in
+// package pkg: row struct with name, as in GoStruct, and columns type that
+// appends rows to proto.Input and reads them from proto.Results.
+//
+// Only insertable columns are bound, see ch.TableColumn.Insertable.
+// Nested Nullable and types without Go equivalent are not supported.
+func Binding(pkg, name, table string, schema ch.TableSchema) ([]byte, error) {
+	var columns ch.TableSchema
+	for _, c := range schema {
+		if c.Insertable() {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no insertable columns")
+	}
+
+	g := newGoGen()
+	type field struct {
+		name   string
+		column ch.TableColumn
+		col    colType
+	}
+	fields := make([]field, 0, len(columns))
+	for _, c := range columns {
+		col, err := g.colType(c.Type, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", c.Name)
+		}
+		fields = append(fields, field{name: goName(c.Name), column: c, col: col})
+	}
+	g.imports["github.com/ClickHouse/ch-go/proto"] = struct{}{}
+
+	b := &g.body
+	fmt.Fprintf(b, "// %s is row of %q table.\n", name, table)
+	g.writeStruct(name, columns)
+
+	cols := name + "Columns"
+	fmt.Fprintf(b, "\n// %s is columns of %q table.\n", cols, table)
+	fmt.Fprintf(b, "type %s struct {\n", cols)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t%s %s\n", f.name, f.col.typ)
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// New%s returns empty columns of %q table.\n", cols, table)
+	fmt.Fprintf(b, "func New%s() *%s {\n\treturn &%s{\n", cols, cols, cols)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t\t%s: %s,\n", f.name, f.col.ctor)
+	}
+	b.WriteString("\t}\n}\n")
+
+	fmt.Fprintf(b, "\n// Append row to columns.\nfunc (c *%s) Append(row %s) {\n", cols, name)
+	for _, f := range fields {
+		switch f.col.kind {
+		case colNullable:
+			fmt.Fprintf(b, "\tif row.%[1]s != nil {\n\t\tc.%[1]s.Append(proto.NewNullable(*row.%[1]s))\n", f.name)
+			fmt.Fprintf(b, "\t} else {\n\t\tc.%s.Append(proto.Null[%s]())\n\t}\n", f.name, f.col.elem)
+		case colFixedStr:
+			fmt.Fprintf(b, "\tc.%[1]s.Append(row.%[1]s[:])\n", f.name)
+		default:
+			fmt.Fprintf(b, "\tc.%[1]s.Append(row.%[1]s)\n", f.name)
+		}
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// Row returns i-th row of columns.\nfunc (c *%s) Row(i int) %s {\n", cols, name)
+	fmt.Fprintf(b, "\tvar row %s\n", name)
+	for _, f := range fields {
+		switch f.col.kind {
+		case colNullable:
+			fmt.Fprintf(b, "\tif v := c.%s.Row(i); v.Set {\n\t\trow.%s = &v.Value\n\t}\n", f.name, f.name)
+		case colFixedStr:
+			fmt.Fprintf(b, "\tcopy(row.%[1]s[:], c.%[1]s.Row(i))\n", f.name)
+		default:
+			fmt.Fprintf(b, "\trow.%[1]s = c.%[1]s.Row(i)\n", f.name)
+		}
+	}
+	b.WriteString("\treturn row\n}\n")
+
+	fmt.Fprintf(b, "\n// Rows returns count of rows in columns.\nfunc (c *%s) Rows() int {\n", cols)
+	fmt.Fprintf(b, "\treturn c.%s.Rows()\n}\n", fields[0].name)
+
+	fmt.Fprintf(b, "\n// Reset columns to zero rows, keeping capacity.\nfunc (c *%s) Reset() {\n", cols)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tc.%s.Reset()\n", f.name)
+	}
+	b.WriteString("}\n")
+
+	for _, kind := range []struct {
+		method, typ, doc string
+	}{
+		{"Input", "proto.Input", "Input returns columns to insert, see proto.Input.Into."},
+		{"Result", "proto.Results", "Result returns columns to select into."},
+	} {
+		fmt.Fprintf(b, "\n// %s\nfunc (c *%s) %s() %s {\n", kind.doc, cols, kind.method, kind.typ)
+		fmt.Fprintf(b, "\treturn %s{\n", kind.typ)
+		for _, f := range fields {
+			fmt.Fprintf(b, "\t\t{Name: %q, Data: c.%s},\n", f.column.Name, f.name)
+		}
+		b.WriteString("\t}\n}\n")
+	}
+
+	return g.source(pkg)
+}
+
+// Kinds of colType that need conversion of Go values.
+const (
+	colPlain = iota
+	colNullable
+	colFixedStr
+)
+
+// colType describes column of binding.
+type colType struct {
+	typ  string // Go type of column, like *proto.ColStr
+	ctor string // expression that creates column
+	elem string // Go type of values, like string
+	kind int
+}
+
+// simpleColumns maps column types to proto columns of Go values.
+var simpleColumns = map[proto.ColumnType]string{
+	proto.ColumnTypeBool:     "ColBool",
+	proto.ColumnTypeInt8:     "ColInt8",
+	proto.ColumnTypeInt16:    "ColInt16",
+	proto.ColumnTypeInt32:    "ColInt32",
+	proto.ColumnTypeInt64:    "ColInt64",
+	proto.ColumnTypeUInt8:    "ColUInt8",
+	proto.ColumnTypeUInt16:   "ColUInt16",
+	proto.ColumnTypeUInt32:   "ColUInt32",
+	proto.ColumnTypeUInt64:   "ColUInt64",
+	proto.ColumnTypeFloat32:  "ColFloat32",
+	proto.ColumnTypeFloat64:  "ColFloat64",
+	proto.ColumnTypeString:   "ColStr",
+	proto.ColumnTypeEnum8:    "ColEnum",
+	proto.ColumnTypeEnum16:   "ColEnum",
+	proto.ColumnTypeDate:     "ColDate",
+	proto.ColumnTypeDate32:   "ColDate32",
+	proto.ColumnTypeDateTime: "ColDateTime",
+	proto.ColumnTypeUUID:     "ColUUID",
+}
+
+// colType returns binding column for t, top is set for columns of table,
+// as opposed to nested columns.
+func (g *goGen) colType(t proto.ColumnType, top bool) (colType, error) {
+	elem := g.goType(t)
+	if elem == "any" {
+		return colType{}, errors.Errorf("unsupported type %s", t)
+	}
+	if name, ok := simpleColumns[t.Base()]; ok {
+		return colType{
+			typ:  "*proto." + name,
+			ctor: "new(proto." + name + ")",
+			elem: elem,
+		}, nil
+	}
+	nested := func(typ, ctor string, args ...proto.ColumnType) (colType, error) {
+		var (
+			params string
+			inner  string
+		)
+		for i, arg := range args {
+			c, err := g.colType(arg, false)
+			if err != nil {
+				return colType{}, err
+			}
+			if i > 0 {
+				params += ", "
+				inner += ", "
+			}
+			params += c.elem
+			inner += c.ctor
+		}
+		return colType{
+			typ:  "*proto." + typ + "[" + params + "]",
+			ctor: "proto." + ctor + "[" + params + "](" + inner + ")",
+			elem: elem,
+		}, nil
+	}
+	switch t.Base() {
+	case proto.ColumnTypeDateTime64:
+		elems := t.Elems()
+		if len(elems) == 0 {
+			return colType{}, errors.New("DateTime64 without precision")
+		}
+		p, err := strconv.Atoi(string(elems[0]))
+		if err != nil {
+			return colType{}, errors.Wrap(err, "DateTime64 precision")
+		}
+		return colType{
+			typ:  "*proto.ColDateTime64",
+			ctor: fmt.Sprintf("new(proto.ColDateTime64).WithPrecision(%d)", p),
+			elem: elem,
+		}, nil
+	case proto.ColumnTypeFixedString:
+		n, err := strconv.Atoi(string(t.Elem()))
+		if err != nil {
+			return colType{}, errors.Wrap(err, "FixedString size")
+		}
+		switch n {
+		case 8, 16, 32, 64, 128, 256, 512:
+			name := "ColFixedStr" + strconv.Itoa(n)
+			return colType{typ: "*proto." + name, ctor: "new(proto." + name + ")", elem: elem}, nil
+		}
+		if !top {
+			return colType{}, errors.Errorf("unsupported nested %s", t)
+		}
+		return colType{
+			typ:  "*proto.ColFixedStr",
+			ctor: fmt.Sprintf("proto.NewFixedStr(%d)", n),
+			elem: elem,
+			kind: colFixedStr,
+		}, nil
+	case proto.ColumnTypeLowCardinality:
+		return nested("ColLowCardinality", "NewLowCardinality", t.Elem())
+	case proto.ColumnTypeArray:
+		return nested("ColArr", "NewArray", t.Elem())
+	case proto.ColumnTypeMap:
+		return nested("ColMap", "NewMap", t.Elems()...)
+	case proto.ColumnTypeNullable:
+		if !top {
+			return colType{}, errors.Errorf("unsupported nested %s", t)
+		}
+		c, err := nested("ColNullable", "NewColNullable", t.Elem())
+		if err != nil {
+			return colType{}, err
+		}
+		c.kind = colNullable
+		c.elem = elem[1:] // *T
+		return c, nil
+	}
+	return colType{}, errors.Errorf("unsupported type %s", t)
+}
@@ -0,0 +1,237 @@
+package chschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+type event struct {
+	ID        uint64            `ch:"id"`
+	Name      string            `ch:"name" chtype:"LowCardinality(String)"`
+	Payload   []byte            `chcodec:"ZSTD(1)" chcomment:"raw event"`
+	Time      time.Time         `ch:"time" chdefault:"now()"`
+	Tags      map[string]string `ch:"tags"`
+	UserID    *uuid.UUID
+	Hash      [16]byte
+	Scores    []float64
+	Skipped   string `ch:"-"`
+	unexposed int
+}
+
+func TestFromStruct(t *testing.T) {
+	schema, err := FromStruct(&event{})
+	require.NoError(t, err)
+	require.Equal(t, ch.TableSchema{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "LowCardinality(String)"},
+		{Name: "payload", Type: "String", Codec: "ZSTD(1)", Comment: "raw event"},
+		{Name: "time", Type: "DateTime", DefaultKind: ch.DefaultKindDefault, DefaultExpression: "now()"},
+		{Name: "tags", Type: "Map(String, String)"},
+		{Name: "user_id", Type: "Nullable(UUID)"},
+		{Name: "hash", Type: "FixedString(16)"},
+		{Name: "scores", Type: "Array(Float64)"},
+	}, schema)
+
+	_, err = FromStruct(1)
+	require.Error(t, err)
+	_, err = FromStruct(struct{ C chan int }{})
+	require.Error(t, err)
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, out := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"HTTPCode":  "http_code",
+		"Name":      "name",
+		"createdAt": "created_at",
+	} {
+		require.Equal(t, out, snakeCase(in), in)
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	ddl, err := CreateTable(Table{
+		Name:        "db.events",
+		IfNotExists: true,
+		OrderBy:     []string{"name", "time"},
+		PartitionBy: "toYYYYMM(time)",
+		TTL:         "time + INTERVAL 1 MONTH",
+		Settings:    []string{"index_granularity = 8192"},
+		Comment:     "user's events",
+	}, event{})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS `db`.`events`\n"+
+		"(\n"+
+		"    `id` UInt64,\n"+
+		"    `name` LowCardinality(String),\n"+
+		"    `payload` String COMMENT 'raw event' CODEC(ZSTD(1)),\n"+
+		"    `time` DateTime DEFAULT now(),\n"+
+		"    `tags` Map(String, String),\n"+
+		"    `user_id` Nullable(UUID),\n"+
+		"    `hash` FixedString(16),\n"+
+		"    `scores` Array(Float64)\n"+
+		")\n"+
+		"ENGINE = MergeTree\n"+
+		"PARTITION BY toYYYYMM(time)\n"+
+		"ORDER BY (name, time)\n"+
+		"TTL time + INTERVAL 1 MONTH\n"+
+		"SETTINGS index_granularity = 8192\n"+
+		"COMMENT 'user\\'s events'", ddl)
+
+	t.Run("Defaults", func(t *testing.T) {
+		ddl, err := CreateTableSchema(Table{Name: "t"}, ch.TableSchema{{Name: "v", Type: "UInt8"}})
+		require.NoError(t, err)
+		require.Equal(t, "CREATE TABLE `t`\n(\n    `v` UInt8\n)\nENGINE = MergeTree\nORDER BY tuple()", ddl)
+
+		ddl, err = CreateTableSchema(Table{Name: "t", Engine: "Memory"}, ch.TableSchema{{Name: "v", Type: "UInt8"}})
+		require.NoError(t, err)
+		require.Equal(t, "CREATE TABLE `t`\n(\n    `v` UInt8\n)\nENGINE = Memory", ddl)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := CreateTableSchema(Table{}, ch.TableSchema{{Name: "v", Type: "UInt8"}})
+		require.Error(t, err)
+		_, err = CreateTableSchema(Table{Name: "t"}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestGoStruct(t *testing.T) {
+	schema := ch.TableSchema{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "LowCardinality(String)", DefaultKind: ch.DefaultKindDefault, DefaultExpression: "'unknown'"},
+		{Name: "payload", Type: "String", Codec: "ZSTD(1)", Comment: "raw event"},
+		{Name: "created_at", Type: "DateTime64(3)"},
+		{Name: "user_id", Type: "Nullable(UUID)"},
+		{Name: "tags", Type: "Map(String, Array(UInt32))"},
+		{Name: "point", Type: "Tuple(Float64, Float64)"},
+	}
+	out, err := GoStruct("model", "Event", schema)
+	require.NoError(t, err)
+	require.Equal(t, `package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Event struct {
+	ID        uint64              `+"`"+`ch:"id"`+"`"+`
+	Name      string              `+"`"+`ch:"name" chtype:"LowCardinality(String)" chdefault:"'unknown'"`+"`"+`
+	Payload   string              `+"`"+`ch:"payload" chcodec:"ZSTD(1)" chcomment:"raw event"`+"`"+`
+	CreatedAt time.Time           `+"`"+`ch:"created_at" chtype:"DateTime64(3)"`+"`"+`
+	UserID    *uuid.UUID          `+"`"+`ch:"user_id"`+"`"+`
+	Tags      map[string][]uint32 `+"`"+`ch:"tags"`+"`"+`
+	// Point has unsupported type.
+	Point any `+"`"+`ch:"point" chtype:"Tuple(Float64, Float64)"`+"`"+`
+}
+`, string(out))
+}
+
+func TestRoundTrip(t *testing.T) {
+	schema, err := FromStruct(event{})
+	require.NoError(t, err)
+	out, err := GoStruct("model", "Event", schema)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Hash    [16]byte")
+	require.Contains(t, string(out), "Scores  []float64")
+	require.Contains(t, string(out), "Payload string            `ch:\"payload\" chcodec:\"ZSTD(1)\" chcomment:\"raw event\"`")
+}
+
+func TestParseCreateTable(t *testing.T) {
+	name, schema, err := ParseCreateTable("create table if not exists `db`.`my table` on cluster c (" +
+		"id UInt64 NOT NULL, " +
+		"/* comment */ s String NULL DEFAULT 'a,b' CODEC(LZ4) TTL d + INTERVAL 1 DAY, " +
+		"d DateTime('UTC') MATERIALIZED now() COMMENT 'it''s' , " +
+		"m Map(String, UInt8) ALIAS map('a', 1), " +
+		"CONSTRAINT c CHECK id > 0, " +
+		"INDEX i s TYPE bloom_filter GRANULARITY 1," +
+		"PRIMARY KEY id" +
+		") ENGINE = Memory")
+	require.NoError(t, err)
+	require.Equal(t, "db.my table", name)
+	require.Equal(t, ch.TableSchema{
+		{Name: "id", Type: "UInt64"},
+		{
+			Name:              "s",
+			Type:              "Nullable(String)",
+			DefaultKind:       ch.DefaultKindDefault,
+			DefaultExpression: "'a,b'",
+			Codec:             "LZ4",
+			TTL:               "d + INTERVAL 1 DAY",
+		},
+		{
+			Name:              "d",
+			Type:              "DateTime('UTC')",
+			DefaultKind:       ch.DefaultKindMaterialized,
+			DefaultExpression: "now()",
+			Comment:           "it's",
+		},
+		{Name: "m", Type: "Map(String, UInt8)", DefaultKind: ch.DefaultKindAlias, DefaultExpression: "map('a', 1)"},
+	}, schema)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		schema, err := FromStruct(event{})
+		require.NoError(t, err)
+		ddl, err := CreateTable(Table{Name: "events"}, event{})
+		require.NoError(t, err)
+		name, parsed, err := ParseCreateTable(ddl)
+		require.NoError(t, err)
+		require.Equal(t, "events", name)
+		require.Equal(t, schema, parsed)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, query := range []string{
+			"",
+			"SELECT 1",
+			"CREATE TABLE t",
+			"CREATE TABLE t (",
+			"CREATE TABLE t ()",
+			"CREATE TABLE t (id)",
+			"CREATE TABLE t (id UInt8 FOO)",
+			"CREATE TABLE t (id UInt8 COMMENT x)",
+		} {
+			_, _, err := ParseCreateTable(query)
+			require.Error(t, err, query)
+		}
+	})
+}
+
+func TestBinding(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("internal", "example", "events.sql"))
+	require.NoError(t, err)
+	name, schema, err := ParseCreateTable(string(data))
+	require.NoError(t, err)
+	out, err := Binding("example", "Event", name, schema)
+	require.NoError(t, err)
+
+	// Generated by ch-gen-table, see internal/example.
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "events_gen.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), "// Code generated by ch-gen-table, DO NOT EDIT.\n\n"+string(out))
+
+	t.Run("Unsupported", func(t *testing.T) {
+		for _, typ := range []proto.ColumnType{
+			"Tuple(String, UInt8)",
+			"Array(Nullable(String))",
+			"Array(FixedString(3))",
+			"Decimal(10, 2)",
+		} {
+			_, err := Binding("p", "T", "t", ch.TableSchema{{Name: "v", Type: typ}})
+			require.Error(t, err, typ)
+		}
+		_, err := Binding("p", "T", "t", ch.TableSchema{
+			{Name: "v", Type: "UInt8", DefaultKind: ch.DefaultKindAlias, DefaultExpression: "1"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,159 @@
+package chschema
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+)
+
+// Table options of CREATE TABLE query.
+type Table struct {
+	// Name of table, can be qualified with database like "db.table".
+	Name string
+	// IfNotExists adds IF NOT EXISTS clause.
+	IfNotExists bool
+	// Engine of table with parameters, defaults to MergeTree.
+	Engine string
+	// OrderBy expressions, defaults to tuple() for MergeTree family.
+	OrderBy []string
+	// PartitionBy expression, optional.
+	PartitionBy string
+	// PrimaryKey expressions, optional, defaults to OrderBy by server.
+	PrimaryKey []string
+	// TTL expression of table, optional.
+	TTL string
+	// Settings of table, like "index_granularity = 8192".
+	Settings []string
+	// Comment of table, optional.
+	Comment string
+}
+
+// CreateTable returns CREATE TABLE query for table with columns of struct
+// v, see FromStruct.
+func CreateTable(t Table, v any) (string, error) {
+	schema, err := FromStruct(v)
+	if err != nil {
+		return "", errors.Wrap(err, "schema")
+	}
+	return CreateTableSchema(t, schema)
+}
+
+// CreateTableSchema returns CREATE TABLE query for table with schema, e.g.
+// from ch.DescribeTable.
+func CreateTableSchema(t Table, schema ch.TableSchema) (string, error) {
+	if t.Name == "" {
+		return "", errors.New("empty table name")
+	}
+	if len(schema) == 0 {
+		return "", errors.New("no columns")
+	}
+	if t.Engine == "" {
+		t.Engine = "MergeTree"
+	}
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	if t.IfNotExists {
+		b.WriteString("IF NOT EXISTS ")
+	}
+	for i, part := range strings.Split(t.Name, ".") {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(quoteIdentifier(part))
+	}
+	b.WriteString("\n(\n")
+	for i, c := range schema {
+		b.WriteString("    ")
+		writeColumn(&b, c)
+		if i < len(schema)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString(")\nENGINE = ")
+	b.WriteString(t.Engine)
+	if t.PartitionBy != "" {
+		b.WriteString("\nPARTITION BY ")
+		b.WriteString(t.PartitionBy)
+	}
+	switch {
+	case len(t.OrderBy) > 0:
+		b.WriteString("\nORDER BY ")
+		writeTuple(&b, t.OrderBy)
+	case strings.Contains(t.Engine, "MergeTree"):
+		b.WriteString("\nORDER BY tuple()")
+	}
+	if len(t.PrimaryKey) > 0 {
+		b.WriteString("\nPRIMARY KEY ")
+		writeTuple(&b, t.PrimaryKey)
+	}
+	if t.TTL != "" {
+		b.WriteString("\nTTL ")
+		b.WriteString(t.TTL)
+	}
+	if len(t.Settings) > 0 {
+		b.WriteString("\nSETTINGS ")
+		b.WriteString(strings.Join(t.Settings, ", "))
+	}
+	if t.Comment != "" {
+		b.WriteString("\nCOMMENT ")
+		b.WriteString(quoteString(t.Comment))
+	}
+	return b.String(), nil
+}
+
+func writeColumn(b *strings.Builder, c ch.TableColumn) {
+	b.WriteString(quoteIdentifier(c.Name))
+	b.WriteByte(' ')
+	b.WriteString(c.Type.String())
+	if c.DefaultKind != "" {
+		b.WriteByte(' ')
+		b.WriteString(c.DefaultKind)
+		if c.DefaultExpression != "" {
+			b.WriteByte(' ')
+			b.WriteString(c.DefaultExpression)
+		}
+	}
+	if c.Comment != "" {
+		b.WriteString(" COMMENT ")
+		b.WriteString(quoteString(c.Comment))
+	}
+	if c.Codec != "" {
+		b.WriteString(" CODEC(")
+		b.WriteString(codecArgs(c.Codec))
+		b.WriteByte(')')
+	}
+	if c.TTL != "" {
+		b.WriteString(" TTL ")
+		b.WriteString(c.TTL)
+	}
+}
+
+// codecArgs returns codecs without CODEC(...) wrapper, e.g. ZSTD(1) for
+// CODEC(ZSTD(1)), as reported by DESCRIBE TABLE.
+func codecArgs(codec string) string {
+	if strings.HasPrefix(codec, "CODEC(") && strings.HasSuffix(codec, ")") {
+		return codec[len("CODEC(") : len(codec)-1]
+	}
+	return codec
+}
+
+func writeTuple(b *strings.Builder, exprs []string) {
+	if len(exprs) == 1 {
+		b.WriteString(exprs[0])
+		return
+	}
+	b.WriteByte('(')
+	b.WriteString(strings.Join(exprs, ", "))
+	b.WriteByte(')')
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(s) + "'"
+}
@@ -0,0 +1,17 @@
+// Package chschema keeps Go types and ClickHouse table schemas in sync,
+// generating CREATE TABLE DDL from tagged structs and Go structs from
+// table schema.
+//
+// Struct fields are mapped to columns by tags:
+//
+//	type Event struct {
+//		ID      uint64            `ch:"id"`
+//		Name    string            `ch:"name" chtype:"LowCardinality(String)"`
+//		Payload string            `ch:"payload" chcodec:"ZSTD(1)" chcomment:"raw event"`
+//		Time    time.Time         `ch:"time" chdefault:"now()"`
+//		Tags    map[string]string `ch:"tags"`
+//		Skipped string            `ch:"-"`
+//	}
+//
+// Column type is inferred from Go type if chtype is not set.
+package chschema
@@ -0,0 +1,217 @@
+package chschema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// GoStruct returns Go source of struct type with name for table schema,
+// e.g. from ch.DescribeTable, in package pkg.
+//
+// Column type is set in chtype tag if it can't be inferred back from Go
+// type, like LowCardinality(String) or DateTime64(3). Columns of types
+// without Go equivalent are generated as any.
+func GoStruct(pkg, name string, schema ch.TableSchema) ([]byte, error) {
+	if len(schema) == 0 {
+		return nil, errors.New("no columns")
+	}
+	g := newGoGen()
+	g.writeStruct(name, schema)
+	return g.source(pkg)
+}
+
+type goGen struct {
+	imports map[string]struct{}
+	body    bytes.Buffer
+}
+
+func newGoGen() *goGen {
+	return &goGen{imports: map[string]struct{}{}}
+}
+
+// writeStruct writes struct type with name and fields for columns.
+func (g *goGen) writeStruct(name string, schema ch.TableSchema) {
+	fmt.Fprintf(&g.body, "type %s struct {\n", name)
+	for _, c := range schema {
+		typ := g.goType(c.Type)
+		tags := []string{fmt.Sprintf("%s:%q", TagName, c.Name)}
+		if typ == "any" {
+			fmt.Fprintf(&g.body, "\t// %s has unsupported type.\n", goName(c.Name))
+			tags = append(tags, fmt.Sprintf("%s:%q", TagType, c.Type))
+		} else if !g.roundTrips(c.Type) {
+			tags = append(tags, fmt.Sprintf("%s:%q", TagType, c.Type))
+		}
+		if c.DefaultKind == ch.DefaultKindDefault && c.DefaultExpression != "" {
+			tags = append(tags, fmt.Sprintf("%s:%q", TagDefault, c.DefaultExpression))
+		}
+		if c.Codec != "" {
+			tags = append(tags, fmt.Sprintf("%s:%q", TagCodec, codecArgs(c.Codec)))
+		}
+		if c.Comment != "" {
+			tags = append(tags, fmt.Sprintf("%s:%q", TagComment, c.Comment))
+		}
+		fmt.Fprintf(&g.body, "\t%s %s `%s`\n", goName(c.Name), typ, strings.Join(tags, " "))
+	}
+	g.body.WriteString("}\n")
+}
+
+// source returns formatted source of package pkg with imports and body.
+func (g *goGen) source(pkg string) ([]byte, error) {
+	// Imports are grouped as standard library, third party and ch-go.
+	groups := make([][]string, 3)
+	for p := range g.imports {
+		switch {
+		case strings.HasPrefix(p, "github.com/ClickHouse/ch-go"):
+			groups[2] = append(groups[2], p)
+		case strings.Contains(strings.SplitN(p, "/", 2)[0], "."):
+			groups[1] = append(groups[1], p)
+		default:
+			groups[0] = append(groups[0], p)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		b.WriteString("import (\n")
+		for _, group := range groups {
+			sort.Strings(group)
+			for _, p := range group {
+				fmt.Fprintf(&b, "\t%q\n", p)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(")\n\n")
+	}
+	b.Write(g.body.Bytes())
+
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "format")
+	}
+	return out, nil
+}
+
+// goType returns Go type for column type t, or "any" if not supported.
+func (g *goGen) goType(t proto.ColumnType) string {
+	switch t.Base() {
+	case proto.ColumnTypeBool:
+		return "bool"
+	case proto.ColumnTypeInt8:
+		return "int8"
+	case proto.ColumnTypeInt16:
+		return "int16"
+	case proto.ColumnTypeInt32:
+		return "int32"
+	case proto.ColumnTypeInt64:
+		return "int64"
+	case proto.ColumnTypeUInt8:
+		return "uint8"
+	case proto.ColumnTypeUInt16:
+		return "uint16"
+	case proto.ColumnTypeUInt32:
+		return "uint32"
+	case proto.ColumnTypeUInt64:
+		return "uint64"
+	case proto.ColumnTypeFloat32:
+		return "float32"
+	case proto.ColumnTypeFloat64:
+		return "float64"
+	case proto.ColumnTypeString, proto.ColumnTypeEnum8, proto.ColumnTypeEnum16:
+		return "string"
+	case proto.ColumnTypeFixedString:
+		return "[" + string(t.Elem()) + "]byte"
+	case proto.ColumnTypeDate, proto.ColumnTypeDate32,
+		proto.ColumnTypeDateTime, proto.ColumnTypeDateTime64:
+		g.imports["time"] = struct{}{}
+		return "time.Time"
+	case proto.ColumnTypeUUID:
+		g.imports["github.com/google/uuid"] = struct{}{}
+		return "uuid.UUID"
+	case proto.ColumnTypeLowCardinality:
+		return g.goType(t.Elem())
+	case proto.ColumnTypeNullable:
+		return g.nested("*", t.Elem())
+	case proto.ColumnTypeArray:
+		return g.nested("[]", t.Elem())
+	case proto.ColumnTypeMap:
+		elems := t.Elems()
+		if len(elems) != 2 {
+			return "any"
+		}
+		k, v := g.goType(elems[0]), g.goType(elems[1])
+		if k == "any" || v == "any" {
+			return "any"
+		}
+		return "map[" + k + "]" + v
+	}
+	return "any"
+}
+
+func (g *goGen) nested(prefix string, elem proto.ColumnType) string {
+	typ := g.goType(elem)
+	if typ == "any" {
+		return typ
+	}
+	return prefix + typ
+}
+
+// roundTrips reports whether t is inferred back from its Go type.
+func (g *goGen) roundTrips(t proto.ColumnType) bool {
+	switch t.Base() {
+	case proto.ColumnTypeNullable, proto.ColumnTypeArray:
+		return g.roundTrips(t.Elem())
+	case proto.ColumnTypeMap:
+		for _, e := range t.Elems() {
+			if !g.roundTrips(e) {
+				return false
+			}
+		}
+		return true
+	case proto.ColumnTypeFixedString:
+		return true
+	case proto.ColumnTypeDateTime:
+		// DateTime with timezone is not inferred.
+		return t == proto.ColumnTypeDateTime
+	case proto.ColumnTypeLowCardinality, proto.ColumnTypeEnum8, proto.ColumnTypeEnum16,
+		proto.ColumnTypeDate, proto.ColumnTypeDate32, proto.ColumnTypeDateTime64:
+		return false
+	}
+	return g.goType(t) != "any"
+}
+
+// goInitialisms are upper cased in Go names.
+var goInitialisms = map[string]struct{}{
+	"api": {}, "cpu": {}, "dns": {}, "http": {}, "id": {}, "ip": {},
+	"json": {}, "sql": {}, "ttl": {}, "uid": {}, "uri": {}, "url": {}, "uuid": {},
+}
+
+// goName converts column name to exported Go name, e.g. user_id to UserID.
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if _, ok := goInitialisms[strings.ToLower(part)]; ok {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "F" + name
+	}
+	return name
+}
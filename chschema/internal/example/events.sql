@@ -0,0 +1,19 @@
+-- Schema of example table for ch-gen-table.
+CREATE TABLE IF NOT EXISTS events
+(
+    `id`         UInt64,
+    `name`       LowCardinality(String) DEFAULT 'unknown',
+    `level`      Enum8('debug' = 1, 'info' = 2, 'error' = 3),
+    `payload`    String COMMENT 'raw event' CODEC(ZSTD(1)),
+    `created_at` DateTime64(3) DEFAULT now64(3),
+    `day`        Date MATERIALIZED toDate(created_at),
+    `user_id`    Nullable(UUID),
+    `trace_id`   FixedString(16),
+    `digest`     FixedString(20),
+    `tags`       Map(String, Array(UInt32)),
+    `scores`     Array(Float64),
+    INDEX name_idx name TYPE bloom_filter GRANULARITY 4
+)
+ENGINE = MergeTree
+PARTITION BY toYYYYMM(created_at)
+ORDER BY (name, created_at);
@@ -0,0 +1,4 @@
+// Package example contains binding generated by ch-gen-table.
+package example
+
+//go:generate go run ../../../cmd/ch-gen-table -schema events.sql -type Event -package example -output events_gen.go
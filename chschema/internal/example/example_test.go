@@ -0,0 +1,73 @@
+package example
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestEventColumns(t *testing.T) {
+	const levelType = "Enum8('debug' = 1, 'info' = 2, 'error' = 3)"
+	userID := uuid.New()
+	rows := []Event{
+		{
+			ID:        1,
+			Name:      "login",
+			Level:     "info",
+			Payload:   "{}",
+			CreatedAt: time.Unix(1700000000, 123e6).UTC(),
+			UserID:    &userID,
+			TraceID:   [16]byte{1, 2, 3},
+			Digest:    [20]byte{4, 5, 6},
+			Tags:      map[string][]uint32{"a": {1, 2}},
+			Scores:    []float64{0.5},
+		},
+		{
+			ID:        2,
+			Name:      "logout",
+			Level:     "error",
+			CreatedAt: time.Unix(1700000001, 0).UTC(),
+			Tags:      map[string][]uint32{},
+		},
+	}
+
+	in := NewEventColumns()
+	require.NoError(t, in.Level.Infer(levelType))
+	for _, row := range rows {
+		in.Append(row)
+	}
+	require.Equal(t, len(rows), in.Rows())
+
+	out := NewEventColumns()
+	require.NoError(t, out.Level.Infer(levelType))
+	results := out.Result()
+	for i, col := range in.Input() {
+		var b proto.Buffer
+		if p, ok := col.Data.(proto.Preparable); ok {
+			require.NoError(t, p.Prepare())
+		}
+		if s, ok := col.Data.(proto.StateEncoder); ok {
+			s.EncodeState(&b)
+		}
+		col.Data.EncodeColumn(&b)
+
+		r := b.Reader()
+		if s, ok := results[i].Data.(proto.StateDecoder); ok {
+			require.NoError(t, s.DecodeState(r))
+		}
+		require.NoError(t, results[i].Data.DecodeColumn(r, in.Rows()), col.Name)
+	}
+	require.Equal(t, len(rows), out.Rows())
+	for i, row := range rows {
+		got := out.Row(i)
+		got.CreatedAt = got.CreatedAt.UTC()
+		require.Equal(t, row, got)
+	}
+
+	out.Reset()
+	require.Zero(t, out.Rows())
+}
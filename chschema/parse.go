@@ -0,0 +1,337 @@
+package chschema
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ParseCreateTable parses name and columns of table from CREATE TABLE
+// query, e.g. from schema file or SHOW CREATE TABLE.
+//
+// Indices, projections and constraints are skipped, as well as engine and
+// other table clauses.
+func ParseCreateTable(query string) (name string, schema ch.TableSchema, err error) {
+	s := &scanner{s: query}
+	if !s.keyword("CREATE") {
+		return "", nil, errors.New("expected CREATE")
+	}
+	s.keyword("OR", "REPLACE")
+	if !s.keyword("TABLE") {
+		return "", nil, errors.New("expected TABLE")
+	}
+	s.keyword("IF", "NOT", "EXISTS")
+
+	var parts []string
+	for {
+		part, err := s.ident()
+		if err != nil {
+			return "", nil, errors.Wrap(err, "table name")
+		}
+		parts = append(parts, part)
+		if !s.next('.') {
+			break
+		}
+	}
+	name = strings.Join(parts, ".")
+	if s.keyword("ON", "CLUSTER") {
+		if _, err := s.ident(); err != nil {
+			return "", nil, errors.Wrap(err, "cluster")
+		}
+	}
+
+	s.skipSpace()
+	if !s.next('(') {
+		return "", nil, errors.New("expected column list")
+	}
+	for {
+		s.skipSpace()
+		start := s.pos
+		s.skipUntil(func(c byte) bool { return c == ',' || c == ')' })
+		if s.eof() {
+			return "", nil, errors.New("unterminated column list")
+		}
+		def := query[start:s.pos]
+		if c, ok, err := parseColumn(def); err != nil {
+			return "", nil, errors.Wrapf(err, "column %q", strings.TrimSpace(def))
+		} else if ok {
+			schema = append(schema, c)
+		}
+		if s.next(')') {
+			break
+		}
+		s.pos++ // ','
+	}
+	if len(schema) == 0 {
+		return "", nil, errors.New("no columns")
+	}
+	return name, schema, nil
+}
+
+// parseColumn parses column definition, returning false for indices,
+// projections and constraints.
+func parseColumn(def string) (ch.TableColumn, bool, error) {
+	s := &scanner{s: def}
+	for _, kw := range []string{"INDEX", "PROJECTION", "CONSTRAINT"} {
+		if s.keyword(kw) {
+			return ch.TableColumn{}, false, nil
+		}
+	}
+	if s.keyword("PRIMARY", "KEY") {
+		return ch.TableColumn{}, false, nil
+	}
+
+	var (
+		c   ch.TableColumn
+		err error
+	)
+	if c.Name, err = s.ident(); err != nil {
+		return c, false, errors.Wrap(err, "name")
+	}
+	s.skipSpace()
+	start := s.pos
+	if s.word() == "" {
+		return c, false, errors.New("expected type")
+	}
+	s.skipSpace()
+	if s.peek('(') {
+		s.pos++
+		s.skipUntil(func(c byte) bool { return c == ')' })
+		if !s.next(')') {
+			return c, false, errors.New("unterminated type")
+		}
+	}
+	c.Type = proto.ColumnType(strings.TrimSpace(def[start:s.pos]))
+
+	// Clauses that terminate expressions.
+	stop := func() bool {
+		save := s.pos
+		defer func() { s.pos = save }()
+		switch strings.ToUpper(s.word()) {
+		case "CODEC", "COMMENT", "TTL", "SETTINGS":
+			return true
+		}
+		return false
+	}
+	for {
+		s.skipSpace()
+		if s.eof() {
+			return c, true, nil
+		}
+		switch kw := strings.ToUpper(s.word()); kw {
+		case "NULL":
+			c.Type = proto.ColumnTypeNullable.Sub(c.Type)
+		case "NOT":
+			if !s.keyword("NULL") {
+				return c, false, errors.New("expected NULL")
+			}
+		case ch.DefaultKindDefault, ch.DefaultKindMaterialized, ch.DefaultKindAlias, ch.DefaultKindEphemeral:
+			c.DefaultKind = kw
+			c.DefaultExpression = s.expr(stop)
+		case "CODEC":
+			s.skipSpace()
+			start := s.pos
+			if !s.next('(') {
+				return c, false, errors.New("expected codec list")
+			}
+			s.skipUntil(func(c byte) bool { return c == ')' })
+			if !s.next(')') {
+				return c, false, errors.New("unterminated codec list")
+			}
+			c.Codec = def[start+1 : s.pos-1]
+		case "COMMENT":
+			s.skipSpace()
+			if !s.peek('\'') {
+				return c, false, errors.New("expected comment string")
+			}
+			start := s.pos
+			s.pos = skipQuoted(def, s.pos)
+			c.Comment = unquote(def[start:s.pos])
+		case "TTL":
+			c.TTL = s.expr(stop)
+		case "":
+			return c, false, errors.Errorf("unexpected %q", def[s.pos:])
+		default:
+			return c, false, errors.Errorf("unexpected %s", kw)
+		}
+	}
+}
+
+// scanner of SQL query.
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (s *scanner) eof() bool { return s.pos >= len(s.s) }
+
+func (s *scanner) peek(c byte) bool { return !s.eof() && s.s[s.pos] == c }
+
+// next consumes c after spaces.
+func (s *scanner) next(c byte) bool {
+	s.skipSpace()
+	if s.peek(c) {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace and comments.
+func (s *scanner) skipSpace() {
+	for !s.eof() {
+		switch rest := s.s[s.pos:]; {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			s.pos++
+		case strings.HasPrefix(rest, "--"):
+			if end := strings.IndexByte(rest, '\n'); end >= 0 {
+				s.pos += end + 1
+			} else {
+				s.pos = len(s.s)
+			}
+		case strings.HasPrefix(rest, "/*"):
+			if end := strings.Index(rest[2:], "*/"); end >= 0 {
+				s.pos += end + 4
+			} else {
+				s.pos = len(s.s)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// word reads bare word after spaces.
+func (s *scanner) word() string {
+	s.skipSpace()
+	start := s.pos
+	for !s.eof() {
+		c := s.s[s.pos]
+		if c != '_' && !isAlpha(c) && !isDigit(c) {
+			break
+		}
+		s.pos++
+	}
+	return s.s[start:s.pos]
+}
+
+// keyword consumes sequence of case-insensitive words if all match.
+func (s *scanner) keyword(words ...string) bool {
+	save := s.pos
+	for _, w := range words {
+		if !strings.EqualFold(s.word(), w) {
+			s.pos = save
+			return false
+		}
+	}
+	return true
+}
+
+// ident reads bare, back quoted or double quoted identifier.
+func (s *scanner) ident() (string, error) {
+	s.skipSpace()
+	if s.peek('`') || s.peek('"') {
+		start := s.pos
+		s.pos = skipQuoted(s.s, s.pos)
+		return unquote(s.s[start:s.pos]), nil
+	}
+	if w := s.word(); w != "" {
+		return w, nil
+	}
+	return "", errors.New("expected identifier")
+}
+
+// skipUntil skips to first byte matching f outside of quotes and
+// parentheses.
+func (s *scanner) skipUntil(f func(c byte) bool) {
+	depth := 0
+	for !s.eof() {
+		switch c := s.s[s.pos]; {
+		case c == '\'' || c == '"' || c == '`':
+			s.pos = skipQuoted(s.s, s.pos)
+			continue
+		case depth == 0 && f(c):
+			return
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		}
+		s.pos++
+	}
+}
+
+// expr reads expression until stop clause outside of quotes and
+// parentheses.
+func (s *scanner) expr(stop func() bool) string {
+	s.skipSpace()
+	start := s.pos
+	end := s.pos
+	for {
+		s.skipSpace()
+		if s.eof() || stop() {
+			break
+		}
+		// Consume single token or balanced group.
+		switch c := s.s[s.pos]; {
+		case c == '\'' || c == '"' || c == '`':
+			s.pos = skipQuoted(s.s, s.pos)
+		case c == '(' || c == '[':
+			s.pos++
+			s.skipUntil(func(c byte) bool { return c == ')' || c == ']' })
+			s.pos++
+		case c == '_' || isAlpha(c) || isDigit(c):
+			s.word()
+		default:
+			s.pos++
+		}
+		end = s.pos
+	}
+	s.pos = end
+	return strings.TrimSpace(s.s[start:end])
+}
+
+// skipQuoted returns position after quoted string that starts at i.
+func skipQuoted(s string, i int) int {
+	q := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case q:
+			if j+1 < len(s) && s[j+1] == q {
+				// Doubled quote.
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// unquote returns content of quoted string or identifier, reverting
+// backslash escaping and doubled quotes.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	q := s[0]
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if i+1 < len(s) && (c == '\\' || (c == q && s[i+1] == q)) {
+			i++
+			c = s[i]
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isAlpha(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
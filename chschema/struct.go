@@ -0,0 +1,164 @@
+package chschema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Tags of struct fields.
+const (
+	TagName    = "ch"        // column name, "-" to skip field
+	TagType    = "chtype"    // column type, inferred if not set
+	TagDefault = "chdefault" // DEFAULT expression
+	TagCodec   = "chcodec"   // codec, like ZSTD(1)
+	TagComment = "chcomment" // column comment
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// FromStruct returns schema of struct v, which can be value or pointer,
+// see package documentation for tags.
+//
+// Fields without ch tag are named in snake case, e.g. UserID as user_id.
+// Unexported fields are skipped.
+func FromStruct(v any) (ch.TableSchema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("%T is not struct", v)
+	}
+	var schema ch.TableSchema
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Tag.Get(TagName)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+		col := ch.TableColumn{
+			Name:    name,
+			Type:    proto.ColumnType(f.Tag.Get(TagType)),
+			Comment: f.Tag.Get(TagComment),
+		}
+		if col.Type == "" {
+			typ, err := ColumnType(f.Type)
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", f.Name)
+			}
+			col.Type = typ
+		}
+		if v := f.Tag.Get(TagDefault); v != "" {
+			col.DefaultKind = ch.DefaultKindDefault
+			col.DefaultExpression = v
+		}
+		if v := f.Tag.Get(TagCodec); v != "" {
+			col.Codec = codecArgs(v)
+		}
+		schema = append(schema, col)
+	}
+	if len(schema) == 0 {
+		return nil, errors.Errorf("%s has no columns", t)
+	}
+	return schema, nil
+}
+
+// ColumnType returns ClickHouse type for Go type t.
+func ColumnType(t reflect.Type) (proto.ColumnType, error) {
+	switch t {
+	case timeType:
+		return proto.ColumnTypeDateTime, nil
+	case uuidType:
+		return proto.ColumnTypeUUID, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return proto.ColumnTypeBool, nil
+	case reflect.Int8:
+		return proto.ColumnTypeInt8, nil
+	case reflect.Int16:
+		return proto.ColumnTypeInt16, nil
+	case reflect.Int32:
+		return proto.ColumnTypeInt32, nil
+	case reflect.Int64, reflect.Int:
+		return proto.ColumnTypeInt64, nil
+	case reflect.Uint8:
+		return proto.ColumnTypeUInt8, nil
+	case reflect.Uint16:
+		return proto.ColumnTypeUInt16, nil
+	case reflect.Uint32:
+		return proto.ColumnTypeUInt32, nil
+	case reflect.Uint64, reflect.Uint:
+		return proto.ColumnTypeUInt64, nil
+	case reflect.Float32:
+		return proto.ColumnTypeFloat32, nil
+	case reflect.Float64:
+		return proto.ColumnTypeFloat64, nil
+	case reflect.String:
+		return proto.ColumnTypeString, nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return proto.ColumnTypeFixedString.With(strconv.Itoa(t.Len())), nil
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return proto.ColumnTypeString, nil
+		}
+		elem, err := ColumnType(t.Elem())
+		if err != nil {
+			return "", errors.Wrap(err, "array")
+		}
+		return elem.Array(), nil
+	case reflect.Pointer:
+		elem, err := ColumnType(t.Elem())
+		if err != nil {
+			return "", errors.Wrap(err, "nullable")
+		}
+		return proto.ColumnTypeNullable.Sub(elem), nil
+	case reflect.Map:
+		k, err := ColumnType(t.Key())
+		if err != nil {
+			return "", errors.Wrap(err, "map key")
+		}
+		v, err := ColumnType(t.Elem())
+		if err != nil {
+			return "", errors.Wrap(err, "map value")
+		}
+		return proto.ColumnTypeMap.Sub(k, v), nil
+	}
+	return "", errors.Errorf("unsupported type %s", t)
+}
+
+// snakeCase converts Go name to snake case, e.g. UserID to user_id.
+func snakeCase(s string) string {
+	var b strings.Builder
+	r := []rune(s)
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]) && unicode.IsUpper(r[i-1]))) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
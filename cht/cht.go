@@ -4,6 +4,7 @@ package cht
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	_ "embed"
 	"encoding/xml"
 	"net"
@@ -52,6 +53,12 @@ type Server struct {
 	TCP    string
 	HTTP   string
 	Config Config
+
+	// TLS listeners, see WithTLS.
+	TCPSecure string
+	HTTPS     string
+	// TLS is client config that trusts server certificate.
+	TLS *tls.Config
 }
 
 func writeXML(t testing.TB, name string, v interface{}) {
@@ -66,6 +73,7 @@ func portOf(t testing.TB, addr string) int {
 	t.Helper()
 
 	addr = strings.TrimPrefix(addr, "http://")
+	addr = strings.TrimPrefix(addr, "https://")
 
 	_, port, err := net.SplitHostPort(addr)
 	require.NoError(t, err)
@@ -102,10 +110,39 @@ type options struct {
 	keeper           *KeeperConfig
 	macros           Map
 	ddl              *DistributedDDL
+	bin              string // overrides Bin
+	image            string // forces Docker backend
+	tls              bool
+	users            []User
+	profiles         []Profile
+	quotas           []Quota
 
 	maxServerMemoryUsage int
 }
 
+// WithTLS enables secure native and HTTPS listeners with self-signed
+// certificate generated for test, see Server.TLS.
+func WithTLS() Option {
+	return func(o *options) {
+		o.tls = true
+	}
+}
+
+// WithBin sets path to ClickHouse binary instead of Bin.
+func WithBin(path string) Option {
+	return func(o *options) {
+		o.bin = path
+	}
+}
+
+// WithDockerImage starts server in Docker with image, skipping test if
+// Docker is not available.
+func WithDockerImage(image string) Option {
+	return func(o *options) {
+		o.image = image
+	}
+}
+
 func WithMaxServerMemoryUsage(n int) Option {
 	return func(o *options) {
 		o.maxServerMemoryUsage = n
@@ -201,7 +238,7 @@ func Many(t testing.TB, opts ...Option) []Server {
 
 // Skip test if e2e is not available.
 func Skip(t testing.TB) {
-	_ = BinOrSkip(t)
+	_, _ = options{}.backendOrSkip(t)
 }
 
 // BinOrSkip returns binary path or skips test.
@@ -239,6 +276,8 @@ type OpenTelemetry struct {
 //
 // Override binary with CH_BIN.
 // Can be clickhouse-server or clickhouse.
+//
+// If no binary is available, server is started in Docker, see EnvDocker.
 func New(t testing.TB, opts ...Option) Server {
 	o := options{
 		lg: zap.NewNop(),
@@ -247,56 +286,16 @@ func New(t testing.TB, opts ...Option) Server {
 		opt(&o)
 	}
 
-	binaryPath := BinOrSkip(t)
+	binaryPath, docker := o.backendOrSkip(t)
+	if docker {
+		return newDocker(t, o)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Setup data directory and config.
 	dir := t.TempDir()
 	cfgPath := filepath.Join(dir, "config.xml")
-	userCfgPath := filepath.Join(dir, "users.xml")
-	cfg := Config{
-		Logger: Logger{
-			Level:   "trace",
-			Console: 1,
-		},
-
-		HTTP: o.http,
-		TCP:  o.tcp,
-
-		InterServerHTTP: o.httpInternal,
-
-		Host: "127.0.0.1",
-
-		Path:          filepath.Join(dir, "data"),
-		TempPath:      filepath.Join(dir, "tmp"),
-		UserFilesPath: filepath.Join(dir, "users"),
-
-		MaxServerMemoryUsage: o.maxServerMemoryUsage,
-
-		MarkCacheSize: 5368709120,
-		MMAPCacheSize: 1000,
-
-		OpenTelemetrySpanLog: &OpenTelemetry{
-			Table:    "opentelemetry_span_log",
-			Database: "system",
-			Engine: `engine MergeTree
-            partition by toYYYYMM(finish_date)
-            order by (finish_date, finish_time_us, trace_id)`,
-		},
-
-		UserDirectories: UserDir{
-			UsersXML: UsersXML{
-				Path: userCfgPath,
-			},
-		},
-
-		Keeper:         o.keeper,
-		ZooKeeper:      o.zooKeeper,
-		RemoteServers:  o.clusters,
-		Macros:         o.macros,
-		DistributedDDL: o.ddl,
-	}
-	writeXML(t, cfgPath, cfg)
+	cfg := o.config(dir, dir, filepath.Join)
 	for _, dir := range []string{
 		cfg.Path,
 		cfg.TempPath,
@@ -304,7 +303,7 @@ func New(t testing.TB, opts ...Option) Server {
 	} {
 		require.NoError(t, os.MkdirAll(dir, 0o750))
 	}
-	require.NoError(t, os.WriteFile(userCfgPath, usersCfg, 0o600))
+	tlsConfig := o.writeConfig(t, dir, cfg)
 
 	// Setup command.
 	var args []string
@@ -317,8 +316,10 @@ func New(t testing.TB, opts ...Option) Server {
 	cmd := exec.CommandContext(ctx, binaryPath, args...) // #nosec G204
 
 	var (
-		tcpAddr  string
-		httpAddr string
+		tcpAddr       string
+		httpAddr      string
+		tcpSecureAddr string
+		httpsAddr     string
 	)
 	started := make(chan struct{})
 	onAddr := func(info logInfo) {
@@ -328,12 +329,20 @@ func New(t testing.TB, opts ...Option) Server {
 		if !strings.Contains(info.Addr, "127.0.0.1") {
 			return
 		}
-		if strings.HasPrefix(info.Addr, "http:") {
+		port := portOf(t, info.Addr)
+		switch {
+		case strings.HasPrefix(info.Addr, "https:"):
+			httpsAddr = info.Addr
+			cfg.HTTPS = &port
+		case info.Secure:
+			tcpSecureAddr = info.Addr
+			cfg.TCPSecure = &port
+		case strings.HasPrefix(info.Addr, "http:"):
 			httpAddr = info.Addr
-			cfg.HTTP = portOf(t, httpAddr)
-		} else {
+			cfg.HTTP = port
+		default:
 			tcpAddr = info.Addr
-			cfg.TCP = portOf(t, tcpAddr)
+			cfg.TCP = port
 		}
 	}
 	cmd.Stdout = logProxy(o.lg, onAddr)
@@ -383,8 +392,95 @@ func New(t testing.TB, opts ...Option) Server {
 	})
 
 	return Server{
-		TCP:    tcpAddr,
-		HTTP:   httpAddr,
-		Config: cfg,
+		TCP:       tcpAddr,
+		HTTP:      httpAddr,
+		Config:    cfg,
+		TCPSecure: tcpSecureAddr,
+		HTTPS:     httpsAddr,
+		TLS:       tlsConfig,
 	}
 }
+
+// config returns server config with data in dataDir and other config
+// files in cfgDir, see writeConfig.
+func (o options) config(dataDir, cfgDir string, join func(elem ...string) string) Config {
+	cfg := Config{
+		Logger: Logger{
+			Level:   "trace",
+			Console: 1,
+		},
+
+		HTTP: o.http,
+		TCP:  o.tcp,
+
+		InterServerHTTP: o.httpInternal,
+
+		Host: "127.0.0.1",
+
+		Path:          join(dataDir, "data"),
+		TempPath:      join(dataDir, "tmp"),
+		UserFilesPath: join(dataDir, "users"),
+
+		MaxServerMemoryUsage: o.maxServerMemoryUsage,
+
+		MarkCacheSize: 5368709120,
+		MMAPCacheSize: 1000,
+
+		OpenTelemetrySpanLog: &OpenTelemetry{
+			Table:    "opentelemetry_span_log",
+			Database: "system",
+			Engine: `engine MergeTree
+            partition by toYYYYMM(finish_date)
+            order by (finish_date, finish_time_us, trace_id)`,
+		},
+
+		UserDirectories: UserDir{
+			UsersXML: UsersXML{
+				Path: join(cfgDir, "users.xml"),
+			},
+		},
+
+		Keeper:         o.keeper,
+		ZooKeeper:      o.zooKeeper,
+		RemoteServers:  o.clusters,
+		Macros:         o.macros,
+		DistributedDDL: o.ddl,
+	}
+	if o.tls {
+		var tcpSecure, https int
+		cfg.TCPSecure = &tcpSecure
+		cfg.HTTPS = &https
+		cfg.OpenSSL = &OpenSSL{
+			Server: OpenSSLServer{
+				CertificateFile:  join(cfgDir, "server.crt"),
+				PrivateKeyFile:   join(cfgDir, "server.key"),
+				VerificationMode: "none",
+				CacheSessions:    true,
+				DisableProtocols: "sslv2,sslv3",
+			},
+		}
+	}
+	return cfg
+}
+
+// writeConfig writes config.xml, users and certificates to dir.
+//
+// Returns TLS config of client if TLS is enabled.
+func (o options) writeConfig(t testing.TB, dir string, cfg Config) *tls.Config {
+	writeXML(t, filepath.Join(dir, "config.xml"), cfg)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.xml"), usersCfg, 0o600))
+	if users := o.usersConfig(); users != nil {
+		// Merged to users.xml by server.
+		usersDir := filepath.Join(dir, "users.d")
+		require.NoError(t, os.MkdirAll(usersDir, 0o750))
+		writeXML(t, filepath.Join(usersDir, "cht.xml"), users)
+	}
+	if !o.tls {
+		return nil
+	}
+	certs, err := generateCerts()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.crt"), certs.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.key"), certs.keyPEM, 0o600))
+	return certs.clientConfig()
+}
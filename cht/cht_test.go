@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/go-faster/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ClickHouse/ch-go"
@@ -341,3 +342,147 @@ ENGINE = Distributed('nexus', default, hits, rand())`,
 		require.NoError(t, client.Ping(ctx))
 	})
 }
+
+func TestNewCluster(t *testing.T) {
+	{
+		ctx := context.Background()
+		server := cht.New(t, cht.WithLog(ztest.NewLogger(t)))
+		client, err := ch.Dial(ctx, ch.Options{Address: server.TCP})
+		require.NoError(t, err)
+		if v := client.ServerInfo(); (v.Major < 22) || (v.Major == 22 && v.Minor < 6) {
+			t.Skip("Skipping (not supported)")
+		}
+	}
+	t.Parallel()
+	var (
+		ctx     = context.Background()
+		lg      = ztest.NewLogger(t)
+		cluster = cht.NewCluster(t, cht.ClusterOptions{
+			Name:     "nexus",
+			Shards:   2,
+			Replicas: 2,
+			Options:  []cht.Option{cht.WithLog(lg)},
+		})
+	)
+	require.Len(t, cluster.Nodes, 4)
+	require.Len(t, cluster.Shard(2), 2)
+
+	client, err := ch.Dial(ctx, ch.Options{Address: cluster.Node(1, 1).TCP, Logger: lg.Named("client")})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	info, err := ch.Cluster(ctx, client, cluster.Name)
+	require.NoError(t, err)
+	require.Len(t, info.Shards, 2)
+	require.Len(t, info.Shards[0].Replicas, 2)
+
+	require.NoError(t, ch.DoOnCluster(ctx, client, ch.Query{
+		Body: `CREATE TABLE events ON CLUSTER '{cluster}' (id UInt64)
+ENGINE = ReplicatedMergeTree('/clickhouse/tables/{shard}/events', '{replica}') ORDER BY id`,
+	}))
+	require.NoError(t, ch.DoOnCluster(ctx, client, ch.Query{
+		Body: `CREATE TABLE events_distributed ON CLUSTER '{cluster}' AS events
+ENGINE = Distributed('{cluster}', default, events, id)`,
+	}))
+	require.NoError(t, client.Do(ctx, ch.Query{
+		Body:     "INSERT INTO events_distributed SELECT number FROM system.numbers LIMIT 100",
+		Settings: []ch.Setting{ch.SettingBool("insert_distributed_sync", true)},
+	}))
+
+	// Replica of second shard has only rows of its shard.
+	replica, err := ch.Dial(ctx, ch.Options{Address: cluster.Node(2, 2).TCP})
+	require.NoError(t, err)
+	defer func() { _ = replica.Close() }()
+	for _, q := range []struct {
+		table string
+		total uint64
+	}{
+		{table: "events_distributed", total: 100},
+		{table: "events", total: 50},
+	} {
+		require.NoError(t, backoff.Retry(func() error {
+			var count proto.ColUInt64
+			if err := replica.Do(ctx, ch.Query{
+				Body:   "SELECT count() AS total FROM " + q.table,
+				Result: proto.Results{{Name: "total", Data: &count}},
+			}); err != nil {
+				return backoff.Permanent(err)
+			}
+			if count.Row(0) != q.total {
+				return errors.Errorf("%s: got %d rows", q.table, count.Row(0))
+			}
+			return nil
+		}, backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond*100), 100)))
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	cht.Matrix(t, []string{"23.8", "24.3"}, func(t *testing.T, version string, opt cht.Option) {
+		ctx := context.Background()
+		server := cht.New(t, opt, cht.WithLog(ztest.NewLogger(t)))
+		client, err := ch.Dial(ctx, ch.Options{Address: server.TCP})
+		require.NoError(t, err)
+		defer func() { _ = client.Close() }()
+
+		v := client.ServerInfo()
+		require.Equal(t, version, fmt.Sprintf("%d.%d", v.Major, v.Minor))
+	})
+}
+
+func TestTLSAndUsers(t *testing.T) {
+	ctx := context.Background()
+	server := cht.New(t,
+		cht.WithLog(ztest.NewLogger(t)),
+		cht.WithTLS(),
+		cht.WithProfiles(cht.Profile{Name: "ro", Settings: cht.Map{"readonly": "1"}}),
+		cht.WithQuotas(cht.Quota{Name: "single", Intervals: []cht.QuotaInterval{
+			{Duration: time.Hour, Queries: 1},
+		}}),
+		cht.WithUsers(
+			cht.User{Name: "alice", Password: "secret", Profile: "ro"},
+			cht.User{Name: "bob", Quota: "single"},
+		),
+	)
+	t.Parallel()
+	require.NotEmpty(t, server.TCPSecure)
+	require.NotEmpty(t, server.HTTPS)
+
+	dial := func(t *testing.T, opt ch.Options) (*ch.Client, error) {
+		opt.Address = server.TCPSecure
+		opt.TLS = server.TLS
+		client, err := ch.Dial(ctx, opt)
+		if err == nil {
+			t.Cleanup(func() { _ = client.Close() })
+		}
+		return client, err
+	}
+
+	t.Run("TLS", func(t *testing.T) {
+		client, err := dial(t, ch.Options{})
+		require.NoError(t, err)
+		require.NoError(t, client.Ping(ctx))
+	})
+	t.Run("Password", func(t *testing.T) {
+		_, err := dial(t, ch.Options{User: "alice", Password: "wrong"})
+		require.True(t, ch.HasCode(err, proto.ErrAuthenticationFailed), "%v", err)
+
+		client, err := dial(t, ch.Options{User: "alice", Password: "secret"})
+		require.NoError(t, err)
+		err = client.Do(ctx, ch.Query{Body: "CREATE TABLE t (id UInt8) ENGINE = Memory"})
+		require.True(t, ch.HasCode(err, proto.ErrReadonly), "%v", err)
+	})
+	t.Run("Quota", func(t *testing.T) {
+		client, err := dial(t, ch.Options{User: "bob"})
+		require.NoError(t, err)
+		selectOne := func() error {
+			var data proto.ColUInt8
+			return client.Do(ctx, ch.Query{
+				Body:   "SELECT 1 AS one",
+				Result: proto.Results{{Name: "one", Data: &data}},
+			})
+		}
+		require.NoError(t, selectOne())
+		err = selectOne()
+		require.True(t, ch.HasCode(err, proto.ErrQuotaExpired), "%v", err)
+	})
+}
@@ -0,0 +1,191 @@
+package cht
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ClusterOptions configures NewCluster.
+type ClusterOptions struct {
+	Name     string // of cluster in remote_servers, defaults to "cluster"
+	Shards   int    // defaults to 1
+	Replicas int    // of each shard, defaults to 1
+	Secret   string // inter-server secret of cluster, optional
+	// Options for every node, e.g. WithLog.
+	Options []Option
+}
+
+func (o *ClusterOptions) setDefaults() {
+	if o.Name == "" {
+		o.Name = "cluster"
+	}
+	if o.Shards == 0 {
+		o.Shards = 1
+	}
+	if o.Replicas == 0 {
+		o.Replicas = 1
+	}
+}
+
+// ClusterNode is server of cluster.
+type ClusterNode struct {
+	Server
+	Shard   int // starting from 1
+	Replica int // starting from 1
+}
+
+// ClusterServers is cluster started by NewCluster.
+type ClusterServers struct {
+	Name  string
+	Nodes []ClusterNode // ordered by shard and replica
+}
+
+// Shard returns replicas of shard, starting from 1.
+func (c ClusterServers) Shard(shard int) []ClusterNode {
+	var nodes []ClusterNode
+	for _, n := range c.Nodes {
+		if n.Shard == shard {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// Node returns replica of shard, both starting from 1.
+func (c ClusterServers) Node(shard, replica int) ClusterNode {
+	for _, n := range c.Nodes {
+		if n.Shard == shard && n.Replica == replica {
+			return n
+		}
+	}
+	panic(fmt.Sprintf("cht: no replica %d of shard %d", replica, shard))
+}
+
+// clusterNode is config of cluster node.
+type clusterNode struct {
+	shard       int
+	replica     int
+	tcp         int
+	interServer int
+	keeper      *KeeperConfig
+}
+
+// clusterConfig is config of cluster nodes.
+type clusterConfig struct {
+	nodes     []clusterNode
+	clusters  Clusters
+	zooKeeper []ZooKeeperNode
+	raft      RaftConfig
+}
+
+// clusterHost is listen host of cluster nodes.
+const clusterHost = "127.0.0.1"
+
+// newClusterConfig allocates ports of nodes and generates remote_servers
+// config. Keeper is embedded to first three nodes, or to the first one if
+// cluster has less than three nodes.
+func newClusterConfig(opt ClusterOptions, ports []int) clusterConfig {
+	total := opt.Shards * opt.Replicas
+	keepers := 3
+	if total < keepers {
+		keepers = 1
+	}
+	var (
+		cfg     clusterConfig
+		cluster = Cluster{Secret: opt.Secret}
+	)
+	for shard := 1; shard <= opt.Shards; shard++ {
+		s := Shard{InternalReplication: true}
+		for replica := 1; replica <= opt.Replicas; replica++ {
+			p := ports[len(cfg.nodes)*4:]
+			node := clusterNode{
+				shard:       shard,
+				replica:     replica,
+				tcp:         p[0],
+				interServer: p[1],
+			}
+			if len(cfg.nodes) < keepers {
+				id := len(cfg.nodes) + 1
+				node.keeper = &KeeperConfig{ServerID: id, TCPPort: p[2]}
+				cfg.zooKeeper = append(cfg.zooKeeper, ZooKeeperNode{Index: id, Host: clusterHost, Port: p[2]})
+				cfg.raft.Servers = append(cfg.raft.Servers, RaftServer{ID: id, Hostname: clusterHost, Port: p[3]})
+			}
+			s.Replicas = append(s.Replicas, Replica{Host: clusterHost, Port: node.tcp})
+			cfg.nodes = append(cfg.nodes, node)
+		}
+		cluster.Shards = append(cluster.Shards, s)
+	}
+	cfg.clusters = Clusters{opt.Name: cluster}
+	return cfg
+}
+
+// macros returns macros of node, which can be used in engines of
+// replicated tables and in ON CLUSTER clause as '{cluster}'.
+func (n clusterNode) macros(cluster string) Map {
+	return Map{
+		"cluster": cluster,
+		"shard":   fmt.Sprintf("%02d", n.shard),
+		"replica": fmt.Sprintf("%02d", n.replica),
+	}
+}
+
+// NewCluster starts cluster of shards with replicas and embedded Keeper,
+// generating remote_servers config, macros and distributed DDL config of
+// every node.
+//
+// Nodes have {cluster}, {shard} and {replica} macros, so replicated
+// tables can be created as
+//
+//	CREATE TABLE t ON CLUSTER '{cluster}' (...)
+//	ENGINE = ReplicatedMergeTree('/clickhouse/tables/{shard}/t', '{replica}')
+//
+// Skips test like New.
+func NewCluster(t testing.TB, opt ClusterOptions) ClusterServers {
+	opt.setDefaults()
+	Skip(t)
+
+	cfg := newClusterConfig(opt, Ports(t, opt.Shards*opt.Replicas*4))
+	coordination := CoordinationConfig{
+		ElectionTimeoutLowerBoundMs: 250,
+		ElectionTimeoutUpperBoundMs: 350,
+		HeartBeatIntervalMs:         100,
+		DeadSessionCheckPeriodMs:    100,
+		OperationTimeoutMs:          200,
+	}
+	opts := make([]Option, 0, len(cfg.nodes))
+	for _, n := range cfg.nodes {
+		nodeOpts := []Option{
+			WithTCP(n.tcp),
+			WithInterServerHTTP(n.interServer),
+			WithInterServerHost(clusterHost),
+			WithClusters(cfg.clusters),
+			WithZooKeeper(cfg.zooKeeper),
+			WithMacros(n.macros(opt.Name)),
+			WithDistributedDDL(DistributedDDL{
+				PoolSize: 1,
+				Profile:  "default",
+				Path:     "/clickhouse/" + opt.Name + "/task_queue/ddl",
+			}),
+		}
+		if n.keeper != nil {
+			keeper := *n.keeper
+			keeper.Raft = cfg.raft
+			keeper.Coordination = coordination
+			keeper.LogStoragePath = t.TempDir()
+			keeper.SnapshotStoragePath = t.TempDir()
+			nodeOpts = append(nodeOpts, WithKeeper(keeper))
+		}
+		opts = append(opts, With(append(nodeOpts, opt.Options...)...))
+	}
+
+	servers := Many(t, opts...)
+	c := ClusterServers{Name: opt.Name}
+	for i, s := range servers {
+		c.Nodes = append(c.Nodes, ClusterNode{
+			Server:  s,
+			Shard:   cfg.nodes[i].shard,
+			Replica: cfg.nodes[i].replica,
+		})
+	}
+	return c
+}
@@ -0,0 +1,50 @@
+package cht
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterConfig(t *testing.T) {
+	opt := ClusterOptions{Shards: 2, Replicas: 2}
+	opt.setDefaults()
+	ports := make([]int, 16)
+	for i := range ports {
+		ports[i] = 10000 + i
+	}
+	cfg := newClusterConfig(opt, ports)
+
+	require.Len(t, cfg.nodes, 4)
+	require.Len(t, cfg.zooKeeper, 3)
+	require.Len(t, cfg.raft.Servers, 3)
+	for i, n := range cfg.nodes {
+		require.Equal(t, i/2+1, n.shard)
+		require.Equal(t, i%2+1, n.replica)
+		require.Equal(t, 10000+i*4, n.tcp)
+		require.Equal(t, i < 3, n.keeper != nil)
+	}
+	require.Equal(t, 10002, cfg.zooKeeper[0].Port)
+	require.Equal(t, 10003, cfg.raft.Servers[0].Port)
+	require.Equal(t, Map{
+		"cluster": "cluster",
+		"shard":   "02",
+		"replica": "01",
+	}, cfg.nodes[2].macros(opt.Name))
+
+	cluster := cfg.clusters["cluster"]
+	require.Len(t, cluster.Shards, 2)
+	require.Equal(t, []Replica{
+		{Host: clusterHost, Port: 10008},
+		{Host: clusterHost, Port: 10012},
+	}, cluster.Shards[1].Replicas)
+	logXML(t, Config{RemoteServers: cfg.clusters})
+
+	t.Run("Single", func(t *testing.T) {
+		opt := ClusterOptions{}
+		opt.setDefaults()
+		cfg := newClusterConfig(opt, ports[:4])
+		require.Len(t, cfg.nodes, 1)
+		require.Len(t, cfg.zooKeeper, 1)
+	})
+}
@@ -103,6 +103,10 @@ type Config struct {
 	TCP     int      `xml:"tcp_port"`
 	Host    string   `xml:"listen_host"`
 
+	TCPSecure *int     `xml:"tcp_port_secure,omitempty"`
+	HTTPS     *int     `xml:"https_port,omitempty"`
+	OpenSSL   *OpenSSL `xml:"openSSL,omitempty"`
+
 	InterServerHTTP     *int    `xml:"interserver_http_port,omitempty"`
 	InterServerHTTPHost *string `xml:"interserver_http_host,omitempty"`
 
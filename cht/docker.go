@@ -0,0 +1,266 @@
+package cht
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/internal/e2e"
+)
+
+const (
+	// EnvDocker is environmental variable that disables Docker backend if
+	// set to false value.
+	EnvDocker = "CH_DOCKER"
+	// EnvDockerImage is environmental variable that sets ClickHouse image
+	// of Docker backend, e.g. clickhouse/clickhouse-server:23.8.
+	EnvDockerImage = "CH_DOCKER_IMAGE"
+)
+
+// DockerRepository is repository of official ClickHouse server images.
+const DockerRepository = "clickhouse/clickhouse-server"
+
+// DefaultDockerImage is image of Docker backend if EnvDockerImage is not set.
+const DefaultDockerImage = DockerRepository + ":latest"
+
+// Ports of server in container.
+const (
+	dockerTCP       = 9000
+	dockerHTTP      = 8123
+	dockerTCPSecure = 9440
+	dockerHTTPS     = 8443
+)
+
+// docker runs docker command and returns its trimmed output.
+func docker(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "docker", args...) // #nosec G204
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.Wrap(err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+var (
+	dockerOnce sync.Once
+	dockerOK   bool
+)
+
+// dockerAvailable reports whether Docker backend can be used.
+func dockerAvailable() bool {
+	dockerOnce.Do(func() {
+		dockerOK = dockerCheck()
+	})
+	return dockerOK
+}
+
+func dockerCheck() bool {
+	if v, ok := os.LookupEnv(EnvDocker); ok && v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil && !enabled {
+			return false
+		}
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	// Check that daemon is running.
+	_, err := docker(ctx, "version", "--format", "{{.Server.Version}}")
+	return err == nil
+}
+
+// backendOrSkip returns binary path, or reports that server should be
+// started in Docker, or skips test.
+func (o options) backendOrSkip(t testing.TB) (binaryPath string, useDocker bool) {
+	status := e2e.Get(t)
+	if status == e2e.Disabled {
+		t.Skip("E2E: Disabled")
+	}
+	switch {
+	case o.bin != "":
+		p, err := exec.LookPath(o.bin)
+		require.NoError(t, err, "lookup")
+		return p, false
+	case o.image != "":
+		if !dockerAvailable() {
+			t.Skipf("Docker: not available for %s", o.image)
+		}
+		return "", true
+	}
+	if p, err := Bin(); err == nil {
+		return p, false
+	}
+	if dockerAvailable() {
+		return "", true
+	}
+	return BinOrSkip(t), false
+}
+
+// dockerImage returns image of Docker backend.
+func (o options) dockerImage() string {
+	if o.image != "" {
+		return o.image
+	}
+	if v := os.Getenv(EnvDockerImage); v != "" {
+		return v
+	}
+	return DefaultDockerImage
+}
+
+// hostPort returns address on host that is mapped to port of container.
+func hostPort(ctx context.Context, id string, port int) (string, error) {
+	out, err := docker(ctx, "port", id, strconv.Itoa(port)+"/tcp")
+	if err != nil {
+		return "", err
+	}
+	// Can be mapped to multiple addresses, e.g. IPv4 and IPv6.
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "127.0.0.1:") {
+			return line, nil
+		}
+	}
+	return "", errors.Errorf("port %d is not mapped: %q", port, out)
+}
+
+// waitReady probes HTTP interface of server until it is ready.
+func waitReady(ctx context.Context, httpAddr string) error {
+	client := &http.Client{Timeout: time.Second}
+	ticker := time.NewTicker(time.Millisecond * 100)
+	defer ticker.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpAddr+"/ping", http.NoBody)
+		if err != nil {
+			return err
+		}
+		if res, err := client.Do(req); err == nil {
+			body, _ := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+			if res.StatusCode == http.StatusOK && strings.TrimSpace(string(body)) == "Ok." {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// newDocker starts server in Docker container with ports mapped to
+// loopback interface of host.
+//
+// Options that require network between servers, like clusters and Keeper,
+// are not supported, so test is skipped.
+func newDocker(t testing.TB, o options) Server {
+	if o.clusters != nil || o.keeper != nil || o.zooKeeper != nil || o.httpInternal != nil {
+		t.Skip("Docker: cluster options are not supported")
+	}
+	image := o.dockerImage()
+
+	// Paths are in container, config directory is mounted.
+	const (
+		dataDir = "/var/lib/clickhouse"
+		cfgDir  = "/etc/cht"
+	)
+	dir := t.TempDir()
+	cfg := o.config(dataDir, cfgDir, path.Join)
+	cfg.Host = "0.0.0.0"
+	cfg.TCP = dockerTCP
+	cfg.HTTP = dockerHTTP
+	if o.tls {
+		tcpSecure, https := dockerTCPSecure, dockerHTTPS
+		cfg.TCPSecure = &tcpSecure
+		cfg.HTTPS = &https
+	}
+	tlsConfig := o.writeConfig(t, dir, cfg)
+
+	publish := func(host, container int) string {
+		v := "127.0.0.1:"
+		if host != 0 {
+			v += strconv.Itoa(host)
+		}
+		return v + ":" + strconv.Itoa(container)
+	}
+	args := []string{"run", "--detach", "--rm",
+		"--ulimit", "nofile=262144:262144",
+		"--publish", publish(o.tcp, dockerTCP),
+		"--publish", publish(o.http, dockerHTTP),
+		"--volume", dir + ":" + cfgDir + ":ro",
+		"--env", "CLICKHOUSE_CONFIG=" + cfgDir + "/config.xml",
+		// Entrypoint restricts network access of default user otherwise.
+		"--env", "CLICKHOUSE_SKIP_USER_SETUP=1",
+	}
+	if o.tls {
+		args = append(args,
+			"--publish", publish(0, dockerTCPSecure),
+			"--publish", publish(0, dockerHTTPS),
+		)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	id, err := docker(ctx, append(args, image)...)
+	require.NoError(t, err, "docker run %s", image)
+
+	// Streaming logs until container is removed.
+	logs := exec.Command("docker", "logs", "--follow", id) // #nosec G204
+	logs.Stdout = logProxy(o.lg, func(logInfo) {})
+	logs.Stderr = logs.Stdout
+	require.NoError(t, logs.Start())
+
+	t.Cleanup(func() {
+		t.Log("Shutting down")
+		startClose := time.Now()
+		if _, err := docker(context.Background(), "rm", "--force", id); err != nil {
+			t.Errorf("docker rm: %v", err)
+		}
+		_ = logs.Wait()
+		t.Log("Closed in", time.Since(startClose).Round(time.Millisecond))
+	})
+
+	s := Server{TLS: tlsConfig}
+	s.TCP, err = hostPort(ctx, id, dockerTCP)
+	require.NoError(t, err)
+	s.HTTP, err = hostPort(ctx, id, dockerHTTP)
+	require.NoError(t, err)
+	s.HTTP = "http://" + s.HTTP
+	if o.tls {
+		s.TCPSecure, err = hostPort(ctx, id, dockerTCPSecure)
+		require.NoError(t, err)
+		s.HTTPS, err = hostPort(ctx, id, dockerHTTPS)
+		require.NoError(t, err)
+		s.HTTPS = "https://" + s.HTTPS
+
+		tcpSecure, https := portOf(t, s.TCPSecure), portOf(t, s.HTTPS)
+		cfg.TCPSecure = &tcpSecure
+		cfg.HTTPS = &https
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	require.NoError(t, waitReady(readyCtx, s.HTTP), "wait for server in %s", image)
+	t.Log("Started in Docker", time.Since(start).Round(time.Millisecond), image, s.TCP, s.HTTP)
+
+	cfg.TCP = portOf(t, s.TCP)
+	cfg.HTTP = portOf(t, s.HTTP)
+	s.Config = cfg
+	return s
+}
@@ -0,0 +1,44 @@
+package cht
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitReady(t *testing.T) {
+	var calls atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ping", r.URL.Path)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("Ok.\n"))
+	}))
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	require.NoError(t, waitReady(ctx, s.URL))
+	require.Equal(t, int32(3), calls.Load())
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*250)
+		defer cancel()
+		require.ErrorIs(t, waitReady(ctx, "http://127.0.0.1:1"), context.DeadlineExceeded)
+	})
+}
+
+func TestDockerImage(t *testing.T) {
+	t.Setenv(EnvDockerImage, "")
+	require.Equal(t, DefaultDockerImage, options{}.dockerImage())
+	t.Setenv(EnvDockerImage, "clickhouse/clickhouse-server:23.8")
+	require.Equal(t, "clickhouse/clickhouse-server:23.8", options{}.dockerImage())
+	require.Equal(t, "clickhouse/clickhouse-server:24.3", options{image: "clickhouse/clickhouse-server:24.3"}.dockerImage())
+}
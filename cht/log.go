@@ -11,8 +11,9 @@ import (
 )
 
 type logInfo struct {
-	Addr  string
-	Ready bool
+	Addr   string
+	Ready  bool
+	Secure bool // TLS listener
 }
 
 // cut field between start and end, trimming space.
@@ -115,7 +116,10 @@ func logProxy(lg *zap.Logger, f func(info logInfo)) io.Writer {
 			}
 
 			elems := strings.Split(e.Message, " ")
-			f(logInfo{Addr: elems[len(elems)-1]})
+			f(logInfo{
+				Addr:   elems[len(elems)-1],
+				Secure: strings.Contains(e.Message, "secure"),
+			})
 		}
 	}()
 
@@ -0,0 +1,73 @@
+package cht
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/internal/e2e"
+)
+
+const (
+	// EnvVersions is environmental variable that overrides versions of
+	// Matrix, separated by comma.
+	EnvVersions = "CH_VERSIONS"
+	// EnvBinDir is environmental variable that sets directory with cached
+	// binaries of versions for Matrix, named like clickhouse-23.8.
+	EnvBinDir = "CH_BIN_DIR"
+)
+
+// matrixVersions returns versions of matrix, overridden by EnvVersions.
+func matrixVersions(versions []string) []string {
+	v := os.Getenv(EnvVersions)
+	if v == "" {
+		return versions
+	}
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// versionOption returns option that starts server of version, preferring
+// cached binary from EnvBinDir to Docker image.
+func versionOption(version string) (Option, bool) {
+	if dir := os.Getenv(EnvBinDir); dir != "" {
+		p := filepath.Join(dir, "clickhouse-"+version)
+		if s, err := os.Stat(p); err == nil && !s.IsDir() {
+			return WithBin(p), true
+		}
+	}
+	if dockerAvailable() {
+		return WithDockerImage(DockerRepository + ":" + version), true
+	}
+	return nil, false
+}
+
+// Matrix runs f as subtest for each ClickHouse version, e.g. "23.8" or
+// "24.3.2.23", passing option that should be used to start server of
+// that version with New.
+//
+// Server of version is started from binary in EnvBinDir or from
+// DockerRepository image with version tag, pulled and cached by Docker.
+// Subtests of versions that are not available are skipped. Versions can
+// be overridden by EnvVersions.
+func Matrix(t *testing.T, versions []string, f func(t *testing.T, version string, opt Option)) {
+	if e2e.Get(t) == e2e.Disabled {
+		t.Skip("E2E: Disabled")
+	}
+	for _, version := range matrixVersions(versions) {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			opt, ok := versionOption(version)
+			if !ok {
+				t.Skipf("Version %s is not available", version)
+			}
+			f(t, version, opt)
+		})
+	}
+}
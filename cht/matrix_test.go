@@ -0,0 +1,31 @@
+package cht
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixVersions(t *testing.T) {
+	versions := []string{"23.8", "24.3"}
+	t.Setenv(EnvVersions, "")
+	require.Equal(t, versions, matrixVersions(versions))
+	t.Setenv(EnvVersions, " 22.8, ,24.8 ")
+	require.Equal(t, []string{"22.8", "24.8"}, matrixVersions(versions))
+}
+
+func TestVersionOption(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "clickhouse-23.8")
+	require.NoError(t, os.WriteFile(bin, nil, 0o600))
+	t.Setenv(EnvBinDir, dir)
+
+	opt, ok := versionOption("23.8")
+	require.True(t, ok)
+	var o options
+	opt(&o)
+	require.Equal(t, bin, o.bin)
+	require.Empty(t, o.image)
+}
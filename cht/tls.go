@@ -0,0 +1,103 @@
+package cht
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// OpenSSL config of server.
+type OpenSSL struct {
+	Server OpenSSLServer `xml:"server"`
+}
+
+// OpenSSLServer is TLS config of server listeners.
+type OpenSSLServer struct {
+	CertificateFile   string `xml:"certificateFile"`
+	PrivateKeyFile    string `xml:"privateKeyFile"`
+	VerificationMode  string `xml:"verificationMode,omitempty"`
+	LoadDefaultCAFile bool   `xml:"loadDefaultCAFile"`
+	CacheSessions     bool   `xml:"cacheSessions"`
+	DisableProtocols  string `xml:"disableProtocols,omitempty"`
+}
+
+// serverCerts is self-signed CA and server certificate issued by it.
+type serverCerts struct {
+	ca      *x509.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// clientConfig returns TLS config of client that trusts CA.
+func (c *serverCerts) clientConfig() *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.ca)
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// generateCerts generates CA and server certificate for loopback
+// addresses and localhost.
+func generateCerts() (*serverCerts, error) {
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "ca key")
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cht CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour * 24),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "ca")
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ca")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "key")
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour * 24),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "certificate")
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal key")
+	}
+	return &serverCerts{
+		ca:      ca,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,182 @@
+package cht
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"sort"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// User of server, see WithUsers.
+type User struct {
+	Name     string
+	Password string // empty for no password
+	Profile  string // defaults to "default"
+	Quota    string // defaults to "default"
+	// AccessManagement allows user to manage access with SQL, e.g. create
+	// users and roles.
+	AccessManagement bool
+}
+
+// Profile is settings profile, see WithProfiles.
+type Profile struct {
+	Name     string
+	Settings Map
+}
+
+// Quota limits resource usage of users, see WithQuotas.
+type Quota struct {
+	Name      string
+	Intervals []QuotaInterval
+}
+
+// QuotaInterval is limits of quota for interval, zero is no limit.
+type QuotaInterval struct {
+	Duration      time.Duration
+	Queries       int
+	Errors        int
+	ResultRows    int
+	ReadRows      int
+	ExecutionTime time.Duration
+}
+
+// WithUsers adds users to server.
+func WithUsers(users ...User) Option {
+	return func(o *options) {
+		o.users = append(o.users, users...)
+	}
+}
+
+// WithProfiles adds settings profiles to server.
+func WithProfiles(profiles ...Profile) Option {
+	return func(o *options) {
+		o.profiles = append(o.profiles, profiles...)
+	}
+}
+
+// WithQuotas adds quotas to server.
+func WithQuotas(quotas ...Quota) Option {
+	return func(o *options) {
+		o.quotas = append(o.quotas, quotas...)
+	}
+}
+
+type userXML struct {
+	Password         *string  `xml:"password,omitempty"`
+	PasswordSHA256   string   `xml:"password_sha256_hex,omitempty"`
+	Networks         []string `xml:"networks>ip"`
+	Profile          string   `xml:"profile"`
+	Quota            string   `xml:"quota"`
+	AccessManagement int      `xml:"access_management,omitempty"`
+}
+
+type quotaIntervalXML struct {
+	Duration      int `xml:"duration"`
+	Queries       int `xml:"queries"`
+	Errors        int `xml:"errors"`
+	ResultRows    int `xml:"result_rows"`
+	ReadRows      int `xml:"read_rows"`
+	ExecutionTime int `xml:"execution_time"`
+}
+
+type quotaXML struct {
+	Intervals []quotaIntervalXML `xml:"interval"`
+}
+
+// named is map of elements with names as tags.
+type named map[string]interface{}
+
+func (n named) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// Sort for deterministic marshaling.
+	var keys []string
+	for k := range n {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return errors.Wrap(err, "start")
+	}
+	for _, k := range keys {
+		if err := e.EncodeElement(n[k], xml.StartElement{
+			Name: xml.Name{Local: k},
+		}); err != nil {
+			return errors.Wrap(err, "elem")
+		}
+	}
+	if err := e.EncodeToken(start.End()); err != nil {
+		return errors.Wrap(err, "end")
+	}
+
+	return e.Flush()
+}
+
+// UsersConfig is config of users, profiles and quotas that is merged to
+// users.xml.
+type UsersConfig struct {
+	XMLName  xml.Name `xml:"clickhouse"`
+	Profiles named    `xml:"profiles,omitempty"`
+	Users    named    `xml:"users,omitempty"`
+	Quotas   named    `xml:"quotas,omitempty"`
+}
+
+// usersConfig returns users config of options, or nil if no users,
+// profiles or quotas are set.
+func (o options) usersConfig() *UsersConfig {
+	if len(o.users) == 0 && len(o.profiles) == 0 && len(o.quotas) == 0 {
+		return nil
+	}
+	cfg := &UsersConfig{
+		Profiles: named{},
+		Users:    named{},
+		Quotas:   named{},
+	}
+	for _, p := range o.profiles {
+		settings := p.Settings
+		if settings == nil {
+			settings = Map{}
+		}
+		cfg.Profiles[p.Name] = settings
+	}
+	for _, u := range o.users {
+		v := userXML{
+			Networks: []string{"::/0"},
+			Profile:  u.Profile,
+			Quota:    u.Quota,
+		}
+		if u.Password != "" {
+			h := sha256.Sum256([]byte(u.Password))
+			v.PasswordSHA256 = hex.EncodeToString(h[:])
+		} else {
+			v.Password = new(string)
+		}
+		if v.Profile == "" {
+			v.Profile = "default"
+		}
+		if v.Quota == "" {
+			v.Quota = "default"
+		}
+		if u.AccessManagement {
+			v.AccessManagement = 1
+		}
+		cfg.Users[u.Name] = v
+	}
+	for _, q := range o.quotas {
+		var v quotaXML
+		for _, i := range q.Intervals {
+			v.Intervals = append(v.Intervals, quotaIntervalXML{
+				Duration:      int(i.Duration.Seconds()),
+				Queries:       i.Queries,
+				Errors:        i.Errors,
+				ResultRows:    i.ResultRows,
+				ReadRows:      i.ReadRows,
+				ExecutionTime: int(i.ExecutionTime.Seconds()),
+			})
+		}
+		cfg.Quotas[q.Name] = v
+	}
+	return cfg
+}
@@ -0,0 +1,108 @@
+package cht
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersConfig(t *testing.T) {
+	require.Nil(t, options{}.usersConfig())
+
+	cfg := options{
+		users: []User{
+			{Name: "alice", Password: "secret", Profile: "readonly", AccessManagement: true},
+			{Name: "bob", Quota: "limited"},
+		},
+		profiles: []Profile{
+			{Name: "readonly", Settings: Map{"readonly": "1"}},
+		},
+		quotas: []Quota{
+			{Name: "limited", Intervals: []QuotaInterval{
+				{Duration: time.Hour, Queries: 10, ExecutionTime: time.Minute},
+			}},
+		},
+	}.usersConfig()
+	buf := new(bytes.Buffer)
+	e := xml.NewEncoder(buf)
+	e.Indent("", "  ")
+	require.NoError(t, e.Encode(cfg))
+	require.Equal(t, `<clickhouse>
+  <profiles>
+    <readonly>
+      <readonly>1</readonly>
+    </readonly>
+  </profiles>
+  <users>
+    <alice>
+      <password_sha256_hex>2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b</password_sha256_hex>
+      <networks>
+        <ip>::/0</ip>
+      </networks>
+      <profile>readonly</profile>
+      <quota>default</quota>
+      <access_management>1</access_management>
+    </alice>
+    <bob>
+      <password></password>
+      <networks>
+        <ip>::/0</ip>
+      </networks>
+      <profile>default</profile>
+      <quota>limited</quota>
+    </bob>
+  </users>
+  <quotas>
+    <limited>
+      <interval>
+        <duration>3600</duration>
+        <queries>10</queries>
+        <errors>0</errors>
+        <result_rows>0</result_rows>
+        <read_rows>0</read_rows>
+        <execution_time>60</execution_time>
+      </interval>
+    </limited>
+  </quotas>
+</clickhouse>`, buf.String())
+}
+
+func TestGenerateCerts(t *testing.T) {
+	certs, err := generateCerts()
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certs.certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	cfg := certs.clientConfig()
+	for _, name := range []string{"127.0.0.1", "localhost"} {
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName: name,
+			Roots:   cfg.RootCAs,
+		})
+		require.NoError(t, err, name)
+	}
+
+	block, _ = pem.Decode(certs.keyPEM)
+	require.NotNil(t, block)
+	_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+}
+
+func TestConfigTLS(t *testing.T) {
+	cfg := options{tls: true}.config("/data", "/etc/cht", func(elem ...string) string {
+		return elem[0] + "/" + elem[1]
+	})
+	require.NotNil(t, cfg.TCPSecure)
+	require.NotNil(t, cfg.HTTPS)
+	require.Equal(t, "/etc/cht/server.crt", cfg.OpenSSL.Server.CertificateFile)
+	require.Equal(t, "/etc/cht/users.xml", cfg.UserDirectories.UsersXML.Path)
+	logXML(t, cfg)
+}
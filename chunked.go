@@ -0,0 +1,89 @@
+package ch
+
+import (
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// negotiateChunked selects chunked framing for both directions from client
+// options and server capabilities.
+//
+// Server send mode is matched against client receive mode and vice versa.
+func (c *Client) negotiateChunked() (send, recv bool, err error) {
+	if !proto.FeatureChunkedPackets.In(c.protocolVersion) {
+		return false, false, nil
+	}
+	send, err = proto.NegotiateChunked(c.chunkedModes[0], c.server.ChunkedRecv)
+	if err != nil {
+		return false, false, errors.Wrap(err, "send")
+	}
+	recv, err = proto.NegotiateChunked(c.chunkedModes[1], c.server.ChunkedSend)
+	if err != nil {
+		return false, false, errors.Wrap(err, "recv")
+	}
+	return send, recv, nil
+}
+
+// encodeChunked encodes negotiated chunked framing to addendum.
+func (c *Client) encodeChunked(send, recv bool) {
+	mode := func(v bool) string {
+		if v {
+			return string(proto.Chunked)
+		}
+		return string(proto.NotChunked)
+	}
+	c.buf.PutString(mode(send))
+	c.buf.PutString(mode(recv))
+}
+
+// enableChunked enables chunked framing after addendum is sent.
+func (c *Client) enableChunked(send, recv bool) {
+	if send || recv {
+		c.lg.Debug("Chunked packets enabled",
+			zap.Bool("send", send),
+			zap.Bool("recv", recv),
+		)
+	}
+	c.chunkedSend = send
+	if recv {
+		c.chunked.Enable()
+	}
+}
+
+// endPacket marks end of packet in c.buf, so packets can be framed
+// separately if chunked sending is enabled.
+func (c *Client) endPacket() {
+	if c.chunkedSend {
+		c.packets = append(c.packets, len(c.buf.Buf))
+	}
+}
+
+// frame returns packets of b in chunked framing.
+//
+// Buffers other than c.buf are always single packet.
+func (c *Client) frame(b *proto.Buffer) []byte {
+	out := new(proto.Buffer)
+	var ends []int
+	if b == c.buf {
+		// Not allocating for main buffer, which is not used concurrently.
+		out = &c.chunkedBuffer
+		out.Reset()
+		ends = c.packets
+		c.packets = c.packets[:0]
+	}
+	start := 0
+	for _, end := range ends {
+		if end > len(b.Buf) {
+			// Buffer was reset after packet was encoded.
+			break
+		}
+		proto.PutChunked(out, b.Buf[start:end])
+		start = end
+	}
+	if start < len(b.Buf) {
+		proto.PutChunked(out, b.Buf[start:])
+	}
+	return out.Buf
+}
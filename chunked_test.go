@@ -0,0 +1,56 @@
+package ch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_frame(t *testing.T) {
+	c := &Client{buf: new(proto.Buffer), chunkedSend: true}
+	c.buf.PutString("first")
+	c.endPacket()
+	c.buf.PutString("second")
+	c.endPacket()
+	c.buf.PutString("third")
+
+	r := proto.NewChunkedReader(bytes.NewReader(c.frame(c.buf)))
+	r.Enable()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, c.buf.Buf, data)
+	require.Empty(t, c.packets)
+
+	// Each packet is terminated by zero chunk.
+	var b proto.Buffer
+	b.PutString("first")
+	framed := c.frame(&b)
+	require.Len(t, framed, len(b.Buf)+8)
+	require.Equal(t, make([]byte, 4), framed[len(framed)-4:])
+}
+
+func TestClient_chunked(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		ChunkedSend: proto.ChunkedOptional,
+		ChunkedRecv: proto.ChunkedOptional,
+	})
+	if !conn.ServerInfo().Has(proto.FeatureChunkedPackets) {
+		t.Skip("Chunked packets are not supported")
+	}
+	require.True(t, conn.chunkedSend)
+
+	var data proto.ColUInt64
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT number FROM system.numbers LIMIT 10",
+		Result: proto.Results{{Name: "number", Data: &data}},
+	}))
+	require.Equal(t, 10, data.Rows())
+	require.NoError(t, conn.Ping(ctx))
+}
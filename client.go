@@ -2,10 +2,15 @@ package ch
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
+	"os"
+	"os/user"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +21,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/ClickHouse/ch-go/compress"
@@ -26,25 +32,65 @@ import (
 
 // Client implements ClickHouse binary protocol client on top of
 // single TCP connection.
+//
+// Client is safe for concurrent use: Do and Ping calls are serialized,
+// waiting in FIFO order until connection is free or context is done.
+// Use chpool to execute queries in parallel.
 type Client struct {
 	lg       *zap.Logger
+	addr     string
 	conn     net.Conn
 	buf      *proto.Buffer
 	reader   *proto.Reader
 	info     proto.ClientHello
 	server   proto.ServerHello
-	version  clientVersion
+	version  ClientVersion
 	quotaKey string
+	// Substitute parameters on client side, see Options.BindParameters.
+	bindParameters bool
+	// Inter-server cluster secret, see Options.ClusterSecret.
+	clusterSecret string
+	osUser        string
+	hostname      string
+
+	// Default location for DateTime values without explicit time zone.
+	location *time.Location
+	// Location is set by Options and not changed by server time zone.
+	fixedLocation bool
 
 	mux    sync.Mutex
 	closed bool
 
+	// Exclusive use of connection by single query, see acquire.
+	sem chan struct{}
+
+	// Active transaction, see Begin.
+	tx *Tx
+	// Retry of first query, see Options.WakeTimeout. Guarded by mux.
+	wake *wakeRetry
+
+	// Index of block of current query being decoded, see
+	// CorruptedDataErr.
+	queryBlock int
+	// Hook of checksum mismatch, see Options.OnCorruptedData.
+	onCorrupted func(err *CorruptedDataErr)
+
 	// Single packet read timeout.
 	readTimeout time.Duration
-
-	otel   bool
-	tracer trace.Tracer
-	meter  metric.Meter
+	// Timeouts of current query, see Query.ReadTimeout.
+	queryReadTimeout time.Duration
+	writeTimeout     time.Duration
+	// Default of Query.StallTimeout.
+	defaultStallTimeout time.Duration
+	// Set by watchStall if current query is stalled.
+	stalled atomic.Bool
+
+	otel    bool
+	events  bool // record span events
+	tracer  trace.Tracer
+	meter   metric.Meter
+	metrics *clientMetrics
+	stats   *clientStats
 
 	// TCP Binary protocol version.
 	protocolVersion int
@@ -56,6 +102,26 @@ type Client struct {
 	compressionMethod compress.Method
 
 	settings []Setting
+	// Settings of resource guards from Options, which are sent after all
+	// other settings, so can't be overridden by query.
+	guards []proto.Setting
+	// Derive execution time of query from context deadline, see
+	// Options.DeadlineExecutionTime.
+	deadlineExecutionTime bool
+	maxExecutionTime      time.Duration
+	// Budget of result memory, see Options.MaxResultMemory.
+	maxResultMemory int
+
+	// Chunked packets framing, see chunked.go.
+	chunked       *proto.ChunkedReader
+	chunkedSend   bool
+	chunkedModes  [2]proto.ChunkedMode // send, recv
+	packets       []int                // end offsets of packets in buf
+	chunkedBuffer proto.Buffer
+
+	interceptor  QueryInterceptor
+	onQueryStart OnQueryStart
+	onQueryEnd   OnQueryEnd
 }
 
 // Setting to send to server.
@@ -73,9 +139,24 @@ func SettingInt(k string, v int) Setting {
 	}
 }
 
-// ServerInfo returns server information.
+// ServerInfo returns server information from handshake: name, version,
+// revision, time zone and display name.
+//
+// See ProtocolVersion for negotiated protocol version.
 func (c *Client) ServerInfo() proto.ServerHello { return c.server }
 
+// Addr returns address of server that client is connected to, i.e.
+// Options.Address or address from Options.Endpoints.
+func (c *Client) Addr() string { return c.addr }
+
+// ProtocolVersion returns negotiated protocol version, which is the
+// minimum of client and server revisions.
+func (c *Client) ProtocolVersion() int { return c.protocolVersion }
+
+// Supports reports whether feature is available in negotiated protocol
+// version, i.e. is supported by both client and server.
+func (c *Client) Supports(f proto.Feature) bool { return f.In(c.protocolVersion) }
+
 // ErrClosed means that client was already closed.
 var ErrClosed = errors.New("client is closed")
 
@@ -104,6 +185,36 @@ func (c *Client) IsClosed() bool {
 	return c.closed
 }
 
+// ErrNestedQuery means that Do was called from callback of query that is
+// running on the same Client, which would wait for itself forever.
+var ErrNestedQuery = errors.New("nested query on the same client")
+
+// runningQueryKey is context key of Client that executes query.
+type runningQueryKey struct{}
+
+// acquire waits until connection is not used by other query or ping.
+//
+// Waiting goroutines acquire connection in FIFO order, as blocked senders
+// of channel are queued.
+func (c *Client) acquire(ctx context.Context) error {
+	if ctx.Value(runningQueryKey{}) == c {
+		return ErrNestedQuery
+	}
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "wait for connection")
+	}
+}
+
+// release makes connection available for next query.
+func (c *Client) release() {
+	c.stats.setState(ConnIdle)
+	c.stats.queryID.Store("")
+	<-c.sem
+}
+
 // Exception is server-side error.
 type Exception struct {
 	Code    proto.Error
@@ -213,6 +324,12 @@ func (c *Client) profile() (proto.Profile, error) {
 // packet reads server code.
 func (c *Client) packet(ctx context.Context) (proto.ServerCode, error) {
 	timeout := c.readTimeout
+	switch {
+	case c.queryReadTimeout < 0:
+		timeout = 0
+	case c.queryReadTimeout > 0:
+		timeout = c.queryReadTimeout
+	}
 	var deadline time.Time
 	if timeout > 0 {
 		deadline = time.Now().Add(timeout)
@@ -232,8 +349,16 @@ func (c *Client) packet(ctx context.Context) (proto.ServerCode, error) {
 		defer func() {
 			// Reset deadline.
 			_ = c.conn.SetReadDeadline(time.Time{})
+			if c.stalled.Load() {
+				// Stall watchdog could set its deadline before reset, so
+				// restoring it to interrupt following reads of block.
+				_ = c.conn.SetReadDeadline(time.Now())
+			}
 		}()
 	}
+	if err := c.stallErr(); err != nil {
+		return 0, err
+	}
 
 	n, err := c.reader.UVarInt()
 	if err != nil {
@@ -248,12 +373,23 @@ func (c *Client) packet(ctx context.Context) (proto.ServerCode, error) {
 		)
 	}
 	if !code.IsAServerCode() {
-		return 0, errors.Errorf("bad server packet type %d", n)
+		return 0, errors.Wrapf(ErrProtocol, "bad server packet type %d", n)
 	}
 
 	return code, nil
 }
 
+// writeDeadline returns earliest of context deadline and write timeout.
+func (c *Client) writeDeadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+	if c.writeTimeout > 0 {
+		if d := time.Now().Add(c.writeTimeout); !ok || d.Before(deadline) {
+			return d, true
+		}
+	}
+	return deadline, ok
+}
+
 func (c *Client) flushBuf(ctx context.Context, b *proto.Buffer) error {
 	if err := ctx.Err(); err != nil {
 		return errors.Wrap(err, "context")
@@ -262,23 +398,28 @@ func (c *Client) flushBuf(ctx context.Context, b *proto.Buffer) error {
 		// Nothing to flush.
 		return nil
 	}
-	if deadline, ok := ctx.Deadline(); ok {
+	if deadline, ok := c.writeDeadline(ctx); ok {
 		if err := c.conn.SetWriteDeadline(deadline); err != nil {
 			return errors.Wrap(err, "set write deadline")
 		}
 		// Reset deadline.
 		defer func() { _ = c.conn.SetWriteDeadline(time.Time{}) }()
 	}
-	n, err := c.conn.Write(b.Buf)
+	data := b.Buf
+	if c.chunkedSend {
+		data = c.frame(b)
+	}
+	n, err := c.conn.Write(data)
 	if err != nil {
 		return errors.Wrap(err, "write")
 	}
-	if n != len(b.Buf) {
+	if n != len(data) {
 		return errors.Wrap(io.ErrShortWrite, "wrote less than expected")
 	}
 	if ce := c.lg.Check(zap.DebugLevel, "Flush"); ce != nil {
 		ce.Write(zap.Int("bytes", n))
 	}
+	c.stats.bytesSent.Add(uint64(n))
 	b.Reset()
 	return nil
 }
@@ -311,6 +452,19 @@ const (
 	CompressionLZ4HC
 )
 
+// ChecksumPolicy of compressed data verification, see
+// Options.ChecksumPolicy.
+type ChecksumPolicy = compress.ChecksumPolicy
+
+const (
+	// ChecksumStrict fails query with *CorruptedDataErr on mismatch.
+	ChecksumStrict = compress.ChecksumStrict
+	// ChecksumWarn logs mismatch and continues.
+	ChecksumWarn = compress.ChecksumWarn
+	// ChecksumSkip does not verify checksums.
+	ChecksumSkip = compress.ChecksumSkip
+)
+
 // CompressionLevel setting. A level == 0 is invalid and resolves to the default.
 //
 // Supported by: LZ4HC.
@@ -319,16 +473,113 @@ type CompressionLevel uint32
 // Options for Client. Zero value is valid.
 type Options struct {
 	Logger           *zap.Logger      // defaults to Nop.
+	SlogLogger       *slog.Logger     // alternative to Logger, used if Logger is nil
 	Address          string           // 127.0.0.1:9000
 	Database         string           // "default"
 	User             string           // "default"
 	Password         string           // blank string by default
-	QuotaKey         string           // blank string by default
+	QuotaKey         string           // blank string by default, also default for Query.QuotaKey
 	Compression      Compression      // disabled by default
 	CompressionLevel CompressionLevel // compression algorithm specific default
-	ClientName       string           // blank string by default
+	ClientName       string           // blank string by default, appended to "ch-go"
+	ClientVersion    ClientVersion    // overrides reported client name and version, ch-go by default
+	OSUser           string           // current OS user by default
+	ClientHostname   string           // os.Hostname() by default
 	Settings         []Setting        // none by default
 
+	// ChunkedSend and ChunkedRecv are modes of chunked packets framing
+	// for sending and receiving, negotiated with server during handshake.
+	//
+	// Default to proto.NotChunkedOptional, so chunked framing is used only
+	// if required by server.
+	ChunkedSend proto.ChunkedMode
+	ChunkedRecv proto.ChunkedMode
+
+	// ClusterSecret is inter-server secret of Cluster, which is used
+	// instead of User and Password to connect as another server of cluster,
+	// e.g. to execute Distributed queries on behalf of Query.InitialUser.
+	//
+	// Queries are authenticated by hash of secret with random salt and
+	// server nonce, if supported.
+	//
+	// See https://clickhouse.com/docs/en/engines/table-engines/special/distributed/#distributed-clusters
+	ClusterSecret string
+	Cluster       string
+
+	// BindParameters enables substitution of query parameters on client
+	// side by BindParameters if server does not support them, so the same
+	// parameterized queries can be used with older servers.
+	BindParameters bool
+
+	// Roles to activate for session instead of default roles of User,
+	// set before connection is returned or OnConnect is called.
+	Roles []string
+
+	// OnConnect is optional hook called after handshake to initialize
+	// connection, e.g. by SET ROLE or USE statements, see OnConnectExec.
+	//
+	// Connection is closed if hook fails. Also called for each connection
+	// of chpool.
+	OnConnect func(ctx context.Context, c *Client) error
+
+	// ReadOnly forbids queries that change data or schema, like readonly=2
+	// setting, while query settings can still be changed.
+	ReadOnly bool
+	// MaxMemoryUsage limits memory usage of single query in bytes, optional.
+	MaxMemoryUsage uint64
+	// MaxExecutionTime limits execution time of single query, optional.
+	MaxExecutionTime time.Duration
+	// DeadlineExecutionTime sets max_execution_time and
+	// timeout_before_checking_execution_speed of query to time left until
+	// context deadline, so server stops query when client gives up.
+	//
+	// Explicit max_execution_time of Query.Settings takes precedence, and
+	// MaxExecutionTime is used if it is shorter.
+	DeadlineExecutionTime bool
+	// MaxResultMemory limits bytes retained by client while receiving
+	// result of single query, i.e. read buffers and decoded block, optional.
+	//
+	// This is a limit on accumulated result that is checked after each
+	// block is decoded, not a memory cap: single large block is decoded
+	// into memory before query is canceled with *ResultLimitError. Use
+	// max_block_size setting or DecodeLimits to bound size of block.
+	// Includes read buffer of 128KB. Read buffers are released to pool
+	// after each query, so idle connections do not retain memory of large
+	// results.
+	MaxResultMemory int
+	// DecodeLimits are limits of sizes decoded from server data, like
+	// string lengths or rows count, protecting from huge allocations on
+	// corrupted or hostile data. Zero fields are proto defaults.
+	DecodeLimits proto.Limits
+	// ChecksumPolicy of compressed data received from server, defaults to
+	// ChecksumStrict, i.e. query fails with *CorruptedDataErr on mismatch.
+	//
+	// ChecksumWarn logs mismatch, calls OnCorruptedData and continues,
+	// ChecksumSkip does not verify checksums at all, e.g. for trusted links.
+	ChecksumPolicy ChecksumPolicy
+	// OnCorruptedData is optional hook called on checksum mismatch with
+	// ChecksumWarn policy.
+	OnCorruptedData func(err *CorruptedDataErr)
+
+	// Location is used for DateTime and DateTime64 result values if column
+	// type has no explicit time zone.
+	//
+	// Defaults to server time zone. Set to time.UTC to force UTC.
+	Location *time.Location
+
+	// QueryInterceptor wraps every Do call, optional.
+	//
+	// Use ChainQueryInterceptors to compose multiple interceptors.
+	QueryInterceptor QueryInterceptor
+
+	// OnQueryStart and OnQueryEnd are optional hooks called on start and
+	// end of each query, e.g. for slow query logging.
+	OnQueryStart OnQueryStart
+	OnQueryEnd   OnQueryEnd
+
+	// StallTimeout is default of Query.StallTimeout, disabled by default.
+	StallTimeout time.Duration
+
 	// ReadTimeout is a timeout for reading a single packet from the server.
 	//
 	// Defaults to 3s. No timeout if negative (you can use NoTimeout const).
@@ -341,13 +592,43 @@ type Options struct {
 	ProtocolVersion  int           // force protocol version, optional
 	HandshakeTimeout time.Duration // longer lasting handshake is a case for ClickHouse cloud idle instances, defaults to 5m
 
+	// Endpoints are resolved server addresses to dial instead of Address,
+	// trying next address on network error, e.g. from DNS SRV record, see
+	// NewEndpoints. Share between clients to spread connections.
+	Endpoints *Endpoints
+
+	// WakeTimeout enables retrying Dial on network errors for up to
+	// WakeTimeout with exponential backoff, disabled by default.
+	//
+	// Suspended ClickHouse Cloud service refuses, resets or times out
+	// connections while waking up, so serverless users can set it instead
+	// of retrying every call site. Dial and handshake of each attempt are
+	// limited by time left of WakeTimeout, even if DialTimeout or
+	// HandshakeTimeout are longer.
+	//
+	// First Do call is retried on new connection too, if it fails with
+	// network error before anything is received from server, so query
+	// was not executed. Queries with OnInput, OnRawInput or BodyReader are
+	// not retried, as their input is consumed. Next queries are not
+	// retried, as server is awake after first one.
+	WakeTimeout time.Duration
+
 	// Additional OpenTelemetry instrumentation that will capture query body
 	// and other parameters.
 	//
 	// Note: OpenTelemetry context propagation works without this option too.
 	OpenTelemetryInstrumentation bool
-	TracerProvider               trace.TracerProvider
-	MeterProvider                metric.MeterProvider
+	// OpenTelemetrySpanEvents enables recording of progress and profile
+	// packets as query span events, showing when query produced data.
+	//
+	// Requires OpenTelemetryInstrumentation.
+	OpenTelemetrySpanEvents bool
+	TracerProvider          trace.TracerProvider
+	// MeterProvider is used to record query metrics, like duration,
+	// processed rows and bytes, active queries and errors.
+	//
+	// Defaults to global MeterProvider.
+	MeterProvider metric.MeterProvider
 
 	meter  metric.Meter
 	tracer trace.Tracer
@@ -377,9 +658,23 @@ func (o *Options) setDefaults() {
 	if o.Database == "" {
 		o.Database = DefaultDatabase
 	}
+	if o.OSUser == "" {
+		// Best effort, like clickhouse-client.
+		if u, err := user.Current(); err == nil {
+			o.OSUser = u.Username
+		}
+	}
+	if o.ClientHostname == "" {
+		if h, err := os.Hostname(); err == nil {
+			o.ClientHostname = h
+		}
+	}
 	if o.User == "" {
 		o.User = DefaultUser
 	}
+	if o.Logger == nil && o.SlogLogger != nil {
+		o.Logger = NewSlogLogger(o.SlogLogger)
+	}
 	if o.Logger == nil {
 		o.Logger = zap.NewNop()
 	}
@@ -411,38 +706,93 @@ func (o *Options) setDefaults() {
 	if o.ReadTimeout == 0 {
 		o.ReadTimeout = DefaultReadTimeout
 	}
+	if o.ChunkedSend == "" {
+		o.ChunkedSend = proto.NotChunkedOptional
+	}
+	if o.ChunkedRecv == "" {
+		o.ChunkedRecv = proto.NotChunkedOptional
+	}
 	if o.ReadTimeout < 0 || o.ReadTimeout == NoTimeout {
 		o.ReadTimeout = 0
 	}
 }
 
-type clientVersion struct {
+// guardSettings returns settings for ReadOnly, MaxMemoryUsage and
+// MaxExecutionTime options.
+func (o Options) guardSettings() []proto.Setting {
+	var settings []Setting
+	if o.ReadOnly {
+		settings = append(settings, SettingReadonly(2))
+	}
+	if o.MaxMemoryUsage > 0 {
+		settings = append(settings, SettingMaxMemoryUsage(o.MaxMemoryUsage))
+	}
+	if o.MaxExecutionTime > 0 {
+		settings = append(settings, SettingMaxExecutionTime(o.MaxExecutionTime))
+	}
+	var result []proto.Setting
+	for _, s := range settings {
+		result = append(result, proto.Setting{
+			Key:       s.Key,
+			Value:     s.Value,
+			Important: true,
+		})
+	}
+	return result
+}
+
+// ClientVersion is client name and version reported to server in
+// Hello and ClientInfo packets.
+type ClientVersion struct {
 	Name  string
 	Major int
 	Minor int
 	Patch int
 }
 
-// Connect performs handshake with ClickHouse server and initializes
-// application level connection.
-func Connect(ctx context.Context, conn net.Conn, opt Options) (*Client, error) {
-	opt.setDefaults()
-
+// clientVersion returns client version to report to server.
+func (o Options) clientVersion() ClientVersion {
 	clientName := proto.Name
 	pkg := pkgVersion.Get()
-	if opt.ClientName == "" {
+	if opt := o.ClientVersion; opt.Name != "" {
+		clientName = opt.Name
+	} else if o.ClientName == "" {
 		if pkg.Name != "" {
 			clientName = fmt.Sprintf("%s (%s)", clientName, pkg.Name)
 		}
 	} else {
-		clientName = fmt.Sprintf("%s %s", clientName, opt.ClientName)
+		clientName = fmt.Sprintf("%s %s", clientName, o.ClientName)
 	}
-	ver := clientVersion{
+	ver := ClientVersion{
 		Name:  clientName,
 		Major: pkg.Major,
 		Minor: pkg.Minor,
 		Patch: pkg.Patch,
 	}
+	if opt := o.ClientVersion; opt.Major != 0 || opt.Minor != 0 || opt.Patch != 0 {
+		ver.Major = opt.Major
+		ver.Minor = opt.Minor
+		ver.Patch = opt.Patch
+	}
+	return ver
+}
+
+// Connect performs handshake with ClickHouse server and initializes
+// application level connection.
+func Connect(ctx context.Context, conn net.Conn, opt Options) (*Client, error) {
+	return connect(ctx, conn, opt, new(clientStats))
+}
+
+// connect is Connect that counts statistics to stats, so connection of
+// Client can be replaced, see wakeRetry.
+func connect(ctx context.Context, conn net.Conn, opt Options, stats *clientStats) (*Client, error) {
+	opt.setDefaults()
+	if err := validateSettings(opt.Settings); err != nil {
+		return nil, errors.Wrap(err, "settings")
+	}
+
+	ver := opt.clientVersion()
+	clientName := ver.Name
 
 	if opt.OpenTelemetryInstrumentation {
 		newCtx, span := opt.tracer.Start(ctx, "Connect",
@@ -454,18 +804,41 @@ func Connect(ctx context.Context, conn net.Conn, opt Options) (*Client, error) {
 		ctx = newCtx
 		defer span.End()
 	}
+	chunked := proto.NewChunkedReader(countingReader{r: conn, n: &stats.bytesReceived})
+	reader := proto.NewReader(chunked)
+	reader.SetLimits(opt.DecodeLimits)
 	c := &Client{
+		sem:      make(chan struct{}, 1),
+		addr:     opt.Address,
 		conn:     conn,
 		buf:      new(proto.Buffer),
-		reader:   proto.NewReader(conn),
+		reader:   reader,
+		chunked:  chunked,
+		stats:    stats,
 		settings: opt.Settings,
 		lg:       opt.Logger,
 		otel:     opt.OpenTelemetryInstrumentation,
+		events:   opt.OpenTelemetryInstrumentation && opt.OpenTelemetrySpanEvents,
 		tracer:   opt.tracer,
 		meter:    opt.meter,
 		quotaKey: opt.QuotaKey,
 
-		readTimeout: opt.ReadTimeout,
+		clusterSecret:  opt.ClusterSecret,
+		bindParameters: opt.BindParameters,
+		osUser:         opt.OSUser,
+		hostname:       opt.ClientHostname,
+		location:       opt.Location,
+
+		fixedLocation: opt.Location != nil,
+
+		chunkedModes: [2]proto.ChunkedMode{opt.ChunkedSend, opt.ChunkedRecv},
+
+		interceptor:  opt.QueryInterceptor,
+		onQueryStart: opt.OnQueryStart,
+		onQueryEnd:   opt.OnQueryEnd,
+
+		readTimeout:         opt.ReadTimeout,
+		defaultStallTimeout: opt.StallTimeout,
 
 		compressor: compress.NewWriterWithLevel(compress.Level(opt.CompressionLevel)),
 
@@ -483,6 +856,16 @@ func Connect(ctx context.Context, conn net.Conn, opt Options) (*Client, error) {
 			Password: opt.Password,
 		},
 	}
+	if opt.ClusterSecret != "" {
+		salt := make([]byte, sha256.Size)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, errors.Wrap(err, "salt")
+		}
+		c.info.User = proto.InterserverUser
+		c.info.Password = ""
+		c.info.Cluster = opt.Cluster
+		c.info.Salt = string(salt)
+	}
 	switch opt.Compression {
 	case CompressionLZ4:
 		c.compression = proto.CompressionEnabled
@@ -500,11 +883,37 @@ func Connect(ctx context.Context, conn net.Conn, opt Options) (*Client, error) {
 		c.compression = proto.CompressionDisabled
 	}
 
+	c.guards = opt.guardSettings()
+	c.deadlineExecutionTime = opt.DeadlineExecutionTime
+	c.maxExecutionTime = opt.MaxExecutionTime
+	c.maxResultMemory = opt.MaxResultMemory
+	c.onCorrupted = opt.OnCorruptedData
+	c.reader.SetChecksumPolicy(opt.ChecksumPolicy, c.onCorruptedData)
+
+	metrics, err := newClientMetrics(opt.meter)
+	if err != nil {
+		return nil, errors.Wrap(err, "metrics")
+	}
+	c.metrics = metrics
+
 	handshakeCtx, cancel := context.WithTimeout(ctx, opt.HandshakeTimeout)
 	defer cancel()
 	if err := c.handshake(handshakeCtx); err != nil {
 		return nil, errors.Wrap(err, "handshake")
 	}
+	c.stats.setState(ConnIdle)
+	if len(opt.Roles) > 0 {
+		if err := c.setRoles(ctx, opt.Roles); err != nil {
+			_ = c.Close()
+			return nil, errors.Wrap(err, "roles")
+		}
+	}
+	if f := opt.OnConnect; f != nil {
+		if err := f(ctx, c); err != nil {
+			_ = c.Close()
+			return nil, errors.Wrap(err, "on connect")
+		}
+	}
 
 	return c, nil
 }
@@ -549,15 +958,49 @@ func Dial(ctx context.Context, opt Options) (c *Client, err error) {
 		}
 	}
 
-	conn, err := opt.Dialer.DialContext(ctx, "tcp", opt.Address)
-	if err != nil {
-		return nil, errors.Wrap(err, "dial")
+	// Dial and handshake are limited by deadline, if not zero.
+	stats := new(clientStats)
+	dial := func(ctx context.Context, deadline time.Time) (*Client, error) {
+		dialAddr := func(addr string) (*Client, error) {
+			addrOpt := opt
+			addrOpt.Address = addr
+			dialCtx := ctx
+			if !deadline.IsZero() {
+				var cancel context.CancelFunc
+				dialCtx, cancel = context.WithDeadline(ctx, deadline)
+				defer cancel()
+				if left := time.Until(deadline); left < addrOpt.HandshakeTimeout {
+					addrOpt.HandshakeTimeout = left
+				}
+			}
+			conn, err := opt.Dialer.DialContext(dialCtx, "tcp", addr)
+			if err != nil {
+				return nil, errors.Wrap(err, "dial")
+			}
+			client, err := connect(ctx, conn, addrOpt, stats)
+			if err != nil {
+				_ = conn.Close()
+				return nil, errors.Wrap(err, "connect")
+			}
+			return client, nil
+		}
+		if opt.Endpoints != nil {
+			return dialEndpoints(ctx, opt.Endpoints, dialAddr)
+		}
+		return dialAddr(opt.Address)
 	}
-
-	client, err := Connect(ctx, conn, opt)
-	if err != nil {
-		return nil, errors.Wrap(err, "connect")
+	if opt.WakeTimeout > 0 {
+		c, err := dialWake(ctx, opt.WakeTimeout, opt.Logger, dial)
+		if err != nil {
+			return nil, err
+		}
+		c.wake = &wakeRetry{
+			deadline: time.Now().Add(opt.WakeTimeout),
+			redial: func(ctx context.Context, timeout time.Duration) (*Client, error) {
+				return dialWake(ctx, timeout, opt.Logger, dial)
+			},
+		}
+		return c, nil
 	}
-
-	return client, nil
+	return dial(ctx, time.Time{})
 }
@@ -0,0 +1,105 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_acquire(t *testing.T) {
+	ctx := context.Background()
+	c := &Client{sem: make(chan struct{}, 1), stats: new(clientStats)}
+
+	t.Run("Context", func(t *testing.T) {
+		require.NoError(t, c.acquire(ctx))
+		defer c.release()
+
+		waitCtx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+		defer cancel()
+		require.ErrorIs(t, c.acquire(waitCtx), context.DeadlineExceeded)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		nestedCtx := context.WithValue(ctx, runningQueryKey{}, c)
+		require.ErrorIs(t, c.acquire(nestedCtx), ErrNestedQuery)
+
+		// Other client is not affected.
+		other := &Client{sem: make(chan struct{}, 1), stats: new(clientStats)}
+		require.NoError(t, other.acquire(nestedCtx))
+		other.release()
+	})
+	t.Run("FIFO", func(t *testing.T) {
+		require.NoError(t, c.acquire(ctx))
+
+		const waiters = 5
+		var (
+			mux   sync.Mutex
+			order []int
+			wg    sync.WaitGroup
+		)
+		for i := 0; i < waiters; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if err := c.acquire(ctx); err != nil {
+					panic(err)
+				}
+				mux.Lock()
+				order = append(order, i)
+				mux.Unlock()
+				c.release()
+			}(i)
+			// Let goroutine block on send before next one.
+			time.Sleep(time.Millisecond * 10)
+		}
+		c.release()
+		wg.Wait()
+		require.Equal(t, []int{0, 1, 2, 3, 4}, order)
+	})
+}
+
+func TestClient_Do_concurrent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func() error {
+			var data proto.ColUInt64
+			if err := conn.Do(ctx, Query{
+				Body:   fmt.Sprintf("SELECT number FROM system.numbers LIMIT %d", i+1),
+				Result: proto.Results{{Name: "number", Data: &data}},
+			}); err != nil {
+				return err
+			}
+			if data.Rows() != i+1 {
+				return fmt.Errorf("got %d rows instead of %d", data.Rows(), i+1)
+			}
+			return nil
+		})
+		g.Go(func() error {
+			return conn.Ping(ctx)
+		})
+	}
+	require.NoError(t, g.Wait())
+
+	t.Run("Nested", func(t *testing.T) {
+		var data proto.ColUInt8
+		err := conn.Do(context.Background(), Query{
+			Body:   "SELECT 1 AS v",
+			Result: proto.Results{{Name: "v", Data: &data}},
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				return conn.Ping(ctx)
+			},
+		})
+		require.ErrorIs(t, err, ErrNestedQuery)
+	})
+}
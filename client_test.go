@@ -3,7 +3,9 @@ package ch
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -87,3 +89,116 @@ func TestDial(t *testing.T) {
 		require.True(t, IsErr(err, proto.ErrUnknownDatabase))
 	})
 }
+
+func TestClient_clientInfo(t *testing.T) {
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		OSUser:         "ch-go-user",
+		ClientHostname: "ch-go-host",
+	})
+	var (
+		osUser   proto.ColStr
+		hostname proto.ColStr
+	)
+	queryID := "client-info"
+	require.NoError(t, conn.Do(ctx, Query{Body: "SELECT 1", QueryID: queryID, Result: discardResult()}))
+	require.NoError(t, conn.Do(ctx, Query{Body: "SYSTEM FLUSH LOGS"}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT os_user, client_hostname FROM system.query_log WHERE query_id = {id:String} LIMIT 1",
+		Parameters: Parameters(map[string]any{
+			"id": queryID,
+		}),
+		Result: proto.Results{
+			{Name: "os_user", Data: &osUser},
+			{Name: "client_hostname", Data: &hostname},
+		},
+	}))
+	require.Equal(t, "ch-go-user", osUser.Row(0))
+	require.Equal(t, "ch-go-host", hostname.Row(0))
+}
+
+func TestOptions_setDefaults(t *testing.T) {
+	var opt Options
+	opt.setDefaults()
+	if h, err := os.Hostname(); err == nil {
+		require.Equal(t, h, opt.ClientHostname)
+	}
+
+	opt = Options{OSUser: "user", ClientHostname: "host"}
+	opt.setDefaults()
+	require.Equal(t, "user", opt.OSUser)
+	require.Equal(t, "host", opt.ClientHostname)
+}
+
+func TestOptions_clientVersion(t *testing.T) {
+	v := Options{}.clientVersion()
+	require.True(t, strings.HasPrefix(v.Name, proto.Name), v.Name)
+
+	v = Options{ClientName: "app"}.clientVersion()
+	require.Equal(t, proto.Name+" app", v.Name)
+
+	v = Options{
+		ClientName: "ignored",
+		ClientVersion: ClientVersion{
+			Name:  "my-tool",
+			Major: 1,
+			Minor: 2,
+			Patch: 3,
+		},
+	}.clientVersion()
+	require.Equal(t, ClientVersion{Name: "my-tool", Major: 1, Minor: 2, Patch: 3}, v)
+}
+
+func TestClient_Supports(t *testing.T) {
+	c := &Client{protocolVersion: int(proto.FeatureParameters)}
+	require.True(t, c.Supports(proto.FeatureBlockInfo))
+	require.True(t, c.Supports(proto.FeatureParameters))
+	require.False(t, c.Supports(proto.FeatureServerQueryTimeInProgress))
+}
+
+func TestOptions_guardSettings(t *testing.T) {
+	require.Empty(t, Options{}.guardSettings())
+	c := &Client{guards: Options{
+		ReadOnly:         true,
+		MaxMemoryUsage:   1 << 30,
+		MaxExecutionTime: time.Minute,
+	}.guardSettings()}
+	settings := c.querySettings(Query{Settings: []Setting{
+		SettingReadonly(0),
+		SettingMaxMemoryUsage(1 << 40),
+	}})
+	// Guards are last to take precedence.
+	require.Equal(t, []proto.Setting{
+		{Key: "readonly", Value: "0", Important: true},
+		{Key: "max_memory_usage", Value: "1099511627776", Important: true},
+		{Key: "readonly", Value: "2", Important: true},
+		{Key: "max_memory_usage", Value: "1073741824", Important: true},
+		{Key: "max_execution_time", Value: "60", Important: true},
+	}, settings)
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{ReadOnly: true})
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT 1",
+		Result: discardResult(),
+	}))
+	err := conn.Do(ctx, Query{
+		Body: "CREATE TABLE read_only_test (v UInt8) ENGINE = Memory",
+	})
+	require.True(t, IsErr(err, proto.ErrReadonly), "%v", err)
+}
+
+func TestClient_Do_quotaKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{QuotaKey: "tenant"})
+	var key proto.ColStr
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT quota_key FROM system.processes WHERE query_id = queryID()",
+		Result: proto.Results{{Name: "quota_key", Data: &key}},
+	}))
+	require.Equal(t, "tenant", key.Row(0))
+}
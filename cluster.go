@@ -0,0 +1,136 @@
+package ch
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ErrClusterNotFound means that cluster is not found in system.clusters.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// ClusterInfo is topology of cluster, see Cluster.
+type ClusterInfo struct {
+	Name   string
+	Shards []ClusterShard // in order of shard_num
+}
+
+// ClusterShard is shard of cluster.
+type ClusterShard struct {
+	Num      int // shard_num, starting from 1
+	Weight   int // shard_weight
+	Replicas []ClusterReplica
+}
+
+// ClusterReplica is replica of cluster shard.
+type ClusterReplica struct {
+	ShardNum int
+	Num      int    // replica_num, starting from 1
+	Host     string // host_name
+	Address  string // host_address, resolved IP of host
+	Port     uint16 // native protocol port
+	IsLocal  bool   // replica is the server that was queried
+	User     string // user of connections between servers
+	Database string // default_database, can be empty
+
+	// Errors and EstimatedRecovery are tracked by server for load
+	// balancing between replicas.
+	Errors            int
+	EstimatedRecovery int // seconds
+}
+
+// Addr returns address of replica native protocol endpoint.
+func (r ClusterReplica) Addr() string {
+	return net.JoinHostPort(r.Host, strconv.Itoa(int(r.Port)))
+}
+
+// Options returns client options to connect to replica, based on base.
+//
+// Address is set to replica address, and Database to default database of
+// replica if not set in base. Credentials are not available in
+// system.clusters, so they are kept from base.
+func (r ClusterReplica) Options(base Options) Options {
+	base.Address = r.Addr()
+	if base.Database == "" && r.Database != "" {
+		base.Database = r.Database
+	}
+	return base
+}
+
+// Replicas returns replicas of all shards.
+func (i *ClusterInfo) Replicas() []ClusterReplica {
+	var replicas []ClusterReplica
+	for _, s := range i.Shards {
+		replicas = append(replicas, s.Replicas...)
+	}
+	return replicas
+}
+
+// Cluster returns topology of cluster with name from system.clusters of
+// server that client is connected to.
+//
+// Returns ErrClusterNotFound if there is no such cluster.
+func Cluster(ctx context.Context, c *Client, name string) (*ClusterInfo, error) {
+	var (
+		shardNum, shardWeight, replicaNum proto.ColUInt32
+		host, address, user, database     proto.ColStr
+		port                              proto.ColUInt16
+		isLocal                           proto.ColUInt8
+		errorsCount, recovery             proto.ColUInt32
+
+		info = &ClusterInfo{Name: name}
+	)
+	if err := c.Do(ctx, Query{
+		Body: "SELECT shard_num, shard_weight, replica_num, host_name, host_address, port, is_local, " +
+			"user, default_database, errors_count, estimated_recovery_time FROM system.clusters " +
+			"WHERE cluster = " + string(proto.LitString(name)) + " ORDER BY shard_num, replica_num",
+		Result: proto.Results{
+			{Name: "shard_num", Data: &shardNum},
+			{Name: "shard_weight", Data: &shardWeight},
+			{Name: "replica_num", Data: &replicaNum},
+			{Name: "host_name", Data: &host},
+			{Name: "host_address", Data: &address},
+			{Name: "port", Data: &port},
+			{Name: "is_local", Data: &isLocal},
+			{Name: "user", Data: &user},
+			{Name: "default_database", Data: &database},
+			{Name: "errors_count", Data: &errorsCount},
+			{Name: "estimated_recovery_time", Data: &recovery},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			for i := 0; i < shardNum.Rows(); i++ {
+				r := ClusterReplica{
+					ShardNum:          int(shardNum.Row(i)),
+					Num:               int(replicaNum.Row(i)),
+					Host:              host.Row(i),
+					Address:           address.Row(i),
+					Port:              port.Row(i),
+					IsLocal:           isLocal.Row(i) == 1,
+					User:              user.Row(i),
+					Database:          database.Row(i),
+					Errors:            int(errorsCount.Row(i)),
+					EstimatedRecovery: int(recovery.Row(i)),
+				}
+				if n := len(info.Shards); n == 0 || info.Shards[n-1].Num != r.ShardNum {
+					info.Shards = append(info.Shards, ClusterShard{
+						Num:    r.ShardNum,
+						Weight: int(shardWeight.Row(i)),
+					})
+				}
+				s := &info.Shards[len(info.Shards)-1]
+				s.Replicas = append(s.Replicas, r)
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "query")
+	}
+	if len(info.Shards) == 0 {
+		return nil, errors.Wrapf(ErrClusterNotFound, "%q", name)
+	}
+	return info, nil
+}
@@ -0,0 +1,47 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterReplica_Options(t *testing.T) {
+	r := ClusterReplica{Host: "::1", Port: 9000, Database: "db"}
+	require.Equal(t, "[::1]:9000", r.Addr())
+
+	opt := r.Options(Options{User: "u", Password: "p"})
+	require.Equal(t, Options{Address: "[::1]:9000", Database: "db", User: "u", Password: "p"}, opt)
+
+	opt = r.Options(Options{Database: "other"})
+	require.Equal(t, "other", opt.Database)
+}
+
+func TestCluster(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	_, err := Cluster(ctx, conn, "test_cluster_missing")
+	require.ErrorIs(t, err, ErrClusterNotFound)
+
+	// Defined in default server config.
+	info, err := Cluster(ctx, conn, "test_shard_localhost")
+	if err != nil {
+		t.Skipf("Cluster not configured: %v", err)
+	}
+	require.Len(t, info.Shards, 1)
+	require.Equal(t, 1, info.Shards[0].Num)
+	replicas := info.Replicas()
+	require.Len(t, replicas, 1)
+	require.Equal(t, "localhost", replicas[0].Host)
+
+	// Replica is reachable with options of connection.
+	replica, err := Dial(ctx, replicas[0].Options(Options{}))
+	if err != nil {
+		t.Skipf("Replica is not reachable: %v", err)
+	}
+	defer func() { _ = replica.Close() }()
+	require.NoError(t, replica.Ping(ctx))
+}
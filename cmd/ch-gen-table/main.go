@@ -0,0 +1,109 @@
+// Binary ch-gen-table generates typed Go binding of ClickHouse table: row
+// struct and columns that append rows to proto.Input and read them from
+// proto.Results.
+//
+// Table schema is read from live server by DESCRIBE TABLE or from file with
+// CREATE TABLE query:
+//
+//	ch-gen-table -table events -type Event -package model -output events_gen.go
+//	ch-gen-table -schema events.sql -type Event -package model -output events_gen.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/chschema"
+)
+
+const header = "// Code generated by ch-gen-table, DO NOT EDIT.\n\n"
+
+type config struct {
+	Schema  string
+	Table   string
+	Options ch.Options
+	Package string
+	Type    string
+	Output  string
+}
+
+// schema returns table name and schema from file or server.
+func schema(ctx context.Context, cfg config) (string, ch.TableSchema, error) {
+	if cfg.Schema != "" {
+		data, err := os.ReadFile(cfg.Schema)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "read")
+		}
+		table, s, err := chschema.ParseCreateTable(string(data))
+		if err != nil {
+			return "", nil, errors.Wrap(err, "parse")
+		}
+		if cfg.Table != "" {
+			table = cfg.Table
+		}
+		return table, s, nil
+	}
+	if cfg.Table == "" {
+		return "", nil, errors.New("table or schema file is required")
+	}
+	client, err := ch.Dial(ctx, cfg.Options)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "dial")
+	}
+	defer func() { _ = client.Close() }()
+	s, err := ch.DescribeTable(ctx, client, cfg.Table)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "describe")
+	}
+	return cfg.Table, s, nil
+}
+
+func run(ctx context.Context, cfg config) error {
+	if cfg.Type == "" {
+		return errors.New("type name is required")
+	}
+	table, s, err := schema(ctx, cfg)
+	if err != nil {
+		return errors.Wrap(err, "schema")
+	}
+	src, err := chschema.Binding(cfg.Package, cfg.Type, table, s)
+	if err != nil {
+		return errors.Wrap(err, "generate")
+	}
+	src = append([]byte(header), src...)
+	if cfg.Output == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	if err := os.WriteFile(cfg.Output, src, 0o600); err != nil {
+		return errors.Wrap(err, "write file")
+	}
+	return nil
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Schema, "schema", "", "path of file with CREATE TABLE query, instead of server")
+	flag.StringVar(&cfg.Table, "table", "", "table name, like db.events")
+	flag.StringVar(&cfg.Options.Address, "addr", "localhost:9000", "server address")
+	flag.StringVar(&cfg.Options.Database, "database", "default", "database")
+	flag.StringVar(&cfg.Options.User, "user", "default", "user")
+	flag.StringVar(&cfg.Options.Password, "password", "", "password")
+	flag.StringVar(&cfg.Package, "package", "main", "package name of generated code")
+	flag.StringVar(&cfg.Type, "type", "", "name of row type, like Event")
+	flag.StringVar(&cfg.Output, "output", "", "output file, stdout if empty")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := run(ctx, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %+v\n", err)
+		os.Exit(2)
+	}
+}
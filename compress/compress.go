@@ -56,12 +56,41 @@ const (
 	hMethod   = 16
 )
 
+// ChecksumPolicy configures verification of compressed blocks checksums.
+type ChecksumPolicy byte
+
+// Possible checksum policies.
+const (
+	// ChecksumStrict returns *CorruptedDataErr on checksum mismatch.
+	ChecksumStrict ChecksumPolicy = iota
+	// ChecksumWarn reports checksum mismatch to hook and decompresses
+	// block anyway.
+	ChecksumWarn
+	// ChecksumSkip does not verify checksums, e.g. for trusted links.
+	ChecksumSkip
+)
+
+// String implements fmt.Stringer.
+func (p ChecksumPolicy) String() string {
+	switch p {
+	case ChecksumStrict:
+		return "strict"
+	case ChecksumWarn:
+		return "warn"
+	case ChecksumSkip:
+		return "skip"
+	default:
+		return fmt.Sprintf("ChecksumPolicy(%d)", byte(p))
+	}
+}
+
 // CorruptedDataErr means that provided hash mismatch with calculated.
 type CorruptedDataErr struct {
 	Actual    city.U128
 	Reference city.U128
 	RawSize   int
 	DataSize  int
+	Block     int // index of compressed block read by Reader
 }
 
 func (c *CorruptedDataErr) Error() string {
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/go-faster/city"
 	"github.com/go-faster/errors"
@@ -19,6 +20,17 @@ type Reader struct {
 	raw    []byte
 	header []byte
 	zstd   *zstd.Decoder
+	blocks int // total compressed blocks read
+
+	checksum    ChecksumPolicy
+	onCorrupted func(err *CorruptedDataErr)
+}
+
+// SetChecksumPolicy sets verification policy of checksums, and optional
+// hook that is called on mismatch with ChecksumWarn.
+func (r *Reader) SetChecksumPolicy(p ChecksumPolicy, onCorrupted func(err *CorruptedDataErr)) {
+	r.checksum = p
+	r.onCorrupted = onCorrupted
 }
 
 // FormatU128 formats city.U128 as hex.
@@ -49,6 +61,12 @@ func (r *Reader) readBlock() error {
 		return errors.Errorf("raw size should be %d < %d < %d", 0, rawSize, maxBlockSize)
 	}
 
+	if r.data == nil {
+		r.data = getBuf()
+	}
+	if r.raw == nil {
+		r.raw = getBuf()
+	}
 	r.data = append(r.data[:0], make([]byte, dataSize)...)
 	r.raw = append(r.raw[:0], r.header...)
 	r.raw = append(r.raw, make([]byte, rawSize)...)
@@ -57,18 +75,29 @@ func (r *Reader) readBlock() error {
 	if _, err := io.ReadFull(r.reader, r.raw[headerSize:]); err != nil {
 		return errors.Wrap(err, "read raw")
 	}
-	hGot := city.U128{
-		Low:  binary.LittleEndian.Uint64(r.raw[0:8]),
-		High: binary.LittleEndian.Uint64(r.raw[8:16]),
-	}
-	h := city.CH128(r.raw[hMethod:])
-	if hGot != h {
-		return errors.Wrap(&CorruptedDataErr{
-			Actual:    h,
-			Reference: hGot,
-			RawSize:   rawSize,
-			DataSize:  dataSize,
-		}, "mismatch")
+	block := r.blocks
+	r.blocks++
+	if r.checksum != ChecksumSkip {
+		hGot := city.U128{
+			Low:  binary.LittleEndian.Uint64(r.raw[0:8]),
+			High: binary.LittleEndian.Uint64(r.raw[8:16]),
+		}
+		h := city.CH128(r.raw[hMethod:])
+		if hGot != h {
+			err := &CorruptedDataErr{
+				Actual:    h,
+				Reference: hGot,
+				RawSize:   rawSize,
+				DataSize:  dataSize,
+				Block:     block,
+			}
+			if r.checksum != ChecksumWarn {
+				return errors.Wrap(err, "mismatch")
+			}
+			if r.onCorrupted != nil {
+				r.onCorrupted(err)
+			}
+		}
 	}
 	switch m := methodEncoding(r.header[hMethod]); m {
 	case encodedLZ4: // == encodedLZ4HC, as decompression is similar for both
@@ -125,6 +154,37 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 	return n, nil
 }
 
+// Retained returns capacity of internal buffers, i.e. memory retained by
+// Reader between reads.
+func (r *Reader) Retained() int {
+	return cap(r.data) + cap(r.raw)
+}
+
+// Release returns internal buffers to pool, so they can be reused by
+// other readers. Should be called only after decompressed data is read.
+func (r *Reader) Release() {
+	putBuf(r.data)
+	putBuf(r.raw)
+	r.data, r.raw, r.pos = nil, nil, 0
+}
+
+var bufPool sync.Pool
+
+func getBuf() []byte {
+	if b, ok := bufPool.Get().(*[]byte); ok {
+		return (*b)[:0]
+	}
+	return nil
+}
+
+func putBuf(b []byte) {
+	if cap(b) == 0 {
+		return
+	}
+	b = b[:0]
+	bufPool.Put(&b)
+}
+
 // NewReader returns new *Reader from r.
 func NewReader(r io.Reader) *Reader {
 	return &Reader{
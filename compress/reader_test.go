@@ -1,6 +1,9 @@
 package compress
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/go-faster/city"
@@ -11,3 +14,75 @@ func TestFormatU128(t *testing.T) {
 	v := city.CH128([]byte("Moscow"))
 	require.Equal(t, "6ddf3eeebf17df2e559d40c605f3ae22", FormatU128(v))
 }
+
+func TestReader_Release(t *testing.T) {
+	data := []byte(strings.Repeat("Hello!\n", 25))
+	w := NewWriter()
+	require.NoError(t, w.Compress(LZ4, data))
+
+	br := bytes.NewReader(nil)
+	r := NewReader(br)
+	out := make([]byte, len(data))
+	for i := 0; i < 3; i++ {
+		br.Reset(w.Data)
+		_, err := io.ReadFull(r, out)
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+		require.NotZero(t, r.Retained())
+
+		r.Release()
+		require.Zero(t, r.Retained())
+	}
+}
+
+func TestReader_SetChecksumPolicy(t *testing.T) {
+	data := []byte(strings.Repeat("Hello!\n", 25))
+	w := NewWriter()
+	require.NoError(t, w.Compress(None, data))
+	var blocks []byte
+	blocks = append(blocks, w.Data...)
+	blocks = append(blocks, w.Data...)
+	// Corrupt data of second block, it is stored as is with None.
+	blocks[len(w.Data)+headerSize]++
+
+	read := func(t *testing.T, p ChecksumPolicy, onCorrupted func(err *CorruptedDataErr)) ([]byte, error) {
+		t.Helper()
+		r := NewReader(bytes.NewReader(blocks))
+		r.SetChecksumPolicy(p, onCorrupted)
+		out := make([]byte, len(data)*2)
+		_, err := io.ReadFull(r, out)
+		return out, err
+	}
+	t.Run("Strict", func(t *testing.T) {
+		_, err := read(t, ChecksumStrict, func(err *CorruptedDataErr) {
+			t.Fatal("should not be called")
+		})
+		var badData *CorruptedDataErr
+		require.ErrorAs(t, err, &badData)
+		require.Equal(t, 1, badData.Block)
+	})
+	t.Run("Warn", func(t *testing.T) {
+		var corrupted []*CorruptedDataErr
+		out, err := read(t, ChecksumWarn, func(err *CorruptedDataErr) {
+			corrupted = append(corrupted, err)
+		})
+		require.NoError(t, err)
+		require.Equal(t, data, out[:len(data)])
+		require.NotEqual(t, data, out[len(data):])
+		require.Len(t, corrupted, 1)
+		require.Equal(t, 1, corrupted[0].Block)
+	})
+	t.Run("Skip", func(t *testing.T) {
+		_, err := read(t, ChecksumSkip, func(err *CorruptedDataErr) {
+			t.Fatal("should not be called")
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestChecksumPolicy_String(t *testing.T) {
+	require.Equal(t, "strict", ChecksumStrict.String())
+	require.Equal(t, "warn", ChecksumWarn.String())
+	require.Equal(t, "skip", ChecksumSkip.String())
+	require.Equal(t, "ChecksumPolicy(10)", ChecksumPolicy(10).String())
+}
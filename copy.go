@@ -0,0 +1,157 @@
+package ch
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-faster/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// CopyProgress is total progress of Copy.
+type CopyProgress struct {
+	Blocks int
+	Rows   int
+	Bytes  int // of blocks as received from source
+}
+
+// CopyOptions configures Copy.
+type CopyOptions struct {
+	// Buffer is maximum number of blocks that are read from source but
+	// not yet written to destination. Defaults to 4.
+	Buffer int
+	// Settings of SELECT query, e.g. max_block_size.
+	Settings []Setting
+	// OnProgress is called after each block is written to destination.
+	OnProgress func(ctx context.Context, p CopyProgress) error
+}
+
+func (o *CopyOptions) setDefaults() {
+	if o.Buffer <= 0 {
+		o.Buffer = 4
+	}
+}
+
+// Copy streams result of selectQuery on src into insertTable on dst,
+// e.g. for backfills and migrations between clusters.
+//
+// Blocks are passed as is, without decoding columns (see RawBlock), so
+// protocol versions of clients must match. Columns of insertTable are
+// matched by names of result columns. Blocks that were written before
+// error are not rolled back.
+func Copy(ctx context.Context, src, dst *Client, selectQuery, insertTable string, opt CopyOptions) error {
+	opt.setDefaults()
+	if src.ProtocolVersion() != dst.ProtocolVersion() {
+		return errors.Errorf("protocol versions of source (%d) and destination (%d) differ",
+			src.ProtocolVersion(), dst.ProtocolVersion(),
+		)
+	}
+	var (
+		blocks = make(chan RawBlock, opt.Buffer)
+		// Data buffers of written blocks to reuse.
+		free = make(chan []byte, opt.Buffer+1)
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		if err := src.Do(ctx, Query{
+			Body:     selectQuery,
+			Settings: opt.Settings,
+			OnRawBlock: func(ctx context.Context, b RawBlock) error {
+				if b.Header.Rows == 0 {
+					return nil
+				}
+				var buf []byte
+				select {
+				case buf = <-free:
+				default:
+				}
+				// Data is valid only during OnRawBlock.
+				b.Data = append(buf[:0], b.Data...)
+				b.Header.Columns = append([]proto.ColInfo(nil), b.Header.Columns...)
+				select {
+				case blocks <- b:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		}); err != nil {
+			return errors.Wrap(err, "select")
+		}
+		// Closing only on success, so INSERT is not finished on error.
+		close(blocks)
+		return nil
+	})
+	g.Go(func() error {
+		var first RawBlock
+		select {
+		case b, ok := <-blocks:
+			if !ok {
+				// No rows.
+				return nil
+			}
+			first = b
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		var (
+			columns  proto.Input
+			progress CopyProgress
+			written  *RawBlock
+		)
+		for _, c := range first.Header.Columns {
+			columns = append(columns, proto.InputColumn{Name: c.Name})
+		}
+		// Previous block is flushed when next one is requested.
+		done := func(ctx context.Context) error {
+			if written == nil {
+				return nil
+			}
+			progress.Blocks++
+			progress.Rows += written.Header.Rows
+			progress.Bytes += len(written.Data)
+			select {
+			case free <- written.Data:
+			default:
+			}
+			written = nil
+			if f := opt.OnProgress; f != nil {
+				return f(ctx, progress)
+			}
+			return nil
+		}
+		if err := dst.Do(ctx, Query{
+			Body: "INSERT INTO " + insertTable + " " + columns.Columns() + " VALUES",
+			OnRawInput: func(ctx context.Context) (RawBlock, error) {
+				if err := done(ctx); err != nil {
+					return RawBlock{}, errors.Wrap(err, "progress")
+				}
+				var (
+					b  RawBlock
+					ok bool
+				)
+				if first.Data != nil {
+					b, ok = first, true
+					first = RawBlock{}
+				} else {
+					select {
+					case b, ok = <-blocks:
+					case <-ctx.Done():
+						return RawBlock{}, ctx.Err()
+					}
+				}
+				if !ok {
+					return RawBlock{}, io.EOF
+				}
+				written = &b
+				return b, nil
+			},
+		}); err != nil {
+			return errors.Wrap(err, "insert")
+		}
+		return nil
+	})
+	return g.Wait()
+}
@@ -0,0 +1,57 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var (
+		src = Conn(t)
+		dst = Conn(t)
+	)
+	require.NoError(t, dst.Do(ctx, Query{
+		Body: "CREATE TABLE dst (id UInt64, name String, extra UInt8 DEFAULT 42) ENGINE = Memory",
+	}))
+
+	var progress []CopyProgress
+	require.NoError(t, Copy(ctx, src, dst,
+		"SELECT number AS id, toString(number) AS name FROM numbers(10000)", "dst",
+		CopyOptions{
+			Buffer:   2,
+			Settings: []Setting{SettingInt("max_block_size", 1000)},
+			OnProgress: func(ctx context.Context, p CopyProgress) error {
+				progress = append(progress, p)
+				return nil
+			},
+		},
+	))
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	require.Equal(t, 10000, last.Rows)
+	require.Equal(t, len(progress), last.Blocks)
+
+	var count, extra proto.ColUInt64
+	require.NoError(t, dst.Do(ctx, Query{
+		Body: "SELECT count(), sum(extra) FROM dst WHERE name = toString(id)",
+		Result: proto.Results{
+			{Name: "count()", Data: &count},
+			{Name: "sum(extra)", Data: &extra},
+		},
+	}))
+	require.Equal(t, uint64(10000), count.Row(0))
+	require.Equal(t, uint64(42*10000), extra.Row(0))
+
+	t.Run("Empty", func(t *testing.T) {
+		require.NoError(t, Copy(ctx, src, dst, "SELECT 1 AS id, '' AS name LIMIT 0", "dst", CopyOptions{}))
+	})
+	t.Run("Error", func(t *testing.T) {
+		require.Error(t, Copy(ctx, Conn(t), Conn(t), "SELECT 1 AS id", "missing", CopyOptions{}))
+	})
+}
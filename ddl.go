@@ -0,0 +1,207 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// DDL statuses of host in system.distributed_ddl_queue.
+const (
+	DDLStatusInactive = "Inactive"
+	DDLStatusActive   = "Active"
+	DDLStatusFinished = "Finished"
+	DDLStatusRemoving = "Removing"
+	DDLStatusUnknown  = "Unknown"
+)
+
+// DDLHostStatus is status of distributed DDL query on host.
+type DDLHostStatus struct {
+	Host   string
+	Port   uint16
+	Status string // one of DDLStatus* constants
+	// Err is exception of query on host, nil if query succeeded or is
+	// not finished.
+	Err *Exception
+}
+
+func (s DDLHostStatus) String() string {
+	addr := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	if s.Err != nil {
+		return addr + ": " + s.Err.Error()
+	}
+	return addr + ": " + s.Status
+}
+
+// DDLError is returned by DoOnCluster if distributed DDL query failed or
+// is not finished on some hosts.
+type DDLError struct {
+	Entry string          // entry of DDL queue, like query-0000000001
+	Hosts []DDLHostStatus // all hosts of cluster
+	Err   error           // context error, if not finished
+}
+
+// Failed returns hosts that are failed or not finished.
+func (e *DDLError) Failed() []DDLHostStatus {
+	var failed []DDLHostStatus
+	for _, h := range e.Hosts {
+		if h.Err != nil || h.Status != DDLStatusFinished {
+			failed = append(failed, h)
+		}
+	}
+	return failed
+}
+
+func (e *DDLError) Error() string {
+	failed := e.Failed()
+	hosts := make([]string, 0, len(failed))
+	for _, h := range failed {
+		hosts = append(hosts, h.String())
+	}
+	msg := fmt.Sprintf("distributed ddl %s failed on %d of %d hosts: %s",
+		e.Entry, len(failed), len(e.Hosts), strings.Join(hosts, "; "),
+	)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns context error and exceptions of hosts, so errors.Is and
+// HasCode can be used.
+func (e *DDLError) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	for _, h := range e.Hosts {
+		if h.Err != nil {
+			errs = append(errs, h.Err)
+		}
+	}
+	return errs
+}
+
+// onClusterRegexp matches ON CLUSTER clause.
+var onClusterRegexp = regexp.MustCompile(`(?i)\bON\s+CLUSTER\b`)
+
+// ddlTag is tag of query that identifies entry of DDL queue.
+const ddlTag = "ch_go_ddl"
+
+// DoOnCluster executes distributed DDL query with ON CLUSTER clause and
+// waits until it is finished on all hosts, polling
+// system.distributed_ddl_queue with backoff.
+//
+// Instead of waiting on server for distributed_ddl_task_timeout, query is
+// sent with zero timeout and tagged, see Query.Tags, to find its entry in
+// DDL queue, so q should not have explicit log_comment setting.
+//
+// Returns *DDLError if query failed on some hosts or context is done before
+// query is finished on all hosts.
+func DoOnCluster(ctx context.Context, c *Client, q Query) error {
+	if !onClusterRegexp.MatchString(q.Body) {
+		return errors.New("query has no ON CLUSTER clause")
+	}
+	for _, s := range q.Settings {
+		if s.Key == "log_comment" {
+			return errors.New("explicit log_comment setting is not supported")
+		}
+	}
+	id := uuid.New().String()
+	tags := make(map[string]string, len(q.Tags)+1)
+	for k, v := range q.Tags {
+		tags[k] = v
+	}
+	tags[ddlTag] = id
+	q.Tags = tags
+	q.Settings = append(q.Settings[:len(q.Settings):len(q.Settings)],
+		SettingUInt("distributed_ddl_task_timeout", 0),
+	)
+	if err := c.Do(ctx, q); err != nil {
+		return errors.Wrap(err, "query")
+	}
+	return waitDDL(ctx, c, id)
+}
+
+// waitDDL waits until DDL queue entry with tag id is finished on all hosts.
+func waitDDL(ctx context.Context, c *Client, id string) error {
+	var (
+		entry, host, status, text proto.ColStr
+		port, code                proto.ColUInt16
+
+		hosts []DDLHostStatus
+		ddl   = &DDLError{}
+	)
+	query := Query{
+		Body: "SELECT entry, host, port, toString(status) AS status, exception_code, exception_text " +
+			"FROM system.distributed_ddl_queue WHERE position(settings['log_comment'], " +
+			string(proto.LitString(id)) + ") > 0 ORDER BY host, port",
+		Result: proto.Results{
+			{Name: "entry", Data: &entry},
+			{Name: "host", Data: &host},
+			{Name: "port", Data: &port},
+			{Name: "status", Data: &status},
+			{Name: "exception_code", Data: &code},
+			{Name: "exception_text", Data: &text},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			for i := 0; i < entry.Rows(); i++ {
+				ddl.Entry = entry.Row(i)
+				s := DDLHostStatus{
+					Host:   host.Row(i),
+					Port:   port.Row(i),
+					Status: status.Row(i),
+				}
+				if v := code.Row(i); v != 0 {
+					s.Err = parseFailReason(text.Row(i))
+					if s.Err == nil {
+						s.Err = &Exception{Code: proto.Error(v), Message: text.Row(i)}
+					}
+				}
+				hosts = append(hosts, s)
+			}
+			return nil
+		},
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 50 * time.Millisecond
+	b.MaxInterval = 2 * time.Second
+	b.MaxElapsedTime = 0 // until context is done
+	err := backoff.Retry(func() error {
+		hosts = hosts[:0:0]
+		if err := c.Do(ctx, query); err != nil {
+			return backoff.Permanent(errors.Wrap(err, "query"))
+		}
+		if len(hosts) == 0 {
+			return errors.New("entry not found")
+		}
+		ddl.Hosts = hosts
+		for _, h := range hosts {
+			if h.Status != DDLStatusFinished {
+				return errors.New("not finished")
+			}
+		}
+		for _, h := range hosts {
+			// Exception is reported with Finished status.
+			if h.Err != nil {
+				return backoff.Permanent(ddl)
+			}
+		}
+		return nil
+	}, backoff.WithContext(b, ctx))
+	if err != nil && ctx.Err() != nil && len(ddl.Hosts) > 0 {
+		// Report status of hosts instead of context error.
+		ddl.Err = ctx.Err()
+		return ddl
+	}
+	return err
+}
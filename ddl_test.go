@@ -0,0 +1,67 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestDDLError(t *testing.T) {
+	err := &DDLError{
+		Entry: "query-0000000001",
+		Hosts: []DDLHostStatus{
+			{Host: "a", Port: 9000, Status: DDLStatusFinished},
+			{
+				Host: "b", Port: 9000, Status: DDLStatusFinished,
+				Err: parseFailReason("Code: 57. DB::Exception: Table default.t already exists. (TABLE_ALREADY_EXISTS)"),
+			},
+			{Host: "c", Port: 9000, Status: DDLStatusActive},
+		},
+		Err: context.DeadlineExceeded,
+	}
+	require.Len(t, err.Failed(), 2)
+	require.EqualError(t, err, "distributed ddl query-0000000001 failed on 2 of 3 hosts: "+
+		"b:9000: TABLE_ALREADY_EXISTS (57): DB::Exception: Table default.t already exists. (TABLE_ALREADY_EXISTS); "+
+		"c:9000: Active: context deadline exceeded")
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.True(t, HasCode(err, proto.ErrTableAlreadyExists))
+	require.ErrorIs(t, err, ErrServerException)
+}
+
+func TestDoOnCluster(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	require.Error(t, DoOnCluster(ctx, nil, Query{Body: "CREATE TABLE t (v UInt8) ENGINE = Memory"}))
+	require.Error(t, DoOnCluster(ctx, nil, Query{
+		Body:     "CREATE TABLE t ON CLUSTER c (v UInt8) ENGINE = Memory",
+		Settings: []Setting{SettingString("log_comment", "foo")},
+	}))
+
+	conn := Conn(t)
+	err := DoOnCluster(ctx, conn, Query{
+		Body: "CREATE TABLE test_on_cluster ON CLUSTER test_shard_localhost (v UInt8) ENGINE = Memory",
+	})
+	if HasCode(err, proto.ErrNoZookeeper, proto.ErrClusterDoesntExist) {
+		t.Skip("Distributed DDL is not configured")
+	}
+	require.NoError(t, err)
+
+	err = DoOnCluster(ctx, conn, Query{
+		Body: "CREATE TABLE test_on_cluster ON CLUSTER test_shard_localhost (v UInt8) ENGINE = Memory",
+	})
+	var ddlErr *DDLError
+	require.ErrorAs(t, err, &ddlErr)
+	require.NotEmpty(t, ddlErr.Entry)
+	require.True(t, HasCode(err, proto.ErrTableAlreadyExists))
+
+	require.NoError(t, DoOnCluster(ctx, conn, Query{
+		Body: "DROP TABLE test_on_cluster ON CLUSTER test_shard_localhost",
+	}))
+}
@@ -0,0 +1,129 @@
+package ch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Default kinds of TableColumn.
+const (
+	DefaultKindDefault      = "DEFAULT"
+	DefaultKindMaterialized = "MATERIALIZED"
+	DefaultKindAlias        = "ALIAS"
+	DefaultKindEphemeral    = "EPHEMERAL"
+)
+
+// TableColumn describes column of table, see DescribeTable.
+type TableColumn struct {
+	Name string
+	Type proto.ColumnType
+	// DefaultKind is one of DefaultKind* constants, or empty if column has
+	// no default expression.
+	DefaultKind       string
+	DefaultExpression string
+	Comment           string
+	Codec             string // like ZSTD(1)
+	TTL               string
+}
+
+// Insertable reports whether column value can be inserted, i.e. column is
+// not MATERIALIZED or ALIAS.
+func (c TableColumn) Insertable() bool {
+	return c.DefaultKind != DefaultKindMaterialized && c.DefaultKind != DefaultKindAlias
+}
+
+// TableSchema is list of table columns in order of definition.
+type TableSchema []TableColumn
+
+// Names returns names of columns.
+func (s TableSchema) Names() []string {
+	names := make([]string, 0, len(s))
+	for _, c := range s {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Results returns result columns for all columns of table, to be used
+// with SELECT of Names in the same order.
+func (s TableSchema) Results() (proto.Results, error) {
+	results := make(proto.Results, 0, len(s))
+	for _, c := range s {
+		col, err := proto.NewColumn(c.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", c.Name)
+		}
+		results = append(results, proto.ResultColumn{Name: c.Name, Data: col})
+	}
+	return results, nil
+}
+
+// Input returns empty input columns for insertable columns of table,
+// see TableColumn.Insertable. Use proto.Input.Into to get INSERT query.
+//
+// Columns can be type-asserted to proto.ColumnOf[T] to append values.
+func (s TableSchema) Input() (proto.Input, error) {
+	input := make(proto.Input, 0, len(s))
+	for _, c := range s {
+		if !c.Insertable() {
+			continue
+		}
+		col, err := proto.NewColumn(c.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", c.Name)
+		}
+		input = append(input, proto.InputColumn{Name: c.Name, Data: col})
+	}
+	return input, nil
+}
+
+// DescribeTable returns schema of table, which can be qualified with
+// database like "db.table".
+func DescribeTable(ctx context.Context, c *Client, table string) (TableSchema, error) {
+	if table == "" {
+		return nil, errors.New("empty table name")
+	}
+	parts := strings.Split(table, ".")
+	for i, p := range parts {
+		parts[i] = quoteIdentifier(p)
+	}
+	var (
+		name, typ, defaultKind, defaultExpr proto.ColStr
+		comment, codec, ttl                 proto.ColStr
+
+		schema TableSchema
+	)
+	if err := c.Do(ctx, Query{
+		Body: "DESCRIBE TABLE " + strings.Join(parts, "."),
+		Result: proto.Results{
+			{Name: "name", Data: &name},
+			{Name: "type", Data: &typ},
+			{Name: "default_type", Data: &defaultKind},
+			{Name: "default_expression", Data: &defaultExpr},
+			{Name: "comment", Data: &comment},
+			{Name: "codec_expression", Data: &codec},
+			{Name: "ttl_expression", Data: &ttl},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			for i := 0; i < name.Rows(); i++ {
+				schema = append(schema, TableColumn{
+					Name:              name.Row(i),
+					Type:              proto.ColumnType(typ.Row(i)),
+					DefaultKind:       defaultKind.Row(i),
+					DefaultExpression: defaultExpr.Row(i),
+					Comment:           comment.Row(i),
+					Codec:             codec.Row(i),
+					TTL:               ttl.Row(i),
+				})
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "describe")
+	}
+	return schema, nil
+}
@@ -0,0 +1,70 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestTableSchema(t *testing.T) {
+	schema := TableSchema{
+		{Name: "id", Type: "UInt64"},
+		{Name: "name", Type: "LowCardinality(String)", DefaultKind: DefaultKindDefault, DefaultExpression: "'unknown'"},
+		{Name: "tags", Type: "Array(String)"},
+		{Name: "name_len", Type: "UInt64", DefaultKind: DefaultKindMaterialized, DefaultExpression: "length(name)"},
+		{Name: "upper", Type: "String", DefaultKind: DefaultKindAlias, DefaultExpression: "upper(name)"},
+	}
+	require.Equal(t, []string{"id", "name", "tags", "name_len", "upper"}, schema.Names())
+
+	results, err := schema.Results()
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	require.Equal(t, proto.ColumnType("Array(String)"), results[2].Data.Type())
+
+	input, err := schema.Input()
+	require.NoError(t, err)
+	require.Len(t, input, 3)
+	require.Equal(t, `INSERT INTO "t" ("id","name","tags") VALUES`, input.Into("t"))
+	input[0].Data.(proto.ColumnOf[uint64]).Append(1)
+	require.Equal(t, 1, input[0].Data.Rows())
+
+	_, err = TableSchema{{Name: "bad", Type: "Unknown"}}.Input()
+	require.Error(t, err)
+}
+
+func TestDescribeTable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: `CREATE TABLE test_describe (
+	id UInt64 COMMENT 'identifier',
+	name String DEFAULT 'unknown' CODEC(ZSTD(1)),
+	name_len UInt64 MATERIALIZED length(name)
+) ENGINE = Memory`,
+	}))
+
+	schema, err := DescribeTable(ctx, conn, "test_describe")
+	require.NoError(t, err)
+	require.Equal(t, TableSchema{
+		{Name: "id", Type: "UInt64", Comment: "identifier"},
+		{Name: "name", Type: "String", DefaultKind: DefaultKindDefault, DefaultExpression: "'unknown'", Codec: "ZSTD(1)"},
+		{Name: "name_len", Type: "UInt64", DefaultKind: DefaultKindMaterialized, DefaultExpression: "length(name)"},
+	}, schema)
+
+	input, err := schema.Input()
+	require.NoError(t, err)
+	input[0].Data.(proto.ColumnOf[uint64]).Append(1)
+	input[1].Data.(proto.ColumnOf[string]).Append("foo")
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:  input.Into("test_describe"),
+		Input: input,
+	}))
+
+	_, err = DescribeTable(ctx, conn, "test_describe_missing")
+	require.True(t, HasCode(err, proto.ErrUnknownTable))
+}
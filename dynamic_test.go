@@ -0,0 +1,53 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestDynamic(t *testing.T) {
+	conn := ConnOpt(t, Options{
+		Settings: []Setting{
+			SettingInt("allow_experimental_dynamic_type", 1),
+		},
+	})
+	if v := conn.ServerInfo(); (v.Major < 24) || (v.Major == 24 && v.Minor < 8) {
+		t.Skip("Skipping (not supported)")
+	}
+	ctx := context.Background()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_dynamic (v Dynamic) ENGINE = Memory",
+	}))
+	data := proto.NewDynamic(new(proto.ColStr), new(proto.ColInt64))
+	require.NoError(t, proto.AppendDynamic[string](data, proto.ColumnTypeString, "foo"))
+	require.NoError(t, proto.AppendDynamic[int64](data, proto.ColumnTypeInt64, 100))
+	data.AppendNull()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_dynamic VALUES",
+		Input: proto.Input{
+			{Name: "v", Data: data},
+		},
+	}))
+
+	got := new(proto.ColDynamic)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT v FROM test_dynamic",
+		Result: proto.Results{
+			{Name: "v", Data: got},
+		},
+	}))
+	require.Equal(t, data.Rows(), got.Rows())
+	for i := 0; i < data.Rows(); i++ {
+		require.Equal(t, data.Row(i).Type(), got.Row(i).Type())
+	}
+	s, ok := proto.DynamicValueAs[string](got.Row(0))
+	require.True(t, ok)
+	require.Equal(t, "foo", s)
+	n, ok := proto.DynamicValueAs[int64](got.Row(1))
+	require.True(t, ok)
+	require.Equal(t, int64(100), n)
+}
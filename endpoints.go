@@ -0,0 +1,268 @@
+package ch
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-faster/errors"
+	"go.uber.org/atomic"
+)
+
+// ErrNoEndpoints means that Resolver returned no addresses.
+var ErrNoEndpoints = errors.New("no endpoints")
+
+// Resolver returns current addresses of server native protocol endpoints,
+// like "host:9000", in order of preference.
+type Resolver func(ctx context.Context) ([]string, error)
+
+// SRV returns Resolver of DNS SRV record "_service._proto.name", e.g. of
+// Kubernetes headless service, like SRV("tcp", "tcp", "clickhouse.ns.svc").
+//
+// Addresses are sorted by priority and randomized by weight. Default
+// resolver is used if r is nil.
+func SRV(r *net.Resolver, service, proto, name string) Resolver {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	return func(ctx context.Context) ([]string, error) {
+		_, records, err := r.LookupSRV(ctx, service, proto, name)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup srv")
+		}
+		return srvAddrs(records), nil
+	}
+}
+
+func srvAddrs(records []*net.SRV) []string {
+	addrs := make([]string, 0, len(records))
+	for _, r := range records {
+		host := strings.TrimSuffix(r.Target, ".")
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+	}
+	return addrs
+}
+
+// EndpointsOptions for Endpoints.
+type EndpointsOptions struct {
+	// RefreshInterval is maximum age of resolved addresses, defaults to 30s.
+	RefreshInterval time.Duration
+}
+
+// DefaultEndpointsRefreshInterval is default of
+// EndpointsOptions.RefreshInterval.
+const DefaultEndpointsRefreshInterval = time.Second * 30
+
+func (o *EndpointsOptions) setDefaults() {
+	if o.RefreshInterval == 0 {
+		o.RefreshInterval = DefaultEndpointsRefreshInterval
+	}
+}
+
+// Endpoints is set of server addresses from Resolver, refreshed
+// periodically, see Options.Endpoints.
+//
+// Safe for concurrent use, so can be shared by clients, e.g. by
+// connections of chpool.Pool.
+type Endpoints struct {
+	resolve Resolver
+	refresh time.Duration
+
+	mux      sync.Mutex
+	addrs    []string
+	resolved time.Time
+	// In-flight resolving, closed when done.
+	resolving chan struct{}
+	// Backoff of resolving after failure.
+	retry   *backoff.ExponentialBackOff
+	retryAt time.Time
+	err     error // last error of resolving
+	// Addresses that are dialed only if others are unavailable.
+	stale map[string]struct{}
+
+	// Index of first address to dial, to spread connections.
+	next atomic.Uint64
+}
+
+// NewEndpoints returns Endpoints of r. Addresses are resolved on first use.
+func NewEndpoints(r Resolver, opt EndpointsOptions) *Endpoints {
+	opt.setDefaults()
+	retry := backoff.NewExponentialBackOff()
+	retry.InitialInterval = time.Second
+	retry.MaxInterval = opt.RefreshInterval
+	retry.MaxElapsedTime = 0
+	return &Endpoints{
+		resolve: r,
+		refresh: opt.RefreshInterval,
+		retry:   retry,
+	}
+}
+
+// Addrs returns current addresses, resolving them if they are older than
+// refresh interval.
+//
+// Resolving is not blocking: previous addresses are returned while other
+// call resolves them, or if resolving fails, so slow or temporarily failing
+// DNS does not prevent dialing known servers. Failed resolving is retried
+// with exponential backoff, up to refresh interval.
+func (e *Endpoints) Addrs(ctx context.Context) ([]string, error) {
+	for {
+		e.mux.Lock()
+		now := time.Now()
+		switch {
+		case e.addrs != nil && now.Sub(e.resolved) < e.refresh:
+			addrs := e.addrs
+			e.mux.Unlock()
+			return addrs, nil
+		case e.resolving == nil && now.Before(e.retryAt):
+			// Backing off after failure.
+			addrs, err := e.addrs, e.err
+			e.mux.Unlock()
+			if addrs != nil {
+				return addrs, nil
+			}
+			return nil, err
+		case e.resolving != nil:
+			addrs, done := e.addrs, e.resolving
+			e.mux.Unlock()
+			if addrs != nil {
+				return addrs, nil
+			}
+			// Nothing to return, waiting for result.
+			select {
+			case <-done:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		e.resolving = done
+		e.mux.Unlock()
+
+		addrs, err := e.resolve(ctx)
+		if err == nil && len(addrs) == 0 {
+			err = ErrNoEndpoints
+		}
+
+		e.mux.Lock()
+		e.resolving = nil
+		close(done)
+		if err != nil {
+			e.err = err
+			e.retryAt = time.Now().Add(e.retry.NextBackOff())
+			addrs = e.addrs
+		} else {
+			e.addrs, e.resolved, e.err = addrs, time.Now(), nil
+			e.retryAt = time.Time{}
+			e.retry.Reset()
+		}
+		e.mux.Unlock()
+
+		if addrs == nil {
+			return nil, err
+		}
+		return addrs, nil
+	}
+}
+
+// Has reports whether addr is in last resolved addresses.
+func (e *Endpoints) Has(addr string) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	for _, a := range e.addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidate forces resolving on next use, unless resolving is backing
+// off after failure.
+func (e *Endpoints) Invalidate() {
+	e.mux.Lock()
+	e.resolved = time.Time{}
+	e.mux.Unlock()
+}
+
+// SetStale marks addresses of servers that should be dialed only if other
+// ones are unavailable, e.g. replicas lagging behind, replacing previously
+// marked ones.
+func (e *Endpoints) SetStale(addrs []string) {
+	stale := make(map[string]struct{}, len(addrs))
+	for _, a := range addrs {
+		stale[a] = struct{}{}
+	}
+	e.mux.Lock()
+	e.stale = stale
+	e.mux.Unlock()
+}
+
+// IsStale reports whether addr is marked stale, see SetStale.
+func (e *Endpoints) IsStale(addr string) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	_, ok := e.stale[addr]
+	return ok
+}
+
+// dialOrder returns addresses to dial in order, starting from next
+// address in round-robin. Stale addresses are last.
+func (e *Endpoints) dialOrder(ctx context.Context) ([]string, error) {
+	addrs, err := e.Addrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := int((e.next.Inc() - 1) % uint64(len(addrs)))
+	rotated := make([]string, 0, len(addrs))
+	rotated = append(rotated, addrs[start:]...)
+	rotated = append(rotated, addrs[:start]...)
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if len(e.stale) == 0 {
+		return rotated, nil
+	}
+	order := make([]string, 0, len(addrs))
+	for _, a := range rotated {
+		if _, ok := e.stale[a]; !ok {
+			order = append(order, a)
+		}
+	}
+	for _, a := range rotated {
+		if _, ok := e.stale[a]; ok {
+			order = append(order, a)
+		}
+	}
+	return order, nil
+}
+
+// dialEndpoints dials addresses of e in order, failing over to next address
+// on network error.
+//
+// Addresses are invalidated if all of them are unavailable, as servers
+// could be replaced, e.g. by rescheduled pods.
+func dialEndpoints(ctx context.Context, e *Endpoints, dial func(addr string) (*Client, error)) (*Client, error) {
+	addrs, err := e.dialOrder(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve")
+	}
+	var errs []error
+	for _, addr := range addrs {
+		c, err := dial(addr)
+		if err == nil {
+			return c, nil
+		}
+		if !isNetworkError(err) || ctx.Err() != nil {
+			return nil, errors.Wrap(err, addr)
+		}
+		errs = append(errs, errors.Wrap(err, addr))
+	}
+	e.Invalidate()
+	return nil, errors.Wrapf(errors.Join(errs...), "all %d endpoints failed", len(addrs))
+}
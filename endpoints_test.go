@@ -0,0 +1,209 @@
+package ch
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/ClickHouse/ch-go/cht"
+)
+
+func TestEndpoints(t *testing.T) {
+	ctx := context.Background()
+	var (
+		calls int
+		addrs = []string{"a:9000", "b:9000"}
+		err   error
+	)
+	e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+		calls++
+		return addrs, err
+	}, EndpointsOptions{RefreshInterval: time.Hour})
+
+	got, resolveErr := e.Addrs(ctx)
+	require.NoError(t, resolveErr)
+	require.Equal(t, addrs, got)
+	require.True(t, e.Has("a:9000"))
+	require.False(t, e.Has("c:9000"))
+
+	// Cached.
+	_, _ = e.Addrs(ctx)
+	require.Equal(t, 1, calls)
+
+	// Round-robin.
+	for _, expected := range [][]string{
+		{"a:9000", "b:9000"},
+		{"b:9000", "a:9000"},
+	} {
+		order, orderErr := e.dialOrder(ctx)
+		require.NoError(t, orderErr)
+		require.Equal(t, expected, order)
+	}
+
+	// Previous addresses are kept on failure.
+	e.Invalidate()
+	err = errors.New("dns failure")
+	got, resolveErr = e.Addrs(ctx)
+	require.NoError(t, resolveErr)
+	require.Equal(t, []string{"a:9000", "b:9000"}, got)
+	require.Equal(t, 2, calls)
+
+	// Not resolving again until backoff is elapsed.
+	e.Invalidate()
+	addrs, err = []string{"c:9000"}, nil
+	got, resolveErr = e.Addrs(ctx)
+	require.NoError(t, resolveErr)
+	require.Equal(t, []string{"a:9000", "b:9000"}, got)
+	require.Equal(t, 2, calls)
+
+	e.mux.Lock()
+	e.retryAt = time.Time{}
+	e.mux.Unlock()
+	got, resolveErr = e.Addrs(ctx)
+	require.NoError(t, resolveErr)
+	require.Equal(t, addrs, got)
+	require.False(t, e.Has("a:9000"))
+	require.Equal(t, 3, calls)
+
+	t.Run("Empty", func(t *testing.T) {
+		var calls int
+		e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+			calls++
+			return nil, nil
+		}, EndpointsOptions{})
+		_, err := e.Addrs(ctx)
+		require.ErrorIs(t, err, ErrNoEndpoints)
+
+		// Error is returned during backoff.
+		_, err = e.Addrs(ctx)
+		require.ErrorIs(t, err, ErrNoEndpoints)
+		require.Equal(t, 1, calls)
+	})
+	t.Run("Slow", func(t *testing.T) {
+		var (
+			started = make(chan struct{})
+			release = make(chan struct{})
+			calls   atomic.Int32
+		)
+		e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+			if calls.Add(1) > 1 {
+				close(started)
+				<-release
+			}
+			return []string{"a:9000"}, nil
+		}, EndpointsOptions{RefreshInterval: time.Hour})
+		_, err := e.Addrs(ctx)
+		require.NoError(t, err)
+
+		e.Invalidate()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = e.Addrs(ctx)
+		}()
+		<-started
+
+		// Previous addresses are returned while other call is resolving.
+		got, err := e.Addrs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a:9000"}, got)
+		require.True(t, e.Has("a:9000"))
+		require.Equal(t, int32(2), calls.Load())
+
+		close(release)
+		<-done
+	})
+}
+
+func TestEndpoints_SetStale(t *testing.T) {
+	ctx := context.Background()
+	e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+		return []string{"a:9000", "b:9000", "c:9000"}, nil
+	}, EndpointsOptions{})
+
+	e.SetStale([]string{"a:9000"})
+	require.True(t, e.IsStale("a:9000"))
+	require.False(t, e.IsStale("b:9000"))
+
+	// Stale addresses are dialed last, rest is round-robin.
+	for _, expected := range [][]string{
+		{"b:9000", "c:9000", "a:9000"},
+		{"b:9000", "c:9000", "a:9000"},
+		{"c:9000", "b:9000", "a:9000"},
+	} {
+		order, err := e.dialOrder(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expected, order)
+	}
+
+	e.SetStale(nil)
+	require.False(t, e.IsStale("a:9000"))
+}
+
+func TestSRV(t *testing.T) {
+	require.Equal(t, []string{"ch-0.ch.svc:9000", "[::1]:9440"}, srvAddrs([]*net.SRV{
+		{Target: "ch-0.ch.svc.", Port: 9000},
+		{Target: "::1", Port: 9440},
+	}))
+}
+
+// addrDialer records dialed addresses and fails with connection refused,
+// except for address err is returned for.
+type addrDialer struct {
+	dialed []string
+	addr   string
+	err    error
+}
+
+func (d *addrDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.dialed = append(d.dialed, address)
+	if address == d.addr {
+		return nil, d.err
+	}
+	return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+}
+
+func TestDial_endpoints(t *testing.T) {
+	ctx := context.Background()
+	addrs := []string{"a:9000", "b:9000", "c:9000"}
+	e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+		return addrs, nil
+	}, EndpointsOptions{})
+
+	t.Run("Failover", func(t *testing.T) {
+		errAuth := errors.New("authentication failed")
+		d := &addrDialer{addr: "b:9000", err: errAuth}
+		_, err := Dial(ctx, Options{Dialer: d, Endpoints: e})
+		require.ErrorIs(t, err, errAuth)
+		require.Equal(t, []string{"a:9000", "b:9000"}, d.dialed)
+	})
+	t.Run("Unavailable", func(t *testing.T) {
+		d := &addrDialer{}
+		_, err := Dial(ctx, Options{Dialer: d, Endpoints: e})
+		require.ErrorIs(t, err, syscall.ECONNREFUSED)
+		require.ErrorContains(t, err, "all 3 endpoints failed")
+		// Starting from next endpoint.
+		require.Equal(t, []string{"b:9000", "c:9000", "a:9000"}, d.dialed)
+	})
+}
+
+func TestDial_endpointsServer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := cht.New(t).TCP
+
+	e := NewEndpoints(func(ctx context.Context) ([]string, error) {
+		return []string{"127.0.0.1:1", server}, nil
+	}, EndpointsOptions{})
+	conn, err := Dial(ctx, Options{Endpoints: e})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	require.Equal(t, server, conn.Addr())
+	require.NoError(t, conn.Ping(ctx))
+}
@@ -0,0 +1,90 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Categories of errors returned by Client.Do, can be matched by errors.Is,
+// e.g. to decide whether query should be retried.
+var (
+	// ErrNetwork means that connection failed, e.g. was reset or closed.
+	// Client is not usable, but query can be retried on new connection.
+	ErrNetwork = errors.New("network error")
+	// ErrProtocol means that server sent unexpected data.
+	ErrProtocol = errors.New("protocol error")
+	// ErrServerException means that server returned *Exception, see
+	// HasCode to check exception code.
+	ErrServerException = errors.New("server exception")
+	// ErrCorruptedData means that checksum of compressed data mismatch,
+	// see *CorruptedDataErr.
+	ErrCorruptedData = errors.New("corrupted data")
+	// ErrCanceled means that query was canceled by context or server.
+	ErrCanceled = errors.New("query canceled")
+)
+
+// Is reports whether target is ErrServerException.
+func (e *Exception) Is(target error) bool {
+	return target == ErrServerException
+}
+
+// Is reports whether target is ErrCorruptedData.
+func (c *CorruptedDataErr) Is(target error) bool {
+	return target == ErrCorruptedData
+}
+
+// classifiedError is error with category that can be matched by errors.Is.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+
+func (e *classifiedError) Unwrap() error { return e.err }
+
+func (e *classifiedError) Is(target error) bool { return target == e.class }
+
+// classifyError adds category to error of query if it has no one.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var class error
+	switch {
+	case errors.Is(err, ErrCanceled):
+		// Already classified.
+		return err
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded),
+		IsErr(err, proto.ErrQueryWasCancelled):
+		class = ErrCanceled
+	case errors.Is(err, ErrServerException), errors.Is(err, ErrCorruptedData),
+		errors.Is(err, ErrProtocol), errors.Is(err, ErrNetwork):
+		// Already classified.
+		return err
+	case isNetworkError(err):
+		class = ErrNetwork
+	default:
+		return err
+	}
+	return &classifiedError{err: err, class: class}
+}
+
+// isNetworkError reports whether err is connection failure.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, ErrClosed) ||
+		errors.Is(err, ErrQueryStalled)
+}
@@ -0,0 +1,95 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/compress"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClassifyError(t *testing.T) {
+	exception := &Exception{Code: proto.ErrUnknownTable}
+	for _, tt := range []struct {
+		Name  string
+		Err   error
+		Class error
+	}{
+		{"EOF", errors.Wrap(io.EOF, "read"), ErrNetwork},
+		{"UnexpectedEOF", errors.Wrap(io.ErrUnexpectedEOF, "read"), ErrNetwork},
+		{"OpError", &net.OpError{Op: "read", Err: errors.New("connection reset")}, ErrNetwork},
+		{"Closed", ErrClosed, ErrNetwork},
+		{"Stalled", errors.Wrap(ErrQueryStalled, "nothing received"), ErrNetwork},
+		{"Canceled", errors.Wrap(context.Canceled, "canceled"), ErrCanceled},
+		{"Deadline", errors.Wrap(context.DeadlineExceeded, "canceled"), ErrCanceled},
+		{"QueryWasCancelled", &Exception{Code: proto.ErrQueryWasCancelled}, ErrCanceled},
+		{"Exception", errors.Wrap(exception, "query"), ErrServerException},
+		{"Corrupted", errors.Wrap(&CorruptedDataErr{}, "bad block"), ErrCorruptedData},
+		{"Protocol", errors.Wrapf(ErrProtocol, "unexpected packet %q", proto.ServerCodeHello), ErrProtocol},
+		{"Unknown", errors.New("handler failed"), nil},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			err := classifyError(tt.Err)
+			require.Equal(t, tt.Err.Error(), err.Error())
+			require.ErrorIs(t, err, tt.Err)
+			if tt.Class == ErrServerException {
+				require.ErrorIs(t, err, ErrServerException)
+			}
+			for _, class := range []error{
+				ErrNetwork, ErrProtocol, ErrCorruptedData, ErrCanceled,
+			} {
+				require.Equal(t, class == tt.Class, errors.Is(err, class), "%s", class)
+			}
+			// Classification is idempotent.
+			require.Equal(t, err, classifyError(err))
+		})
+	}
+	require.NoError(t, classifyError(nil))
+
+	var ex *Exception
+	require.ErrorAs(t, classifyError(errors.Wrap(exception, "query")), &ex)
+	require.Equal(t, exception, ex)
+}
+
+func TestClient_Do_errorClass(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	err := conn.Do(ctx, Query{Body: "SELECT * FROM table_that_does_not_exist"})
+	require.ErrorIs(t, err, ErrServerException)
+	require.False(t, errors.Is(err, ErrNetwork))
+	require.True(t, HasCode(err, proto.ErrUnknownTable))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, conn.Do(canceled, Query{Body: "SELECT 1", Result: discardResult()}), ErrCanceled)
+}
+
+func TestClient_onCorruptedData(t *testing.T) {
+	var got *CorruptedDataErr
+	c := &Client{
+		lg:         zap.NewNop(),
+		stats:      new(clientStats),
+		queryBlock: 2,
+		onCorrupted: func(err *CorruptedDataErr) {
+			got = err
+		},
+	}
+	c.stats.queryID.Store("query")
+	bad := &compress.CorruptedDataErr{RawSize: 10, DataSize: 20, Block: 5}
+	c.onCorruptedData(bad)
+	require.Equal(t, &CorruptedDataErr{
+		RawSize:  10,
+		DataSize: 20,
+		Block:    2,
+		QueryID:  "query",
+	}, got)
+	require.ErrorIs(t, got, ErrCorruptedData)
+}
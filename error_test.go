@@ -44,3 +44,67 @@ func TestException_Error(t *testing.T) {
 	require.False(t, IsErr(err, proto.ErrTableIsDropped))
 	require.False(t, IsErr(io.EOF, proto.ErrBadArguments))
 }
+
+func TestException_Chain(t *testing.T) {
+	e := &Exception{
+		Code:    proto.ErrUnknownTable,
+		Name:    "DB::Exception",
+		Message: "Received from remote",
+		Next: []Exception{
+			{Code: proto.ErrTableIsDropped, Name: "DB::Exception"},
+			{Code: proto.ErrBadArguments, Name: "DB::Exception"},
+		},
+	}
+	chain := e.Chain()
+	require.Len(t, chain, 3)
+	require.Equal(t, proto.ErrUnknownTable, chain[0].Code)
+	require.Nil(t, chain[0].Next)
+	require.Equal(t, proto.ErrBadArguments, chain[2].Code)
+	require.Nil(t, (*Exception)(nil).Chain())
+
+	err := errors.Wrap(e, "query")
+	require.True(t, HasCode(err, proto.ErrBadArguments))
+	require.True(t, HasCode(err, proto.ErrNoSuchColumnInTable, proto.ErrUnknownTable))
+	require.False(t, IsErr(err, proto.ErrBadArguments))
+	require.False(t, HasCode(err, proto.ErrNoSuchColumnInTable))
+	require.False(t, HasCode(io.EOF, proto.ErrBadArguments))
+}
+
+func TestParseStackTrace(t *testing.T) {
+	const stack = `Stack trace:
+
+0. ./build_docker/./src/Common/Exception.cpp:101: DB::Exception::Exception(DB::Exception::MessageMasked&&, int, bool) @ 0x000000000c800f1b in /usr/bin/clickhouse
+1. DB::Exception::Exception<String>(int, FormatStringHelperImpl<String>, String&&) @ 0x0000000007216fd3
+2. ? @ 0x00007f2d3a0b2ac3
+3. start_thread @ 0x94ac3 in ?
+`
+	frames := ParseStackTrace(stack)
+	require.Equal(t, []StackFrame{
+		{
+			Index:    0,
+			Function: "DB::Exception::Exception(DB::Exception::MessageMasked&&, int, bool)",
+			File:     "./build_docker/./src/Common/Exception.cpp",
+			Line:     101,
+			Address:  0xc800f1b,
+			Binary:   "/usr/bin/clickhouse",
+		},
+		{
+			Index:    1,
+			Function: "DB::Exception::Exception<String>(int, FormatStringHelperImpl<String>, String&&)",
+			Address:  0x7216fd3,
+		},
+		{
+			Index:    2,
+			Function: "?",
+			Address:  0x7f2d3a0b2ac3,
+		},
+		{
+			Index:    3,
+			Function: "start_thread",
+			Address:  0x94ac3,
+			Binary:   "?",
+		},
+	}, frames)
+	require.Equal(t, frames, (&Exception{Stack: stack}).Frames())
+	require.Empty(t, ParseStackTrace(""))
+}
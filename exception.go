@@ -0,0 +1,87 @@
+package ch
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Chain returns exception and its nested exceptions, from top to the
+// innermost one.
+func (e *Exception) Chain() []Exception {
+	if e == nil {
+		return nil
+	}
+	top := *e
+	top.Next = nil
+	return append([]Exception{top}, e.Next...)
+}
+
+// Frames returns parsed server stack trace of exception.
+func (e *Exception) Frames() []StackFrame {
+	return ParseStackTrace(e.Stack)
+}
+
+// HasCode reports whether err is exception with any of provided codes
+// or has nested exception with such code.
+//
+// Unlike IsErr, whole exception chain is checked, e.g. to find the cause
+// of error on remote server of Distributed query.
+func HasCode(err error, codes ...proto.Error) bool {
+	e, ok := AsException(err)
+	if !ok {
+		return false
+	}
+	for _, next := range e.Chain() {
+		if next.IsCode(codes...) {
+			return true
+		}
+	}
+	return false
+}
+
+// StackFrame is frame of server stack trace.
+type StackFrame struct {
+	Index    int
+	Function string // demangled function name, or "?" if unknown
+	File     string // source file, if available
+	Line     int    // line in source file, if available
+	Address  uint64 // address of instruction
+	Binary   string // path to binary, if available
+}
+
+// stackFrameRegexp matches frame of server stack trace with optional
+// source location, address and binary:
+//
+//	N. ./src/Common/Exception.cpp:101: DB::Exception::Exception(...) @ 0x000000000c800f1b in /usr/bin/clickhouse
+var stackFrameRegexp = regexp.MustCompile(
+	`^\s*(\d+)\.\s+(?:(\S+):(\d+):\s+)?(.*?)(?:\s+@\s+0x([0-9a-fA-F]+))?(?:\s+in\s+(\S+))?\s*$`,
+)
+
+// ParseStackTrace parses server stack trace into frames, skipping lines
+// that are not frames.
+func ParseStackTrace(stack string) []StackFrame {
+	var frames []StackFrame
+	for _, line := range strings.Split(stack, "\n") {
+		m := stackFrameRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		f := StackFrame{
+			File:     m[2],
+			Function: m[4],
+			Binary:   m[6],
+		}
+		f.Index, _ = strconv.Atoi(m[1])
+		if m[3] != "" {
+			f.Line, _ = strconv.Atoi(m[3])
+		}
+		if m[5] != "" {
+			f.Address, _ = strconv.ParseUint(m[5], 16, 64)
+		}
+		frames = append(frames, f)
+	}
+	return frames
+}
@@ -0,0 +1,207 @@
+package ch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ExplainKind is kind of EXPLAIN query.
+type ExplainKind byte
+
+// Possible explain kinds.
+const (
+	ExplainPlan     ExplainKind = iota // EXPLAIN PLAN, tree of query plan steps
+	ExplainPipeline                    // EXPLAIN PIPELINE, tree of processors
+	ExplainEstimate                    // EXPLAIN ESTIMATE, rows and parts to read
+	ExplainAST                         // EXPLAIN AST, tree of syntax nodes
+)
+
+func (k ExplainKind) String() string {
+	switch k {
+	case ExplainPlan:
+		return "PLAN"
+	case ExplainPipeline:
+		return "PIPELINE"
+	case ExplainEstimate:
+		return "ESTIMATE"
+	case ExplainAST:
+		return "AST"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ExplainNode is node of tree output of EXPLAIN, like plan step.
+type ExplainNode struct {
+	Text     string // line of output without indentation
+	Children []*ExplainNode
+}
+
+// Name returns first word of node text, like ReadFromMergeTree for
+// "ReadFromMergeTree (default.t)".
+func (n *ExplainNode) Name() string {
+	name, _, _ := strings.Cut(n.Text, " ")
+	return name
+}
+
+// Walk calls f for node and its descendants in depth-first order, stopping
+// descend if f returns false.
+func (n *ExplainNode) Walk(f func(n *ExplainNode, depth int) bool) {
+	n.walk(f, 0)
+}
+
+func (n *ExplainNode) walk(f func(n *ExplainNode, depth int) bool, depth int) {
+	if !f(n, depth) {
+		return
+	}
+	for _, c := range n.Children {
+		c.walk(f, depth+1)
+	}
+}
+
+// TableEstimate is row of EXPLAIN ESTIMATE output.
+type TableEstimate struct {
+	Database string
+	Table    string
+	Parts    uint64
+	Rows     uint64
+	Marks    uint64
+}
+
+// ExplainResult is parsed output of EXPLAIN query.
+type ExplainResult struct {
+	Kind ExplainKind
+	// Lines of raw output, not set for ExplainEstimate.
+	Lines []string
+	// Nodes are top-level nodes of tree output, not set for
+	// ExplainEstimate.
+	Nodes []*ExplainNode
+	// Estimates of tables, set only for ExplainEstimate.
+	Estimates []TableEstimate
+}
+
+// Find returns nodes of tree output with name, see ExplainNode.Name.
+func (r *ExplainResult) Find(name string) []*ExplainNode {
+	var found []*ExplainNode
+	for _, n := range r.Nodes {
+		n.Walk(func(n *ExplainNode, depth int) bool {
+			if n.Name() == name {
+				found = append(found, n)
+			}
+			return true
+		})
+	}
+	return found
+}
+
+// String returns tree output with two spaces indentation per level, which
+// is stable for comparison.
+func (r *ExplainResult) String() string {
+	var b strings.Builder
+	for _, n := range r.Nodes {
+		n.Walk(func(n *ExplainNode, depth int) bool {
+			b.WriteString(strings.Repeat("  ", depth))
+			b.WriteString(n.Text)
+			b.WriteByte('\n')
+			return true
+		})
+	}
+	return b.String()
+}
+
+// ParseExplainTree parses tree output of EXPLAIN, where nesting is denoted
+// by indentation with spaces.
+func ParseExplainTree(lines []string) []*ExplainNode {
+	type level struct {
+		indent int
+		node   *ExplainNode
+	}
+	var (
+		roots []*ExplainNode
+		stack []level
+	)
+	for _, line := range lines {
+		text := strings.TrimLeft(line, " ")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		indent := len(line) - len(text)
+		n := &ExplainNode{Text: strings.TrimRight(text, " ")}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, n)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, n)
+		}
+		stack = append(stack, level{indent: indent, node: n})
+	}
+	return roots
+}
+
+// Explain executes EXPLAIN query of kind for query and returns parsed
+// output.
+func Explain(ctx context.Context, c *Client, query string, kind ExplainKind) (*ExplainResult, error) {
+	if query == "" {
+		return nil, errors.New("empty query")
+	}
+	if kind > ExplainAST {
+		return nil, errors.Errorf("unknown explain kind %d", kind)
+	}
+	r := &ExplainResult{Kind: kind}
+	body := "EXPLAIN " + kind.String() + " " + query
+	if kind == ExplainEstimate {
+		var (
+			database, table    proto.ColStr
+			parts, rows, marks proto.ColUInt64
+		)
+		if err := c.Do(ctx, Query{
+			Body: body,
+			Result: proto.Results{
+				{Name: "database", Data: &database},
+				{Name: "table", Data: &table},
+				{Name: "parts", Data: &parts},
+				{Name: "rows", Data: &rows},
+				{Name: "marks", Data: &marks},
+			},
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				for i := 0; i < table.Rows(); i++ {
+					r.Estimates = append(r.Estimates, TableEstimate{
+						Database: database.Row(i),
+						Table:    table.Row(i),
+						Parts:    parts.Row(i),
+						Rows:     rows.Row(i),
+						Marks:    marks.Row(i),
+					})
+				}
+				return nil
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "explain")
+		}
+		return r, nil
+	}
+	var explain proto.ColStr
+	if err := c.Do(ctx, Query{
+		Body: body,
+		Result: proto.Results{
+			{Name: "explain", Data: &explain},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			for i := 0; i < explain.Rows(); i++ {
+				r.Lines = append(r.Lines, explain.Row(i))
+			}
+			return nil
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "explain")
+	}
+	r.Nodes = ParseExplainTree(r.Lines)
+	return r, nil
+}
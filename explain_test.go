@@ -0,0 +1,84 @@
+package ch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExplainTree(t *testing.T) {
+	nodes := ParseExplainTree(strings.Split(`Expression ((Projection + Before ORDER BY))
+  Filter (WHERE)
+    ReadFromMergeTree (default.t)
+      Indexes:
+        PrimaryKey
+  Filter (extra)
+`, "\n"))
+	r := &ExplainResult{Nodes: nodes}
+	require.Len(t, nodes, 1)
+	require.Equal(t, "Expression", nodes[0].Name())
+	require.Len(t, nodes[0].Children, 2)
+	require.Equal(t, "ReadFromMergeTree (default.t)", nodes[0].Children[0].Children[0].Text)
+	require.Len(t, r.Find("Filter"), 2)
+	require.Len(t, r.Find("PrimaryKey"), 1)
+	require.Empty(t, r.Find("Sorting"))
+
+	// AST is indented by single space.
+	ast := &ExplainResult{Nodes: ParseExplainTree([]string{
+		"SelectWithUnionQuery (children 1)",
+		" ExpressionList (children 1)",
+		"  SelectQuery (children 1)",
+		"   ExpressionList (children 1)",
+		"    Literal UInt64_1",
+	})}
+	require.Equal(t, "SelectWithUnionQuery (children 1)\n"+
+		"  ExpressionList (children 1)\n"+
+		"    SelectQuery (children 1)\n"+
+		"      ExpressionList (children 1)\n"+
+		"        Literal UInt64_1\n", ast.String())
+
+	var depths []int
+	ast.Nodes[0].Walk(func(n *ExplainNode, depth int) bool {
+		depths = append(depths, depth)
+		return n.Name() != "SelectQuery"
+	})
+	require.Equal(t, []int{0, 1, 2}, depths)
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_explain (v UInt64) ENGINE = MergeTree ORDER BY v",
+	}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_explain SELECT number FROM numbers(100)",
+	}))
+	const query = "SELECT v FROM test_explain WHERE v > 10"
+
+	plan, err := Explain(ctx, conn, query, ExplainPlan)
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.Lines)
+	require.NotEmpty(t, plan.Find("ReadFromMergeTree"))
+
+	pipeline, err := Explain(ctx, conn, query, ExplainPipeline)
+	require.NoError(t, err)
+	require.NotEmpty(t, pipeline.Nodes)
+
+	ast, err := Explain(ctx, conn, query, ExplainAST)
+	require.NoError(t, err)
+	require.Equal(t, "SelectWithUnionQuery", ast.Nodes[0].Name())
+
+	estimate, err := Explain(ctx, conn, query, ExplainEstimate)
+	require.NoError(t, err)
+	require.Len(t, estimate.Estimates, 1)
+	require.Equal(t, "test_explain", estimate.Estimates[0].Table)
+	require.Equal(t, uint64(1), estimate.Estimates[0].Parts)
+
+	_, err = Explain(ctx, conn, query, ExplainKind(100))
+	require.Error(t, err)
+}
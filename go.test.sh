@@ -2,8 +2,11 @@
 
 set -e
 
+# Nested modules use local ch-go via go.work.
+pkgs="./... ./charrow/... ./chprom/..."
+
 echo "test"
-go test --timeout 5m ./...
+go test --timeout 5m $pkgs
 
 echo "test -race"
-go test --timeout 5m -race ./...
+go test --timeout 5m -race $pkgs
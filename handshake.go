@@ -13,10 +13,16 @@ import (
 	"github.com/ClickHouse/ch-go/proto"
 )
 
-func (c *Client) encodeAddendum() {
+func (c *Client) encodeAddendum(chunkedSend, chunkedRecv bool) {
 	if proto.FeatureQuotaKey.In(c.protocolVersion) {
 		c.buf.PutString(c.quotaKey)
 	}
+	if proto.FeatureChunkedPackets.In(c.protocolVersion) {
+		c.encodeChunked(chunkedSend, chunkedRecv)
+	}
+	if proto.FeatureVersionedParallelReplicas.In(c.protocolVersion) {
+		c.buf.PutUVarInt(proto.ParallelReplicasVersion)
+	}
 }
 
 func (c *Client) handshake(ctx context.Context) error {
@@ -75,6 +81,15 @@ func (c *Client) handshake(ctx context.Context) error {
 			// Downgrade to server version.
 			c.protocolVersion = c.server.Revision
 		}
+		if c.location == nil {
+			// Using server time zone by default.
+			loc, err := c.server.Location()
+			if err != nil {
+				c.lg.Warn("Failed to load server time zone, using local", zap.Error(err))
+			} else {
+				c.location = loc
+			}
+		}
 
 		c.lg.Debug("Connected",
 			zap.Int("protocol_version", c.protocolVersion),
@@ -98,11 +113,16 @@ func (c *Client) handshake(ctx context.Context) error {
 			)
 		}
 		if proto.FeatureAddendum.In(c.protocolVersion) {
+			chunkedSend, chunkedRecv, err := c.negotiateChunked()
+			if err != nil {
+				return errors.Wrap(err, "chunked")
+			}
 			c.lg.Debug("Writing addendum")
-			c.encodeAddendum()
+			c.encodeAddendum(chunkedSend, chunkedRecv)
 			if err := c.flush(wgCtx); err != nil {
 				return errors.Wrap(err, "flush")
 			}
+			c.enableChunked(chunkedSend, chunkedRecv)
 		}
 
 		return nil
@@ -25,3 +25,21 @@ func TestDial_Exception(t *testing.T) {
 	require.ErrorAs(t, err, &e)
 	require.True(t, IsErr(err, proto.ErrAuthenticationFailed))
 }
+
+func TestClient_ServerInfo(t *testing.T) {
+	t.Parallel()
+	conn := ConnOpt(t, Options{ProtocolVersion: 54451})
+	info := conn.ServerInfo()
+	require.NotEmpty(t, info.Name)
+	require.Positive(t, info.Major)
+	require.Equal(t, 54451, conn.ProtocolVersion())
+	require.True(t, conn.Supports(proto.FeatureBlockInfo))
+	require.False(t, conn.Supports(proto.FeatureParameters))
+
+	var version proto.ColStr
+	require.NoError(t, conn.Do(context.Background(), Query{
+		Body:   "SELECT version() as v",
+		Result: proto.Results{{Name: "v", Data: &version}},
+	}))
+	require.Contains(t, version.Row(0), info.Version())
+}
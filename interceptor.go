@@ -0,0 +1,24 @@
+package ch
+
+import "context"
+
+// DoFunc performs Query, like Client.Do.
+type DoFunc func(ctx context.Context, q Query) error
+
+// QueryInterceptor wraps next DoFunc, allowing to implement cross-cutting
+// concerns like query rewriting, settings injection or slow query logging.
+//
+// Interceptor should call next to actually perform query.
+type QueryInterceptor func(next DoFunc) DoFunc
+
+// ChainQueryInterceptors composes interceptors into single one.
+//
+// First interceptor is outermost, i.e. called first.
+func ChainQueryInterceptors(interceptors ...QueryInterceptor) QueryInterceptor {
+	return func(next DoFunc) DoFunc {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i](next)
+		}
+		return next
+	}
+}
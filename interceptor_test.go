@@ -0,0 +1,51 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainQueryInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) QueryInterceptor {
+		return func(next DoFunc) DoFunc {
+			return func(ctx context.Context, q Query) error {
+				calls = append(calls, name)
+				q.Settings = append(q.Settings, Setting{Key: name})
+				return next(ctx, q)
+			}
+		}
+	}
+	do := ChainQueryInterceptors(
+		interceptor("first"),
+		interceptor("second"),
+	)(func(ctx context.Context, q Query) error {
+		calls = append(calls, "do")
+		require.Equal(t, []Setting{{Key: "first"}, {Key: "second"}}, q.Settings)
+		return nil
+	})
+
+	require.NoError(t, do(context.Background(), Query{}))
+	require.Equal(t, []string{"first", "second", "do"}, calls)
+}
+
+func TestClient_Do_interceptor(t *testing.T) {
+	ctx := context.Background()
+	var body string
+	conn := ConnOpt(t, Options{
+		QueryInterceptor: func(next DoFunc) DoFunc {
+			return func(ctx context.Context, q Query) error {
+				body = q.Body
+				q.Body = "SELECT 1"
+				return next(ctx, q)
+			}
+		},
+	})
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT invalid",
+		Result: discardResult(),
+	}))
+	require.Equal(t, "SELECT invalid", body)
+}
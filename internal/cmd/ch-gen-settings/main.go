@@ -0,0 +1,188 @@
+// Binary ch-gen-settings generates catalog of known ClickHouse settings.
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-faster/errors"
+)
+
+// Kind of setting value, matches ch.SettingKind.
+type Kind string
+
+const (
+	KindBool         Kind = "Bool"
+	KindUInt         Kind = "UInt"
+	KindFloat        Kind = "Float"
+	KindString       Kind = "String"
+	KindSeconds      Kind = "Seconds"
+	KindMilliseconds Kind = "Milliseconds"
+	KindEnum         Kind = "Enum"
+	KindMaxThreads   Kind = "MaxThreads"
+)
+
+// Setting definition.
+type Setting struct {
+	Name        string
+	Kind        Kind
+	Description string
+	Values      []string // of enum
+}
+
+// Func returns name of constructor function.
+func (s Setting) Func() string {
+	var b strings.Builder
+	b.WriteString("Setting")
+	for _, part := range strings.Split(s.Name, "_") {
+		switch part {
+		case "ms":
+			b.WriteString("Ms")
+			continue
+		case "ttl":
+			b.WriteString("TTL")
+			continue
+		case "ast":
+			b.WriteString("AST")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// GoType returns type of constructor argument.
+func (s Setting) GoType() string {
+	switch s.Kind {
+	case KindBool:
+		return "bool"
+	case KindUInt:
+		return "uint64"
+	case KindFloat:
+		return "float64"
+	case KindSeconds, KindMilliseconds:
+		return "time.Duration"
+	case KindMaxThreads:
+		return "int"
+	default:
+		return "string"
+	}
+}
+
+// Generic returns generic constructor for value.
+func (s Setting) Generic() string {
+	switch s.Kind {
+	case KindEnum:
+		return "SettingString"
+	case KindMaxThreads:
+		return "SettingInt"
+	default:
+		return "Setting" + string(s.Kind)
+	}
+}
+
+// Quoted returns quoted enum values.
+func (s Setting) Quoted() string {
+	var values []string
+	for _, v := range s.Values {
+		values = append(values, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(values, ", ")
+}
+
+var overflowModes = []string{"throw", "break"}
+
+// settings is catalog of common settings.
+//
+// See https://clickhouse.com/docs/en/operations/settings/settings.
+var settings = []Setting{
+	{Name: "max_threads", Kind: KindMaxThreads, Description: "maximum number of query processing threads, zero means auto"},
+	{Name: "max_block_size", Kind: KindUInt, Description: "maximum number of rows in block for reading"},
+	{Name: "max_insert_block_size", Kind: KindUInt, Description: "maximum number of rows in block for insertion"},
+	{Name: "max_memory_usage", Kind: KindUInt, Description: "maximum amount of memory in bytes for query"},
+	{Name: "max_execution_time", Kind: KindSeconds, Description: "maximum query execution time"},
+	{Name: "max_rows_to_read", Kind: KindUInt, Description: "maximum number of rows that can be read from table"},
+	{Name: "max_bytes_to_read", Kind: KindUInt, Description: "maximum number of bytes that can be read from table"},
+	{Name: "max_result_rows", Kind: KindUInt, Description: "limit on number of rows in result"},
+	{Name: "max_result_bytes", Kind: KindUInt, Description: "limit on number of bytes in result"},
+	{Name: "result_overflow_mode", Kind: KindEnum, Values: overflowModes, Description: "what to do if result limit is exceeded"},
+	{Name: "read_overflow_mode", Kind: KindEnum, Values: overflowModes, Description: "what to do if read limit is exceeded"},
+	{Name: "timeout_overflow_mode", Kind: KindEnum, Values: overflowModes, Description: "what to do if execution time limit is exceeded"},
+	{Name: "max_query_size", Kind: KindUInt, Description: "maximum number of bytes of query string parsed by SQL parser"},
+	{Name: "max_ast_depth", Kind: KindUInt, Description: "maximum nesting depth of query syntactic tree"},
+	{Name: "max_bytes_before_external_group_by", Kind: KindUInt, Description: "memory threshold in bytes for external GROUP BY"},
+	{Name: "max_bytes_before_external_sort", Kind: KindUInt, Description: "memory threshold in bytes for external ORDER BY"},
+	{Name: "max_partitions_per_insert_block", Kind: KindUInt, Description: "maximum number of partitions in single inserted block"},
+	{Name: "readonly", Kind: KindUInt, Description: "restricts permissions for non-DDL (1) or all (2) changing queries"},
+	{Name: "priority", Kind: KindUInt, Description: "priority of query, lower value is higher priority"},
+	{Name: "send_logs_level", Kind: KindEnum, Values: []string{"none", "fatal", "error", "warning", "information", "debug", "trace", "test"}, Description: "minimum level of server logs sent to client"},
+	{Name: "log_queries", Kind: KindBool, Description: "log queries to system.query_log"},
+	{Name: "log_comment", Kind: KindString, Description: "comment of query in system.query_log"},
+	{Name: "async_insert", Kind: KindBool, Description: "enables asynchronous inserts"},
+	{Name: "wait_for_async_insert", Kind: KindBool, Description: "wait for processing of asynchronous insert"},
+	{Name: "async_insert_busy_timeout_ms", Kind: KindMilliseconds, Description: "maximum time to wait before dumping collected asynchronous inserts"},
+	{Name: "insert_quorum", Kind: KindUInt, Description: "number of replicas that should acknowledge insert"},
+	{Name: "insert_deduplicate", Kind: KindBool, Description: "enables deduplication of inserted blocks"},
+	{Name: "insert_deduplication_token", Kind: KindString, Description: "user provided token for deduplication of inserts"},
+	{Name: "input_format_null_as_default", Kind: KindBool, Description: "replace NULL with default values for non-nullable columns"},
+	{Name: "date_time_input_format", Kind: KindEnum, Values: []string{"basic", "best_effort", "best_effort_us"}, Description: "parser of text representation of date and time"},
+	{Name: "optimize_read_in_order", Kind: KindBool, Description: "read data in order of sorting key for ORDER BY"},
+	{Name: "optimize_aggregation_in_order", Kind: KindBool, Description: "aggregate data in order of sorting key for GROUP BY"},
+	{Name: "join_use_nulls", Kind: KindBool, Description: "fill non-joined rows with NULL instead of default values"},
+	{Name: "join_algorithm", Kind: KindString, Description: "comma separated list of JOIN algorithms"},
+	{Name: "use_uncompressed_cache", Kind: KindBool, Description: "use cache of uncompressed blocks"},
+	{Name: "use_query_cache", Kind: KindBool, Description: "use query cache"},
+	{Name: "query_cache_ttl", Kind: KindSeconds, Description: "time to live of query cache entries"},
+	{Name: "final", Kind: KindBool, Description: "apply FINAL modifier to all tables of query"},
+	{Name: "distributed_product_mode", Kind: KindEnum, Values: []string{"deny", "local", "global", "allow"}, Description: "behavior of distributed subqueries"},
+	{Name: "prefer_localhost_replica", Kind: KindBool, Description: "prefer local replica for distributed queries"},
+	{Name: "load_balancing", Kind: KindEnum, Values: []string{"random", "nearest_hostname", "hostname_levenshtein_distance", "in_order", "first_or_random", "round_robin"}, Description: "algorithm of replica selection for distributed queries"},
+	{Name: "max_parallel_replicas", Kind: KindUInt, Description: "maximum number of replicas for each shard for query"},
+	{Name: "connect_timeout", Kind: KindSeconds, Description: "connection timeout of distributed queries"},
+	{Name: "receive_timeout", Kind: KindSeconds, Description: "timeout for receiving data from network"},
+	{Name: "send_timeout", Kind: KindSeconds, Description: "timeout for sending data to network"},
+	{Name: "mutations_sync", Kind: KindUInt, Description: "wait for mutations, 1 for current replica and 2 for all replicas"},
+	{Name: "alter_sync", Kind: KindUInt, Description: "wait for ALTER actions, 1 for current replica and 2 for all replicas"},
+	{Name: "session_timezone", Kind: KindString, Description: "time zone of session"},
+	{Name: "workload", Kind: KindString, Description: "name of workload for resource scheduling"},
+	{Name: "allow_experimental_live_view", Kind: KindBool, Description: "enables LIVE VIEW"},
+	{Name: "live_view_heartbeat_interval", Kind: KindSeconds, Description: "interval of heartbeats of WATCH query"},
+	{Name: "partial_result_update_duration_ms", Kind: KindMilliseconds, Description: "interval of partial results of query"},
+	{Name: "implicit_transaction", Kind: KindBool, Description: "executes query in separate transaction if not in transaction"},
+	{Name: "throw_on_unsupported_query_inside_transaction", Kind: KindBool, Description: "fail queries that are not supported inside transaction"},
+}
+
+//go:embed settings.go.tmpl
+var settingsTemplate string
+
+func run(name string) error {
+	sort.SliceStable(settings, func(i, j int) bool {
+		return settings[i].Name < settings[j].Name
+	})
+	t := template.Must(template.New("settings").Parse(settingsTemplate))
+	out := new(bytes.Buffer)
+	if err := t.Execute(out, settings); err != nil {
+		return errors.Wrap(err, "execute")
+	}
+	data, err := format.Source(out.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "format")
+	}
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return errors.Wrap(err, "write file")
+	}
+	return nil
+}
+
+func main() {
+	if err := run("settings_gen.go"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %+v\n", err)
+		os.Exit(2)
+	}
+}
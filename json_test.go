@@ -0,0 +1,98 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestJSON(t *testing.T) {
+	conn := ConnOpt(t, Options{
+		Settings: []Setting{
+			SettingInt("allow_experimental_json_type", 1),
+			SettingInt("output_format_native_write_json_as_string", 1),
+		},
+	})
+	if v := conn.ServerInfo(); (v.Major < 24) || (v.Major == 24 && v.Minor < 10) {
+		t.Skip("Skipping (not supported)")
+	}
+	type Event struct {
+		Name  string `json:"name"`
+		Count int64  `json:"count"`
+	}
+	ctx := context.Background()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_json (v JSON) ENGINE = Memory",
+	}))
+	data := proto.NewJSONOf[Event]()
+	require.NoError(t, data.AppendValue(Event{Name: "foo", Count: 1}))
+	require.NoError(t, data.AppendValue(Event{Name: "bar", Count: 2}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_json VALUES",
+		Input: proto.Input{
+			{Name: "v", Data: data},
+		},
+	}))
+
+	got := proto.NewJSONOf[Event]()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT v FROM test_json",
+		Result: proto.Results{
+			{Name: "v", Data: got},
+		},
+	}))
+	require.Equal(t, 2, got.Rows())
+	for i := 0; i < got.Rows(); i++ {
+		v, err := got.Value(i)
+		require.NoError(t, err)
+		expected, err := data.Value(i)
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+}
+
+func TestJSON_object(t *testing.T) {
+	conn := ConnOpt(t, Options{
+		Settings: []Setting{
+			SettingInt("allow_experimental_json_type", 1),
+		},
+	})
+	if v := conn.ServerInfo(); (v.Major < 24) || (v.Major == 24 && v.Minor < 10) {
+		t.Skip("Skipping (not supported)")
+	}
+	ctx := context.Background()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_json_object (v JSON(max_dynamic_paths=1, a.b UInt32)) ENGINE = Memory",
+	}))
+	var data proto.ColJSONStr
+	data.Append(`{"a":{"b":1},"c":"foo","d":[1,2]}`)
+	data.Append(`{"a":{"b":2},"e":{"f":true}}`)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_json_object VALUES",
+		Input: proto.Input{
+			{Name: "v", Data: data},
+		},
+	}))
+
+	// Object serialization, as output_format_native_write_json_as_string
+	// is not set.
+	got := proto.NewJSONOf[map[string]any]()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT v FROM test_json_object",
+		Result: proto.Results{
+			{Name: "v", Data: got},
+		},
+	}))
+	require.Equal(t, 2, got.Rows())
+	for i, expected := range []map[string]any{
+		{"a": map[string]any{"b": float64(1)}, "c": "foo", "d": []any{float64(1), float64(2)}},
+		{"a": map[string]any{"b": float64(2)}, "e": map[string]any{"f": true}},
+	} {
+		v, err := got.Value(i)
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+}
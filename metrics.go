@@ -0,0 +1,82 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ClickHouse/ch-go/otelch"
+)
+
+// clientMetrics are OpenTelemetry metric instruments of Client.
+type clientMetrics struct {
+	duration metric.Float64Histogram
+	active   metric.Int64UpDownCounter
+	errors   metric.Int64Counter
+	rows     metric.Int64Counter
+	bytes    metric.Int64Counter
+}
+
+func newClientMetrics(m metric.Meter) (*clientMetrics, error) {
+	var (
+		c   clientMetrics
+		err error
+	)
+	if c.duration, err = m.Float64Histogram(otelch.QueryDurationMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of query execution"),
+	); err != nil {
+		return nil, errors.Wrap(err, "duration")
+	}
+	if c.active, err = m.Int64UpDownCounter(otelch.QueryActiveMetric,
+		metric.WithDescription("Count of queries in progress"),
+	); err != nil {
+		return nil, errors.Wrap(err, "active")
+	}
+	if c.errors, err = m.Int64Counter(otelch.QueryErrorsMetric,
+		metric.WithDescription("Count of failed queries"),
+	); err != nil {
+		return nil, errors.Wrap(err, "errors")
+	}
+	if c.rows, err = m.Int64Counter(otelch.RowsMetric,
+		metric.WithDescription("Rows processed by server, reported by progress packets"),
+	); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	if c.bytes, err = m.Int64Counter(otelch.BytesMetric,
+		metric.WithUnit("By"),
+		metric.WithDescription("Bytes processed by server, reported by progress packets"),
+	); err != nil {
+		return nil, errors.Wrap(err, "bytes")
+	}
+	return &c, nil
+}
+
+// queryStart records start of query and returns function that
+// should be called on query end.
+func (m *clientMetrics) queryStart(ctx context.Context) func(err error) {
+	start := time.Now()
+	m.active.Add(ctx, 1)
+	return func(err error) {
+		// Using background context, because query context can be already
+		// canceled, but measurements should still be recorded.
+		ctx := context.WithoutCancel(ctx)
+		m.active.Add(ctx, -1)
+		m.duration.Record(ctx, time.Since(start).Seconds())
+		if err == nil {
+			return
+		}
+		code := -1
+		if exc, ok := AsException(err); ok {
+			code = int(exc.Code)
+		}
+		m.errors.Add(ctx, 1, metric.WithAttributes(otelch.ErrorCode(code)))
+	}
+}
+
+func (m *clientMetrics) progress(ctx context.Context, rows, bytes uint64) {
+	m.rows.Add(ctx, int64(rows))
+	m.bytes.Add(ctx, int64(bytes))
+}
@@ -0,0 +1,72 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/ClickHouse/ch-go/otelch"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func collectMetrics(t testing.TB, r sdkmetric.Reader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, r.Collect(context.Background(), &rm))
+
+	out := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m.Data
+		}
+	}
+	return out
+}
+
+func TestClientMetrics(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m, err := newClientMetrics(mp.Meter(otelch.Name))
+	require.NoError(t, err)
+
+	end := m.queryStart(ctx)
+	m.progress(ctx, 10, 100)
+	data := collectMetrics(t, reader)
+	require.Equal(t, int64(1), data[otelch.QueryActiveMetric].(metricdata.Sum[int64]).DataPoints[0].Value)
+	end(nil)
+
+	end = m.queryStart(ctx)
+	end(errors.Wrap(&Exception{Code: proto.ErrTimeoutExceeded}, "query"))
+
+	data = collectMetrics(t, reader)
+	require.Equal(t, int64(0), data[otelch.QueryActiveMetric].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, int64(10), data[otelch.RowsMetric].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, int64(100), data[otelch.BytesMetric].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, uint64(2), data[otelch.QueryDurationMetric].(metricdata.Histogram[float64]).DataPoints[0].Count)
+
+	errs := data[otelch.QueryErrorsMetric].(metricdata.Sum[int64]).DataPoints
+	require.Len(t, errs, 1)
+	require.Equal(t, int64(1), errs[0].Value)
+	code, ok := errs[0].Attributes.Value(otelch.ErrorCodeKey)
+	require.True(t, ok)
+	require.Equal(t, int64(proto.ErrTimeoutExceeded), code.AsInt64())
+}
+
+func TestClient_Do_metrics(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	conn := ConnOpt(t, Options{
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, conn.Do(ctx, Query{Body: "SELECT 1", Result: discardResult()}))
+
+	data := collectMetrics(t, reader)
+	require.Equal(t, uint64(1), data[otelch.QueryDurationMetric].(metricdata.Histogram[float64]).DataPoints[0].Count)
+}
@@ -0,0 +1,169 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ErrMutationNotFound means that mutation is not found in system.mutations,
+// e.g. it was killed.
+var ErrMutationNotFound = errors.New("mutation not found")
+
+// MutationError is returned by WaitMutation if mutation failed.
+//
+// Server keeps retrying failed mutation until it is killed, so
+// MutationError reports latest failure.
+type MutationError struct {
+	Table    string
+	ID       string
+	Reason   string     // latest_fail_reason
+	FailTime time.Time  // latest_fail_time
+	Err      *Exception // parsed from Reason, if possible
+}
+
+func (e *MutationError) Error() string {
+	return fmt.Sprintf("mutation %s of %s failed: %s", e.ID, e.Table, e.Reason)
+}
+
+// Unwrap returns parsed exception, so HasCode and IsErr can be used.
+func (e *MutationError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// failReasonRegexp matches "Code: 395. DB::Exception: message (NAME) (version 23.8.1)".
+var failReasonRegexp = regexp.MustCompile(`(?s)^Code: (\d+)\. ([\w:]+): (.*?)(?: \(version .*\))?$`)
+
+// parseFailReason parses exception from latest_fail_reason of mutation.
+func parseFailReason(reason string) *Exception {
+	m := failReasonRegexp.FindStringSubmatch(strings.TrimSpace(reason))
+	if m == nil {
+		return nil
+	}
+	code, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil
+	}
+	return &Exception{
+		Code:    proto.Error(code),
+		Name:    m[2],
+		Message: m[3],
+	}
+}
+
+// mutationTable returns quoted database and table literals for query on
+// system tables.
+func mutationTable(table string) (db, name string) {
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		return string(proto.LitString(table[:i])), string(proto.LitString(table[i+1:]))
+	}
+	return "currentDatabase()", string(proto.LitString(table))
+}
+
+// LatestMutation returns id of latest mutation of table, which can be
+// database-qualified, like "db.table".
+//
+// Can be used to get id of mutation after ALTER TABLE ... DELETE or UPDATE
+// query, if table has no concurrent mutations.
+func LatestMutation(ctx context.Context, c *Client, table string) (string, error) {
+	db, name := mutationTable(table)
+	var (
+		id     proto.ColStr
+		latest string
+	)
+	if err := c.Do(ctx, Query{
+		Body: "SELECT mutation_id FROM system.mutations WHERE database = " + db + " AND table = " + name +
+			" ORDER BY create_time DESC, mutation_id DESC LIMIT 1",
+		Result: proto.Results{
+			{Name: "mutation_id", Data: &id},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			if id.Rows() > 0 {
+				latest = id.Row(0)
+			}
+			return nil
+		},
+	}); err != nil {
+		return "", errors.Wrap(err, "query")
+	}
+	if latest == "" {
+		return "", ErrMutationNotFound
+	}
+	return latest, nil
+}
+
+// WaitMutation waits until mutation of table, which can be
+// database-qualified, is done, polling system.mutations with backoff.
+//
+// Returns *MutationError if mutation failed, ErrMutationNotFound if there
+// is no such mutation, or context error.
+func WaitMutation(ctx context.Context, c *Client, table, mutationID string) error {
+	db, name := mutationTable(table)
+	var (
+		done       proto.ColUInt8
+		failTime   proto.ColDateTime
+		failReason proto.ColStr
+
+		found    bool
+		isDone   bool
+		reason   string
+		failedAt time.Time
+	)
+	query := Query{
+		Body: "SELECT is_done, latest_fail_time, latest_fail_reason FROM system.mutations WHERE database = " + db +
+			" AND table = " + name + " AND mutation_id = " + string(proto.LitString(mutationID)),
+		Result: proto.Results{
+			{Name: "is_done", Data: &done},
+			{Name: "latest_fail_time", Data: &failTime},
+			{Name: "latest_fail_reason", Data: &failReason},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			if done.Rows() == 0 {
+				return nil
+			}
+			found = true
+			isDone = done.Row(0) == 1
+			reason = failReason.Row(0)
+			failedAt = failTime.Row(0)
+			return nil
+		},
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 50 * time.Millisecond
+	b.MaxInterval = 2 * time.Second
+	b.MaxElapsedTime = 0 // until context is done
+	return backoff.Retry(func() error {
+		found = false
+		if err := c.Do(ctx, query); err != nil {
+			return backoff.Permanent(errors.Wrap(err, "query"))
+		}
+		switch {
+		case !found:
+			return backoff.Permanent(ErrMutationNotFound)
+		case isDone:
+			return nil
+		case reason != "":
+			return backoff.Permanent(&MutationError{
+				Table:    table,
+				ID:       mutationID,
+				Reason:   reason,
+				FailTime: failedAt,
+				Err:      parseFailReason(reason),
+			})
+		default:
+			return errors.New("mutation is not done")
+		}
+	}, backoff.WithContext(b, ctx))
+}
@@ -0,0 +1,89 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestParseFailReason(t *testing.T) {
+	e := parseFailReason("Code: 395. DB::Exception: Value passed to 'throwIf' function is non-zero: " +
+		"while executing 'FUNCTION throwIf(1 :: 1) -> throwIf(1) UInt8 : 2'. (FUNCTION_THROW_IF_VALUE_IS_NON_ZERO) " +
+		"(version 23.8.1.2992 (official build))")
+	require.NotNil(t, e)
+	require.Equal(t, proto.ErrFunctionThrowIfValueIsNonZero, e.Code)
+	require.Equal(t, "DB::Exception", e.Name)
+	require.Equal(t, "Value passed to 'throwIf' function is non-zero: "+
+		"while executing 'FUNCTION throwIf(1 :: 1) -> throwIf(1) UInt8 : 2'. (FUNCTION_THROW_IF_VALUE_IS_NON_ZERO)", e.Message)
+
+	require.Nil(t, parseFailReason(""))
+	require.Nil(t, parseFailReason("unknown failure"))
+
+	const reason = "Code: 395. DB::Exception: boom"
+	err := error(&MutationError{
+		Table:  "t",
+		ID:     "mutation_2.txt",
+		Reason: reason,
+		Err:    parseFailReason(reason),
+	})
+	require.True(t, HasCode(err, proto.ErrFunctionThrowIfValueIsNonZero))
+	require.ErrorIs(t, err, ErrServerException)
+	require.EqualError(t, err, "mutation mutation_2.txt of t failed: Code: 395. DB::Exception: boom")
+}
+
+func TestMutationTable(t *testing.T) {
+	db, name := mutationTable("t")
+	require.Equal(t, "currentDatabase()", db)
+	require.Equal(t, "'t'", name)
+
+	db, name = mutationTable("db.t'")
+	require.Equal(t, "'db'", db)
+	require.Equal(t, `'t\''`, name)
+}
+
+func TestWaitMutation(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn := Conn(t)
+
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_mutation (v UInt64) ENGINE = MergeTree ORDER BY v",
+	}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_mutation SELECT number FROM numbers(10)",
+	}))
+
+	_, err := LatestMutation(ctx, conn, "test_mutation")
+	require.ErrorIs(t, err, ErrMutationNotFound)
+
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "ALTER TABLE test_mutation DELETE WHERE v < 5",
+	}))
+	id, err := LatestMutation(ctx, conn, "test_mutation")
+	require.NoError(t, err)
+	require.NoError(t, WaitMutation(ctx, conn, "test_mutation", id))
+
+	require.ErrorIs(t, WaitMutation(ctx, conn, "test_mutation", "mutation_missing.txt"), ErrMutationNotFound)
+
+	t.Run("Failed", func(t *testing.T) {
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "ALTER TABLE test_mutation UPDATE v = throwIf(v > 0) WHERE 1",
+		}))
+		id, err := LatestMutation(ctx, conn, "test_mutation")
+		require.NoError(t, err)
+		err = WaitMutation(ctx, conn, "test_mutation", id)
+		var mutationErr *MutationError
+		require.ErrorAs(t, err, &mutationErr)
+		require.Equal(t, id, mutationErr.ID)
+		require.True(t, HasCode(err, proto.ErrFunctionThrowIfValueIsNonZero))
+
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "KILL MUTATION WHERE database = currentDatabase() AND table = 'test_mutation' AND mutation_id = '" + id + "'",
+		}))
+	})
+}
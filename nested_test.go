@@ -0,0 +1,55 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestNested(t *testing.T) {
+	ctx := context.Background()
+	conn := Conn(t)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_nested (events Nested(id UInt64, name String)) ENGINE = Memory",
+	}))
+
+	type Event struct {
+		ID   uint64
+		Name string
+	}
+	newEvents := func() *proto.ColNested[Event] {
+		return proto.NewNested[Event]("events",
+			proto.NestedFieldOf[Event, uint64]("id", new(proto.ColUInt64),
+				func(v Event) uint64 { return v.ID },
+				func(v *Event, f uint64) { v.ID = f },
+			),
+			proto.NestedFieldOf[Event, string]("name", new(proto.ColStr),
+				func(v Event) string { return v.Name },
+				func(v *Event, f string) { v.Name = f },
+			),
+		)
+	}
+	data := newEvents()
+	data.Append([]Event{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}})
+	data.Append(nil)
+	data.Append([]Event{{ID: 3, Name: "baz"}})
+
+	input := data.Input()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:  input.Into("test_nested"),
+		Input: input,
+	}))
+
+	got := newEvents()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT events.id, events.name FROM test_nested",
+		Result: got.Results(),
+	}))
+	require.Equal(t, data.Rows(), got.Rows())
+	for i := 0; i < data.Rows(); i++ {
+		require.Equal(t, data.Row(i), got.Row(i))
+	}
+}
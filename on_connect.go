@@ -0,0 +1,20 @@
+package ch
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+)
+
+// OnConnectExec returns Options.OnConnect hook that executes queries in
+// order, like "SET ROLE analyst" or "USE analytics".
+func OnConnectExec(queries ...string) func(ctx context.Context, c *Client) error {
+	return func(ctx context.Context, c *Client) error {
+		for _, q := range queries {
+			if err := c.Do(ctx, Query{Body: q}); err != nil {
+				return errors.Wrapf(err, "exec %q", q)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,24 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestOptions_OnConnect(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		OnConnect: OnConnectExec("USE system"),
+	})
+	var data proto.ColStr
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT currentDatabase() AS db",
+		Result: proto.Results{{Name: "db", Data: &data}},
+	}))
+	require.Equal(t, "system", data.Row(0))
+}
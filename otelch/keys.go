@@ -17,6 +17,20 @@ const (
 	RowsReceivedKey    = attribute.Key("ch.rows_received")
 	RowsKey            = attribute.Key("ch.rows")
 	BytesKey           = attribute.Key("ch.bytes")
+	TotalRowsKey       = attribute.Key("ch.total_rows")
+	WroteRowsKey       = attribute.Key("ch.wrote_rows")
+	WroteBytesKey      = attribute.Key("ch.wrote_bytes")
+	ElapsedKey         = attribute.Key("ch.elapsed_ns")
+	BlocksKey          = attribute.Key("ch.blocks")
+	RowsBeforeLimitKey = attribute.Key("ch.rows_before_limit")
+	ServerAddressKey   = attribute.Key("ch.server.address")
+	RouteDecisionKey   = attribute.Key("ch.route.decision")
+)
+
+// Span event names.
+const (
+	ProgressEvent = "Progress"
+	ProfileEvent  = "Profile"
 )
 
 // BlocksSent is cumulative blocks sent count during query execution.
@@ -114,3 +128,67 @@ func ServerName(v string) attribute.KeyValue {
 		Value: attribute.StringValue(v),
 	}
 }
+
+// TotalRows is approximate total rows to process.
+func TotalRows(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   TotalRowsKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// WroteRows is rows written.
+func WroteRows(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   WroteRowsKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// WroteBytes is bytes written.
+func WroteBytes(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   WroteBytesKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// Elapsed is server-side elapsed time in nanoseconds.
+func Elapsed(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   ElapsedKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// Blocks is count of blocks.
+func Blocks(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   BlocksKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// RowsBeforeLimit is rows count before LIMIT was applied.
+func RowsBeforeLimit(v uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   RowsBeforeLimitKey,
+		Value: attribute.Int64Value(int64(v)),
+	}
+}
+
+// ServerAddress attribute.
+func ServerAddress(v string) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   ServerAddressKey,
+		Value: attribute.StringValue(v),
+	}
+}
+
+// RouteDecision attribute.
+func RouteDecision(v string) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   RouteDecisionKey,
+		Value: attribute.StringValue(v),
+	}
+}
@@ -0,0 +1,12 @@
+package otelch
+
+// Metric instrument names.
+const (
+	QueryDurationMetric = "ch.query.duration"
+	QueryActiveMetric   = "ch.query.active"
+	QueryErrorsMetric   = "ch.query.errors"
+	RowsMetric          = "ch.rows"
+	BytesMetric         = "ch.bytes"
+	RouteMetric         = "ch.router.routes"
+	ReplicaDelayMetric  = "ch.replica.delay"
+)
@@ -13,11 +13,15 @@ import (
 
 // Ping server.
 //
-// Do not call concurrently with Do.
+// Waits for running query to finish, as Do.
 func (c *Client) Ping(ctx context.Context) (err error) {
 	if c.IsClosed() {
 		return ErrClosed
 	}
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
 	if c.otel {
 		newCtx, span := c.tracer.Start(ctx, "Ping",
 			trace.WithSpanKind(trace.SpanKindClient),
@@ -36,10 +40,12 @@ func (c *Client) Ping(ctx context.Context) (err error) {
 			span.End()
 		}()
 	}
+	c.stats.setState(ConnSending)
 	c.buf.Encode(proto.ClientCodePing)
 	if err := c.flush(ctx); err != nil {
 		return errors.Wrap(err, "flush")
 	}
+	c.stats.setState(ConnReceiving)
 	p, err := c.packet(ctx)
 	if err != nil {
 		return errors.Wrap(err, "read")
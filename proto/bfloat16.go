@@ -0,0 +1,33 @@
+package proto
+
+import (
+	"math"
+	"strconv"
+)
+
+// BFloat16 represents BFloat16 value, "brain floating point": upper 16 bits
+// of IEEE 754 single-precision float.
+//
+// https://clickhouse.com/docs/en/sql-reference/data-types/float#bfloat16
+type BFloat16 uint16
+
+// NewBFloat16 converts float32 to BFloat16.
+//
+// Lower 16 bits of mantissa are truncated, same as ClickHouse does.
+func NewBFloat16(v float32) BFloat16 {
+	bits := math.Float32bits(v)
+	if v != v {
+		// Preserve NaN, truncation can result in Inf.
+		return BFloat16(bits>>16) | 0x0040
+	}
+	return BFloat16(bits >> 16)
+}
+
+// Float32 returns float32 value of BFloat16.
+func (v BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(v) << 16)
+}
+
+func (v BFloat16) String() string {
+	return strconv.FormatFloat(float64(v.Float32()), 'g', -1, 32)
+}
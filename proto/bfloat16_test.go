@@ -0,0 +1,31 @@
+package proto
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBFloat16(t *testing.T) {
+	t.Parallel()
+	for _, v := range []float32{0, 1, -1, 0.5, 2, 1024, float32(math.Inf(1)), float32(math.Inf(-1))} {
+		require.Equal(t, v, NewBFloat16(v).Float32(), v)
+	}
+	require.Equal(t, BFloat16(0x3f80), NewBFloat16(1))
+	require.Equal(t, "1", NewBFloat16(1).String())
+	// Truncated.
+	require.Equal(t, float32(3.140625), NewBFloat16(3.1415927).Float32())
+	require.True(t, math.IsNaN(float64(NewBFloat16(float32(math.NaN())).Float32())))
+	require.True(t, math.IsNaN(float64(NewBFloat16(math.Float32frombits(0x7f800001)).Float32())))
+}
+
+func TestColBFloat16_Float32s(t *testing.T) {
+	t.Parallel()
+	values := []float32{1, -2, 0.25}
+	var c ColBFloat16
+	c.AppendFloat32s(values)
+	c.AppendFloat32(4)
+	require.Equal(t, ColumnTypeBFloat16, c.Type())
+	require.Equal(t, append(values, 4), c.Float32s(nil))
+}
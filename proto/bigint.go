@@ -0,0 +1,245 @@
+package proto
+
+import (
+	"math/big"
+
+	"github.com/go-faster/errors"
+)
+
+// bigFromLE returns big.Int from little-endian bytes, interpreting them as
+// two's complement if signed.
+func bigFromLE(b []byte, signed bool) *big.Int {
+	be := make([]byte, len(b))
+	for i, v := range b {
+		be[len(b)-1-i] = v
+	}
+	v := new(big.Int).SetBytes(be)
+	if signed && len(b) > 0 && b[len(b)-1]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return v
+}
+
+// putBigLE writes v to b as little-endian (two's complement if signed)
+// and reports whether v fits in len(b) bytes.
+func putBigLE(b []byte, v *big.Int, signed bool) bool {
+	bits := len(b) * 8
+	if signed {
+		bits--
+	}
+	u := v
+	switch {
+	case v.Sign() >= 0 && v.BitLen() > bits:
+		return false
+	case v.Sign() < 0 && !signed:
+		return false
+	case v.Sign() < 0:
+		// Two's complement: 2^n + v, must not be less than -2^(n-1).
+		u = new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8))
+		u.Add(u, v)
+		if u.Sign() <= 0 || u.BitLen() <= bits {
+			return false
+		}
+	}
+	for i := range b {
+		b[i] = 0
+	}
+	be := u.Bytes()
+	for i, x := range be {
+		b[len(be)-1-i] = x
+	}
+	return true
+}
+
+// parseBig parses decimal integer string.
+func parseBig(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid integer %q", s)
+	}
+	return v, nil
+}
+
+// Big returns Int128 as big.Int.
+func (i Int128) Big() *big.Int {
+	var b [128 / 8]byte
+	binPutUInt128(b[:], UInt128(i))
+	return bigFromLE(b[:], true)
+}
+
+func (i Int128) String() string {
+	return i.Big().String()
+}
+
+// Int128FromBig creates new Int128 from big.Int.
+//
+// Returns error if v overflows Int128.
+func Int128FromBig(v *big.Int) (Int128, error) {
+	var b [128 / 8]byte
+	if !putBigLE(b[:], v, true) {
+		return Int128{}, errors.Errorf("%s overflows Int128", v)
+	}
+	return Int128(binUInt128(b[:])), nil
+}
+
+// ParseInt128 parses Int128 from decimal string.
+func ParseInt128(s string) (Int128, error) {
+	v, err := parseBig(s)
+	if err != nil {
+		return Int128{}, err
+	}
+	return Int128FromBig(v)
+}
+
+// Big returns UInt128 as big.Int.
+func (i UInt128) Big() *big.Int {
+	var b [128 / 8]byte
+	binPutUInt128(b[:], i)
+	return bigFromLE(b[:], false)
+}
+
+func (i UInt128) String() string {
+	return i.Big().String()
+}
+
+// UInt128FromBig creates new UInt128 from big.Int.
+//
+// Returns error if v is negative or overflows UInt128.
+func UInt128FromBig(v *big.Int) (UInt128, error) {
+	var b [128 / 8]byte
+	if !putBigLE(b[:], v, false) {
+		return UInt128{}, errors.Errorf("%s overflows UInt128", v)
+	}
+	return binUInt128(b[:]), nil
+}
+
+// ParseUInt128 parses UInt128 from decimal string.
+func ParseUInt128(s string) (UInt128, error) {
+	v, err := parseBig(s)
+	if err != nil {
+		return UInt128{}, err
+	}
+	return UInt128FromBig(v)
+}
+
+// Big returns Int256 as big.Int.
+func (i Int256) Big() *big.Int {
+	var b [256 / 8]byte
+	binPutUInt256(b[:], UInt256(i))
+	return bigFromLE(b[:], true)
+}
+
+func (i Int256) String() string {
+	return i.Big().String()
+}
+
+// Int256FromBig creates new Int256 from big.Int.
+//
+// Returns error if v overflows Int256.
+func Int256FromBig(v *big.Int) (Int256, error) {
+	var b [256 / 8]byte
+	if !putBigLE(b[:], v, true) {
+		return Int256{}, errors.Errorf("%s overflows Int256", v)
+	}
+	return Int256(binUInt256(b[:])), nil
+}
+
+// ParseInt256 parses Int256 from decimal string.
+func ParseInt256(s string) (Int256, error) {
+	v, err := parseBig(s)
+	if err != nil {
+		return Int256{}, err
+	}
+	return Int256FromBig(v)
+}
+
+// Big returns UInt256 as big.Int.
+func (i UInt256) Big() *big.Int {
+	var b [256 / 8]byte
+	binPutUInt256(b[:], i)
+	return bigFromLE(b[:], false)
+}
+
+func (i UInt256) String() string {
+	return i.Big().String()
+}
+
+// UInt256FromBig creates new UInt256 from big.Int.
+//
+// Returns error if v is negative or overflows UInt256.
+func UInt256FromBig(v *big.Int) (UInt256, error) {
+	var b [256 / 8]byte
+	if !putBigLE(b[:], v, false) {
+		return UInt256{}, errors.Errorf("%s overflows UInt256", v)
+	}
+	return binUInt256(b[:]), nil
+}
+
+// ParseUInt256 parses UInt256 from decimal string.
+func ParseUInt256(s string) (UInt256, error) {
+	v, err := parseBig(s)
+	if err != nil {
+		return UInt256{}, err
+	}
+	return UInt256FromBig(v)
+}
+
+// AppendBig appends big.Int value, returning error on overflow.
+func (c *ColInt128) AppendBig(v *big.Int) error {
+	x, err := Int128FromBig(v)
+	if err != nil {
+		return err
+	}
+	c.Append(x)
+	return nil
+}
+
+// RowBig returns i-th row as big.Int.
+func (c ColInt128) RowBig(i int) *big.Int {
+	return c.Row(i).Big()
+}
+
+// AppendBig appends big.Int value, returning error on overflow.
+func (c *ColUInt128) AppendBig(v *big.Int) error {
+	x, err := UInt128FromBig(v)
+	if err != nil {
+		return err
+	}
+	c.Append(x)
+	return nil
+}
+
+// RowBig returns i-th row as big.Int.
+func (c ColUInt128) RowBig(i int) *big.Int {
+	return c.Row(i).Big()
+}
+
+// AppendBig appends big.Int value, returning error on overflow.
+func (c *ColInt256) AppendBig(v *big.Int) error {
+	x, err := Int256FromBig(v)
+	if err != nil {
+		return err
+	}
+	c.Append(x)
+	return nil
+}
+
+// RowBig returns i-th row as big.Int.
+func (c ColInt256) RowBig(i int) *big.Int {
+	return c.Row(i).Big()
+}
+
+// AppendBig appends big.Int value, returning error on overflow.
+func (c *ColUInt256) AppendBig(v *big.Int) error {
+	x, err := UInt256FromBig(v)
+	if err != nil {
+		return err
+	}
+	c.Append(x)
+	return nil
+}
+
+// RowBig returns i-th row as big.Int.
+func (c ColUInt256) RowBig(i int) *big.Int {
+	return c.Row(i).Big()
+}
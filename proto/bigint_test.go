@@ -0,0 +1,150 @@
+package proto
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutBigLE(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		Value  int64
+		Signed bool
+		OK     bool
+	}{
+		{0, true, true},
+		{127, true, true},
+		{128, true, false},
+		{-128, true, true},
+		{-129, true, false},
+		{-1000, true, false},
+		{255, false, true},
+		{256, false, false},
+		{-1, false, false},
+	} {
+		var b [1]byte
+		v := big.NewInt(tt.Value)
+		require.Equal(t, tt.OK, putBigLE(b[:], v, tt.Signed), tt.Value)
+		if tt.OK {
+			require.Equal(t, v.String(), bigFromLE(b[:], tt.Signed).String(), tt.Value)
+		}
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	t.Parallel()
+	const (
+		maxInt128  = "170141183460469231731687303715884105727"
+		minInt128  = "-170141183460469231731687303715884105728"
+		maxUInt128 = "340282366920938463463374607431768211455"
+		maxInt256  = "57896044618658097711785492504343953926634992332820282019728792003956564819967"
+		minInt256  = "-57896044618658097711785492504343953926634992332820282019728792003956564819968"
+		maxUInt256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	)
+	t.Run("Int128", func(t *testing.T) {
+		for _, s := range []string{"0", "1", "-1", maxInt128, minInt128} {
+			v, err := ParseInt128(s)
+			require.NoError(t, err)
+			require.Equal(t, s, v.String())
+		}
+		require.Equal(t, Int128FromInt(-42), mustBig(ParseInt128("-42")))
+		for _, s := range []string{"", "1.5", maxUInt128, "-" + maxUInt128} {
+			_, err := ParseInt128(s)
+			require.Error(t, err, s)
+		}
+	})
+	t.Run("UInt128", func(t *testing.T) {
+		for _, s := range []string{"0", "1", maxUInt128} {
+			v, err := ParseUInt128(s)
+			require.NoError(t, err)
+			require.Equal(t, s, v.String())
+		}
+		require.Equal(t, UInt128FromUInt64(42), mustBig(ParseUInt128("42")))
+		for _, s := range []string{"-1", maxUInt128 + "0"} {
+			_, err := ParseUInt128(s)
+			require.Error(t, err, s)
+		}
+	})
+	t.Run("Int256", func(t *testing.T) {
+		for _, s := range []string{"0", "1", "-1", maxInt256, minInt256} {
+			v, err := ParseInt256(s)
+			require.NoError(t, err)
+			require.Equal(t, s, v.String())
+		}
+		require.Equal(t, Int256FromInt(-42), mustBig(ParseInt256("-42")))
+		for _, s := range []string{"x", maxUInt256, "-" + maxUInt256} {
+			_, err := ParseInt256(s)
+			require.Error(t, err, s)
+		}
+	})
+	t.Run("UInt256", func(t *testing.T) {
+		for _, s := range []string{"0", "1", maxUInt256} {
+			v, err := ParseUInt256(s)
+			require.NoError(t, err)
+			require.Equal(t, s, v.String())
+		}
+		require.Equal(t, UInt256FromInt(42), mustBig(ParseUInt256("42")))
+		for _, s := range []string{"-1", maxUInt256 + "0"} {
+			_, err := ParseUInt256(s)
+			require.Error(t, err, s)
+		}
+	})
+}
+
+func mustBig[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestColBigInt(t *testing.T) {
+	t.Parallel()
+	big10 := func(s string) *big.Int {
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("invalid %q", s)
+		}
+		return v
+	}
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(-1),
+		big10("-" + strings.Repeat("9", 38)),
+	}
+	t.Run("Int128", func(t *testing.T) {
+		var c ColInt128
+		for _, v := range values {
+			require.NoError(t, c.AppendBig(v))
+		}
+		for i, v := range values {
+			require.Equal(t, v.String(), c.RowBig(i).String())
+		}
+		require.Error(t, c.AppendBig(big10(strings.Repeat("9", 39))))
+	})
+	t.Run("Int256", func(t *testing.T) {
+		var c ColInt256
+		for _, v := range values {
+			require.NoError(t, c.AppendBig(v))
+		}
+		for i, v := range values {
+			require.Equal(t, v.String(), c.RowBig(i).String())
+		}
+		require.Error(t, c.AppendBig(big10(strings.Repeat("9", 78))))
+	})
+	t.Run("UInt128", func(t *testing.T) {
+		var c ColUInt128
+		require.NoError(t, c.AppendBig(big10(strings.Repeat("9", 38))))
+		require.Equal(t, strings.Repeat("9", 38), c.RowBig(0).String())
+		require.Error(t, c.AppendBig(big.NewInt(-1)))
+	})
+	t.Run("UInt256", func(t *testing.T) {
+		var c ColUInt256
+		require.NoError(t, c.AppendBig(big10(strings.Repeat("9", 77))))
+		require.Equal(t, strings.Repeat("9", 77), c.RowBig(0).String())
+		require.Error(t, c.AppendBig(big.NewInt(-1)))
+	})
+}
@@ -0,0 +1,487 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/segmentio/asm/bswap"
+)
+
+// Codes of data types in binary encoding, see
+// https://clickhouse.com/docs/en/sql-reference/data-types/data-types-binary-encoding.
+const (
+	binaryTypeNothing        byte = 0x00
+	binaryTypeUInt8          byte = 0x01
+	binaryTypeUInt16         byte = 0x02
+	binaryTypeUInt32         byte = 0x03
+	binaryTypeUInt64         byte = 0x04
+	binaryTypeInt8           byte = 0x07
+	binaryTypeInt16          byte = 0x08
+	binaryTypeInt32          byte = 0x09
+	binaryTypeInt64          byte = 0x0A
+	binaryTypeFloat32        byte = 0x0D
+	binaryTypeFloat64        byte = 0x0E
+	binaryTypeDate           byte = 0x0F
+	binaryTypeDate32         byte = 0x10
+	binaryTypeDateTime       byte = 0x11
+	binaryTypeDateTimeTZ     byte = 0x12
+	binaryTypeDateTime64     byte = 0x13
+	binaryTypeDateTime64TZ   byte = 0x14
+	binaryTypeString         byte = 0x15
+	binaryTypeFixedString    byte = 0x16
+	binaryTypeEnum8          byte = 0x17
+	binaryTypeEnum16         byte = 0x18
+	binaryTypeDecimal32      byte = 0x19
+	binaryTypeDecimal64      byte = 0x1A
+	binaryTypeUUID           byte = 0x1D
+	binaryTypeArray          byte = 0x1E
+	binaryTypeTuple          byte = 0x1F
+	binaryTypeNamedTuple     byte = 0x20
+	binaryTypeNullable       byte = 0x23
+	binaryTypeLowCardinality byte = 0x26
+	binaryTypeMap            byte = 0x27
+	binaryTypeIPv4           byte = 0x28
+	binaryTypeIPv6           byte = 0x29
+	binaryTypeVariant        byte = 0x2A
+	binaryTypeDynamic        byte = 0x2B
+	binaryTypeBool           byte = 0x2D
+	binaryTypeJSON           byte = 0x30
+)
+
+// binaryType is data type in binary encoding.
+type binaryType struct {
+	code  byte
+	size  int            // FixedString size, DateTime64 precision or Decimal scale
+	loc   *time.Location // DateTime timezone
+	elems []binaryType   // Array, Nullable, LowCardinality, Tuple, Map, Variant or JSON typed paths
+	names []string       // Tuple element names or JSON typed paths
+	enum  map[int16]string
+}
+
+// decodeBinaryType decodes data type in binary encoding.
+func decodeBinaryType(r *Reader) (binaryType, error) {
+	code, err := r.UInt8()
+	if err != nil {
+		return binaryType{}, errors.Wrap(err, "code")
+	}
+	t := binaryType{code: code}
+	switch code {
+	case binaryTypeNothing, binaryTypeUInt8, binaryTypeUInt16, binaryTypeUInt32, binaryTypeUInt64,
+		binaryTypeInt8, binaryTypeInt16, binaryTypeInt32, binaryTypeInt64,
+		binaryTypeFloat32, binaryTypeFloat64, binaryTypeDate, binaryTypeDate32, binaryTypeDateTime,
+		binaryTypeString, binaryTypeUUID, binaryTypeIPv4, binaryTypeIPv6, binaryTypeBool:
+	case binaryTypeDateTimeTZ:
+		if t.loc, err = decodeBinaryLocation(r); err != nil {
+			return t, err
+		}
+	case binaryTypeDateTime64, binaryTypeDateTime64TZ:
+		p, err := r.UInt8()
+		if err != nil {
+			return t, errors.Wrap(err, "precision")
+		}
+		t.size = int(p)
+		if code == binaryTypeDateTime64TZ {
+			if t.loc, err = decodeBinaryLocation(r); err != nil {
+				return t, err
+			}
+		}
+	case binaryTypeFixedString:
+		if t.size, err = r.Int(); err != nil {
+			return t, errors.Wrap(err, "size")
+		}
+	case binaryTypeEnum8, binaryTypeEnum16:
+		n, err := r.UVarInt()
+		if err != nil {
+			return t, errors.Wrap(err, "enum size")
+		}
+		t.enum = map[int16]string{}
+		for i := uint64(0); i < n; i++ {
+			name, err := r.Str()
+			if err != nil {
+				return t, errors.Wrapf(err, "enum name [%d]", i)
+			}
+			var v int16
+			if code == binaryTypeEnum8 {
+				v8, err := r.Int8()
+				if err != nil {
+					return t, errors.Wrapf(err, "enum value [%d]", i)
+				}
+				v = int16(v8)
+			} else if v, err = r.Int16(); err != nil {
+				return t, errors.Wrapf(err, "enum value [%d]", i)
+			}
+			t.enum[v] = name
+		}
+	case binaryTypeDecimal32, binaryTypeDecimal64:
+		if _, err := r.UInt8(); err != nil {
+			return t, errors.Wrap(err, "precision")
+		}
+		s, err := r.UInt8()
+		if err != nil {
+			return t, errors.Wrap(err, "scale")
+		}
+		t.size = int(s)
+	case binaryTypeArray, binaryTypeNullable, binaryTypeLowCardinality:
+		elem, err := decodeBinaryType(r)
+		if err != nil {
+			return t, errors.Wrap(err, "element")
+		}
+		t.elems = []binaryType{elem}
+	case binaryTypeMap:
+		for _, name := range []string{"key", "value"} {
+			elem, err := decodeBinaryType(r)
+			if err != nil {
+				return t, errors.Wrap(err, name)
+			}
+			t.elems = append(t.elems, elem)
+		}
+	case binaryTypeTuple, binaryTypeNamedTuple, binaryTypeVariant:
+		n, err := r.UVarInt()
+		if err != nil {
+			return t, errors.Wrap(err, "elements count")
+		}
+		for i := uint64(0); i < n; i++ {
+			if code == binaryTypeNamedTuple {
+				name, err := r.Str()
+				if err != nil {
+					return t, errors.Wrapf(err, "name [%d]", i)
+				}
+				t.names = append(t.names, name)
+			}
+			elem, err := decodeBinaryType(r)
+			if err != nil {
+				return t, errors.Wrapf(err, "element [%d]", i)
+			}
+			t.elems = append(t.elems, elem)
+		}
+	case binaryTypeDynamic:
+		if _, err := r.UInt8(); err != nil {
+			return t, errors.Wrap(err, "max types")
+		}
+	case binaryTypeJSON:
+		if err := t.decodeJSON(r); err != nil {
+			return t, errors.Wrap(err, "json")
+		}
+	default:
+		return t, errors.Errorf("unsupported binary type 0x%02x", code)
+	}
+	return t, nil
+}
+
+func decodeBinaryLocation(r *Reader) (*time.Location, error) {
+	name, err := r.Str()
+	if err != nil {
+		return nil, errors.Wrap(err, "timezone")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		// Same instant in UTC if timezone database is not available.
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// decodeJSON decodes parameters of JSON type, keeping typed paths only.
+func (t *binaryType) decodeJSON(r *Reader) error {
+	if _, err := r.UInt8(); err != nil {
+		return errors.Wrap(err, "serialization version")
+	}
+	if _, err := r.UVarInt(); err != nil {
+		return errors.Wrap(err, "max dynamic paths")
+	}
+	if _, err := r.UInt8(); err != nil {
+		return errors.Wrap(err, "max dynamic types")
+	}
+	n, err := r.UVarInt()
+	if err != nil {
+		return errors.Wrap(err, "typed paths count")
+	}
+	for i := uint64(0); i < n; i++ {
+		name, err := r.Str()
+		if err != nil {
+			return errors.Wrapf(err, "typed path [%d]", i)
+		}
+		elem, err := decodeBinaryType(r)
+		if err != nil {
+			return errors.Wrapf(err, "typed path %q", name)
+		}
+		t.names = append(t.names, name)
+		t.elems = append(t.elems, elem)
+	}
+	// Skipped paths and regular expressions of skipped paths.
+	for _, name := range []string{"skip paths", "skip regexps"} {
+		n, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrapf(err, "%s count", name)
+		}
+		for i := uint64(0); i < n; i++ {
+			if _, err := r.StrRaw(); err != nil {
+				return errors.Wrapf(err, "%s [%d]", name, i)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeBinaryValue decodes data type and value in binary encoding, like
+// values of Dynamic shared variant or JSON shared data, to value that can
+// be marshaled to JSON.
+func decodeBinaryValue(r *Reader) (any, error) {
+	t, err := decodeBinaryType(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "type")
+	}
+	return t.decodeValue(r)
+}
+
+// decodeValue decodes value of t in binary encoding.
+func (t binaryType) decodeValue(r *Reader) (any, error) {
+	switch t.code {
+	case binaryTypeNothing:
+		return nil, nil
+	case binaryTypeUInt8:
+		return r.UInt8()
+	case binaryTypeUInt16:
+		return r.UInt16()
+	case binaryTypeUInt32:
+		return r.UInt32()
+	case binaryTypeUInt64:
+		return r.UInt64()
+	case binaryTypeInt8:
+		return r.Int8()
+	case binaryTypeInt16:
+		return r.Int16()
+	case binaryTypeInt32:
+		return r.Int32()
+	case binaryTypeInt64:
+		return r.Int64()
+	case binaryTypeFloat32:
+		return r.Float32()
+	case binaryTypeFloat64:
+		return r.Float64()
+	case binaryTypeBool:
+		return r.Bool()
+	case binaryTypeString:
+		return r.Str()
+	case binaryTypeFixedString:
+		if err := checkLimit("MaxStrLen", t.size, r.limits.MaxStrLen); err != nil {
+			return nil, err
+		}
+		v, err := r.ReadRaw(t.size)
+		if err != nil {
+			return nil, err
+		}
+		return string(v), nil
+	case binaryTypeDate:
+		v, err := r.UInt16()
+		if err != nil {
+			return nil, err
+		}
+		return Date(v).Time(), nil
+	case binaryTypeDate32:
+		v, err := r.Int32()
+		if err != nil {
+			return nil, err
+		}
+		return Date32(v).Time(), nil
+	case binaryTypeDateTime, binaryTypeDateTimeTZ:
+		v, err := r.UInt32()
+		if err != nil {
+			return nil, err
+		}
+		return t.inLocation(DateTime(v).Time()), nil
+	case binaryTypeDateTime64, binaryTypeDateTime64TZ:
+		v, err := r.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return t.inLocation(DateTime64(v).Time(Precision(t.size))), nil
+	case binaryTypeEnum8:
+		v, err := r.Int8()
+		if err != nil {
+			return nil, err
+		}
+		return t.enum[int16(v)], nil
+	case binaryTypeEnum16:
+		v, err := r.Int16()
+		if err != nil {
+			return nil, err
+		}
+		return t.enum[v], nil
+	case binaryTypeDecimal32:
+		v, err := r.Int32()
+		if err != nil {
+			return nil, err
+		}
+		return formatDecimal(int64(v), t.size), nil
+	case binaryTypeDecimal64:
+		v, err := r.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return formatDecimal(v, t.size), nil
+	case binaryTypeUUID:
+		v, err := r.ReadRaw(16)
+		if err != nil {
+			return nil, err
+		}
+		var u uuid.UUID
+		copy(u[:], v)
+		bswap.Swap64(u[:]) // BE <-> LE
+		return u, nil
+	case binaryTypeIPv4:
+		v, err := r.UInt32()
+		if err != nil {
+			return nil, err
+		}
+		return IPv4(v).ToIP(), nil
+	case binaryTypeIPv6:
+		v, err := r.ReadRaw(16)
+		if err != nil {
+			return nil, err
+		}
+		return binIPv6(v).ToIP(), nil
+	case binaryTypeNullable:
+		isNull, err := r.Bool()
+		if err != nil {
+			return nil, errors.Wrap(err, "null")
+		}
+		if isNull {
+			return nil, nil
+		}
+		return t.elems[0].decodeValue(r)
+	case binaryTypeLowCardinality:
+		return t.elems[0].decodeValue(r)
+	case binaryTypeArray:
+		n, err := r.UVarInt()
+		if err != nil {
+			return nil, errors.Wrap(err, "size")
+		}
+		v := []any{}
+		for i := uint64(0); i < n; i++ {
+			e, err := t.elems[0].decodeValue(r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "[%d]", i)
+			}
+			v = append(v, e)
+		}
+		return v, nil
+	case binaryTypeTuple:
+		v := make([]any, 0, len(t.elems))
+		for i, elem := range t.elems {
+			e, err := elem.decodeValue(r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "[%d]", i)
+			}
+			v = append(v, e)
+		}
+		return v, nil
+	case binaryTypeNamedTuple:
+		v := make(map[string]any, len(t.elems))
+		for i, elem := range t.elems {
+			e, err := elem.decodeValue(r)
+			if err != nil {
+				return nil, errors.Wrap(err, t.names[i])
+			}
+			v[t.names[i]] = e
+		}
+		return v, nil
+	case binaryTypeMap:
+		n, err := r.UVarInt()
+		if err != nil {
+			return nil, errors.Wrap(err, "size")
+		}
+		v := map[string]any{}
+		for i := uint64(0); i < n; i++ {
+			k, err := t.elems[0].decodeValue(r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "key [%d]", i)
+			}
+			e, err := t.elems[1].decodeValue(r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "value [%d]", i)
+			}
+			v[fmt.Sprint(k)] = e
+		}
+		return v, nil
+	case binaryTypeVariant:
+		d, err := r.UInt8()
+		if err != nil {
+			return nil, errors.Wrap(err, "discriminator")
+		}
+		if d == VariantNullDiscriminator {
+			return nil, nil
+		}
+		if int(d) >= len(t.elems) {
+			return nil, errors.Errorf("unexpected discriminator %d", d)
+		}
+		return t.elems[d].decodeValue(r)
+	case binaryTypeDynamic:
+		return decodeBinaryValue(r)
+	case binaryTypeJSON:
+		return t.decodeJSONValue(r)
+	default:
+		return nil, errors.Errorf("unsupported binary type 0x%02x", t.code)
+	}
+}
+
+// decodeJSONValue decodes paths of JSON value, where typed paths have
+// value only and other paths have type and value.
+func (t binaryType) decodeJSONValue(r *Reader) (any, error) {
+	n, err := r.UVarInt()
+	if err != nil {
+		return nil, errors.Wrap(err, "paths count")
+	}
+	obj := map[string]any{}
+	for i := uint64(0); i < n; i++ {
+		path, err := r.Str()
+		if err != nil {
+			return nil, errors.Wrapf(err, "path [%d]", i)
+		}
+		typed := -1
+		for j, name := range t.names {
+			if name == path {
+				typed = j
+				break
+			}
+		}
+		var v any
+		if typed >= 0 {
+			v, err = t.elems[typed].decodeValue(r)
+		} else {
+			v, err = decodeBinaryValue(r)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "path %q", path)
+		}
+		setJSONPath(obj, path, v)
+	}
+	return obj, nil
+}
+
+func (t binaryType) inLocation(v time.Time) time.Time {
+	if t.loc == nil {
+		return v
+	}
+	return v.In(t.loc)
+}
+
+// formatDecimal formats decimal v with scale as JSON number.
+func formatDecimal(v int64, scale int) json.Number {
+	s := strconv.FormatInt(v, 10)
+	if scale <= 0 {
+		return json.Number(s)
+	}
+	sign := ""
+	if v < 0 {
+		sign, s = "-", s[1:]
+	}
+	for len(s) <= scale {
+		s = "0" + s
+	}
+	return json.Number(sign + s[:len(s)-scale] + "." + s[len(s)-scale:])
+}
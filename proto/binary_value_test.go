@@ -0,0 +1,108 @@
+package proto
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBinaryValue(t *testing.T) {
+	str := func(s string) []byte {
+		return append([]byte{byte(len(s))}, s...)
+	}
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+	for _, tt := range []struct {
+		Name  string
+		Data  []byte
+		Value string // JSON
+	}{
+		{"Nothing", []byte{binaryTypeNothing}, `null`},
+		{"UInt8", []byte{binaryTypeUInt8, 200}, `200`},
+		{"Int16", []byte{binaryTypeInt16, 0xfe, 0xff}, `-2`},
+		{"UInt64", []byte{binaryTypeUInt64, 1, 1, 0, 0, 0, 0, 0, 0}, `257`},
+		{"Float64", []byte{binaryTypeFloat64, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f}, `1.5`},
+		{"String", join([]byte{binaryTypeString}, str("foo")), `"foo"`},
+		{"FixedString", []byte{binaryTypeFixedString, 2, 'a', 'b'}, `"ab"`},
+		{"Bool", []byte{binaryTypeBool, 0}, `false`},
+		{"Date", []byte{binaryTypeDate, 1, 0}, `"1970-01-02T00:00:00Z"`},
+		{"DateTime", []byte{binaryTypeDateTime, 60, 0, 0, 0}, `"1970-01-01T00:01:00Z"`},
+		{"DateTime64", []byte{binaryTypeDateTime64, 3, 0xe8, 3, 0, 0, 0, 0, 0, 0}, `"1970-01-01T00:00:01Z"`},
+		{"DateTimeUTC", join([]byte{binaryTypeDateTimeTZ}, str("UTC"), []byte{1, 0, 0, 0}), `"1970-01-01T00:00:01Z"`},
+		{"Decimal32", []byte{binaryTypeDecimal32, 9, 2, 0x85, 0xff, 0xff, 0xff}, `-1.23`},
+		{"Decimal64", []byte{binaryTypeDecimal64, 18, 3, 5, 0, 0, 0, 0, 0, 0, 0}, `0.005`},
+		{"Enum8", join([]byte{binaryTypeEnum8, 2}, str("a"), []byte{1}, str("b"), []byte{2}, []byte{2}), `"b"`},
+		{"UUID", []byte{
+			binaryTypeUUID,
+			0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x00,
+			0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08,
+		}, `"00010203-0405-0607-0809-0a0b0c0d0e0f"`},
+		{"IPv4", []byte{binaryTypeIPv4, 1, 0, 0, 127}, `"127.0.0.1"`},
+		{"NullableNull", []byte{binaryTypeNullable, binaryTypeInt8, 1}, `null`},
+		{"Nullable", []byte{binaryTypeNullable, binaryTypeInt8, 0, 5}, `5`},
+		{"LowCardinality", join([]byte{binaryTypeLowCardinality, binaryTypeString}, str("x")), `"x"`},
+		{"Array", []byte{binaryTypeArray, binaryTypeUInt8, 2, 1, 2}, `[1,2]`},
+		{"EmptyArray", []byte{binaryTypeArray, binaryTypeNothing, 0}, `[]`},
+		{"Tuple", []byte{binaryTypeTuple, 2, binaryTypeUInt8, binaryTypeBool, 1, 1}, `[1,true]`},
+		{"NamedTuple", join([]byte{binaryTypeNamedTuple, 1}, str("a"), []byte{binaryTypeUInt8, 7}), `{"a":7}`},
+		{"Map", join([]byte{binaryTypeMap, binaryTypeString, binaryTypeUInt8, 1}, str("k"), []byte{3}), `{"k":3}`},
+		{"Variant", []byte{binaryTypeVariant, 2, binaryTypeString, binaryTypeUInt8, 1, 9}, `9`},
+		{"VariantNull", []byte{binaryTypeVariant, 1, binaryTypeUInt8, 255}, `null`},
+		{"Dynamic", []byte{binaryTypeDynamic, 32, binaryTypeUInt8, 4}, `4`},
+		{"JSON", join(
+			[]byte{binaryTypeJSON, 0, 8, 4},
+			[]byte{1}, str("a.b"), []byte{binaryTypeUInt8}, // typed paths
+			[]byte{1}, str("s"), // skip paths
+			[]byte{0}, // skip regexps
+			[]byte{2},
+			str("a.b"), []byte{1},
+			str("c"), []byte{binaryTypeBool, 1},
+		), `{"a":{"b":1},"c":true}`},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := NewReader(bytes.NewReader(tt.Data))
+			v, err := decodeBinaryValue(r)
+			require.NoError(t, err)
+			data, err := json.Marshal(v)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.Value, string(data))
+
+			_, err = r.ReadByte()
+			require.Error(t, err, "not all data read")
+		})
+	}
+	for _, tt := range []struct {
+		Name string
+		Data []byte
+	}{
+		{"Empty", nil},
+		{"Unsupported", []byte{0x05}}, // UInt128
+		{"Truncated", []byte{binaryTypeUInt32, 1, 2}},
+		{"Discriminator", []byte{binaryTypeVariant, 1, binaryTypeUInt8, 1, 0}},
+		{"Element", []byte{binaryTypeArray, 0xff}},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			_, err := decodeBinaryValue(NewReader(bytes.NewReader(tt.Data)))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	for _, tt := range []struct {
+		Value    int64
+		Scale    int
+		Expected string
+	}{
+		{123, 0, "123"},
+		{123, 2, "1.23"},
+		{-123, 2, "-1.23"},
+		{5, 3, "0.005"},
+		{-5, 1, "-0.5"},
+	} {
+		require.Equal(t, json.Number(tt.Expected), formatDecimal(tt.Value, tt.Scale))
+	}
+}
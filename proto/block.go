@@ -140,6 +140,20 @@ type Block struct {
 	Rows    int
 }
 
+// BlockMeta describes data packet of query result.
+type BlockMeta struct {
+	// Code of packet, ServerCodeData or ServerCodeTotals.
+	Code ServerCode
+	// Info of block, e.g. overflows row of GROUP BY WITH TOTALS with
+	// totals_mode or bucket number of two-level aggregation.
+	Info BlockInfo
+}
+
+// Totals reports whether block contains totals of GROUP BY WITH TOTALS.
+func (m BlockMeta) Totals() bool {
+	return m.Code == ServerCodeTotals
+}
+
 func (b Block) EncodeAware(buf *Buffer, version int) {
 	if FeatureBlockInfo.In(version) {
 		b.Info.Encode(buf)
@@ -183,32 +197,6 @@ func (b Block) EncodeRawBlock(buf *Buffer, version int, input []InputColumn) err
 	return nil
 }
 
-// This constrains can prevent accidental OOM and allow early detection
-// of erroneous column or row count.
-//
-// Just empirical values, there are no such limits in spec or in ClickHouse,
-// so is subject to change if false-positives occur.
-const (
-	maxColumnsInBlock = 1_000_000
-	maxRowsInBLock    = 100_000_000
-)
-
-func checkRows(n int) error {
-	if n < 0 {
-		return errors.New("negative")
-	}
-	if n > maxRowsInBLock {
-		// Most blocks should be less than 100M values, but technically
-		// there is no limit (can be several billions).
-		// 1B rows is too big and probably several gigabytes in RSS.
-		//
-		// The 100M UInt64 block is ~655MB RSS, should be pretty safe and
-		// protect from accidental (e.g. cosmic rays) rows count corruption.
-		return errors.Errorf("%d is suspiciously big, maximum is %d (preventing possible OOM)", n, maxRowsInBLock)
-	}
-	return nil
-}
-
 func (b *Block) End() bool {
 	return b.Columns == 0 && b.Rows == 0
 }
@@ -219,8 +207,8 @@ func (b *Block) DecodeRawBlock(r *Reader, version int, target Result) error {
 		if err != nil {
 			return errors.Wrap(err, "columns")
 		}
-		if v > maxColumnsInBlock || v < 0 {
-			return errors.Errorf("invalid columns number %d", v)
+		if err := r.checkColumns(v); err != nil {
+			return errors.Wrap(err, "columns count")
 		}
 		b.Columns = v
 	}
@@ -229,7 +217,7 @@ func (b *Block) DecodeRawBlock(r *Reader, version int, target Result) error {
 		if err != nil {
 			return errors.Wrap(err, "rows")
 		}
-		if err := checkRows(v); err != nil {
+		if err := r.checkRows(v); err != nil {
 			return errors.Wrap(err, "rows count")
 		}
 		b.Rows = v
@@ -249,18 +237,12 @@ func (b *Block) DecodeRawBlock(r *Reader, version int, target Result) error {
 				return errors.Wrapf(err, "column [%d] name", i)
 			}
 			// Type.
-			if _, err := r.Str(); err != nil {
+			t, err := r.Str()
+			if err != nil {
 				return errors.Wrapf(err, "column [%d] type", i)
 			}
-			if FeatureCustomSerialization.In(version) {
-				// Custom serialization flag.
-				v, err := r.Bool()
-				if err != nil {
-					return errors.Wrapf(err, "column [%d] custom serialization flag", i)
-				}
-				if v {
-					return errors.Errorf("column [%d] has custom serialization (not supported)", i)
-				}
+			if _, err := decodeSerializationInfo(r, version, ColumnType(t)); err != nil {
+				return errors.Wrapf(err, "column [%d] serialization", i)
 			}
 		}
 		return nil
@@ -0,0 +1,276 @@
+package proto
+
+import (
+	"io"
+
+	"github.com/go-faster/errors"
+)
+
+// BlockHeader describes block without decoding its data, e.g. to pass
+// block through as is.
+type BlockHeader struct {
+	Info    BlockInfo
+	Rows    int
+	Columns []ColInfo
+}
+
+// End reports whether block is special "end of data" block.
+func (h BlockHeader) End() bool {
+	return len(h.Columns) == 0 && h.Rows == 0
+}
+
+// DecodeBlock decodes block from r, skipping column data.
+//
+// Supported column types are the same as of Native format, except
+// Variant, Dynamic, JSON and AggregateFunction.
+func (h *BlockHeader) DecodeBlock(r *Reader, version int) error {
+	h.Info = BlockInfo{}
+	h.Columns = h.Columns[:0]
+	if FeatureBlockInfo.In(version) {
+		if err := h.Info.Decode(r); err != nil {
+			return errors.Wrap(err, "info")
+		}
+	}
+	columns, err := r.Int()
+	if err != nil {
+		return errors.Wrap(err, "columns")
+	}
+	if err := r.checkColumns(columns); err != nil {
+		return errors.Wrap(err, "columns count")
+	}
+	if h.Rows, err = r.Int(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	if err := r.checkRows(h.Rows); err != nil {
+		return errors.Wrap(err, "rows count")
+	}
+	for i := 0; i < columns; i++ {
+		var c ColInfo
+		if c.Name, err = r.Str(); err != nil {
+			return errors.Wrapf(err, "column [%d] name", i)
+		}
+		t, err := r.Str()
+		if err != nil {
+			return errors.Wrapf(err, "column [%d] type", i)
+		}
+		c.Type = ColumnType(t)
+		s, err := decodeSerializationInfo(r, version, c.Type)
+		if err != nil {
+			return errors.Wrapf(err, "column [%d] serialization", i)
+		}
+		h.Columns = append(h.Columns, c)
+		if h.Rows == 0 {
+			continue
+		}
+		if err := skipColumn(r, c.Type, s, h.Rows); err != nil {
+			return errors.Wrapf(err, "column %q", c.Name)
+		}
+	}
+	return nil
+}
+
+// skipColumn skips state and data of rows of column with type t.
+func skipColumn(r *Reader, t ColumnType, s serializationInfo, rows int) error {
+	n, err := t.Parse()
+	if err != nil {
+		return err
+	}
+	if err := skipState(r, n); err != nil {
+		return errors.Wrap(err, "state")
+	}
+	return skipSerialized(r, n, s, rows)
+}
+
+func skipSerialized(r *Reader, t *ColumnTypeNode, s serializationInfo, rows int) error {
+	if !s.Custom() {
+		return skipData(r, t, rows)
+	}
+	if s.Kind == SerializationSparse {
+		// Expanded into default serialization and discarded, so it is
+		// not the fastest path, but sparse columns are mostly small.
+		var b Buffer
+		return decodeSparse(r, t.Type(), rows, &b)
+	}
+	if t.Base != ColumnTypeTuple || len(t.Params) != len(s.Elems) {
+		return errors.Errorf("custom serialization of %s is not supported", t)
+	}
+	for i, e := range t.Params {
+		if err := skipSerialized(r, unnamed(e), s.Elems[i], rows); err != nil {
+			return errors.Wrapf(err, "[%d]", i)
+		}
+	}
+	return nil
+}
+
+// unnamed returns element type of named Tuple.
+func unnamed(t *ColumnTypeNode) *ColumnTypeNode {
+	if t.Name == "" {
+		return t
+	}
+	v := *t
+	v.Name = ""
+	return &v
+}
+
+func skipBytes(r *Reader, n int) error {
+	if _, err := io.CopyN(io.Discard, r, int64(n)); err != nil {
+		return errors.Wrap(err, "skip")
+	}
+	return nil
+}
+
+// skipState skips state prefix of column with type t, i.e. version of
+// LowCardinality keys.
+func skipState(r *Reader, t *ColumnTypeNode) error {
+	switch t.Base {
+	case ColumnTypeLowCardinality:
+		return skipBytes(r, 8)
+	case ColumnTypeArray, ColumnTypeNullable, ColumnTypeMap, ColumnTypeTuple:
+		for _, e := range t.Params {
+			if err := skipState(r, unnamed(e)); err != nil {
+				return err
+			}
+		}
+	case ColumnTypeSimpleAggregateFunction:
+		if e := t.Elem(1); e != nil {
+			return skipState(r, e)
+		}
+	}
+	return nil
+}
+
+// skipData skips data of rows of column with type t.
+func skipData(r *Reader, t *ColumnTypeNode, rows int) error {
+	if size, ok := t.Type().FixedWidth(); ok {
+		return skipBytes(r, size*rows)
+	}
+	elem := func(i int) (*ColumnTypeNode, error) {
+		e := t.Elem(i)
+		if e == nil {
+			return nil, errors.Errorf("%s: no element %d", t, i)
+		}
+		return unnamed(e), nil
+	}
+	switch t.Base {
+	case ColumnTypeString:
+		for i := 0; i < rows; i++ {
+			n, err := r.StrLen()
+			if err != nil {
+				return errors.Wrapf(err, "[%d] length", i)
+			}
+			if err := skipBytes(r, n); err != nil {
+				return errors.Wrapf(err, "[%d]", i)
+			}
+		}
+		return nil
+	case ColumnTypeNothing:
+		return skipBytes(r, rows)
+	case ColumnTypePoint:
+		return skipBytes(r, 16*rows)
+	case ColumnTypeNullable:
+		e, err := elem(0)
+		if err != nil {
+			return err
+		}
+		if err := skipBytes(r, rows); err != nil {
+			return errors.Wrap(err, "nulls")
+		}
+		return skipData(r, e, rows)
+	case ColumnTypeSimpleAggregateFunction:
+		e, err := elem(1)
+		if err != nil {
+			return err
+		}
+		return skipData(r, e, rows)
+	case ColumnTypeTuple:
+		for i := range t.Params {
+			e, err := elem(i)
+			if err != nil {
+				return err
+			}
+			if err := skipData(r, e, rows); err != nil {
+				return errors.Wrapf(err, "[%d]", i)
+			}
+		}
+		return nil
+	case ColumnTypeArray, ColumnTypeMap, ColumnTypeRing, ColumnTypePolygon, ColumnTypeMultiPolygon:
+		if rows == 0 {
+			return nil
+		}
+		if err := skipBytes(r, 8*(rows-1)); err != nil {
+			return errors.Wrap(err, "offsets")
+		}
+		last, err := r.UInt64()
+		if err != nil {
+			return errors.Wrap(err, "offsets")
+		}
+		if err := r.checkArrSize(int(last)); err != nil {
+			return errors.Wrap(err, "elements")
+		}
+		var elems []*ColumnTypeNode
+		switch t.Base {
+		case ColumnTypeRing:
+			elems = append(elems, &ColumnTypeNode{Base: ColumnTypePoint})
+		case ColumnTypePolygon:
+			elems = append(elems, &ColumnTypeNode{Base: ColumnTypeRing})
+		case ColumnTypeMultiPolygon:
+			elems = append(elems, &ColumnTypeNode{Base: ColumnTypePolygon})
+		default:
+			for i := range t.Params {
+				e, err := elem(i)
+				if err != nil {
+					return err
+				}
+				elems = append(elems, e)
+			}
+		}
+		for i, e := range elems {
+			if err := skipData(r, e, int(last)); err != nil {
+				return errors.Wrapf(err, "[%d]", i)
+			}
+		}
+		return nil
+	case ColumnTypeLowCardinality:
+		if rows == 0 {
+			return nil
+		}
+		e, err := elem(0)
+		if err != nil {
+			return err
+		}
+		if e.Base == ColumnTypeNullable {
+			// Dictionary of LowCardinality(Nullable(T)) is T.
+			if e = e.Elem(0); e == nil {
+				return errors.Errorf("%s: no element", t)
+			}
+		}
+		meta, err := r.Int64()
+		if err != nil {
+			return errors.Wrap(err, "meta")
+		}
+		dict, err := r.Int64()
+		if err != nil {
+			return errors.Wrap(err, "dictionary size")
+		}
+		if err := r.checkArrSize(int(dict)); err != nil {
+			return errors.Wrap(err, "dictionary size")
+		}
+		if err := skipData(r, e, int(dict)); err != nil {
+			return errors.Wrap(err, "dictionary")
+		}
+		keys, err := r.Int64()
+		if err != nil {
+			return errors.Wrap(err, "keys count")
+		}
+		if keys != int64(rows) {
+			return errors.Errorf("%d keys, expected %d", keys, rows)
+		}
+		width := 1 << (meta & 0xff)
+		if width > 8 {
+			return errors.Errorf("invalid key width %d", width)
+		}
+		return skipBytes(r, width*rows)
+	default:
+		return errors.Errorf("type %s is not supported", t)
+	}
+}
@@ -0,0 +1,98 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/compress"
+)
+
+func TestBlockHeader_DecodeBlock(t *testing.T) {
+	var (
+		u32   = &ColUInt32{1, 2, 3}
+		str   = new(ColStr)
+		null  = new(ColStr).Nullable()
+		arr   = new(ColStr).LowCardinality().Array()
+		m     = NewMap[string, string](new(ColStr), new(ColStr))
+		tuple = ColTuple{Named[string](new(ColStr), "a"), Named[int64](new(ColInt64), "b")}
+		dt    = new(ColDateTime64).WithPrecision(PrecisionMilli)
+		point = new(ColPoint)
+	)
+	for i := 0; i < 3; i++ {
+		str.Append("foo")
+		null.Append(NewNullable("bar"))
+		arr.Append([]string{"a", "b", "a"}[:i])
+		m.Append(map[string]string{"k": "v"})
+		tuple[0].(*ColNamed[string]).Append("x")
+		tuple[1].(*ColNamed[int64]).Append(int64(i))
+		dt.Append(time.Unix(1700000000, 0))
+		point.Append(Point{X: 1, Y: 2})
+	}
+	input := Input{
+		{Name: "u32", Data: u32},
+		{Name: "str", Data: str},
+		{Name: "null", Data: null},
+		{Name: "arr", Data: arr},
+		{Name: "m", Data: m},
+		{Name: "tuple", Data: tuple},
+		{Name: "dt", Data: dt},
+		{Name: "point", Data: point},
+	}
+	var b Buffer
+	block := Block{Info: BlockInfo{BucketNum: -1}, Columns: len(input), Rows: 3}
+	require.NoError(t, block.EncodeBlock(&b, Version, input))
+	data := append([]byte(nil), b.Buf...)
+
+	expected := BlockHeader{Info: block.Info, Rows: 3}
+	for _, c := range input {
+		expected.Columns = append(expected.Columns, ColInfo{Name: c.Name, Type: c.Data.Type()})
+	}
+
+	t.Run("Raw", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(append(data, 0xAB)))
+		var tee Buffer
+		r.Tee(&tee)
+		var h BlockHeader
+		require.NoError(t, h.DecodeBlock(r, Version))
+		require.Equal(t, expected, h)
+		require.Equal(t, data, tee.Buf)
+
+		r.Tee(nil)
+		v, err := r.Byte()
+		require.NoError(t, err)
+		require.Equal(t, byte(0xAB), v)
+	})
+	t.Run("Compressed", func(t *testing.T) {
+		w := compress.NewWriter()
+		require.NoError(t, w.Compress(compress.LZ4, data))
+		compressed := append([]byte(nil), w.Data...)
+
+		r := NewReader(bytes.NewReader(compressed))
+		var tee Buffer
+		r.Tee(&tee)
+		r.EnableCompression()
+		var h BlockHeader
+		require.NoError(t, h.DecodeBlock(r, Version))
+		require.Equal(t, expected, h)
+		require.Equal(t, compressed, tee.Buf)
+	})
+	t.Run("ShortRead", func(t *testing.T) {
+		for i := 0; i < len(data); i++ {
+			var h BlockHeader
+			require.Error(t, h.DecodeBlock(NewReader(bytes.NewReader(data[:i])), Version))
+		}
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		var b Buffer
+		Block{Columns: 1, Rows: 1}.EncodeAware(&b, Version)
+		b.PutString("v")
+		b.PutString("Dynamic")
+		b.PutBool(false)
+		b.PutByte(0)
+		var h BlockHeader
+		require.Error(t, h.DecodeBlock(NewReader(bytes.NewReader(b.Buf)), Version))
+	})
+}
@@ -0,0 +1,123 @@
+package proto
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// ChunkedMode is capability of chunked packets framing, negotiated
+// during handshake separately for each direction.
+//
+// In chunked mode, each packet is sent as sequence of chunks, prefixed
+// by UInt32 size, and terminated by chunk of zero size.
+type ChunkedMode string
+
+// Possible chunked modes.
+const (
+	Chunked            ChunkedMode = "chunked"
+	NotChunked         ChunkedMode = "notchunked"
+	ChunkedOptional    ChunkedMode = "chunked_optional"
+	NotChunkedOptional ChunkedMode = "notchunked_optional"
+)
+
+// Chunked reports whether chunked framing is preferred.
+func (m ChunkedMode) Chunked() bool { return strings.HasPrefix(string(m), string(Chunked)) }
+
+// Optional reports whether other side can select framing.
+func (m ChunkedMode) Optional() bool { return strings.HasSuffix(string(m), "_optional") }
+
+// Valid reports whether m is known mode.
+func (m ChunkedMode) Valid() bool {
+	switch m {
+	case Chunked, NotChunked, ChunkedOptional, NotChunkedOptional:
+		return true
+	default:
+		return false
+	}
+}
+
+// NegotiateChunked returns whether chunked framing should be used by
+// local side with mode local and remote side with mode remote.
+func NegotiateChunked(local, remote ChunkedMode) (bool, error) {
+	if !local.Valid() {
+		return false, errors.Errorf("invalid chunked mode %q", local)
+	}
+	if !remote.Valid() {
+		return false, errors.Errorf("invalid remote chunked mode %q", remote)
+	}
+	switch {
+	case remote.Optional():
+		return local.Chunked(), nil
+	case local.Optional():
+		return remote.Chunked(), nil
+	case local.Chunked() != remote.Chunked():
+		return false, errors.Errorf("incompatible chunked modes %q and %q (remote)", local, remote)
+	default:
+		return remote.Chunked(), nil
+	}
+}
+
+// PutChunked appends packet to b as single chunk with terminating chunk.
+func PutChunked(b *Buffer, packet []byte) {
+	if len(packet) == 0 {
+		return
+	}
+	b.PutUInt32(uint32(len(packet)))
+	b.PutRaw(packet)
+	b.PutUInt32(0)
+}
+
+// ChunkedReader removes chunked framing from underlying reader if enabled.
+type ChunkedReader struct {
+	r       io.Reader
+	enabled bool
+	left    uint32 // of current chunk
+	header  [4]byte
+	n       int // read bytes of header
+}
+
+// NewChunkedReader returns new ChunkedReader, initially disabled.
+func NewChunkedReader(r io.Reader) *ChunkedReader {
+	return &ChunkedReader{r: r}
+}
+
+// Enable removing of chunked framing.
+func (r *ChunkedReader) Enable() { r.enabled = true }
+
+// Read implements io.Reader.
+//
+// Partial reads of chunk header are resumed on next call, so read
+// timeouts are safe.
+func (r *ChunkedReader) Read(p []byte) (int, error) {
+	if !r.enabled {
+		return r.r.Read(p)
+	}
+	for r.left == 0 {
+		// Reading header, chunks of zero size are end of packet.
+		n, err := r.r.Read(r.header[r.n:])
+		r.n += n
+		if r.n == len(r.header) {
+			r.n = 0
+			r.left = binary.LittleEndian.Uint32(r.header[:])
+			continue
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) && r.n > 0 {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+	}
+	if uint32(len(p)) > r.left {
+		p = p[:r.left]
+	}
+	n, err := r.r.Read(p)
+	r.left -= uint32(n)
+	if errors.Is(err, io.EOF) && r.left > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
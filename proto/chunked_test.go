@@ -0,0 +1,84 @@
+package proto
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateChunked(t *testing.T) {
+	for _, tt := range []struct {
+		Local, Remote ChunkedMode
+		Chunked       bool
+		Error         bool
+	}{
+		{Local: NotChunkedOptional, Remote: NotChunkedOptional},
+		{Local: NotChunkedOptional, Remote: ChunkedOptional},
+		{Local: NotChunkedOptional, Remote: Chunked, Chunked: true},
+		{Local: NotChunkedOptional, Remote: NotChunked},
+		{Local: ChunkedOptional, Remote: NotChunkedOptional, Chunked: true},
+		{Local: Chunked, Remote: ChunkedOptional, Chunked: true},
+		{Local: Chunked, Remote: Chunked, Chunked: true},
+		{Local: NotChunked, Remote: NotChunked},
+		{Local: Chunked, Remote: NotChunked, Error: true},
+		{Local: NotChunked, Remote: Chunked, Error: true},
+		{Local: "bad", Remote: Chunked, Error: true},
+		{Local: Chunked, Remote: "", Error: true},
+	} {
+		tt := tt
+		t.Run(string(tt.Local)+"/"+string(tt.Remote), func(t *testing.T) {
+			v, err := NegotiateChunked(tt.Local, tt.Remote)
+			if tt.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Chunked, v)
+		})
+	}
+}
+
+func TestChunkedReader(t *testing.T) {
+	var b Buffer
+	PutChunked(&b, []byte("hello"))
+	PutChunked(&b, nil)
+	PutChunked(&b, []byte(", world"))
+	// Packet of multiple chunks.
+	b.PutUInt32(1)
+	b.PutRaw([]byte("!"))
+	b.PutUInt32(2)
+	b.PutRaw([]byte("!!"))
+	b.PutUInt32(0)
+
+	t.Run("Enabled", func(t *testing.T) {
+		r := NewChunkedReader(bytes.NewReader(b.Buf))
+		r.Enable()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "hello, world!!!", string(data))
+	})
+	t.Run("OneByte", func(t *testing.T) {
+		r := NewChunkedReader(iotest.OneByteReader(bytes.NewReader(b.Buf)))
+		r.Enable()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "hello, world!!!", string(data))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		r := NewChunkedReader(bytes.NewReader(b.Buf))
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, b.Buf, data)
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		for _, n := range []int{2, 6} {
+			r := NewChunkedReader(bytes.NewReader(b.Buf[:n]))
+			r.Enable()
+			_, err := io.ReadAll(r)
+			require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		}
+	})
+}
@@ -13,6 +13,9 @@ const (
 	ClientCodeCancel          ClientCode = 3 // query cancel
 	ClientCodePing            ClientCode = 4 // ping request to server
 	ClientTablesStatusRequest ClientCode = 5 // tables status request
+
+	ClientCodeReadTaskResponse          ClientCode = 9  // response to ServerReadTaskRequest
+	ClientCodeMergeTreeReadTaskResponse ClientCode = 10 // response to ServerMergeTreeReadTaskRequest
 )
 
 // Encode to buffer.
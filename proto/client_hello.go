@@ -18,6 +18,10 @@ type ClientHello struct {
 	Database string
 	User     string
 	Password string
+
+	// Cluster and Salt are sent only if User is InterserverUser.
+	Cluster string
+	Salt    string
 }
 
 // Encode to Buffer.
@@ -30,6 +34,10 @@ func (c ClientHello) Encode(b *Buffer) {
 	b.PutString(c.Database)
 	b.PutString(c.User)
 	b.PutString(c.Password)
+	if c.User == InterserverUser {
+		b.PutString(c.Cluster)
+		b.PutString(c.Salt)
+	}
 }
 
 func (c *ClientHello) Decode(r *Reader) error {
@@ -82,5 +90,18 @@ func (c *ClientHello) Decode(r *Reader) error {
 		}
 		c.Password = v
 	}
+	if c.User == InterserverUser {
+		v, err := r.Str()
+		if err != nil {
+			return errors.Wrap(err, "cluster")
+		}
+		c.Cluster = v
+
+		salt, err := r.Str()
+		if err != nil {
+			return errors.Wrap(err, "salt")
+		}
+		c.Salt = salt
+	}
 	return nil
 }
@@ -24,6 +24,7 @@ const (
 	KindEnum
 	KindDecimal
 	KindFixedStr
+	KindBFloat16
 )
 
 type Variant struct {
@@ -141,7 +142,7 @@ func (v Variant) Big() bool {
 }
 
 func (v Variant) Cast() bool {
-	return v.Signed || v.IPv4()
+	return v.Signed || v.IPv4() || v.Kind == KindBFloat16
 }
 
 func (v Variant) UnsignedType() string {
@@ -194,6 +195,9 @@ func (v Variant) ElemType() string {
 	if v.Kind == KindEnum {
 		return fmt.Sprintf("Enum%d", v.Bits)
 	}
+	if v.Kind == KindBFloat16 {
+		return "BFloat16"
+	}
 	if v.IPv4() {
 		return "IPv4"
 	}
@@ -284,6 +288,10 @@ func run() error {
 			Kind:   KindFloat,
 			Signed: true,
 		},
+		{ // BFloat16
+			Bits: 16,
+			Kind: KindBFloat16,
+		},
 		{ // IPv4
 			Bits: 32,
 			Kind: KindIP,
@@ -0,0 +1,188 @@
+// Binary ch-gen-corpus generates seed corpora of proto fuzz targets from
+// golden files.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// maxRows is maximum rows count that is tried when inferring rows of
+// golden column.
+const maxRows = 1024
+
+// columns maps golden file names to column types.
+func columns() map[string]proto.ColumnType {
+	c := map[string]proto.ColumnType{
+		"col_arr_int8_manual":       "Array(Int8)",
+		"col_arr_of_str":            "Array(String)",
+		"col_bool":                  "Bool",
+		"col_fixed_str":             "FixedString(32)",
+		"col_ipv4_netaddr":          "IPv4",
+		"col_ipv6_netaddr":          "IPv6",
+		"col_map_of_str_str":        "Map(String, String)",
+		"col_multi_polygon":         "MultiPolygon",
+		"col_nothing_nullable":      "Nullable(Nothing)",
+		"col_nullable_of_str":       "Nullable(String)",
+		"col_nullable_str":          "Nullable(String)",
+		"col_point":                 "Point",
+		"col_polygon":               "Polygon",
+		"col_ring":                  "Ring",
+		"col_str":                   "String",
+		"col_str_bytes":             "String",
+		"col_tuple_named_str_int64": "Tuple(strings String, ints Int64)",
+		"col_tuple_str_int64":       "Tuple(String, Int64)",
+		"col_uuid":                  "UUID",
+	}
+	// Generated columns, see ../ch-gen-col.
+	generated := map[string]proto.ColumnType{
+		"bfloat16":   "BFloat16",
+		"date":       "Date",
+		"date32":     "Date32",
+		"datetime":   "DateTime",
+		"datetime64": "DateTime64(9)",
+		"decimal32":  "Decimal(9, 2)",
+		"decimal64":  "Decimal(18, 2)",
+		"decimal128": "Decimal(38, 2)",
+		"decimal256": "Decimal(76, 2)",
+		"float32":    "Float32",
+		"float64":    "Float64",
+		"ipv4":       "IPv4",
+		"ipv6":       "IPv6",
+	}
+	for _, bits := range []int{8, 16, 32, 64, 128, 256} {
+		generated[fmt.Sprintf("int%d", bits)] = proto.ColumnType(fmt.Sprintf("Int%d", bits))
+		generated[fmt.Sprintf("uint%d", bits)] = proto.ColumnType(fmt.Sprintf("UInt%d", bits))
+	}
+	for _, size := range []int{8, 16, 32, 64, 128, 256, 512} {
+		generated[fmt.Sprintf("byte%d", size)] = proto.ColumnType(fmt.Sprintf("FixedString(%d)", size))
+	}
+	for name, t := range generated {
+		c["col_"+name] = t
+		c["col_arr_"+name] = proto.ColumnTypeArray.Sub(t)
+	}
+	return c
+}
+
+// blocks are golden files of blocks encoded with proto.Version.
+var blocks = []string{
+	"block_int8_uint64",
+}
+
+// decodeColumn decodes data as rows of column with type t, returning
+// error if data is not consumed exactly.
+func decodeColumn(t proto.ColumnType, rows int, data []byte) error {
+	col := new(proto.ColAuto)
+	if err := col.Infer(t); err != nil {
+		return errors.Wrap(err, "infer")
+	}
+	br := bytes.NewReader(data)
+	r := proto.NewReader(br)
+	if err := col.DecodeState(r); err != nil {
+		return errors.Wrap(err, "state")
+	}
+	if err := col.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "column")
+	}
+	if br.Len() != 0 {
+		return errors.Errorf("%d bytes left", br.Len())
+	}
+	return nil
+}
+
+// inferRows returns minimum rows count for which data is decoded exactly.
+func inferRows(t proto.ColumnType, data []byte) (int, error) {
+	for rows := 1; rows <= maxRows; rows++ {
+		if decodeColumn(t, rows, data) == nil {
+			return rows, nil
+		}
+	}
+	return 0, errors.New("unable to infer rows count")
+}
+
+// writeCorpus writes go fuzzing corpus entry of values.
+func writeCorpus(dir, name string, values ...string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return errors.Wrap(err, "mkdir")
+	}
+	data := "go test fuzz v1\n" + strings.Join(values, "\n") + "\n"
+	return os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600)
+}
+
+func run(golden, output string) error {
+	cols := columns()
+	names := make([]string, 0, len(cols))
+	for name := range cols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dir := filepath.Join(output, "FuzzDecodeColumn")
+	for _, name := range names {
+		t := cols[name]
+		if err := new(proto.ColAuto).Infer(t); err != nil {
+			// Not supported by fuzz target.
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(golden, name+".raw"))
+		if errors.Is(err, os.ErrNotExist) {
+			// Not every generated column has golden file.
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%s: read", name)
+		}
+		rows, err := inferRows(t, data)
+		if err != nil {
+			return errors.Wrapf(err, "%s: %s", name, t)
+		}
+		if err := writeCorpus(dir, name,
+			fmt.Sprintf("string(%q)", t),
+			fmt.Sprintf("uint16(%d)", rows),
+			fmt.Sprintf("[]byte(%q)", data),
+		); err != nil {
+			return errors.Wrapf(err, "%s: write", name)
+		}
+	}
+
+	dir = filepath.Join(output, "FuzzBlock_DecodeBlock")
+	for _, name := range blocks {
+		data, err := os.ReadFile(filepath.Join(golden, name+".raw"))
+		if err != nil {
+			return errors.Wrapf(err, "%s: read", name)
+		}
+		var (
+			block   proto.Block
+			results proto.Results
+		)
+		if err := block.DecodeBlock(proto.NewReader(bytes.NewReader(data)), proto.Version, results.Auto()); err != nil {
+			return errors.Wrapf(err, "%s: decode", name)
+		}
+		if err := writeCorpus(dir, name, fmt.Sprintf("[]byte(%q)", data)); err != nil {
+			return errors.Wrapf(err, "%s: write", name)
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	var (
+		golden = flag.String("golden", "_golden", "path to golden files")
+		output = flag.String("output", filepath.Join("testdata", "fuzz"), "path to corpora")
+	)
+	flag.Parse()
+	if err := run(*golden, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %+v\n", err)
+		os.Exit(2)
+	}
+}
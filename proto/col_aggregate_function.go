@@ -0,0 +1,254 @@
+package proto
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// AggregateStateReader reads single serialized aggregate function state
+// from r, appends it to buf and returns result.
+//
+// Aggregate function states are serialized without length prefix, so
+// format of state should be known to split column to rows.
+type AggregateStateReader func(r *Reader, buf []byte) ([]byte, error)
+
+// ColAggregateFunction represents AggregateFunction(f, T1, ..., Tn) column
+// as opaque serialized states, that can be inserted back as-is.
+//
+// States of following functions are read automatically:
+//
+//	count, sum, sumWithOverflow, min, max, any, anyLast
+//
+// with fixed-size arguments, like numbers or dates. For other functions
+// ReadState should be set.
+type ColAggregateFunction struct {
+	Function  string       // function name with parameters, e.g. "quantiles(0.5, 0.9)"
+	Arguments []ColumnType // argument types
+
+	// ReadState reads state of single row. Inferred from Function and
+	// Arguments if not set.
+	ReadState AggregateStateReader
+
+	Buf []byte
+	Pos []Position
+}
+
+// Compile-time assertions for ColAggregateFunction.
+var (
+	_ ColInput         = ColAggregateFunction{}
+	_ ColResult        = (*ColAggregateFunction)(nil)
+	_ Column           = (*ColAggregateFunction)(nil)
+	_ ColumnOf[[]byte] = (*ColAggregateFunction)(nil)
+	_ Inferable        = (*ColAggregateFunction)(nil)
+)
+
+// Type returns AggregateFunction(Function, Arguments...).
+func (c ColAggregateFunction) Type() ColumnType {
+	params := []string{c.Function}
+	for _, a := range c.Arguments {
+		params = append(params, a.String())
+	}
+	return ColumnTypeAggregateFunction.With(params...)
+}
+
+// Infer sets Function and Arguments from column type.
+func (c *ColAggregateFunction) Infer(t ColumnType) error {
+	if t.Base() != ColumnTypeAggregateFunction {
+		return errors.Errorf("unexpected type %q", t)
+	}
+	elems := t.elems()
+	if len(elems) > 0 && isDigits(string(elems[0])) {
+		// Skipping aggregate function version.
+		elems = elems[1:]
+	}
+	if len(elems) == 0 {
+		return errors.Errorf("no function in %q", t)
+	}
+	c.Function = string(elems[0])
+	c.Arguments = elems[1:]
+	return nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Rows returns count of rows in column.
+func (c ColAggregateFunction) Rows() int {
+	return len(c.Pos)
+}
+
+// Reset resets data in column, preserving capacity.
+func (c *ColAggregateFunction) Reset() {
+	c.Buf = c.Buf[:0]
+	c.Pos = c.Pos[:0]
+}
+
+// Append serialized state to column.
+func (c *ColAggregateFunction) Append(v []byte) {
+	start := len(c.Buf)
+	c.Buf = append(c.Buf, v...)
+	c.Pos = append(c.Pos, Position{Start: start, End: len(c.Buf)})
+}
+
+// AppendArr appends serialized states to column.
+func (c *ColAggregateFunction) AppendArr(v [][]byte) {
+	for _, e := range v {
+		c.Append(e)
+	}
+}
+
+// Row returns serialized state of i-th row.
+func (c ColAggregateFunction) Row(i int) []byte {
+	p := c.Pos[i]
+	return c.Buf[p.Start:p.End]
+}
+
+// EncodeColumn encodes states to *Buffer.
+func (c ColAggregateFunction) EncodeColumn(b *Buffer) {
+	if b == nil {
+		return
+	}
+	for _, p := range c.Pos {
+		b.Buf = append(b.Buf, c.Buf[p.Start:p.End]...)
+	}
+}
+
+// DecodeColumn decodes states from *Reader.
+func (c *ColAggregateFunction) DecodeColumn(r *Reader, rows int) error {
+	read := c.ReadState
+	if read == nil {
+		read = aggregateStateReader(c.Function, c.Arguments)
+	}
+	if read == nil {
+		return errors.Errorf("unknown state format of %s (ReadState should be set)", c.Type())
+	}
+	for i := 0; i < rows; i++ {
+		start := len(c.Buf)
+		buf, err := read(r, c.Buf)
+		if err != nil {
+			return errors.Wrapf(err, "[%d]", i)
+		}
+		c.Buf = buf
+		c.Pos = append(c.Pos, Position{Start: start, End: len(c.Buf)})
+	}
+	return nil
+}
+
+// fixedSize returns size of fixed-size type, or zero.
+func fixedSize(t ColumnType) int {
+	switch t.Base() {
+	case ColumnTypeInt8, ColumnTypeUInt8, ColumnTypeBool, ColumnTypeEnum8:
+		return 1
+	case ColumnTypeInt16, ColumnTypeUInt16, ColumnTypeDate, ColumnTypeEnum16:
+		return 2
+	case ColumnTypeInt32, ColumnTypeUInt32, ColumnTypeFloat32,
+		ColumnTypeDate32, ColumnTypeDateTime, ColumnTypeIPv4, ColumnTypeDecimal32:
+		return 4
+	case ColumnTypeInt64, ColumnTypeUInt64, ColumnTypeFloat64,
+		ColumnTypeDateTime64, ColumnTypeDecimal64:
+		return 8
+	case ColumnTypeInt128, ColumnTypeUInt128, ColumnTypeUUID, ColumnTypeIPv6, ColumnTypeDecimal128:
+		return 16
+	case ColumnTypeInt256, ColumnTypeUInt256, ColumnTypeDecimal256:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// sumSize returns size of sum() state for argument type t, or zero.
+func sumSize(t ColumnType) int {
+	switch t.Base() {
+	case ColumnTypeInt8, ColumnTypeInt16, ColumnTypeInt32, ColumnTypeInt64,
+		ColumnTypeUInt8, ColumnTypeUInt16, ColumnTypeUInt32, ColumnTypeUInt64,
+		ColumnTypeFloat32, ColumnTypeFloat64:
+		return 8
+	case ColumnTypeInt128, ColumnTypeUInt128:
+		return 16
+	case ColumnTypeInt256, ColumnTypeUInt256:
+		return 32
+	default:
+		return 0
+	}
+}
+
+func readFixedState(n int) AggregateStateReader {
+	return func(r *Reader, buf []byte) ([]byte, error) {
+		data, err := r.ReadRaw(n)
+		if err != nil {
+			return nil, errors.Wrap(err, "read")
+		}
+		return append(buf, data...), nil
+	}
+}
+
+func readVarUIntState(r *Reader, buf []byte) ([]byte, error) {
+	for {
+		b, err := r.Byte()
+		if err != nil {
+			return nil, errors.Wrap(err, "read")
+		}
+		buf = append(buf, b)
+		if b < 0x80 {
+			return buf, nil
+		}
+	}
+}
+
+// readSingleValueState reads state of min, max, any and anyLast, that is
+// flag followed by value if flag is set.
+func readSingleValueState(n int) AggregateStateReader {
+	value := readFixedState(n)
+	return func(r *Reader, buf []byte) ([]byte, error) {
+		has, err := r.Byte()
+		if err != nil {
+			return nil, errors.Wrap(err, "flag")
+		}
+		buf = append(buf, has)
+		if has == 0 {
+			return buf, nil
+		}
+		return value(r, buf)
+	}
+}
+
+// aggregateStateReader returns AggregateStateReader for known function
+// or nil.
+func aggregateStateReader(function string, args []ColumnType) AggregateStateReader {
+	name := function
+	if idx := strings.Index(name, "("); idx > 0 {
+		name = name[:idx]
+	}
+	switch name {
+	case "count":
+		return readVarUIntState
+	}
+	if len(args) != 1 {
+		return nil
+	}
+	switch name {
+	case "sum":
+		if n := sumSize(args[0]); n > 0 {
+			return readFixedState(n)
+		}
+	case "sumWithOverflow":
+		if n := fixedSize(args[0]); n > 0 {
+			return readFixedState(n)
+		}
+	case "min", "max", "any", "anyLast":
+		if n := fixedSize(args[0]); n > 0 {
+			return readSingleValueState(n)
+		}
+	}
+	return nil
+}
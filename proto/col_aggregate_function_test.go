@@ -0,0 +1,90 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/internal/gold"
+)
+
+func TestColAggregateFunction(t *testing.T) {
+	for _, tt := range []struct {
+		Name   string
+		Type   ColumnType
+		States [][]byte
+	}{
+		{
+			Name:   "count",
+			Type:   "AggregateFunction(count)",
+			States: [][]byte{{0x00}, {0x01}, {0xac, 0x02}},
+		},
+		{
+			Name: "sum",
+			Type: "AggregateFunction(sum, UInt32)",
+			States: [][]byte{
+				{1, 0, 0, 0, 0, 0, 0, 0},
+				{2, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{
+			Name:   "max",
+			Type:   "AggregateFunction(max, Int16)",
+			States: [][]byte{{0}, {1, 10, 0}, {0}},
+		},
+		{
+			Name:   "versioned",
+			Type:   "AggregateFunction(1, anyLast, DateTime)",
+			States: [][]byte{{1, 1, 2, 3, 4}},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			var data ColAggregateFunction
+			require.NoError(t, data.Infer(tt.Type))
+			data.AppendArr(tt.States)
+
+			var buf Buffer
+			data.EncodeColumn(&buf)
+
+			r := NewReader(bytes.NewReader(buf.Buf))
+			dec := new(ColAuto)
+			require.NoError(t, dec.Infer(tt.Type))
+			require.NoError(t, dec.DecodeColumn(r, len(tt.States)))
+			require.Equal(t, len(tt.States), dec.Rows())
+			for i, s := range tt.States {
+				require.Equal(t, s, dec.Data.(*ColAggregateFunction).Row(i))
+			}
+			_, err := r.Byte()
+			require.Error(t, err, "should read all data")
+		})
+	}
+	t.Run("Golden", func(t *testing.T) {
+		var data ColAggregateFunction
+		require.NoError(t, data.Infer("AggregateFunction(sum, UInt64)"))
+		require.Equal(t, "sum", data.Function)
+		require.Equal(t, []ColumnType{ColumnTypeUInt64}, data.Arguments)
+		require.Equal(t, ColumnType("AggregateFunction(sum, UInt64)"), data.Type())
+		data.Append([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+		var buf Buffer
+		data.EncodeColumn(&buf)
+		gold.Bytes(t, buf.Buf, "col_aggregate_function")
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		var data ColAggregateFunction
+		require.NoError(t, data.Infer("AggregateFunction(uniq, String)"))
+		require.Error(t, data.DecodeColumn(NewReader(bytes.NewReader(nil)), 1))
+
+		// Custom reader.
+		data.ReadState = func(r *Reader, buf []byte) ([]byte, error) {
+			v, err := r.ReadRaw(2)
+			if err != nil {
+				return nil, err
+			}
+			return append(buf, v...), nil
+		}
+		require.NoError(t, data.DecodeColumn(NewReader(bytes.NewReader([]byte{1, 2, 3, 4})), 2))
+		require.Equal(t, []byte{3, 4}, data.Row(1))
+	})
+}
@@ -109,7 +109,7 @@ func (c *ColArr[T]) DecodeColumn(r *Reader, rows int) error {
 		// Pick last offset as total size of "elements" column.
 		size = int(c.Offsets[l-1])
 	}
-	if err := checkRows(size); err != nil {
+	if err := r.checkArrSize(size); err != nil {
 		return errors.Wrap(err, "array size")
 	}
 	if err := c.Data.DecodeColumn(r, size); err != nil {
@@ -50,6 +50,10 @@ func (c *ColAuto) Infer(t ColumnType) error {
 		c.Data = new(ColStr).LowCardinality()
 	case ColumnTypeArray.Sub(ColumnTypeLowCardinality.Sub(ColumnTypeString)):
 		c.Data = new(ColStr).LowCardinality().Array()
+	case ColumnTypeLowCardinality.Sub(ColumnTypeNullable.Sub(ColumnTypeString)):
+		c.Data = NewLowCardinality[Nullable[string]](new(ColStr).Nullable())
+	case ColumnTypeArray.Sub(ColumnTypeLowCardinality.Sub(ColumnTypeNullable.Sub(ColumnTypeString))):
+		c.Data = NewLowCardinality[Nullable[string]](new(ColStr).Nullable()).Array()
 	case ColumnTypeBool:
 		c.Data = new(ColBool)
 	case ColumnTypeDateTime:
@@ -58,6 +62,16 @@ func (c *ColAuto) Infer(t ColumnType) error {
 		c.Data = new(ColDate)
 	case "Map(String,String)":
 		c.Data = NewMap[string, string](new(ColStr), new(ColStr))
+	case ColumnTypePoint:
+		c.Data = new(ColPoint)
+	case ColumnTypeRing:
+		c.Data = new(ColRing)
+	case ColumnTypePolygon:
+		c.Data = new(ColPolygon)
+	case ColumnTypeMultiPolygon:
+		c.Data = new(ColMultiPolygon)
+	case ColumnTypeJSON:
+		c.Data = new(ColJSONStr)
 	case ColumnTypeUUID:
 		c.Data = new(ColUUID)
 	case ColumnTypeArray.Sub(ColumnTypeUUID):
@@ -82,6 +96,92 @@ func (c *ColAuto) Infer(t ColumnType) error {
 			c.Data = v
 			c.DataType = t
 			return nil
+		case ColumnTypeFixedString:
+			v := new(ColFixedStr)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "fixed string")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeMap:
+			if t.Normalize() == "Map(String, String)" {
+				c.Data = NewMap[string, string](new(ColStr), new(ColStr))
+				c.DataType = t
+				return nil
+			}
+		case ColumnTypeArray:
+			if t.Elem().Base() != ColumnTypeFixedString {
+				break
+			}
+			v := new(ColFixedStr)
+			if err := v.Infer(t.Elem()); err != nil {
+				return errors.Wrap(err, "array of fixed string")
+			}
+			c.Data = v.Array()
+			c.DataType = t
+			return nil
+		case ColumnTypeDecimal, ColumnTypeDecimal32, ColumnTypeDecimal64,
+			ColumnTypeDecimal128, ColumnTypeDecimal256:
+			v := new(ColDecimal)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "decimal")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeVariant:
+			v := new(ColVariant)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "variant")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeTuple:
+			v, err := inferTuple(t)
+			if err != nil {
+				return errors.Wrap(err, "tuple")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeDynamic:
+			v := new(ColDynamic)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "dynamic")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeAggregateFunction:
+			v := new(ColAggregateFunction)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "aggregate function")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
+		case ColumnTypeSimpleAggregateFunction:
+			inner, ok := t.simpleAggregateType()
+			if !ok {
+				return errors.Errorf("invalid type %q", t)
+			}
+			v := new(ColAuto)
+			if err := v.Infer(inner); err != nil {
+				return errors.Wrap(err, "simple aggregate function")
+			}
+			c.Data = v.Data
+			c.DataType = t
+			return nil
+		case ColumnTypeJSON:
+			v := new(ColJSONStr)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrap(err, "json")
+			}
+			c.Data = v
+			c.DataType = t
+			return nil
 		case ColumnTypeDateTime64:
 			v := new(ColDateTime64)
 			if err := v.Infer(t); err != nil {
@@ -91,6 +191,11 @@ func (c *ColAuto) Infer(t ColumnType) error {
 			c.DataType = t
 			return nil
 		}
+		if v := inferWrapped(t); v != nil {
+			c.Data = v
+			c.DataType = t
+			return nil
+		}
 		return errors.Errorf("automatic column inference not supported for %q", t)
 	}
 
@@ -99,8 +204,10 @@ func (c *ColAuto) Infer(t ColumnType) error {
 }
 
 var (
-	_ Column    = &ColAuto{}
-	_ Inferable = &ColAuto{}
+	_ Column     = &ColAuto{}
+	_ Inferable  = &ColAuto{}
+	_ Stateful   = &ColAuto{}
+	_ Preparable = &ColAuto{}
 )
 
 func (c ColAuto) Type() ColumnType {
@@ -122,3 +229,25 @@ func (c ColAuto) Reset() {
 func (c ColAuto) EncodeColumn(b *Buffer) {
 	c.Data.EncodeColumn(b)
 }
+
+func (c ColAuto) DecodeState(r *Reader) error {
+	if s, ok := c.Data.(StateDecoder); ok {
+		return s.DecodeState(r)
+	}
+	return nil
+}
+
+// Prepare ensures Preparable column propagation, e.g. for inserting
+// LowCardinality column.
+func (c ColAuto) Prepare() error {
+	if p, ok := c.Data.(Preparable); ok {
+		return p.Prepare()
+	}
+	return nil
+}
+
+func (c ColAuto) EncodeState(b *Buffer) {
+	if s, ok := c.Data.(StateEncoder); ok {
+		s.EncodeState(b)
+	}
+}
@@ -16,6 +16,12 @@ func inferGenerated(t ColumnType) Column {
 		return new(ColFloat64).Nullable()
 	case ColumnTypeFloat64:
 		return new(ColFloat64)
+	case ColumnTypeArray.Sub(ColumnTypeBFloat16):
+		return new(ColBFloat16).Array()
+	case ColumnTypeNullable.Sub(ColumnTypeBFloat16):
+		return new(ColBFloat16).Nullable()
+	case ColumnTypeBFloat16:
+		return new(ColBFloat16)
 	case ColumnTypeArray.Sub(ColumnTypeIPv4):
 		return new(ColIPv4).Array()
 	case ColumnTypeNullable.Sub(ColumnTypeIPv4):
@@ -32,6 +32,8 @@ func TestColAuto_Infer(t *testing.T) {
 		ColumnTypeIPv4,
 		ColumnTypeIPv6,
 		ColumnTypeLowCardinality.Sub(ColumnTypeString),
+		ColumnTypeLowCardinality.Sub(ColumnTypeNullable.Sub(ColumnTypeString)),
+		ColumnTypeArray.Sub(ColumnTypeLowCardinality.Sub(ColumnTypeNullable.Sub(ColumnTypeString))),
 		ColumnTypeDateTime.Sub("Europe/Berlin"),
 		ColumnTypeDateTime64.Sub("9"),
 		"Map(String,String)",
@@ -40,12 +42,29 @@ func TestColAuto_Infer(t *testing.T) {
 		"IntervalSecond",
 		"IntervalMinute",
 		ColumnType(IntervalHour.String()),
+		"IntervalMillisecond",
 		ColumnTypeNothing,
 		"Nullable(Nothing)",
 		"Array(Nothing)",
 		ColumnTypeUUID,
 		ColumnTypeArray.Sub(ColumnTypeUUID),
 		ColumnTypeNullable.Sub(ColumnTypeUUID),
+		ColumnTypeJSON,
+		"Variant(String, UInt64)",
+		"Variant(Array(String), String, UInt64)",
+		ColumnTypeDynamic,
+		ColumnTypePoint,
+		ColumnTypeRing,
+		ColumnTypePolygon,
+		ColumnTypeMultiPolygon,
+		"AggregateFunction(sum, UInt64)",
+		"AggregateFunction(quantiles(0.5, 0.9), Float64)",
+		"SimpleAggregateFunction(sum, UInt64)",
+		"SimpleAggregateFunction(anyLast, Array(String))",
+		"Tuple(String, Int64)",
+		"Tuple(a UInt8, b Array(String))",
+		"Tuple(`1` String, `a b` Int64)",
+		"Dynamic(max_types=10)",
 	} {
 		r := AutoResult("foo")
 		require.NoError(t, r.Data.(Inferable).Infer(columnType))
@@ -54,3 +73,50 @@ func TestColAuto_Infer(t *testing.T) {
 		require.Equal(t, 0, r.Data.Rows())
 	}
 }
+
+func TestNewColumn(t *testing.T) {
+	for _, columnType := range []ColumnType{
+		"Array(Nullable(Int32))",
+		"Array(Array(String))",
+		"Nullable(DateTime64(3))",
+		"Nullable(Enum8('a' = 1, 'b' = 2))",
+		"Array(Nullable(UUID))",
+		"Nullable(FixedString(4))",
+		"LowCardinality(UInt32)",
+		"Array(LowCardinality(Int64))",
+		"Nullable(Array(Int8))",
+		"Array(Array(Nullable(Float64)))",
+	} {
+		col, err := NewColumn(columnType)
+		if columnType == "Array(Array(Nullable(Float64)))" {
+			require.Error(t, err, "nesting is limited")
+			continue
+		}
+		require.NoError(t, err, columnType)
+		require.Equal(t, columnType, col.Type())
+		require.Equal(t, 0, col.Rows())
+	}
+
+	col, err := NewColumn("Array(Nullable(Int32))")
+	require.NoError(t, err)
+	data, ok := col.(ColumnOf[[]Nullable[int32]])
+	require.True(t, ok)
+	data.Append([]Nullable[int32]{NewNullable[int32](1), Null[int32]()})
+	data.Append(nil)
+
+	var buf Buffer
+	col.EncodeColumn(&buf)
+	dec := &ColAuto{}
+	require.NoError(t, dec.Infer(col.Type()))
+	require.NoError(t, dec.DecodeColumn(buf.Reader(), 2))
+	require.Equal(t, data.Row(0), dec.Data.(ColumnOf[[]Nullable[int32]]).Row(0))
+
+	lc, err := NewColumn("LowCardinality(UInt32)")
+	require.NoError(t, err)
+	lc.(ColumnOf[uint32]).Append(10)
+	auto := ColAuto{Data: lc, DataType: lc.Type()}
+	require.NoError(t, auto.Prepare())
+
+	_, err = NewColumn("Unknown")
+	require.Error(t, err)
+}
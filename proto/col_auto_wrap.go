@@ -0,0 +1,236 @@
+package proto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewColumn returns new empty column of type t, e.g. to build INSERT input
+// from DESCRIBE TABLE output.
+//
+// Returned column can be type-asserted to ColumnOf[T] to append values,
+// e.g. ColumnOf[string] for String or ColumnOf[[]Nullable[int32]] for
+// Array(Nullable(Int32)).
+func NewColumn(t ColumnType) (Column, error) {
+	var c ColAuto
+	if err := c.Infer(t); err != nil {
+		return nil, err
+	}
+	return c.Data, nil
+}
+
+// colWrapper wraps ColumnOf[T] into Array or Nullable when T is known
+// only at runtime.
+type colWrapper interface {
+	// wrap column into wrappers, listed from innermost, e.g. Nullable and
+	// Array for Array(Nullable(T)).
+	wrap(wrappers []ColumnType) Column
+}
+
+// maxWrapDepth is maximum count of nested wrappers, every level requires
+// separate instantiation, so depth can't be arbitrary.
+const maxWrapDepth = 2
+
+type colWrapperOf[T any] struct {
+	col ColumnOf[T]
+}
+
+func (w colWrapperOf[T]) wrap(wrappers []ColumnType) Column {
+	switch len(wrappers) {
+	case 1:
+		return wrap1[T](w.col, wrappers[0])
+	case 2:
+		return wrap2[T](w.col, wrappers[0], wrappers[1])
+	default:
+		return nil
+	}
+}
+
+func wrap1[T any](c ColumnOf[T], w ColumnType) Column {
+	switch w {
+	case ColumnTypeArray:
+		return NewArray[T](c)
+	case ColumnTypeNullable:
+		return NewColNullable[T](c)
+	default:
+		return nil
+	}
+}
+
+func wrap2[T any](c ColumnOf[T], w1, w2 ColumnType) Column {
+	switch w1 {
+	case ColumnTypeArray:
+		return wrap1[[]T](NewArray[T](c), w2)
+	case ColumnTypeNullable:
+		return wrap1[Nullable[T]](NewColNullable[T](c), w2)
+	default:
+		return nil
+	}
+}
+
+// wrapperOf returns colWrapper for column with known element type.
+func wrapperOf(col Column) colWrapper {
+	switch v := col.(type) {
+	case ColumnOf[string]:
+		return colWrapperOf[string]{col: v}
+	case ColumnOf[[]byte]:
+		return colWrapperOf[[]byte]{col: v}
+	case ColumnOf[bool]:
+		return colWrapperOf[bool]{col: v}
+	case ColumnOf[int8]:
+		return colWrapperOf[int8]{col: v}
+	case ColumnOf[int16]:
+		return colWrapperOf[int16]{col: v}
+	case ColumnOf[int32]:
+		return colWrapperOf[int32]{col: v}
+	case ColumnOf[int64]:
+		return colWrapperOf[int64]{col: v}
+	case ColumnOf[uint8]:
+		return colWrapperOf[uint8]{col: v}
+	case ColumnOf[uint16]:
+		return colWrapperOf[uint16]{col: v}
+	case ColumnOf[uint32]:
+		return colWrapperOf[uint32]{col: v}
+	case ColumnOf[uint64]:
+		return colWrapperOf[uint64]{col: v}
+	case ColumnOf[Int128]:
+		return colWrapperOf[Int128]{col: v}
+	case ColumnOf[UInt128]:
+		return colWrapperOf[UInt128]{col: v}
+	case ColumnOf[Int256]:
+		return colWrapperOf[Int256]{col: v}
+	case ColumnOf[UInt256]:
+		return colWrapperOf[UInt256]{col: v}
+	case ColumnOf[float32]:
+		return colWrapperOf[float32]{col: v}
+	case ColumnOf[float64]:
+		return colWrapperOf[float64]{col: v}
+	case ColumnOf[BFloat16]:
+		return colWrapperOf[BFloat16]{col: v}
+	case ColumnOf[Decimal32]:
+		return colWrapperOf[Decimal32]{col: v}
+	case ColumnOf[Decimal64]:
+		return colWrapperOf[Decimal64]{col: v}
+	case ColumnOf[Decimal128]:
+		return colWrapperOf[Decimal128]{col: v}
+	case ColumnOf[Decimal256]:
+		return colWrapperOf[Decimal256]{col: v}
+	case ColumnOf[time.Time]:
+		return colWrapperOf[time.Time]{col: v}
+	case ColumnOf[uuid.UUID]:
+		return colWrapperOf[uuid.UUID]{col: v}
+	case ColumnOf[IPv4]:
+		return colWrapperOf[IPv4]{col: v}
+	case ColumnOf[IPv6]:
+		return colWrapperOf[IPv6]{col: v}
+	case ColumnOf[Enum8]:
+		return colWrapperOf[Enum8]{col: v}
+	case ColumnOf[Enum16]:
+		return colWrapperOf[Enum16]{col: v}
+	case ColumnOf[Interval]:
+		return colWrapperOf[Interval]{col: v}
+	case ColumnOf[Point]:
+		return colWrapperOf[Point]{col: v}
+	case ColumnOf[Ring]:
+		return colWrapperOf[Ring]{col: v}
+	case ColumnOf[Polygon]:
+		return colWrapperOf[Polygon]{col: v}
+	case ColumnOf[MultiPolygon]:
+		return colWrapperOf[MultiPolygon]{col: v}
+	case ColumnOf[Nothing]:
+		return colWrapperOf[Nothing]{col: v}
+	default:
+		return nil
+	}
+}
+
+// lowCardinalityOf returns LowCardinality(T) of column with known
+// comparable element type.
+func lowCardinalityOf(col Column) Column {
+	switch v := col.(type) {
+	case ColumnOf[string]:
+		return NewLowCardinality[string](v)
+	case ColumnOf[int8]:
+		return NewLowCardinality[int8](v)
+	case ColumnOf[int16]:
+		return NewLowCardinality[int16](v)
+	case ColumnOf[int32]:
+		return NewLowCardinality[int32](v)
+	case ColumnOf[int64]:
+		return NewLowCardinality[int64](v)
+	case ColumnOf[uint8]:
+		return NewLowCardinality[uint8](v)
+	case ColumnOf[uint16]:
+		return NewLowCardinality[uint16](v)
+	case ColumnOf[uint32]:
+		return NewLowCardinality[uint32](v)
+	case ColumnOf[uint64]:
+		return NewLowCardinality[uint64](v)
+	case ColumnOf[float32]:
+		return NewLowCardinality[float32](v)
+	case ColumnOf[float64]:
+		return NewLowCardinality[float64](v)
+	case ColumnOf[time.Time]:
+		return NewLowCardinality[time.Time](v)
+	case ColumnOf[uuid.UUID]:
+		return NewLowCardinality[uuid.UUID](v)
+	case ColumnOf[Nullable[string]]:
+		return NewLowCardinality[Nullable[string]](v)
+	case ColumnOf[Nullable[int64]]:
+		return NewLowCardinality[Nullable[int64]](v)
+	case ColumnOf[Nullable[uint64]]:
+		return NewLowCardinality[Nullable[uint64]](v)
+	case ColumnOf[Nullable[float64]]:
+		return NewLowCardinality[Nullable[float64]](v)
+	default:
+		return nil
+	}
+}
+
+// inferWrapped infers Array(T), Nullable(T) and LowCardinality(T) of
+// any supported T, including nested ones like Array(Nullable(T)).
+func inferWrapped(t ColumnType) Column {
+	var wrappers []ColumnType
+	elem := t
+	for {
+		base := elem.Base()
+		if base != ColumnTypeArray && base != ColumnTypeNullable {
+			break
+		}
+		wrappers = append(wrappers, base)
+		elem = elem.Elem()
+	}
+	if len(wrappers) == 0 && t.Base() != ColumnTypeLowCardinality {
+		return nil
+	}
+	if len(wrappers) > maxWrapDepth {
+		return nil
+	}
+	var col Column
+	if elem.Base() == ColumnTypeLowCardinality {
+		inner, err := NewColumn(elem.Elem())
+		if err != nil {
+			return nil
+		}
+		col = lowCardinalityOf(inner)
+	} else {
+		v, err := NewColumn(elem)
+		if err != nil {
+			return nil
+		}
+		col = v
+	}
+	if col == nil || len(wrappers) == 0 {
+		return col
+	}
+	w := wrapperOf(col)
+	if w == nil {
+		return nil
+	}
+	// Wrappers are collected from outermost.
+	for i, j := 0, len(wrappers)-1; i < j; i, j = i+1, j-1 {
+		wrappers[i], wrappers[j] = wrappers[j], wrappers[i]
+	}
+	return w.wrap(wrappers)
+}
@@ -0,0 +1,22 @@
+package proto
+
+// AppendFloat32 appends float32 value, converted to BFloat16.
+func (c *ColBFloat16) AppendFloat32(v float32) {
+	*c = append(*c, NewBFloat16(v))
+}
+
+// AppendFloat32s appends float32 values, converted to BFloat16.
+func (c *ColBFloat16) AppendFloat32s(vs []float32) {
+	for _, v := range vs {
+		*c = append(*c, NewBFloat16(v))
+	}
+}
+
+// Float32s appends all values of column to dst as float32 and returns
+// resulting slice.
+func (c ColBFloat16) Float32s(dst []float32) []float32 {
+	for _, v := range c {
+		dst = append(dst, v.Float32())
+	}
+	return dst
+}
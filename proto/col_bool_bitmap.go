@@ -0,0 +1,139 @@
+package proto
+
+import (
+	"math/bits"
+
+	"github.com/go-faster/errors"
+)
+
+// Compile-time assertions for ColBoolBitmap.
+var (
+	_ ColInput       = ColBoolBitmap{}
+	_ ColResult      = (*ColBoolBitmap)(nil)
+	_ Column         = (*ColBoolBitmap)(nil)
+	_ ColumnOf[bool] = (*ColBoolBitmap)(nil)
+)
+
+// ColBoolBitmap is Bool column that packs values to bitmap, using one bit
+// per value instead of one byte like ColBool.
+//
+// Values are converted to byte-per-value wire form on encoding, so
+// ColBoolBitmap is useful for holding many rows in memory, trading some
+// CPU on encoding and decoding.
+type ColBoolBitmap struct {
+	Bits []uint64
+	Len  int
+}
+
+// Row returns i-th value.
+func (c ColBoolBitmap) Row(i int) bool {
+	return c.Bits[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// Append value.
+func (c *ColBoolBitmap) Append(v bool) {
+	i := c.Len
+	if i/64 >= len(c.Bits) {
+		c.Bits = append(c.Bits, 0)
+	}
+	if v {
+		c.Bits[i/64] |= 1 << (uint(i) % 64)
+	}
+	c.Len++
+}
+
+// AppendArr appends values.
+func (c *ColBoolBitmap) AppendArr(vs []bool) {
+	c.grow(len(vs))
+	for _, v := range vs {
+		if v {
+			c.Bits[c.Len/64] |= 1 << (uint(c.Len) % 64)
+		}
+		c.Len++
+	}
+}
+
+// grow ensures that Bits can hold n more values.
+func (c *ColBoolBitmap) grow(n int) {
+	need := (c.Len + n + 63) / 64
+	if need > len(c.Bits) {
+		c.Bits = append(c.Bits, make([]uint64, need-len(c.Bits))...)
+	}
+}
+
+// Count returns count of true values.
+func (c ColBoolBitmap) Count() int {
+	var n int
+	for _, w := range c.Bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Type returns ColumnType of Bool.
+func (ColBoolBitmap) Type() ColumnType {
+	return ColumnTypeBool
+}
+
+// Rows returns count of rows in column.
+func (c ColBoolBitmap) Rows() int {
+	return c.Len
+}
+
+// Reset resets data in row, preserving capacity for efficiency.
+func (c *ColBoolBitmap) Reset() {
+	c.Bits = c.Bits[:0]
+	c.Len = 0
+}
+
+// EncodeColumn encodes Bool rows to *Buffer.
+func (c ColBoolBitmap) EncodeColumn(b *Buffer) {
+	if c.Len == 0 {
+		return
+	}
+	start := len(b.Buf)
+	b.Buf = append(b.Buf, make([]byte, c.Len)...)
+	dst := b.Buf[start:]
+	for i := range dst {
+		if c.Row(i) {
+			dst[i] = boolTrue
+		}
+	}
+}
+
+// DecodeColumn decodes Bool rows from *Reader.
+func (c *ColBoolBitmap) DecodeColumn(r *Reader, rows int) error {
+	if rows == 0 {
+		return nil
+	}
+	data, err := r.ReadRaw(rows)
+	if err != nil {
+		return errors.Wrap(err, "read")
+	}
+	c.grow(rows)
+	for i, v := range data {
+		switch v {
+		case boolTrue:
+			c.Bits[c.Len/64] |= 1 << (uint(c.Len) % 64)
+		case boolFalse:
+		default:
+			return errors.Errorf("[%d]: bad value %d for Bool", i, v)
+		}
+		c.Len++
+	}
+	return nil
+}
+
+// Array is helper that creates Array(Bool).
+func (c *ColBoolBitmap) Array() *ColArr[bool] {
+	return &ColArr[bool]{
+		Data: c,
+	}
+}
+
+// Nullable is helper that creates Nullable(Bool).
+func (c *ColBoolBitmap) Nullable() *ColNullable[bool] {
+	return &ColNullable[bool]{
+		Values: c,
+	}
+}
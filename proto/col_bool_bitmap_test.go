@@ -0,0 +1,80 @@
+package proto
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColBoolBitmap_DecodeColumn(t *testing.T) {
+	const rows = 150
+	var (
+		data   ColBoolBitmap
+		values []bool
+	)
+	for i := 0; i < rows; i++ {
+		v := (i % 3) == 0
+		values = append(values, v)
+		if i%2 == 0 {
+			data.Append(v)
+		} else {
+			data.AppendArr([]bool{v})
+		}
+	}
+	require.Equal(t, rows, data.Rows())
+	require.Equal(t, 50, data.Count())
+	require.Len(t, data.Bits, 3)
+	for i, v := range values {
+		require.Equal(t, v, data.Row(i))
+	}
+
+	var buf Buffer
+	data.EncodeColumn(&buf)
+	t.Run("SameAsColBool", func(t *testing.T) {
+		var expected Buffer
+		ColBool(values).EncodeColumn(&expected)
+		require.Equal(t, expected.Buf, buf.Buf)
+	})
+	t.Run("Ok", func(t *testing.T) {
+		br := bytes.NewReader(buf.Buf)
+		r := NewReader(br)
+
+		var dec ColBoolBitmap
+		require.NoError(t, dec.DecodeColumn(r, rows))
+		require.Equal(t, data, dec)
+		require.Equal(t, rows, dec.Rows())
+		dec.Reset()
+		require.Equal(t, 0, dec.Rows())
+		require.Equal(t, ColumnTypeBool, dec.Type())
+
+		// Reuse after reset.
+		dec.AppendArr([]bool{false, true})
+		require.Equal(t, 1, dec.Count())
+		require.True(t, dec.Row(1))
+	})
+	t.Run("BadValue", func(t *testing.T) {
+		var dec ColBoolBitmap
+		require.Error(t, dec.DecodeColumn(NewReader(bytes.NewReader([]byte{1, 2})), 2))
+	})
+	t.Run("EOF", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(nil))
+
+		var dec ColBoolBitmap
+		require.ErrorIs(t, dec.DecodeColumn(r, rows), io.EOF)
+	})
+	t.Run("NoShortRead", func(t *testing.T) {
+		var dec ColBoolBitmap
+		requireNoShortRead(t, buf.Buf, colAware(&dec, rows))
+	})
+	t.Run("Array", func(t *testing.T) {
+		arr := new(ColBoolBitmap).Array()
+		arr.Append([]bool{true, false, true})
+		var buf Buffer
+		arr.EncodeColumn(&buf)
+		dec := new(ColBoolBitmap).Array()
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 1))
+		require.Equal(t, []bool{true, false, true}, dec.Row(0))
+	})
+}
@@ -26,3 +26,18 @@ func (c *ColDate32) Reset() {
 func (ColDate32) Type() ColumnType {
 	return ColumnTypeDate32
 }
+
+// Slice returns rows [i, j) of column without copying.
+func (c ColDate32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDate32.
+func (c *ColDate32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDate32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
@@ -26,3 +26,18 @@ func (c *ColDate) Reset() {
 func (ColDate) Type() ColumnType {
 	return ColumnTypeDate
 }
+
+// Slice returns rows [i, j) of column without copying.
+func (c ColDate) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDate.
+func (c *ColDate) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDate](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
@@ -16,6 +16,8 @@ var (
 type ColDateTime struct {
 	Data     []DateTime
 	Location *time.Location
+
+	defaultLocation *time.Location
 }
 
 func (c *ColDateTime) Reset() {
@@ -50,11 +52,14 @@ func (c *ColDateTime) Infer(t ColumnType) error {
 }
 
 func (c ColDateTime) loc() *time.Location {
-	if c.Location == nil {
-		// Defaulting to local timezone (not UTC).
-		return time.Local
+	if c.Location != nil {
+		return c.Location
+	}
+	if c.defaultLocation != nil {
+		return c.defaultLocation
 	}
-	return c.Location
+	// Defaulting to local timezone (not UTC).
+	return time.Local
 }
 
 func (c ColDateTime) Row(i int) time.Time {
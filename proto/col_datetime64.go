@@ -24,6 +24,8 @@ type ColDateTime64 struct {
 	Location     *time.Location
 	Precision    Precision
 	PrecisionSet bool
+
+	defaultLocation *time.Location
 }
 
 func (c *ColDateTime64) WithPrecision(p Precision) *ColDateTime64 {
@@ -93,11 +95,14 @@ func (c ColDateTime64) Row(i int) time.Time {
 }
 
 func (c ColDateTime64) loc() *time.Location {
-	if c.Location == nil {
-		// Defaulting to local timezone (not UTC).
-		return time.Local
+	if c.Location != nil {
+		return c.Location
+	}
+	if c.defaultLocation != nil {
+		return c.defaultLocation
 	}
-	return c.Location
+	// Defaulting to local timezone (not UTC).
+	return time.Local
 }
 
 func (c *ColDateTime64) AppendRaw(v DateTime64) {
@@ -0,0 +1,255 @@
+package proto
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+var (
+	_ Column    = (*ColDecimal)(nil)
+	_ Inferable = (*ColDecimal)(nil)
+)
+
+// Maximum precision of Decimal types.
+const (
+	decimal32Precision  = 9
+	decimal64Precision  = 18
+	decimal128Precision = 38
+	decimal256Precision = 76
+)
+
+// ColDecimal is Decimal(P, S) column that honors precision and scale.
+//
+// Values are stored as unscaled integers in ColDecimal32, ColDecimal64,
+// ColDecimal128 or ColDecimal256, depending on precision. Precision and
+// scale are inferred from column type or can be set via NewDecimal.
+type ColDecimal struct {
+	Precision int
+	Scale     int
+
+	raw32  ColDecimal32
+	raw64  ColDecimal64
+	raw128 ColDecimal128
+	raw256 ColDecimal256
+}
+
+// NewDecimal returns new Decimal(precision, scale) column.
+func NewDecimal(precision, scale int) *ColDecimal {
+	return &ColDecimal{
+		Precision: precision,
+		Scale:     scale,
+	}
+}
+
+// Raw returns underlying column with unscaled values, i.e. one of
+// ColDecimal32, ColDecimal64, ColDecimal128 or ColDecimal256.
+func (c *ColDecimal) Raw() Column {
+	switch {
+	case c.Precision <= decimal32Precision:
+		return &c.raw32
+	case c.Precision <= decimal64Precision:
+		return &c.raw64
+	case c.Precision <= decimal128Precision:
+		return &c.raw128
+	default:
+		return &c.raw256
+	}
+}
+
+func (c *ColDecimal) Type() ColumnType {
+	return ColumnTypeDecimal.With(strconv.Itoa(c.Precision), strconv.Itoa(c.Scale))
+}
+
+// Infer precision and scale from Decimal(P, S) or DecimalN(S) type.
+func (c *ColDecimal) Infer(t ColumnType) error {
+	elems := t.elems()
+	parse := func(i int) (int, error) {
+		if i >= len(elems) {
+			return 0, errors.Errorf("invalid %q: not enough parameters", t)
+		}
+		v, err := strconv.Atoi(string(elems[i]))
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid %q", t)
+		}
+		return v, nil
+	}
+	var (
+		precision int
+		scaleIdx  int
+	)
+	switch t.Base() {
+	case ColumnTypeDecimal:
+		p, err := parse(0)
+		if err != nil {
+			return errors.Wrap(err, "precision")
+		}
+		precision = p
+		scaleIdx = 1
+	case ColumnTypeDecimal32:
+		precision = decimal32Precision
+	case ColumnTypeDecimal64:
+		precision = decimal64Precision
+	case ColumnTypeDecimal128:
+		precision = decimal128Precision
+	case ColumnTypeDecimal256:
+		precision = decimal256Precision
+	default:
+		return errors.Errorf("invalid base %q to infer decimal", t.Base())
+	}
+	scale, err := parse(scaleIdx)
+	if err != nil {
+		return errors.Wrap(err, "scale")
+	}
+	if precision < 1 || precision > decimal256Precision {
+		return errors.Errorf("precision %d is out of range", precision)
+	}
+	if scale < 0 || scale > precision {
+		return errors.Errorf("scale %d is out of range", scale)
+	}
+	c.Precision = precision
+	c.Scale = scale
+	return nil
+}
+
+func (c *ColDecimal) Rows() int {
+	return c.Raw().Rows()
+}
+
+func (c *ColDecimal) Reset() {
+	c.Raw().Reset()
+}
+
+func (c *ColDecimal) DecodeColumn(r *Reader, rows int) error {
+	return c.Raw().DecodeColumn(r, rows)
+}
+
+func (c *ColDecimal) EncodeColumn(b *Buffer) {
+	c.Raw().EncodeColumn(b)
+}
+
+// RowUnscaled returns i-th row as unscaled integer, e.g. 12345 for 123.45
+// with scale 2.
+func (c *ColDecimal) RowUnscaled(i int) *big.Int {
+	switch v := c.Raw().(type) {
+	case *ColDecimal32:
+		return big.NewInt(int64(v.Row(i)))
+	case *ColDecimal64:
+		return big.NewInt(int64(v.Row(i)))
+	case *ColDecimal128:
+		return Int128(v.Row(i)).Big()
+	default:
+		return Int256(c.raw256.Row(i)).Big()
+	}
+}
+
+// RowBigRat returns i-th row as big.Rat.
+func (c *ColDecimal) RowBigRat(i int) *big.Rat {
+	return new(big.Rat).SetFrac(c.RowUnscaled(i), decimalPow10(c.Scale))
+}
+
+// RowString returns i-th row as decimal string, e.g. "-123.45".
+func (c *ColDecimal) RowString(i int) string {
+	v := c.RowUnscaled(i)
+	digits := new(big.Int).Abs(v).String()
+	if c.Scale > 0 {
+		if len(digits) <= c.Scale {
+			digits = strings.Repeat("0", c.Scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-c.Scale] + "." + digits[len(digits)-c.Scale:]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// AppendUnscaled appends unscaled integer value, e.g. 12345 for 123.45
+// with scale 2.
+//
+// Returns error if value does not fit into precision.
+func (c *ColDecimal) AppendUnscaled(v *big.Int) error {
+	if new(big.Int).Abs(v).Cmp(decimalPow10(c.Precision)) >= 0 {
+		return errors.Errorf("value %s does not fit into Decimal(%d, %d)", v, c.Precision, c.Scale)
+	}
+	switch r := c.Raw().(type) {
+	case *ColDecimal32:
+		r.Append(Decimal32(v.Int64()))
+	case *ColDecimal64:
+		r.Append(Decimal64(v.Int64()))
+	case *ColDecimal128:
+		x, err := Int128FromBig(v)
+		if err != nil {
+			return err
+		}
+		r.Append(Decimal128(x))
+	case *ColDecimal256:
+		x, err := Int256FromBig(v)
+		if err != nil {
+			return err
+		}
+		r.Append(Decimal256(x))
+	}
+	return nil
+}
+
+// AppendBigRat appends big.Rat value.
+//
+// Returns error if value can't be represented with column scale without
+// rounding or does not fit into precision.
+func (c *ColDecimal) AppendBigRat(v *big.Rat) error {
+	scaled := new(big.Rat).Mul(v, new(big.Rat).SetInt(decimalPow10(c.Scale)))
+	if !scaled.IsInt() {
+		return errors.Errorf("value %s can't be represented with scale %d", v.RatString(), c.Scale)
+	}
+	return c.AppendUnscaled(scaled.Num())
+}
+
+// AppendDecimalString appends value from decimal string, e.g. "-123.45".
+//
+// Returns error if value has more fractional digits than column scale
+// (trailing zeros are allowed) or does not fit into precision.
+func (c *ColDecimal) AppendDecimalString(s string) error {
+	digits := s
+	var neg bool
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		neg = true
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if integer == "" && fraction == "" {
+		return errors.Errorf("invalid decimal %q", s)
+	}
+	if len(fraction) > c.Scale {
+		extra := strings.TrimRight(fraction[c.Scale:], "0")
+		if extra != "" {
+			return errors.Errorf("decimal %q has more than %d fractional digits", s, c.Scale)
+		}
+		fraction = fraction[:c.Scale]
+	}
+	fraction += strings.Repeat("0", c.Scale-len(fraction))
+	unscaled := integer + fraction
+	for _, ch := range unscaled {
+		if ch < '0' || ch > '9' {
+			return errors.Errorf("invalid decimal %q", s)
+		}
+	}
+	v, ok := new(big.Int).SetString(unscaled, 10)
+	if !ok {
+		return errors.Errorf("invalid decimal %q", s)
+	}
+	if neg {
+		v.Neg(v)
+	}
+	return c.AppendUnscaled(v)
+}
+
+// decimalPow10 returns 10^n.
+func decimalPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
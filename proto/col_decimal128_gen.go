@@ -27,6 +27,21 @@ func (ColDecimal128) Type() ColumnType {
 	return ColumnTypeDecimal128
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColDecimal128) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDecimal128.
+func (c *ColDecimal128) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDecimal128](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColDecimal128) Row(i int) Decimal128 {
 	return c[i]
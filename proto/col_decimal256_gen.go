@@ -27,6 +27,21 @@ func (ColDecimal256) Type() ColumnType {
 	return ColumnTypeDecimal256
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColDecimal256) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDecimal256.
+func (c *ColDecimal256) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDecimal256](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColDecimal256) Row(i int) Decimal256 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColDecimal32) Type() ColumnType {
 	return ColumnTypeDecimal32
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColDecimal32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDecimal32.
+func (c *ColDecimal32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDecimal32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColDecimal32) Row(i int) Decimal32 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColDecimal64) Type() ColumnType {
 	return ColumnTypeDecimal64
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColDecimal64) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColDecimal64.
+func (c *ColDecimal64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDecimal64](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColDecimal64) Row(i int) Decimal64 {
 	return c[i]
@@ -0,0 +1,143 @@
+package proto
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColDecimal(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		Type   ColumnType
+		Values []string
+		Raw    Column
+	}{
+		{
+			Type:   "Decimal(9, 2)",
+			Values: []string{"0.00", "123.45", "-123.45", "0.01", "-0.50", "9999999.99"},
+			Raw:    new(ColDecimal32),
+		},
+		{
+			Type:   "Decimal(18, 4)",
+			Values: []string{"0.0000", "-1.0000", "12345678901234.5678"},
+			Raw:    new(ColDecimal64),
+		},
+		{
+			Type:   "Decimal(38, 10)",
+			Values: []string{"0.0000000000", "-1234567890123456789012345678.0123456789", "0.0000000001"},
+			Raw:    new(ColDecimal128),
+		},
+		{
+			Type: "Decimal(76, 20)",
+			Values: []string{
+				"-" + strings.Repeat("9", 56) + "." + strings.Repeat("9", 20),
+				"1.00000000000000000001",
+			},
+			Raw: new(ColDecimal256),
+		},
+		{
+			Type:   "Decimal(5, 0)",
+			Values: []string{"0", "-99999", "12"},
+			Raw:    new(ColDecimal32),
+		},
+	} {
+		t.Run(tt.Type.String(), func(t *testing.T) {
+			var data ColDecimal
+			require.NoError(t, data.Infer(tt.Type))
+			require.Equal(t, tt.Type, data.Type())
+			require.IsType(t, tt.Raw, data.Raw())
+			for _, v := range tt.Values {
+				require.NoError(t, data.AppendDecimalString(v))
+			}
+			require.Equal(t, len(tt.Values), data.Rows())
+
+			var buf Buffer
+			data.EncodeColumn(&buf)
+
+			dec := NewDecimal(data.Precision, data.Scale)
+			require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), len(tt.Values)))
+			for i, v := range tt.Values {
+				require.Equal(t, v, dec.RowString(i))
+				expected, ok := new(big.Rat).SetString(v)
+				require.True(t, ok)
+				require.Equal(t, expected.String(), dec.RowBigRat(i).String())
+			}
+			dec.Reset()
+			require.Equal(t, 0, dec.Rows())
+		})
+	}
+}
+
+func TestColDecimal_Infer(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		Type      ColumnType
+		Precision int
+		Scale     int
+	}{
+		{"Decimal(9, 2)", 9, 2},
+		{"Decimal(10,0)", 10, 0},
+		{"Decimal32(3)", 9, 3},
+		{"Decimal64(5)", 18, 5},
+		{"Decimal128(6)", 38, 6},
+		{"Decimal256(7)", 76, 7},
+	} {
+		var c ColDecimal
+		require.NoError(t, c.Infer(tt.Type), tt.Type)
+		require.Equal(t, tt.Precision, c.Precision, tt.Type)
+		require.Equal(t, tt.Scale, c.Scale, tt.Type)
+	}
+	for _, tt := range []ColumnType{
+		"Decimal",
+		"Decimal(9)",
+		"Decimal(0, 0)",
+		"Decimal(77, 2)",
+		"Decimal(9, 10)",
+		"Decimal(a, 2)",
+		"Int32",
+	} {
+		var c ColDecimal
+		require.Error(t, c.Infer(tt), tt)
+	}
+
+	var auto ColAuto
+	require.NoError(t, auto.Infer("Decimal(9, 2)"))
+	require.IsType(t, &ColDecimal{}, auto.Data)
+}
+
+func TestColDecimal_Append(t *testing.T) {
+	t.Parallel()
+	c := NewDecimal(5, 2)
+	require.NoError(t, c.AppendDecimalString("1.5"))
+	require.NoError(t, c.AppendDecimalString("+2.500"))
+	require.NoError(t, c.AppendDecimalString(".25"))
+	require.NoError(t, c.AppendDecimalString("-3."))
+	require.NoError(t, c.AppendBigRat(big.NewRat(-1, 4)))
+	require.NoError(t, c.AppendUnscaled(big.NewInt(99999)))
+	var got []string
+	for i := 0; i < c.Rows(); i++ {
+		got = append(got, c.RowString(i))
+	}
+	require.Equal(t, []string{"1.50", "2.50", "0.25", "-3.00", "-0.25", "999.99"}, got)
+	require.Equal(t, big.NewInt(-25), c.RowUnscaled(4))
+
+	for _, s := range []string{
+		"1.234",   // scale
+		"1000.00", // precision
+		"",
+		"-",
+		".",
+		"1e5",
+		"1.2.3",
+		"--1",
+	} {
+		require.Error(t, c.AppendDecimalString(s), s)
+	}
+	require.Error(t, c.AppendBigRat(big.NewRat(1, 3)))
+	require.Error(t, c.AppendUnscaled(big.NewInt(-100000)))
+	require.Equal(t, 6, c.Rows())
+}
@@ -0,0 +1,266 @@
+package proto
+
+import (
+	"sort"
+
+	"github.com/go-faster/errors"
+)
+
+// Dynamic structure serialization versions.
+const (
+	dynamicSerializationV1 uint64 = 1
+	dynamicSerializationV2 uint64 = 2
+)
+
+const (
+	// DynamicDefaultMaxTypes is default value of max_types parameter
+	// of Dynamic type.
+	DynamicDefaultMaxTypes = 32
+
+	// ColumnTypeSharedVariant is type of Dynamic variant that stores
+	// values of types that are not listed in dynamic types.
+	//
+	// Values are in binary encoding of ClickHouse, i.e. encoded type
+	// followed by encoded value.
+	ColumnTypeSharedVariant ColumnType = "SharedVariant"
+)
+
+// ColDynamic represents Dynamic column.
+//
+// Dynamic is serialized as Variant(T1, T2, ..., Tn, SharedVariant), where
+// T1...Tn are types of values in column. Variants are sorted by type name.
+type ColDynamic struct {
+	Variant  ColVariant
+	MaxTypes int // DynamicDefaultMaxTypes if zero
+
+	types    []ColumnType // variant types, including SharedVariant
+	dataType ColumnType
+}
+
+// NewDynamic returns new Dynamic column that can hold values of provided
+// columns.
+func NewDynamic(columns ...Column) *ColDynamic {
+	columns = append(columns, Alias(new(ColStr), ColumnTypeSharedVariant))
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].Type() < columns[j].Type()
+	})
+	c := &ColDynamic{}
+	for _, col := range columns {
+		c.types = append(c.types, col.Type())
+	}
+	c.Variant.Variants = columns
+	return c
+}
+
+// Compile-time assertions for ColDynamic.
+var (
+	_ ColInput  = (*ColDynamic)(nil)
+	_ ColResult = (*ColDynamic)(nil)
+	_ Column    = (*ColDynamic)(nil)
+	_ Stateful  = (*ColDynamic)(nil)
+	_ Inferable = (*ColDynamic)(nil)
+)
+
+func (c ColDynamic) Type() ColumnType {
+	if c.dataType != "" {
+		return c.dataType
+	}
+	return ColumnTypeDynamic
+}
+
+func (c *ColDynamic) Infer(t ColumnType) error {
+	if t.Base() != ColumnTypeDynamic {
+		return errors.Errorf("unexpected type %q", t)
+	}
+	c.dataType = t
+	return nil
+}
+
+func (c ColDynamic) Rows() int {
+	return c.Variant.Rows()
+}
+
+func (c *ColDynamic) Reset() {
+	c.Variant.Reset()
+}
+
+// Types returns types of variants, including SharedVariant.
+func (c ColDynamic) Types() []ColumnType {
+	return c.types
+}
+
+// Discriminator returns discriminator of variant with type t.
+func (c ColDynamic) Discriminator(t ColumnType) (uint8, bool) {
+	for i, v := range c.types {
+		if v == t {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
+
+// Row returns i-th row.
+func (c ColDynamic) Row(i int) DynamicValue {
+	col, idx := c.Variant.Variant(i)
+	if col == nil {
+		return DynamicValue{idx: -1}
+	}
+	return DynamicValue{
+		t:   c.types[c.Variant.Discriminator(i)],
+		col: col,
+		idx: idx,
+	}
+}
+
+// AppendNull appends NULL row.
+func (c *ColDynamic) AppendNull() {
+	c.Variant.AppendNull()
+}
+
+// AppendDynamic appends v as value of type t.
+func AppendDynamic[T any](c *ColDynamic, t ColumnType, v T) error {
+	d, ok := c.Discriminator(t)
+	if !ok {
+		return errors.Errorf("type %q not found", t)
+	}
+	return AppendVariant[T](&c.Variant, d, v)
+}
+
+func (c ColDynamic) maxTypes() int {
+	if c.MaxTypes == 0 {
+		return DynamicDefaultMaxTypes
+	}
+	return c.MaxTypes
+}
+
+func (c ColDynamic) EncodeState(b *Buffer) {
+	b.PutUInt64(dynamicSerializationV1)
+	b.PutUVarInt(uint64(c.maxTypes()))
+	// Dynamic types, without SharedVariant.
+	b.PutUVarInt(uint64(len(c.types) - 1))
+	for _, t := range c.types {
+		if t == ColumnTypeSharedVariant {
+			continue
+		}
+		b.PutString(t.String())
+	}
+	c.Variant.EncodeState(b)
+}
+
+func (c *ColDynamic) DecodeState(r *Reader) error {
+	version, err := r.UInt64()
+	if err != nil {
+		return errors.Wrap(err, "version")
+	}
+	switch version {
+	case dynamicSerializationV1:
+		maxTypes, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrap(err, "max types")
+		}
+		c.MaxTypes = int(maxTypes)
+	case dynamicSerializationV2:
+	default:
+		return errors.Errorf("unsupported Dynamic serialization version %d", version)
+	}
+	n, err := r.UVarInt()
+	if err != nil {
+		return errors.Wrap(err, "types count")
+	}
+	if n > uint64(c.maxTypes()) {
+		return errors.Errorf("types count %d is greater than maximum (%d)", n, c.maxTypes())
+	}
+	types := []ColumnType{ColumnTypeSharedVariant}
+	for i := 0; i < int(n); i++ {
+		t, err := r.Str()
+		if err != nil {
+			return errors.Wrapf(err, "type [%d]", i)
+		}
+		types = append(types, ColumnType(t))
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	if !c.sameTypes(types) {
+		var variants []Column
+		for _, t := range types {
+			if t == ColumnTypeSharedVariant {
+				variants = append(variants, Alias(new(ColStr), ColumnTypeSharedVariant))
+				continue
+			}
+			v := new(ColAuto)
+			if err := v.Infer(t); err != nil {
+				return errors.Wrapf(err, "infer %q", t)
+			}
+			variants = append(variants, v)
+		}
+		c.types = types
+		c.Variant = ColVariant{Variants: variants}
+	}
+	if err := c.Variant.DecodeState(r); err != nil {
+		return errors.Wrap(err, "variant")
+	}
+	return nil
+}
+
+func (c ColDynamic) sameTypes(types []ColumnType) bool {
+	if len(types) != len(c.types) {
+		return false
+	}
+	for i, t := range types {
+		if c.types[i] != t {
+			return false
+		}
+	}
+	return true
+}
+
+func (c ColDynamic) EncodeColumn(b *Buffer) {
+	c.Variant.EncodeColumn(b)
+}
+
+func (c *ColDynamic) DecodeColumn(r *Reader, rows int) error {
+	return c.Variant.DecodeColumn(r, rows)
+}
+
+// DynamicValue is row of Dynamic column, tagged with its type.
+type DynamicValue struct {
+	t   ColumnType
+	col Column
+	idx int
+}
+
+// Type of value, ColumnTypeSharedVariant for values from shared variant
+// or ColumnTypeNone for NULL.
+func (v DynamicValue) Type() ColumnType {
+	return v.t
+}
+
+// IsNull reports whether value is NULL.
+func (v DynamicValue) IsNull() bool {
+	return v.col == nil
+}
+
+// Column returns variant column of value and index of value in it.
+func (v DynamicValue) Column() (Column, int) {
+	return v.col, v.idx
+}
+
+// DynamicValueAs returns value as T if variant column is ColumnOf[T].
+//
+// Column is unwrapped if it is ColAuto.
+func DynamicValueAs[T any](v DynamicValue) (T, bool) {
+	var zero T
+	col := v.col
+	if auto, ok := col.(*ColAuto); ok {
+		col = auto.Data
+	}
+	if w, ok := col.(colWrap); ok {
+		col = w.Column
+	}
+	c, ok := col.(interface{ Row(i int) T })
+	if !ok {
+		return zero, false
+	}
+	return c.Row(v.idx), true
+}
@@ -0,0 +1,69 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/internal/gold"
+)
+
+func TestColDynamic(t *testing.T) {
+	data := NewDynamic(new(ColUInt64), new(ColStr))
+	require.Equal(t, ColumnTypeDynamic, data.Type())
+	require.Equal(t, []ColumnType{
+		ColumnTypeSharedVariant,
+		ColumnTypeString,
+		ColumnTypeUInt64,
+	}, data.Types())
+
+	require.NoError(t, AppendDynamic[string](data, ColumnTypeString, "foo"))
+	require.NoError(t, AppendDynamic[uint64](data, ColumnTypeUInt64, 10))
+	data.AppendNull()
+	require.Error(t, AppendDynamic[int8](data, ColumnTypeInt8, 1))
+	require.Equal(t, 3, data.Rows())
+
+	var buf Buffer
+	data.EncodeState(&buf)
+	data.EncodeColumn(&buf)
+
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_dynamic")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+		dec := new(ColAuto)
+		require.NoError(t, dec.Infer(ColumnTypeDynamic))
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, data.Rows()))
+
+		col := dec.Data.(*ColDynamic)
+		require.Equal(t, data.Types(), col.Types())
+		require.Equal(t, DynamicDefaultMaxTypes, col.MaxTypes)
+
+		v := col.Row(0)
+		require.Equal(t, ColumnTypeString, v.Type())
+		s, ok := DynamicValueAs[string](v)
+		require.True(t, ok)
+		require.Equal(t, "foo", s)
+		_, ok = DynamicValueAs[uint64](v)
+		require.False(t, ok)
+
+		v = col.Row(1)
+		require.Equal(t, ColumnTypeUInt64, v.Type())
+		n, ok := DynamicValueAs[uint64](v)
+		require.True(t, ok)
+		require.Equal(t, uint64(10), n)
+
+		v = col.Row(2)
+		require.True(t, v.IsNull())
+		require.Equal(t, ColumnTypeNone, v.Type())
+	})
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		var b Buffer
+		b.PutUInt64(3)
+		dec := new(ColDynamic)
+		require.Error(t, dec.DecodeState(NewReader(bytes.NewReader(b.Buf))))
+	})
+}
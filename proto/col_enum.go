@@ -1,6 +1,8 @@
 package proto
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,7 +19,9 @@ var (
 // ColEnum is inference helper for enums.
 //
 // You can set Values and actual enum mapping will be inferred during query
-// execution.
+// execution. Alternatively, mapping can be set explicitly via WithValues or
+// WithValues16, e.g. for external data or when no server round trip is
+// possible.
 type ColEnum struct {
 	t    ColumnType
 	base ColumnType
@@ -59,31 +63,77 @@ func (e *ColEnum) parse(t ColumnType) error {
 		e.strToRaw = map[string]int{}
 	}
 
-	elements := t.Elem().String()
-	for _, elem := range strings.Split(elements, ",") {
-		def := strings.TrimSpace(elem)
+	for _, elem := range t.elems() {
+		def := strings.TrimSpace(string(elem))
 		// 'hello' = 1
-		parts := strings.SplitN(def, "=", 2)
-		if len(parts) != 2 {
+		sep := strings.LastIndex(def, "=")
+		if sep < 0 {
 			return errors.Errorf("bad enum definition %q", def)
 		}
 		var (
-			left  = strings.TrimSpace(parts[0]) // 'hello'
-			right = strings.TrimSpace(parts[1]) // 1
+			left  = strings.TrimSpace(def[:sep])   // 'hello'
+			right = strings.TrimSpace(def[sep+1:]) // 1
 		)
 		idx, err := strconv.Atoi(right)
 		if err != nil {
 			return errors.Errorf("bad right side of definition %q", right)
 		}
-		left = strings.TrimFunc(left, func(c rune) bool {
-			return c == '\''
-		})
+		if len(left) < 2 || left[0] != '\'' || left[len(left)-1] != '\'' {
+			return errors.Errorf("bad left side of definition %q", left)
+		}
+		left = enumNameReplacer.Replace(left[1 : len(left)-1])
 		e.strToRaw[left] = idx
 		e.rawToStr[idx] = left
 	}
 	return nil
 }
 
+var (
+	enumNameReplacer = strings.NewReplacer(`\\`, `\`, `\'`, `'`)
+	enumNameEscaper  = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
+// WithValues sets Enum8 mapping of value names to values, so column can be
+// encoded without inference from column type.
+func (e *ColEnum) WithValues(values map[string]int8) *ColEnum {
+	m := make(map[string]int, len(values))
+	for k, v := range values {
+		m[k] = int(v)
+	}
+	e.setValues(ColumnTypeEnum8, m)
+	return e
+}
+
+// WithValues16 sets Enum16 mapping of value names to values, so column can
+// be encoded without inference from column type.
+func (e *ColEnum) WithValues16(values map[string]int16) *ColEnum {
+	m := make(map[string]int, len(values))
+	for k, v := range values {
+		m[k] = int(v)
+	}
+	e.setValues(ColumnTypeEnum16, m)
+	return e
+}
+
+func (e *ColEnum) setValues(base ColumnType, values map[string]int) {
+	e.base = base
+	e.strToRaw = values
+	e.rawToStr = make(map[int]string, len(values))
+	names := make([]string, 0, len(values))
+	for k, v := range values {
+		e.rawToStr[v] = k
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return values[names[i]] < values[names[j]]
+	})
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		params = append(params, fmt.Sprintf("'%s' = %d", enumNameEscaper.Replace(name), values[name]))
+	}
+	e.t = base.With(params...)
+}
+
 func (e *ColEnum) Infer(t ColumnType) error {
 	if !strings.HasPrefix(t.Base().String(), "Enum") {
 		return errors.Errorf("invalid base %q to infer enum", t.Base())
@@ -102,6 +152,37 @@ func (e *ColEnum) Infer(t ColumnType) error {
 	return nil
 }
 
+// Mapping returns copy of enum value to name mapping, e.g. {1: "foo"} for
+// Enum8('foo' = 1).
+//
+// Mapping is available after Infer, WithValues or WithValues16; it is named
+// so because Values field already holds column data.
+func (e *ColEnum) Mapping() map[int16]string {
+	m := make(map[int16]string, len(e.rawToStr))
+	for k, v := range e.rawToStr {
+		m[int16(k)] = v
+	}
+	return m
+}
+
+// Names returns enum value names ordered by value.
+func (e *ColEnum) Names() []string {
+	names := make([]string, 0, len(e.strToRaw))
+	for k := range e.strToRaw {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return e.strToRaw[names[i]] < e.strToRaw[names[j]]
+	})
+	return names
+}
+
+// Value returns enum value for name and reports whether name is known.
+func (e *ColEnum) Value(name string) (int16, bool) {
+	v, ok := e.strToRaw[name]
+	return int16(v), ok
+}
+
 func (e *ColEnum) Rows() int {
 	return len(e.Values)
 }
@@ -27,6 +27,21 @@ func (ColEnum16) Type() ColumnType {
 	return ColumnTypeEnum16
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColEnum16) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColEnum16.
+func (c *ColEnum16) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColEnum16](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColEnum16) Row(i int) Enum16 {
 	return c[i]
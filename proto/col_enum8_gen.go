@@ -27,6 +27,21 @@ func (ColEnum8) Type() ColumnType {
 	return ColumnTypeEnum8
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColEnum8) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColEnum8.
+func (c *ColEnum8) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColEnum8](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColEnum8) Row(i int) Enum8 {
 	return c[i]
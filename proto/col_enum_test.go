@@ -0,0 +1,83 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColEnum_WithValues(t *testing.T) {
+	t.Parallel()
+	t.Run("Enum8", func(t *testing.T) {
+		data := new(ColEnum).WithValues(map[string]int8{
+			"foo":   1,
+			"bar":   -2,
+			"it's":  3,
+			"a, b":  4,
+			`c\d=e`: 5,
+		})
+		require.Equal(t,
+			ColumnType(`Enum8('bar' = -2, 'foo' = 1, 'it\'s' = 3, 'a, b' = 4, 'c\\d=e' = 5)`),
+			data.Type(),
+		)
+		values := []string{"foo", "bar", "it's", "a, b", `c\d=e`, "foo"}
+		data.AppendArr(values)
+		require.NoError(t, data.Prepare())
+
+		var buf Buffer
+		data.EncodeColumn(&buf)
+		require.Equal(t, []byte{1, 0xfe, 3, 4, 5, 1}, buf.Buf)
+
+		dec := new(ColEnum)
+		require.NoError(t, dec.Infer(data.Type()))
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), len(values)))
+		require.Equal(t, values, dec.Values)
+		require.Equal(t, data.Type(), dec.Type())
+	})
+	t.Run("Enum16", func(t *testing.T) {
+		data := new(ColEnum).WithValues16(map[string]int16{
+			"foo": 1000,
+			"bar": 2,
+		})
+		require.Equal(t, ColumnType("Enum16('bar' = 2, 'foo' = 1000)"), data.Type())
+		values := []string{"foo", "bar"}
+		data.AppendArr(values)
+		require.NoError(t, data.Prepare())
+
+		var buf Buffer
+		data.EncodeColumn(&buf)
+
+		var dec ColAuto
+		require.NoError(t, dec.Infer(data.Type()))
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), len(values)))
+		require.Equal(t, values, dec.Data.(*ColEnum).Values)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		data := new(ColEnum).WithValues(map[string]int8{"foo": 1})
+		data.Append("bar")
+		require.Error(t, data.Prepare())
+	})
+}
+
+func TestColEnum_Mapping(t *testing.T) {
+	t.Parallel()
+	var data ColEnum
+	require.NoError(t, data.Infer("Enum16('foo' = 1, 'bar' = -1000, 'it\\'s' = 3)"))
+	require.Equal(t, map[int16]string{
+		1:     "foo",
+		-1000: "bar",
+		3:     "it's",
+	}, data.Mapping())
+	require.Equal(t, []string{"bar", "foo", "it's"}, data.Names())
+
+	v, ok := data.Value("bar")
+	require.True(t, ok)
+	require.Equal(t, int16(-1000), v)
+	_, ok = data.Value("baz")
+	require.False(t, ok)
+
+	// Mapping is a copy.
+	data.Mapping()[1] = "baz"
+	require.Equal(t, "foo", data.Mapping()[1])
+}
@@ -15,11 +15,18 @@ type ColFixedStr struct {
 	Size int // N
 }
 
+// NewFixedStr returns new FixedString(size) column.
+func NewFixedStr(size int) *ColFixedStr {
+	return &ColFixedStr{Size: size}
+}
+
 // Compile-time assertions for ColFixedStr.
 var (
-	_ ColInput  = ColFixedStr{}
-	_ ColResult = (*ColFixedStr)(nil)
-	_ Column    = (*ColFixedStr)(nil)
+	_ ColInput         = ColFixedStr{}
+	_ ColResult        = (*ColFixedStr)(nil)
+	_ Column           = (*ColFixedStr)(nil)
+	_ ColumnOf[[]byte] = (*ColFixedStr)(nil)
+	_ Inferable        = (*ColFixedStr)(nil)
 )
 
 // Type returns ColumnType of FixedString.
@@ -34,6 +41,22 @@ func (c *ColFixedStr) SetSize(n int) {
 	c.Size = n
 }
 
+// Infer Size from FixedString(N) type.
+func (c *ColFixedStr) Infer(t ColumnType) error {
+	if t.Base() != ColumnTypeFixedString {
+		return errors.Errorf("invalid base %q to infer fixed string", t.Base())
+	}
+	n, err := strconv.Atoi(string(t.Elem()))
+	if err != nil {
+		return errors.Wrap(err, "size")
+	}
+	if n <= 0 {
+		return errors.Errorf("invalid size %d", n)
+	}
+	c.SetSize(n)
+	return nil
+}
+
 // Rows returns count of rows in column.
 func (c ColFixedStr) Rows() int {
 	if c.Size == 0 {
@@ -79,6 +102,9 @@ func (c ColFixedStr) EncodeColumn(b *Buffer) {
 
 // DecodeColumn decodes ColFixedStr rows from *Reader.
 func (c *ColFixedStr) DecodeColumn(r *Reader, rows int) error {
+	if c.Size <= 0 && rows > 0 {
+		return errors.New("size is not set")
+	}
 	c.Buf = append(c.Buf[:0], make([]byte, rows*c.Size)...)
 	if err := r.ReadFull(c.Buf); err != nil {
 		return errors.Wrap(err, "read full")
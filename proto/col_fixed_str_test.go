@@ -142,3 +142,48 @@ func BenchmarkColFixedStr_EncodeColumn(b *testing.B) {
 		data.EncodeColumn(&buf)
 	}
 }
+
+func TestColFixedStr_Infer(t *testing.T) {
+	t.Parallel()
+	var data ColFixedStr
+	require.NoError(t, data.Infer("FixedString(34)"))
+	require.Equal(t, 34, data.Size)
+	require.Equal(t, ColumnType("FixedString(34)"), data.Type())
+	for _, tt := range []ColumnType{
+		"String",
+		"FixedString",
+		"FixedString(0)",
+		"FixedString(x)",
+	} {
+		require.Error(t, new(ColFixedStr).Infer(tt), tt)
+	}
+
+	v := make([]byte, 34)
+	v[0], v[33] = 1, 2
+	data.Append(v)
+	var buf Buffer
+	data.EncodeColumn(&buf)
+
+	t.Run("Auto", func(t *testing.T) {
+		var dec ColAuto
+		require.NoError(t, dec.Infer("FixedString(34)"))
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 1))
+		require.Equal(t, v, dec.Data.(*ColFixedStr).Row(0))
+	})
+	t.Run("AutoArray", func(t *testing.T) {
+		arr := NewFixedStr(34).Array()
+		arr.Append([][]byte{v, v})
+		var buf Buffer
+		arr.EncodeColumn(&buf)
+
+		var dec ColAuto
+		require.NoError(t, dec.Infer("Array(FixedString(34))"))
+		require.Equal(t, ColumnType("Array(FixedString(34))"), dec.Data.Type())
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 1))
+		require.Equal(t, [][]byte{v, v}, dec.Data.(*ColArr[[]byte]).Row(0))
+	})
+	t.Run("NoSize", func(t *testing.T) {
+		var dec ColFixedStr
+		require.Error(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 1))
+	})
+}
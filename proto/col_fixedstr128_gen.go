@@ -27,6 +27,21 @@ func (ColFixedStr128) Type() ColumnType {
 	return ColumnTypeFixedString.With("128")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr128) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr128.
+func (c *ColFixedStr128) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr128](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr128) Row(i int) [128]byte {
 	return c[i]
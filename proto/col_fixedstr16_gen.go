@@ -27,6 +27,21 @@ func (ColFixedStr16) Type() ColumnType {
 	return ColumnTypeFixedString.With("16")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr16) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr16.
+func (c *ColFixedStr16) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr16](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr16) Row(i int) [16]byte {
 	return c[i]
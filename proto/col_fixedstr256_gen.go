@@ -27,6 +27,21 @@ func (ColFixedStr256) Type() ColumnType {
 	return ColumnTypeFixedString.With("256")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr256) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr256.
+func (c *ColFixedStr256) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr256](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr256) Row(i int) [256]byte {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColFixedStr32) Type() ColumnType {
 	return ColumnTypeFixedString.With("32")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr32.
+func (c *ColFixedStr32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr32) Row(i int) [32]byte {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColFixedStr512) Type() ColumnType {
 	return ColumnTypeFixedString.With("512")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr512) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr512.
+func (c *ColFixedStr512) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr512](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr512) Row(i int) [512]byte {
 	return c[i]
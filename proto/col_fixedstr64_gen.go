@@ -27,6 +27,21 @@ func (ColFixedStr64) Type() ColumnType {
 	return ColumnTypeFixedString.With("64")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr64) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr64.
+func (c *ColFixedStr64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr64](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr64) Row(i int) [64]byte {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColFixedStr8) Type() ColumnType {
 	return ColumnTypeFixedString.With("8")
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFixedStr8) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFixedStr8.
+func (c *ColFixedStr8) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr8](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFixedStr8) Row(i int) [8]byte {
 	return c[i]
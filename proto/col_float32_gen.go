@@ -27,6 +27,21 @@ func (ColFloat32) Type() ColumnType {
 	return ColumnTypeFloat32
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFloat32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFloat32.
+func (c *ColFloat32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFloat32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFloat32) Row(i int) float32 {
 	return c[i]
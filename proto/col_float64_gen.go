@@ -27,6 +27,21 @@ func (ColFloat64) Type() ColumnType {
 	return ColumnTypeFloat64
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColFloat64) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColFloat64.
+func (c *ColFloat64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFloat64](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColFloat64) Row(i int) float64 {
 	return c[i]
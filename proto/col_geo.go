@@ -0,0 +1,214 @@
+package proto
+
+import "github.com/go-faster/errors"
+
+// Ring is closed polygon contour without holes.
+type Ring []Point
+
+// Polygon is outer Ring followed by holes.
+type Polygon []Ring
+
+// MultiPolygon consists of multiple polygons.
+type MultiPolygon []Polygon
+
+// Compile-time assertions for geo columns.
+var (
+	_ ColumnOf[Ring]         = (*ColRing)(nil)
+	_ ColumnOf[Polygon]      = (*ColPolygon)(nil)
+	_ ColumnOf[MultiPolygon] = (*ColMultiPolygon)(nil)
+	_ Arrayable[Ring]        = (*ColRing)(nil)
+	_ Arrayable[Polygon]     = (*ColPolygon)(nil)
+)
+
+// geoBounds returns start and end of i-th row elements.
+func geoBounds(offsets ColUInt64, i int) (start, end int) {
+	end = int(offsets[i])
+	if i > 0 {
+		start = int(offsets[i-1])
+	}
+	return start, end
+}
+
+// decodeGeoOffsets decodes offsets and returns total elements count.
+func decodeGeoOffsets(offsets *ColUInt64, r *Reader, rows int) (int, error) {
+	if err := offsets.DecodeColumn(r, rows); err != nil {
+		return 0, errors.Wrap(err, "offsets")
+	}
+	var size int
+	if l := len(*offsets); l > 0 {
+		size = int((*offsets)[l-1])
+	}
+	if err := r.checkArrSize(size); err != nil {
+		return 0, errors.Wrap(err, "size")
+	}
+	return size, nil
+}
+
+// ColRing represents Ring column, that is Array(Point).
+type ColRing struct {
+	Offsets ColUInt64
+	Points  ColPoint
+}
+
+func (c ColRing) Type() ColumnType { return ColumnTypeRing }
+func (c ColRing) Rows() int        { return c.Offsets.Rows() }
+
+func (c *ColRing) Append(v Ring) {
+	c.Points.AppendArr(v)
+	c.Offsets = append(c.Offsets, uint64(c.Points.Rows()))
+}
+
+func (c *ColRing) AppendArr(v []Ring) {
+	for _, vv := range v {
+		c.Append(vv)
+	}
+}
+
+func (c ColRing) Row(i int) Ring {
+	start, end := geoBounds(c.Offsets, i)
+	v := make(Ring, 0, end-start)
+	for idx := start; idx < end; idx++ {
+		v = append(v, c.Points.Row(idx))
+	}
+	return v
+}
+
+func (c *ColRing) DecodeColumn(r *Reader, rows int) error {
+	size, err := decodeGeoOffsets(&c.Offsets, r, rows)
+	if err != nil {
+		return err
+	}
+	if err := c.Points.DecodeColumn(r, size); err != nil {
+		return errors.Wrap(err, "points")
+	}
+	return nil
+}
+
+func (c *ColRing) Reset() {
+	c.Offsets.Reset()
+	c.Points.Reset()
+}
+
+func (c ColRing) EncodeColumn(b *Buffer) {
+	if b == nil {
+		return
+	}
+	c.Offsets.EncodeColumn(b)
+	c.Points.EncodeColumn(b)
+}
+
+// Array is helper that creates Array(Ring).
+func (c *ColRing) Array() *ColArr[Ring] {
+	return &ColArr[Ring]{Data: c}
+}
+
+// ColPolygon represents Polygon column, that is Array(Ring).
+type ColPolygon struct {
+	Offsets ColUInt64
+	Rings   ColRing
+}
+
+func (c ColPolygon) Type() ColumnType { return ColumnTypePolygon }
+func (c ColPolygon) Rows() int        { return c.Offsets.Rows() }
+
+func (c *ColPolygon) Append(v Polygon) {
+	c.Rings.AppendArr(v)
+	c.Offsets = append(c.Offsets, uint64(c.Rings.Rows()))
+}
+
+func (c *ColPolygon) AppendArr(v []Polygon) {
+	for _, vv := range v {
+		c.Append(vv)
+	}
+}
+
+func (c ColPolygon) Row(i int) Polygon {
+	start, end := geoBounds(c.Offsets, i)
+	v := make(Polygon, 0, end-start)
+	for idx := start; idx < end; idx++ {
+		v = append(v, c.Rings.Row(idx))
+	}
+	return v
+}
+
+func (c *ColPolygon) DecodeColumn(r *Reader, rows int) error {
+	size, err := decodeGeoOffsets(&c.Offsets, r, rows)
+	if err != nil {
+		return err
+	}
+	if err := c.Rings.DecodeColumn(r, size); err != nil {
+		return errors.Wrap(err, "rings")
+	}
+	return nil
+}
+
+func (c *ColPolygon) Reset() {
+	c.Offsets.Reset()
+	c.Rings.Reset()
+}
+
+func (c ColPolygon) EncodeColumn(b *Buffer) {
+	if b == nil {
+		return
+	}
+	c.Offsets.EncodeColumn(b)
+	c.Rings.EncodeColumn(b)
+}
+
+// Array is helper that creates Array(Polygon).
+func (c *ColPolygon) Array() *ColArr[Polygon] {
+	return &ColArr[Polygon]{Data: c}
+}
+
+// ColMultiPolygon represents MultiPolygon column, that is Array(Polygon).
+type ColMultiPolygon struct {
+	Offsets  ColUInt64
+	Polygons ColPolygon
+}
+
+func (c ColMultiPolygon) Type() ColumnType { return ColumnTypeMultiPolygon }
+func (c ColMultiPolygon) Rows() int        { return c.Offsets.Rows() }
+
+func (c *ColMultiPolygon) Append(v MultiPolygon) {
+	c.Polygons.AppendArr(v)
+	c.Offsets = append(c.Offsets, uint64(c.Polygons.Rows()))
+}
+
+func (c *ColMultiPolygon) AppendArr(v []MultiPolygon) {
+	for _, vv := range v {
+		c.Append(vv)
+	}
+}
+
+func (c ColMultiPolygon) Row(i int) MultiPolygon {
+	start, end := geoBounds(c.Offsets, i)
+	v := make(MultiPolygon, 0, end-start)
+	for idx := start; idx < end; idx++ {
+		v = append(v, c.Polygons.Row(idx))
+	}
+	return v
+}
+
+func (c *ColMultiPolygon) DecodeColumn(r *Reader, rows int) error {
+	size, err := decodeGeoOffsets(&c.Offsets, r, rows)
+	if err != nil {
+		return err
+	}
+	if err := c.Polygons.DecodeColumn(r, size); err != nil {
+		return errors.Wrap(err, "polygons")
+	}
+	return nil
+}
+
+func (c *ColMultiPolygon) Reset() {
+	c.Offsets.Reset()
+	c.Polygons.Reset()
+}
+
+func (c ColMultiPolygon) EncodeColumn(b *Buffer) {
+	if b == nil {
+		return
+	}
+	c.Offsets.EncodeColumn(b)
+	c.Polygons.EncodeColumn(b)
+}
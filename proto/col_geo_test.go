@@ -0,0 +1,112 @@
+package proto
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/internal/gold"
+)
+
+func testPolygon(i int) Polygon {
+	f := float64(i)
+	return Polygon{
+		{{X: 0, Y: 0}, {X: f, Y: 0}, {X: f, Y: f}, {X: 0, Y: f}},
+		{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}},
+	}
+}
+
+func TestColRing(t *testing.T) {
+	t.Parallel()
+	const rows = 10
+	var data ColRing
+	for i := 0; i < rows; i++ {
+		v := testPolygon(i)[0][:i%4]
+		data.Append(v)
+		require.Equal(t, v, data.Row(i))
+	}
+
+	var buf Buffer
+	data.EncodeColumn(&buf)
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_ring")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+
+		var dec ColRing
+		require.NoError(t, dec.DecodeColumn(r, rows))
+		require.Equal(t, data, dec)
+		require.Equal(t, ColumnTypeRing, dec.Type())
+		dec.Reset()
+		require.Equal(t, 0, dec.Rows())
+	})
+	t.Run("EOF", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(nil))
+
+		var dec ColRing
+		require.ErrorIs(t, dec.DecodeColumn(r, rows), io.EOF)
+	})
+}
+
+func TestColPolygon(t *testing.T) {
+	t.Parallel()
+	const rows = 10
+	var data ColPolygon
+	for i := 0; i < rows; i++ {
+		v := testPolygon(i)
+		data.Append(v)
+		require.Equal(t, v, data.Row(i))
+	}
+
+	var buf Buffer
+	data.EncodeColumn(&buf)
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_polygon")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+
+		var dec ColPolygon
+		require.NoError(t, dec.DecodeColumn(r, rows))
+		require.Equal(t, data, dec)
+		require.Equal(t, ColumnTypePolygon, dec.Type())
+	})
+	t.Run("Array", func(t *testing.T) {
+		arr := new(ColPolygon).Array()
+		arr.Append([]Polygon{testPolygon(1), testPolygon(2)})
+		require.Equal(t, ColumnTypePolygon.Array(), arr.Type())
+		require.Equal(t, []Polygon{testPolygon(1), testPolygon(2)}, arr.Row(0))
+	})
+}
+
+func TestColMultiPolygon(t *testing.T) {
+	t.Parallel()
+	const rows = 10
+	var data ColMultiPolygon
+	for i := 0; i < rows; i++ {
+		v := MultiPolygon{testPolygon(i), testPolygon(i + 1)}
+		data.Append(v)
+		require.Equal(t, v, data.Row(i))
+	}
+
+	var buf Buffer
+	data.EncodeColumn(&buf)
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_multi_polygon")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+
+		var dec ColMultiPolygon
+		require.NoError(t, dec.DecodeColumn(r, rows))
+		require.Equal(t, data, dec)
+		require.Equal(t, ColumnTypeMultiPolygon, dec.Type())
+	})
+	t.Run("NoShortRead", func(t *testing.T) {
+		var dec ColMultiPolygon
+		requireNoShortRead(t, buf.Buf, colAware(&dec, rows))
+	})
+}
@@ -27,6 +27,21 @@ func (ColInt128) Type() ColumnType {
 	return ColumnTypeInt128
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt128) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt128.
+func (c *ColInt128) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt128](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt128) Row(i int) Int128 {
 	return c[i]
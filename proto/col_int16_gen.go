@@ -27,6 +27,21 @@ func (ColInt16) Type() ColumnType {
 	return ColumnTypeInt16
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt16) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt16.
+func (c *ColInt16) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt16](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt16) Row(i int) int16 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColInt256) Type() ColumnType {
 	return ColumnTypeInt256
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt256) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt256.
+func (c *ColInt256) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt256](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt256) Row(i int) Int256 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColInt32) Type() ColumnType {
 	return ColumnTypeInt32
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt32.
+func (c *ColInt32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt32) Row(i int) int32 {
 	return c[i]
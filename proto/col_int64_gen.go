@@ -27,6 +27,21 @@ func (ColInt64) Type() ColumnType {
 	return ColumnTypeInt64
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt64) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt64.
+func (c *ColInt64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt64](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt64) Row(i int) int64 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColInt8) Type() ColumnType {
 	return ColumnTypeInt8
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColInt8) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColInt8.
+func (c *ColInt8) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColInt8](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColInt8) Row(i int) int8 {
 	return c[i]
@@ -21,6 +21,9 @@ const (
 	IntervalMonth
 	IntervalQuarter
 	IntervalYear
+	IntervalNanosecond
+	IntervalMicrosecond
+	IntervalMillisecond
 )
 
 type Interval struct {
@@ -31,6 +34,12 @@ type Interval struct {
 // Add Interval to time.Time.
 func (i Interval) Add(t time.Time) time.Time {
 	switch i.Scale {
+	case IntervalNanosecond:
+		return t.Add(time.Duration(i.Value))
+	case IntervalMicrosecond:
+		return t.Add(time.Microsecond * time.Duration(i.Value))
+	case IntervalMillisecond:
+		return t.Add(time.Millisecond * time.Duration(i.Value))
 	case IntervalSecond:
 		return t.Add(time.Second * time.Duration(i.Value))
 	case IntervalMinute:
@@ -44,7 +53,7 @@ func (i Interval) Add(t time.Time) time.Time {
 	case IntervalMonth:
 		return t.AddDate(0, int(i.Value), 0)
 	case IntervalQuarter:
-		return t.AddDate(0, int(i.Value)*4, 0)
+		return t.AddDate(0, int(i.Value)*3, 0)
 	case IntervalYear:
 		return t.AddDate(int(i.Value), 0, 0)
 	default:
@@ -63,6 +72,16 @@ func (i Interval) String() string {
 	return out.String()
 }
 
+// Compile-time assertions for ColInterval.
+var (
+	_ ColInput           = ColInterval{}
+	_ ColResult          = (*ColInterval)(nil)
+	_ Column             = (*ColInterval)(nil)
+	_ ColumnOf[Interval] = (*ColInterval)(nil)
+	_ Inferable          = (*ColInterval)(nil)
+)
+
+// ColInterval represents Interval* column, e.g. IntervalSecond.
 type ColInterval struct {
 	Scale  IntervalScale
 	Values ColInt64
@@ -84,6 +103,12 @@ func (c *ColInterval) Append(v Interval) {
 	c.Values.Append(v.Value)
 }
 
+func (c *ColInterval) AppendArr(v []Interval) {
+	for _, vv := range v {
+		c.Append(vv)
+	}
+}
+
 func (c ColInterval) Row(i int) Interval {
 	return Interval{
 		Scale: c.Scale,
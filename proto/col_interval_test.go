@@ -17,6 +17,18 @@ func TestInterval_Add(t *testing.T) {
 		Scale  IntervalScale
 		Result time.Time
 	}{
+		{
+			Scale:  IntervalNanosecond,
+			Result: v.Add(time.Nanosecond * 2),
+		},
+		{
+			Scale:  IntervalMicrosecond,
+			Result: v.Add(time.Microsecond * 2),
+		},
+		{
+			Scale:  IntervalMillisecond,
+			Result: v.Add(time.Millisecond * 2),
+		},
 		{
 			Scale:  IntervalSecond,
 			Result: v.Add(time.Second * 2),
@@ -43,7 +55,7 @@ func TestInterval_Add(t *testing.T) {
 		},
 		{
 			Scale:  IntervalQuarter,
-			Result: v.AddDate(0, 4*2, 0),
+			Result: v.AddDate(0, 3*2, 0),
 		},
 		{
 			Scale:  IntervalYear,
@@ -70,6 +82,11 @@ func TestInterval_String(t *testing.T) {
 			One:   "1 second",
 			Many:  "3 seconds",
 		},
+		{
+			Scale: IntervalMillisecond,
+			One:   "1 millisecond",
+			Many:  "3 milliseconds",
+		},
 		{
 			Scale: IntervalQuarter,
 			One:   "1 quarter",
@@ -0,0 +1,148 @@
+package proto
+
+import (
+	"net/netip"
+
+	"github.com/go-faster/errors"
+)
+
+// AppendAddr appends netip.Addr to IPv4 column.
+//
+// IPv4-mapped IPv6 addresses are unmapped, other IPv6 addresses
+// are rejected.
+func (c *ColIPv4) AppendAddr(ip netip.Addr) error {
+	ip = ip.Unmap()
+	if !ip.Is4() {
+		return errors.Errorf("%s is not IPv4 address", ip)
+	}
+	c.Append(ToIPv4(ip))
+	return nil
+}
+
+// RowAddr returns i-th row as netip.Addr.
+func (c ColIPv4) RowAddr(i int) netip.Addr {
+	return c.Row(i).ToIP()
+}
+
+// Addr returns ColumnOf[netip.Addr] view of column.
+func (c *ColIPv4) Addr() *ColIPv4Addr {
+	return &ColIPv4Addr{ColIPv4: c}
+}
+
+var (
+	_ ColumnOf[netip.Addr] = (*ColIPv4Addr)(nil)
+	_ ColumnOf[netip.Addr] = (*ColIPv6Addr)(nil)
+)
+
+// ColIPv4Addr is ColIPv4 wrapper to implement ColumnOf[netip.Addr].
+//
+// Zero (invalid) netip.Addr is appended as 0.0.0.0, e.g. for NULL
+// values of Nullable(IPv4). Append panics if address is IPv6, use
+// ColIPv4.AppendAddr to handle such addresses gracefully.
+type ColIPv4Addr struct {
+	*ColIPv4
+}
+
+func (c ColIPv4Addr) Append(ip netip.Addr) {
+	if !ip.IsValid() {
+		c.ColIPv4.Append(0)
+		return
+	}
+	if err := c.AppendAddr(ip); err != nil {
+		panic(err)
+	}
+}
+
+func (c ColIPv4Addr) AppendArr(v []netip.Addr) {
+	for _, ip := range v {
+		c.Append(ip)
+	}
+}
+
+func (c ColIPv4Addr) Row(i int) netip.Addr {
+	return c.RowAddr(i)
+}
+
+// Array is helper that creates Array(IPv4) of netip.Addr.
+func (c ColIPv4Addr) Array() *ColArr[netip.Addr] {
+	return &ColArr[netip.Addr]{Data: c}
+}
+
+// Nullable is helper that creates Nullable(IPv4) of netip.Addr.
+func (c ColIPv4Addr) Nullable() *ColNullable[netip.Addr] {
+	return &ColNullable[netip.Addr]{Values: c}
+}
+
+// AppendAddr appends netip.Addr to IPv6 column.
+//
+// IPv4 addresses are stored as IPv4-mapped IPv6, like ClickHouse does.
+func (c *ColIPv6) AppendAddr(ip netip.Addr) error {
+	if !ip.IsValid() {
+		return errors.New("invalid address")
+	}
+	c.Append(ToIPv6(ip))
+	return nil
+}
+
+// RowAddr returns i-th row as netip.Addr.
+//
+// IPv4-mapped addresses are returned as is, use netip.Addr.Unmap or
+// ColIPv6Addr with Unmap option to get IPv4 address.
+func (c ColIPv6) RowAddr(i int) netip.Addr {
+	return c.Row(i).ToIP()
+}
+
+// Addr returns ColumnOf[netip.Addr] view of column.
+func (c *ColIPv6) Addr() *ColIPv6Addr {
+	return &ColIPv6Addr{ColIPv6: c}
+}
+
+// ColIPv6Addr is ColIPv6 wrapper to implement ColumnOf[netip.Addr].
+//
+// Zero (invalid) netip.Addr is appended as ::, e.g. for NULL values of
+// Nullable(IPv6).
+type ColIPv6Addr struct {
+	*ColIPv6
+
+	// Unmap IPv4-mapped addresses on Row, so ::ffff:1.2.3.4 is returned
+	// as 1.2.3.4.
+	Unmap bool
+}
+
+// WithUnmap sets Unmap option.
+func (c *ColIPv6Addr) WithUnmap(unmap bool) *ColIPv6Addr {
+	c.Unmap = unmap
+	return c
+}
+
+func (c ColIPv6Addr) Append(ip netip.Addr) {
+	if !ip.IsValid() {
+		c.ColIPv6.Append(IPv6{})
+		return
+	}
+	c.ColIPv6.Append(ToIPv6(ip))
+}
+
+func (c ColIPv6Addr) AppendArr(v []netip.Addr) {
+	for _, ip := range v {
+		c.Append(ip)
+	}
+}
+
+func (c ColIPv6Addr) Row(i int) netip.Addr {
+	ip := c.RowAddr(i)
+	if c.Unmap {
+		return ip.Unmap()
+	}
+	return ip
+}
+
+// Array is helper that creates Array(IPv6) of netip.Addr.
+func (c ColIPv6Addr) Array() *ColArr[netip.Addr] {
+	return &ColArr[netip.Addr]{Data: c}
+}
+
+// Nullable is helper that creates Nullable(IPv6) of netip.Addr.
+func (c ColIPv6Addr) Nullable() *ColNullable[netip.Addr] {
+	return &ColNullable[netip.Addr]{Values: c}
+}
@@ -0,0 +1,68 @@
+package proto
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColIPv4_Addr(t *testing.T) {
+	t.Parallel()
+	var c ColIPv4
+	require.NoError(t, c.AppendAddr(netip.MustParseAddr("10.0.0.1")))
+	require.NoError(t, c.AppendAddr(netip.MustParseAddr("::ffff:192.168.1.1")))
+	require.Error(t, c.AppendAddr(netip.MustParseAddr("2001:db8::1")))
+	require.Error(t, c.AppendAddr(netip.Addr{}))
+	require.Equal(t, 2, c.Rows())
+	require.Equal(t, netip.MustParseAddr("192.168.1.1"), c.RowAddr(1))
+
+	addr := c.Addr()
+	addr.Append(netip.MustParseAddr("127.0.0.1"))
+	require.Panics(t, func() {
+		addr.Append(netip.MustParseAddr("::1"))
+	})
+	addr.Append(netip.Addr{})
+	require.Equal(t, netip.IPv4Unspecified(), addr.Row(3))
+	require.Equal(t, []netip.Addr{
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("192.168.1.1"),
+		netip.MustParseAddr("127.0.0.1"),
+	}, []netip.Addr{addr.Row(0), addr.Row(1), addr.Row(2)})
+	require.Equal(t, ColumnTypeIPv4, addr.Type())
+
+	arr := new(ColIPv4).Addr().Array()
+	arr.Append([]netip.Addr{netip.MustParseAddr("1.1.1.1"), netip.MustParseAddr("8.8.8.8")})
+	require.Equal(t, ColumnTypeIPv4.Array(), arr.Type())
+
+	var buf Buffer
+	arr.EncodeColumn(&buf)
+	dec := new(ColIPv4).Addr().Array()
+	require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 1))
+	require.Equal(t, arr.Row(0), dec.Row(0))
+}
+
+func TestColIPv6_Addr(t *testing.T) {
+	t.Parallel()
+	var c ColIPv6
+	require.NoError(t, c.AppendAddr(netip.MustParseAddr("2001:db8::1")))
+	require.NoError(t, c.AppendAddr(netip.MustParseAddr("10.0.0.1")))
+	require.Error(t, c.AppendAddr(netip.Addr{}))
+	require.Equal(t, netip.MustParseAddr("::ffff:10.0.0.1"), c.RowAddr(1))
+
+	addr := c.Addr()
+	require.Equal(t, netip.MustParseAddr("::ffff:10.0.0.1"), addr.Row(1))
+	addr.WithUnmap(true)
+	require.Equal(t, netip.MustParseAddr("10.0.0.1"), addr.Row(1))
+	require.Equal(t, netip.MustParseAddr("2001:db8::1"), addr.Row(0))
+	addr.Append(netip.Addr{})
+	require.Equal(t, netip.IPv6Unspecified(), addr.Row(2))
+
+	n := new(ColIPv6).Addr().Nullable()
+	n.Append(NewNullable(netip.MustParseAddr("::1")))
+	n.Append(Null[netip.Addr]())
+	require.Equal(t, ColumnTypeNullable.Sub(ColumnTypeIPv6), n.Type())
+	require.Equal(t, netip.MustParseAddr("::1"), n.Row(0).Value)
+	require.False(t, n.Row(1).Set)
+}
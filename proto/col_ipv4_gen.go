@@ -27,6 +27,21 @@ func (ColIPv4) Type() ColumnType {
 	return ColumnTypeIPv4
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColIPv4) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColIPv4.
+func (c *ColIPv4) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColIPv4](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColIPv4) Row(i int) IPv4 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColIPv6) Type() ColumnType {
 	return ColumnTypeIPv6
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColIPv6) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColIPv6.
+func (c *ColIPv6) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColIPv6](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColIPv6) Row(i int) IPv6 {
 	return c[i]
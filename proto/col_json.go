@@ -0,0 +1,218 @@
+package proto
+
+import (
+	"encoding/json"
+
+	"github.com/go-faster/errors"
+)
+
+// JSONStringSerializationVersion is serialization version of JSON column
+// where each row is serialized as JSON string.
+const JSONStringSerializationVersion uint64 = 1
+
+// ColJSONStr represents JSON column with rows as JSON strings.
+//
+// Rows are encoded with string serialization, that server accepts without
+// additional settings. Both string serialization, i.e. with enabled
+// output_format_native_write_json_as_string setting, and native object
+// serialization with typed paths, dynamic paths and shared data are
+// decoded, in latter case rows are marshaled to JSON with paths as nested
+// objects. Keys of such rows are sorted.
+//
+// Use ColJSONBytes for []byte ColumnOf implementation and ColJSONOf for
+// values that are (un)marshaled with encoding/json.
+type ColJSONStr struct {
+	Str ColStr
+
+	dataType ColumnType
+	native   bool // object serialization of current block
+	obj      jsonObject
+}
+
+// ColJSON is alias of ColJSONStr, default representation of JSON column.
+type ColJSON = ColJSONStr
+
+// Compile-time assertions for ColJSONStr.
+var (
+	_ ColInput          = ColJSONStr{}
+	_ ColResult         = (*ColJSONStr)(nil)
+	_ Column            = (*ColJSONStr)(nil)
+	_ ColumnOf[string]  = (*ColJSONStr)(nil)
+	_ Arrayable[string] = (*ColJSONStr)(nil)
+	_ StateEncoder      = (*ColJSONStr)(nil)
+	_ StateDecoder      = (*ColJSONStr)(nil)
+	_ Inferable         = (*ColJSONStr)(nil)
+)
+
+// Type returns ColumnType of JSON.
+func (c ColJSONStr) Type() ColumnType {
+	if c.dataType != "" {
+		return c.dataType
+	}
+	return ColumnTypeJSON
+}
+
+// Infer initializes typed paths from column type, like JSON(a.b UInt32).
+func (c *ColJSONStr) Infer(t ColumnType) error {
+	if t.Base() != ColumnTypeJSON {
+		return errors.Errorf("unexpected type %q", t)
+	}
+	if t == c.dataType {
+		return nil
+	}
+	typed, err := jsonTypedPaths(t)
+	if err != nil {
+		return errors.Wrap(err, "typed paths")
+	}
+	c.dataType = t
+	c.obj.typed = typed
+	return nil
+}
+
+// Rows returns count of rows in column.
+func (c ColJSONStr) Rows() int {
+	return c.Str.Rows()
+}
+
+// Reset column data.
+func (c *ColJSONStr) Reset() {
+	c.Str.Reset()
+}
+
+// EncodeState encodes string serialization version.
+func (c ColJSONStr) EncodeState(b *Buffer) {
+	b.PutUInt64(JSONStringSerializationVersion)
+}
+
+// DecodeState decodes serialization version and, for object
+// serialization, dynamic paths and states of path columns.
+func (c *ColJSONStr) DecodeState(r *Reader) error {
+	v, err := r.UInt64()
+	if err != nil {
+		return errors.Wrap(err, "serialization version")
+	}
+	switch v {
+	case JSONStringSerializationVersion:
+		c.native = false
+		return nil
+	case jsonSerializationV1, jsonSerializationV2:
+		c.native = true
+		return c.obj.DecodeState(r, v)
+	default:
+		return errors.Errorf("unsupported JSON serialization version %d "+
+			"(output_format_native_write_json_as_string setting can be enabled)", v,
+		)
+	}
+}
+
+// EncodeColumn encodes JSON rows to *Buffer.
+func (c ColJSONStr) EncodeColumn(b *Buffer) {
+	c.Str.EncodeColumn(b)
+}
+
+// DecodeColumn decodes JSON rows from *Reader.
+func (c *ColJSONStr) DecodeColumn(r *Reader, rows int) error {
+	if c.native {
+		return c.obj.DecodeColumn(r, rows, &c.Str)
+	}
+	return c.Str.DecodeColumn(r, rows)
+}
+
+// Append JSON string to column.
+func (c *ColJSONStr) Append(v string) {
+	c.Str.Append(v)
+}
+
+// AppendBytes appends JSON byte slice to column.
+func (c *ColJSONStr) AppendBytes(v []byte) {
+	c.Str.AppendBytes(v)
+}
+
+// AppendArr appends JSON strings to column.
+func (c *ColJSONStr) AppendArr(v []string) {
+	c.Str.AppendArr(v)
+}
+
+// Row returns JSON string with number i.
+func (c ColJSONStr) Row(i int) string {
+	return c.Str.Row(i)
+}
+
+// RowBytes returns JSON with number i as byte slice.
+func (c ColJSONStr) RowBytes(i int) []byte {
+	return c.Str.RowBytes(i)
+}
+
+// Array is helper that creates Array(JSON).
+func (c *ColJSONStr) Array() *ColArr[string] {
+	return &ColArr[string]{
+		Data: c,
+	}
+}
+
+// ColJSONBytes is ColJSONStr wrapper to be ColumnOf for []byte.
+type ColJSONBytes struct {
+	ColJSONStr
+}
+
+// Compile-time assertions for ColJSONBytes.
+var (
+	_ ColumnOf[[]byte]  = (*ColJSONBytes)(nil)
+	_ Arrayable[[]byte] = (*ColJSONBytes)(nil)
+)
+
+// Row returns row with number i.
+func (c ColJSONBytes) Row(i int) []byte {
+	return c.RowBytes(i)
+}
+
+// Append byte slice to column.
+func (c *ColJSONBytes) Append(v []byte) {
+	c.AppendBytes(v)
+}
+
+// AppendArr append slice of byte slices to column.
+func (c *ColJSONBytes) AppendArr(v [][]byte) {
+	for _, s := range v {
+		c.Append(s)
+	}
+}
+
+// Array is helper that creates Array(JSON).
+func (c *ColJSONBytes) Array() *ColArr[[]byte] {
+	return &ColArr[[]byte]{
+		Data: c,
+	}
+}
+
+// ColJSONOf is JSON column with values of T, like map[string]any or
+// struct, that are (un)marshaled with encoding/json.
+//
+// Raw JSON strings can be appended or read via embedded ColJSONStr.
+type ColJSONOf[T any] struct {
+	ColJSONStr
+}
+
+// NewJSONOf returns new JSON column of T.
+func NewJSONOf[T any]() *ColJSONOf[T] {
+	return &ColJSONOf[T]{}
+}
+
+// AppendValue marshals v and appends it to column.
+func (c *ColJSONOf[T]) AppendValue(v T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal")
+	}
+	c.AppendBytes(data)
+	return nil
+}
+
+// Value unmarshals row with number i.
+func (c ColJSONOf[T]) Value(i int) (T, error) {
+	var v T
+	if err := json.Unmarshal(c.RowBytes(i), &v); err != nil {
+		return v, errors.Wrapf(err, "unmarshal [%d]", i)
+	}
+	return v, nil
+}
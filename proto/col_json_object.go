@@ -0,0 +1,404 @@
+package proto
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+)
+
+// Object serialization versions of JSON column, see
+// JSONStringSerializationVersion.
+const (
+	jsonSerializationV1 uint64 = 0 // with max_dynamic_paths
+	jsonSerializationV2 uint64 = 2
+)
+
+// jsonPath is column of JSON path values.
+type jsonPath struct {
+	Name string
+	Data Column
+}
+
+// jsonTypedPaths returns typed paths of JSON type, sorted by path like
+// they are serialized, e.g. [a.b] for JSON(max_dynamic_paths=8, a.b UInt32).
+func jsonTypedPaths(t ColumnType) ([]jsonPath, error) {
+	var paths []jsonPath
+	for _, e := range t.elems() {
+		name, rest, err := jsonPathName(string(e))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parameter %q", e)
+		}
+		if strings.HasPrefix(rest, "=") || name == "SKIP" {
+			// Parameter like max_dynamic_paths=8 or SKIP path.
+			continue
+		}
+		if rest == "" {
+			return nil, errors.Errorf("parameter %q: no type", e)
+		}
+		v := new(ColAuto)
+		if err := v.Infer(ColumnType(rest)); err != nil {
+			return nil, errors.Wrapf(err, "path %q", name)
+		}
+		paths = append(paths, jsonPath{Name: name, Data: v})
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i].Name < paths[j].Name
+	})
+	return paths, nil
+}
+
+// jsonPathName splits JSON type parameter to path, which can be quoted
+// with backticks, and rest.
+func jsonPathName(s string) (name, rest string, err error) {
+	if !strings.HasPrefix(s, "`") {
+		end := strings.IndexAny(s, " \t=")
+		if end < 0 {
+			return s, "", nil
+		}
+		return s[:end], strings.TrimSpace(s[end:]), nil
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", errors.New("unterminated escape")
+			}
+			b.WriteByte(s[i])
+		case '`':
+			return b.String(), strings.TrimSpace(s[i+1:]), nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", errors.New("unterminated quote")
+}
+
+// jsonObject is native object serialization of JSON column.
+//
+// Each typed and dynamic path is serialized as separate column, and paths
+// over max_dynamic_paths limit are serialized as shared data, that is
+// Array(Tuple(paths String, values String)) with values in binary
+// encoding.
+type jsonObject struct {
+	typed   []jsonPath // from column type
+	dynamic []jsonPath // from serialization state, sorted by path
+
+	offsets ColUInt64
+	paths   ColStr
+	values  ColStr
+
+	rows []jsonRowFunc // of typed paths
+}
+
+// DecodeState decodes dynamic paths and states of path columns.
+func (o *jsonObject) DecodeState(r *Reader, version uint64) error {
+	if version == jsonSerializationV1 {
+		if _, err := r.UVarInt(); err != nil {
+			return errors.Wrap(err, "max dynamic paths")
+		}
+	}
+	n, err := r.UVarInt()
+	if err != nil {
+		return errors.Wrap(err, "dynamic paths count")
+	}
+	o.dynamic = o.dynamic[:0]
+	for i := uint64(0); i < n; i++ {
+		name, err := r.Str()
+		if err != nil {
+			return errors.Wrapf(err, "dynamic path [%d]", i)
+		}
+		if int(i) < cap(o.dynamic) && o.dynamic[:i+1][i].Data != nil {
+			// Reusing column, variants are re-inferred if types differ.
+			o.dynamic = o.dynamic[:i+1]
+			o.dynamic[i].Name = name
+			continue
+		}
+		o.dynamic = append(o.dynamic, jsonPath{Name: name, Data: new(ColDynamic)})
+	}
+	for _, p := range o.typed {
+		if s, ok := p.Data.(StateDecoder); ok {
+			if err := s.DecodeState(r); err != nil {
+				return errors.Wrapf(err, "typed path %q", p.Name)
+			}
+		}
+	}
+	for _, p := range o.dynamic {
+		if err := p.Data.(*ColDynamic).DecodeState(r); err != nil {
+			return errors.Wrapf(err, "dynamic path %q", p.Name)
+		}
+	}
+	return nil
+}
+
+// DecodeColumn decodes rows of path columns and shared data, and appends
+// them as JSON strings to s.
+func (o *jsonObject) DecodeColumn(r *Reader, rows int, s *ColStr) error {
+	for _, p := range o.typed {
+		p.Data.Reset()
+		if err := p.Data.DecodeColumn(r, rows); err != nil {
+			return errors.Wrapf(err, "typed path %q", p.Name)
+		}
+	}
+	for _, p := range o.dynamic {
+		p.Data.Reset()
+		if err := p.Data.DecodeColumn(r, rows); err != nil {
+			return errors.Wrapf(err, "dynamic path %q", p.Name)
+		}
+	}
+	o.offsets.Reset()
+	o.paths.Reset()
+	o.values.Reset()
+	if err := o.offsets.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "shared data offsets")
+	}
+	var prev uint64
+	for i, v := range o.offsets {
+		if v < prev {
+			return errors.Errorf("shared data offset [%d] %d is less than previous %d", i, v, prev)
+		}
+		prev = v
+	}
+	if err := checkLimit("MaxArrSize", int(prev), r.limits.MaxArrSize); err != nil {
+		return errors.Wrap(err, "shared data")
+	}
+	if err := o.paths.DecodeColumn(r, int(prev)); err != nil {
+		return errors.Wrap(err, "shared data paths")
+	}
+	if err := o.values.DecodeColumn(r, int(prev)); err != nil {
+		return errors.Wrap(err, "shared data values")
+	}
+	o.rows = o.rows[:0]
+	for _, p := range o.typed {
+		f, err := jsonRows(r, p.Data)
+		if err != nil {
+			return errors.Wrapf(err, "typed path %q", p.Name)
+		}
+		o.rows = append(o.rows, f)
+	}
+	for i := 0; i < rows; i++ {
+		data, err := o.row(r, i)
+		if err != nil {
+			return errors.Wrapf(err, "row [%d]", i)
+		}
+		s.AppendBytes(data)
+	}
+	return nil
+}
+
+// row returns i-th row as JSON.
+func (o *jsonObject) row(r *Reader, i int) ([]byte, error) {
+	obj := map[string]any{}
+	for j, p := range o.typed {
+		v, err := o.rows[j](i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "typed path %q", p.Name)
+		}
+		setJSONPath(obj, p.Name, v)
+	}
+	for _, p := range o.dynamic {
+		dv := p.Data.(*ColDynamic).Row(i)
+		if dv.IsNull() {
+			// Path is absent.
+			continue
+		}
+		v, err := jsonDynamicValue(r, dv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dynamic path %q", p.Name)
+		}
+		setJSONPath(obj, p.Name, v)
+	}
+	var start uint64
+	if i > 0 {
+		start = o.offsets[i-1]
+	}
+	for j := int(start); j < int(o.offsets[i]); j++ {
+		path := o.paths.Row(j)
+		v, err := decodeBinaryValue(r.subReader(o.values.RowBytes(j)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "shared path %q", path)
+		}
+		setJSONPath(obj, path, v)
+	}
+	return json.Marshal(obj)
+}
+
+// setJSONPath sets value of dot-separated path in obj.
+func setJSONPath(obj map[string]any, path string, v any) {
+	for {
+		i := strings.IndexByte(path, '.')
+		if i < 0 {
+			obj[path] = v
+			return
+		}
+		child, ok := obj[path[:i]].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			obj[path[:i]] = child
+		}
+		obj, path = child, path[i+1:]
+	}
+}
+
+// jsonDynamicValue returns value of Dynamic row, decoding values from
+// shared variant.
+func jsonDynamicValue(r *Reader, v DynamicValue) (any, error) {
+	if v.Type() == ColumnTypeSharedVariant {
+		s, ok := DynamicValueAs[string](v)
+		if !ok {
+			return nil, errors.New("unexpected shared variant column")
+		}
+		return decodeBinaryValue(r.subReader([]byte(s)))
+	}
+	col, idx := v.Column()
+	return jsonColumnValue(r, col, idx)
+}
+
+// jsonRowFunc returns i-th row of column as value that can be marshaled
+// to JSON.
+type jsonRowFunc func(i int) (any, error)
+
+// jsonColumnValue returns i-th row of column as value that can be
+// marshaled to JSON, unwrapping Nullable values.
+func jsonColumnValue(r *Reader, col Column, i int) (any, error) {
+	f, err := jsonRows(r, col)
+	if err != nil {
+		return nil, err
+	}
+	return f(i)
+}
+
+// jsonRows returns function that returns rows of column as values that
+// can be marshaled to JSON, unwrapping Nullable values, so column is
+// inspected once instead of for every row.
+func jsonRows(r *Reader, col Column) (jsonRowFunc, error) {
+	if auto, ok := col.(*ColAuto); ok {
+		col = auto.Data
+	}
+	if w, ok := col.(colWrap); ok {
+		col = w.Column
+	}
+	if d, ok := col.(*ColDynamic); ok {
+		return func(i int) (any, error) {
+			v := d.Row(i)
+			if v.IsNull() {
+				return nil, nil
+			}
+			return jsonDynamicValue(r, v)
+		}, nil
+	}
+	values := col
+	if n, ok := col.(interface{ nullableValues() Column }); ok {
+		values = n.nullableValues()
+	}
+	row, err := jsonRowOf(values)
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := col.(interface{ IsElemNull(i int) bool }); ok {
+		return func(i int) (any, error) {
+			if n.IsElemNull(i) {
+				return nil, nil
+			}
+			return row(i), nil
+		}, nil
+	}
+	return func(i int) (any, error) {
+		return row(i), nil
+	}, nil
+}
+
+// jsonRowOf returns function that returns rows of column, without
+// reflection for columns of common types.
+func jsonRowOf(col Column) (func(i int) any, error) {
+	switch c := col.(type) {
+	case ColumnOf[string]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[bool]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[int8]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[int16]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[int32]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[int64]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[uint8]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[uint16]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[uint32]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[uint64]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[float32]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[float64]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[time.Time]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[uuid.UUID]:
+		return jsonRowsOf(c), nil
+	case ColumnOf[[]string]:
+		return jsonSliceRowsOf(c), nil
+	case ColumnOf[[]int64]:
+		return jsonSliceRowsOf(c), nil
+	case ColumnOf[[]uint64]:
+		return jsonSliceRowsOf(c), nil
+	case ColumnOf[[]float64]:
+		return jsonSliceRowsOf(c), nil
+	}
+	// Other types, like Nullable elements of Array, require unwrapping,
+	// so Row method is found once and called by reflection.
+	row := reflect.ValueOf(col).MethodByName("Row")
+	if !row.IsValid() || row.Type().NumIn() != 1 || row.Type().NumOut() != 1 {
+		return nil, errors.Errorf("unsupported column %T (%s)", col, col.Type())
+	}
+	return func(i int) any {
+		return jsonValue(row.Call([]reflect.Value{reflect.ValueOf(i)})[0])
+	}, nil
+}
+
+func jsonRowsOf[T any](c ColumnOf[T]) func(i int) any {
+	return func(i int) any { return c.Row(i) }
+}
+
+// jsonSliceRowsOf is like jsonRowsOf, but marshals empty arrays as [], not
+// null.
+func jsonSliceRowsOf[T any](c ColumnOf[[]T]) func(i int) any {
+	return func(i int) any {
+		v := c.Row(i)
+		if v == nil {
+			return []T{}
+		}
+		return v
+	}
+}
+
+// jsonValue unwraps Nullable values, including elements of slices.
+func jsonValue(v reflect.Value) any {
+	if n, ok := v.Interface().(interface{ IsSet() bool }); ok {
+		if !n.IsSet() {
+			return nil
+		}
+		return jsonValue(v.FieldByName("Value"))
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		if v.IsNil() {
+			return []any{}
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = jsonValue(v.Index(i))
+		}
+		return out
+	}
+	return v.Interface()
+}
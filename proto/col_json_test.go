@@ -0,0 +1,268 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColJSONStr(t *testing.T) {
+	var data ColJSONStr
+	data.Append(`{"a":1}`)
+	data.AppendBytes([]byte(`{"b":"foo"}`))
+	require.Equal(t, ColumnTypeJSON, data.Type())
+	require.Equal(t, 2, data.Rows())
+
+	var buf Buffer
+	data.EncodeState(&buf)
+	data.EncodeColumn(&buf)
+
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+
+		var dec ColJSONStr
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, 2))
+		require.Equal(t, data, dec)
+		require.Equal(t, `{"a":1}`, dec.Row(0))
+		require.Equal(t, []byte(`{"b":"foo"}`), dec.RowBytes(1))
+	})
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		var b Buffer
+		b.PutUInt64(3)
+		r := NewReader(bytes.NewReader(b.Buf))
+
+		var dec ColJSONStr
+		require.Error(t, dec.DecodeState(r))
+	})
+	t.Run("Array", func(t *testing.T) {
+		arr := new(ColJSONStr).Array()
+		arr.Append([]string{`{"a":1}`, `{"a":2}`})
+		require.Equal(t, ColumnTypeJSON.Array(), arr.Type())
+
+		var b Buffer
+		arr.EncodeState(&b)
+		arr.EncodeColumn(&b)
+		r := NewReader(bytes.NewReader(b.Buf))
+
+		dec := new(ColJSONStr).Array()
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, 1))
+		require.Equal(t, arr.Row(0), dec.Row(0))
+	})
+}
+
+func TestColJSONBytes(t *testing.T) {
+	testColumn(t, "json_bytes", func() ColumnOf[[]byte] {
+		return new(ColJSONBytes)
+	}, []byte(`{"a":1}`), []byte(`{}`))
+}
+
+func TestColJSONOf(t *testing.T) {
+	type Event struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	data := NewJSONOf[Event]()
+	require.NoError(t, data.AppendValue(Event{Name: "foo", Count: 1}))
+	data.Append(`{"name":"bar","count":2}`)
+
+	var buf Buffer
+	data.EncodeState(&buf)
+	data.EncodeColumn(&buf)
+
+	r := NewReader(bytes.NewReader(buf.Buf))
+	dec := NewJSONOf[map[string]any]()
+	require.NoError(t, dec.DecodeState(r))
+	require.NoError(t, dec.DecodeColumn(r, 2))
+
+	v, err := dec.Value(1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"name": "bar", "count": float64(2)}, v)
+
+	dec.Append(`{`)
+	_, err = dec.Value(2)
+	require.Error(t, err)
+}
+
+// encodeJSONObject encodes 4 rows of JSON(a.b UInt32) in object
+// serialization with dynamic path "c" and shared data.
+func encodeJSONObject(t *testing.T, b *Buffer, version uint64) {
+	t.Helper()
+
+	typed := ColUInt32{1, 2, 3, 4}
+	dynamic := NewDynamic(new(ColInt64), new(ColStr))
+	require.NoError(t, AppendDynamic[int64](dynamic, ColumnTypeInt64, 1))
+	require.NoError(t, AppendDynamic[string](dynamic, ColumnTypeString, "foo"))
+	shared, ok := dynamic.Discriminator(ColumnTypeSharedVariant)
+	require.True(t, ok)
+	dynamic.Variant.Variants[shared].(colWrap).Column.(*ColStr).AppendBytes([]byte{binaryTypeUInt8, 7})
+	dynamic.Variant.AppendDiscriminator(shared)
+	dynamic.AppendNull()
+
+	// Shared data values, i.e. binary encoded type and value.
+	var (
+		offsets = ColUInt64{1, 1, 3, 3}
+		paths   ColStr
+		values  ColStr
+	)
+	paths.Append("f")
+	values.AppendBytes([]byte{binaryTypeInt64, 42, 0, 0, 0, 0, 0, 0, 0})
+	paths.Append("g.h")
+	values.AppendBytes([]byte{
+		binaryTypeArray, binaryTypeNullable, binaryTypeString,
+		2,         // size
+		0, 1, 'x', // "x"
+		1, // NULL
+	})
+	paths.Append("t")
+	values.AppendBytes([]byte{binaryTypeBool, 1})
+
+	b.PutUInt64(version)
+	if version == jsonSerializationV1 {
+		b.PutUVarInt(1024) // max_dynamic_paths
+	}
+	b.PutUVarInt(1)
+	b.PutString("c")
+	dynamic.EncodeState(b)
+
+	typed.EncodeColumn(b)
+	dynamic.EncodeColumn(b)
+	offsets.EncodeColumn(b)
+	paths.EncodeColumn(b)
+	values.EncodeColumn(b)
+}
+
+func TestColJSONStr_object(t *testing.T) {
+	const typ ColumnType = "JSON(max_dynamic_paths=8, a.b UInt32, SKIP x)"
+	expected := []string{
+		`{"a":{"b":1},"c":1,"f":42}`,
+		`{"a":{"b":2},"c":"foo"}`,
+		`{"a":{"b":3},"c":7,"g":{"h":["x",null]},"t":true}`,
+		`{"a":{"b":4}}`,
+	}
+	for _, version := range []uint64{
+		jsonSerializationV1,
+		jsonSerializationV2,
+	} {
+		var buf Buffer
+		encodeJSONObject(t, &buf, version)
+
+		dec := new(ColAuto)
+		require.NoError(t, dec.Infer(typ))
+		require.Equal(t, typ, dec.Type())
+
+		// Decoding twice to check reuse of columns.
+		for i := 0; i < 2; i++ {
+			dec.Reset()
+			r := NewReader(bytes.NewReader(buf.Buf))
+			require.NoError(t, dec.DecodeState(r))
+			require.NoError(t, dec.DecodeColumn(r, len(expected)))
+
+			col := dec.Data.(*ColJSONStr)
+			require.Equal(t, len(expected), col.Rows())
+			for j, v := range expected {
+				require.JSONEq(t, v, col.Row(j))
+			}
+		}
+	}
+	t.Run("JSONOf", func(t *testing.T) {
+		var buf Buffer
+		encodeJSONObject(t, &buf, jsonSerializationV2)
+
+		type Value struct {
+			A struct {
+				B int `json:"b"`
+			} `json:"a"`
+			T bool `json:"t"`
+		}
+		dec := NewJSONOf[Value]()
+		require.NoError(t, dec.Infer(typ))
+		r := NewReader(bytes.NewReader(buf.Buf))
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, 4))
+
+		v, err := dec.Value(2)
+		require.NoError(t, err)
+		require.Equal(t, 3, v.A.B)
+		require.True(t, v.T)
+	})
+	t.Run("Untyped", func(t *testing.T) {
+		var b Buffer
+		b.PutUInt64(jsonSerializationV2)
+		b.PutUVarInt(0)
+		ColUInt64{0, 0}.EncodeColumn(&b) // no shared data
+
+		var dec ColJSONStr
+		r := NewReader(bytes.NewReader(b.Buf))
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, 2))
+		require.Equal(t, "{}", dec.Row(0))
+		require.Equal(t, "{}", dec.Row(1))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		var buf Buffer
+		encodeJSONObject(t, &buf, jsonSerializationV2)
+
+		var dec ColJSONStr
+		require.NoError(t, dec.Infer(typ))
+		r := NewReader(bytes.NewReader(buf.Buf[:len(buf.Buf)-1]))
+		require.NoError(t, dec.DecodeState(r))
+		require.Error(t, dec.DecodeColumn(r, 4))
+	})
+}
+
+func TestJSONTypedPaths(t *testing.T) {
+	paths, err := jsonTypedPaths("JSON(max_dynamic_types=4, z String, `a.b c` Nullable(UInt8), SKIP d, SKIP REGEXP '^x')")
+	require.NoError(t, err)
+	var names []string
+	for _, p := range paths {
+		names = append(names, p.Name)
+	}
+	require.Equal(t, []string{"a.b c", "z"}, names)
+	require.Equal(t, ColumnType("Nullable(UInt8)"), paths[0].Data.Type())
+
+	for _, typ := range []ColumnType{
+		"JSON(a)",
+		"JSON(`a UInt8)",
+		"JSON(a Unknown)",
+	} {
+		_, err := jsonTypedPaths(typ)
+		require.Error(t, err, typ)
+	}
+}
+
+func TestJSONRows(t *testing.T) {
+	for _, tt := range []struct {
+		Type   ColumnType
+		Values []any
+	}{
+		{Type: "Nullable(UInt8)", Values: []any{uint8(1), nil}},
+		{Type: "Array(String)", Values: []any{[]string{"a"}, []string{}}},
+		{Type: "Array(Nullable(Int32))", Values: []any{[]any{int32(1), nil}, []any{}}},
+	} {
+		t.Run(tt.Type.String(), func(t *testing.T) {
+			col, err := NewColumn(tt.Type)
+			require.NoError(t, err)
+			switch c := col.(type) {
+			case ColumnOf[Nullable[uint8]]:
+				c.Append(NewNullable[uint8](1))
+				c.Append(Null[uint8]())
+			case ColumnOf[[]string]:
+				c.Append([]string{"a"})
+				c.Append(nil)
+			case ColumnOf[[]Nullable[int32]]:
+				c.Append([]Nullable[int32]{NewNullable[int32](1), Null[int32]()})
+				c.Append(nil)
+			}
+			rows, err := jsonRows(nil, col)
+			require.NoError(t, err)
+			for i, expected := range tt.Values {
+				v, err := rows(i)
+				require.NoError(t, err)
+				require.Equal(t, expected, v)
+			}
+		})
+	}
+}
@@ -33,6 +33,12 @@ const (
 //
 // NB: shared dictionaries and on-the-fly dictionary update is not supported,
 // because it is not currently used in client protocol.
+//
+// Dictionary can't be reused across blocks either: server reads state prefix
+// and dictionary of LowCardinality column for each block of Native format
+// independently, so every block should contain all keys it references.
+// Full dictionary is sent with every block, only its memory is reused on
+// Reset.
 const (
 	cardinalityKeyMask = 0b0000_1111_1111 // last byte
 
@@ -145,10 +151,14 @@ func (c *ColLowCardinality[T]) DecodeColumn(r *Reader, rows int) error {
 	if err != nil {
 		return errors.Wrap(err, "index size")
 	}
-	if err := checkRows(int(indexRows)); err != nil {
+	if err := r.checkArrSize(int(indexRows)); err != nil {
 		return errors.Wrap(err, "index size")
 	}
-	if err := c.index.DecodeColumn(r, int(indexRows)); err != nil {
+	if n, ok := c.index.(lowCardinalityNullableIndex); ok {
+		if err := n.decodeLowCardinalityIndex(r, int(indexRows)); err != nil {
+			return errors.Wrap(err, "nullable index column")
+		}
+	} else if err := c.index.DecodeColumn(r, int(indexRows)); err != nil {
 		return errors.Wrap(err, "index column")
 	}
 
@@ -156,7 +166,7 @@ func (c *ColLowCardinality[T]) DecodeColumn(r *Reader, rows int) error {
 	if err != nil {
 		return errors.Wrap(err, "keys size")
 	}
-	if err := checkRows(int(keyRows)); err != nil {
+	if err := r.checkRows(int(keyRows)); err != nil {
 		return errors.Wrap(err, "index size")
 	}
 	switch c.key {
@@ -215,7 +225,11 @@ func (c *ColLowCardinality[T]) EncodeColumn(b *Buffer) {
 
 	// Writing index (dictionary).
 	b.PutInt64(int64(c.index.Rows()))
-	c.index.EncodeColumn(b)
+	if n, ok := c.index.(lowCardinalityNullableIndex); ok {
+		n.encodeLowCardinalityIndex(b)
+	} else {
+		c.index.EncodeColumn(b)
+	}
 
 	b.PutInt64(int64(c.Rows()))
 	switch c.key {
@@ -245,6 +259,14 @@ func (c *ColLowCardinality[T]) Reset() {
 	c.index.Reset()
 }
 
+// lowCardinalityNullableIndex is index of LowCardinality(Nullable(T)).
+//
+// Index is encoded as T column, where first element is placeholder for NULL.
+type lowCardinalityNullableIndex interface {
+	encodeLowCardinalityIndex(b *Buffer)
+	decodeLowCardinalityIndex(r *Reader, rows int) error
+}
+
 type cardinalityKeyValue interface {
 	~uint8 | ~uint16 | ~uint32 | ~uint64
 }
@@ -305,8 +327,26 @@ func (c *ColLowCardinality[T]) Prepare() error {
 	}
 
 	// Fill keys with value indexes.
-	var last int
+	var (
+		last     int
+		zero     T
+		_, isNil = c.index.(lowCardinalityNullableIndex)
+	)
+	if isNil {
+		// First element of nullable index is reserved for NULL.
+		if _, ok := c.kv[zero]; !ok {
+			c.index.Append(zero)
+			c.kv[zero] = 0
+		}
+		last = c.index.Rows()
+	}
 	for i, v := range c.Values {
+		if isNil {
+			if n, ok := any(v).(interface{ IsSet() bool }); ok && !n.IsSet() {
+				// Normalizing NULL values.
+				v = zero
+			}
+		}
 		idx, ok := c.kv[v]
 		if !ok {
 			c.index.Append(v)
@@ -340,6 +380,10 @@ func (c *ColLowCardinality[T]) Array() *ColArr[T] {
 }
 
 // NewLowCardinality creates new LowCardinality column from another column for T.
+//
+// Use ColNullable as index for LowCardinality(Nullable(T)), e.g.
+//
+//	NewLowCardinality[Nullable[string]](new(ColStr).Nullable())
 func NewLowCardinality[T comparable](c ColumnOf[T]) *ColLowCardinality[T] {
 	return &ColLowCardinality[T]{
 		index: c,
@@ -106,7 +106,7 @@ func (c *ColLowCardinalityRaw) DecodeColumn(r *Reader, rows int) error {
 	if err != nil {
 		return errors.Wrap(err, "index size")
 	}
-	if err := checkRows(int(indexRows)); err != nil {
+	if err := r.checkArrSize(int(indexRows)); err != nil {
 		return errors.Wrap(err, "index size")
 	}
 	if err := c.Index.DecodeColumn(r, int(indexRows)); err != nil {
@@ -117,7 +117,7 @@ func (c *ColLowCardinalityRaw) DecodeColumn(r *Reader, rows int) error {
 	if err != nil {
 		return errors.Wrap(err, "keys size")
 	}
-	if err := checkRows(int(keyRows)); err != nil {
+	if err := r.checkRows(int(keyRows)); err != nil {
 		return errors.Wrap(err, "index size")
 	}
 	if err := c.Keys().DecodeColumn(r, int(keyRows)); err != nil {
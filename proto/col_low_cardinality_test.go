@@ -168,3 +168,41 @@ func TestColLowCardinality_DecodeColumn(t *testing.T) {
 		require.Error(t, dec.DecodeColumn(buf.Reader(), 1))
 	})
 }
+
+func TestLowCardinalityOfNullableStr(t *testing.T) {
+	col := NewLowCardinality[Nullable[string]](new(ColStr).Nullable())
+	require.Equal(t, ColumnType("LowCardinality(Nullable(String))"), col.Type())
+	v := []Nullable[string]{
+		NewNullable("foo"),
+		Null[string](),
+		NewNullable(""),
+		NewNullable("foo"),
+		{Value: "ignored"}, // NULL with value
+	}
+	col.AppendArr(v)
+	require.NoError(t, col.Prepare())
+
+	var buf Buffer
+	col.EncodeColumn(&buf)
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_low_cardinality_of_nullable_str")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+		dec := NewLowCardinality[Nullable[string]](new(ColStr).Nullable())
+
+		require.NoError(t, dec.DecodeColumn(r, col.Rows()))
+		require.Equal(t, col.Rows(), dec.Rows())
+		require.Equal(t, []Nullable[string]{
+			NewNullable("foo"),
+			Null[string](),
+			NewNullable(""),
+			NewNullable("foo"),
+			Null[string](),
+		}, dec.Values)
+	})
+	t.Run("NoShortRead", func(t *testing.T) {
+		dec := NewLowCardinality[Nullable[string]](new(ColStr).Nullable())
+		requireNoShortRead(t, buf.Buf, colAware(dec, col.Rows()))
+	})
+}
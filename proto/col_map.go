@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/go-faster/errors"
@@ -67,13 +68,24 @@ func (c ColMap[K, V]) EncodeState(b *Buffer) {
 	}
 }
 
-func (c ColMap[K, V]) Row(i int) map[K]V {
-	m := make(map[K]V)
-	var start int
-	end := int(c.Offsets[i])
+// bounds returns start and end of i-th row entries.
+func (c ColMap[K, V]) bounds(i int) (start, end int) {
+	end = int(c.Offsets[i])
 	if i > 0 {
 		start = int(c.Offsets[i-1])
 	}
+	return start, end
+}
+
+// RowLen returns count of entries in i-th row.
+func (c ColMap[K, V]) RowLen(i int) int {
+	start, end := c.bounds(i)
+	return end - start
+}
+
+func (c ColMap[K, V]) Row(i int) map[K]V {
+	start, end := c.bounds(i)
+	m := make(map[K]V, end-start)
 	for idx := start; idx < end; idx++ {
 		m[c.Keys.Row(idx)] = c.Values.Row(idx)
 	}
@@ -82,19 +94,32 @@ func (c ColMap[K, V]) Row(i int) map[K]V {
 
 // RowKV returns a slice of KV[K, V] for a given row.
 func (c ColMap[K, V]) RowKV(i int) []KV[K, V] {
-	var start int
-	end := int(c.Offsets[i])
-	if i > 0 {
-		start = int(c.Offsets[i-1])
-	}
-	v := make([]KV[K, V], 0, end-start)
+	return c.RowKVAppend(i, make([]KV[K, V], 0, c.RowLen(i)))
+}
+
+// RowKVAppend appends entries of i-th row to target in column order
+// and returns it.
+func (c ColMap[K, V]) RowKVAppend(i int, target []KV[K, V]) []KV[K, V] {
+	start, end := c.bounds(i)
 	for idx := start; idx < end; idx++ {
-		v = append(v, KV[K, V]{
+		target = append(target, KV[K, V]{
 			Key:   c.Keys.Row(idx),
 			Value: c.Values.Row(idx),
 		})
 	}
-	return v
+	return target
+}
+
+// ForEachRow calls f on each entry of i-th row in column order without
+// materializing map.
+func (c ColMap[K, V]) ForEachRow(i int, f func(k K, v V) error) error {
+	start, end := c.bounds(i)
+	for idx := start; idx < end; idx++ {
+		if err := f(c.Keys.Row(idx), c.Values.Row(idx)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // KV is a key-value pair.
@@ -113,9 +138,30 @@ func (c *ColMap[K, V]) AppendKV(kv []KV[K, V]) {
 }
 
 func (c *ColMap[K, V]) Append(m map[K]V) {
-	for k, v := range m {
+	c.AppendMap(m, nil)
+}
+
+// AppendMap appends map as row.
+//
+// If cmp is not nil, entries are appended in order of keys defined by cmp,
+// e.g. cmp.Compare for ordered keys. Otherwise, order is unspecified.
+func (c *ColMap[K, V]) AppendMap(m map[K]V, cmp func(a, b K) int) {
+	if cmp == nil {
+		for k, v := range m {
+			c.Keys.Append(k)
+			c.Values.Append(v)
+		}
+		c.Offsets.Append(uint64(c.Keys.Rows()))
+		return
+	}
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, cmp)
+	for _, k := range keys {
 		c.Keys.Append(k)
-		c.Values.Append(v)
+		c.Values.Append(m[k])
 	}
 	c.Offsets.Append(uint64(c.Keys.Rows()))
 }
@@ -135,7 +181,7 @@ func (c *ColMap[K, V]) DecodeColumn(r *Reader, rows int) error {
 	}
 
 	count := int(c.Offsets[rows-1])
-	if err := checkRows(count); err != nil {
+	if err := r.checkArrSize(count); err != nil {
 		return errors.Wrap(err, "keys count")
 	}
 	if err := c.Keys.DecodeColumn(r, count); err != nil {
@@ -2,9 +2,11 @@ package proto
 
 import (
 	"bytes"
+	"cmp"
 	"io"
 	"testing"
 
+	"github.com/go-faster/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ClickHouse/ch-go/internal/gold"
@@ -171,3 +173,44 @@ func TestColMap_RowKV(t *testing.T) {
 		requireNoShortRead(t, buf.Buf, colAware(dec, rows))
 	})
 }
+
+func TestColMap_AppendMap(t *testing.T) {
+	v := NewMap[string, int64](new(ColStr), new(ColInt64))
+	v.AppendMap(map[string]int64{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}, cmp.Compare[string])
+	v.AppendMap(map[string]int64{}, cmp.Compare[string])
+	v.AppendMap(map[string]int64{"d": 4}, nil)
+	require.Equal(t, 3, v.Rows())
+	require.Equal(t, 3, v.RowLen(0))
+	require.Equal(t, 0, v.RowLen(1))
+	require.Equal(t, []KV[string, int64]{
+		{"a", 1},
+		{"b", 2},
+		{"c", 3},
+	}, v.RowKV(0))
+	require.Equal(t, map[string]int64{"d": 4}, v.Row(2))
+
+	t.Run("ForEachRow", func(t *testing.T) {
+		var keys []string
+		require.NoError(t, v.ForEachRow(0, func(k string, _ int64) error {
+			keys = append(keys, k)
+			return nil
+		}))
+		require.Equal(t, []string{"a", "b", "c"}, keys)
+
+		testErr := errors.New("test")
+		require.ErrorIs(t, v.ForEachRow(0, func(string, int64) error {
+			return testErr
+		}), testErr)
+	})
+	t.Run("RowKVAppend", func(t *testing.T) {
+		buf := make([]KV[string, int64], 0, 4)
+		buf = v.RowKVAppend(0, buf)
+		buf = v.RowKVAppend(2, buf)
+		require.Len(t, buf, 4)
+		require.Equal(t, KV[string, int64]{"d", 4}, buf[3])
+	})
+}
@@ -0,0 +1,216 @@
+package proto
+
+import "github.com/go-faster/errors"
+
+// NestedField binds field of Nested element T to element column.
+//
+// Use NestedFieldOf to create NestedField.
+type NestedField[T any] struct {
+	Name string // name of field, without Nested column name prefix
+	Data Column // element column
+
+	append func(v T)
+	set    func(i int, v *T)
+}
+
+// NestedFieldOf creates NestedField that reads and writes field of T
+// with get and set functions.
+func NestedFieldOf[T, V any](name string, data ColumnOf[V], get func(v T) V, set func(v *T, f V)) NestedField[T] {
+	return NestedField[T]{
+		Name: name,
+		Data: data,
+		append: func(v T) {
+			data.Append(get(v))
+		},
+		set: func(i int, v *T) {
+			set(v, data.Row(i))
+		},
+	}
+}
+
+// ColNested groups flattened columns of Nested(field1 T1, ...) column,
+// i.e. name.field1 Array(T1), name.field2 Array(T2), ...
+//
+// Each row is slice of T, where each element of T is stored in element
+// columns of fields. Arrays of all fields share same offsets.
+//
+// Nested columns are flattened by server only if flatten_nested setting
+// is enabled (default).
+type ColNested[T any] struct {
+	Name    string
+	Fields  []NestedField[T]
+	Offsets ColUInt64
+}
+
+// NewNested returns new ColNested with provided fields.
+func NewNested[T any](name string, fields ...NestedField[T]) *ColNested[T] {
+	return &ColNested[T]{
+		Name:   name,
+		Fields: fields,
+	}
+}
+
+// Rows returns count of rows.
+func (c ColNested[T]) Rows() int {
+	return c.Offsets.Rows()
+}
+
+// Reset all fields.
+func (c *ColNested[T]) Reset() {
+	c.Offsets.Reset()
+	for _, f := range c.Fields {
+		f.Data.Reset()
+	}
+}
+
+// Append row of nested elements.
+func (c *ColNested[T]) Append(v []T) {
+	for _, e := range v {
+		for _, f := range c.Fields {
+			f.append(e)
+		}
+	}
+	var last uint64
+	if n := len(c.Offsets); n > 0 {
+		last = c.Offsets[n-1]
+	}
+	c.Offsets = append(c.Offsets, last+uint64(len(v)))
+}
+
+// AppendArr appends rows of nested elements.
+func (c *ColNested[T]) AppendArr(v [][]T) {
+	for _, e := range v {
+		c.Append(e)
+	}
+}
+
+// Row returns elements of i-th row.
+func (c ColNested[T]) Row(i int) []T {
+	var start int
+	end := int(c.Offsets[i])
+	if i > 0 {
+		start = int(c.Offsets[i-1])
+	}
+	v := make([]T, end-start)
+	for _, f := range c.Fields {
+		for idx := start; idx < end; idx++ {
+			f.set(idx, &v[idx-start])
+		}
+	}
+	return v
+}
+
+func (c *ColNested[T]) columns() []nestedArray {
+	var columns []nestedArray
+	for i, f := range c.Fields {
+		columns = append(columns, nestedArray{
+			offsets: &c.Offsets,
+			data:    f.Data,
+			primary: i == 0,
+		})
+	}
+	return columns
+}
+
+func (c ColNested[T]) columnName(f NestedField[T]) string {
+	return c.Name + "." + f.Name
+}
+
+// Input returns Input with flattened columns.
+func (c *ColNested[T]) Input() Input {
+	var input Input
+	for i, col := range c.columns() {
+		input = append(input, InputColumn{
+			Name: c.columnName(c.Fields[i]),
+			Data: col,
+		})
+	}
+	return input
+}
+
+// Results returns Results with flattened columns.
+func (c *ColNested[T]) Results() Results {
+	var results Results
+	for i, col := range c.columns() {
+		col := col
+		results = append(results, ResultColumn{
+			Name: c.columnName(c.Fields[i]),
+			Data: &col,
+		})
+	}
+	return results
+}
+
+// nestedArray is Array(T) column of Nested field with shared offsets.
+type nestedArray struct {
+	offsets *ColUInt64
+	data    Column
+	primary bool // offsets are decoded and reset only by primary column
+
+	scratch ColUInt64
+}
+
+func (c nestedArray) Type() ColumnType {
+	return c.data.Type().Array()
+}
+
+func (c nestedArray) Rows() int {
+	return c.offsets.Rows()
+}
+
+func (c *nestedArray) Reset() {
+	if c.primary {
+		c.offsets.Reset()
+	}
+	c.data.Reset()
+}
+
+func (c nestedArray) EncodeState(b *Buffer) {
+	if s, ok := c.data.(StateEncoder); ok {
+		s.EncodeState(b)
+	}
+}
+
+func (c *nestedArray) DecodeState(r *Reader) error {
+	if s, ok := c.data.(StateDecoder); ok {
+		return s.DecodeState(r)
+	}
+	return nil
+}
+
+func (c nestedArray) EncodeColumn(b *Buffer) {
+	c.offsets.EncodeColumn(b)
+	c.data.EncodeColumn(b)
+}
+
+func (c *nestedArray) DecodeColumn(r *Reader, rows int) error {
+	offsets := c.offsets
+	if !c.primary {
+		c.scratch.Reset()
+		offsets = &c.scratch
+	}
+	if err := offsets.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "offsets")
+	}
+	if !c.primary {
+		if len(c.scratch) != len(*c.offsets) {
+			return errors.Errorf("got %d offsets, expected %d", len(c.scratch), len(*c.offsets))
+		}
+		for i, v := range c.scratch {
+			if (*c.offsets)[i] != v {
+				return errors.Errorf("offset [%d] mismatch: %d != %d", i, v, (*c.offsets)[i])
+			}
+		}
+	}
+	var size int
+	if l := len(*offsets); l > 0 {
+		size = int((*offsets)[l-1])
+	}
+	if err := r.checkArrSize(size); err != nil {
+		return errors.Wrap(err, "size")
+	}
+	if err := c.data.DecodeColumn(r, size); err != nil {
+		return errors.Wrap(err, "data")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type nestedEvent struct {
+	ID   uint64
+	Name string
+}
+
+func newNestedEvents() *ColNested[nestedEvent] {
+	return NewNested[nestedEvent]("events",
+		NestedFieldOf[nestedEvent, uint64]("id", new(ColUInt64),
+			func(v nestedEvent) uint64 { return v.ID },
+			func(v *nestedEvent, f uint64) { v.ID = f },
+		),
+		NestedFieldOf[nestedEvent, string]("name", new(ColStr),
+			func(v nestedEvent) string { return v.Name },
+			func(v *nestedEvent, f string) { v.Name = f },
+		),
+	)
+}
+
+func TestColNested(t *testing.T) {
+	rows := [][]nestedEvent{
+		{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}},
+		{},
+		{{ID: 3, Name: "baz"}},
+	}
+	data := newNestedEvents()
+	data.AppendArr(rows)
+	require.Equal(t, len(rows), data.Rows())
+	for i, r := range rows {
+		require.Equal(t, r, data.Row(i))
+	}
+
+	input := data.Input()
+	require.Len(t, input, 2)
+	require.Equal(t, "events.id", input[0].Name)
+	require.Equal(t, "events.name", input[1].Name)
+	require.Equal(t, ColumnType("Array(UInt64)"), input[0].Data.Type())
+	require.Equal(t, ColumnType("Array(String)"), input[1].Data.Type())
+
+	var buf Buffer
+	for _, c := range input {
+		c.Data.EncodeColumn(&buf)
+	}
+
+	t.Run("Ok", func(t *testing.T) {
+		dec := newNestedEvents()
+		r := NewReader(bytes.NewReader(buf.Buf))
+		for _, c := range dec.Results() {
+			c.Data.Reset()
+			require.NoError(t, c.Data.DecodeColumn(r, len(rows)))
+		}
+		require.Equal(t, len(rows), dec.Rows())
+		for i, r := range rows {
+			require.Equal(t, r, dec.Row(i))
+		}
+		dec.Reset()
+		require.Equal(t, 0, dec.Rows())
+	})
+	t.Run("OffsetsMismatch", func(t *testing.T) {
+		var b Buffer
+		input[0].Data.EncodeColumn(&b)
+		ColUInt64{1, 1, 1}.EncodeColumn(&b)
+
+		dec := newNestedEvents()
+		r := NewReader(bytes.NewReader(b.Buf))
+		results := dec.Results()
+		require.NoError(t, results[0].Data.DecodeColumn(r, len(rows)))
+		require.Error(t, results[1].Data.DecodeColumn(r, len(rows)))
+	})
+}
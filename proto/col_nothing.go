@@ -71,3 +71,62 @@ func (c ColNothing) EncodeColumn(b *Buffer) {
 	}
 	b.PutRaw(make([]byte, c))
 }
+
+// nothingDecoder decodes column of Nothing elements to column of other
+// element type, e.g. Nullable(Nothing) of "SELECT NULL" to Nullable(String).
+type nothingDecoder interface {
+	decodeNothing(r *Reader, rows int) error
+}
+
+// nothingCompatible reports whether column of type got can be decoded to
+// column of type has via nothingDecoder.
+func nothingCompatible(got, has ColumnType) bool {
+	switch got {
+	case ColumnTypeNullable.Sub(ColumnTypeNothing):
+		return has.Base() == ColumnTypeNullable
+	case ColumnTypeArray.Sub(ColumnTypeNothing):
+		return has.Base() == ColumnTypeArray
+	default:
+		return false
+	}
+}
+
+// decodeNothing decodes Nullable(Nothing), appending zero values.
+func (c *ColNullable[T]) decodeNothing(r *Reader, rows int) error {
+	if err := c.Nulls.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "nulls")
+	}
+	var values ColNothing
+	if err := values.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "values")
+	}
+	var zero T
+	for i := 0; i < rows; i++ {
+		c.Values.Append(zero)
+	}
+	return nil
+}
+
+// decodeNothing decodes Array(Nothing), appending zero values if arrays
+// are not empty.
+func (c *ColArr[T]) decodeNothing(r *Reader, rows int) error {
+	if err := c.Offsets.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "read offsets")
+	}
+	var size int
+	if l := len(c.Offsets); l > 0 {
+		size = int(c.Offsets[l-1])
+	}
+	if err := r.checkArrSize(size); err != nil {
+		return errors.Wrap(err, "array size")
+	}
+	var data ColNothing
+	if err := data.DecodeColumn(r, size); err != nil {
+		return errors.Wrap(err, "decode data")
+	}
+	var zero T
+	for i := 0; i < size; i++ {
+		c.Data.Append(zero)
+	}
+	return nil
+}
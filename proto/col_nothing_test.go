@@ -62,3 +62,47 @@ func TestColNothing(t *testing.T) {
 		v.EncodeColumn(nil) // should be no-op
 	})
 }
+
+func TestColNothing_DecodeAsOtherType(t *testing.T) {
+	t.Parallel()
+	const rows = 3
+	var b Buffer
+	Block{Columns: 2, Rows: rows}.EncodeAware(&b, Version)
+	nullable := new(ColNothing).Nullable()
+	arr := new(ColNothing).Array()
+	for i := 0; i < rows; i++ {
+		nullable.Append(Null[Nothing]())
+		arr.Append(nil)
+	}
+	for _, c := range []InputColumn{
+		{Name: "n", Data: nullable},
+		{Name: "a", Data: arr},
+	} {
+		c.EncodeStart(&b, Version)
+		c.Data.EncodeColumn(&b)
+	}
+
+	var (
+		str = new(ColStr).Nullable()
+		i32 = new(ColInt32).Array()
+		dec Block
+	)
+	require.NoError(t, dec.DecodeBlock(NewReader(bytes.NewReader(b.Buf)), Version, Results{
+		{Name: "n", Data: str},
+		{Name: "a", Data: i32},
+	}))
+	require.Equal(t, rows, str.Rows())
+	require.Equal(t, rows, i32.Rows())
+	for i := 0; i < rows; i++ {
+		require.False(t, str.Row(i).Set)
+		require.Empty(t, i32.Row(i))
+	}
+
+	t.Run("Conflict", func(t *testing.T) {
+		var dec Block
+		require.Error(t, dec.DecodeBlock(NewReader(bytes.NewReader(b.Buf)), Version, Results{
+			{Name: "n", Data: new(ColStr)},
+			{Name: "a", Data: i32},
+		}))
+	})
+}
@@ -133,3 +133,30 @@ func (c ColNullable[T]) IsElemNull(i int) bool {
 	}
 	return false
 }
+
+// nullableValues returns column of values, see jsonRows.
+func (c ColNullable[T]) nullableValues() Column {
+	return c.Values
+}
+
+// encodeLowCardinalityIndex encodes values of LowCardinality(Nullable(T))
+// index, where first value is NULL placeholder.
+func (c ColNullable[T]) encodeLowCardinalityIndex(b *Buffer) {
+	c.Values.EncodeColumn(b)
+}
+
+// decodeLowCardinalityIndex decodes values of LowCardinality(Nullable(T))
+// index, where first value is NULL placeholder.
+func (c *ColNullable[T]) decodeLowCardinalityIndex(r *Reader, rows int) error {
+	if err := c.Values.DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "values")
+	}
+	for i := 0; i < rows; i++ {
+		null := boolFalse
+		if i == 0 {
+			null = boolTrue
+		}
+		c.Nulls.Append(null)
+	}
+	return nil
+}
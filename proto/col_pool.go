@@ -0,0 +1,66 @@
+package proto
+
+import (
+	"sync"
+
+	"github.com/go-faster/errors"
+)
+
+// ColumnPool recycles columns of same type, so their backing slices
+// (including nested Array, Nullable and LowCardinality internals) are
+// reused across queries instead of being allocated again.
+//
+// Zero value and nil pool are valid, nil pool just allocates new
+// columns. Safe for concurrent use.
+type ColumnPool struct {
+	pools sync.Map // ColumnType -> *sync.Pool
+}
+
+// NewColumnPool returns new ColumnPool.
+func NewColumnPool() *ColumnPool {
+	return &ColumnPool{}
+}
+
+func (p *ColumnPool) pool(t ColumnType) *sync.Pool {
+	if v, ok := p.pools.Load(t); ok {
+		return v.(*sync.Pool)
+	}
+	v, _ := p.pools.LoadOrStore(t, new(sync.Pool))
+	return v.(*sync.Pool)
+}
+
+// Get returns empty column of type t, reusing one from pool if possible.
+//
+// New columns are inferred like ColAuto does.
+func (p *ColumnPool) Get(t ColumnType) (Column, error) {
+	if p != nil {
+		if v, ok := p.pool(t).Get().(Column); ok {
+			return v, nil
+		}
+	}
+	col, err := NewColumn(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "infer")
+	}
+	return col, nil
+}
+
+// Put resets column and returns it to pool, so it can be returned by
+// Get for the same type. Column must not be used after Put.
+func (p *ColumnPool) Put(col Column) {
+	if p == nil || col == nil {
+		return
+	}
+	col.Reset()
+	p.pool(col.Type()).Put(col)
+}
+
+// PutInput returns all columns of input to pool, skipping columns that
+// are not Column, e.g. ColInput-only implementations.
+func (p *ColumnPool) PutInput(input Input) {
+	for _, c := range input {
+		if col, ok := c.Data.(Column); ok {
+			p.Put(col)
+		}
+	}
+}
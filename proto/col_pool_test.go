@@ -0,0 +1,71 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnPool(t *testing.T) {
+	t.Parallel()
+	p := NewColumnPool()
+
+	col, err := p.Get(ColumnTypeArray.Sub(ColumnTypeString))
+	require.NoError(t, err)
+	arr, ok := col.(*ColArr[string])
+	require.True(t, ok)
+	arr.Append([]string{"foo", "bar"})
+	p.Put(arr)
+	require.Equal(t, 0, arr.Rows(), "should reset on put")
+
+	col, err = p.Get(ColumnTypeArray.Sub(ColumnTypeString))
+	require.NoError(t, err)
+	require.Equal(t, 0, col.Rows())
+	require.Equal(t, ColumnTypeArray.Sub(ColumnTypeString), col.Type())
+
+	_, err = p.Get("Unknown")
+	require.Error(t, err)
+
+	var nilPool *ColumnPool
+	col, err = nilPool.Get(ColumnTypeInt64)
+	require.NoError(t, err)
+	require.IsType(t, &ColInt64{}, col)
+	nilPool.Put(col)
+}
+
+func TestResults_AutoPool(t *testing.T) {
+	t.Parallel()
+	b := new(Buffer)
+	v := Block{Rows: 2, Columns: 2}
+	require.NoError(t, v.EncodeRawBlock(b, Version, []InputColumn{
+		{Name: "title", Data: colStr("Foo", "Bar")},
+		{Name: "data", Data: ColInt64{1, 2}},
+	}), "encode")
+
+	p := NewColumnPool()
+	var results Results
+	for i := 0; i < 3; i++ {
+		require.NoError(t, v.DecodeRawBlock(b.Reader(), Version, results.AutoPool(p)))
+		require.Len(t, results, 2)
+		require.Equal(t, "Bar", results[0].Data.(*ColStr).Row(1))
+		require.Equal(t, int64(2), results[1].Data.(*ColInt64).Row(1))
+		results.Release(p)
+		require.Empty(t, results)
+	}
+}
+
+func BenchmarkColumnPool(b *testing.B) {
+	p := NewColumnPool()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		col, err := p.Get(ColumnTypeNullable.Sub(ColumnTypeString))
+		if err != nil {
+			b.Fatal(err)
+		}
+		c := col.(*ColNullable[string])
+		for j := 0; j < 100; j++ {
+			c.Append(NewNullable("value"))
+		}
+		p.Put(col)
+	}
+}
@@ -0,0 +1,231 @@
+package proto
+
+import (
+	"encoding/binary"
+
+	"github.com/go-faster/errors"
+)
+
+// Sliceable is column that can return subset of its rows without copying
+// underlying data, e.g. to split received block into smaller chunks.
+type Sliceable interface {
+	// Slice returns rows [i, j) of column. Result shares memory with
+	// column, so column should not be modified while result is in use.
+	Slice(i, j int) ColInput
+}
+
+// Compile-time assertions for Sliceable.
+var (
+	_ Sliceable = ColInt64{}
+	_ Sliceable = ColStr{}
+	_ Sliceable = ColFixedStr{}
+	_ Sliceable = ColBool{}
+	_ Sliceable = ColUUID{}
+	_ Sliceable = ColDateTime{}
+	_ Sliceable = ColDateTime64{}
+	_ Sliceable = (*ColDecimal)(nil)
+)
+
+// innerSlicer is implemented by composite columns that are sliceable
+// only if their inner columns are.
+type innerSlicer interface {
+	sliceInput(i, j int) (ColInput, error)
+}
+
+// SliceColumn returns rows [i, j) of column without copying underlying
+// data.
+//
+// Supported columns are Sliceable ones and Array or Nullable of them.
+func SliceColumn(col ColInput, i, j int) (ColInput, error) {
+	if i < 0 || j < i || j > col.Rows() {
+		return nil, errors.Errorf("slice [%d:%d] out of range with %d rows", i, j, col.Rows())
+	}
+	switch v := col.(type) {
+	case Sliceable:
+		return v.Slice(i, j), nil
+	case innerSlicer:
+		return v.sliceInput(i, j)
+	default:
+		return nil, errors.Errorf("column %T (%s) is not sliceable", col, col.Type())
+	}
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColStr) Slice(i, j int) ColInput {
+	return ColStr{Buf: c.Buf, Pos: c.Pos[i:j:j]}
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColFixedStr) Slice(i, j int) ColInput {
+	return ColFixedStr{Buf: c.Buf[i*c.Size : j*c.Size : j*c.Size], Size: c.Size}
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColBool) Slice(i, j int) ColInput {
+	return c[i:j:j]
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColUUID) Slice(i, j int) ColInput {
+	return c[i:j:j]
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColDateTime) Slice(i, j int) ColInput {
+	c.Data = c.Data[i:j:j]
+	return c
+}
+
+// Slice returns rows [i, j) of column.
+func (c ColDateTime64) Slice(i, j int) ColInput {
+	c.Data = c.Data[i:j:j]
+	return c
+}
+
+// Slice returns rows [i, j) of column.
+func (c *ColDecimal) Slice(i, j int) ColInput {
+	// Every raw decimal column is generated, so it is Sliceable.
+	return c.Raw().(Sliceable).Slice(i, j)
+}
+
+func (c ColArr[T]) sliceInput(i, j int) (ColInput, error) {
+	var start, end uint64
+	if i > 0 {
+		start = c.Offsets[i-1]
+	}
+	if j > 0 {
+		end = c.Offsets[j-1]
+	}
+	data, err := SliceColumn(c.Data, int(start), int(end))
+	if err != nil {
+		return nil, errors.Wrap(err, "data")
+	}
+	return colArrSlice{
+		offsets: c.Offsets[i:j:j],
+		base:    start,
+		data:    data,
+	}, nil
+}
+
+// colArrSlice is Array(T) view with offsets relative to base.
+type colArrSlice struct {
+	offsets ColUInt64
+	base    uint64
+	data    ColInput
+}
+
+func (c colArrSlice) Type() ColumnType {
+	return ColumnTypeArray.Sub(c.data.Type())
+}
+
+func (c colArrSlice) Rows() int {
+	return len(c.offsets)
+}
+
+func (c colArrSlice) EncodeColumn(b *Buffer) {
+	for _, v := range c.offsets {
+		b.Buf = binary.LittleEndian.AppendUint64(b.Buf, v-c.base)
+	}
+	c.data.EncodeColumn(b)
+}
+
+func (c ColNullable[T]) sliceInput(i, j int) (ColInput, error) {
+	values, err := SliceColumn(c.Values, i, j)
+	if err != nil {
+		return nil, errors.Wrap(err, "values")
+	}
+	return colNullableSlice{
+		nulls:  c.Nulls[i:j:j],
+		values: values,
+	}, nil
+}
+
+// colNullableSlice is Nullable(T) view.
+type colNullableSlice struct {
+	nulls  ColUInt8
+	values ColInput
+}
+
+func (c colNullableSlice) Type() ColumnType {
+	return ColumnTypeNullable.Sub(c.values.Type())
+}
+
+func (c colNullableSlice) Rows() int {
+	return len(c.nulls)
+}
+
+func (c colNullableSlice) EncodeColumn(b *Buffer) {
+	c.nulls.EncodeColumn(b)
+	c.values.EncodeColumn(b)
+}
+
+// ColSlice is read-only ColumnOf view of rows [Start, End) of column,
+// e.g. to pass part of received block to worker without copying.
+type ColSlice[T any] struct {
+	Data       ColumnOf[T]
+	Start, End int
+}
+
+// NewColSlice returns view of rows [i, j) of column.
+func NewColSlice[T any](c ColumnOf[T], i, j int) ColSlice[T] {
+	if i < 0 || j < i || j > c.Rows() {
+		panic(errors.Errorf("slice [%d:%d] out of range with %d rows", i, j, c.Rows()))
+	}
+	return ColSlice[T]{Data: c, Start: i, End: j}
+}
+
+// Type returns type of underlying column.
+func (c ColSlice[T]) Type() ColumnType {
+	return c.Data.Type()
+}
+
+// Rows returns count of rows in view.
+func (c ColSlice[T]) Rows() int {
+	return c.End - c.Start
+}
+
+// Row returns i-th row of view.
+func (c ColSlice[T]) Row(i int) T {
+	return c.Data.Row(c.Start + i)
+}
+
+// Input returns view as ColInput, so it can be inserted.
+//
+// See SliceColumn for supported columns.
+func (c ColSlice[T]) Input() (ColInput, error) {
+	return SliceColumn(c.Data, c.Start, c.End)
+}
+
+// Slice returns header of block with rows [i, j).
+//
+// Use Input.Slice or Results.Slice to slice block data.
+func (b Block) Slice(i, j int) Block {
+	if i < 0 || j < i || j > b.Rows {
+		panic(errors.Errorf("slice [%d:%d] out of range with %d rows", i, j, b.Rows))
+	}
+	b.Rows = j - i
+	return b
+}
+
+// Slice returns rows [i, j) of all input columns without copying.
+func (i Input) Slice(start, end int) (Input, error) {
+	out := make(Input, 0, len(i))
+	for _, c := range i {
+		data, err := SliceColumn(c.Data, start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		out = append(out, InputColumn{Name: c.Name, Data: data})
+	}
+	return out, nil
+}
+
+// Slice returns rows [i, j) of all result columns as Input without
+// copying, e.g. to insert received block in smaller chunks.
+func (s Results) Slice(i, j int) (Input, error) {
+	input, err := s.Input()
+	if err != nil {
+		return nil, err
+	}
+	return input.Slice(i, j)
+}
@@ -0,0 +1,123 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceColumn(t *testing.T) {
+	t.Parallel()
+	var (
+		ints  = ColInt64{1, 2, 3, 4}
+		str   = new(ColStr)
+		fixed = NewFixedStr(2)
+		arr   = new(ColStr).Array()
+		null  = new(ColInt32).Nullable()
+		nest  = NewArray[[]Nullable[int32]](NewArray[Nullable[int32]](new(ColInt32).Nullable()))
+	)
+	str.AppendArr([]string{"a", "bb", "ccc", ""})
+	fixed.AppendArr([][]byte{[]byte("aa"), []byte("bb"), []byte("cc"), []byte("dd")})
+	arr.AppendArr([][]string{{"a"}, {"b", "c"}, {}, {"d", "e", "f"}})
+	null.AppendArr([]Nullable[int32]{Null[int32](), NewNullable[int32](1), Null[int32](), NewNullable[int32](3)})
+	nest.AppendArr([][][]Nullable[int32]{
+		{{NewNullable[int32](1)}},
+		{{Null[int32]()}, {}},
+		{},
+		{{NewNullable[int32](2), NewNullable[int32](3)}},
+	})
+	input := Input{
+		{Name: "ints", Data: ints},
+		{Name: "str", Data: str},
+		{Name: "fixed", Data: fixed},
+		{Name: "arr", Data: arr},
+		{Name: "null", Data: null},
+		{Name: "nest", Data: nest},
+	}
+
+	for _, tt := range []struct{ i, j int }{
+		{0, 4}, {1, 3}, {2, 2}, {3, 4}, {0, 1},
+	} {
+		got, err := input.Slice(tt.i, tt.j)
+		require.NoError(t, err)
+		require.Len(t, got, len(input))
+
+		// Slice should be encoded same as column with copied rows.
+		expected := []ColInput{
+			ints[tt.i:tt.j],
+			new(ColStr),
+			NewFixedStr(2),
+			new(ColStr).Array(),
+			new(ColInt32).Nullable(),
+			NewArray[[]Nullable[int32]](NewArray[Nullable[int32]](new(ColInt32).Nullable())),
+		}
+		for k := tt.i; k < tt.j; k++ {
+			expected[1].(*ColStr).Append(str.Row(k))
+			expected[2].(*ColFixedStr).Append(fixed.Row(k))
+			expected[3].(*ColArr[string]).Append(arr.Row(k))
+			expected[4].(*ColNullable[int32]).Append(null.Row(k))
+			expected[5].(*ColArr[[]Nullable[int32]]).Append(nest.Row(k))
+		}
+		for k, c := range got {
+			require.Equal(t, input[k].Name, c.Name)
+			require.Equal(t, expected[k].Type(), c.Data.Type(), c.Name)
+			require.Equal(t, tt.j-tt.i, c.Data.Rows(), c.Name)
+			var a, e Buffer
+			c.Data.EncodeColumn(&a)
+			expected[k].EncodeColumn(&e)
+			require.Equal(t, e.Buf, a.Buf, "%s [%d:%d]", c.Name, tt.i, tt.j)
+		}
+	}
+
+	_, err := SliceColumn(ints, 3, 5)
+	require.Error(t, err)
+	_, err = SliceColumn(new(ColStr).LowCardinality(), 0, 0)
+	require.Error(t, err)
+	_, err = SliceColumn(new(ColStr).LowCardinality().Array(), 0, 0)
+	require.Error(t, err)
+}
+
+func TestResults_Slice(t *testing.T) {
+	t.Parallel()
+	var (
+		title = colStr("Foo", "Bar", "Baz")
+		data  = ColInt64{1, 2, 3}
+	)
+	results := Results{
+		{Name: "title", Data: &title},
+		{Name: "data", Data: &data},
+	}
+	input, err := results.Slice(1, 3)
+	require.NoError(t, err)
+
+	v := Block{Rows: 3, Columns: 2}.Slice(1, 3)
+	require.Equal(t, 2, v.Rows)
+	b := new(Buffer)
+	require.NoError(t, v.EncodeRawBlock(b, Version, input))
+
+	var (
+		gotTitle ColStr
+		gotData  ColInt64
+	)
+	require.NoError(t, v.DecodeRawBlock(b.Reader(), Version, Results{
+		{Name: "title", Data: &gotTitle},
+		{Name: "data", Data: &gotData},
+	}))
+	require.Equal(t, []string{"Bar", "Baz"}, []string{gotTitle.Row(0), gotTitle.Row(1)})
+	require.Equal(t, ColInt64{2, 3}, gotData)
+}
+
+func TestColSlice(t *testing.T) {
+	t.Parallel()
+	col := new(ColStr)
+	col.AppendArr([]string{"a", "b", "c"})
+	v := NewColSlice[string](col, 1, 3)
+	require.Equal(t, ColumnTypeString, v.Type())
+	require.Equal(t, 2, v.Rows())
+	require.Equal(t, "b", v.Row(0))
+	require.Equal(t, "c", v.Row(1))
+	in, err := v.Input()
+	require.NoError(t, err)
+	require.Equal(t, 2, in.Rows())
+	require.Panics(t, func() { NewColSlice[string](col, 2, 4) })
+}
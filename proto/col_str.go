@@ -2,6 +2,7 @@ package proto
 
 import (
 	"encoding/binary"
+	"math"
 
 	"github.com/go-faster/errors"
 )
@@ -35,6 +36,49 @@ func (c *ColStr) AppendBytes(v []byte) {
 	c.Pos = append(c.Pos, Position{Start: start, End: end})
 }
 
+// AppendNoCopy appends byte slice as string to column without copying
+// if v was obtained from Alloc and no other rows were appended since,
+// otherwise v is copied like in AppendBytes.
+func (c *ColStr) AppendNoCopy(v []byte) {
+	start := len(c.Buf)
+	end := start + len(v)
+	if len(v) > 0 && end <= cap(c.Buf) && &c.Buf[:end][start] == &v[0] {
+		// Already written to arena tail, just take ownership.
+		c.Buf = c.Buf[:end]
+		c.Pos = append(c.Pos, Position{Start: start, End: end})
+		return
+	}
+	c.AppendBytes(v)
+}
+
+// Alloc returns n bytes of column buffer to write string into and then
+// append via AppendNoCopy, so all strings are stored in single buffer
+// without intermediate allocations.
+//
+// Returned slice is valid only until next append or Reset.
+func (c *ColStr) Alloc(n int) []byte {
+	c.Grow(0, n)
+	start := len(c.Buf)
+	return c.Buf[start : start+n : start+n]
+}
+
+// Grow grows column capacity to fit at least rows more strings with
+// total size of n bytes without reallocation.
+func (c *ColStr) Grow(rows, n int) {
+	if free := cap(c.Pos) - len(c.Pos); free < rows {
+		c.Pos = append(c.Pos[:cap(c.Pos)], make([]Position, rows-free)...)[:len(c.Pos)]
+	}
+	if free := cap(c.Buf) - len(c.Buf); free < n {
+		c.Buf = append(c.Buf[:cap(c.Buf)], make([]byte, n-free)...)[:len(c.Buf)]
+	}
+}
+
+// Cap returns capacity of column as number of rows and bytes that can be
+// stored without reallocation.
+func (c ColStr) Cap() (rows, n int) {
+	return cap(c.Pos), cap(c.Buf)
+}
+
 func (c *ColStr) AppendArr(v []string) {
 	for _, e := range v {
 		c.Append(e)
@@ -100,6 +144,16 @@ func (c ColStr) RowBytes(i int) []byte {
 	return c.Buf[p.Start:p.End]
 }
 
+// RowBytesNoCopy returns row with number i as byte slice that references
+// column buffer, same as RowBytes.
+//
+// Result is valid only until Reset or DecodeColumn, so it should not be
+// retained or modified.
+func (c ColStr) RowBytesNoCopy(i int) []byte {
+	p := c.Pos[i]
+	return c.Buf[p.Start:p.End:p.End]
+}
+
 // ForEachBytes calls f on each string from column as byte slice.
 func (c ColStr) ForEachBytes(f func(i int, b []byte) error) error {
 	for i, p := range c.Pos {
@@ -125,14 +179,28 @@ func (c *ColStr) DecodeColumn(r *Reader, rows int) error {
 			return errors.Wrapf(err, "row %d: read length", i)
 		}
 
+		if n > math.MaxInt-p.End {
+			return errors.Errorf("row %d: size %d is too big", i, n)
+		}
+
 		p.Start = p.End
 		p.End += n
 
+		if len(c.Buf) < p.End && n > maxPrealloc {
+			// Big string, grow buffer while reading to not trust
+			// the length blindly.
+			c.Buf, err = r.appendFull(c.Buf[:p.Start], n)
+			if err != nil {
+				return errors.Wrapf(err, "row %d: read full", i)
+			}
+			c.Pos = append(c.Pos, p)
+			continue
+		}
 		if len(c.Buf) < p.End {
 			var an int
 			if n < 128 {
 				// small size, do batch buffer alloc
-				an = n * (rows - i)
+				an = min(n*(rows-i), maxPrealloc)
 			} else {
 				an = n
 			}
@@ -0,0 +1,10 @@
+//go:build !(amd64 || arm64 || riscv64) || purego
+
+package proto
+
+// RowNoCopy returns row with number i as string.
+//
+// In purego builds string is copied, so it is equivalent to Row.
+func (c ColStr) RowNoCopy(i int) string {
+	return c.Row(i)
+}
@@ -3,6 +3,7 @@ package proto
 import (
 	"bytes"
 	"io"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -148,3 +149,82 @@ func BenchmarkColStr_EncodeColumn(b *testing.B) {
 		data.EncodeColumn(&buf)
 	}
 }
+
+func TestColStr_AppendNoCopy(t *testing.T) {
+	var data ColStr
+	data.Grow(3, 64)
+	rows, n := data.Cap()
+	require.GreaterOrEqual(t, rows, 3)
+	require.GreaterOrEqual(t, n, 64)
+	buf := data.Buf
+
+	b := data.Alloc(5)
+	copy(b, "Hello")
+	data.AppendNoCopy(b)
+	b = data.Alloc(10)
+	data.AppendNoCopy(append(b[:0], "World"...))
+	data.AppendNoCopy([]byte("copied"))
+	data.AppendNoCopy(nil)
+
+	require.Equal(t, 4, data.Rows())
+	require.Equal(t, &buf[:1][0], &data.Buf[0], "buffer should not be reallocated")
+	for i, s := range []string{"Hello", "World", "copied", ""} {
+		require.Equal(t, s, data.Row(i))
+		require.Equal(t, s, data.RowNoCopy(i))
+		require.Equal(t, s, string(data.RowBytesNoCopy(i)))
+	}
+
+	var expected ColStr
+	expected.AppendArr([]string{"Hello", "World", "copied", ""})
+	var a, e Buffer
+	data.EncodeColumn(&a)
+	expected.EncodeColumn(&e)
+	require.Equal(t, e.Buf, a.Buf)
+}
+
+func BenchmarkColStr_AppendNoCopy(b *testing.B) {
+	const rows = 1_000
+	value := []byte("2024-01-01T00:00:00Z INFO request handled")
+	var data ColStr
+	b.ReportAllocs()
+	b.SetBytes(rows * int64(len(value)))
+	for i := 0; i < b.N; i++ {
+		data.Reset()
+		data.Grow(rows, rows*len(value))
+		for j := 0; j < rows; j++ {
+			data.AppendNoCopy(append(data.Alloc(len(value))[:0], value...))
+		}
+	}
+}
+
+func TestColStr_DecodeColumnBigSize(t *testing.T) {
+	t.Run("Corrupted", func(t *testing.T) {
+		// Length is 1<<62-1.
+		data := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x3f}
+		var dec ColStr
+		var limitErr *LimitError
+		require.ErrorAs(t, dec.DecodeColumn(NewReader(bytes.NewReader(data)), 1), &limitErr)
+		require.Equal(t, "MaxStrLen", limitErr.Limit)
+
+		// Should not be allocated before reading even without limit.
+		r := NewReader(bytes.NewReader(data))
+		r.SetLimits(Limits{MaxStrLen: math.MaxInt})
+		require.ErrorIs(t, dec.DecodeColumn(r, 1), io.EOF)
+	})
+	t.Run("Ok", func(t *testing.T) {
+		var data ColStr
+		data.Append("foo")
+		data.AppendBytes(bytes.Repeat([]byte{'a'}, maxPrealloc*2+10))
+		data.Append("bar")
+
+		var buf Buffer
+		data.EncodeColumn(&buf)
+
+		var dec ColStr
+		require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), data.Rows()))
+		require.Equal(t, data.Rows(), dec.Rows())
+		for i := 0; i < data.Rows(); i++ {
+			require.Equal(t, data.Row(i), dec.Row(i))
+		}
+	})
+}
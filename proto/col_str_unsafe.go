@@ -0,0 +1,18 @@
+//go:build (amd64 || arm64 || riscv64) && !purego
+
+package proto
+
+import "unsafe"
+
+// RowNoCopy returns row with number i as string that references column
+// buffer without copying.
+//
+// Result is valid only until Reset or DecodeColumn, because buffer is
+// reused, so it should not be retained after that.
+func (c ColStr) RowNoCopy(i int) string {
+	b := c.RowBytesNoCopy(i)
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b)) // #nosec G103
+}
@@ -1,6 +1,10 @@
 package proto
 
-import "github.com/go-faster/errors"
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
 
 // ColTuple is Tuple column.
 //
@@ -106,17 +110,125 @@ func (c ColTuple) Prepare() error {
 	return nil
 }
 
+// Infer propagates element types of Tuple(T1, T2, ...) to elements.
 func (c ColTuple) Infer(t ColumnType) error {
-	for _, v := range c {
+	elems := t.elems()
+	if len(elems) != len(c) {
+		return errors.Errorf("got %d elements, expected %d", len(elems), len(c))
+	}
+	for i, v := range c {
 		if s, ok := v.(Inferable); ok {
-			if err := s.Infer(t); err != nil {
-				return errors.Wrap(err, "infer")
+			_, elemType := tupleElem(elems[i])
+			if err := s.Infer(elemType); err != nil {
+				return errors.Wrapf(err, "infer [%d]", i)
 			}
 		}
 	}
 	return nil
 }
 
+// Field returns element with provided name, if any.
+//
+// Named elements are ColNamed or elements of inferred named tuple.
+func (c ColTuple) Field(name string) (Column, bool) {
+	for _, v := range c {
+		if n, ok := v.(interface{ ColumnName() string }); ok && n.ColumnName() == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns names of elements, or blank names for unnamed elements.
+func (c ColTuple) Names() []string {
+	var names []string
+	for _, v := range c {
+		var name string
+		if n, ok := v.(interface{ ColumnName() string }); ok {
+			name = n.ColumnName()
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// tupleElem splits tuple element type to name and type, e.g.
+// "a UInt8" to "a" and "UInt8". Name is blank for unnamed element.
+func tupleElem(t ColumnType) (string, ColumnType) {
+	s := string(t)
+	if strings.HasPrefix(s, "`") {
+		end := strings.Index(s[1:], "`")
+		if end < 0 {
+			return "", t
+		}
+		return s[1 : end+1], ColumnType(strings.TrimSpace(s[end+2:]))
+	}
+	space := strings.IndexByte(s, ' ')
+	if space < 0 {
+		return "", t
+	}
+	if paren := strings.IndexByte(s, '('); paren >= 0 && paren < space {
+		// Space is inside of type parameters.
+		return "", t
+	}
+	return s[:space], ColumnType(strings.TrimSpace(s[space+1:]))
+}
+
+// inferTuple creates ColTuple of ColAuto elements from Tuple type.
+func inferTuple(t ColumnType) (ColTuple, error) {
+	var c ColTuple
+	for i, e := range t.elems() {
+		name, elemType := tupleElem(e)
+		v := new(ColAuto)
+		if err := v.Infer(elemType); err != nil {
+			return nil, errors.Wrapf(err, "[%d]", i)
+		}
+		if name == "" {
+			c = append(c, v)
+			continue
+		}
+		c = append(c, &colNamedAuto{ColAuto: v, name: name})
+	}
+	return c, nil
+}
+
+// colNamedAuto is named ColAuto element of tuple.
+type colNamedAuto struct {
+	*ColAuto
+	name string
+}
+
+func (c colNamedAuto) ColumnName() string {
+	return c.name
+}
+
+func (c colNamedAuto) Type() ColumnType {
+	name := c.name
+	if !isIdentifier(name) {
+		name = "`" + name + "`"
+	}
+	return ColumnType(name + " " + c.ColAuto.Type().String())
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (c *colNamedAuto) Infer(t ColumnType) error {
+	return c.ColAuto.Infer(t)
+}
+
 func (c ColTuple) EncodeState(b *Buffer) {
 	for _, v := range c {
 		if s, ok := v.(StateEncoder); ok {
@@ -0,0 +1,145 @@
+package proto
+
+import "github.com/go-faster/errors"
+
+// Tuple2 is value of Tuple(A, B).
+type Tuple2[A, B any] struct {
+	V1 A
+	V2 B
+}
+
+// Tuple3 is value of Tuple(A, B, C).
+type Tuple3[A, B, C any] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+// ColTuple2 is Tuple(A, B) column of typed elements.
+//
+// Use Named elements for named tuple, e.g. Tuple(a String, b Int64).
+type ColTuple2[A, B any] struct {
+	C1 ColumnOf[A]
+	C2 ColumnOf[B]
+}
+
+// NewTuple2 returns new Tuple(A, B) column.
+func NewTuple2[A, B any](c1 ColumnOf[A], c2 ColumnOf[B]) *ColTuple2[A, B] {
+	return &ColTuple2[A, B]{C1: c1, C2: c2}
+}
+
+// Compile-time assertions for ColTuple2.
+var (
+	_ ColumnOf[Tuple2[string, int64]] = NewTuple2[string, int64](nil, nil)
+	_ Stateful                        = NewTuple2[string, int64](nil, nil)
+	_ Inferable                       = NewTuple2[string, int64](nil, nil)
+	_ Preparable                      = NewTuple2[string, int64](nil, nil)
+)
+
+func (c ColTuple2[A, B]) tuple() ColTuple {
+	return ColTuple{c.C1, c.C2}
+}
+
+func (c ColTuple2[A, B]) Type() ColumnType             { return c.tuple().Type() }
+func (c ColTuple2[A, B]) Rows() int                    { return c.C1.Rows() }
+func (c ColTuple2[A, B]) EncodeColumn(b *Buffer)       { c.tuple().EncodeColumn(b) }
+func (c ColTuple2[A, B]) EncodeState(b *Buffer)        { c.tuple().EncodeState(b) }
+func (c *ColTuple2[A, B]) DecodeState(r *Reader) error { return c.tuple().DecodeState(r) }
+func (c *ColTuple2[A, B]) Infer(t ColumnType) error    { return c.tuple().Infer(t) }
+func (c *ColTuple2[A, B]) Prepare() error              { return c.tuple().Prepare() }
+func (c *ColTuple2[A, B]) Reset()                      { c.tuple().Reset() }
+func (c *ColTuple2[A, B]) DecodeColumn(r *Reader, rows int) error {
+	if err := c.tuple().DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "tuple")
+	}
+	return nil
+}
+
+func (c *ColTuple2[A, B]) Append(v Tuple2[A, B]) {
+	c.C1.Append(v.V1)
+	c.C2.Append(v.V2)
+}
+
+func (c *ColTuple2[A, B]) AppendArr(v []Tuple2[A, B]) {
+	for _, e := range v {
+		c.Append(e)
+	}
+}
+
+func (c ColTuple2[A, B]) Row(i int) Tuple2[A, B] {
+	return Tuple2[A, B]{
+		V1: c.C1.Row(i),
+		V2: c.C2.Row(i),
+	}
+}
+
+// Array is helper that creates Array(Tuple(A, B)).
+func (c *ColTuple2[A, B]) Array() *ColArr[Tuple2[A, B]] {
+	return &ColArr[Tuple2[A, B]]{Data: c}
+}
+
+// ColTuple3 is Tuple(A, B, C) column of typed elements.
+//
+// Use Named elements for named tuple, e.g. Tuple(a String, b Int64, c UUID).
+type ColTuple3[A, B, C any] struct {
+	C1 ColumnOf[A]
+	C2 ColumnOf[B]
+	C3 ColumnOf[C]
+}
+
+// NewTuple3 returns new Tuple(A, B, C) column.
+func NewTuple3[A, B, C any](c1 ColumnOf[A], c2 ColumnOf[B], c3 ColumnOf[C]) *ColTuple3[A, B, C] {
+	return &ColTuple3[A, B, C]{C1: c1, C2: c2, C3: c3}
+}
+
+// Compile-time assertions for ColTuple3.
+var (
+	_ ColumnOf[Tuple3[string, int64, bool]] = NewTuple3[string, int64, bool](nil, nil, nil)
+	_ Stateful                              = NewTuple3[string, int64, bool](nil, nil, nil)
+	_ Inferable                             = NewTuple3[string, int64, bool](nil, nil, nil)
+	_ Preparable                            = NewTuple3[string, int64, bool](nil, nil, nil)
+)
+
+func (c ColTuple3[A, B, C]) tuple() ColTuple {
+	return ColTuple{c.C1, c.C2, c.C3}
+}
+
+func (c ColTuple3[A, B, C]) Type() ColumnType             { return c.tuple().Type() }
+func (c ColTuple3[A, B, C]) Rows() int                    { return c.C1.Rows() }
+func (c ColTuple3[A, B, C]) EncodeColumn(b *Buffer)       { c.tuple().EncodeColumn(b) }
+func (c ColTuple3[A, B, C]) EncodeState(b *Buffer)        { c.tuple().EncodeState(b) }
+func (c *ColTuple3[A, B, C]) DecodeState(r *Reader) error { return c.tuple().DecodeState(r) }
+func (c *ColTuple3[A, B, C]) Infer(t ColumnType) error    { return c.tuple().Infer(t) }
+func (c *ColTuple3[A, B, C]) Prepare() error              { return c.tuple().Prepare() }
+func (c *ColTuple3[A, B, C]) Reset()                      { c.tuple().Reset() }
+func (c *ColTuple3[A, B, C]) DecodeColumn(r *Reader, rows int) error {
+	if err := c.tuple().DecodeColumn(r, rows); err != nil {
+		return errors.Wrap(err, "tuple")
+	}
+	return nil
+}
+
+func (c *ColTuple3[A, B, C]) Append(v Tuple3[A, B, C]) {
+	c.C1.Append(v.V1)
+	c.C2.Append(v.V2)
+	c.C3.Append(v.V3)
+}
+
+func (c *ColTuple3[A, B, C]) AppendArr(v []Tuple3[A, B, C]) {
+	for _, e := range v {
+		c.Append(e)
+	}
+}
+
+func (c ColTuple3[A, B, C]) Row(i int) Tuple3[A, B, C] {
+	return Tuple3[A, B, C]{
+		V1: c.C1.Row(i),
+		V2: c.C2.Row(i),
+		V3: c.C3.Row(i),
+	}
+}
+
+// Array is helper that creates Array(Tuple(A, B, C)).
+func (c *ColTuple3[A, B, C]) Array() *ColArr[Tuple3[A, B, C]] {
+	return &ColArr[Tuple3[A, B, C]]{Data: c}
+}
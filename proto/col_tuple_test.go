@@ -179,3 +179,77 @@ func BenchmarkColTuple_EncodeColumn(b *testing.B) {
 		data.EncodeColumn(&buf)
 	}
 }
+
+func TestTupleElem(t *testing.T) {
+	for _, tt := range []struct {
+		Input ColumnType
+		Name  string
+		Type  ColumnType
+	}{
+		{Input: "String", Type: "String"},
+		{Input: "Decimal(9, 2)", Type: "Decimal(9, 2)"},
+		{Input: "a UInt8", Name: "a", Type: "UInt8"},
+		{Input: "b Map(String, UInt64)", Name: "b", Type: "Map(String, UInt64)"},
+		{Input: "`a b` Int64", Name: "a b", Type: "Int64"},
+	} {
+		name, typ := tupleElem(tt.Input)
+		require.Equal(t, tt.Name, name, tt.Input)
+		require.Equal(t, tt.Type, typ, tt.Input)
+	}
+}
+
+func TestColTuple_Field(t *testing.T) {
+	var auto ColAuto
+	require.NoError(t, auto.Infer("Tuple(a UInt8, b String)"))
+	tuple := auto.Data.(ColTuple)
+	require.Equal(t, []string{"a", "b"}, tuple.Names())
+	require.Equal(t, ColumnType("Tuple(a UInt8, b String)"), tuple.Type())
+
+	b, ok := tuple.Field("b")
+	require.True(t, ok)
+	require.Equal(t, ColumnType("b String"), b.Type())
+	_, ok = tuple.Field("c")
+	require.False(t, ok)
+
+	// Decoding named tuple with inferred elements.
+	var buf Buffer
+	ColUInt8{1, 2}.EncodeColumn(&buf)
+	str := new(ColStr)
+	str.AppendArr([]string{"foo", "bar"})
+	str.EncodeColumn(&buf)
+
+	require.NoError(t, tuple.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), 2))
+	require.Equal(t, 2, tuple.Rows())
+	b, _ = tuple.Field("b")
+	require.Equal(t, "bar", b.(*colNamedAuto).Data.(*ColStr).Row(1))
+
+	t.Run("InferMismatch", func(t *testing.T) {
+		require.Error(t, ColTuple{new(ColStr)}.Infer("Tuple(String, String)"))
+	})
+}
+
+func TestColTuple2(t *testing.T) {
+	data := NewTuple2[string, int64](Named[string](new(ColStr), "s"), new(ColInt64))
+	require.Equal(t, ColumnType("Tuple(s String, Int64)"), data.Type())
+	values := []Tuple2[string, int64]{
+		{V1: "foo", V2: 1},
+		{V1: "bar", V2: 2},
+	}
+	data.AppendArr(values)
+
+	var buf Buffer
+	data.EncodeColumn(&buf)
+
+	dec := NewTuple2[string, int64](Named[string](new(ColStr), "s"), new(ColInt64))
+	require.NoError(t, dec.Infer(data.Type()))
+	require.NoError(t, dec.DecodeColumn(NewReader(bytes.NewReader(buf.Buf)), len(values)))
+	requireEqual[Tuple2[string, int64]](t, data, dec)
+	dec.Reset()
+	require.Equal(t, 0, dec.Rows())
+}
+
+func TestColTuple3(t *testing.T) {
+	testColumn[Tuple3[string, int64, bool]](t, "tuple3", func() ColumnOf[Tuple3[string, int64, bool]] {
+		return NewTuple3[string, int64, bool](new(ColStr), new(ColInt64), new(ColBool))
+	}, Tuple3[string, int64, bool]{V1: "foo", V2: 1, V3: true}, Tuple3[string, int64, bool]{V1: "bar", V2: -1})
+}
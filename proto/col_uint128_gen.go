@@ -27,6 +27,21 @@ func (ColUInt128) Type() ColumnType {
 	return ColumnTypeUInt128
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt128) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt128.
+func (c *ColUInt128) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt128](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt128) Row(i int) UInt128 {
 	return c[i]
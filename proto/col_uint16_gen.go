@@ -27,6 +27,21 @@ func (ColUInt16) Type() ColumnType {
 	return ColumnTypeUInt16
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt16) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt16.
+func (c *ColUInt16) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt16](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt16) Row(i int) uint16 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColUInt256) Type() ColumnType {
 	return ColumnTypeUInt256
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt256) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt256.
+func (c *ColUInt256) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt256](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt256) Row(i int) UInt256 {
 	return c[i]
@@ -27,6 +27,21 @@ func (ColUInt32) Type() ColumnType {
 	return ColumnTypeUInt32
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt32) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt32.
+func (c *ColUInt32) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt32](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt32) Row(i int) uint32 {
 	return c[i]
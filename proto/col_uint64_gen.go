@@ -27,6 +27,21 @@ func (ColUInt64) Type() ColumnType {
 	return ColumnTypeUInt64
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt64) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt64.
+func (c *ColUInt64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt64](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt64) Row(i int) uint64 {
 	return c[i]
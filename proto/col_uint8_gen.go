@@ -27,6 +27,21 @@ func (ColUInt8) Type() ColumnType {
 	return ColumnTypeUInt8
 }
 
+// Slice returns rows [i, j) of column without copying.
+func (c ColUInt8) Slice(i, j int) ColInput {
+	return c[i:j]
+}
+
+// appendColumn appends rows [i, j) of src to column if it is ColUInt8.
+func (c *ColUInt8) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUInt8](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
 // Row returns i-th row of column.
 func (c ColUInt8) Row(i int) uint8 {
 	return c[i]
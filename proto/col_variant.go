@@ -0,0 +1,283 @@
+package proto
+
+import (
+	"github.com/go-faster/errors"
+)
+
+// VariantNullDiscriminator is discriminator of NULL row in Variant column.
+const VariantNullDiscriminator uint8 = 255
+
+// Discriminators serialization modes.
+const (
+	variantModeBasic   uint64 = 0
+	variantModeCompact uint64 = 1
+)
+
+// Granule formats of compact discriminators serialization.
+const (
+	variantGranulePlain   uint8 = 0
+	variantGranuleCompact uint8 = 1
+)
+
+// ColVariant represents Variant(T1, T2, ..., Tn) column.
+//
+// Each row has discriminator, that is index of variant in Variants,
+// or VariantNullDiscriminator for NULL. Variant columns contain only
+// values of rows with corresponding discriminator, in order of rows.
+//
+// Variants should be in the same order as in column type: ClickHouse
+// sorts variant types by name, e.g. Variant(UInt64, String) is
+// Variant(String, UInt64).
+type ColVariant struct {
+	Variants       []Column
+	Discriminators []uint8
+
+	offsets []int // row index in variant column
+	counts  []int // rows per variant
+	compact bool  // compact discriminators serialization
+}
+
+// NewVariant returns new Variant(T1, T2, ..., Tn) from variant columns.
+func NewVariant(variants ...Column) *ColVariant {
+	return &ColVariant{
+		Variants: variants,
+	}
+}
+
+// Compile-time assertions for ColVariant.
+var (
+	_ ColInput  = (*ColVariant)(nil)
+	_ ColResult = (*ColVariant)(nil)
+	_ Column    = (*ColVariant)(nil)
+	_ Stateful  = (*ColVariant)(nil)
+	_ Inferable = (*ColVariant)(nil)
+)
+
+func (c ColVariant) Type() ColumnType {
+	var types []ColumnType
+	for _, v := range c.Variants {
+		types = append(types, v.Type())
+	}
+	return ColumnTypeVariant.Sub(types...)
+}
+
+func (c ColVariant) Rows() int {
+	return len(c.Discriminators)
+}
+
+func (c *ColVariant) Reset() {
+	for _, v := range c.Variants {
+		v.Reset()
+	}
+	c.Discriminators = c.Discriminators[:0]
+	c.offsets = c.offsets[:0]
+	c.counts = c.counts[:0]
+}
+
+// Infer initializes variants from column type.
+//
+// If Variants is empty, it is populated with ColAuto columns.
+func (c *ColVariant) Infer(t ColumnType) error {
+	elems := t.elems()
+	if len(c.Variants) == 0 {
+		for _, e := range elems {
+			v := new(ColAuto)
+			if err := v.Infer(e); err != nil {
+				return errors.Wrapf(err, "variant %q", e)
+			}
+			c.Variants = append(c.Variants, v)
+		}
+		return nil
+	}
+	if len(elems) != len(c.Variants) {
+		return errors.Errorf("got %d variants, expected %d", len(elems), len(c.Variants))
+	}
+	for i, v := range c.Variants {
+		if s, ok := v.(Inferable); ok {
+			if err := s.Infer(elems[i]); err != nil {
+				return errors.Wrapf(err, "variant [%d]", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Discriminator returns discriminator of i-th row.
+func (c ColVariant) Discriminator(i int) uint8 {
+	return c.Discriminators[i]
+}
+
+// Offset returns index of i-th row in variant column, or -1 for NULL.
+func (c ColVariant) Offset(i int) int {
+	if c.Discriminators[i] == VariantNullDiscriminator {
+		return -1
+	}
+	return c.offsets[i]
+}
+
+// Variant returns variant column of i-th row and index of row in it.
+//
+// Returns nil column for NULL row.
+func (c ColVariant) Variant(i int) (Column, int) {
+	d := c.Discriminators[i]
+	if d == VariantNullDiscriminator {
+		return nil, -1
+	}
+	return c.Variants[d], c.offsets[i]
+}
+
+// AppendNull appends NULL row.
+func (c *ColVariant) AppendNull() {
+	c.Discriminators = append(c.Discriminators, VariantNullDiscriminator)
+	c.offsets = append(c.offsets, -1)
+}
+
+// AppendDiscriminator appends row of variant d.
+//
+// Corresponding value should be appended to Variants[d] separately,
+// see AppendVariant.
+func (c *ColVariant) AppendDiscriminator(d uint8) {
+	if d == VariantNullDiscriminator {
+		c.AppendNull()
+		return
+	}
+	for len(c.counts) <= int(d) {
+		c.counts = append(c.counts, 0)
+	}
+	c.Discriminators = append(c.Discriminators, d)
+	c.offsets = append(c.offsets, c.counts[d])
+	c.counts[d]++
+}
+
+// AppendVariant appends v as value of variant d.
+func AppendVariant[T any](c *ColVariant, d uint8, v T) error {
+	if int(d) >= len(c.Variants) {
+		return errors.Errorf("variant %d not found", d)
+	}
+	col, ok := c.Variants[d].(interface{ Append(v T) })
+	if !ok {
+		return errors.Errorf("variant %d (%s) is %T, can't append %T",
+			d, c.Variants[d].Type(), c.Variants[d], v,
+		)
+	}
+	col.Append(v)
+	c.AppendDiscriminator(d)
+	return nil
+}
+
+func (c ColVariant) EncodeState(b *Buffer) {
+	b.PutUInt64(variantModeBasic)
+	for _, v := range c.Variants {
+		if s, ok := v.(StateEncoder); ok {
+			s.EncodeState(b)
+		}
+	}
+}
+
+func (c *ColVariant) DecodeState(r *Reader) error {
+	mode, err := r.UInt64()
+	if err != nil {
+		return errors.Wrap(err, "discriminators mode")
+	}
+	switch mode {
+	case variantModeBasic, variantModeCompact:
+		c.compact = mode == variantModeCompact
+	default:
+		return errors.Errorf("unknown discriminators mode %d", mode)
+	}
+	for i, v := range c.Variants {
+		if s, ok := v.(StateDecoder); ok {
+			if err := s.DecodeState(r); err != nil {
+				return errors.Wrapf(err, "variant [%d]", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (c ColVariant) EncodeColumn(b *Buffer) {
+	if b == nil {
+		return
+	}
+	b.Buf = append(b.Buf, c.Discriminators...)
+	for _, v := range c.Variants {
+		v.EncodeColumn(b)
+	}
+}
+
+func (c *ColVariant) decodeDiscriminators(r *Reader, rows int) error {
+	if !c.compact {
+		data, err := r.ReadRaw(rows)
+		if err != nil {
+			return errors.Wrap(err, "read")
+		}
+		c.Discriminators = append(c.Discriminators, data...)
+		return nil
+	}
+	for rows > 0 {
+		n, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrap(err, "granule size")
+		}
+		if n == 0 || n > uint64(rows) {
+			return errors.Errorf("invalid granule size %d", n)
+		}
+		format, err := r.UInt8()
+		if err != nil {
+			return errors.Wrap(err, "granule format")
+		}
+		switch format {
+		case variantGranulePlain:
+			data, err := r.ReadRaw(int(n))
+			if err != nil {
+				return errors.Wrap(err, "read")
+			}
+			c.Discriminators = append(c.Discriminators, data...)
+		case variantGranuleCompact:
+			d, err := r.UInt8()
+			if err != nil {
+				return errors.Wrap(err, "discriminator")
+			}
+			for i := uint64(0); i < n; i++ {
+				c.Discriminators = append(c.Discriminators, d)
+			}
+		default:
+			return errors.Errorf("unknown granule format %d", format)
+		}
+		rows -= int(n)
+	}
+	return nil
+}
+
+func (c *ColVariant) DecodeColumn(r *Reader, rows int) error {
+	start := len(c.Discriminators)
+	if err := c.decodeDiscriminators(r, rows); err != nil {
+		return errors.Wrap(err, "discriminators")
+	}
+	for len(c.counts) < len(c.Variants) {
+		c.counts = append(c.counts, 0)
+	}
+	// Counting rows of each variant in this block.
+	blockCounts := make([]int, len(c.Variants))
+	for _, d := range c.Discriminators[start:] {
+		if d == VariantNullDiscriminator {
+			c.offsets = append(c.offsets, -1)
+			continue
+		}
+		if int(d) >= len(c.Variants) {
+			return errors.Errorf("unexpected discriminator %d", d)
+		}
+		c.offsets = append(c.offsets, c.counts[d])
+		c.counts[d]++
+		blockCounts[d]++
+	}
+	for i, v := range c.Variants {
+		if blockCounts[i] == 0 {
+			continue
+		}
+		if err := v.DecodeColumn(r, blockCounts[i]); err != nil {
+			return errors.Wrapf(err, "variant [%d]", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/internal/gold"
+)
+
+func TestColVariant(t *testing.T) {
+	data := NewVariant(new(ColStr), new(ColUInt64))
+	require.Equal(t, ColumnType("Variant(String, UInt64)"), data.Type())
+
+	require.NoError(t, AppendVariant[string](data, 0, "foo"))
+	require.NoError(t, AppendVariant[uint64](data, 1, 10))
+	data.AppendNull()
+	require.NoError(t, AppendVariant[string](data, 0, "bar"))
+	require.Error(t, AppendVariant[string](data, 1, "baz"))
+	require.Error(t, AppendVariant[string](data, 2, "baz"))
+	require.Equal(t, 4, data.Rows())
+
+	var buf Buffer
+	data.EncodeState(&buf)
+	data.EncodeColumn(&buf)
+
+	t.Run("Golden", func(t *testing.T) {
+		gold.Bytes(t, buf.Buf, "col_variant")
+	})
+	t.Run("Ok", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+		dec := NewVariant(new(ColStr), new(ColUInt64))
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, data.Rows()))
+		require.Equal(t, data.Discriminators, dec.Discriminators)
+
+		col, idx := dec.Variant(3)
+		require.Equal(t, "bar", col.(*ColStr).Row(idx))
+		col, idx = dec.Variant(1)
+		require.Equal(t, uint64(10), col.(*ColUInt64).Row(idx))
+		col, idx = dec.Variant(2)
+		require.Nil(t, col)
+		require.Equal(t, -1, idx)
+		require.Equal(t, VariantNullDiscriminator, dec.Discriminator(2))
+		require.Equal(t, 1, dec.Offset(3))
+
+		dec.Reset()
+		require.Equal(t, 0, dec.Rows())
+		require.Equal(t, 0, dec.Variants[0].Rows())
+	})
+	t.Run("Auto", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf))
+		dec := new(ColAuto)
+		require.NoError(t, dec.Infer(data.Type()))
+		require.NoError(t, dec.DecodeState(r))
+		require.NoError(t, dec.DecodeColumn(r, data.Rows()))
+		require.Equal(t, data.Type(), dec.Type())
+		require.Equal(t, data.Rows(), dec.Rows())
+	})
+	t.Run("ErrUnexpectedEOF", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(buf.Buf[:len(buf.Buf)-1]))
+		dec := NewVariant(new(ColStr), new(ColUInt64))
+		require.NoError(t, dec.DecodeState(r))
+		require.Error(t, dec.DecodeColumn(r, data.Rows()))
+	})
+}
+
+func TestColVariant_DecodeCompact(t *testing.T) {
+	var buf Buffer
+	buf.PutUInt64(variantModeCompact)
+	// Granule of 2 rows with same discriminator.
+	buf.PutUVarInt(2)
+	buf.PutUInt8(variantGranuleCompact)
+	buf.PutUInt8(1)
+	// Granule of 2 rows with different discriminators.
+	buf.PutUVarInt(2)
+	buf.PutUInt8(variantGranulePlain)
+	buf.PutRaw([]byte{0, VariantNullDiscriminator})
+	// Variant values.
+	str := new(ColStr)
+	str.Append("foo")
+	str.EncodeColumn(&buf)
+	ints := new(ColUInt64)
+	ints.AppendArr([]uint64{1, 2})
+	ints.EncodeColumn(&buf)
+
+	r := NewReader(bytes.NewReader(buf.Buf))
+	dec := NewVariant(new(ColStr), new(ColUInt64))
+	require.NoError(t, dec.DecodeState(r))
+	require.NoError(t, dec.DecodeColumn(r, 4))
+	require.Equal(t, []uint8{1, 1, 0, VariantNullDiscriminator}, dec.Discriminators)
+	require.Equal(t, []int{0, 1, 0, -1}, dec.offsets)
+	require.Equal(t, str, dec.Variants[0])
+	require.Equal(t, ints, dec.Variants[1])
+}
@@ -87,6 +87,13 @@ func (c ColumnType) Conflicts(b ColumnType) bool {
 	if c == b {
 		return false
 	}
+	// SimpleAggregateFunction(f, T) is transparent wrapper of T.
+	if inner, ok := c.simpleAggregateType(); ok {
+		return inner.Conflicts(b)
+	}
+	if inner, ok := b.simpleAggregateType(); ok {
+		return c.Conflicts(inner)
+	}
 	{
 		a := c
 		if b.Base() == ColumnTypeEnum8 || b.Base() == ColumnTypeEnum16 {
@@ -105,6 +112,9 @@ func (c ColumnType) Conflicts(b ColumnType) bool {
 	if c.normalizeCommas() == b.normalizeCommas() {
 		return false
 	}
+	if c.Normalize() == b.Normalize() {
+		return false
+	}
 	switch c.Base() {
 	case ColumnTypeDateTime, ColumnTypeDateTime64:
 		// TODO(ernado): improve check
@@ -159,6 +169,55 @@ func (c ColumnType) Elem() ColumnType {
 	return c[start+1 : end]
 }
 
+// simpleAggregateType returns T of SimpleAggregateFunction(f, T).
+func (c ColumnType) simpleAggregateType() (ColumnType, bool) {
+	if c.Base() != ColumnTypeSimpleAggregateFunction {
+		return "", false
+	}
+	elems := c.elems()
+	if len(elems) < 2 {
+		return "", false
+	}
+	return elems[len(elems)-1], true
+}
+
+// Elems returns top-level type parameters, e.g. [A, B(C, D)] for
+// T(A, B(C, D)).
+func (c ColumnType) Elems() []ColumnType {
+	return c.elems()
+}
+
+// elems returns top-level type parameters, e.g. [A, B(C, D)] for T(A, B(C, D)).
+func (c ColumnType) elems() []ColumnType {
+	var (
+		elem   = string(c.Elem())
+		elems  []ColumnType
+		depth  int
+		quoted bool
+		start  int
+	)
+	if elem == "" {
+		return nil
+	}
+	for i := 0; i < len(elem); i++ {
+		switch ch := elem[i]; {
+		case ch == '\\' && quoted:
+			i++ // skip escaped character
+		case ch == '\'':
+			quoted = !quoted
+		case quoted:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == ',' && depth == 0:
+			elems = append(elems, ColumnType(strings.TrimSpace(elem[start:i])))
+			start = i + 1
+		}
+	}
+	return append(elems, ColumnType(strings.TrimSpace(elem[start:])))
+}
+
 // IsArray reports whether ColumnType is composite.
 func (c ColumnType) IsArray() bool {
 	return strings.HasPrefix(string(c), string(ColumnTypeArray))
@@ -189,6 +248,7 @@ const (
 	ColumnTypeUInt256        ColumnType = "UInt256"
 	ColumnTypeFloat32        ColumnType = "Float32"
 	ColumnTypeFloat64        ColumnType = "Float64"
+	ColumnTypeBFloat16       ColumnType = "BFloat16"
 	ColumnTypeString         ColumnType = "String"
 	ColumnTypeFixedString    ColumnType = "FixedString"
 	ColumnTypeArray          ColumnType = "Array"
@@ -210,9 +270,19 @@ const (
 	ColumnTypeDecimal64      ColumnType = "Decimal64"
 	ColumnTypeDecimal128     ColumnType = "Decimal128"
 	ColumnTypeDecimal256     ColumnType = "Decimal256"
+	ColumnTypeDecimal        ColumnType = "Decimal"
 	ColumnTypePoint          ColumnType = "Point"
+	ColumnTypeRing           ColumnType = "Ring"
+	ColumnTypePolygon        ColumnType = "Polygon"
+	ColumnTypeMultiPolygon   ColumnType = "MultiPolygon"
 	ColumnTypeInterval       ColumnType = "Interval"
 	ColumnTypeNothing        ColumnType = "Nothing"
+	ColumnTypeJSON           ColumnType = "JSON"
+	ColumnTypeVariant        ColumnType = "Variant"
+	ColumnTypeDynamic        ColumnType = "Dynamic"
+
+	ColumnTypeAggregateFunction       ColumnType = "AggregateFunction"
+	ColumnTypeSimpleAggregateFunction ColumnType = "SimpleAggregateFunction"
 )
 
 // colWrap wraps Column with type t.
@@ -272,14 +342,8 @@ func (s *ColInfoInput) DecodeResult(r *Reader, version int, b Block) error {
 		if err != nil {
 			return errors.Wrapf(err, "column [%d] type", i)
 		}
-		if FeatureCustomSerialization.In(version) {
-			customSerialization, err := r.Bool()
-			if err != nil {
-				return errors.Wrapf(err, "column [%d] custom serialization", i)
-			}
-			if customSerialization {
-				return errors.Wrapf(err, "column [%d] has custom serialization (not supported)", i)
-			}
+		if _, err := decodeSerializationInfo(r, version, ColumnType(columnTypeRaw)); err != nil {
+			return errors.Wrapf(err, "column [%d] serialization", i)
 		}
 		*s = append(*s, ColInfo{
 			Name: columnName,
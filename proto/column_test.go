@@ -22,6 +22,12 @@ func TestColumnType_Elem(t *testing.T) {
 		assert.True(t, v.IsArray())
 		assert.Equal(t, ColumnTypeInt16, v.Elem())
 	})
+	t.Run("Elems", func(t *testing.T) {
+		assert.Nil(t, ColumnTypeString.elems())
+		assert.Equal(t, []ColumnType{"String", "Array(Map(String, UInt64))", "Enum8('a,(' = 1, 'b' = 2)"},
+			ColumnType("Variant(String, Array(Map(String, UInt64)), Enum8('a,(' = 1, 'b' = 2))").elems(),
+		)
+	})
 	t.Run("Simple", func(t *testing.T) {
 		assert.Equal(t, ColumnTypeNone, ColumnTypeFloat32.Elem())
 		assert.False(t, ColumnTypeInt32.IsArray())
@@ -40,6 +46,8 @@ func TestColumnType_Elem(t *testing.T) {
 				{A: "Map(String,String)", B: "Map(String, String)"},
 				{A: "Enum8('increment' = 1, 'gauge' = 2)", B: "Int8"},
 				{A: "Int8", B: "Enum8('increment' = 1, 'gauge' = 2)"},
+				{A: "SimpleAggregateFunction(sum, UInt64)", B: "UInt64"},
+				{A: "Array(String)", B: "SimpleAggregateFunction(groupUniqArrayArray, Array(String))"},
 			} {
 				assert.False(t, tt.A.Conflicts(tt.B),
 					"%s ~ %s", tt.A, tt.B,
@@ -56,6 +64,7 @@ func TestColumnType_Elem(t *testing.T) {
 				{A: ColumnTypeArray.Sub(ColumnTypeInt32), B: ColumnTypeArray.Sub(ColumnTypeInt64)},
 				{A: "Map(String,String)", B: "Map(String,Int32)"},
 				{A: "Enum16('increment' = 1, 'gauge' = 2)", B: "Int8"},
+				{A: "SimpleAggregateFunction(sum, UInt64)", B: "Int64"},
 			} {
 				assert.True(t, tt.A.Conflicts(tt.B),
 					"%s !~ %s", tt.A, tt.B,
@@ -0,0 +1,353 @@
+package proto
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// ColumnTypeNodeKind is kind of ColumnTypeNode.
+type ColumnTypeNodeKind byte
+
+// Possible kinds of ColumnTypeNode.
+const (
+	// ColumnTypeNodeType is type or function, e.g. Int8, Array(String)
+	// or quantiles(0.5) in AggregateFunction(quantiles(0.5), Float64).
+	ColumnTypeNodeType ColumnTypeNodeKind = iota
+	// ColumnTypeNodeString is string literal, e.g. 'UTC' in DateTime('UTC').
+	ColumnTypeNodeString
+	// ColumnTypeNodeNumber is numeric literal, e.g. 3 in DateTime64(3).
+	ColumnTypeNodeNumber
+	// ColumnTypeNodeEnum is enum element, e.g. 'a' = 1 in Enum8('a' = 1).
+	ColumnTypeNodeEnum
+	// ColumnTypeNodeSetting is type setting, e.g. max_types=10 in
+	// Dynamic(max_types=10).
+	ColumnTypeNodeSetting
+)
+
+// ColumnTypeNode is node of parsed ColumnType.
+type ColumnTypeNode struct {
+	Kind ColumnTypeNodeKind
+	// Name of named Tuple element (or JSON path), e.g. a in Tuple(a String),
+	// or key of setting.
+	Name string
+	// Base type or function name, e.g. Array in Array(String).
+	Base ColumnType
+	// Value of literal (unquoted for strings), enum element name or
+	// setting value.
+	Value string
+	// EnumValue is value of enum element.
+	EnumValue int
+	// Params of type, e.g. [String] for Array(String) or [9, 2] for
+	// Decimal(9, 2). Empty non-nil for types with empty parameters list,
+	// e.g. Tuple().
+	Params []*ColumnTypeNode
+}
+
+// Parse parses ColumnType into tree of nodes.
+func (c ColumnType) Parse() (*ColumnTypeNode, error) {
+	p := &columnTypeParser{s: string(c)}
+	n, err := p.param()
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %q", c)
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, errors.Errorf("parse %q: unexpected %q at %d", c, p.s[p.pos:], p.pos)
+	}
+	if n.Kind != ColumnTypeNodeType || n.Name != "" {
+		return nil, errors.Errorf("parse %q: not a type", c)
+	}
+	return n, nil
+}
+
+// Normalize returns canonical representation of ColumnType, as
+// ClickHouse formats it, e.g. Map(String, Enum8('a' = 1)) for
+// Map(String,Enum8('a'=1)).
+//
+// Returns ColumnType as is if it can't be parsed.
+func (c ColumnType) Normalize() ColumnType {
+	n, err := c.Parse()
+	if err != nil {
+		return c
+	}
+	return n.Type()
+}
+
+// Type returns ColumnType of node.
+func (n *ColumnTypeNode) Type() ColumnType {
+	return ColumnType(n.String())
+}
+
+// Elem returns i-th parameter of node or nil if there is no such
+// parameter.
+func (n *ColumnTypeNode) Elem(i int) *ColumnTypeNode {
+	if i < 0 || i >= len(n.Params) {
+		return nil
+	}
+	return n.Params[i]
+}
+
+// String formats node in canonical form.
+func (n *ColumnTypeNode) String() string {
+	var b strings.Builder
+	n.write(&b)
+	return b.String()
+}
+
+func (n *ColumnTypeNode) write(b *strings.Builder) {
+	switch n.Kind {
+	case ColumnTypeNodeString:
+		writeQuoted(b, n.Value)
+	case ColumnTypeNodeNumber:
+		b.WriteString(n.Value)
+	case ColumnTypeNodeEnum:
+		writeQuoted(b, n.Value)
+		b.WriteString(" = ")
+		b.WriteString(strconv.Itoa(n.EnumValue))
+	case ColumnTypeNodeSetting:
+		b.WriteString(n.Name)
+		b.WriteByte('=')
+		b.WriteString(n.Value)
+	default:
+		if n.Name != "" {
+			writeIdent(b, n.Name)
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(n.Base))
+		if n.Params == nil {
+			return
+		}
+		b.WriteByte('(')
+		for i, p := range n.Params {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			p.write(b)
+		}
+		b.WriteByte(')')
+	}
+}
+
+func writeQuoted(b *strings.Builder, s string) {
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('\'')
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentChar(ch byte) bool {
+	return isIdentStart(ch) || (ch >= '0' && ch <= '9') || ch == '.'
+}
+
+func writeIdent(b *strings.Builder, s string) {
+	plain := s != "" && isIdentStart(s[0])
+	for i := 0; i < len(s) && plain; i++ {
+		plain = isIdentChar(s[i])
+	}
+	if plain {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte('`')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '`' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('`')
+}
+
+type columnTypeParser struct {
+	s   string
+	pos int
+}
+
+func (p *columnTypeParser) skipSpace() {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns next non-space character or 0 on end of input.
+func (p *columnTypeParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// quoted reads string quoted by q, e.g. 'foo' or `bar`.
+func (p *columnTypeParser) quoted(q byte) (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		ch := p.s[p.pos]
+		p.pos++
+		switch {
+		case ch == '\\':
+			if p.pos >= len(p.s) {
+				return "", errors.Errorf("unterminated escape at %d", p.pos)
+			}
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		case ch == q && p.pos < len(p.s) && p.s[p.pos] == q:
+			// Doubled quote.
+			b.WriteByte(q)
+			p.pos++
+		case ch == q:
+			return b.String(), nil
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", errors.Errorf("unterminated quote at %d", start)
+}
+
+func (p *columnTypeParser) ident() (string, error) {
+	if p.peek() == '`' {
+		return p.quoted('`')
+	}
+	start := p.pos
+	if start >= len(p.s) || !isIdentStart(p.s[start]) {
+		return "", errors.Errorf("expected identifier at %d", start)
+	}
+	for p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *columnTypeParser) number() (string, error) {
+	start := p.pos
+	if p.pos < len(p.s) && (p.s[p.pos] == '-' || p.s[p.pos] == '+') {
+		p.pos++
+	}
+	for p.pos < len(p.s) {
+		ch := p.s[p.pos]
+		if (ch >= '0' && ch <= '9') || ch == '.' || ch == 'e' || ch == 'E' ||
+			((ch == '-' || ch == '+') && (p.s[p.pos-1] == 'e' || p.s[p.pos-1] == 'E')) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	v := p.s[start:p.pos]
+	if _, err := strconv.ParseFloat(v, 64); err != nil {
+		return "", errors.Errorf("invalid number %q at %d", v, start)
+	}
+	return v, nil
+}
+
+func (p *columnTypeParser) params() ([]*ColumnTypeNode, error) {
+	p.pos++ // (
+	var params []*ColumnTypeNode
+	if p.peek() == ')' {
+		p.pos++
+		// Non-nil to distinguish T() from T.
+		return []*ColumnTypeNode{}, nil
+	}
+	for {
+		n, err := p.param()
+		if err != nil {
+			return nil, errors.Wrapf(err, "param %d", len(params))
+		}
+		params = append(params, n)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return params, nil
+		default:
+			return nil, errors.Errorf("expected ',' or ')' at %d", p.pos)
+		}
+	}
+}
+
+func (p *columnTypeParser) param() (*ColumnTypeNode, error) {
+	switch ch := p.peek(); {
+	case ch == 0:
+		return nil, errors.New("unexpected end")
+	case ch == '\'':
+		v, err := p.quoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != '=' {
+			return &ColumnTypeNode{Kind: ColumnTypeNodeString, Value: v}, nil
+		}
+		p.pos++
+		p.skipSpace()
+		num, err := p.number()
+		if err != nil {
+			return nil, errors.Wrap(err, "enum value")
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return nil, errors.Wrapf(err, "enum value %q", num)
+		}
+		return &ColumnTypeNode{Kind: ColumnTypeNodeEnum, Value: v, EnumValue: n}, nil
+	case ch == '-' || ch == '+' || (ch >= '0' && ch <= '9'):
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		return &ColumnTypeNode{Kind: ColumnTypeNodeNumber, Value: v}, nil
+	}
+	quotedName := p.peek() == '`'
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	switch ch := p.peek(); {
+	case ch == '=':
+		p.pos++
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != ')' {
+			p.pos++
+		}
+		return &ColumnTypeNode{
+			Kind:  ColumnTypeNodeSetting,
+			Name:  name,
+			Value: strings.TrimSpace(p.s[start:p.pos]),
+		}, nil
+	case isIdentStart(ch) || ch == '`' || quotedName:
+		// Named element, e.g. "a String" in Tuple(a String).
+		n, err := p.param()
+		if err != nil {
+			return nil, errors.Wrapf(err, "element %q", name)
+		}
+		if n.Kind != ColumnTypeNodeType || n.Name != "" {
+			return nil, errors.Errorf("element %q: not a type", name)
+		}
+		n.Name = name
+		return n, nil
+	case ch == '(':
+		params, err := p.params()
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+		return &ColumnTypeNode{Base: ColumnType(name), Params: params}, nil
+	default:
+		return &ColumnTypeNode{Base: ColumnType(name)}, nil
+	}
+}
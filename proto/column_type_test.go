@@ -0,0 +1,82 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnType_Parse(t *testing.T) {
+	t.Parallel()
+	n, err := ColumnType("Map(String, Tuple(a Enum8('x,y' = 1, 'it\\'s' = -2), `b c` Array(Nullable(Decimal(9, 2)))))").Parse()
+	require.NoError(t, err)
+	require.Equal(t, &ColumnTypeNode{
+		Base: ColumnTypeMap,
+		Params: []*ColumnTypeNode{
+			{Base: ColumnTypeString},
+			{
+				Base: ColumnTypeTuple,
+				Params: []*ColumnTypeNode{
+					{
+						Name: "a",
+						Base: ColumnTypeEnum8,
+						Params: []*ColumnTypeNode{
+							{Kind: ColumnTypeNodeEnum, Value: "x,y", EnumValue: 1},
+							{Kind: ColumnTypeNodeEnum, Value: "it's", EnumValue: -2},
+						},
+					},
+					{
+						Name: "b c",
+						Base: ColumnTypeArray,
+						Params: []*ColumnTypeNode{{
+							Base: ColumnTypeNullable,
+							Params: []*ColumnTypeNode{{
+								Base: ColumnTypeDecimal,
+								Params: []*ColumnTypeNode{
+									{Kind: ColumnTypeNodeNumber, Value: "9"},
+									{Kind: ColumnTypeNodeNumber, Value: "2"},
+								},
+							}},
+						}},
+					},
+				},
+			},
+		},
+	}, n)
+	require.Equal(t, "a", n.Elem(1).Elem(0).Name)
+	require.Nil(t, n.Elem(2))
+
+	for _, tt := range []struct {
+		Input  ColumnType
+		Output ColumnType
+	}{
+		{"String", "String"},
+		{"Array( String )", "Array(String)"},
+		{"Map(String,UInt64)", "Map(String, UInt64)"},
+		{"Enum8('a'=1,'b'=2)", "Enum8('a' = 1, 'b' = 2)"},
+		{"DateTime64(3,'Europe/Moscow')", "DateTime64(3, 'Europe/Moscow')"},
+		{"Tuple(`1` String,`a b` Int64)", "Tuple(`1` String, `a b` Int64)"},
+		{"Tuple(a.b String)", "Tuple(a.b String)"},
+		{"AggregateFunction(quantiles(0.5,0.9),Float64)", "AggregateFunction(quantiles(0.5, 0.9), Float64)"},
+		{"Dynamic(max_types=10)", "Dynamic(max_types=10)"},
+		{"Nested(a String,b Array(Int8))", "Nested(a String, b Array(Int8))"},
+		{"Enum8('it''s' = 1)", "Enum8('it\\'s' = 1)"},
+		{"Tuple( )", "Tuple()"},
+		// Invalid types are returned as is.
+		{"Array(String", "Array(String"},
+		{"Enum8('a' = x)", "Enum8('a' = x)"},
+		{"", ""},
+	} {
+		require.Equal(t, tt.Output, tt.Input.Normalize(), tt.Input)
+	}
+
+	for _, s := range []ColumnType{
+		"", "(", "Array(String))", "'foo'", "1", "Tuple(a 'x')", "Foo(1 2)",
+	} {
+		_, err := s.Parse()
+		require.Error(t, err, s)
+	}
+
+	require.False(t, ColumnType("Map(String,Enum8('a'=1))").Conflicts("Map(String, Enum8('a' = 1))"))
+	require.True(t, ColumnType("Map(String,Enum8('a'=1))").Conflicts("Map(String, Enum8('a' = 2))"))
+}
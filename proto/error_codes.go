@@ -373,4 +373,5 @@ const (
 	ErrIllegalProjectionManipulator                 Error = 2002
 
 	ErrAuthenticationFailed Error = 516
+	ErrClusterDoesntExist   Error = 701
 )
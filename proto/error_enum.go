@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-const _ErrorName = "UNSUPPORTED_METHODUNSUPPORTED_PARAMETERUNEXPECTED_END_OF_FILEEXPECTED_END_OF_FILECANNOT_PARSE_TEXTINCORRECT_NUMBER_OF_COLUMNSTHERE_IS_NO_COLUMNSIZES_OF_COLUMNS_DOESNT_MATCHNOT_FOUND_COLUMN_IN_BLOCKPOSITION_OUT_OF_BOUNDPARAMETER_OUT_OF_BOUNDSIZES_OF_COLUMNS_IN_TUPLE_DOESNT_MATCHDUPLICATE_COLUMNNO_SUCH_COLUMN_IN_TABLEDELIMITER_IN_STRING_LITERAL_DOESNT_MATCHCANNOT_INSERT_ELEMENT_INTO_CONSTANT_COLUMNSIZE_OF_FIXED_STRING_DOESNT_MATCHNUMBER_OF_COLUMNS_DOESNT_MATCHCANNOT_READ_ALL_DATA_FROM_TAB_SEPARATED_INPUTCANNOT_PARSE_ALL_VALUE_FROM_TAB_SEPARATED_INPUTCANNOT_READ_FROM_ISTREAMCANNOT_WRITE_TO_OSTREAMCANNOT_PARSE_ESCAPE_SEQUENCECANNOT_PARSE_QUOTED_STRINGCANNOT_PARSE_INPUT_ASSERTION_FAILEDCANNOT_PRINT_FLOAT_OR_DOUBLE_NUMBERCANNOT_PRINT_INTEGERCANNOT_READ_SIZE_OF_COMPRESSED_CHUNKCANNOT_READ_COMPRESSED_CHUNKATTEMPT_TO_READ_AFTER_EOFCANNOT_READ_ALL_DATATOO_MANY_ARGUMENTS_FOR_FUNCTIONTOO_LESS_ARGUMENTS_FOR_FUNCTIONBAD_ARGUMENTSUNKNOWN_ELEMENT_IN_ASTCANNOT_PARSE_DATETOO_LARGE_SIZE_COMPRESSEDCHECKSUM_DOESNT_MATCHCANNOT_PARSE_DATETIMENUMBER_OF_ARGUMENTS_DOESNT_MATCHILLEGAL_TYPE_OF_ARGUMENTILLEGAL_COLUMNILLEGAL_NUMBER_OF_RESULT_COLUMNSUNKNOWN_FUNCTIONUNKNOWN_IDENTIFIERNOT_IMPLEMENTEDLOGICAL_ERRORUNKNOWN_TYPEEMPTY_LIST_OF_COLUMNS_QUERIEDCOLUMN_QUERIED_MORE_THAN_ONCETYPE_MISMATCHSTORAGE_DOESNT_ALLOW_PARAMETERSSTORAGE_REQUIRES_PARAMETERUNKNOWN_STORAGETABLE_ALREADY_EXISTSTABLE_METADATA_ALREADY_EXISTSILLEGAL_TYPE_OF_COLUMN_FOR_FILTERUNKNOWN_TABLEONLY_FILTER_COLUMN_IN_BLOCKSYNTAX_ERRORUNKNOWN_AGGREGATE_FUNCTIONCANNOT_READ_AGGREGATE_FUNCTION_FROM_TEXTCANNOT_WRITE_AGGREGATE_FUNCTION_AS_TEXTNOT_A_COLUMNILLEGAL_KEY_OF_AGGREGATIONCANNOT_GET_SIZE_OF_FIELDARGUMENT_OUT_OF_BOUNDCANNOT_CONVERT_TYPECANNOT_WRITE_AFTER_END_OF_BUFFERCANNOT_PARSE_NUMBERUNKNOWN_FORMATCANNOT_READ_FROM_FILE_DESCRIPTORCANNOT_WRITE_TO_FILE_DESCRIPTORCANNOT_OPEN_FILECANNOT_CLOSE_FILEUNKNOWN_TYPE_OF_QUERYINCORRECT_FILE_NAMEINCORRECT_QUERYUNKNOWN_DATABASEDATABASE_ALREADY_EXISTSDIRECTORY_DOESNT_EXISTDIRECTORY_ALREADY_EXISTSFORMAT_IS_NOT_SUITABLE_FOR_INPUTRECEIVED_ERROR_FROM_REMOTE_IO_SERVERCANNOT_SEEK_THROUGH_FILECANNOT_TRUNCATE_FILEUNKNOWN_COMPRESSION_METHODEMPTY_LIST_OF_COLUMNS_PASSEDSIZES_OF_MARKS_FILES_ARE_INCONSISTENTEMPTY_DATA_PASSEDUNKNOWN_AGGREGATED_DATA_VARIANTCANNOT_MERGE_DIFFERENT_AGGREGATED_DATA_VARIANTSCANNOT_READ_FROM_SOCKETCANNOT_WRITE_TO_SOCKETCANNOT_READ_ALL_DATA_FROM_CHUNKED_INPUTCANNOT_WRITE_TO_EMPTY_BLOCK_OUTPUT_STREAMUNKNOWN_PACKET_FROM_CLIENTUNKNOWN_PACKET_FROM_SERVERUNEXPECTED_PACKET_FROM_CLIENTUNEXPECTED_PACKET_FROM_SERVERRECEIVED_DATA_FOR_WRONG_QUERY_IDTOO_SMALL_BUFFER_SIZECANNOT_READ_HISTORYCANNOT_APPEND_HISTORYFILE_DOESNT_EXISTNO_DATA_TO_INSERTCANNOT_BLOCK_SIGNALCANNOT_UNBLOCK_SIGNALCANNOT_MANIPULATE_SIGSETCANNOT_WAIT_FOR_SIGNALTHERE_IS_NO_SESSIONCANNOT_CLOCK_GETTIMEUNKNOWN_SETTINGTHERE_IS_NO_DEFAULT_VALUEINCORRECT_DATAENGINE_REQUIREDCANNOT_INSERT_VALUE_OF_DIFFERENT_SIZE_INTO_TUPLEUNKNOWN_SET_DATA_VARIANTINCOMPATIBLE_COLUMNSUNKNOWN_TYPE_OF_AST_NODEINCORRECT_ELEMENT_OF_SETINCORRECT_RESULT_OF_SCALAR_SUBQUERYCANNOT_GET_RETURN_TYPEILLEGAL_INDEXTOO_LARGE_ARRAY_SIZEFUNCTION_IS_SPECIALCANNOT_READ_ARRAY_FROM_TEXTTOO_LARGE_STRING_SIZECANNOT_CREATE_TABLE_FROM_METADATAAGGREGATE_FUNCTION_DOESNT_ALLOW_PARAMETERSPARAMETERS_TO_AGGREGATE_FUNCTIONS_MUST_BE_LITERALSZERO_ARRAY_OR_TUPLE_INDEXUNKNOWN_ELEMENT_IN_CONFIGEXCESSIVE_ELEMENT_IN_CONFIGNO_ELEMENTS_IN_CONFIGALL_REQUESTED_COLUMNS_ARE_MISSINGSAMPLING_NOT_SUPPORTEDNOT_FOUND_NODEFOUND_MORE_THAN_ONE_NODEFIRST_DATE_IS_BIGGER_THAN_LAST_DATEUNKNOWN_OVERFLOW_MODEQUERY_SECTION_DOESNT_MAKE_SENSENOT_FOUND_FUNCTION_ELEMENT_FOR_AGGREGATENOT_FOUND_RELATION_ELEMENT_FOR_CONDITIONNOT_FOUND_RHS_ELEMENT_FOR_CONDITIONNO_ATTRIBUTES_LISTEDINDEX_OF_COLUMN_IN_SORT_CLAUSE_IS_OUT_OF_RANGEUNKNOWN_DIRECTION_OF_SORTINGILLEGAL_DIVISIONAGGREGATE_FUNCTION_NOT_APPLICABLEUNKNOWN_RELATIONDICTIONARIES_WAS_NOT_LOADEDILLEGAL_OVERFLOW_MODETOO_MANY_ROWSTIMEOUT_EXCEEDEDTOO_SLOWTOO_MANY_COLUMNSTOO_DEEP_SUBQUERIESTOO_DEEP_PIPELINEREADONLYTOO_MANY_TEMPORARY_COLUMNSTOO_MANY_TEMPORARY_NON_CONST_COLUMNSTOO_DEEP_ASTTOO_BIG_ASTBAD_TYPE_OF_FIELDBAD_GETBLOCKS_HAVE_DIFFERENT_STRUCTURECANNOT_CREATE_DIRECTORYCANNOT_ALLOCATE_MEMORYCYCLIC_ALIASESCHUNK_NOT_FOUNDDUPLICATE_CHUNK_NAMEMULTIPLE_ALIASES_FOR_EXPRESSIONMULTIPLE_EXPRESSIONS_FOR_ALIASTHERE_IS_NO_PROFILEILLEGAL_FINALILLEGAL_PREWHEREUNEXPECTED_EXPRESSIONILLEGAL_AGGREGATIONUNSUPPORTED_MYISAM_BLOCK_TYPEUNSUPPORTED_COLLATION_LOCALECOLLATION_COMPARISON_FAILEDUNKNOWN_ACTIONTABLE_MUST_NOT_BE_CREATED_MANUALLYSIZES_OF_ARRAYS_DOESNT_MATCHSET_SIZE_LIMIT_EXCEEDEDUNKNOWN_USERWRONG_PASSWORDREQUIRED_PASSWORDIP_ADDRESS_NOT_ALLOWEDUNKNOWN_ADDRESS_PATTERN_TYPESERVER_REVISION_IS_TOO_OLDDNS_ERRORUNKNOWN_QUOTAQUOTA_DOESNT_ALLOW_KEYSQUOTA_EXPIREDTOO_MANY_SIMULTANEOUS_QUERIESNO_FREE_CONNECTIONCANNOT_FSYNCNESTED_TYPE_TOO_DEEPALIAS_REQUIREDAMBIGUOUS_IDENTIFIEREMPTY_NESTED_TABLESOCKET_TIMEOUTNETWORK_ERROREMPTY_QUERYUNKNOWN_LOAD_BALANCINGUNKNOWN_TOTALS_MODECANNOT_STATVFSNOT_AN_AGGREGATEQUERY_WITH_SAME_ID_IS_ALREADY_RUNNINGCLIENT_HAS_CONNECTED_TO_WRONG_PORTTABLE_IS_DROPPEDDATABASE_NOT_EMPTYDUPLICATE_INTERSERVER_IO_ENDPOINTNO_SUCH_INTERSERVER_IO_ENDPOINTADDING_REPLICA_TO_NON_EMPTY_TABLEUNEXPECTED_AST_STRUCTUREREPLICA_IS_ALREADY_ACTIVENO_ZOOKEEPERNO_FILE_IN_DATA_PARTUNEXPECTED_FILE_IN_DATA_PARTBAD_SIZE_OF_FILE_IN_DATA_PARTQUERY_IS_TOO_LARGENOT_FOUND_EXPECTED_DATA_PARTTOO_MANY_UNEXPECTED_DATA_PARTSNO_SUCH_DATA_PARTBAD_DATA_PART_NAMENO_REPLICA_HAS_PARTDUPLICATE_DATA_PARTABORTEDNO_REPLICA_NAME_GIVENFORMAT_VERSION_TOO_OLDCANNOT_MUNMAPCANNOT_MREMAPMEMORY_LIMIT_EXCEEDEDTABLE_IS_READ_ONLYNOT_ENOUGH_SPACEUNEXPECTED_ZOOKEEPER_ERRORCORRUPTED_DATAINCORRECT_MARKINVALID_PARTITION_VALUENOT_ENOUGH_BLOCK_NUMBERSNO_SUCH_REPLICATOO_MANY_PARTSREPLICA_IS_ALREADY_EXISTNO_ACTIVE_REPLICASTOO_MANY_RETRIES_TO_FETCH_PARTSPARTITION_ALREADY_EXISTSPARTITION_DOESNT_EXISTUNION_ALL_RESULT_STRUCTURES_MISMATCHCLIENT_OUTPUT_FORMAT_SPECIFIEDUNKNOWN_BLOCK_INFO_FIELDBAD_COLLATIONCANNOT_COMPILE_CODEINCOMPATIBLE_TYPE_OF_JOINNO_AVAILABLE_REPLICAMISMATCH_REPLICAS_DATA_SOURCESSTORAGE_DOESNT_SUPPORT_PARALLEL_REPLICASCPUID_ERRORINFINITE_LOOPCANNOT_COMPRESSCANNOT_DECOMPRESSAIO_SUBMIT_ERRORAIO_COMPLETION_ERRORAIO_READ_ERRORAIO_WRITE_ERRORINDEX_NOT_USEDLEADERSHIP_LOSTALL_CONNECTION_TRIES_FAILEDNO_AVAILABLE_DATADICTIONARY_IS_EMPTYINCORRECT_INDEXUNKNOWN_DISTRIBUTED_PRODUCT_MODEUNKNOWN_GLOBAL_SUBQUERIES_METHODTOO_LESS_LIVE_REPLICASUNSATISFIED_QUORUM_FOR_PREVIOUS_WRITEUNKNOWN_FORMAT_VERSIONDISTRIBUTED_IN_JOIN_SUBQUERY_DENIEDREPLICA_IS_NOT_IN_QUORUMLIMIT_EXCEEDEDDATABASE_ACCESS_DENIEDLEADERSHIP_CHANGEDMONGODB_CANNOT_AUTHENTICATEINVALID_BLOCK_EXTRA_INFORECEIVED_EMPTY_DATANO_REMOTE_SHARD_FOUNDSHARD_HAS_NO_CONNECTIONSCANNOT_PIPECANNOT_FORKCANNOT_DLSYMCANNOT_CREATE_CHILD_PROCESSCHILD_WAS_NOT_EXITED_NORMALLYCANNOT_SELECTCANNOT_WAITPIDTABLE_WAS_NOT_DROPPEDTOO_DEEP_RECURSIONTOO_MANY_BYTESUNEXPECTED_NODE_IN_ZOOKEEPERFUNCTION_CANNOT_HAVE_PARAMETERSINVALID_SHARD_WEIGHTINVALID_CONFIG_PARAMETERUNKNOWN_STATUS_OF_INSERTVALUE_IS_OUT_OF_RANGE_OF_DATA_TYPEBARRIER_TIMEOUTUNKNOWN_DATABASE_ENGINEDDL_GUARD_IS_ACTIVEUNFINISHEDMETADATA_MISMATCHSUPPORT_IS_DISABLEDTABLE_DIFFERS_TOO_MUCHCANNOT_CONVERT_CHARSETCANNOT_LOAD_CONFIGCANNOT_INSERT_NULL_IN_ORDINARY_COLUMNINCOMPATIBLE_SOURCE_TABLESAMBIGUOUS_TABLE_NAMEAMBIGUOUS_COLUMN_NAMEINDEX_OF_POSITIONAL_ARGUMENT_IS_OUT_OF_RANGEZLIB_INFLATE_FAILEDZLIB_DEFLATE_FAILEDBAD_LAMBDARESERVED_IDENTIFIER_NAMEINTO_OUTFILE_NOT_ALLOWEDTABLE_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMITCANNOT_CREATE_CHARSET_CONVERTERSEEK_POSITION_OUT_OF_BOUNDCURRENT_WRITE_BUFFER_IS_EXHAUSTEDCANNOT_CREATE_IO_BUFFERRECEIVED_ERROR_TOO_MANY_REQUESTSOUTPUT_IS_NOT_SORTEDSIZES_OF_NESTED_COLUMNS_ARE_INCONSISTENTTOO_MANY_FETCHESBAD_CASTALL_REPLICAS_ARE_STALEDATA_TYPE_CANNOT_BE_USED_IN_TABLESINCONSISTENT_CLUSTER_DEFINITIONSESSION_NOT_FOUNDSESSION_IS_LOCKEDINVALID_SESSION_TIMEOUTCANNOT_DLOPENCANNOT_PARSE_UUIDILLEGAL_SYNTAX_FOR_DATA_TYPEDATA_TYPE_CANNOT_HAVE_ARGUMENTSUNKNOWN_STATUS_OF_DISTRIBUTED_DDL_TASKCANNOT_KILLHTTP_LENGTH_REQUIREDCANNOT_LOAD_CATBOOST_MODELCANNOT_APPLY_CATBOOST_MODELPART_IS_TEMPORARILY_LOCKEDMULTIPLE_STREAMS_REQUIREDNO_COMMON_TYPEEXTERNAL_LOADABLE_ALREADY_EXISTSCANNOT_ASSIGN_OPTIMIZEINSERT_WAS_DEDUPLICATEDCANNOT_GET_CREATE_TABLE_QUERYEXTERNAL_LIBRARY_ERRORQUERY_IS_PROHIBITEDTHERE_IS_NO_QUERYQUERY_WAS_CANCELLEDFUNCTION_THROW_IF_VALUE_IS_NON_ZEROTOO_MANY_ROWS_OR_BYTESQUERY_IS_NOT_SUPPORTED_IN_MATERIALIZED_VIEWCANNOT_PARSE_DOMAIN_VALUE_FROM_STRINGAUTHENTICATION_FAILEDKEEPER_EXCEPTIONPOCO_EXCEPTIONSTD_EXCEPTIONUNKNOWN_EXCEPTIONCONDITIONAL_TREE_PARENT_NOT_FOUNDILLEGAL_PROJECTION_MANIPULATOR"
-const _ErrorLowerName = "unsupported_methodunsupported_parameterunexpected_end_of_fileexpected_end_of_filecannot_parse_textincorrect_number_of_columnsthere_is_no_columnsizes_of_columns_doesnt_matchnot_found_column_in_blockposition_out_of_boundparameter_out_of_boundsizes_of_columns_in_tuple_doesnt_matchduplicate_columnno_such_column_in_tabledelimiter_in_string_literal_doesnt_matchcannot_insert_element_into_constant_columnsize_of_fixed_string_doesnt_matchnumber_of_columns_doesnt_matchcannot_read_all_data_from_tab_separated_inputcannot_parse_all_value_from_tab_separated_inputcannot_read_from_istreamcannot_write_to_ostreamcannot_parse_escape_sequencecannot_parse_quoted_stringcannot_parse_input_assertion_failedcannot_print_float_or_double_numbercannot_print_integercannot_read_size_of_compressed_chunkcannot_read_compressed_chunkattempt_to_read_after_eofcannot_read_all_datatoo_many_arguments_for_functiontoo_less_arguments_for_functionbad_argumentsunknown_element_in_astcannot_parse_datetoo_large_size_compressedchecksum_doesnt_matchcannot_parse_datetimenumber_of_arguments_doesnt_matchillegal_type_of_argumentillegal_columnillegal_number_of_result_columnsunknown_functionunknown_identifiernot_implementedlogical_errorunknown_typeempty_list_of_columns_queriedcolumn_queried_more_than_oncetype_mismatchstorage_doesnt_allow_parametersstorage_requires_parameterunknown_storagetable_already_existstable_metadata_already_existsillegal_type_of_column_for_filterunknown_tableonly_filter_column_in_blocksyntax_errorunknown_aggregate_functioncannot_read_aggregate_function_from_textcannot_write_aggregate_function_as_textnot_a_columnillegal_key_of_aggregationcannot_get_size_of_fieldargument_out_of_boundcannot_convert_typecannot_write_after_end_of_buffercannot_parse_numberunknown_formatcannot_read_from_file_descriptorcannot_write_to_file_descriptorcannot_open_filecannot_close_fileunknown_type_of_queryincorrect_file_nameincorrect_queryunknown_databasedatabase_already_existsdirectory_doesnt_existdirectory_already_existsformat_is_not_suitable_for_inputreceived_error_from_remote_io_servercannot_seek_through_filecannot_truncate_fileunknown_compression_methodempty_list_of_columns_passedsizes_of_marks_files_are_inconsistentempty_data_passedunknown_aggregated_data_variantcannot_merge_different_aggregated_data_variantscannot_read_from_socketcannot_write_to_socketcannot_read_all_data_from_chunked_inputcannot_write_to_empty_block_output_streamunknown_packet_from_clientunknown_packet_from_serverunexpected_packet_from_clientunexpected_packet_from_serverreceived_data_for_wrong_query_idtoo_small_buffer_sizecannot_read_historycannot_append_historyfile_doesnt_existno_data_to_insertcannot_block_signalcannot_unblock_signalcannot_manipulate_sigsetcannot_wait_for_signalthere_is_no_sessioncannot_clock_gettimeunknown_settingthere_is_no_default_valueincorrect_dataengine_requiredcannot_insert_value_of_different_size_into_tupleunknown_set_data_variantincompatible_columnsunknown_type_of_ast_nodeincorrect_element_of_setincorrect_result_of_scalar_subquerycannot_get_return_typeillegal_indextoo_large_array_sizefunction_is_specialcannot_read_array_from_texttoo_large_string_sizecannot_create_table_from_metadataaggregate_function_doesnt_allow_parametersparameters_to_aggregate_functions_must_be_literalszero_array_or_tuple_indexunknown_element_in_configexcessive_element_in_configno_elements_in_configall_requested_columns_are_missingsampling_not_supportednot_found_nodefound_more_than_one_nodefirst_date_is_bigger_than_last_dateunknown_overflow_modequery_section_doesnt_make_sensenot_found_function_element_for_aggregatenot_found_relation_element_for_conditionnot_found_rhs_element_for_conditionno_attributes_listedindex_of_column_in_sort_clause_is_out_of_rangeunknown_direction_of_sortingillegal_divisionaggregate_function_not_applicableunknown_relationdictionaries_was_not_loadedillegal_overflow_modetoo_many_rowstimeout_exceededtoo_slowtoo_many_columnstoo_deep_subqueriestoo_deep_pipelinereadonlytoo_many_temporary_columnstoo_many_temporary_non_const_columnstoo_deep_asttoo_big_astbad_type_of_fieldbad_getblocks_have_different_structurecannot_create_directorycannot_allocate_memorycyclic_aliaseschunk_not_foundduplicate_chunk_namemultiple_aliases_for_expressionmultiple_expressions_for_aliasthere_is_no_profileillegal_finalillegal_prewhereunexpected_expressionillegal_aggregationunsupported_myisam_block_typeunsupported_collation_localecollation_comparison_failedunknown_actiontable_must_not_be_created_manuallysizes_of_arrays_doesnt_matchset_size_limit_exceededunknown_userwrong_passwordrequired_passwordip_address_not_allowedunknown_address_pattern_typeserver_revision_is_too_olddns_errorunknown_quotaquota_doesnt_allow_keysquota_expiredtoo_many_simultaneous_queriesno_free_connectioncannot_fsyncnested_type_too_deepalias_requiredambiguous_identifierempty_nested_tablesocket_timeoutnetwork_errorempty_queryunknown_load_balancingunknown_totals_modecannot_statvfsnot_an_aggregatequery_with_same_id_is_already_runningclient_has_connected_to_wrong_porttable_is_droppeddatabase_not_emptyduplicate_interserver_io_endpointno_such_interserver_io_endpointadding_replica_to_non_empty_tableunexpected_ast_structurereplica_is_already_activeno_zookeeperno_file_in_data_partunexpected_file_in_data_partbad_size_of_file_in_data_partquery_is_too_largenot_found_expected_data_parttoo_many_unexpected_data_partsno_such_data_partbad_data_part_nameno_replica_has_partduplicate_data_partabortedno_replica_name_givenformat_version_too_oldcannot_munmapcannot_mremapmemory_limit_exceededtable_is_read_onlynot_enough_spaceunexpected_zookeeper_errorcorrupted_dataincorrect_markinvalid_partition_valuenot_enough_block_numbersno_such_replicatoo_many_partsreplica_is_already_existno_active_replicastoo_many_retries_to_fetch_partspartition_already_existspartition_doesnt_existunion_all_result_structures_mismatchclient_output_format_specifiedunknown_block_info_fieldbad_collationcannot_compile_codeincompatible_type_of_joinno_available_replicamismatch_replicas_data_sourcesstorage_doesnt_support_parallel_replicascpuid_errorinfinite_loopcannot_compresscannot_decompressaio_submit_erroraio_completion_erroraio_read_erroraio_write_errorindex_not_usedleadership_lostall_connection_tries_failedno_available_datadictionary_is_emptyincorrect_indexunknown_distributed_product_modeunknown_global_subqueries_methodtoo_less_live_replicasunsatisfied_quorum_for_previous_writeunknown_format_versiondistributed_in_join_subquery_deniedreplica_is_not_in_quorumlimit_exceededdatabase_access_deniedleadership_changedmongodb_cannot_authenticateinvalid_block_extra_inforeceived_empty_datano_remote_shard_foundshard_has_no_connectionscannot_pipecannot_forkcannot_dlsymcannot_create_child_processchild_was_not_exited_normallycannot_selectcannot_waitpidtable_was_not_droppedtoo_deep_recursiontoo_many_bytesunexpected_node_in_zookeeperfunction_cannot_have_parametersinvalid_shard_weightinvalid_config_parameterunknown_status_of_insertvalue_is_out_of_range_of_data_typebarrier_timeoutunknown_database_engineddl_guard_is_activeunfinishedmetadata_mismatchsupport_is_disabledtable_differs_too_muchcannot_convert_charsetcannot_load_configcannot_insert_null_in_ordinary_columnincompatible_source_tablesambiguous_table_nameambiguous_column_nameindex_of_positional_argument_is_out_of_rangezlib_inflate_failedzlib_deflate_failedbad_lambdareserved_identifier_nameinto_outfile_not_allowedtable_size_exceeds_max_drop_size_limitcannot_create_charset_converterseek_position_out_of_boundcurrent_write_buffer_is_exhaustedcannot_create_io_bufferreceived_error_too_many_requestsoutput_is_not_sortedsizes_of_nested_columns_are_inconsistenttoo_many_fetchesbad_castall_replicas_are_staledata_type_cannot_be_used_in_tablesinconsistent_cluster_definitionsession_not_foundsession_is_lockedinvalid_session_timeoutcannot_dlopencannot_parse_uuidillegal_syntax_for_data_typedata_type_cannot_have_argumentsunknown_status_of_distributed_ddl_taskcannot_killhttp_length_requiredcannot_load_catboost_modelcannot_apply_catboost_modelpart_is_temporarily_lockedmultiple_streams_requiredno_common_typeexternal_loadable_already_existscannot_assign_optimizeinsert_was_deduplicatedcannot_get_create_table_queryexternal_library_errorquery_is_prohibitedthere_is_no_queryquery_was_cancelledfunction_throw_if_value_is_non_zerotoo_many_rows_or_bytesquery_is_not_supported_in_materialized_viewcannot_parse_domain_value_from_stringauthentication_failedkeeper_exceptionpoco_exceptionstd_exceptionunknown_exceptionconditional_tree_parent_not_foundillegal_projection_manipulator"
+const _ErrorName = "UNSUPPORTED_METHODUNSUPPORTED_PARAMETERUNEXPECTED_END_OF_FILEEXPECTED_END_OF_FILECANNOT_PARSE_TEXTINCORRECT_NUMBER_OF_COLUMNSTHERE_IS_NO_COLUMNSIZES_OF_COLUMNS_DOESNT_MATCHNOT_FOUND_COLUMN_IN_BLOCKPOSITION_OUT_OF_BOUNDPARAMETER_OUT_OF_BOUNDSIZES_OF_COLUMNS_IN_TUPLE_DOESNT_MATCHDUPLICATE_COLUMNNO_SUCH_COLUMN_IN_TABLEDELIMITER_IN_STRING_LITERAL_DOESNT_MATCHCANNOT_INSERT_ELEMENT_INTO_CONSTANT_COLUMNSIZE_OF_FIXED_STRING_DOESNT_MATCHNUMBER_OF_COLUMNS_DOESNT_MATCHCANNOT_READ_ALL_DATA_FROM_TAB_SEPARATED_INPUTCANNOT_PARSE_ALL_VALUE_FROM_TAB_SEPARATED_INPUTCANNOT_READ_FROM_ISTREAMCANNOT_WRITE_TO_OSTREAMCANNOT_PARSE_ESCAPE_SEQUENCECANNOT_PARSE_QUOTED_STRINGCANNOT_PARSE_INPUT_ASSERTION_FAILEDCANNOT_PRINT_FLOAT_OR_DOUBLE_NUMBERCANNOT_PRINT_INTEGERCANNOT_READ_SIZE_OF_COMPRESSED_CHUNKCANNOT_READ_COMPRESSED_CHUNKATTEMPT_TO_READ_AFTER_EOFCANNOT_READ_ALL_DATATOO_MANY_ARGUMENTS_FOR_FUNCTIONTOO_LESS_ARGUMENTS_FOR_FUNCTIONBAD_ARGUMENTSUNKNOWN_ELEMENT_IN_ASTCANNOT_PARSE_DATETOO_LARGE_SIZE_COMPRESSEDCHECKSUM_DOESNT_MATCHCANNOT_PARSE_DATETIMENUMBER_OF_ARGUMENTS_DOESNT_MATCHILLEGAL_TYPE_OF_ARGUMENTILLEGAL_COLUMNILLEGAL_NUMBER_OF_RESULT_COLUMNSUNKNOWN_FUNCTIONUNKNOWN_IDENTIFIERNOT_IMPLEMENTEDLOGICAL_ERRORUNKNOWN_TYPEEMPTY_LIST_OF_COLUMNS_QUERIEDCOLUMN_QUERIED_MORE_THAN_ONCETYPE_MISMATCHSTORAGE_DOESNT_ALLOW_PARAMETERSSTORAGE_REQUIRES_PARAMETERUNKNOWN_STORAGETABLE_ALREADY_EXISTSTABLE_METADATA_ALREADY_EXISTSILLEGAL_TYPE_OF_COLUMN_FOR_FILTERUNKNOWN_TABLEONLY_FILTER_COLUMN_IN_BLOCKSYNTAX_ERRORUNKNOWN_AGGREGATE_FUNCTIONCANNOT_READ_AGGREGATE_FUNCTION_FROM_TEXTCANNOT_WRITE_AGGREGATE_FUNCTION_AS_TEXTNOT_A_COLUMNILLEGAL_KEY_OF_AGGREGATIONCANNOT_GET_SIZE_OF_FIELDARGUMENT_OUT_OF_BOUNDCANNOT_CONVERT_TYPECANNOT_WRITE_AFTER_END_OF_BUFFERCANNOT_PARSE_NUMBERUNKNOWN_FORMATCANNOT_READ_FROM_FILE_DESCRIPTORCANNOT_WRITE_TO_FILE_DESCRIPTORCANNOT_OPEN_FILECANNOT_CLOSE_FILEUNKNOWN_TYPE_OF_QUERYINCORRECT_FILE_NAMEINCORRECT_QUERYUNKNOWN_DATABASEDATABASE_ALREADY_EXISTSDIRECTORY_DOESNT_EXISTDIRECTORY_ALREADY_EXISTSFORMAT_IS_NOT_SUITABLE_FOR_INPUTRECEIVED_ERROR_FROM_REMOTE_IO_SERVERCANNOT_SEEK_THROUGH_FILECANNOT_TRUNCATE_FILEUNKNOWN_COMPRESSION_METHODEMPTY_LIST_OF_COLUMNS_PASSEDSIZES_OF_MARKS_FILES_ARE_INCONSISTENTEMPTY_DATA_PASSEDUNKNOWN_AGGREGATED_DATA_VARIANTCANNOT_MERGE_DIFFERENT_AGGREGATED_DATA_VARIANTSCANNOT_READ_FROM_SOCKETCANNOT_WRITE_TO_SOCKETCANNOT_READ_ALL_DATA_FROM_CHUNKED_INPUTCANNOT_WRITE_TO_EMPTY_BLOCK_OUTPUT_STREAMUNKNOWN_PACKET_FROM_CLIENTUNKNOWN_PACKET_FROM_SERVERUNEXPECTED_PACKET_FROM_CLIENTUNEXPECTED_PACKET_FROM_SERVERRECEIVED_DATA_FOR_WRONG_QUERY_IDTOO_SMALL_BUFFER_SIZECANNOT_READ_HISTORYCANNOT_APPEND_HISTORYFILE_DOESNT_EXISTNO_DATA_TO_INSERTCANNOT_BLOCK_SIGNALCANNOT_UNBLOCK_SIGNALCANNOT_MANIPULATE_SIGSETCANNOT_WAIT_FOR_SIGNALTHERE_IS_NO_SESSIONCANNOT_CLOCK_GETTIMEUNKNOWN_SETTINGTHERE_IS_NO_DEFAULT_VALUEINCORRECT_DATAENGINE_REQUIREDCANNOT_INSERT_VALUE_OF_DIFFERENT_SIZE_INTO_TUPLEUNKNOWN_SET_DATA_VARIANTINCOMPATIBLE_COLUMNSUNKNOWN_TYPE_OF_AST_NODEINCORRECT_ELEMENT_OF_SETINCORRECT_RESULT_OF_SCALAR_SUBQUERYCANNOT_GET_RETURN_TYPEILLEGAL_INDEXTOO_LARGE_ARRAY_SIZEFUNCTION_IS_SPECIALCANNOT_READ_ARRAY_FROM_TEXTTOO_LARGE_STRING_SIZECANNOT_CREATE_TABLE_FROM_METADATAAGGREGATE_FUNCTION_DOESNT_ALLOW_PARAMETERSPARAMETERS_TO_AGGREGATE_FUNCTIONS_MUST_BE_LITERALSZERO_ARRAY_OR_TUPLE_INDEXUNKNOWN_ELEMENT_IN_CONFIGEXCESSIVE_ELEMENT_IN_CONFIGNO_ELEMENTS_IN_CONFIGALL_REQUESTED_COLUMNS_ARE_MISSINGSAMPLING_NOT_SUPPORTEDNOT_FOUND_NODEFOUND_MORE_THAN_ONE_NODEFIRST_DATE_IS_BIGGER_THAN_LAST_DATEUNKNOWN_OVERFLOW_MODEQUERY_SECTION_DOESNT_MAKE_SENSENOT_FOUND_FUNCTION_ELEMENT_FOR_AGGREGATENOT_FOUND_RELATION_ELEMENT_FOR_CONDITIONNOT_FOUND_RHS_ELEMENT_FOR_CONDITIONNO_ATTRIBUTES_LISTEDINDEX_OF_COLUMN_IN_SORT_CLAUSE_IS_OUT_OF_RANGEUNKNOWN_DIRECTION_OF_SORTINGILLEGAL_DIVISIONAGGREGATE_FUNCTION_NOT_APPLICABLEUNKNOWN_RELATIONDICTIONARIES_WAS_NOT_LOADEDILLEGAL_OVERFLOW_MODETOO_MANY_ROWSTIMEOUT_EXCEEDEDTOO_SLOWTOO_MANY_COLUMNSTOO_DEEP_SUBQUERIESTOO_DEEP_PIPELINEREADONLYTOO_MANY_TEMPORARY_COLUMNSTOO_MANY_TEMPORARY_NON_CONST_COLUMNSTOO_DEEP_ASTTOO_BIG_ASTBAD_TYPE_OF_FIELDBAD_GETBLOCKS_HAVE_DIFFERENT_STRUCTURECANNOT_CREATE_DIRECTORYCANNOT_ALLOCATE_MEMORYCYCLIC_ALIASESCHUNK_NOT_FOUNDDUPLICATE_CHUNK_NAMEMULTIPLE_ALIASES_FOR_EXPRESSIONMULTIPLE_EXPRESSIONS_FOR_ALIASTHERE_IS_NO_PROFILEILLEGAL_FINALILLEGAL_PREWHEREUNEXPECTED_EXPRESSIONILLEGAL_AGGREGATIONUNSUPPORTED_MYISAM_BLOCK_TYPEUNSUPPORTED_COLLATION_LOCALECOLLATION_COMPARISON_FAILEDUNKNOWN_ACTIONTABLE_MUST_NOT_BE_CREATED_MANUALLYSIZES_OF_ARRAYS_DOESNT_MATCHSET_SIZE_LIMIT_EXCEEDEDUNKNOWN_USERWRONG_PASSWORDREQUIRED_PASSWORDIP_ADDRESS_NOT_ALLOWEDUNKNOWN_ADDRESS_PATTERN_TYPESERVER_REVISION_IS_TOO_OLDDNS_ERRORUNKNOWN_QUOTAQUOTA_DOESNT_ALLOW_KEYSQUOTA_EXPIREDTOO_MANY_SIMULTANEOUS_QUERIESNO_FREE_CONNECTIONCANNOT_FSYNCNESTED_TYPE_TOO_DEEPALIAS_REQUIREDAMBIGUOUS_IDENTIFIEREMPTY_NESTED_TABLESOCKET_TIMEOUTNETWORK_ERROREMPTY_QUERYUNKNOWN_LOAD_BALANCINGUNKNOWN_TOTALS_MODECANNOT_STATVFSNOT_AN_AGGREGATEQUERY_WITH_SAME_ID_IS_ALREADY_RUNNINGCLIENT_HAS_CONNECTED_TO_WRONG_PORTTABLE_IS_DROPPEDDATABASE_NOT_EMPTYDUPLICATE_INTERSERVER_IO_ENDPOINTNO_SUCH_INTERSERVER_IO_ENDPOINTADDING_REPLICA_TO_NON_EMPTY_TABLEUNEXPECTED_AST_STRUCTUREREPLICA_IS_ALREADY_ACTIVENO_ZOOKEEPERNO_FILE_IN_DATA_PARTUNEXPECTED_FILE_IN_DATA_PARTBAD_SIZE_OF_FILE_IN_DATA_PARTQUERY_IS_TOO_LARGENOT_FOUND_EXPECTED_DATA_PARTTOO_MANY_UNEXPECTED_DATA_PARTSNO_SUCH_DATA_PARTBAD_DATA_PART_NAMENO_REPLICA_HAS_PARTDUPLICATE_DATA_PARTABORTEDNO_REPLICA_NAME_GIVENFORMAT_VERSION_TOO_OLDCANNOT_MUNMAPCANNOT_MREMAPMEMORY_LIMIT_EXCEEDEDTABLE_IS_READ_ONLYNOT_ENOUGH_SPACEUNEXPECTED_ZOOKEEPER_ERRORCORRUPTED_DATAINCORRECT_MARKINVALID_PARTITION_VALUENOT_ENOUGH_BLOCK_NUMBERSNO_SUCH_REPLICATOO_MANY_PARTSREPLICA_IS_ALREADY_EXISTNO_ACTIVE_REPLICASTOO_MANY_RETRIES_TO_FETCH_PARTSPARTITION_ALREADY_EXISTSPARTITION_DOESNT_EXISTUNION_ALL_RESULT_STRUCTURES_MISMATCHCLIENT_OUTPUT_FORMAT_SPECIFIEDUNKNOWN_BLOCK_INFO_FIELDBAD_COLLATIONCANNOT_COMPILE_CODEINCOMPATIBLE_TYPE_OF_JOINNO_AVAILABLE_REPLICAMISMATCH_REPLICAS_DATA_SOURCESSTORAGE_DOESNT_SUPPORT_PARALLEL_REPLICASCPUID_ERRORINFINITE_LOOPCANNOT_COMPRESSCANNOT_DECOMPRESSAIO_SUBMIT_ERRORAIO_COMPLETION_ERRORAIO_READ_ERRORAIO_WRITE_ERRORINDEX_NOT_USEDLEADERSHIP_LOSTALL_CONNECTION_TRIES_FAILEDNO_AVAILABLE_DATADICTIONARY_IS_EMPTYINCORRECT_INDEXUNKNOWN_DISTRIBUTED_PRODUCT_MODEUNKNOWN_GLOBAL_SUBQUERIES_METHODTOO_LESS_LIVE_REPLICASUNSATISFIED_QUORUM_FOR_PREVIOUS_WRITEUNKNOWN_FORMAT_VERSIONDISTRIBUTED_IN_JOIN_SUBQUERY_DENIEDREPLICA_IS_NOT_IN_QUORUMLIMIT_EXCEEDEDDATABASE_ACCESS_DENIEDLEADERSHIP_CHANGEDMONGODB_CANNOT_AUTHENTICATEINVALID_BLOCK_EXTRA_INFORECEIVED_EMPTY_DATANO_REMOTE_SHARD_FOUNDSHARD_HAS_NO_CONNECTIONSCANNOT_PIPECANNOT_FORKCANNOT_DLSYMCANNOT_CREATE_CHILD_PROCESSCHILD_WAS_NOT_EXITED_NORMALLYCANNOT_SELECTCANNOT_WAITPIDTABLE_WAS_NOT_DROPPEDTOO_DEEP_RECURSIONTOO_MANY_BYTESUNEXPECTED_NODE_IN_ZOOKEEPERFUNCTION_CANNOT_HAVE_PARAMETERSINVALID_SHARD_WEIGHTINVALID_CONFIG_PARAMETERUNKNOWN_STATUS_OF_INSERTVALUE_IS_OUT_OF_RANGE_OF_DATA_TYPEBARRIER_TIMEOUTUNKNOWN_DATABASE_ENGINEDDL_GUARD_IS_ACTIVEUNFINISHEDMETADATA_MISMATCHSUPPORT_IS_DISABLEDTABLE_DIFFERS_TOO_MUCHCANNOT_CONVERT_CHARSETCANNOT_LOAD_CONFIGCANNOT_INSERT_NULL_IN_ORDINARY_COLUMNINCOMPATIBLE_SOURCE_TABLESAMBIGUOUS_TABLE_NAMEAMBIGUOUS_COLUMN_NAMEINDEX_OF_POSITIONAL_ARGUMENT_IS_OUT_OF_RANGEZLIB_INFLATE_FAILEDZLIB_DEFLATE_FAILEDBAD_LAMBDARESERVED_IDENTIFIER_NAMEINTO_OUTFILE_NOT_ALLOWEDTABLE_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMITCANNOT_CREATE_CHARSET_CONVERTERSEEK_POSITION_OUT_OF_BOUNDCURRENT_WRITE_BUFFER_IS_EXHAUSTEDCANNOT_CREATE_IO_BUFFERRECEIVED_ERROR_TOO_MANY_REQUESTSOUTPUT_IS_NOT_SORTEDSIZES_OF_NESTED_COLUMNS_ARE_INCONSISTENTTOO_MANY_FETCHESBAD_CASTALL_REPLICAS_ARE_STALEDATA_TYPE_CANNOT_BE_USED_IN_TABLESINCONSISTENT_CLUSTER_DEFINITIONSESSION_NOT_FOUNDSESSION_IS_LOCKEDINVALID_SESSION_TIMEOUTCANNOT_DLOPENCANNOT_PARSE_UUIDILLEGAL_SYNTAX_FOR_DATA_TYPEDATA_TYPE_CANNOT_HAVE_ARGUMENTSUNKNOWN_STATUS_OF_DISTRIBUTED_DDL_TASKCANNOT_KILLHTTP_LENGTH_REQUIREDCANNOT_LOAD_CATBOOST_MODELCANNOT_APPLY_CATBOOST_MODELPART_IS_TEMPORARILY_LOCKEDMULTIPLE_STREAMS_REQUIREDNO_COMMON_TYPEEXTERNAL_LOADABLE_ALREADY_EXISTSCANNOT_ASSIGN_OPTIMIZEINSERT_WAS_DEDUPLICATEDCANNOT_GET_CREATE_TABLE_QUERYEXTERNAL_LIBRARY_ERRORQUERY_IS_PROHIBITEDTHERE_IS_NO_QUERYQUERY_WAS_CANCELLEDFUNCTION_THROW_IF_VALUE_IS_NON_ZEROTOO_MANY_ROWS_OR_BYTESQUERY_IS_NOT_SUPPORTED_IN_MATERIALIZED_VIEWCANNOT_PARSE_DOMAIN_VALUE_FROM_STRINGAUTHENTICATION_FAILEDCLUSTER_DOESNT_EXISTKEEPER_EXCEPTIONPOCO_EXCEPTIONSTD_EXCEPTIONUNKNOWN_EXCEPTIONCONDITIONAL_TREE_PARENT_NOT_FOUNDILLEGAL_PROJECTION_MANIPULATOR"
+const _ErrorLowerName = "unsupported_methodunsupported_parameterunexpected_end_of_fileexpected_end_of_filecannot_parse_textincorrect_number_of_columnsthere_is_no_columnsizes_of_columns_doesnt_matchnot_found_column_in_blockposition_out_of_boundparameter_out_of_boundsizes_of_columns_in_tuple_doesnt_matchduplicate_columnno_such_column_in_tabledelimiter_in_string_literal_doesnt_matchcannot_insert_element_into_constant_columnsize_of_fixed_string_doesnt_matchnumber_of_columns_doesnt_matchcannot_read_all_data_from_tab_separated_inputcannot_parse_all_value_from_tab_separated_inputcannot_read_from_istreamcannot_write_to_ostreamcannot_parse_escape_sequencecannot_parse_quoted_stringcannot_parse_input_assertion_failedcannot_print_float_or_double_numbercannot_print_integercannot_read_size_of_compressed_chunkcannot_read_compressed_chunkattempt_to_read_after_eofcannot_read_all_datatoo_many_arguments_for_functiontoo_less_arguments_for_functionbad_argumentsunknown_element_in_astcannot_parse_datetoo_large_size_compressedchecksum_doesnt_matchcannot_parse_datetimenumber_of_arguments_doesnt_matchillegal_type_of_argumentillegal_columnillegal_number_of_result_columnsunknown_functionunknown_identifiernot_implementedlogical_errorunknown_typeempty_list_of_columns_queriedcolumn_queried_more_than_oncetype_mismatchstorage_doesnt_allow_parametersstorage_requires_parameterunknown_storagetable_already_existstable_metadata_already_existsillegal_type_of_column_for_filterunknown_tableonly_filter_column_in_blocksyntax_errorunknown_aggregate_functioncannot_read_aggregate_function_from_textcannot_write_aggregate_function_as_textnot_a_columnillegal_key_of_aggregationcannot_get_size_of_fieldargument_out_of_boundcannot_convert_typecannot_write_after_end_of_buffercannot_parse_numberunknown_formatcannot_read_from_file_descriptorcannot_write_to_file_descriptorcannot_open_filecannot_close_fileunknown_type_of_queryincorrect_file_nameincorrect_queryunknown_databasedatabase_already_existsdirectory_doesnt_existdirectory_already_existsformat_is_not_suitable_for_inputreceived_error_from_remote_io_servercannot_seek_through_filecannot_truncate_fileunknown_compression_methodempty_list_of_columns_passedsizes_of_marks_files_are_inconsistentempty_data_passedunknown_aggregated_data_variantcannot_merge_different_aggregated_data_variantscannot_read_from_socketcannot_write_to_socketcannot_read_all_data_from_chunked_inputcannot_write_to_empty_block_output_streamunknown_packet_from_clientunknown_packet_from_serverunexpected_packet_from_clientunexpected_packet_from_serverreceived_data_for_wrong_query_idtoo_small_buffer_sizecannot_read_historycannot_append_historyfile_doesnt_existno_data_to_insertcannot_block_signalcannot_unblock_signalcannot_manipulate_sigsetcannot_wait_for_signalthere_is_no_sessioncannot_clock_gettimeunknown_settingthere_is_no_default_valueincorrect_dataengine_requiredcannot_insert_value_of_different_size_into_tupleunknown_set_data_variantincompatible_columnsunknown_type_of_ast_nodeincorrect_element_of_setincorrect_result_of_scalar_subquerycannot_get_return_typeillegal_indextoo_large_array_sizefunction_is_specialcannot_read_array_from_texttoo_large_string_sizecannot_create_table_from_metadataaggregate_function_doesnt_allow_parametersparameters_to_aggregate_functions_must_be_literalszero_array_or_tuple_indexunknown_element_in_configexcessive_element_in_configno_elements_in_configall_requested_columns_are_missingsampling_not_supportednot_found_nodefound_more_than_one_nodefirst_date_is_bigger_than_last_dateunknown_overflow_modequery_section_doesnt_make_sensenot_found_function_element_for_aggregatenot_found_relation_element_for_conditionnot_found_rhs_element_for_conditionno_attributes_listedindex_of_column_in_sort_clause_is_out_of_rangeunknown_direction_of_sortingillegal_divisionaggregate_function_not_applicableunknown_relationdictionaries_was_not_loadedillegal_overflow_modetoo_many_rowstimeout_exceededtoo_slowtoo_many_columnstoo_deep_subqueriestoo_deep_pipelinereadonlytoo_many_temporary_columnstoo_many_temporary_non_const_columnstoo_deep_asttoo_big_astbad_type_of_fieldbad_getblocks_have_different_structurecannot_create_directorycannot_allocate_memorycyclic_aliaseschunk_not_foundduplicate_chunk_namemultiple_aliases_for_expressionmultiple_expressions_for_aliasthere_is_no_profileillegal_finalillegal_prewhereunexpected_expressionillegal_aggregationunsupported_myisam_block_typeunsupported_collation_localecollation_comparison_failedunknown_actiontable_must_not_be_created_manuallysizes_of_arrays_doesnt_matchset_size_limit_exceededunknown_userwrong_passwordrequired_passwordip_address_not_allowedunknown_address_pattern_typeserver_revision_is_too_olddns_errorunknown_quotaquota_doesnt_allow_keysquota_expiredtoo_many_simultaneous_queriesno_free_connectioncannot_fsyncnested_type_too_deepalias_requiredambiguous_identifierempty_nested_tablesocket_timeoutnetwork_errorempty_queryunknown_load_balancingunknown_totals_modecannot_statvfsnot_an_aggregatequery_with_same_id_is_already_runningclient_has_connected_to_wrong_porttable_is_droppeddatabase_not_emptyduplicate_interserver_io_endpointno_such_interserver_io_endpointadding_replica_to_non_empty_tableunexpected_ast_structurereplica_is_already_activeno_zookeeperno_file_in_data_partunexpected_file_in_data_partbad_size_of_file_in_data_partquery_is_too_largenot_found_expected_data_parttoo_many_unexpected_data_partsno_such_data_partbad_data_part_nameno_replica_has_partduplicate_data_partabortedno_replica_name_givenformat_version_too_oldcannot_munmapcannot_mremapmemory_limit_exceededtable_is_read_onlynot_enough_spaceunexpected_zookeeper_errorcorrupted_dataincorrect_markinvalid_partition_valuenot_enough_block_numbersno_such_replicatoo_many_partsreplica_is_already_existno_active_replicastoo_many_retries_to_fetch_partspartition_already_existspartition_doesnt_existunion_all_result_structures_mismatchclient_output_format_specifiedunknown_block_info_fieldbad_collationcannot_compile_codeincompatible_type_of_joinno_available_replicamismatch_replicas_data_sourcesstorage_doesnt_support_parallel_replicascpuid_errorinfinite_loopcannot_compresscannot_decompressaio_submit_erroraio_completion_erroraio_read_erroraio_write_errorindex_not_usedleadership_lostall_connection_tries_failedno_available_datadictionary_is_emptyincorrect_indexunknown_distributed_product_modeunknown_global_subqueries_methodtoo_less_live_replicasunsatisfied_quorum_for_previous_writeunknown_format_versiondistributed_in_join_subquery_deniedreplica_is_not_in_quorumlimit_exceededdatabase_access_deniedleadership_changedmongodb_cannot_authenticateinvalid_block_extra_inforeceived_empty_datano_remote_shard_foundshard_has_no_connectionscannot_pipecannot_forkcannot_dlsymcannot_create_child_processchild_was_not_exited_normallycannot_selectcannot_waitpidtable_was_not_droppedtoo_deep_recursiontoo_many_bytesunexpected_node_in_zookeeperfunction_cannot_have_parametersinvalid_shard_weightinvalid_config_parameterunknown_status_of_insertvalue_is_out_of_range_of_data_typebarrier_timeoutunknown_database_engineddl_guard_is_activeunfinishedmetadata_mismatchsupport_is_disabledtable_differs_too_muchcannot_convert_charsetcannot_load_configcannot_insert_null_in_ordinary_columnincompatible_source_tablesambiguous_table_nameambiguous_column_nameindex_of_positional_argument_is_out_of_rangezlib_inflate_failedzlib_deflate_failedbad_lambdareserved_identifier_nameinto_outfile_not_allowedtable_size_exceeds_max_drop_size_limitcannot_create_charset_converterseek_position_out_of_boundcurrent_write_buffer_is_exhaustedcannot_create_io_bufferreceived_error_too_many_requestsoutput_is_not_sortedsizes_of_nested_columns_are_inconsistenttoo_many_fetchesbad_castall_replicas_are_staledata_type_cannot_be_used_in_tablesinconsistent_cluster_definitionsession_not_foundsession_is_lockedinvalid_session_timeoutcannot_dlopencannot_parse_uuidillegal_syntax_for_data_typedata_type_cannot_have_argumentsunknown_status_of_distributed_ddl_taskcannot_killhttp_length_requiredcannot_load_catboost_modelcannot_apply_catboost_modelpart_is_temporarily_lockedmultiple_streams_requiredno_common_typeexternal_loadable_already_existscannot_assign_optimizeinsert_was_deduplicatedcannot_get_create_table_queryexternal_library_errorquery_is_prohibitedthere_is_no_queryquery_was_cancelledfunction_throw_if_value_is_non_zerotoo_many_rows_or_bytesquery_is_not_supported_in_materialized_viewcannot_parse_domain_value_from_stringauthentication_failedcluster_doesnt_existkeeper_exceptionpoco_exceptionstd_exceptionunknown_exceptionconditional_tree_parent_not_foundillegal_projection_manipulator"
 
 var _ErrorMap = map[Error]string{
 	1:    _ErrorName[0:18],
@@ -375,12 +375,13 @@ var _ErrorMap = map[Error]string{
 	397:  _ErrorName[8326:8369],
 	441:  _ErrorName[8369:8406],
 	516:  _ErrorName[8406:8427],
-	999:  _ErrorName[8427:8443],
-	1000: _ErrorName[8443:8457],
-	1001: _ErrorName[8457:8470],
-	1002: _ErrorName[8470:8487],
-	2001: _ErrorName[8487:8520],
-	2002: _ErrorName[8520:8550],
+	701:  _ErrorName[8427:8447],
+	999:  _ErrorName[8447:8463],
+	1000: _ErrorName[8463:8477],
+	1001: _ErrorName[8477:8490],
+	1002: _ErrorName[8490:8507],
+	2001: _ErrorName[8507:8540],
+	2002: _ErrorName[8540:8570],
 }
 
 func (i Error) String() string {
@@ -758,6 +759,7 @@ func _ErrorNoOp() {
 	_ = x[ErrQueryIsNotSupportedInMaterializedView-(397)]
 	_ = x[ErrCannotParseDomainValueFromString-(441)]
 	_ = x[ErrAuthenticationFailed-(516)]
+	_ = x[ErrClusterDoesntExist-(701)]
 	_ = x[ErrKeeperException-(999)]
 	_ = x[ErrPocoException-(1000)]
 	_ = x[ErrStdException-(1001)]
@@ -766,7 +768,7 @@ func _ErrorNoOp() {
 	_ = x[ErrIllegalProjectionManipulator-(2002)]
 }
 
-var _ErrorValues = []Error{ErrUnsupportedMethod, ErrUnsupportedParameter, ErrUnexpectedEndOfFile, ErrExpectedEndOfFile, ErrCannotParseText, ErrIncorrectNumberOfColumns, ErrThereIsNoColumn, ErrSizesOfColumnsDoesntMatch, ErrNotFoundColumnInBlock, ErrPositionOutOfBound, ErrParameterOutOfBound, ErrSizesOfColumnsInTupleDoesntMatch, ErrDuplicateColumn, ErrNoSuchColumnInTable, ErrDelimiterInStringLiteralDoesntMatch, ErrCannotInsertElementIntoConstantColumn, ErrSizeOfFixedStringDoesntMatch, ErrNumberOfColumnsDoesntMatch, ErrCannotReadAllDataFromTabSeparatedInput, ErrCannotParseAllValueFromTabSeparatedInput, ErrCannotReadFromIstream, ErrCannotWriteToOstream, ErrCannotParseEscapeSequence, ErrCannotParseQuotedString, ErrCannotParseInputAssertionFailed, ErrCannotPrintFloatOrDoubleNumber, ErrCannotPrintInteger, ErrCannotReadSizeOfCompressedChunk, ErrCannotReadCompressedChunk, ErrAttemptToReadAfterEOF, ErrCannotReadAllData, ErrTooManyArgumentsForFunction, ErrTooLessArgumentsForFunction, ErrBadArguments, ErrUnknownElementInAst, ErrCannotParseDate, ErrTooLargeSizeCompressed, ErrChecksumDoesntMatch, ErrCannotParseDatetime, ErrNumberOfArgumentsDoesntMatch, ErrIllegalTypeOfArgument, ErrIllegalColumn, ErrIllegalNumberOfResultColumns, ErrUnknownFunction, ErrUnknownIdentifier, ErrNotImplemented, ErrLogicalError, ErrUnknownType, ErrEmptyListOfColumnsQueried, ErrColumnQueriedMoreThanOnce, ErrTypeMismatch, ErrStorageDoesntAllowParameters, ErrStorageRequiresParameter, ErrUnknownStorage, ErrTableAlreadyExists, ErrTableMetadataAlreadyExists, ErrIllegalTypeOfColumnForFilter, ErrUnknownTable, ErrOnlyFilterColumnInBlock, ErrSyntaxError, ErrUnknownAggregateFunction, ErrCannotReadAggregateFunctionFromText, ErrCannotWriteAggregateFunctionAsText, ErrNotAColumn, ErrIllegalKeyOfAggregation, ErrCannotGetSizeOfField, ErrArgumentOutOfBound, ErrCannotConvertType, ErrCannotWriteAfterEndOfBuffer, ErrCannotParseNumber, ErrUnknownFormat, ErrCannotReadFromFileDescriptor, ErrCannotWriteToFileDescriptor, ErrCannotOpenFile, ErrCannotCloseFile, ErrUnknownTypeOfQuery, ErrIncorrectFileName, ErrIncorrectQuery, ErrUnknownDatabase, ErrDatabaseAlreadyExists, ErrDirectoryDoesntExist, ErrDirectoryAlreadyExists, ErrFormatIsNotSuitableForInput, ErrReceivedErrorFromRemoteIoServer, ErrCannotSeekThroughFile, ErrCannotTruncateFile, ErrUnknownCompressionMethod, ErrEmptyListOfColumnsPassed, ErrSizesOfMarksFilesAreInconsistent, ErrEmptyDataPassed, ErrUnknownAggregatedDataVariant, ErrCannotMergeDifferentAggregatedDataVariants, ErrCannotReadFromSocket, ErrCannotWriteToSocket, ErrCannotReadAllDataFromChunkedInput, ErrCannotWriteToEmptyBlockOutputStream, ErrUnknownPacketFromClient, ErrUnknownPacketFromServer, ErrUnexpectedPacketFromClient, ErrUnexpectedPacketFromServer, ErrReceivedDataForWrongQueryID, ErrTooSmallBufferSize, ErrCannotReadHistory, ErrCannotAppendHistory, ErrFileDoesntExist, ErrNoDataToInsert, ErrCannotBlockSignal, ErrCannotUnblockSignal, ErrCannotManipulateSigset, ErrCannotWaitForSignal, ErrThereIsNoSession, ErrCannotClockGettime, ErrUnknownSetting, ErrThereIsNoDefaultValue, ErrIncorrectData, ErrEngineRequired, ErrCannotInsertValueOfDifferentSizeIntoTuple, ErrUnknownSetDataVariant, ErrIncompatibleColumns, ErrUnknownTypeOfAstNode, ErrIncorrectElementOfSet, ErrIncorrectResultOfScalarSubquery, ErrCannotGetReturnType, ErrIllegalIndex, ErrTooLargeArraySize, ErrFunctionIsSpecial, ErrCannotReadArrayFromText, ErrTooLargeStringSize, ErrCannotCreateTableFromMetadata, ErrAggregateFunctionDoesntAllowParameters, ErrParametersToAggregateFunctionsMustBeLiterals, ErrZeroArrayOrTupleIndex, ErrUnknownElementInConfig, ErrExcessiveElementInConfig, ErrNoElementsInConfig, ErrAllRequestedColumnsAreMissing, ErrSamplingNotSupported, ErrNotFoundNode, ErrFoundMoreThanOneNode, ErrFirstDateIsBiggerThanLastDate, ErrUnknownOverflowMode, ErrQuerySectionDoesntMakeSense, ErrNotFoundFunctionElementForAggregate, ErrNotFoundRelationElementForCondition, ErrNotFoundRHSElementForCondition, ErrNoAttributesListed, ErrIndexOfColumnInSortClauseIsOutOfRange, ErrUnknownDirectionOfSorting, ErrIllegalDivision, ErrAggregateFunctionNotApplicable, ErrUnknownRelation, ErrDictionariesWasNotLoaded, ErrIllegalOverflowMode, ErrTooManyRows, ErrTimeoutExceeded, ErrTooSlow, ErrTooManyColumns, ErrTooDeepSubqueries, ErrTooDeepPipeline, ErrReadonly, ErrTooManyTemporaryColumns, ErrTooManyTemporaryNonConstColumns, ErrTooDeepAst, ErrTooBigAst, ErrBadTypeOfField, ErrBadGet, ErrBlocksHaveDifferentStructure, ErrCannotCreateDirectory, ErrCannotAllocateMemory, ErrCyclicAliases, ErrChunkNotFound, ErrDuplicateChunkName, ErrMultipleAliasesForExpression, ErrMultipleExpressionsForAlias, ErrThereIsNoProfile, ErrIllegalFinal, ErrIllegalPrewhere, ErrUnexpectedExpression, ErrIllegalAggregation, ErrUnsupportedMyisamBlockType, ErrUnsupportedCollationLocale, ErrCollationComparisonFailed, ErrUnknownAction, ErrTableMustNotBeCreatedManually, ErrSizesOfArraysDoesntMatch, ErrSetSizeLimitExceeded, ErrUnknownUser, ErrWrongPassword, ErrRequiredPassword, ErrIPAddressNotAllowed, ErrUnknownAddressPatternType, ErrServerRevisionIsTooOld, ErrDNSError, ErrUnknownQuota, ErrQuotaDoesntAllowKeys, ErrQuotaExpired, ErrTooManySimultaneousQueries, ErrNoFreeConnection, ErrCannotFsync, ErrNestedTypeTooDeep, ErrAliasRequired, ErrAmbiguousIdentifier, ErrEmptyNestedTable, ErrSocketTimeout, ErrNetworkError, ErrEmptyQuery, ErrUnknownLoadBalancing, ErrUnknownTotalsMode, ErrCannotStatvfs, ErrNotAnAggregate, ErrQueryWithSameIDIsAlreadyRunning, ErrClientHasConnectedToWrongPort, ErrTableIsDropped, ErrDatabaseNotEmpty, ErrDuplicateInterserverIoEndpoint, ErrNoSuchInterserverIoEndpoint, ErrAddingReplicaToNonEmptyTable, ErrUnexpectedAstStructure, ErrReplicaIsAlreadyActive, ErrNoZookeeper, ErrNoFileInDataPart, ErrUnexpectedFileInDataPart, ErrBadSizeOfFileInDataPart, ErrQueryIsTooLarge, ErrNotFoundExpectedDataPart, ErrTooManyUnexpectedDataParts, ErrNoSuchDataPart, ErrBadDataPartName, ErrNoReplicaHasPart, ErrDuplicateDataPart, ErrAborted, ErrNoReplicaNameGiven, ErrFormatVersionTooOld, ErrCannotMunmap, ErrCannotMremap, ErrMemoryLimitExceeded, ErrTableIsReadOnly, ErrNotEnoughSpace, ErrUnexpectedZookeeperError, ErrCorruptedData, ErrIncorrectMark, ErrInvalidPartitionValue, ErrNotEnoughBlockNumbers, ErrNoSuchReplica, ErrTooManyParts, ErrReplicaIsAlreadyExist, ErrNoActiveReplicas, ErrTooManyRetriesToFetchParts, ErrPartitionAlreadyExists, ErrPartitionDoesntExist, ErrUnionAllResultStructuresMismatch, ErrClientOutputFormatSpecified, ErrUnknownBlockInfoField, ErrBadCollation, ErrCannotCompileCode, ErrIncompatibleTypeOfJoin, ErrNoAvailableReplica, ErrMismatchReplicasDataSources, ErrStorageDoesntSupportParallelReplicas, ErrCPUIDError, ErrInfiniteLoop, ErrCannotCompress, ErrCannotDecompress, ErrAioSubmitError, ErrAioCompletionError, ErrAioReadError, ErrAioWriteError, ErrIndexNotUsed, ErrLeadershipLost, ErrAllConnectionTriesFailed, ErrNoAvailableData, ErrDictionaryIsEmpty, ErrIncorrectIndex, ErrUnknownDistributedProductMode, ErrUnknownGlobalSubqueriesMethod, ErrTooLessLiveReplicas, ErrUnsatisfiedQuorumForPreviousWrite, ErrUnknownFormatVersion, ErrDistributedInJoinSubqueryDenied, ErrReplicaIsNotInQuorum, ErrLimitExceeded, ErrDatabaseAccessDenied, ErrLeadershipChanged, ErrMongodbCannotAuthenticate, ErrInvalidBlockExtraInfo, ErrReceivedEmptyData, ErrNoRemoteShardFound, ErrShardHasNoConnections, ErrCannotPipe, ErrCannotFork, ErrCannotDlsym, ErrCannotCreateChildProcess, ErrChildWasNotExitedNormally, ErrCannotSelect, ErrCannotWaitpid, ErrTableWasNotDropped, ErrTooDeepRecursion, ErrTooManyBytes, ErrUnexpectedNodeInZookeeper, ErrFunctionCannotHaveParameters, ErrInvalidShardWeight, ErrInvalidConfigParameter, ErrUnknownStatusOfInsert, ErrValueIsOutOfRangeOfDataType, ErrBarrierTimeout, ErrUnknownDatabaseEngine, ErrDdlGuardIsActive, ErrUnfinished, ErrMetadataMismatch, ErrSupportIsDisabled, ErrTableDiffersTooMuch, ErrCannotConvertCharset, ErrCannotLoadConfig, ErrCannotInsertNullInOrdinaryColumn, ErrIncompatibleSourceTables, ErrAmbiguousTableName, ErrAmbiguousColumnName, ErrIndexOfPositionalArgumentIsOutOfRange, ErrZlibInflateFailed, ErrZlibDeflateFailed, ErrBadLambda, ErrReservedIdentifierName, ErrIntoOutfileNotAllowed, ErrTableSizeExceedsMaxDropSizeLimit, ErrCannotCreateCharsetConverter, ErrSeekPositionOutOfBound, ErrCurrentWriteBufferIsExhausted, ErrCannotCreateIoBuffer, ErrReceivedErrorTooManyRequests, ErrOutputIsNotSorted, ErrSizesOfNestedColumnsAreInconsistent, ErrTooManyFetches, ErrBadCast, ErrAllReplicasAreStale, ErrDataTypeCannotBeUsedInTables, ErrInconsistentClusterDefinition, ErrSessionNotFound, ErrSessionIsLocked, ErrInvalidSessionTimeout, ErrCannotDlopen, ErrCannotParseUUID, ErrIllegalSyntaxForDataType, ErrDataTypeCannotHaveArguments, ErrUnknownStatusOfDistributedDdlTask, ErrCannotKill, ErrHTTPLengthRequired, ErrCannotLoadCatboostModel, ErrCannotApplyCatboostModel, ErrPartIsTemporarilyLocked, ErrMultipleStreamsRequired, ErrNoCommonType, ErrExternalLoadableAlreadyExists, ErrCannotAssignOptimize, ErrInsertWasDeduplicated, ErrCannotGetCreateTableQuery, ErrExternalLibraryError, ErrQueryIsProhibited, ErrThereIsNoQuery, ErrQueryWasCancelled, ErrFunctionThrowIfValueIsNonZero, ErrTooManyRowsOrBytes, ErrQueryIsNotSupportedInMaterializedView, ErrCannotParseDomainValueFromString, ErrAuthenticationFailed, ErrKeeperException, ErrPocoException, ErrStdException, ErrUnknownException, ErrConditionalTreeParentNotFound, ErrIllegalProjectionManipulator}
+var _ErrorValues = []Error{ErrUnsupportedMethod, ErrUnsupportedParameter, ErrUnexpectedEndOfFile, ErrExpectedEndOfFile, ErrCannotParseText, ErrIncorrectNumberOfColumns, ErrThereIsNoColumn, ErrSizesOfColumnsDoesntMatch, ErrNotFoundColumnInBlock, ErrPositionOutOfBound, ErrParameterOutOfBound, ErrSizesOfColumnsInTupleDoesntMatch, ErrDuplicateColumn, ErrNoSuchColumnInTable, ErrDelimiterInStringLiteralDoesntMatch, ErrCannotInsertElementIntoConstantColumn, ErrSizeOfFixedStringDoesntMatch, ErrNumberOfColumnsDoesntMatch, ErrCannotReadAllDataFromTabSeparatedInput, ErrCannotParseAllValueFromTabSeparatedInput, ErrCannotReadFromIstream, ErrCannotWriteToOstream, ErrCannotParseEscapeSequence, ErrCannotParseQuotedString, ErrCannotParseInputAssertionFailed, ErrCannotPrintFloatOrDoubleNumber, ErrCannotPrintInteger, ErrCannotReadSizeOfCompressedChunk, ErrCannotReadCompressedChunk, ErrAttemptToReadAfterEOF, ErrCannotReadAllData, ErrTooManyArgumentsForFunction, ErrTooLessArgumentsForFunction, ErrBadArguments, ErrUnknownElementInAst, ErrCannotParseDate, ErrTooLargeSizeCompressed, ErrChecksumDoesntMatch, ErrCannotParseDatetime, ErrNumberOfArgumentsDoesntMatch, ErrIllegalTypeOfArgument, ErrIllegalColumn, ErrIllegalNumberOfResultColumns, ErrUnknownFunction, ErrUnknownIdentifier, ErrNotImplemented, ErrLogicalError, ErrUnknownType, ErrEmptyListOfColumnsQueried, ErrColumnQueriedMoreThanOnce, ErrTypeMismatch, ErrStorageDoesntAllowParameters, ErrStorageRequiresParameter, ErrUnknownStorage, ErrTableAlreadyExists, ErrTableMetadataAlreadyExists, ErrIllegalTypeOfColumnForFilter, ErrUnknownTable, ErrOnlyFilterColumnInBlock, ErrSyntaxError, ErrUnknownAggregateFunction, ErrCannotReadAggregateFunctionFromText, ErrCannotWriteAggregateFunctionAsText, ErrNotAColumn, ErrIllegalKeyOfAggregation, ErrCannotGetSizeOfField, ErrArgumentOutOfBound, ErrCannotConvertType, ErrCannotWriteAfterEndOfBuffer, ErrCannotParseNumber, ErrUnknownFormat, ErrCannotReadFromFileDescriptor, ErrCannotWriteToFileDescriptor, ErrCannotOpenFile, ErrCannotCloseFile, ErrUnknownTypeOfQuery, ErrIncorrectFileName, ErrIncorrectQuery, ErrUnknownDatabase, ErrDatabaseAlreadyExists, ErrDirectoryDoesntExist, ErrDirectoryAlreadyExists, ErrFormatIsNotSuitableForInput, ErrReceivedErrorFromRemoteIoServer, ErrCannotSeekThroughFile, ErrCannotTruncateFile, ErrUnknownCompressionMethod, ErrEmptyListOfColumnsPassed, ErrSizesOfMarksFilesAreInconsistent, ErrEmptyDataPassed, ErrUnknownAggregatedDataVariant, ErrCannotMergeDifferentAggregatedDataVariants, ErrCannotReadFromSocket, ErrCannotWriteToSocket, ErrCannotReadAllDataFromChunkedInput, ErrCannotWriteToEmptyBlockOutputStream, ErrUnknownPacketFromClient, ErrUnknownPacketFromServer, ErrUnexpectedPacketFromClient, ErrUnexpectedPacketFromServer, ErrReceivedDataForWrongQueryID, ErrTooSmallBufferSize, ErrCannotReadHistory, ErrCannotAppendHistory, ErrFileDoesntExist, ErrNoDataToInsert, ErrCannotBlockSignal, ErrCannotUnblockSignal, ErrCannotManipulateSigset, ErrCannotWaitForSignal, ErrThereIsNoSession, ErrCannotClockGettime, ErrUnknownSetting, ErrThereIsNoDefaultValue, ErrIncorrectData, ErrEngineRequired, ErrCannotInsertValueOfDifferentSizeIntoTuple, ErrUnknownSetDataVariant, ErrIncompatibleColumns, ErrUnknownTypeOfAstNode, ErrIncorrectElementOfSet, ErrIncorrectResultOfScalarSubquery, ErrCannotGetReturnType, ErrIllegalIndex, ErrTooLargeArraySize, ErrFunctionIsSpecial, ErrCannotReadArrayFromText, ErrTooLargeStringSize, ErrCannotCreateTableFromMetadata, ErrAggregateFunctionDoesntAllowParameters, ErrParametersToAggregateFunctionsMustBeLiterals, ErrZeroArrayOrTupleIndex, ErrUnknownElementInConfig, ErrExcessiveElementInConfig, ErrNoElementsInConfig, ErrAllRequestedColumnsAreMissing, ErrSamplingNotSupported, ErrNotFoundNode, ErrFoundMoreThanOneNode, ErrFirstDateIsBiggerThanLastDate, ErrUnknownOverflowMode, ErrQuerySectionDoesntMakeSense, ErrNotFoundFunctionElementForAggregate, ErrNotFoundRelationElementForCondition, ErrNotFoundRHSElementForCondition, ErrNoAttributesListed, ErrIndexOfColumnInSortClauseIsOutOfRange, ErrUnknownDirectionOfSorting, ErrIllegalDivision, ErrAggregateFunctionNotApplicable, ErrUnknownRelation, ErrDictionariesWasNotLoaded, ErrIllegalOverflowMode, ErrTooManyRows, ErrTimeoutExceeded, ErrTooSlow, ErrTooManyColumns, ErrTooDeepSubqueries, ErrTooDeepPipeline, ErrReadonly, ErrTooManyTemporaryColumns, ErrTooManyTemporaryNonConstColumns, ErrTooDeepAst, ErrTooBigAst, ErrBadTypeOfField, ErrBadGet, ErrBlocksHaveDifferentStructure, ErrCannotCreateDirectory, ErrCannotAllocateMemory, ErrCyclicAliases, ErrChunkNotFound, ErrDuplicateChunkName, ErrMultipleAliasesForExpression, ErrMultipleExpressionsForAlias, ErrThereIsNoProfile, ErrIllegalFinal, ErrIllegalPrewhere, ErrUnexpectedExpression, ErrIllegalAggregation, ErrUnsupportedMyisamBlockType, ErrUnsupportedCollationLocale, ErrCollationComparisonFailed, ErrUnknownAction, ErrTableMustNotBeCreatedManually, ErrSizesOfArraysDoesntMatch, ErrSetSizeLimitExceeded, ErrUnknownUser, ErrWrongPassword, ErrRequiredPassword, ErrIPAddressNotAllowed, ErrUnknownAddressPatternType, ErrServerRevisionIsTooOld, ErrDNSError, ErrUnknownQuota, ErrQuotaDoesntAllowKeys, ErrQuotaExpired, ErrTooManySimultaneousQueries, ErrNoFreeConnection, ErrCannotFsync, ErrNestedTypeTooDeep, ErrAliasRequired, ErrAmbiguousIdentifier, ErrEmptyNestedTable, ErrSocketTimeout, ErrNetworkError, ErrEmptyQuery, ErrUnknownLoadBalancing, ErrUnknownTotalsMode, ErrCannotStatvfs, ErrNotAnAggregate, ErrQueryWithSameIDIsAlreadyRunning, ErrClientHasConnectedToWrongPort, ErrTableIsDropped, ErrDatabaseNotEmpty, ErrDuplicateInterserverIoEndpoint, ErrNoSuchInterserverIoEndpoint, ErrAddingReplicaToNonEmptyTable, ErrUnexpectedAstStructure, ErrReplicaIsAlreadyActive, ErrNoZookeeper, ErrNoFileInDataPart, ErrUnexpectedFileInDataPart, ErrBadSizeOfFileInDataPart, ErrQueryIsTooLarge, ErrNotFoundExpectedDataPart, ErrTooManyUnexpectedDataParts, ErrNoSuchDataPart, ErrBadDataPartName, ErrNoReplicaHasPart, ErrDuplicateDataPart, ErrAborted, ErrNoReplicaNameGiven, ErrFormatVersionTooOld, ErrCannotMunmap, ErrCannotMremap, ErrMemoryLimitExceeded, ErrTableIsReadOnly, ErrNotEnoughSpace, ErrUnexpectedZookeeperError, ErrCorruptedData, ErrIncorrectMark, ErrInvalidPartitionValue, ErrNotEnoughBlockNumbers, ErrNoSuchReplica, ErrTooManyParts, ErrReplicaIsAlreadyExist, ErrNoActiveReplicas, ErrTooManyRetriesToFetchParts, ErrPartitionAlreadyExists, ErrPartitionDoesntExist, ErrUnionAllResultStructuresMismatch, ErrClientOutputFormatSpecified, ErrUnknownBlockInfoField, ErrBadCollation, ErrCannotCompileCode, ErrIncompatibleTypeOfJoin, ErrNoAvailableReplica, ErrMismatchReplicasDataSources, ErrStorageDoesntSupportParallelReplicas, ErrCPUIDError, ErrInfiniteLoop, ErrCannotCompress, ErrCannotDecompress, ErrAioSubmitError, ErrAioCompletionError, ErrAioReadError, ErrAioWriteError, ErrIndexNotUsed, ErrLeadershipLost, ErrAllConnectionTriesFailed, ErrNoAvailableData, ErrDictionaryIsEmpty, ErrIncorrectIndex, ErrUnknownDistributedProductMode, ErrUnknownGlobalSubqueriesMethod, ErrTooLessLiveReplicas, ErrUnsatisfiedQuorumForPreviousWrite, ErrUnknownFormatVersion, ErrDistributedInJoinSubqueryDenied, ErrReplicaIsNotInQuorum, ErrLimitExceeded, ErrDatabaseAccessDenied, ErrLeadershipChanged, ErrMongodbCannotAuthenticate, ErrInvalidBlockExtraInfo, ErrReceivedEmptyData, ErrNoRemoteShardFound, ErrShardHasNoConnections, ErrCannotPipe, ErrCannotFork, ErrCannotDlsym, ErrCannotCreateChildProcess, ErrChildWasNotExitedNormally, ErrCannotSelect, ErrCannotWaitpid, ErrTableWasNotDropped, ErrTooDeepRecursion, ErrTooManyBytes, ErrUnexpectedNodeInZookeeper, ErrFunctionCannotHaveParameters, ErrInvalidShardWeight, ErrInvalidConfigParameter, ErrUnknownStatusOfInsert, ErrValueIsOutOfRangeOfDataType, ErrBarrierTimeout, ErrUnknownDatabaseEngine, ErrDdlGuardIsActive, ErrUnfinished, ErrMetadataMismatch, ErrSupportIsDisabled, ErrTableDiffersTooMuch, ErrCannotConvertCharset, ErrCannotLoadConfig, ErrCannotInsertNullInOrdinaryColumn, ErrIncompatibleSourceTables, ErrAmbiguousTableName, ErrAmbiguousColumnName, ErrIndexOfPositionalArgumentIsOutOfRange, ErrZlibInflateFailed, ErrZlibDeflateFailed, ErrBadLambda, ErrReservedIdentifierName, ErrIntoOutfileNotAllowed, ErrTableSizeExceedsMaxDropSizeLimit, ErrCannotCreateCharsetConverter, ErrSeekPositionOutOfBound, ErrCurrentWriteBufferIsExhausted, ErrCannotCreateIoBuffer, ErrReceivedErrorTooManyRequests, ErrOutputIsNotSorted, ErrSizesOfNestedColumnsAreInconsistent, ErrTooManyFetches, ErrBadCast, ErrAllReplicasAreStale, ErrDataTypeCannotBeUsedInTables, ErrInconsistentClusterDefinition, ErrSessionNotFound, ErrSessionIsLocked, ErrInvalidSessionTimeout, ErrCannotDlopen, ErrCannotParseUUID, ErrIllegalSyntaxForDataType, ErrDataTypeCannotHaveArguments, ErrUnknownStatusOfDistributedDdlTask, ErrCannotKill, ErrHTTPLengthRequired, ErrCannotLoadCatboostModel, ErrCannotApplyCatboostModel, ErrPartIsTemporarilyLocked, ErrMultipleStreamsRequired, ErrNoCommonType, ErrExternalLoadableAlreadyExists, ErrCannotAssignOptimize, ErrInsertWasDeduplicated, ErrCannotGetCreateTableQuery, ErrExternalLibraryError, ErrQueryIsProhibited, ErrThereIsNoQuery, ErrQueryWasCancelled, ErrFunctionThrowIfValueIsNonZero, ErrTooManyRowsOrBytes, ErrQueryIsNotSupportedInMaterializedView, ErrCannotParseDomainValueFromString, ErrAuthenticationFailed, ErrClusterDoesntExist, ErrKeeperException, ErrPocoException, ErrStdException, ErrUnknownException, ErrConditionalTreeParentNotFound, ErrIllegalProjectionManipulator}
 
 var _ErrorNameToValueMap = map[string]Error{
 	_ErrorName[0:18]:           ErrUnsupportedMethod,
@@ -1497,18 +1499,20 @@ var _ErrorNameToValueMap = map[string]Error{
 	_ErrorLowerName[8369:8406]: ErrCannotParseDomainValueFromString,
 	_ErrorName[8406:8427]:      ErrAuthenticationFailed,
 	_ErrorLowerName[8406:8427]: ErrAuthenticationFailed,
-	_ErrorName[8427:8443]:      ErrKeeperException,
-	_ErrorLowerName[8427:8443]: ErrKeeperException,
-	_ErrorName[8443:8457]:      ErrPocoException,
-	_ErrorLowerName[8443:8457]: ErrPocoException,
-	_ErrorName[8457:8470]:      ErrStdException,
-	_ErrorLowerName[8457:8470]: ErrStdException,
-	_ErrorName[8470:8487]:      ErrUnknownException,
-	_ErrorLowerName[8470:8487]: ErrUnknownException,
-	_ErrorName[8487:8520]:      ErrConditionalTreeParentNotFound,
-	_ErrorLowerName[8487:8520]: ErrConditionalTreeParentNotFound,
-	_ErrorName[8520:8550]:      ErrIllegalProjectionManipulator,
-	_ErrorLowerName[8520:8550]: ErrIllegalProjectionManipulator,
+	_ErrorName[8427:8447]:      ErrClusterDoesntExist,
+	_ErrorLowerName[8427:8447]: ErrClusterDoesntExist,
+	_ErrorName[8447:8463]:      ErrKeeperException,
+	_ErrorLowerName[8447:8463]: ErrKeeperException,
+	_ErrorName[8463:8477]:      ErrPocoException,
+	_ErrorLowerName[8463:8477]: ErrPocoException,
+	_ErrorName[8477:8490]:      ErrStdException,
+	_ErrorLowerName[8477:8490]: ErrStdException,
+	_ErrorName[8490:8507]:      ErrUnknownException,
+	_ErrorLowerName[8490:8507]: ErrUnknownException,
+	_ErrorName[8507:8540]:      ErrConditionalTreeParentNotFound,
+	_ErrorLowerName[8507:8540]: ErrConditionalTreeParentNotFound,
+	_ErrorName[8540:8570]:      ErrIllegalProjectionManipulator,
+	_ErrorLowerName[8540:8570]: ErrIllegalProjectionManipulator,
 }
 
 var _ErrorNames = []string{
@@ -1876,12 +1880,13 @@ var _ErrorNames = []string{
 	_ErrorName[8326:8369],
 	_ErrorName[8369:8406],
 	_ErrorName[8406:8427],
-	_ErrorName[8427:8443],
-	_ErrorName[8443:8457],
-	_ErrorName[8457:8470],
-	_ErrorName[8470:8487],
-	_ErrorName[8487:8520],
-	_ErrorName[8520:8550],
+	_ErrorName[8427:8447],
+	_ErrorName[8447:8463],
+	_ErrorName[8463:8477],
+	_ErrorName[8477:8490],
+	_ErrorName[8490:8507],
+	_ErrorName[8507:8540],
+	_ErrorName[8540:8570],
 }
 
 // ErrorString retrieves an enum value from the enum constants string name.
@@ -18,3 +18,23 @@ func TestError_Error(t *testing.T) {
 
 	require.Equal(t, errors.Wrap(Error(-1), "failed").Error(), "failed: UNKNOWN (-1)")
 }
+
+func TestError_String(t *testing.T) {
+	require.Equal(t, "TIMEOUT_EXCEEDED", ErrTimeoutExceeded.String())
+	require.Equal(t, Error(159), ErrTimeoutExceeded)
+	require.Equal(t, "Error(-1)", Error(-1).String())
+
+	v, err := ErrorString("timeout_exceeded")
+	require.NoError(t, err)
+	require.Equal(t, ErrTimeoutExceeded, v)
+	_, err = ErrorString("NOT_AN_ERROR")
+	require.Error(t, err)
+
+	values := ErrorValues()
+	names := ErrorStrings()
+	require.Len(t, names, len(values))
+	for i, v := range values {
+		require.True(t, v.IsAError())
+		require.Equal(t, v.String(), names[i])
+	}
+}
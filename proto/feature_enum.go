@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-const _FeatureName = "TempTablesBlockInfoTimezoneQuotaKeyInClientInfoDisplayNameVersionPatchServerLogsColumnDefaultsMetadataClientWriteInfoSettingsSerializedAsStringsInterServerSecretOpenTelemetryXForwardedForInClientInfoRefererInClientInfoDistributedDepthQueryStartTimeProfileEventsParallelReplicasCustomSerializationQuotaKeyParametersServerQueryTimeInProgress"
-const _FeatureLowerName = "temptablesblockinfotimezonequotakeyinclientinfodisplaynameversionpatchserverlogscolumndefaultsmetadataclientwriteinfosettingsserializedasstringsinterserversecretopentelemetryxforwardedforinclientinforefererinclientinfodistributeddepthquerystarttimeprofileeventsparallelreplicascustomserializationquotakeyparametersserverquerytimeinprogress"
+const _FeatureName = "TempTablesBlockInfoTimezoneQuotaKeyInClientInfoDisplayNameVersionPatchServerLogsColumnDefaultsMetadataClientWriteInfoSettingsSerializedAsStringsInterServerSecretOpenTelemetryXForwardedForInClientInfoRefererInClientInfoDistributedDepthQueryStartTimeProfileEventsParallelReplicasCustomSerializationQuotaKeyParametersServerQueryTimeInProgressPasswordComplexityRulesInterServerSecretV2TotalBytesInProgressTimezoneUpdatesSparseSerializationSSHAuthenticationTableReadOnlyCheckSystemKeywordsTableRowsBeforeAggregationChunkedPacketsVersionedParallelReplicas"
+const _FeatureLowerName = "temptablesblockinfotimezonequotakeyinclientinfodisplaynameversionpatchserverlogscolumndefaultsmetadataclientwriteinfosettingsserializedasstringsinterserversecretopentelemetryxforwardedforinclientinforefererinclientinfodistributeddepthquerystarttimeprofileeventsparallelreplicascustomserializationquotakeyparametersserverquerytimeinprogresspasswordcomplexityrulesinterserversecretv2totalbytesinprogresstimezoneupdatessparseserializationsshauthenticationtablereadonlychecksystemkeywordstablerowsbeforeaggregationchunkedpacketsversionedparallelreplicas"
 
 var _FeatureMap = map[Feature]string{
 	50264: _FeatureName[0:10],
@@ -33,6 +33,17 @@ var _FeatureMap = map[Feature]string{
 	54458: _FeatureName[296:304],
 	54459: _FeatureName[304:314],
 	54460: _FeatureName[314:339],
+	54461: _FeatureName[339:362],
+	54462: _FeatureName[362:381],
+	54463: _FeatureName[381:401],
+	54464: _FeatureName[401:416],
+	54465: _FeatureName[416:435],
+	54466: _FeatureName[435:452],
+	54467: _FeatureName[452:470],
+	54468: _FeatureName[470:489],
+	54469: _FeatureName[489:510],
+	54470: _FeatureName[510:524],
+	54471: _FeatureName[524:549],
 }
 
 func (i Feature) String() string {
@@ -68,9 +79,20 @@ func _FeatureNoOp() {
 	_ = x[FeatureQuotaKey-(54458)]
 	_ = x[FeatureParameters-(54459)]
 	_ = x[FeatureServerQueryTimeInProgress-(54460)]
+	_ = x[FeaturePasswordComplexityRules-(54461)]
+	_ = x[FeatureInterServerSecretV2-(54462)]
+	_ = x[FeatureTotalBytesInProgress-(54463)]
+	_ = x[FeatureTimezoneUpdates-(54464)]
+	_ = x[FeatureSparseSerialization-(54465)]
+	_ = x[FeatureSSHAuthentication-(54466)]
+	_ = x[FeatureTableReadOnlyCheck-(54467)]
+	_ = x[FeatureSystemKeywordsTable-(54468)]
+	_ = x[FeatureRowsBeforeAggregation-(54469)]
+	_ = x[FeatureChunkedPackets-(54470)]
+	_ = x[FeatureVersionedParallelReplicas-(54471)]
 }
 
-var _FeatureValues = []Feature{FeatureTempTables, FeatureBlockInfo, FeatureTimezone, FeatureQuotaKeyInClientInfo, FeatureDisplayName, FeatureVersionPatch, FeatureServerLogs, FeatureColumnDefaultsMetadata, FeatureClientWriteInfo, FeatureSettingsSerializedAsStrings, FeatureInterServerSecret, FeatureOpenTelemetry, FeatureXForwardedForInClientInfo, FeatureRefererInClientInfo, FeatureDistributedDepth, FeatureQueryStartTime, FeatureProfileEvents, FeatureParallelReplicas, FeatureCustomSerialization, FeatureQuotaKey, FeatureParameters, FeatureServerQueryTimeInProgress}
+var _FeatureValues = []Feature{FeatureTempTables, FeatureBlockInfo, FeatureTimezone, FeatureQuotaKeyInClientInfo, FeatureDisplayName, FeatureVersionPatch, FeatureServerLogs, FeatureColumnDefaultsMetadata, FeatureClientWriteInfo, FeatureSettingsSerializedAsStrings, FeatureInterServerSecret, FeatureOpenTelemetry, FeatureXForwardedForInClientInfo, FeatureRefererInClientInfo, FeatureDistributedDepth, FeatureQueryStartTime, FeatureProfileEvents, FeatureParallelReplicas, FeatureCustomSerialization, FeatureQuotaKey, FeatureParameters, FeatureServerQueryTimeInProgress, FeaturePasswordComplexityRules, FeatureInterServerSecretV2, FeatureTotalBytesInProgress, FeatureTimezoneUpdates, FeatureSparseSerialization, FeatureSSHAuthentication, FeatureTableReadOnlyCheck, FeatureSystemKeywordsTable, FeatureRowsBeforeAggregation, FeatureChunkedPackets, FeatureVersionedParallelReplicas}
 
 var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureName[0:10]:         FeatureTempTables,
@@ -117,6 +139,28 @@ var _FeatureNameToValueMap = map[string]Feature{
 	_FeatureLowerName[304:314]: FeatureParameters,
 	_FeatureName[314:339]:      FeatureServerQueryTimeInProgress,
 	_FeatureLowerName[314:339]: FeatureServerQueryTimeInProgress,
+	_FeatureName[339:362]:      FeaturePasswordComplexityRules,
+	_FeatureLowerName[339:362]: FeaturePasswordComplexityRules,
+	_FeatureName[362:381]:      FeatureInterServerSecretV2,
+	_FeatureLowerName[362:381]: FeatureInterServerSecretV2,
+	_FeatureName[381:401]:      FeatureTotalBytesInProgress,
+	_FeatureLowerName[381:401]: FeatureTotalBytesInProgress,
+	_FeatureName[401:416]:      FeatureTimezoneUpdates,
+	_FeatureLowerName[401:416]: FeatureTimezoneUpdates,
+	_FeatureName[416:435]:      FeatureSparseSerialization,
+	_FeatureLowerName[416:435]: FeatureSparseSerialization,
+	_FeatureName[435:452]:      FeatureSSHAuthentication,
+	_FeatureLowerName[435:452]: FeatureSSHAuthentication,
+	_FeatureName[452:470]:      FeatureTableReadOnlyCheck,
+	_FeatureLowerName[452:470]: FeatureTableReadOnlyCheck,
+	_FeatureName[470:489]:      FeatureSystemKeywordsTable,
+	_FeatureLowerName[470:489]: FeatureSystemKeywordsTable,
+	_FeatureName[489:510]:      FeatureRowsBeforeAggregation,
+	_FeatureLowerName[489:510]: FeatureRowsBeforeAggregation,
+	_FeatureName[510:524]:      FeatureChunkedPackets,
+	_FeatureLowerName[510:524]: FeatureChunkedPackets,
+	_FeatureName[524:549]:      FeatureVersionedParallelReplicas,
+	_FeatureLowerName[524:549]: FeatureVersionedParallelReplicas,
 }
 
 var _FeatureNames = []string{
@@ -142,6 +186,17 @@ var _FeatureNames = []string{
 	_FeatureName[296:304],
 	_FeatureName[304:314],
 	_FeatureName[314:339],
+	_FeatureName[339:362],
+	_FeatureName[362:381],
+	_FeatureName[381:401],
+	_FeatureName[401:416],
+	_FeatureName[416:435],
+	_FeatureName[435:452],
+	_FeatureName[452:470],
+	_FeatureName[470:489],
+	_FeatureName[489:510],
+	_FeatureName[510:524],
+	_FeatureName[524:549],
 }
 
 // FeatureString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,73 @@
+package proto
+
+import (
+	"github.com/go-faster/errors"
+)
+
+// Input returns results as Input, e.g. to insert decoded block.
+//
+// Columns are not copied.
+func (s Results) Input() (Input, error) {
+	out := make(Input, 0, len(s))
+	for _, c := range s {
+		col, ok := c.Data.(ColInput)
+		if !ok {
+			return nil, errors.Errorf("%s: column %T is not ColInput", c.Name, c.Data)
+		}
+		out = append(out, InputColumn{Name: c.Name, Data: col})
+	}
+	return out, nil
+}
+
+// Select returns input with only specified columns in specified order.
+//
+// Columns are not copied.
+func (i Input) Select(names ...string) (Input, error) {
+	out := make(Input, 0, len(names))
+	for _, name := range names {
+		idx := -1
+		for k, c := range i {
+			if c.Name == name {
+				idx = k
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, errors.Errorf("column %q not found", name)
+		}
+		out = append(out, i[idx])
+	}
+	return out, nil
+}
+
+// Filter returns new input with copies of rows for which mask is true.
+//
+// Mask length should be equal to rows count, ColBool can be used as mask.
+func (i Input) Filter(mask []bool) (Input, error) {
+	out, err := newInputLike(i)
+	if err != nil {
+		return nil, errors.Wrap(err, "columns")
+	}
+	for k, c := range i {
+		if rows := c.Data.Rows(); rows != len(mask) {
+			return nil, errors.Errorf("%s: %d (rows) != %d (mask)", c.Name, rows, len(mask))
+		}
+		dst := out[k].Data
+		// Appending contiguous ranges of selected rows.
+		for start := 0; start < len(mask); {
+			if !mask[start] {
+				start++
+				continue
+			}
+			end := start + 1
+			for end < len(mask) && mask[end] {
+				end++
+			}
+			if !appendColumn(dst, c.Data, start, end) {
+				return nil, errors.Errorf("%s: can't append %T to %T", c.Name, c.Data, dst)
+			}
+			start = end
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput_Filter(t *testing.T) {
+	t.Parallel()
+	input := reblockInput(0, 10)
+	mask := ColBool{true, true, false, false, true, false, true, true, true, false}
+	got, err := input.Filter(mask)
+	require.NoError(t, err)
+
+	var expected Input
+	for i, v := range mask {
+		if !v {
+			continue
+		}
+		expected, err = MergeBlocks(expected, reblockInput(i, 1))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 6, got[0].Data.Rows())
+	require.Equal(t, encodeInput(t, expected), encodeInput(t, got))
+
+	none, err := input.Filter(make([]bool, 10))
+	require.NoError(t, err)
+	require.Equal(t, 0, none[0].Data.Rows())
+
+	_, err = input.Filter(mask[:5])
+	require.Error(t, err)
+}
+
+func TestInput_Select(t *testing.T) {
+	t.Parallel()
+	input := reblockInput(0, 3)
+	got, err := input.Select("null", "ints")
+	require.NoError(t, err)
+	require.Equal(t, "(\"null\",\"ints\")", got.Columns())
+	require.Equal(t, input[3].Data, got[0].Data)
+	require.Equal(t, input[0].Data, got[1].Data)
+
+	_, err = input.Select("ints", "missing")
+	require.Error(t, err)
+
+	var results Results
+	for _, c := range input {
+		results = append(results, ResultColumn{Name: c.Name, Data: c.Data.(Column)})
+	}
+	converted, err := results.Input()
+	require.NoError(t, err)
+	require.Equal(t, input, converted)
+}
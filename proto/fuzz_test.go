@@ -0,0 +1,93 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Seed corpora of fuzz targets are generated from golden files to
+// testdata/fuzz by ./cmd/ch-gen-corpus.
+
+// fuzzLimits are lowered, so fuzzer is not spending time on allocation of
+// huge, but valid, columns.
+var fuzzLimits = Limits{
+	MaxArrSize: 1 << 16,
+	MaxRows:    1 << 16,
+}
+
+// decodeColumnAuto decodes rows of column with type typ, returning nil
+// if type is not supported.
+func decodeColumnAuto(typ string, rows int, data []byte) (*ColAuto, error) {
+	col := new(ColAuto)
+	if err := col.Infer(ColumnType(typ)); err != nil {
+		return nil, nil
+	}
+	r := NewReader(bytes.NewReader(data))
+	r.SetLimits(fuzzLimits)
+	if err := col.DecodeState(r); err != nil {
+		return nil, err
+	}
+	if err := col.DecodeColumn(r, rows); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func FuzzDecodeColumn(f *testing.F) {
+	{
+		var b Buffer
+		v := colStr("foo", "bar", "baz")
+		v.EncodeColumn(&b)
+		f.Add(string(ColumnTypeString), uint16(v.Rows()), b.Buf)
+	}
+	{
+		var b Buffer
+		v := new(ColStr).LowCardinality()
+		v.AppendArr([]string{"foo", "bar", "foo"})
+		if err := v.Prepare(); err != nil {
+			f.Fatal(err)
+		}
+		v.EncodeState(&b)
+		v.EncodeColumn(&b)
+		f.Add(string(v.Type()), uint16(v.Rows()), b.Buf)
+	}
+
+	f.Fuzz(func(t *testing.T, typ string, rows uint16, data []byte) {
+		col, err := decodeColumnAuto(typ, int(rows), data)
+		if err != nil || col == nil {
+			t.Skip()
+		}
+		if col.Rows() != int(rows) {
+			t.Fatalf("%s: decoded %d rows instead of %d", typ, col.Rows(), rows)
+		}
+	})
+}
+
+func FuzzBlock_DecodeBlock(f *testing.F) {
+	{
+		var b Buffer
+		v := Block{Rows: 2, Columns: 2}
+		if err := v.EncodeBlock(&b, Version, []InputColumn{
+			{Name: "title", Data: colStr("Foo", "Bar")},
+			{Name: "data", Data: ColInt64{1, 2}},
+		}); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b.Buf)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var (
+			block   Block
+			results Results
+		)
+		r := NewReader(bytes.NewReader(data))
+		r.SetLimits(fuzzLimits)
+		if err := block.DecodeBlock(r, Version, results.Auto()); err != nil {
+			t.Skip()
+		}
+		if block.Rows != results.Rows() && block.Columns > 0 {
+			t.Fatalf("decoded %d rows instead of %d", results.Rows(), block.Rows)
+		}
+	})
+}
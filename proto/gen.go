@@ -1,3 +1,4 @@
 package proto
 
 //go:generate go run ./cmd/ch-gen-col
+//go:generate go run ./cmd/ch-gen-corpus
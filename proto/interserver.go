@@ -0,0 +1,30 @@
+package proto
+
+import (
+	"crypto/sha256"
+	"strconv"
+)
+
+// InterserverUser is user name marker of inter-server connection, which is
+// authenticated by cluster secret instead of password.
+//
+// Cluster name and salt are sent after ClientHello for such connections.
+const InterserverUser = " INTERSERVER SECRET "
+
+// InterserverHash returns hash of query for inter-server cluster secret
+// authentication, which is sent as Query.Secret.
+//
+// Nonce is provided by server with FeatureInterServerSecretV2 and should
+// be nil for older revisions.
+func (q Query) InterserverHash(salt, secret string, nonce *uint64) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(salt))
+	if nonce != nil {
+		_, _ = h.Write(strconv.AppendUint(nil, *nonce, 10))
+	}
+	_, _ = h.Write([]byte(secret))
+	_, _ = h.Write([]byte(q.Body))
+	_, _ = h.Write([]byte(q.ID))
+	_, _ = h.Write([]byte(q.Info.InitialUser))
+	return string(h.Sum(nil))
+}
@@ -0,0 +1,46 @@
+package proto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_InterserverHash(t *testing.T) {
+	q := Query{
+		ID:   "id",
+		Body: "SELECT 1",
+		Info: ClientInfo{InitialUser: "user"},
+	}
+	nonce := uint64(42)
+	require.Equal(t,
+		"ed14dc0e55453084419770632f1bf0d596beacb6d93a337311482008a8d1712d",
+		hex.EncodeToString([]byte(q.InterserverHash("salt", "secret", &nonce))),
+	)
+	require.Equal(t,
+		"7213faac15629cb2802201b57db1375ae667480e21d3e948acce80cf600914a4",
+		hex.EncodeToString([]byte(q.InterserverHash("salt", "secret", nil))),
+	)
+}
+
+func TestClientHello_interserver(t *testing.T) {
+	var b Buffer
+	v := ClientHello{
+		Name:            "ch",
+		Major:           1,
+		Minor:           1,
+		ProtocolVersion: Version,
+		Database:        "default",
+		User:            InterserverUser,
+		Cluster:         "cluster",
+		Salt:            "salt",
+	}
+	b.Encode(v)
+
+	var dec ClientHello
+	buf := skipCode(t, b.Buf, int(ClientCodeHello))
+	requireDecode(t, buf, &dec)
+	require.Equal(t, v, dec)
+	requireNoShortRead(t, buf, &dec)
+}
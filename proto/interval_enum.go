@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _IntervalScaleName = "IntervalSecondIntervalMinuteIntervalHourIntervalDayIntervalWeekIntervalMonthIntervalQuarterIntervalYear"
+const _IntervalScaleName = "IntervalSecondIntervalMinuteIntervalHourIntervalDayIntervalWeekIntervalMonthIntervalQuarterIntervalYearIntervalNanosecondIntervalMicrosecondIntervalMillisecond"
 
-var _IntervalScaleIndex = [...]uint8{0, 14, 28, 40, 51, 63, 76, 91, 103}
+var _IntervalScaleIndex = [...]uint8{0, 14, 28, 40, 51, 63, 76, 91, 103, 121, 140, 159}
 
-const _IntervalScaleLowerName = "intervalsecondintervalminuteintervalhourintervaldayintervalweekintervalmonthintervalquarterintervalyear"
+const _IntervalScaleLowerName = "intervalsecondintervalminuteintervalhourintervaldayintervalweekintervalmonthintervalquarterintervalyearintervalnanosecondintervalmicrosecondintervalmillisecond"
 
 func (i IntervalScale) String() string {
 	if i >= IntervalScale(len(_IntervalScaleIndex)-1) {
@@ -32,27 +32,36 @@ func _IntervalScaleNoOp() {
 	_ = x[IntervalMonth-(5)]
 	_ = x[IntervalQuarter-(6)]
 	_ = x[IntervalYear-(7)]
+	_ = x[IntervalNanosecond-(8)]
+	_ = x[IntervalMicrosecond-(9)]
+	_ = x[IntervalMillisecond-(10)]
 }
 
-var _IntervalScaleValues = []IntervalScale{IntervalSecond, IntervalMinute, IntervalHour, IntervalDay, IntervalWeek, IntervalMonth, IntervalQuarter, IntervalYear}
+var _IntervalScaleValues = []IntervalScale{IntervalSecond, IntervalMinute, IntervalHour, IntervalDay, IntervalWeek, IntervalMonth, IntervalQuarter, IntervalYear, IntervalNanosecond, IntervalMicrosecond, IntervalMillisecond}
 
 var _IntervalScaleNameToValueMap = map[string]IntervalScale{
-	_IntervalScaleName[0:14]:        IntervalSecond,
-	_IntervalScaleLowerName[0:14]:   IntervalSecond,
-	_IntervalScaleName[14:28]:       IntervalMinute,
-	_IntervalScaleLowerName[14:28]:  IntervalMinute,
-	_IntervalScaleName[28:40]:       IntervalHour,
-	_IntervalScaleLowerName[28:40]:  IntervalHour,
-	_IntervalScaleName[40:51]:       IntervalDay,
-	_IntervalScaleLowerName[40:51]:  IntervalDay,
-	_IntervalScaleName[51:63]:       IntervalWeek,
-	_IntervalScaleLowerName[51:63]:  IntervalWeek,
-	_IntervalScaleName[63:76]:       IntervalMonth,
-	_IntervalScaleLowerName[63:76]:  IntervalMonth,
-	_IntervalScaleName[76:91]:       IntervalQuarter,
-	_IntervalScaleLowerName[76:91]:  IntervalQuarter,
-	_IntervalScaleName[91:103]:      IntervalYear,
-	_IntervalScaleLowerName[91:103]: IntervalYear,
+	_IntervalScaleName[0:14]:         IntervalSecond,
+	_IntervalScaleLowerName[0:14]:    IntervalSecond,
+	_IntervalScaleName[14:28]:        IntervalMinute,
+	_IntervalScaleLowerName[14:28]:   IntervalMinute,
+	_IntervalScaleName[28:40]:        IntervalHour,
+	_IntervalScaleLowerName[28:40]:   IntervalHour,
+	_IntervalScaleName[40:51]:        IntervalDay,
+	_IntervalScaleLowerName[40:51]:   IntervalDay,
+	_IntervalScaleName[51:63]:        IntervalWeek,
+	_IntervalScaleLowerName[51:63]:   IntervalWeek,
+	_IntervalScaleName[63:76]:        IntervalMonth,
+	_IntervalScaleLowerName[63:76]:   IntervalMonth,
+	_IntervalScaleName[76:91]:        IntervalQuarter,
+	_IntervalScaleLowerName[76:91]:   IntervalQuarter,
+	_IntervalScaleName[91:103]:       IntervalYear,
+	_IntervalScaleLowerName[91:103]:  IntervalYear,
+	_IntervalScaleName[103:121]:      IntervalNanosecond,
+	_IntervalScaleLowerName[103:121]: IntervalNanosecond,
+	_IntervalScaleName[121:140]:      IntervalMicrosecond,
+	_IntervalScaleLowerName[121:140]: IntervalMicrosecond,
+	_IntervalScaleName[140:159]:      IntervalMillisecond,
+	_IntervalScaleLowerName[140:159]: IntervalMillisecond,
 }
 
 var _IntervalScaleNames = []string{
@@ -64,6 +73,9 @@ var _IntervalScaleNames = []string{
 	_IntervalScaleName[63:76],
 	_IntervalScaleName[76:91],
 	_IntervalScaleName[91:103],
+	_IntervalScaleName[103:121],
+	_IntervalScaleName[121:140],
+	_IntervalScaleName[140:159],
 }
 
 // IntervalScaleString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,101 @@
+package proto
+
+import (
+	"fmt"
+
+	"github.com/go-faster/errors"
+)
+
+// Default limits of decoded sizes.
+//
+// Just empirical values, there are no such limits in spec or in ClickHouse,
+// so are subject to change if false-positives occur.
+const (
+	// DefaultMaxStrLen is same as DEFAULT_MAX_STRING_SIZE of ClickHouse.
+	DefaultMaxStrLen = 1 << 30
+	// DefaultMaxArrSize allows 100M elements of all arrays in column.
+	DefaultMaxArrSize = 100_000_000
+	// DefaultMaxColumns is maximum columns in block.
+	DefaultMaxColumns = 1_000_000
+	// DefaultMaxRows is maximum rows in block.
+	//
+	// Most blocks should be less than 100M values, but technically
+	// there is no limit (can be several billions).
+	// 1B rows is too big and probably several gigabytes in RSS.
+	//
+	// The 100M UInt64 block is ~655MB RSS, should be pretty safe and
+	// protect from accidental (e.g. cosmic rays) rows count corruption.
+	DefaultMaxRows = 100_000_000
+)
+
+// Limits of sizes decoded by Reader, checked before memory is allocated,
+// so corrupted or hostile peer can't make decoder allocate gigabytes.
+//
+// Zero value of field means default.
+type Limits struct {
+	MaxStrLen  int // length of string, DefaultMaxStrLen
+	MaxArrSize int // total elements of Array, Map, geo or Nested column and LowCardinality dictionary size, DefaultMaxArrSize
+	MaxColumns int // columns in block, DefaultMaxColumns
+	MaxRows    int // rows in block, DefaultMaxRows
+}
+
+func (l *Limits) setDefaults() {
+	if l.MaxStrLen == 0 {
+		l.MaxStrLen = DefaultMaxStrLen
+	}
+	if l.MaxArrSize == 0 {
+		l.MaxArrSize = DefaultMaxArrSize
+	}
+	if l.MaxColumns == 0 {
+		l.MaxColumns = DefaultMaxColumns
+	}
+	if l.MaxRows == 0 {
+		l.MaxRows = DefaultMaxRows
+	}
+}
+
+// LimitError is returned by Reader when decoded size exceeds Limits.
+type LimitError struct {
+	Limit string // name of Limits field, like "MaxRows"
+	Size  int
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%d is suspiciously big, %s is %d (preventing possible OOM)", e.Size, e.Limit, e.Max)
+}
+
+func checkLimit(name string, n, max int) error {
+	if n < 0 {
+		return errors.Errorf("%d is negative", n)
+	}
+	if n > max {
+		return &LimitError{Limit: name, Size: n, Max: max}
+	}
+	return nil
+}
+
+// SetLimits sets limits of decoded sizes, zero fields are set to
+// defaults.
+func (r *Reader) SetLimits(l Limits) {
+	l.setDefaults()
+	r.limits = l
+}
+
+// Limits returns current limits of decoded sizes.
+func (r *Reader) Limits() Limits {
+	return r.limits
+}
+
+func (r *Reader) checkRows(n int) error {
+	return checkLimit("MaxRows", n, r.limits.MaxRows)
+}
+
+func (r *Reader) checkColumns(n int) error {
+	return checkLimit("MaxColumns", n, r.limits.MaxColumns)
+}
+
+// checkArrSize checks total number of elements of array-like column.
+func (r *Reader) checkArrSize(n int) error {
+	return checkLimit("MaxArrSize", n, r.limits.MaxArrSize)
+}
@@ -0,0 +1,94 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_SetLimits(t *testing.T) {
+	r := NewReader(bytes.NewReader(nil))
+	require.Equal(t, Limits{
+		MaxStrLen:  DefaultMaxStrLen,
+		MaxArrSize: DefaultMaxArrSize,
+		MaxColumns: DefaultMaxColumns,
+		MaxRows:    DefaultMaxRows,
+	}, r.Limits())
+
+	r.SetLimits(Limits{MaxRows: 10})
+	require.Equal(t, 10, r.Limits().MaxRows)
+	require.Equal(t, DefaultMaxStrLen, r.Limits().MaxStrLen)
+}
+
+func TestLimits(t *testing.T) {
+	encodeBlock := func(t *testing.T, cols []InputColumn) []byte {
+		var b Buffer
+		v := Block{Rows: cols[0].Data.Rows(), Columns: len(cols)}
+		require.NoError(t, v.EncodeBlock(&b, Version, cols))
+		return b.Buf
+	}
+	arr := new(ColStr).Array()
+	arr.Append([]string{"foo", "bar", "baz"})
+	arr.Append([]string{"foo"})
+
+	for _, tt := range []struct {
+		Name   string
+		Limits Limits
+		Data   []byte
+	}{
+		{
+			Name:   "MaxStrLen",
+			Limits: Limits{MaxStrLen: 5},
+			Data: encodeBlock(t, []InputColumn{
+				{Name: "s", Data: colStr("foo", "Hello, World!")},
+			}),
+		},
+		{
+			Name:   "MaxArrSize",
+			Limits: Limits{MaxArrSize: 3},
+			Data: encodeBlock(t, []InputColumn{
+				{Name: "a", Data: arr},
+			}),
+		},
+		{
+			Name:   "MaxColumns",
+			Limits: Limits{MaxColumns: 1},
+			Data: encodeBlock(t, []InputColumn{
+				{Name: "a", Data: ColInt64{1}},
+				{Name: "b", Data: ColInt64{2}},
+			}),
+		},
+		{
+			Name:   "MaxRows",
+			Limits: Limits{MaxRows: 2},
+			Data: encodeBlock(t, []InputColumn{
+				{Name: "a", Data: ColInt64{1, 2, 3}},
+			}),
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Run("Ok", func(t *testing.T) {
+				var (
+					block   Block
+					results Results
+				)
+				r := NewReader(bytes.NewReader(tt.Data))
+				require.NoError(t, block.DecodeBlock(r, Version, results.Auto()))
+			})
+			t.Run("Exceeded", func(t *testing.T) {
+				var (
+					block   Block
+					results Results
+				)
+				r := NewReader(bytes.NewReader(tt.Data))
+				r.SetLimits(tt.Limits)
+				err := block.DecodeBlock(r, Version, results.Auto())
+
+				var limitErr *LimitError
+				require.ErrorAs(t, err, &limitErr)
+				require.Equal(t, tt.Name, limitErr.Limit)
+			})
+		})
+	}
+}
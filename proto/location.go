@@ -0,0 +1,78 @@
+package proto
+
+import "time"
+
+// DefaultLocationSetter is implemented by columns with time values that
+// use default location if column type has no explicit time zone, e.g.
+// DateTime instead of DateTime('UTC').
+//
+// Composite columns propagate default location to their elements.
+type DefaultLocationSetter interface {
+	SetDefaultLocation(loc *time.Location)
+}
+
+func setDefaultLocation(v any, loc *time.Location) {
+	if s, ok := v.(DefaultLocationSetter); ok {
+		s.SetDefaultLocation(loc)
+	}
+}
+
+// Compile-time assertions for DefaultLocationSetter.
+var (
+	_ DefaultLocationSetter = (*ColDateTime)(nil)
+	_ DefaultLocationSetter = (*ColDateTime64)(nil)
+	_ DefaultLocationSetter = (*ColArr[string])(nil)
+	_ DefaultLocationSetter = (*ColNullable[string])(nil)
+	_ DefaultLocationSetter = (*ColMap[string, string])(nil)
+	_ DefaultLocationSetter = ColTuple(nil)
+	_ DefaultLocationSetter = (*ColNamed[string])(nil)
+	_ DefaultLocationSetter = (*ColAuto)(nil)
+	_ DefaultLocationSetter = Results(nil)
+	_ DefaultLocationSetter = autoResults{}
+)
+
+// SetDefaultLocation sets location that is used if column type has no
+// explicit time zone. Defaults to time.Local.
+func (c *ColDateTime) SetDefaultLocation(loc *time.Location) { c.defaultLocation = loc }
+
+// SetDefaultLocation sets location that is used if column type has no
+// explicit time zone. Defaults to time.Local.
+func (c *ColDateTime64) SetDefaultLocation(loc *time.Location) { c.defaultLocation = loc }
+
+// SetDefaultLocation propagates default location to Data.
+func (c *ColArr[T]) SetDefaultLocation(loc *time.Location) { setDefaultLocation(c.Data, loc) }
+
+// SetDefaultLocation propagates default location to Values.
+func (c *ColNullable[T]) SetDefaultLocation(loc *time.Location) { setDefaultLocation(c.Values, loc) }
+
+// SetDefaultLocation propagates default location to Keys and Values.
+func (c *ColMap[K, V]) SetDefaultLocation(loc *time.Location) {
+	setDefaultLocation(c.Keys, loc)
+	setDefaultLocation(c.Values, loc)
+}
+
+// SetDefaultLocation propagates default location to all elements.
+func (c ColTuple) SetDefaultLocation(loc *time.Location) {
+	for _, v := range c {
+		setDefaultLocation(v, loc)
+	}
+}
+
+// SetDefaultLocation propagates default location to underlying column.
+func (c *ColNamed[T]) SetDefaultLocation(loc *time.Location) {
+	setDefaultLocation(c.ColumnOf, loc)
+}
+
+// SetDefaultLocation propagates default location to Data.
+func (c *ColAuto) SetDefaultLocation(loc *time.Location) { setDefaultLocation(c.Data, loc) }
+
+// SetDefaultLocation propagates default location to all columns.
+func (s Results) SetDefaultLocation(loc *time.Location) {
+	for _, c := range s {
+		setDefaultLocation(c.Data, loc)
+	}
+}
+
+func (s autoResults) SetDefaultLocation(loc *time.Location) {
+	s.results.SetDefaultLocation(loc)
+}
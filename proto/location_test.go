@@ -0,0 +1,50 @@
+package proto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	v := time.Unix(1546290000, 0)
+
+	var (
+		dt     ColDateTime
+		dt64   = new(ColDateTime64).WithPrecision(PrecisionMilli)
+		arr    = new(ColDateTime).Array()
+		null   = new(ColDateTime).Nullable()
+		auto   ColAuto
+		withTZ = &ColDateTime{Location: time.UTC}
+	)
+	require.NoError(t, auto.Infer(ColumnTypeDateTime))
+	dt.Append(v)
+	dt64.Append(v)
+	arr.Append([]time.Time{v})
+	null.Append(NewNullable(v))
+	auto.Data.(*ColDateTime).Append(v)
+	withTZ.Append(v)
+
+	Results{
+		{Name: "dt", Data: &dt},
+		{Name: "dt64", Data: dt64},
+		{Name: "arr", Data: arr},
+		{Name: "null", Data: null},
+		{Name: "auto", Data: &auto},
+		{Name: "tz", Data: withTZ},
+	}.SetDefaultLocation(loc)
+
+	require.Equal(t, loc, dt.Row(0).Location())
+	require.Equal(t, loc, dt64.Row(0).Location())
+	require.Equal(t, loc, arr.Row(0)[0].Location())
+	require.Equal(t, loc, null.Row(0).Value.Location())
+	require.Equal(t, loc, auto.Data.(*ColDateTime).Row(0).Location())
+	require.Equal(t, time.UTC, withTZ.Row(0).Location(), "explicit location")
+	require.True(t, dt.Row(0).Equal(v))
+
+	// Type is not affected.
+	require.Equal(t, ColumnTypeDateTime, dt.Type())
+}
@@ -0,0 +1,251 @@
+package proto
+
+import "github.com/go-faster/errors"
+
+// CoordinationMode of parallel reading from replicas.
+type CoordinationMode byte
+
+// Possible coordination modes.
+const (
+	CoordinationDefault      CoordinationMode = 0
+	CoordinationWithOrder    CoordinationMode = 1
+	CoordinationReverseOrder CoordinationMode = 2
+)
+
+// ParallelReplicasVersion is version of parallel replicas protocol,
+// which is sent in each of its packets.
+//
+// Segment size of marks is sent in announcement since version 4.
+const ParallelReplicasVersion = 4
+
+// MarkRange is half-open range of marks in data part.
+type MarkRange struct {
+	Begin uint64
+	End   uint64
+}
+
+// PartInfo describes MergeTree data part.
+type PartInfo struct {
+	PartitionID       string
+	MinBlock          int64
+	MaxBlock          int64
+	Level             uint32
+	Mutation          int64
+	UseLegacyMaxLevel bool
+}
+
+// partInfoVersion is version of PartInfo serialization.
+const partInfoVersion = 1
+
+func (p PartInfo) Encode(b *Buffer) {
+	b.PutUInt64(partInfoVersion)
+	b.PutString(p.PartitionID)
+	b.PutInt64(p.MinBlock)
+	b.PutInt64(p.MaxBlock)
+	b.PutUInt32(p.Level)
+	b.PutInt64(p.Mutation)
+	putBoolText(b, p.UseLegacyMaxLevel)
+}
+
+func (p *PartInfo) Decode(r *Reader) error {
+	v, err := r.UInt64()
+	if err != nil {
+		return errors.Wrap(err, "version")
+	}
+	if v != partInfoVersion {
+		return errors.Errorf("unsupported part info version %d", v)
+	}
+	if p.PartitionID, err = r.Str(); err != nil {
+		return errors.Wrap(err, "partition id")
+	}
+	if p.MinBlock, err = r.Int64(); err != nil {
+		return errors.Wrap(err, "min block")
+	}
+	if p.MaxBlock, err = r.Int64(); err != nil {
+		return errors.Wrap(err, "max block")
+	}
+	if p.Level, err = r.UInt32(); err != nil {
+		return errors.Wrap(err, "level")
+	}
+	if p.Mutation, err = r.Int64(); err != nil {
+		return errors.Wrap(err, "mutation")
+	}
+	if p.UseLegacyMaxLevel, err = boolText(r); err != nil {
+		return errors.Wrap(err, "use legacy max level")
+	}
+	return nil
+}
+
+// RangesInDataPart describes ranges of marks to read from data part.
+type RangesInDataPart struct {
+	Info   PartInfo
+	Ranges []MarkRange
+}
+
+// RangesInDataParts describes ranges of marks to read from data parts.
+type RangesInDataParts []RangesInDataPart
+
+func (d RangesInDataParts) Encode(b *Buffer) {
+	b.PutUVarInt(uint64(len(d)))
+	for _, p := range d {
+		p.Info.Encode(b)
+		b.PutUInt64(uint64(len(p.Ranges)))
+		for _, m := range p.Ranges {
+			b.PutUInt64(m.Begin)
+			b.PutUInt64(m.End)
+		}
+	}
+}
+
+func (d *RangesInDataParts) Decode(r *Reader) error {
+	n, err := r.Int()
+	if err != nil {
+		return errors.Wrap(err, "parts")
+	}
+	*d = (*d)[:0]
+	for i := 0; i < n; i++ {
+		var p RangesInDataPart
+		if err := p.Info.Decode(r); err != nil {
+			return errors.Wrapf(err, "[%d] info", i)
+		}
+		ranges, err := r.UInt64()
+		if err != nil {
+			return errors.Wrapf(err, "[%d] ranges", i)
+		}
+		for j := uint64(0); j < ranges; j++ {
+			var m MarkRange
+			if m.Begin, err = r.UInt64(); err != nil {
+				return errors.Wrapf(err, "[%d] range [%d] begin", i, j)
+			}
+			if m.End, err = r.UInt64(); err != nil {
+				return errors.Wrapf(err, "[%d] range [%d] end", i, j)
+			}
+			p.Ranges = append(p.Ranges, m)
+		}
+		*d = append(*d, p)
+	}
+	return nil
+}
+
+// MergeTreeAllRangesAnnouncement is sent by replica to initiator with
+// all ranges that replica can read.
+type MergeTreeAllRangesAnnouncement struct {
+	Version         uint64
+	Mode            CoordinationMode
+	Description     RangesInDataParts
+	ReplicaNum      uint64
+	MarkSegmentSize uint64
+}
+
+func (a MergeTreeAllRangesAnnouncement) Encode(b *Buffer) {
+	b.PutUInt64(a.Version)
+	b.PutByte(byte(a.Mode))
+	a.Description.Encode(b)
+	b.PutUInt64(a.ReplicaNum)
+	if a.Version >= 4 {
+		b.PutUInt64(a.MarkSegmentSize)
+	}
+}
+
+func (a *MergeTreeAllRangesAnnouncement) Decode(r *Reader) error {
+	var err error
+	if a.Version, err = r.UInt64(); err != nil {
+		return errors.Wrap(err, "version")
+	}
+	mode, err := r.Byte()
+	if err != nil {
+		return errors.Wrap(err, "mode")
+	}
+	a.Mode = CoordinationMode(mode)
+	if err := a.Description.Decode(r); err != nil {
+		return errors.Wrap(err, "description")
+	}
+	if a.ReplicaNum, err = r.UInt64(); err != nil {
+		return errors.Wrap(err, "replica num")
+	}
+	if a.Version >= 4 {
+		if a.MarkSegmentSize, err = r.UInt64(); err != nil {
+			return errors.Wrap(err, "mark segment size")
+		}
+	}
+	return nil
+}
+
+// MergeTreeReadTaskRequest is sent by replica to initiator to request
+// next ranges to read.
+type MergeTreeReadTaskRequest struct {
+	Version          uint64
+	Mode             CoordinationMode
+	ReplicaNum       uint64
+	MinNumberOfMarks uint64
+	Description      RangesInDataParts
+}
+
+func (t MergeTreeReadTaskRequest) Encode(b *Buffer) {
+	b.PutUInt64(t.Version)
+	b.PutByte(byte(t.Mode))
+	b.PutUInt64(t.ReplicaNum)
+	b.PutUInt64(t.MinNumberOfMarks)
+	t.Description.Encode(b)
+}
+
+func (t *MergeTreeReadTaskRequest) Decode(r *Reader) error {
+	var err error
+	if t.Version, err = r.UInt64(); err != nil {
+		return errors.Wrap(err, "version")
+	}
+	mode, err := r.Byte()
+	if err != nil {
+		return errors.Wrap(err, "mode")
+	}
+	t.Mode = CoordinationMode(mode)
+	if t.ReplicaNum, err = r.UInt64(); err != nil {
+		return errors.Wrap(err, "replica num")
+	}
+	if t.MinNumberOfMarks, err = r.UInt64(); err != nil {
+		return errors.Wrap(err, "min number of marks")
+	}
+	if err := t.Description.Decode(r); err != nil {
+		return errors.Wrap(err, "description")
+	}
+	return nil
+}
+
+// MergeTreeReadTaskResponse is sent by initiator to replica in response
+// to MergeTreeReadTaskRequest. Finish means that there are no more ranges.
+type MergeTreeReadTaskResponse struct {
+	Version     uint64
+	Finish      bool
+	Description RangesInDataParts
+}
+
+func (t MergeTreeReadTaskResponse) Encode(b *Buffer) {
+	ClientCodeMergeTreeReadTaskResponse.Encode(b)
+	b.PutUInt64(t.Version)
+	putBoolText(b, t.Finish)
+	t.Description.Encode(b)
+}
+
+// putBoolText writes boolean as text character, '1' or '0'.
+func putBoolText(b *Buffer, v bool) {
+	if v {
+		b.PutByte('1')
+	} else {
+		b.PutByte('0')
+	}
+}
+
+func boolText(r *Reader) (bool, error) {
+	v, err := r.Byte()
+	if err != nil {
+		return false, err
+	}
+	switch v {
+	case '0':
+		return false, nil
+	case '1':
+		return true, nil
+	default:
+		return false, errors.Errorf("unexpected bool %q", v)
+	}
+}
@@ -0,0 +1,77 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelReplicas(t *testing.T) {
+	description := RangesInDataParts{
+		{
+			Info: PartInfo{
+				PartitionID: "all",
+				MinBlock:    1,
+				MaxBlock:    10,
+				Level:       2,
+				Mutation:    -1,
+			},
+			Ranges: []MarkRange{{Begin: 0, End: 8}, {Begin: 16, End: 24}},
+		},
+		{
+			Info:   PartInfo{PartitionID: "202401", UseLegacyMaxLevel: true},
+			Ranges: []MarkRange{{Begin: 1, End: 2}},
+		},
+	}
+	t.Run("Announcement", func(t *testing.T) {
+		for _, version := range []uint64{3, ParallelReplicasVersion} {
+			a := MergeTreeAllRangesAnnouncement{
+				Version:     version,
+				Mode:        CoordinationWithOrder,
+				Description: description,
+				ReplicaNum:  3,
+			}
+			if version >= 4 {
+				a.MarkSegmentSize = 16384
+			}
+			var b Buffer
+			a.Encode(&b)
+			requireNoShortRead(t, b.Buf, new(MergeTreeAllRangesAnnouncement))
+			var dec MergeTreeAllRangesAnnouncement
+			requireDecode(t, b.Buf, &dec)
+			require.Equal(t, a, dec)
+		}
+	})
+	t.Run("Request", func(t *testing.T) {
+		req := MergeTreeReadTaskRequest{
+			Version:          ParallelReplicasVersion,
+			Mode:             CoordinationDefault,
+			ReplicaNum:       1,
+			MinNumberOfMarks: 24,
+			Description:      description,
+		}
+		var b Buffer
+		req.Encode(&b)
+		requireNoShortRead(t, b.Buf, new(MergeTreeReadTaskRequest))
+		var dec MergeTreeReadTaskRequest
+		requireDecode(t, b.Buf, &dec)
+		require.Equal(t, req, dec)
+	})
+	t.Run("Response", func(t *testing.T) {
+		var b Buffer
+		MergeTreeReadTaskResponse{Version: ParallelReplicasVersion, Finish: true}.Encode(&b)
+		require.Equal(t, []byte{
+			byte(ClientCodeMergeTreeReadTaskResponse),
+			4, 0, 0, 0, 0, 0, 0, 0, // version
+			'1', // finish
+			0,   // no parts
+		}, b.Buf)
+	})
+	t.Run("BadPartInfoVersion", func(t *testing.T) {
+		var b Buffer
+		b.PutUInt64(2)
+		var info PartInfo
+		require.Error(t, info.Decode(NewReader(bytes.NewReader(b.Buf))))
+	})
+}
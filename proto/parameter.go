@@ -0,0 +1,196 @@
+package proto
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Literal is value in ClickHouse quoted text format, like 'foo', 10 or
+// [1, 2], which is used for Parameter values and nested elements of
+// composite values.
+//
+// Use Lit* constructors to build literals with correct escaping.
+type Literal string
+
+// LitNull is NULL literal.
+const LitNull Literal = "NULL"
+
+// LitString returns quoted and escaped string literal.
+func LitString(v string) Literal {
+	return Literal(appendQuoted(nil, v))
+}
+
+// LitInt returns integer literal.
+func LitInt(v int64) Literal { return Literal(strconv.FormatInt(v, 10)) }
+
+// LitUInt returns unsigned integer literal.
+func LitUInt(v uint64) Literal { return Literal(strconv.FormatUint(v, 10)) }
+
+// LitFloat returns floating point literal.
+func LitFloat(v float64) Literal {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	default:
+		return Literal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+}
+
+// LitBool returns boolean literal.
+func LitBool(v bool) Literal {
+	if v {
+		return "true"
+	}
+	return "false"
+}
+
+// LitDate returns Date literal of t in its location.
+func LitDate(t time.Time) Literal {
+	return LitString(t.Format("2006-01-02"))
+}
+
+// LitDateTime returns DateTime literal of t as unix timestamp, so value
+// does not depend on time zone of column or server.
+func LitDateTime(t time.Time) Literal {
+	return LitString(strconv.FormatInt(t.Unix(), 10))
+}
+
+// LitDateTime64 returns DateTime64 literal of t with precision p as unix
+// timestamp with fractional part, like '1700000000.123'.
+//
+// Only values after unix epoch are supported.
+func LitDateTime64(t time.Time, p Precision) Literal {
+	v := int64(ToDateTime64(t, p))
+	if p == PrecisionSecond {
+		return LitString(strconv.FormatInt(v, 10))
+	}
+	div := 1e9 / p.Scale()
+	frac := strconv.FormatInt(v%div, 10)
+	return LitString(strconv.FormatInt(v/div, 10) + "." +
+		strings.Repeat("0", int(p)-len(frac)) + frac,
+	)
+}
+
+// LitArray returns array literal of elements.
+func LitArray(elems ...Literal) Literal {
+	return Literal(appendList(nil, '[', elems, ']'))
+}
+
+// LitTuple returns tuple literal of elements.
+func LitTuple(elems ...Literal) Literal {
+	return Literal(appendList(nil, '(', elems, ')'))
+}
+
+// LitMap returns map literal of key-value pairs.
+func LitMap(pairs ...[2]Literal) Literal {
+	b := []byte{'{'}
+	for i, kv := range pairs {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, kv[0]...)
+		b = append(b, ':')
+		b = append(b, kv[1]...)
+	}
+	return Literal(append(b, '}'))
+}
+
+func appendList(b []byte, start byte, elems []Literal, end byte) []byte {
+	b = append(b, start)
+	for i, e := range elems {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, e...)
+	}
+	return append(b, end)
+}
+
+// appendQuoted appends v as quoted string, escaping special characters.
+func appendQuoted(b []byte, v string) []byte {
+	b = append(b, '\'')
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; c {
+		case '\'', '\\':
+			b = append(b, '\\', c)
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\f':
+			b = append(b, '\\', 'f')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		case 0:
+			b = append(b, '\\', '0')
+		default:
+			b = append(b, c)
+		}
+	}
+	return append(b, '\'')
+}
+
+// Param returns query parameter with value v, to be used like {key:Type}
+// in query body.
+//
+// Value of top-level string literal is used without quotes, as server
+// expects, while nested values are kept quoted.
+func Param(key string, v Literal) Parameter {
+	text := string(v)
+	switch {
+	case v == LitNull:
+		text = `\N`
+	case len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'':
+		// Escape sequences of quoted format are valid in escaped one.
+		text = text[1 : len(text)-1]
+	}
+	// Parameter values are sent as quoted strings.
+	return Parameter{
+		Key:   key,
+		Value: string(appendQuoted(nil, text)),
+	}
+}
+
+// ParamString returns String parameter.
+func ParamString(key, v string) Parameter { return Param(key, LitString(v)) }
+
+// ParamIdentifier returns Identifier parameter, e.g. table or column name,
+// which is quoted by server.
+func ParamIdentifier(key, name string) Parameter { return Param(key, LitString(name)) }
+
+// ParamInt returns integer parameter.
+func ParamInt(key string, v int64) Parameter { return Param(key, LitInt(v)) }
+
+// ParamUInt returns unsigned integer parameter.
+func ParamUInt(key string, v uint64) Parameter { return Param(key, LitUInt(v)) }
+
+// ParamFloat returns floating point parameter.
+func ParamFloat(key string, v float64) Parameter { return Param(key, LitFloat(v)) }
+
+// ParamBool returns Bool parameter.
+func ParamBool(key string, v bool) Parameter { return Param(key, LitBool(v)) }
+
+// ParamDateTime returns DateTime parameter.
+func ParamDateTime(key string, t time.Time) Parameter { return Param(key, LitDateTime(t)) }
+
+// ParamDateTime64 returns DateTime64 parameter with precision p.
+func ParamDateTime64(key string, t time.Time, p Precision) Parameter {
+	return Param(key, LitDateTime64(t, p))
+}
+
+// ParamArray returns Array parameter of elements.
+func ParamArray(key string, elems ...Literal) Parameter { return Param(key, LitArray(elems...)) }
+
+// ParamTuple returns Tuple parameter of elements.
+func ParamTuple(key string, elems ...Literal) Parameter { return Param(key, LitTuple(elems...)) }
+
+// ParamMap returns Map parameter of key-value pairs.
+func ParamMap(key string, pairs ...[2]Literal) Parameter { return Param(key, LitMap(pairs...)) }
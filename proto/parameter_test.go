@@ -0,0 +1,62 @@
+package proto
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteral(t *testing.T) {
+	ts := time.Date(2023, 11, 14, 22, 13, 20, 12_345_678, time.UTC)
+	for _, tt := range []struct {
+		Literal Literal
+		Value   string
+	}{
+		{LitString("foo"), `'foo'`},
+		{LitString("it's\\\n\t"), `'it\'s\\\n\t'`},
+		{LitInt(-10), `-10`},
+		{LitUInt(10), `10`},
+		{LitFloat(1.5), `1.5`},
+		{LitFloat(math.Inf(-1)), `-inf`},
+		{LitFloat(math.NaN()), `nan`},
+		{LitBool(true), `true`},
+		{LitNull, `NULL`},
+		{LitDate(ts), `'2023-11-14'`},
+		{LitDateTime(ts), `'1700000000'`},
+		{LitDateTime64(ts, PrecisionMilli), `'1700000000.012'`},
+		{LitDateTime64(ts, PrecisionNano), `'1700000000.012345678'`},
+		{LitDateTime64(ts, PrecisionSecond), `'1700000000'`},
+		{LitArray(LitString("a"), LitString("b'c")), `['a','b\'c']`},
+		{LitArray(), `[]`},
+		{LitTuple(LitInt(1), LitNull), `(1,NULL)`},
+		{LitMap([2]Literal{LitString("k"), LitArray(LitInt(1))}), `{'k':[1]}`},
+	} {
+		require.Equal(t, tt.Value, string(tt.Literal))
+	}
+}
+
+func TestParam(t *testing.T) {
+	for _, tt := range []struct {
+		Param Parameter
+		Value string
+	}{
+		{ParamString("s", "foo"), `'foo'`},
+		{ParamString("s", "it's"), `'it\\\'s'`},
+		{ParamString("s", "a\tb"), `'a\\tb'`},
+		{ParamIdentifier("table", "events"), `'events'`},
+		{ParamInt("i", -1), `'-1'`},
+		{ParamUInt("u", 1), `'1'`},
+		{ParamFloat("f", 0.25), `'0.25'`},
+		{ParamBool("b", false), `'false'`},
+		{ParamDateTime("t", time.Unix(1700000000, 0)), `'1700000000'`},
+		{ParamDateTime64("t", time.Unix(1700000000, 5e8), PrecisionMilli), `'1700000000.500'`},
+		{ParamArray("a", LitString("x'y")), `'[\'x\\\'y\']'`},
+		{ParamTuple("t", LitInt(1), LitString("a")), `'(1,\'a\')'`},
+		{ParamMap("m", [2]Literal{LitString("k"), LitInt(1)}), `'{\'k\':1}'`},
+		{Param("n", LitNull), `'\\N'`},
+	} {
+		require.Equal(t, tt.Value, tt.Param.Value, tt.Param.Key)
+	}
+}
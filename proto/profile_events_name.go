@@ -0,0 +1,134 @@
+package proto
+
+// ProfileEventName is name of ProfileEvent.
+//
+// See ProfileEvents.cpp in ClickHouse sources or system.events table
+// for full list and descriptions. Only constants of commonly used events
+// are defined, any other name can be used by conversion.
+type ProfileEventName string
+
+// Profile events that are added by server to ProfileEvents packet, so
+// they are not defined in ProfileEvents.cpp.
+const (
+	ProfileEventMemoryTrackerUsage     ProfileEventName = "MemoryTrackerUsage"     // Current memory usage of query, gauge.
+	ProfileEventMemoryTrackerPeakUsage ProfileEventName = "MemoryTrackerPeakUsage" // Peak memory usage of query, gauge.
+)
+
+// Well-known profile events, subset of ProfileEvents.cpp of ClickHouse.
+//
+// The list is maintained by hand, names and descriptions are taken from
+// src/Common/ProfileEvents.cpp of ClickHouse 24.8.
+const (
+	// Number of queries to be interpreted and potentially executed.
+	ProfileEventQuery ProfileEventName = "Query"
+	// Same as Query, but only for SELECT queries.
+	ProfileEventSelectQuery ProfileEventName = "SelectQuery"
+	// Same as Query, but only for INSERT queries.
+	ProfileEventInsertQuery ProfileEventName = "InsertQuery"
+	// Number of failed queries.
+	ProfileEventFailedQuery ProfileEventName = "FailedQuery"
+	// Same as FailedQuery, but only for SELECT queries.
+	ProfileEventFailedSelectQuery ProfileEventName = "FailedSelectQuery"
+	// Same as FailedQuery, but only for INSERT queries.
+	ProfileEventFailedInsertQuery ProfileEventName = "FailedInsertQuery"
+	// Total time of all queries.
+	ProfileEventQueryTimeMicroseconds ProfileEventName = "QueryTimeMicroseconds"
+	// Total time of SELECT queries.
+	ProfileEventSelectQueryTimeMicroseconds ProfileEventName = "SelectQueryTimeMicroseconds"
+	// Total time of INSERT queries.
+	ProfileEventInsertQueryTimeMicroseconds ProfileEventName = "InsertQueryTimeMicroseconds"
+	// Number of files opened.
+	ProfileEventFileOpen ProfileEventName = "FileOpen"
+	// Number of times the lseek function was called.
+	ProfileEventSeek ProfileEventName = "Seek"
+	// Number of reads from a file descriptor.
+	ProfileEventReadBufferFromFileDescriptorRead ProfileEventName = "ReadBufferFromFileDescriptorRead"
+	// Number of bytes read from file descriptors.
+	ProfileEventReadBufferFromFileDescriptorReadBytes ProfileEventName = "ReadBufferFromFileDescriptorReadBytes"
+	// Number of writes to a file descriptor.
+	ProfileEventWriteBufferFromFileDescriptorWrite ProfileEventName = "WriteBufferFromFileDescriptorWrite"
+	// Number of bytes written to file descriptors.
+	ProfileEventWriteBufferFromFileDescriptorWriteBytes ProfileEventName = "WriteBufferFromFileDescriptorWriteBytes"
+	// Number of bytes read from compressed sources.
+	ProfileEventReadCompressedBytes ProfileEventName = "ReadCompressedBytes"
+	// Number of compressed blocks read from compressed sources.
+	ProfileEventCompressedReadBufferBlocks ProfileEventName = "CompressedReadBufferBlocks"
+	// Number of uncompressed bytes read from compressed sources.
+	ProfileEventCompressedReadBufferBytes ProfileEventName = "CompressedReadBufferBytes"
+	// Number of uncompressed cache hits.
+	ProfileEventUncompressedCacheHits ProfileEventName = "UncompressedCacheHits"
+	// Number of uncompressed cache misses.
+	ProfileEventUncompressedCacheMisses ProfileEventName = "UncompressedCacheMisses"
+	// Number of mark cache hits.
+	ProfileEventMarkCacheHits ProfileEventName = "MarkCacheHits"
+	// Number of mark cache misses.
+	ProfileEventMarkCacheMisses ProfileEventName = "MarkCacheMisses"
+	// Total time spent waiting for data to receive from network.
+	ProfileEventNetworkReceiveElapsedMicroseconds ProfileEventName = "NetworkReceiveElapsedMicroseconds"
+	// Total time spent waiting for data to send to network.
+	ProfileEventNetworkSendElapsedMicroseconds ProfileEventName = "NetworkSendElapsedMicroseconds"
+	// Total number of bytes received from network.
+	ProfileEventNetworkReceiveBytes ProfileEventName = "NetworkReceiveBytes"
+	// Total number of bytes sent to network.
+	ProfileEventNetworkSendBytes ProfileEventName = "NetworkSendBytes"
+	// Total time spent waiting for read syscall.
+	ProfileEventDiskReadElapsedMicroseconds ProfileEventName = "DiskReadElapsedMicroseconds"
+	// Total time spent waiting for write syscall.
+	ProfileEventDiskWriteElapsedMicroseconds ProfileEventName = "DiskWriteElapsedMicroseconds"
+	// Number of rows inserted to all tables.
+	ProfileEventInsertedRows ProfileEventName = "InsertedRows"
+	// Number of bytes inserted to all tables.
+	ProfileEventInsertedBytes ProfileEventName = "InsertedBytes"
+	// Number of data parts selected to read from MergeTree table.
+	ProfileEventSelectedParts ProfileEventName = "SelectedParts"
+	// Number of non-adjacent ranges selected to read from MergeTree table.
+	ProfileEventSelectedRanges ProfileEventName = "SelectedRanges"
+	// Number of marks selected to read from MergeTree table.
+	ProfileEventSelectedMarks ProfileEventName = "SelectedMarks"
+	// Number of rows selected to read from MergeTree table.
+	ProfileEventSelectedRows ProfileEventName = "SelectedRows"
+	// Number of bytes selected to read from MergeTree table.
+	ProfileEventSelectedBytes ProfileEventName = "SelectedBytes"
+	// Rows read for background merges.
+	ProfileEventMergedRows ProfileEventName = "MergedRows"
+	// Uncompressed bytes read for background merges.
+	ProfileEventMergedUncompressedBytes ProfileEventName = "MergedUncompressedBytes"
+	// Number of times the lock of Context was acquired or tried to acquire.
+	ProfileEventContextLock ProfileEventName = "ContextLock"
+	// Total wall clock time spent in processing threads.
+	ProfileEventRealTimeMicroseconds ProfileEventName = "RealTimeMicroseconds"
+	// Total time spent in processing threads executing CPU instructions in user space.
+	ProfileEventUserTimeMicroseconds ProfileEventName = "UserTimeMicroseconds"
+	// Total time spent in processing threads executing CPU instructions in OS kernel space.
+	ProfileEventSystemTimeMicroseconds ProfileEventName = "SystemTimeMicroseconds"
+	// Number of soft page faults in query execution threads.
+	ProfileEventSoftPageFaults ProfileEventName = "SoftPageFaults"
+	// Number of hard page faults in query execution threads.
+	ProfileEventHardPageFaults ProfileEventName = "HardPageFaults"
+	// Total time a thread spent waiting for a result of IO operation.
+	ProfileEventOSIOWaitMicroseconds ProfileEventName = "OSIOWaitMicroseconds"
+	// Total time a thread was ready for execution but waiting to be scheduled by OS.
+	ProfileEventOSCPUWaitMicroseconds ProfileEventName = "OSCPUWaitMicroseconds"
+	// CPU time spent seen by OS.
+	ProfileEventOSCPUVirtualTimeMicroseconds ProfileEventName = "OSCPUVirtualTimeMicroseconds"
+	// Number of bytes read from disks or block devices.
+	ProfileEventOSReadBytes ProfileEventName = "OSReadBytes"
+	// Number of bytes written to disks or block devices.
+	ProfileEventOSWriteBytes ProfileEventName = "OSWriteBytes"
+	// Number of bytes read from filesystem, including page cache.
+	ProfileEventOSReadChars ProfileEventName = "OSReadChars"
+	// Number of bytes written to filesystem, including page cache.
+	ProfileEventOSWriteChars ProfileEventName = "OSWriteChars"
+	// Number of chunks allocated for memory Arena.
+	ProfileEventArenaAllocChunks ProfileEventName = "ArenaAllocChunks"
+	// Number of bytes allocated for memory Arena.
+	ProfileEventArenaAllocBytes ProfileEventName = "ArenaAllocBytes"
+	// Number of SQL ordinary function calls.
+	ProfileEventFunctionExecute ProfileEventName = "FunctionExecute"
+	// Number of table function calls.
+	ProfileEventTableFunctionExecute ProfileEventName = "TableFunctionExecute"
+	// Number of times ordinary read buffer was created for reading data.
+	ProfileEventCreatedReadBufferOrdinary ProfileEventName = "CreatedReadBufferOrdinary"
+	// Number of times when memory limit exceeded for query.
+	ProfileEventQueryMemoryLimitExceeded ProfileEventName = "QueryMemoryLimitExceeded"
+)
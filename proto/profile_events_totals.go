@@ -0,0 +1,77 @@
+package proto
+
+import (
+	"context"
+	"sort"
+)
+
+// ProfileEventTotals accumulates profile events across batches.
+//
+// Increments are summed over all hosts and threads, gauges are set to
+// the latest received value.
+//
+// Zero value is valid. Not goroutine-safe.
+type ProfileEventTotals struct {
+	values map[ProfileEventName]int64
+}
+
+// Add events to totals.
+func (t *ProfileEventTotals) Add(events []ProfileEvent) {
+	if t.values == nil {
+		t.values = make(map[ProfileEventName]int64, len(events))
+	}
+	for _, e := range events {
+		name := ProfileEventName(e.Name)
+		switch e.Type {
+		case ProfileGauge:
+			t.values[name] = e.Value
+		default:
+			t.values[name] += e.Value
+		}
+	}
+}
+
+// Handler returns function that can be used as OnProfileEvents query handler.
+func (t *ProfileEventTotals) Handler() func(ctx context.Context, events []ProfileEvent) error {
+	return func(ctx context.Context, events []ProfileEvent) error {
+		t.Add(events)
+		return nil
+	}
+}
+
+// Get returns accumulated value of event and whether it was received.
+func (t *ProfileEventTotals) Get(name ProfileEventName) (int64, bool) {
+	v, ok := t.values[name]
+	return v, ok
+}
+
+// Value returns accumulated value of event or zero.
+func (t *ProfileEventTotals) Value(name ProfileEventName) int64 {
+	return t.values[name]
+}
+
+// Names returns sorted names of all received events.
+func (t *ProfileEventTotals) Names() []ProfileEventName {
+	names := make([]ProfileEventName, 0, len(t.values))
+	for name := range t.values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// Map returns copy of accumulated values.
+func (t *ProfileEventTotals) Map() map[ProfileEventName]int64 {
+	out := make(map[ProfileEventName]int64, len(t.values))
+	for k, v := range t.values {
+		out[k] = v
+	}
+	return out
+}
+
+// Reset removes all accumulated values.
+func (t *ProfileEventTotals) Reset() {
+	for k := range t.values {
+		delete(t.values, k)
+	}
+}
@@ -0,0 +1,40 @@
+package proto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileEventTotals(t *testing.T) {
+	var totals ProfileEventTotals
+	require.Empty(t, totals.Names())
+
+	h := totals.Handler()
+	require.NoError(t, h(context.Background(), []ProfileEvent{
+		{Type: ProfileIncrement, Name: "SelectedRows", Value: 10, ThreadID: 1},
+		{Type: ProfileIncrement, Name: "SelectedRows", Value: 5, ThreadID: 2},
+		{Type: ProfileGauge, Name: "MemoryTrackerUsage", Value: 100},
+	}))
+	totals.Add([]ProfileEvent{
+		{Type: ProfileIncrement, Name: "SelectedRows", Value: 1},
+		{Type: ProfileGauge, Name: "MemoryTrackerUsage", Value: 50},
+	})
+
+	require.Equal(t, int64(16), totals.Value(ProfileEventSelectedRows))
+	require.Equal(t, int64(50), totals.Value(ProfileEventMemoryTrackerUsage))
+	_, ok := totals.Get(ProfileEventOSCPUVirtualTimeMicroseconds)
+	require.False(t, ok)
+	require.Equal(t, []ProfileEventName{
+		ProfileEventMemoryTrackerUsage,
+		ProfileEventSelectedRows,
+	}, totals.Names())
+	require.Equal(t, map[ProfileEventName]int64{
+		ProfileEventMemoryTrackerUsage: 50,
+		ProfileEventSelectedRows:       16,
+	}, totals.Map())
+
+	totals.Reset()
+	require.Empty(t, totals.Names())
+}
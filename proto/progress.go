@@ -10,6 +10,9 @@ type Progress struct {
 	Bytes     uint64
 	TotalRows uint64
 
+	// TotalBytes to read, sent since FeatureTotalBytesInProgress.
+	TotalBytes uint64
+
 	WroteRows  uint64
 	WroteBytes uint64
 	ElapsedNs  uint64
@@ -19,6 +22,9 @@ func (p Progress) EncodeAware(b *Buffer, version int) {
 	b.PutUVarInt(p.Rows)
 	b.PutUVarInt(p.Bytes)
 	b.PutUVarInt(p.TotalRows)
+	if FeatureTotalBytesInProgress.In(version) {
+		b.PutUVarInt(p.TotalBytes)
+	}
 	if FeatureClientWriteInfo.In(version) {
 		b.PutUVarInt(p.WroteRows)
 		b.PutUVarInt(p.WroteBytes)
@@ -50,6 +56,13 @@ func (p *Progress) DecodeAware(r *Reader, version int) error {
 		}
 		p.TotalRows = v
 	}
+	if FeatureTotalBytesInProgress.In(version) {
+		v, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrap(err, "total bytes")
+		}
+		p.TotalBytes = v
+	}
 	if FeatureClientWriteInfo.In(version) {
 		{
 			v, err := r.UVarInt()
@@ -0,0 +1,72 @@
+package proto
+
+import "time"
+
+// Add returns sum of progress increments p and other.
+func (p Progress) Add(other Progress) Progress {
+	return Progress{
+		Rows:       p.Rows + other.Rows,
+		Bytes:      p.Bytes + other.Bytes,
+		TotalRows:  p.TotalRows + other.TotalRows,
+		TotalBytes: p.TotalBytes + other.TotalBytes,
+		WroteRows:  p.WroteRows + other.WroteRows,
+		WroteBytes: p.WroteBytes + other.WroteBytes,
+		ElapsedNs:  p.ElapsedNs + other.ElapsedNs,
+	}
+}
+
+// ProgressAccumulator accumulates progress increments of query, as server
+// sends difference since previous Progress packet.
+//
+// Zero value is ready to use.
+type ProgressAccumulator struct {
+	// Total progress of query.
+	Total Progress
+	// Start is time of first update.
+	Start time.Time
+	// Updated is time of last update.
+	Updated time.Time
+}
+
+// Add accumulates progress increment p received now.
+func (a *ProgressAccumulator) Add(p Progress) {
+	a.AddAt(p, time.Now())
+}
+
+// AddAt accumulates progress increment p received at t.
+func (a *ProgressAccumulator) AddAt(p Progress, t time.Time) {
+	if a.Start.IsZero() {
+		a.Start = t
+	}
+	a.Updated = t
+	a.Total = a.Total.Add(p)
+}
+
+// Elapsed returns time between first and last update.
+func (a *ProgressAccumulator) Elapsed() time.Duration {
+	return a.Updated.Sub(a.Start)
+}
+
+// Percent returns percentage of read rows from TotalRows, in range [0, 100],
+// or zero if total is unknown.
+func (a *ProgressAccumulator) Percent() float64 {
+	if a.Total.TotalRows == 0 {
+		return 0
+	}
+	v := float64(a.Total.Rows) / float64(a.Total.TotalRows) * 100
+	if v > 100 {
+		// Total rows is estimation and can be less than actually read.
+		return 100
+	}
+	return v
+}
+
+// ETA returns estimated time left after last update to read TotalRows,
+// assuming constant read speed, or zero if unknown.
+func (a *ProgressAccumulator) ETA() time.Duration {
+	if a.Total.Rows == 0 || a.Total.TotalRows <= a.Total.Rows {
+		return 0
+	}
+	left := float64(a.Total.TotalRows - a.Total.Rows)
+	return time.Duration(float64(a.Elapsed()) * left / float64(a.Total.Rows))
+}
@@ -0,0 +1,34 @@
+package proto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressAccumulator(t *testing.T) {
+	var a ProgressAccumulator
+	require.Zero(t, a.Percent())
+	require.Zero(t, a.ETA())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a.AddAt(Progress{Rows: 100, Bytes: 800, TotalRows: 1000}, start)
+	a.AddAt(Progress{Rows: 150, Bytes: 1200}, start.Add(time.Second))
+	a.AddAt(Progress{Rows: 0, TotalRows: 0, ElapsedNs: 10}, start.Add(2*time.Second))
+
+	require.Equal(t, Progress{
+		Rows:      250,
+		Bytes:     2000,
+		TotalRows: 1000,
+		ElapsedNs: 10,
+	}, a.Total)
+	require.Equal(t, 2*time.Second, a.Elapsed())
+	require.InDelta(t, 25, a.Percent(), 1e-9)
+	require.Equal(t, 6*time.Second, a.ETA())
+
+	// Total rows is estimation.
+	a.AddAt(Progress{Rows: 1000}, start.Add(3*time.Second))
+	require.Equal(t, float64(100), a.Percent())
+	require.Zero(t, a.ETA())
+}
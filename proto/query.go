@@ -182,6 +182,14 @@ func (q *Query) DecodeAware(r *Reader, version int) error {
 }
 
 func (q Query) EncodeAware(b *Buffer, version int) {
+	q.EncodeBeforeBody(b, version)
+	b.PutString(q.Body)
+	q.EncodeAfterBody(b, version)
+}
+
+// EncodeBeforeBody encodes packet until body, so body can be written
+// separately as length-prefixed string.
+func (q Query) EncodeBeforeBody(b *Buffer, version int) {
 	ClientCodeQuery.Encode(b)
 	b.PutString(q.ID)
 	if FeatureClientWriteInfo.In(version) {
@@ -201,9 +209,10 @@ func (q Query) EncodeAware(b *Buffer, version int) {
 
 	StageComplete.Encode(b)
 	q.Compression.Encode(b)
+}
 
-	b.PutString(q.Body)
-
+// EncodeAfterBody encodes packet after body, see EncodeBeforeBody.
+func (q Query) EncodeAfterBody(b *Buffer, version int) {
 	if FeatureParameters.In(version) {
 		for _, p := range q.Parameters {
 			p.Encode(b)
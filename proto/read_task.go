@@ -0,0 +1,20 @@
+package proto
+
+// ClusterProcessingVersion is version of distributed processing protocol,
+// e.g. for s3Cluster table function.
+const ClusterProcessingVersion = 1
+
+// ReadTaskResponse is sent in response to ServerReadTaskRequest with next
+// task to process, e.g. next file for s3Cluster.
+//
+// Empty Task means that there are no more tasks.
+type ReadTaskResponse struct {
+	Version int
+	Task    string
+}
+
+func (t ReadTaskResponse) Encode(b *Buffer) {
+	ClientCodeReadTaskResponse.Encode(b)
+	b.PutInt(t.Version)
+	b.PutString(t.Task)
+}
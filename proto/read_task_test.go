@@ -0,0 +1,23 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTaskResponse_Encode(t *testing.T) {
+	var b Buffer
+	ReadTaskResponse{Version: ClusterProcessingVersion, Task: "data/1.csv"}.Encode(&b)
+
+	r := b.Reader()
+	code, err := r.UVarInt()
+	require.NoError(t, err)
+	require.Equal(t, ClientCodeReadTaskResponse, ClientCode(code))
+	version, err := r.Int()
+	require.NoError(t, err)
+	require.Equal(t, ClusterProcessingVersion, version)
+	task, err := r.Str()
+	require.NoError(t, err)
+	require.Equal(t, "data/1.csv", task)
+}
@@ -2,9 +2,12 @@ package proto
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"io"
 	"math"
+	"slices"
+	"sync"
 
 	"github.com/go-faster/errors"
 
@@ -25,10 +28,39 @@ type AwareDecoder interface {
 // Not goroutine-safe.
 type Reader struct {
 	raw  *bufio.Reader // raw bytes, e.g. on the wire
-	data io.Reader     // data, decompressed or same as raw
+	tee  *teeReader    // raw bytes, optionally copied
+	data io.Reader     // data, decompressed or same as tee
 	b    *Buffer       // internal buffer
+	n    int64         // total data bytes read
 
-	decompressed io.Reader // decompressed data stream, from raw
+	limits Limits
+
+	decompressed *compress.Reader // decompressed data stream, from raw
+
+	// Reused for expanded sparse columns, see subReader.
+	sparse    Buffer
+	sub       *Reader
+	subSource bytes.Reader
+}
+
+// teeReader appends bytes read from r to buf, if set.
+type teeReader struct {
+	r   io.Reader
+	buf *Buffer
+}
+
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if t.buf != nil {
+		t.buf.Buf = append(t.buf.Buf, p[:n]...)
+	}
+	return n, err
+}
+
+// Tee makes next reads append raw bytes, i.e. compressed data as is if
+// compression is enabled, to b. Nil b stops appending.
+func (r *Reader) Tee(b *Buffer) {
+	r.tee.buf = b
 }
 
 func (r *Reader) ReadByte() (byte, error) {
@@ -43,13 +75,102 @@ func (r *Reader) EnableCompression() {
 	r.data = r.decompressed
 }
 
+// SetChecksumPolicy sets verification policy of compressed data checksums,
+// see compress.Reader.SetChecksumPolicy.
+func (r *Reader) SetChecksumPolicy(p compress.ChecksumPolicy, onCorrupted func(err *compress.CorruptedDataErr)) {
+	r.decompressed.SetChecksumPolicy(p, onCorrupted)
+}
+
 // DisableCompression makes next read use raw source of data.
 func (r *Reader) DisableCompression() {
-	r.data = r.raw
+	r.data = r.tee
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
-	return r.data.Read(p)
+	n, err = r.data.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// BytesRead returns total number of data bytes read, i.e. decompressed
+// bytes if compression is enabled.
+func (r *Reader) BytesRead() int64 {
+	return r.n
+}
+
+// Retained returns size of internal buffers, i.e. memory retained by
+// Reader between reads.
+func (r *Reader) Retained() int {
+	n := r.raw.Size() + cap(r.b.Buf) + r.decompressed.Retained() + cap(r.sparse.Buf)
+	if r.sub != nil {
+		n += cap(r.sub.b.Buf)
+	}
+	return n
+}
+
+// sparseBuf returns reset buffer for expanded sparse column.
+func (r *Reader) sparseBuf() *Buffer {
+	r.sparse.Reset()
+	return &r.sparse
+}
+
+// subReader returns reader of buf with the same limits as r, e.g. to
+// decode expanded sparse column. Reader is reused, so it is valid until
+// next call.
+//
+// Unlike NewReader, sub reader is not buffered and does not support
+// compression, as data is already in memory.
+func (r *Reader) subReader(buf []byte) *Reader {
+	if r.sub == nil {
+		tee := &teeReader{r: &r.subSource}
+		r.sub = &Reader{
+			tee:  tee,
+			data: tee,
+			b:    &Buffer{},
+		}
+	}
+	r.subSource.Reset(buf)
+	r.sub.limits = r.limits
+	r.sub.n = 0
+	return r.sub
+}
+
+// Release returns internal buffers to pool, so memory of large values is
+// not retained by idle Reader. Buffered raw data is kept.
+//
+// Slices returned by ReadRaw or StrRaw are not valid after Release.
+func (r *Reader) Release() {
+	if cap(r.b.Buf) >= minPooledSize {
+		buf := r.b.Buf[:0]
+		readerPool.Put(&buf)
+	}
+	r.b.Buf = nil
+	r.decompressed.Release()
+	r.sparse = Buffer{}
+	if r.sub != nil {
+		r.sub.b.Buf = nil
+	}
+}
+
+var readerPool sync.Pool
+
+// minPooledSize is minimum size of pooled internal buffer, smaller ones
+// are cheap to allocate.
+const minPooledSize = 4 * 1024
+
+// ensure sets length of internal buffer to n, reusing pooled buffer if
+// current one is too small.
+func (r *Reader) ensure(n int) {
+	if n >= minPooledSize && cap(r.b.Buf) < n {
+		if buf, ok := readerPool.Get().(*[]byte); ok {
+			if cap(*buf) >= n {
+				r.b.Buf = *buf
+			} else {
+				readerPool.Put(buf)
+			}
+		}
+	}
+	r.b.Ensure(n)
 }
 
 // Decode value.
@@ -64,9 +185,33 @@ func (r *Reader) ReadFull(buf []byte) error {
 	return nil
 }
 
+// maxPrealloc is the maximum number of bytes allocated before reading
+// length-prefixed data. Larger payloads are read in chunks, so corrupted
+// lengths result in unexpected EOF instead of huge allocations.
+const maxPrealloc = 1 << 20
+
+// appendFull reads n bytes and appends them to buf.
+func (r *Reader) appendFull(buf []byte, n int) ([]byte, error) {
+	for n > 0 {
+		chunk := min(n, maxPrealloc)
+		start := len(buf)
+		buf = slices.Grow(buf, chunk)[:start+chunk]
+		if err := r.ReadFull(buf[start:]); err != nil {
+			return buf[:start], err
+		}
+		n -= chunk
+	}
+	return buf, nil
+}
+
 func (r *Reader) readFull(n int) error {
-	r.b.Ensure(n)
-	return r.ReadFull(r.b.Buf)
+	if n <= maxPrealloc {
+		r.ensure(n)
+		return r.ReadFull(r.b.Buf)
+	}
+	buf, err := r.appendFull(r.b.Buf[:0], n)
+	r.b.Buf = buf
+	return err
 }
 
 // ReadRaw reads raw n bytes.
@@ -96,6 +241,9 @@ func (r *Reader) StrLen() (int, error) {
 	if n < 0 {
 		return 0, errors.Errorf("size %d is invalid", n)
 	}
+	if err := checkLimit("MaxStrLen", n, r.limits.MaxStrLen); err != nil {
+		return 0, err
+	}
 
 	return n, nil
 }
@@ -108,8 +256,7 @@ func (r *Reader) StrRaw() ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "read length")
 	}
-	r.b.Ensure(n)
-	if _, err := io.ReadFull(r.data, r.b.Buf); err != nil {
+	if err := r.readFull(n); err != nil {
 		return nil, errors.Wrap(err, "read str")
 	}
 
@@ -281,10 +428,14 @@ const defaultReaderSize = 1024 * 128 // 128kb
 // NewReader initializes new Reader from provided io.Reader.
 func NewReader(r io.Reader) *Reader {
 	c := bufio.NewReaderSize(r, defaultReaderSize)
-	return &Reader{
+	t := &teeReader{r: c}
+	rd := &Reader{
 		raw:          c,
-		data:         c,
+		tee:          t,
+		data:         t,
 		b:            &Buffer{},
-		decompressed: compress.NewReader(c),
+		decompressed: compress.NewReader(t),
 	}
+	rd.SetLimits(Limits{})
+	return rd
 }
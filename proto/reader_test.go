@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -32,3 +33,32 @@ func TestReader_Int(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 529, v)
 }
+
+func TestReader_Release(t *testing.T) {
+	var b Buffer
+	long := strings.Repeat("x", minPooledSize*2)
+	b.PutString(long)
+	b.PutString("short")
+	b.PutString(long)
+
+	r := b.Reader()
+	retained := r.Retained()
+
+	v, err := r.StrRaw()
+	require.NoError(t, err)
+	require.Equal(t, long, string(v))
+	require.Greater(t, r.Retained(), retained)
+	require.Equal(t, int64(len(long)+2), r.BytesRead())
+
+	r.Release()
+	require.Equal(t, retained, r.Retained())
+
+	s, err := r.Str()
+	require.NoError(t, err)
+	require.Equal(t, "short", s)
+	require.Less(t, r.Retained(), retained+minPooledSize, "pooled buffer should not be used")
+
+	v, err = r.StrRaw()
+	require.NoError(t, err)
+	require.Equal(t, long, string(v))
+}
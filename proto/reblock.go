@@ -0,0 +1,322 @@
+package proto
+
+import (
+	"github.com/go-faster/errors"
+)
+
+// columnAppender is column that can append rows of other column of
+// compatible type.
+type columnAppender interface {
+	// appendColumn appends rows [i, j) of src, returning false if src
+	// type is not supported.
+	appendColumn(src ColInput, i, j int) bool
+}
+
+// colAs returns src as T if src is T or *T.
+func colAs[T any](src ColInput) (T, bool) {
+	switch v := any(src).(type) {
+	case T:
+		return v, true
+	case *T:
+		return *v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+func appendColumn(dst, src ColInput, i, j int) bool {
+	a, ok := dst.(columnAppender)
+	return ok && a.appendColumn(src, i, j)
+}
+
+// AppendColumn appends all rows of src to dst.
+//
+// Columns should be of same kind, e.g. ColStr and ColStr (or ColBytes),
+// ColArr[T] and ColArr[T].
+func AppendColumn(dst Column, src ColInput) error {
+	if !appendColumn(dst, src, 0, src.Rows()) {
+		return errors.Errorf("can't append %T (%s) to %T (%s)", src, src.Type(), dst, dst.Type())
+	}
+	return nil
+}
+
+func (c *ColStr) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColStr](src)
+	if !ok {
+		b, isBytes := colAs[ColBytes](src)
+		if !isBytes {
+			return false
+		}
+		v = b.ColStr
+	}
+	for k := i; k < j; k++ {
+		c.AppendBytes(v.RowBytes(k))
+	}
+	return true
+}
+
+func (c *ColFixedStr) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColFixedStr](src)
+	if !ok || v.Size != c.Size {
+		return false
+	}
+	c.Buf = append(c.Buf, v.Buf[i*v.Size:j*v.Size]...)
+	return true
+}
+
+func (c *ColBool) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColBool](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
+func (c *ColUUID) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColUUID](src)
+	if !ok {
+		return false
+	}
+	*c = append(*c, v[i:j]...)
+	return true
+}
+
+func (c *ColDateTime) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDateTime](src)
+	if !ok {
+		return false
+	}
+	c.Data = append(c.Data, v.Data[i:j]...)
+	return true
+}
+
+func (c *ColDateTime64) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDateTime64](src)
+	if !ok || v.Precision != c.Precision {
+		return false
+	}
+	c.Data = append(c.Data, v.Data[i:j]...)
+	return true
+}
+
+func (c *ColDecimal) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColDecimal](src)
+	if !ok || v.Precision != c.Precision || v.Scale != c.Scale {
+		return false
+	}
+	return appendColumn(c.Raw(), v.Raw(), i, j)
+}
+
+func (c *ColLowCardinality[T]) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColLowCardinality[T]](src)
+	if !ok {
+		return false
+	}
+	for k := i; k < j; k++ {
+		c.Append(v.Row(k))
+	}
+	return true
+}
+
+func (c *ColArr[T]) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColArr[T]](src)
+	if !ok {
+		return false
+	}
+	var start, end, base uint64
+	if i > 0 {
+		start = v.Offsets[i-1]
+	}
+	if j > 0 {
+		end = v.Offsets[j-1]
+	}
+	if l := len(c.Offsets); l > 0 {
+		base = c.Offsets[l-1]
+	}
+	if !appendColumn(c.Data, v.Data, int(start), int(end)) {
+		// Falling back to appending row by row.
+		for k := int(start); k < int(end); k++ {
+			c.Data.Append(v.Data.Row(k))
+		}
+	}
+	for _, o := range v.Offsets[i:j] {
+		c.Offsets = append(c.Offsets, base+o-start)
+	}
+	return true
+}
+
+func (c *ColNullable[T]) appendColumn(src ColInput, i, j int) bool {
+	v, ok := colAs[ColNullable[T]](src)
+	if !ok {
+		return false
+	}
+	c.Nulls = append(c.Nulls, v.Nulls[i:j]...)
+	if !appendColumn(c.Values, v.Values, i, j) {
+		for k := i; k < j; k++ {
+			c.Values.Append(v.Values.Row(k))
+		}
+	}
+	return true
+}
+
+// MergeBlocks concatenates blocks with same columns into single block.
+func MergeBlocks(blocks ...Input) (Input, error) {
+	var r Reblocker
+	for i, b := range blocks {
+		if err := r.Add(b); err != nil {
+			return nil, errors.Wrapf(err, "block [%d]", i)
+		}
+	}
+	return r.Input(), nil
+}
+
+// Reblocker concatenates many small blocks into blocks of target size,
+// e.g. to re-insert data that was received in many small blocks.
+//
+// Blocks that are larger than target size are split.
+type Reblocker struct {
+	// MaxRows is target rows count of block. Zero means no limit.
+	MaxRows int
+	// MaxBytes is target approximate size of encoded block. Zero means
+	// no limit.
+	MaxBytes int
+	// Flush is called with block of target size. Input is reset and
+	// reused after Flush returns.
+	//
+	// If nil, blocks are accumulated until Reset.
+	Flush func(input Input) error
+
+	input Input
+	bytes int
+	buf   Buffer
+}
+
+// Rows returns count of accumulated rows.
+func (r *Reblocker) Rows() int {
+	if len(r.input) == 0 {
+		return 0
+	}
+	return r.input[0].Data.Rows()
+}
+
+// Input returns accumulated block.
+func (r *Reblocker) Input() Input {
+	return r.input
+}
+
+// Reset accumulated rows, preserving columns for reuse.
+func (r *Reblocker) Reset() {
+	r.input.Reset()
+	r.bytes = 0
+}
+
+func (r *Reblocker) full() bool {
+	return (r.MaxRows > 0 && r.Rows() >= r.MaxRows) ||
+		(r.MaxBytes > 0 && r.bytes >= r.MaxBytes)
+}
+
+func (r *Reblocker) flush() error {
+	if r.Rows() == 0 || r.Flush == nil {
+		return nil
+	}
+	if err := r.Flush(r.input); err != nil {
+		return errors.Wrap(err, "flush")
+	}
+	r.Reset()
+	return nil
+}
+
+// Close flushes remaining rows.
+func (r *Reblocker) Close() error {
+	return r.flush()
+}
+
+func (r *Reblocker) init(input Input) error {
+	if r.input != nil {
+		if len(r.input) != len(input) {
+			return errors.Errorf("%d (columns) != %d (expected)", len(input), len(r.input))
+		}
+		for i, c := range input {
+			if e := r.input[i]; c.Name != e.Name || c.Data.Type().Conflicts(e.Data.Type()) {
+				return errors.Errorf("[%d]: unexpected column %q %q (%q %q expected)",
+					i, c.Name, c.Data.Type(), e.Name, e.Data.Type(),
+				)
+			}
+		}
+		return nil
+	}
+	out, err := newInputLike(input)
+	if err != nil {
+		return err
+	}
+	r.input = out
+	return nil
+}
+
+// Add appends block to accumulated rows, calling Flush on every block of
+// target size.
+func (r *Reblocker) Add(input Input) error {
+	if len(input) == 0 {
+		return nil
+	}
+	if err := r.init(input); err != nil {
+		return errors.Wrap(err, "columns")
+	}
+	rows := input[0].Data.Rows()
+	var size int
+	if r.MaxBytes > 0 && rows > 0 {
+		r.buf.Reset()
+		for _, c := range input {
+			c.Data.EncodeColumn(&r.buf)
+		}
+		size = len(r.buf.Buf)
+	}
+	for start := 0; start < rows; {
+		end := rows
+		if r.MaxRows > 0 {
+			end = min(end, start+r.MaxRows-r.Rows())
+		}
+		if size > 0 {
+			perRow := (size + rows - 1) / rows
+			end = min(end, start+max(1, (r.MaxBytes-r.bytes)/perRow))
+		}
+		for i, c := range input {
+			if !appendColumn(r.input[i].Data, c.Data, start, end) {
+				return errors.Errorf("%s: can't append %T to %T", c.Name, c.Data, r.input[i].Data)
+			}
+		}
+		r.bytes += size * (end - start) / rows
+		if r.full() {
+			if err := r.flush(); err != nil {
+				return err
+			}
+		}
+		start = end
+	}
+	return nil
+}
+
+// AddResults appends decoded block to accumulated rows.
+func (r *Reblocker) AddResults(results Results) error {
+	input, err := results.Input()
+	if err != nil {
+		return err
+	}
+	return r.Add(input)
+}
+
+// newInputLike returns new empty columns with same names and types as
+// input.
+func newInputLike(input Input) (Input, error) {
+	out := make(Input, 0, len(input))
+	for _, c := range input {
+		col, err := NewColumn(c.Data.Type())
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		out = append(out, InputColumn{Name: c.Name, Data: col})
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package proto
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func reblockInput(start, rows int) Input {
+	var (
+		ints = new(ColInt64)
+		str  = new(ColStr)
+		arr  = new(ColStr).LowCardinality().Array()
+		null = new(ColUInt16).Nullable()
+	)
+	for i := start; i < start+rows; i++ {
+		ints.Append(int64(i))
+		str.Append(strconv.Itoa(i))
+		arr.Append([]string{"a", strconv.Itoa(i % 3)}[:i%3])
+		if i%2 == 0 {
+			null.Append(Null[uint16]())
+		} else {
+			null.Append(NewNullable(uint16(i)))
+		}
+	}
+	return Input{
+		{Name: "ints", Data: ints},
+		{Name: "str", Data: str},
+		{Name: "arr", Data: arr},
+		{Name: "null", Data: null},
+	}
+}
+
+func encodeInput(t testing.TB, input Input) []byte {
+	t.Helper()
+	var b Buffer
+	for _, c := range input {
+		if s, ok := c.Data.(StateEncoder); ok {
+			s.EncodeState(&b)
+		}
+		if p, ok := c.Data.(Preparable); ok {
+			require.NoError(t, p.Prepare())
+		}
+		c.Data.EncodeColumn(&b)
+	}
+	return b.Buf
+}
+
+func TestMergeBlocks(t *testing.T) {
+	t.Parallel()
+	merged, err := MergeBlocks(
+		reblockInput(0, 3),
+		reblockInput(3, 0),
+		reblockInput(3, 10),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 13, merged[0].Data.Rows())
+	require.Equal(t, encodeInput(t, reblockInput(0, 13)), encodeInput(t, merged))
+
+	_, err = MergeBlocks(reblockInput(0, 1), reblockInput(0, 1)[1:])
+	require.Error(t, err)
+	_, err = MergeBlocks(reblockInput(0, 1), Input{
+		{Name: "ints", Data: new(ColInt32)},
+		{Name: "str", Data: new(ColStr)},
+		{Name: "arr", Data: new(ColStr).LowCardinality().Array()},
+		{Name: "null", Data: new(ColUInt16).Nullable()},
+	})
+	require.Error(t, err)
+}
+
+func TestReblocker(t *testing.T) {
+	t.Parallel()
+	t.Run("Rows", func(t *testing.T) {
+		var (
+			sizes []int
+			start int
+		)
+		r := &Reblocker{
+			MaxRows: 7,
+			Flush: func(input Input) error {
+				rows := input[0].Data.Rows()
+				sizes = append(sizes, rows)
+				require.Equal(t, encodeInput(t, reblockInput(start, rows)), encodeInput(t, input))
+				start += rows
+				return nil
+			},
+		}
+		for i := 0; i < 10; i++ {
+			require.NoError(t, r.Add(reblockInput(i*3, 3)))
+		}
+		require.NoError(t, r.Add(reblockInput(30, 20)))
+		require.NoError(t, r.Close())
+		require.Equal(t, []int{7, 7, 7, 7, 7, 7, 7, 1}, sizes)
+		require.Equal(t, 0, r.Rows())
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		var total int
+		r := &Reblocker{
+			MaxBytes: 256,
+			Flush: func(input Input) error {
+				total += input[0].Data.Rows()
+				require.LessOrEqual(t, len(encodeInput(t, input)), 256+64)
+				return nil
+			},
+		}
+		for i := 0; i < 100; i++ {
+			require.NoError(t, r.Add(reblockInput(i*2, 2)))
+		}
+		require.NoError(t, r.Close())
+		require.Equal(t, 200, total)
+	})
+}
+
+func TestAppendColumn(t *testing.T) {
+	t.Parallel()
+	dst := NewDecimal(9, 2)
+	src := NewDecimal(9, 2)
+	require.NoError(t, src.AppendDecimalString("1.25"))
+	require.NoError(t, AppendColumn(dst, src))
+	require.Equal(t, "1.25", dst.RowString(0))
+	require.Error(t, AppendColumn(NewDecimal(9, 3), src))
+
+	bytes := new(ColBytes)
+	bytes.Append([]byte("foo"))
+	var str ColStr
+	require.NoError(t, AppendColumn(&str, bytes))
+	require.Equal(t, "foo", str.Row(0))
+	require.Error(t, AppendColumn(&str, ColInt8{1}))
+}
@@ -12,10 +12,11 @@ type Results []ResultColumn
 
 type autoResults struct {
 	results *Results
+	pool    *ColumnPool
 }
 
 func (s autoResults) DecodeResult(r *Reader, version int, b Block) error {
-	return s.results.decodeAuto(r, version, b)
+	return s.results.decodeAuto(r, version, b, s.pool)
 }
 
 func (s Results) Rows() int {
@@ -29,7 +30,26 @@ func (s *Results) Auto() Result {
 	return autoResults{results: s}
 }
 
-func (s *Results) decodeAuto(r *Reader, version int, b Block) error {
+// AutoPool is like Auto, but takes inferred columns from pool.
+//
+// Use Release to return columns to pool after use.
+func (s *Results) AutoPool(p *ColumnPool) Result {
+	return autoResults{results: s, pool: p}
+}
+
+// Release returns columns to pool and truncates results, so they can be
+// reused with AutoPool. Columns must not be used after Release.
+func (s *Results) Release(p *ColumnPool) {
+	for i, c := range *s {
+		if col, ok := c.Data.(Column); ok {
+			p.Put(col)
+		}
+		(*s)[i] = ResultColumn{}
+	}
+	*s = (*s)[:0]
+}
+
+func (s *Results) decodeAuto(r *Reader, version int, b Block, pool *ColumnPool) error {
 	if len(*s) > 0 {
 		// Already inferred.
 		return s.DecodeResult(r, version, b)
@@ -43,37 +63,28 @@ func (s *Results) decodeAuto(r *Reader, version int, b Block) error {
 		if err != nil {
 			return errors.Wrapf(err, "column [%d] type", i)
 		}
-		var customSerialization bool
-		if FeatureCustomSerialization.In(version) {
-			if customSerialization, err = r.Bool(); err != nil {
-				return errors.Wrapf(err, "column [%d] custom serialization", i)
-			}
-			if customSerialization {
-				// Not implemented.
-				return errors.Wrapf(err, "column [%d] has custom serialization (not supported)", i)
-			}
+		colType := ColumnType(columnTypeRaw)
+		serialization, err := decodeSerializationInfo(r, version, colType)
+		if err != nil {
+			return errors.Wrapf(err, "column [%d] serialization", i)
 		}
-		var (
-			colType = ColumnType(columnTypeRaw)
-			col     = &ColAuto{}
-		)
-		if err := col.Infer(colType); err != nil {
+		col, err := pool.Get(colType)
+		if err != nil {
 			return errors.Wrap(err, "column type inference")
 		}
-		col.Data.Reset()
 		if b.Rows != 0 {
-			if s, ok := col.Data.(Stateful); ok {
+			if s, ok := col.(Stateful); ok {
 				if err := s.DecodeState(r); err != nil {
 					return errors.Wrapf(err, "%s state", columnName)
 				}
 			}
-			if err := col.Data.DecodeColumn(r, b.Rows); err != nil {
+			if err := serialization.DecodeColumn(r, colType, col, b.Rows); err != nil {
 				return errors.Wrap(err, columnName)
 			}
 		}
 		*s = append(*s, ResultColumn{
 			Name: columnName,
-			Data: col.Data,
+			Data: col,
 		})
 	}
 	return nil
@@ -98,15 +109,9 @@ func (s Results) DecodeResult(r *Reader, version int, b Block) error {
 		if err != nil {
 			return errors.Wrapf(err, "column [%d] type", i)
 		}
-		if FeatureCustomSerialization.In(version) {
-			customSerialization, err := r.Bool()
-			if err != nil {
-				return errors.Wrapf(err, "column [%d] custom serialization", i)
-			}
-			if customSerialization {
-				// Not implemented.
-				return errors.Wrapf(err, "column [%d] has custom serialization (not supported)", i)
-			}
+		serialization, err := decodeSerializationInfo(r, version, ColumnType(columnType))
+		if err != nil {
+			return errors.Wrapf(err, "column [%d] serialization", i)
 		}
 		if noTarget {
 			// Just reading types and names.
@@ -130,7 +135,9 @@ func (s Results) DecodeResult(r *Reader, version int, b Block) error {
 			}
 		}
 		hasType := t.Data.Type()
-		if gotType.Conflicts(hasType) {
+		nothing, isNothing := t.Data.(nothingDecoder)
+		isNothing = isNothing && nothingCompatible(gotType, hasType)
+		if gotType.Conflicts(hasType) && !isNothing {
 			return errors.Errorf("[%d]: %s: unexpected type %q (got) instead of %q (has)",
 				i, columnName, gotType, hasType,
 			)
@@ -139,12 +146,19 @@ func (s Results) DecodeResult(r *Reader, version int, b Block) error {
 		if b.Rows == 0 {
 			continue
 		}
+		if isNothing {
+			// Decoding e.g. "SELECT NULL" to Nullable(String).
+			if err := nothing.decodeNothing(r, b.Rows); err != nil {
+				return errors.Wrap(err, columnName)
+			}
+			continue
+		}
 		if s, ok := t.Data.(StateDecoder); ok {
 			if err := s.DecodeState(r); err != nil {
 				return errors.Wrapf(err, "%s state", columnName)
 			}
 		}
-		if err := t.Data.DecodeColumn(r, b.Rows); err != nil {
+		if err := serialization.DecodeColumn(r, gotType, t.Data, b.Rows); err != nil {
 			return errors.Wrap(err, columnName)
 		}
 	}
@@ -0,0 +1,116 @@
+package rowbinary
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// readerSize is size of proto.Reader buffer, so proto.Reader reuses
+// Decoder buffer and Decoder can peek to detect end of data.
+const readerSize = 128 * 1024
+
+// Decoder decodes RowBinary rows into columns.
+type Decoder struct {
+	buf     *bufio.Reader
+	r       *proto.Reader
+	columns []proto.ColInfo
+	nodes   []node
+	results proto.Results
+	native  proto.Buffer
+}
+
+// NewDecoder returns new Decoder of RowBinary data with provided columns.
+func NewDecoder(r io.Reader, columns []proto.ColInfo) (*Decoder, error) {
+	buf := bufio.NewReaderSize(r, readerSize)
+	d := &Decoder{
+		buf: buf,
+		r:   proto.NewReader(buf),
+	}
+	for _, c := range columns {
+		t, n, err := parseType(c.Type)
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		col, err := proto.NewColumn(nativeType(t).Type())
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		d.columns = append(d.columns, c)
+		d.nodes = append(d.nodes, n)
+		d.results = append(d.results, proto.ResultColumn{Name: c.Name, Data: col})
+	}
+	return d, nil
+}
+
+// NewDecoderWithNamesAndTypes returns new Decoder of
+// RowBinaryWithNamesAndTypes data, reading columns from header.
+func NewDecoderWithNamesAndTypes(r io.Reader) (*Decoder, error) {
+	buf := bufio.NewReaderSize(r, readerSize)
+	h := proto.NewReader(buf)
+	n, err := h.UVarInt()
+	if err != nil {
+		return nil, errors.Wrap(err, "columns count")
+	}
+	if n > 1<<16 {
+		return nil, errors.Errorf("too many columns: %d", n)
+	}
+	columns := make([]proto.ColInfo, n)
+	for i := range columns {
+		if columns[i].Name, err = h.Str(); err != nil {
+			return nil, errors.Wrapf(err, "column [%d] name", i)
+		}
+	}
+	for i := range columns {
+		t, err := h.Str()
+		if err != nil {
+			return nil, errors.Wrapf(err, "column [%d] type", i)
+		}
+		columns[i].Type = proto.ColumnType(t)
+	}
+	return NewDecoder(buf, columns)
+}
+
+// Columns returns names and types of columns.
+func (d *Decoder) Columns() []proto.ColInfo {
+	return d.columns
+}
+
+// Decode decodes up to maxRows rows (or all rows if maxRows is zero)
+// and returns them as columns, returning io.EOF if there are no rows left.
+//
+// LowCardinality(T) columns are decoded as T. Returned results are valid
+// until next call to Decode.
+func (d *Decoder) Decode(maxRows int) (proto.Results, error) {
+	for _, n := range d.nodes {
+		n.reset()
+	}
+	var rows int
+	for maxRows <= 0 || rows < maxRows {
+		if _, err := d.buf.Peek(1); err == io.EOF {
+			break
+		}
+		for i, n := range d.nodes {
+			if err := n.read(d.r); err != nil {
+				return nil, errors.Wrapf(err, "row %d: %s", rows, d.columns[i].Name)
+			}
+		}
+		rows++
+	}
+	if rows == 0 {
+		return nil, io.EOF
+	}
+	for i, n := range d.nodes {
+		d.native.Reset()
+		n.native(&d.native)
+		col := d.results[i].Data
+		col.Reset()
+		if err := col.DecodeColumn(d.native.Reader(), rows); err != nil {
+			return nil, errors.Wrap(err, d.columns[i].Name)
+		}
+	}
+	return d.results, nil
+}
@@ -0,0 +1,402 @@
+package rowbinary
+
+import (
+	"encoding/binary"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// node transcodes values of single type between RowBinary (row-major)
+// and Native (column-major) layouts.
+type node interface {
+	// read reads single RowBinary value and appends it in Native layout.
+	read(r *proto.Reader) error
+	// appendDefault appends default value, e.g. for NULL of Nullable(T).
+	appendDefault()
+	// native appends accumulated Native data to b.
+	native(b *proto.Buffer)
+	reset()
+
+	// parse splits Native data of rows values, returning remaining data.
+	parse(data []byte, rows int) ([]byte, error)
+	// write writes i-th parsed value in RowBinary layout.
+	write(b *proto.Buffer, i int)
+}
+
+// newNode returns node for type.
+//
+// LowCardinality(T) is transcoded as T, see nativeType.
+func newNode(t *proto.ColumnTypeNode) (node, error) {
+	if t.Kind != proto.ColumnTypeNodeType {
+		return nil, errors.Errorf("%s is not a type", t)
+	}
+	elem := func(i int) (node, error) {
+		e := t.Elem(i)
+		if e == nil {
+			return nil, errors.Errorf("%s: no element %d", t, i)
+		}
+		return newNode(e)
+	}
+	switch t.Base {
+	case proto.ColumnTypeString:
+		return &strNode{}, nil
+	case proto.ColumnTypeLowCardinality:
+		return elem(0)
+	case proto.ColumnTypeNullable:
+		e, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		return &nullableNode{elem: e}, nil
+	case proto.ColumnTypeArray:
+		e, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		return &arrNode{elem: e}, nil
+	case proto.ColumnTypeMap:
+		k, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		v, err := elem(1)
+		if err != nil {
+			return nil, err
+		}
+		// Map(K, V) has same layout as Array(Tuple(K, V)).
+		return &arrNode{elem: &tupleNode{elems: []node{k, v}}}, nil
+	case proto.ColumnTypeTuple:
+		n := &tupleNode{}
+		for i := range t.Params {
+			e, err := elem(i)
+			if err != nil {
+				return nil, err
+			}
+			n.elems = append(n.elems, e)
+		}
+		return n, nil
+	case proto.ColumnTypePoint:
+		return &tupleNode{elems: []node{&fixedNode{size: 8}, &fixedNode{size: 8}}}, nil
+	case proto.ColumnTypeRing:
+		return newNode(&proto.ColumnTypeNode{Base: proto.ColumnTypeArray, Params: []*proto.ColumnTypeNode{
+			{Base: proto.ColumnTypePoint},
+		}})
+	case proto.ColumnTypePolygon:
+		return newNode(&proto.ColumnTypeNode{Base: proto.ColumnTypeArray, Params: []*proto.ColumnTypeNode{
+			{Base: proto.ColumnTypeRing},
+		}})
+	case proto.ColumnTypeMultiPolygon:
+		return newNode(&proto.ColumnTypeNode{Base: proto.ColumnTypeArray, Params: []*proto.ColumnTypeNode{
+			{Base: proto.ColumnTypePolygon},
+		}})
+	}
+	size, ok := t.Type().FixedWidth()
+	if !ok {
+		return nil, errors.Errorf("type %s is not supported", t)
+	}
+	n := &fixedNode{size: size}
+	if (t.Base == proto.ColumnTypeEnum8 || t.Base == proto.ColumnTypeEnum16) && len(t.Params) > 0 {
+		// Zero may be not a valid value of enum, so first value is default.
+		n.def = make([]byte, size)
+		v := t.Params[0].EnumValue
+		if size == 1 {
+			n.def[0] = byte(int8(v))
+		} else {
+			binary.LittleEndian.PutUint16(n.def, uint16(int16(v)))
+		}
+	}
+	return n, nil
+}
+
+// nativeType returns type of column that holds values of t in Native
+// layout, i.e. t with LowCardinality(T) replaced by T, because RowBinary
+// has no dictionary encoding.
+func nativeType(t *proto.ColumnTypeNode) *proto.ColumnTypeNode {
+	if t.Kind != proto.ColumnTypeNodeType {
+		return t
+	}
+	if t.Base == proto.ColumnTypeLowCardinality && len(t.Params) == 1 {
+		inner := nativeType(t.Params[0])
+		if inner.Name == "" {
+			v := *inner
+			v.Name = t.Name
+			return &v
+		}
+		return inner
+	}
+	v := *t
+	v.Params = nil
+	for _, p := range t.Params {
+		v.Params = append(v.Params, nativeType(p))
+	}
+	if t.Params != nil && v.Params == nil {
+		v.Params = []*proto.ColumnTypeNode{}
+	}
+	return &v
+}
+
+// fixedNode is fixed-width value, same in both layouts.
+type fixedNode struct {
+	size int
+	def  []byte
+	data []byte
+}
+
+func (n *fixedNode) read(r *proto.Reader) error {
+	start := len(n.data)
+	n.data = append(n.data, make([]byte, n.size)...)
+	return r.ReadFull(n.data[start:])
+}
+
+func (n *fixedNode) appendDefault() {
+	if n.def != nil {
+		n.data = append(n.data, n.def...)
+		return
+	}
+	n.data = append(n.data, make([]byte, n.size)...)
+}
+
+func (n *fixedNode) native(b *proto.Buffer) { b.PutRaw(n.data) }
+
+func (n *fixedNode) reset() { n.data = n.data[:0] }
+
+func (n *fixedNode) parse(data []byte, rows int) ([]byte, error) {
+	size := rows * n.size
+	if len(data) < size {
+		return nil, errors.Errorf("not enough data: %d < %d", len(data), size)
+	}
+	n.data = data[:size]
+	return data[size:], nil
+}
+
+func (n *fixedNode) write(b *proto.Buffer, i int) {
+	b.PutRaw(n.data[i*n.size : (i+1)*n.size])
+}
+
+// strNode is String, same in both layouts.
+type strNode struct {
+	data []byte
+	pos  [][2]int
+}
+
+func (n *strNode) read(r *proto.Reader) error {
+	l, err := r.StrLen()
+	if err != nil {
+		return errors.Wrap(err, "length")
+	}
+	n.data = binary.AppendUvarint(n.data, uint64(l))
+	start := len(n.data)
+	n.data = append(n.data, make([]byte, l)...)
+	return r.ReadFull(n.data[start:])
+}
+
+func (n *strNode) appendDefault() { n.data = append(n.data, 0) }
+
+func (n *strNode) native(b *proto.Buffer) { b.PutRaw(n.data) }
+
+func (n *strNode) reset() {
+	n.data = n.data[:0]
+	n.pos = n.pos[:0]
+}
+
+func (n *strNode) parse(data []byte, rows int) ([]byte, error) {
+	n.pos = n.pos[:0]
+	var offset int
+	for i := 0; i < rows; i++ {
+		l, size := binary.Uvarint(data[offset:])
+		if size <= 0 {
+			return nil, errors.Errorf("row %d: invalid length", i)
+		}
+		start := offset
+		offset += size + int(l)
+		if offset > len(data) || offset < start {
+			return nil, errors.Errorf("row %d: not enough data", i)
+		}
+		n.pos = append(n.pos, [2]int{start, offset})
+	}
+	n.data = data[:offset]
+	return data[offset:], nil
+}
+
+func (n *strNode) write(b *proto.Buffer, i int) {
+	p := n.pos[i]
+	b.PutRaw(n.data[p[0]:p[1]])
+}
+
+// nullableNode is Nullable(T).
+//
+// RowBinary: null flag, then value only if not null.
+// Native: null flags of all rows, then values (default ones for nulls).
+type nullableNode struct {
+	nulls []byte
+	elem  node
+}
+
+func (n *nullableNode) read(r *proto.Reader) error {
+	null, err := r.Byte()
+	if err != nil {
+		return errors.Wrap(err, "null")
+	}
+	n.nulls = append(n.nulls, null)
+	if null != 0 {
+		n.elem.appendDefault()
+		return nil
+	}
+	return n.elem.read(r)
+}
+
+func (n *nullableNode) appendDefault() {
+	n.nulls = append(n.nulls, 1)
+	n.elem.appendDefault()
+}
+
+func (n *nullableNode) native(b *proto.Buffer) {
+	b.PutRaw(n.nulls)
+	n.elem.native(b)
+}
+
+func (n *nullableNode) reset() {
+	n.nulls = n.nulls[:0]
+	n.elem.reset()
+}
+
+func (n *nullableNode) parse(data []byte, rows int) ([]byte, error) {
+	if len(data) < rows {
+		return nil, errors.Errorf("not enough data for nulls: %d < %d", len(data), rows)
+	}
+	n.nulls = data[:rows]
+	return n.elem.parse(data[rows:], rows)
+}
+
+func (n *nullableNode) write(b *proto.Buffer, i int) {
+	b.PutByte(n.nulls[i])
+	if n.nulls[i] == 0 {
+		n.elem.write(b, i)
+	}
+}
+
+// arrNode is Array(T) or Map(K, V) as Array(Tuple(K, V)).
+//
+// RowBinary: varint length, then values.
+// Native: offsets of all rows, then values.
+type arrNode struct {
+	offsets []uint64
+	elem    node
+}
+
+func (n *arrNode) last() uint64 {
+	if len(n.offsets) == 0 {
+		return 0
+	}
+	return n.offsets[len(n.offsets)-1]
+}
+
+func (n *arrNode) read(r *proto.Reader) error {
+	l, err := r.UVarInt()
+	if err != nil {
+		return errors.Wrap(err, "length")
+	}
+	for i := uint64(0); i < l; i++ {
+		if err := n.elem.read(r); err != nil {
+			return errors.Wrapf(err, "[%d]", i)
+		}
+	}
+	n.offsets = append(n.offsets, n.last()+l)
+	return nil
+}
+
+func (n *arrNode) appendDefault() { n.offsets = append(n.offsets, n.last()) }
+
+func (n *arrNode) native(b *proto.Buffer) {
+	for _, o := range n.offsets {
+		b.PutUInt64(o)
+	}
+	n.elem.native(b)
+}
+
+func (n *arrNode) reset() {
+	n.offsets = n.offsets[:0]
+	n.elem.reset()
+}
+
+func (n *arrNode) parse(data []byte, rows int) ([]byte, error) {
+	if len(data) < rows*8 {
+		return nil, errors.Errorf("not enough data for offsets: %d < %d", len(data), rows*8)
+	}
+	n.offsets = n.offsets[:0]
+	var prev uint64
+	for i := 0; i < rows; i++ {
+		o := binary.LittleEndian.Uint64(data[i*8:])
+		if o < prev {
+			return nil, errors.Errorf("row %d: invalid offset %d", i, o)
+		}
+		prev = o
+		n.offsets = append(n.offsets, o)
+	}
+	return n.elem.parse(data[rows*8:], int(n.last()))
+}
+
+func (n *arrNode) write(b *proto.Buffer, i int) {
+	var start uint64
+	if i > 0 {
+		start = n.offsets[i-1]
+	}
+	end := n.offsets[i]
+	b.PutUVarInt(end - start)
+	for k := start; k < end; k++ {
+		n.elem.write(b, int(k))
+	}
+}
+
+// tupleNode is Tuple(T1, T2, ...).
+//
+// RowBinary: values of elements.
+// Native: columns of elements, one after another.
+type tupleNode struct {
+	elems []node
+}
+
+func (n *tupleNode) read(r *proto.Reader) error {
+	for i, e := range n.elems {
+		if err := e.read(r); err != nil {
+			return errors.Wrapf(err, "element %d", i)
+		}
+	}
+	return nil
+}
+
+func (n *tupleNode) appendDefault() {
+	for _, e := range n.elems {
+		e.appendDefault()
+	}
+}
+
+func (n *tupleNode) native(b *proto.Buffer) {
+	for _, e := range n.elems {
+		e.native(b)
+	}
+}
+
+func (n *tupleNode) reset() {
+	for _, e := range n.elems {
+		e.reset()
+	}
+}
+
+func (n *tupleNode) parse(data []byte, rows int) ([]byte, error) {
+	for i, e := range n.elems {
+		var err error
+		if data, err = e.parse(data, rows); err != nil {
+			return nil, errors.Wrapf(err, "element %d", i)
+		}
+	}
+	return data, nil
+}
+
+func (n *tupleNode) write(b *proto.Buffer, i int) {
+	for _, e := range n.elems {
+		e.write(b, i)
+	}
+}
@@ -0,0 +1,101 @@
+// Package rowbinary implements RowBinary and RowBinaryWithNamesAndTypes
+// formats using proto columns.
+//
+// Values are transcoded between row-major RowBinary and column-major
+// Native layouts, so all columns that can be decoded from or encoded to
+// Native format are supported, with some exceptions:
+//
+//   - LowCardinality(T) is decoded as T, because RowBinary has no
+//     dictionary encoding, and can't be encoded, use T column instead.
+//   - Types with state, like Variant, Dynamic or JSON, are not supported.
+//
+// See https://clickhouse.com/docs/en/interfaces/formats#rowbinary.
+package rowbinary
+
+import (
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func parseType(t proto.ColumnType) (*proto.ColumnTypeNode, node, error) {
+	n, err := t.Parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	v, err := newNode(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return n, v, nil
+}
+
+// Encode appends rows of input to b in RowBinary format.
+func Encode(b *proto.Buffer, input proto.Input) error {
+	if len(input) == 0 {
+		return nil
+	}
+	var (
+		nodes = make([]node, len(input))
+		bufs  = make([]proto.Buffer, len(input))
+		rows  = input[0].Data.Rows()
+	)
+	for i, c := range input {
+		t, n, err := parseType(c.Data.Type())
+		if err != nil {
+			return errors.Wrap(err, c.Name)
+		}
+		if nativeType(t).String() != t.String() {
+			return errors.Errorf("%s: LowCardinality is not supported", c.Name)
+		}
+		if c.Data.Rows() != rows {
+			return errors.Errorf("%s: %d (rows) != %d (expected)", c.Name, c.Data.Rows(), rows)
+		}
+		if _, ok := c.Data.(proto.StateEncoder); ok {
+			// Stateful columns encode state (and data) in custom way.
+			if !isStateless(c.Data) {
+				return errors.Errorf("%s: column with state is not supported", c.Name)
+			}
+		}
+		if p, ok := c.Data.(proto.Preparable); ok {
+			if err := p.Prepare(); err != nil {
+				return errors.Wrapf(err, "%s: prepare", c.Name)
+			}
+		}
+		c.Data.EncodeColumn(&bufs[i])
+		rest, err := n.parse(bufs[i].Buf, rows)
+		if err != nil {
+			return errors.Wrap(err, c.Name)
+		}
+		if len(rest) != 0 {
+			return errors.Errorf("%s: %d unexpected trailing bytes", c.Name, len(rest))
+		}
+		nodes[i] = n
+	}
+	for i := 0; i < rows; i++ {
+		for _, n := range nodes {
+			n.write(b, i)
+		}
+	}
+	return nil
+}
+
+// isStateless reports whether column with EncodeState writes no state.
+func isStateless(c proto.ColInput) bool {
+	var b proto.Buffer
+	c.(proto.StateEncoder).EncodeState(&b)
+	return len(b.Buf) == 0
+}
+
+// EncodeWithNamesAndTypes appends header and rows of input to b in
+// RowBinaryWithNamesAndTypes format.
+func EncodeWithNamesAndTypes(b *proto.Buffer, input proto.Input) error {
+	b.PutUVarInt(uint64(len(input)))
+	for _, c := range input {
+		b.PutString(c.Name)
+	}
+	for _, c := range input {
+		b.PutString(c.Data.Type().String())
+	}
+	return Encode(b, input)
+}
@@ -0,0 +1,154 @@
+package rowbinary
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestEncode(t *testing.T) {
+	var (
+		u8   = proto.ColUInt8{1, 2}
+		str  = new(proto.ColStr)
+		null = new(proto.ColInt32).Nullable()
+		arr  = new(proto.ColUInt8).Array()
+	)
+	str.AppendArr([]string{"ab", ""})
+	null.AppendArr([]proto.Nullable[int32]{proto.Null[int32](), proto.NewNullable[int32](-1)})
+	arr.AppendArr([][]uint8{{1, 2}, {}})
+
+	var b proto.Buffer
+	require.NoError(t, Encode(&b, proto.Input{
+		{Name: "u8", Data: u8},
+		{Name: "str", Data: str},
+		{Name: "null", Data: null},
+		{Name: "arr", Data: arr},
+	}))
+	require.Equal(t, []byte{
+		// Row 0.
+		0x01,
+		0x02, 'a', 'b',
+		0x01,
+		0x02, 0x01, 0x02,
+		// Row 1.
+		0x02,
+		0x00,
+		0x00, 0xff, 0xff, 0xff, 0xff,
+		0x00,
+	}, b.Buf)
+
+	require.Error(t, Encode(&b, proto.Input{
+		{Name: "lc", Data: new(proto.ColStr).LowCardinality()},
+	}))
+	require.Error(t, Encode(&b, proto.Input{
+		{Name: "a", Data: proto.ColUInt8{1}},
+		{Name: "b", Data: proto.ColUInt8{1, 2}},
+	}))
+}
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		ints  = new(proto.ColInt64)
+		str   = new(proto.ColStr)
+		fixed = proto.NewFixedStr(3)
+		enum  = new(proto.ColEnum).WithValues(map[string]int8{"a": 1, "b": 2})
+		null  = new(proto.ColStr).Nullable()
+		arr   = proto.NewArray[[]string](new(proto.ColStr).Array())
+		dt    = new(proto.ColDateTime64).WithPrecision(proto.PrecisionMilli)
+		m     = proto.NewMap[string, string](new(proto.ColStr), new(proto.ColStr))
+		tuple = proto.ColTuple{new(proto.ColStr), new(proto.ColUInt16).Nullable()}
+		now   = time.Unix(1700000000, 123e6)
+	)
+	for i := 0; i < 5; i++ {
+		ints.Append(int64(i) - 2)
+		str.Append(string(rune('a' + i)))
+		fixed.Append([]byte{byte(i), 1, 2})
+		enum.Append([]string{"a", "b"}[i%2])
+		if i%2 == 0 {
+			null.Append(proto.Null[string]())
+		} else {
+			null.Append(proto.NewNullable("x"))
+		}
+		arr.Append([][]string{{"foo"}, {}, {"bar", "baz"}}[:i%3])
+		dt.Append(now.Add(time.Duration(i) * time.Second))
+		m.Append(map[string]string{"k": str.Row(i)})
+		tuple[0].(*proto.ColStr).Append("t")
+		tuple[1].(*proto.ColNullable[uint16]).Append(proto.NewNullable(uint16(i)))
+	}
+	input := proto.Input{
+		{Name: "ints", Data: ints},
+		{Name: "str", Data: str},
+		{Name: "fixed", Data: fixed},
+		{Name: "enum", Data: enum},
+		{Name: "null", Data: null},
+		{Name: "arr", Data: arr},
+		{Name: "dt", Data: dt},
+		{Name: "m", Data: m},
+		{Name: "tuple", Data: tuple},
+	}
+
+	var b proto.Buffer
+	require.NoError(t, EncodeWithNamesAndTypes(&b, input))
+	d, err := NewDecoderWithNamesAndTypes(bytes.NewReader(b.Buf))
+	require.NoError(t, err)
+	require.Len(t, d.Columns(), len(input))
+
+	var rows int
+	for {
+		results, err := d.Decode(2)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		n := results.Rows()
+		for i := 0; i < n; i++ {
+			row := rows + i
+			require.Equal(t, ints.Row(row), results[0].Data.(*proto.ColInt64).Row(i))
+			require.Equal(t, str.Row(row), results[1].Data.(*proto.ColStr).Row(i))
+			require.Equal(t, fixed.Row(row), results[2].Data.(*proto.ColFixedStr).Row(i))
+			require.Equal(t, enum.Row(row), results[3].Data.(*proto.ColEnum).Row(i))
+			require.Equal(t, null.Row(row), results[4].Data.(*proto.ColNullable[string]).Row(i))
+			require.Equal(t, arr.Row(row), results[5].Data.(*proto.ColArr[[]string]).Row(i))
+			require.True(t, dt.Row(row).Equal(results[6].Data.(*proto.ColDateTime64).Row(i)))
+			require.Equal(t, m.Row(row), results[7].Data.(proto.ColumnOf[map[string]string]).Row(i))
+		}
+		rows += n
+	}
+	require.Equal(t, 5, rows)
+}
+
+func TestDecoder_LowCardinality(t *testing.T) {
+	data := []byte{0x00, 0x01, 'a', 0x00, 0x00, 0x01, 0x00, 0x01, 'b'}
+	d, err := NewDecoder(bytes.NewReader(data), []proto.ColInfo{
+		{Name: "s", Type: "LowCardinality(Nullable(String))"},
+	})
+	require.NoError(t, err)
+	results, err := d.Decode(0)
+	require.NoError(t, err)
+	col := results[0].Data.(*proto.ColNullable[string])
+	require.Equal(t, []proto.Nullable[string]{
+		proto.NewNullable("a"),
+		proto.NewNullable(""),
+		proto.Null[string](),
+		proto.NewNullable("b"),
+	}, []proto.Nullable[string]{col.Row(0), col.Row(1), col.Row(2), col.Row(3)})
+	_, err = d.Decode(0)
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = NewDecoder(bytes.NewReader(nil), []proto.ColInfo{{Name: "v", Type: "Variant(String)"}})
+	require.Error(t, err)
+}
+
+func TestDecoder_Truncated(t *testing.T) {
+	d, err := NewDecoder(bytes.NewReader([]byte{0x05, 'a'}), []proto.ColInfo{
+		{Name: "s", Type: "String"},
+	})
+	require.NoError(t, err)
+	_, err = d.Decode(0)
+	require.Error(t, err)
+}
@@ -0,0 +1,275 @@
+package proto
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// SerializationKind is kind of custom column serialization.
+type SerializationKind byte
+
+// Possible serialization kinds.
+const (
+	SerializationDefault SerializationKind = 0
+	SerializationSparse  SerializationKind = 1
+)
+
+func (k SerializationKind) String() string {
+	switch k {
+	case SerializationDefault:
+		return "Default"
+	case SerializationSparse:
+		return "Sparse"
+	default:
+		return "SerializationKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// sparseEndOfGranule marks last group of sparse offsets.
+const sparseEndOfGranule = 1 << 62
+
+// serializationInfo describes serialization of column, sent after column
+// name and type if FeatureCustomSerialization is supported.
+//
+// Tuple elements have their own serialization kinds.
+type serializationInfo struct {
+	Kind  SerializationKind
+	Elems []serializationInfo
+}
+
+// Custom reports whether column or any of its elements has non-default
+// serialization.
+func (s serializationInfo) Custom() bool {
+	if s.Kind != SerializationDefault {
+		return true
+	}
+	for _, e := range s.Elems {
+		if e.Custom() {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSerializationInfo decodes serialization info of column with type t.
+func decodeSerializationInfo(r *Reader, version int, t ColumnType) (serializationInfo, error) {
+	if !FeatureCustomSerialization.In(version) {
+		return serializationInfo{}, nil
+	}
+	custom, err := r.Bool()
+	if err != nil {
+		return serializationInfo{}, errors.Wrap(err, "custom serialization flag")
+	}
+	if !custom {
+		return serializationInfo{}, nil
+	}
+	var s serializationInfo
+	if err := s.decodeKinds(r, t); err != nil {
+		return serializationInfo{}, errors.Wrap(err, "kinds")
+	}
+	return s, nil
+}
+
+func (s *serializationInfo) decodeKinds(r *Reader, t ColumnType) error {
+	v, err := r.UInt8()
+	if err != nil {
+		return errors.Wrap(err, "kind")
+	}
+	s.Kind = SerializationKind(v)
+	switch s.Kind {
+	case SerializationDefault, SerializationSparse:
+	default:
+		return errors.Errorf("%s is not supported", s.Kind)
+	}
+	if t.Base() != ColumnTypeTuple {
+		return nil
+	}
+	for i, e := range t.elems() {
+		_, elemType := tupleElem(e)
+		var elem serializationInfo
+		if err := elem.decodeKinds(r, elemType); err != nil {
+			return errors.Wrapf(err, "[%d]", i)
+		}
+		s.Elems = append(s.Elems, elem)
+	}
+	return nil
+}
+
+// DecodeColumn decodes rows of column with type t to col, expanding sparse
+// serialization if needed.
+func (s serializationInfo) DecodeColumn(r *Reader, t ColumnType, col ColResult, rows int) error {
+	if !s.Custom() {
+		return col.DecodeColumn(r, rows)
+	}
+	switch v := col.(type) {
+	case *ColAuto:
+		col = v.Data
+	case colNamedAuto:
+		col = v.Data
+	}
+	if t.Base() == ColumnTypeTuple {
+		if s.Kind != SerializationDefault {
+			return errors.Errorf("%s serialization of tuple is not supported", s.Kind)
+		}
+		var tuple ColTuple
+		switch v := col.(type) {
+		case ColTuple:
+			tuple = v
+		case *ColTuple:
+			tuple = *v
+		default:
+			return errors.Errorf("custom serialization of tuple elements is not supported for %T", col)
+		}
+		elems := t.elems()
+		if len(elems) != len(tuple) || len(elems) != len(s.Elems) {
+			return errors.Errorf("tuple has %d elements, got %d", len(tuple), len(elems))
+		}
+		for i, e := range elems {
+			_, elemType := tupleElem(e)
+			if err := s.Elems[i].DecodeColumn(r, elemType, tuple[i], rows); err != nil {
+				return errors.Wrapf(err, "[%d]", i)
+			}
+		}
+		return nil
+	}
+
+	if t.Base() == ColumnTypeNullable {
+		return errors.Errorf("sparse serialization of Nullable column %q is not supported", t)
+	}
+
+	// Expanding sparse column to default serialization.
+	b := r.sparseBuf()
+	if err := decodeSparse(r, t, rows, b); err != nil {
+		return errors.Wrap(err, "sparse")
+	}
+	return col.DecodeColumn(r.subReader(b.Buf), rows)
+}
+
+// decodeSparse reads sparse serialization of column with type t from r and
+// writes default serialization of the same rows to b.
+//
+// Sparse serialization consists of offsets and non-default values. Offsets
+// are encoded as count of default values before each non-default value,
+// with last count (trailing defaults) marked by sparseEndOfGranule flag.
+func decodeSparse(r *Reader, t ColumnType, rows int, b *Buffer) error {
+	width, fixed := fixedWidth(t)
+	if !fixed && t != ColumnTypeString {
+		return errors.Errorf("sparse serialization of %q is not supported", t)
+	}
+	var (
+		nonDefault []int // indexes of non-default rows
+		pos        int
+	)
+	for {
+		v, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrap(err, "offset")
+		}
+		end := v&sparseEndOfGranule != 0
+		v &^= sparseEndOfGranule
+		if v > uint64(rows-pos) {
+			return errors.Errorf("offset %d is out of range for %d rows", pos+int(v), rows)
+		}
+		pos += int(v)
+		if end {
+			break
+		}
+		if pos >= rows {
+			return errors.Errorf("value index %d is out of range for %d rows", pos, rows)
+		}
+		nonDefault = append(nonDefault, pos)
+		pos++
+	}
+	if pos != rows {
+		return errors.Errorf("offsets cover %d rows, expected %d", pos, rows)
+	}
+
+	if !fixed {
+		for i := 0; i < rows; i++ {
+			if len(nonDefault) == 0 || nonDefault[0] != i {
+				b.PutUVarInt(0)
+				continue
+			}
+			v, err := r.StrRaw()
+			if err != nil {
+				return errors.Wrapf(err, "value [%d]", i)
+			}
+			b.PutUVarInt(uint64(len(v)))
+			b.PutRaw(v)
+			nonDefault = nonDefault[1:]
+		}
+		return nil
+	}
+
+	data, err := r.ReadRaw(len(nonDefault) * width)
+	if err != nil {
+		return errors.Wrap(err, "values")
+	}
+	start := len(b.Buf)
+	b.Buf = append(b.Buf, make([]byte, rows*width)...)
+	for i, idx := range nonDefault {
+		copy(b.Buf[start+idx*width:], data[i*width:(i+1)*width])
+	}
+	return nil
+}
+
+// FixedWidth returns size of single value in bytes, if type has fixed
+// width, like Int32 or FixedString(N).
+func (c ColumnType) FixedWidth() (int, bool) {
+	return fixedWidth(c)
+}
+
+// fixedWidth returns size of single value of t in bytes, if t has fixed
+// width.
+func fixedWidth(t ColumnType) (int, bool) {
+	if strings.HasPrefix(t.String(), ColumnTypeInterval.String()) {
+		return 8, true
+	}
+	switch t.Base() {
+	case ColumnTypeInt8, ColumnTypeUInt8, ColumnTypeBool, ColumnTypeEnum8:
+		return 1, true
+	case ColumnTypeInt16, ColumnTypeUInt16, ColumnTypeEnum16, ColumnTypeDate, ColumnTypeBFloat16:
+		return 2, true
+	case ColumnTypeInt32, ColumnTypeUInt32, ColumnTypeFloat32, ColumnTypeDate32,
+		ColumnTypeDateTime, ColumnTypeIPv4, ColumnTypeDecimal32:
+		return 4, true
+	case ColumnTypeInt64, ColumnTypeUInt64, ColumnTypeFloat64,
+		ColumnTypeDateTime64, ColumnTypeDecimal64:
+		return 8, true
+	case ColumnTypeInt128, ColumnTypeUInt128, ColumnTypeDecimal128,
+		ColumnTypeUUID, ColumnTypeIPv6:
+		return 16, true
+	case ColumnTypeInt256, ColumnTypeUInt256, ColumnTypeDecimal256:
+		return 32, true
+	case ColumnTypeFixedString:
+		n, err := strconv.Atoi(string(t.Elem()))
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return n, true
+	case ColumnTypeDecimal:
+		elems := t.elems()
+		if len(elems) == 0 {
+			return 0, false
+		}
+		precision, err := strconv.Atoi(string(elems[0]))
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case precision <= 0:
+			return 0, false
+		case precision <= 9:
+			return 4, true
+		case precision <= 18:
+			return 8, true
+		case precision <= 38:
+			return 16, true
+		case precision <= 76:
+			return 32, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,225 @@
+package proto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// putSparseHeader writes column header with sparse serialization kind.
+func putSparseHeader(b *Buffer, name string, t ColumnType, kinds ...SerializationKind) {
+	b.PutString(name)
+	b.PutString(string(t))
+	b.PutBool(true)
+	for _, k := range kinds {
+		b.PutByte(byte(k))
+	}
+}
+
+// putSparseOffsets writes sparse offsets for non-default rows.
+func putSparseOffsets(b *Buffer, rows int, nonDefault ...int) {
+	var start int
+	for _, idx := range nonDefault {
+		b.PutUVarInt(uint64(idx - start))
+		start = idx + 1
+	}
+	b.PutUVarInt(uint64(rows-start) | sparseEndOfGranule)
+}
+
+func TestSerializationSparse(t *testing.T) {
+	t.Parallel()
+	const rows = 6
+	var b Buffer
+	Block{Columns: 4, Rows: rows}.EncodeAware(&b, Version)
+
+	putSparseHeader(&b, "num", ColumnTypeUInt64, SerializationSparse)
+	putSparseOffsets(&b, rows, 1, 4)
+	b.PutUInt64(10)
+	b.PutUInt64(40)
+
+	putSparseHeader(&b, "str", ColumnTypeString, SerializationSparse)
+	putSparseOffsets(&b, rows, 0, 5)
+	b.PutString("foo")
+	b.PutString("bar")
+
+	putSparseHeader(&b, "date", ColumnTypeDate, SerializationSparse)
+	putSparseOffsets(&b, rows)
+
+	tupleType := ColumnTypeTuple.Sub(ColumnTypeInt32, ColumnTypeString)
+	putSparseHeader(&b, "tuple", tupleType,
+		SerializationDefault, SerializationSparse, SerializationDefault,
+	)
+	putSparseOffsets(&b, rows, 2)
+	b.PutInt32(-3)
+	for i := 0; i < rows; i++ {
+		b.PutString("v")
+	}
+
+	var (
+		num   ColUInt64
+		str   ColStr
+		tuple = ColTuple{new(ColInt32), new(ColStr)}
+		res   = Results{
+			{Name: "num", Data: &num},
+			{Name: "str", Data: &str},
+			AutoResult("date"),
+			{Name: "tuple", Data: tuple},
+		}
+		dec Block
+	)
+	r := NewReader(bytes.NewReader(b.Buf))
+	require.NoError(t, dec.DecodeBlock(r, Version, res))
+
+	require.Equal(t, ColUInt64{0, 10, 0, 0, 40, 0}, num)
+	var gotStr []string
+	_ = str.ForEach(func(i int, s string) error {
+		gotStr = append(gotStr, s)
+		return nil
+	})
+	require.Equal(t, []string{"foo", "", "", "", "", "bar"}, gotStr)
+	require.Equal(t, rows, res[2].Data.Rows())
+	require.Equal(t, ColInt32{0, 0, -3, 0, 0, 0}, *tuple[0].(*ColInt32))
+	require.Equal(t, rows, tuple[1].Rows())
+
+	t.Run("Auto", func(t *testing.T) {
+		var (
+			auto Results
+			dec  Block
+		)
+		r := NewReader(bytes.NewReader(b.Buf))
+		require.NoError(t, dec.DecodeBlock(r, Version, auto.Auto()))
+		require.Len(t, auto, 4)
+		require.Equal(t, num, *auto[0].Data.(*ColUInt64))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		var dec Block
+		r := NewReader(bytes.NewReader(b.Buf[:len(b.Buf)-5]))
+		require.Error(t, dec.DecodeBlock(r, Version, Results{
+			{Name: "num", Data: new(ColUInt64)},
+			{Name: "str", Data: new(ColStr)},
+			AutoResult("date"),
+			{Name: "tuple", Data: ColTuple{new(ColInt32), new(ColStr)}},
+		}))
+	})
+}
+
+func TestSerializationSparse_reader(t *testing.T) {
+	t.Parallel()
+	const rows = 4
+	var b Buffer
+	for i := 0; i < 2; i++ {
+		Block{Columns: 1, Rows: rows}.EncodeAware(&b, Version)
+		putSparseHeader(&b, "str", ColumnTypeString, SerializationSparse)
+		putSparseOffsets(&b, rows, 1)
+		b.PutString("foo")
+	}
+
+	r := NewReader(bytes.NewReader(b.Buf))
+	r.SetLimits(Limits{MaxStrLen: 10})
+	var (
+		str ColStr
+		dec Block
+	)
+	require.NoError(t, dec.DecodeBlock(r, Version, Results{{Name: "str", Data: &str}}))
+	sub := r.sub
+	require.NotNil(t, sub)
+	require.Equal(t, r.Limits(), sub.Limits())
+
+	// Sub reader is reused.
+	str.Reset()
+	require.NoError(t, dec.DecodeBlock(r, Version, Results{{Name: "str", Data: &str}}))
+	require.Same(t, sub, r.sub)
+	require.Equal(t, "foo", str.Row(1))
+
+	t.Run("Limits", func(t *testing.T) {
+		r := NewReader(bytes.NewReader(nil))
+		r.SetLimits(Limits{MaxStrLen: 2})
+		var out Buffer
+		out.PutString("foo")
+		var str ColStr
+		var limitErr *LimitError
+		require.ErrorAs(t, str.DecodeColumn(r.subReader(out.Buf), 1), &limitErr)
+	})
+	t.Run("Nullable", func(t *testing.T) {
+		var b Buffer
+		putSparseOffsets(&b, rows)
+		s := serializationInfo{Kind: SerializationSparse}
+		col := new(ColNullable[uint8])
+		require.ErrorContains(t, s.DecodeColumn(NewReader(bytes.NewReader(b.Buf)),
+			ColumnTypeNullable.Sub(ColumnTypeUInt8), col, rows,
+		), "Nullable")
+	})
+}
+
+func TestSerializationSparse_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		Name string
+		Type ColumnType
+		Put  func(b *Buffer)
+	}{
+		{
+			Name: "OutOfRange",
+			Type: ColumnTypeUInt8,
+			Put: func(b *Buffer) {
+				b.PutUVarInt(10)
+			},
+		},
+		{
+			Name: "ShortOffsets",
+			Type: ColumnTypeUInt8,
+			Put: func(b *Buffer) {
+				b.PutUVarInt(1 | sparseEndOfGranule)
+			},
+		},
+		{
+			Name: "NotSupported",
+			Type: ColumnTypeArray.Sub(ColumnTypeUInt8),
+			Put: func(b *Buffer) {
+				putSparseOffsets(b, 3)
+			},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			var b Buffer
+			tt.Put(&b)
+			var out Buffer
+			require.Error(t, decodeSparse(NewReader(bytes.NewReader(b.Buf)), tt.Type, 3, &out))
+		})
+	}
+}
+
+func TestFixedWidth(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		Type  ColumnType
+		Width int
+	}{
+		{ColumnTypeUInt8, 1},
+		{ColumnTypeEnum16.With("'a' = 1"), 2},
+		{ColumnTypeDateTime.With("'UTC'"), 4},
+		{ColumnTypeDateTime64.With("3"), 8},
+		{ColumnTypeUUID, 16},
+		{ColumnTypeInt256, 32},
+		{ColumnTypeFixedString.With("10"), 10},
+		{ColumnTypeDecimal.With("9", "2"), 4},
+		{ColumnTypeDecimal.With("18", "2"), 8},
+		{ColumnTypeDecimal.With("38", "2"), 16},
+		{ColumnTypeDecimal.With("76", "2"), 32},
+		{"IntervalSecond", 8},
+	} {
+		w, ok := fixedWidth(tt.Type)
+		require.True(t, ok, tt.Type)
+		require.Equal(t, tt.Width, w, tt.Type)
+	}
+	for _, tt := range []ColumnType{
+		ColumnTypeString,
+		ColumnTypeArray.Sub(ColumnTypeUInt8),
+		ColumnTypeNullable.Sub(ColumnTypeUInt8),
+		ColumnTypeFixedString.With("x"),
+	} {
+		_, ok := fixedWidth(tt)
+		require.False(t, ok, tt)
+	}
+}
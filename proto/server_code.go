@@ -22,6 +22,10 @@ const (
 	ServerPartUUIDs        ServerCode = 12 // list of unique parts ids.
 	ServerReadTaskRequest  ServerCode = 13 // String (UUID) describes a request for which next task is needed
 	ServerProfileEvents    ServerCode = 14 // Packet with profile events from server
+
+	ServerMergeTreeAllRangesAnnouncement ServerCode = 15 // parallel replicas: ranges that replica can read
+	ServerMergeTreeReadTaskRequest       ServerCode = 16 // parallel replicas: request for next ranges to read
+	ServerTimezoneUpdate                 ServerCode = 17 // String, new session timezone
 )
 
 // Encode to buffer.
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ServerCodeName = "HelloDataExceptionProgressPongEndOfStreamProfileTotalsExtremesTablesStatusLogTableColumnsServerPartUUIDsServerReadTaskRequestServerProfileEvents"
+const _ServerCodeName = "HelloDataExceptionProgressPongEndOfStreamProfileTotalsExtremesTablesStatusLogTableColumnsServerPartUUIDsServerReadTaskRequestServerProfileEventsServerMergeTreeAllRangesAnnouncementServerMergeTreeReadTaskRequestServerTimezoneUpdate"
 
-var _ServerCodeIndex = [...]uint8{0, 5, 9, 18, 26, 30, 41, 48, 54, 62, 74, 77, 89, 104, 125, 144}
+var _ServerCodeIndex = [...]uint8{0, 5, 9, 18, 26, 30, 41, 48, 54, 62, 74, 77, 89, 104, 125, 144, 180, 210, 230}
 
-const _ServerCodeLowerName = "hellodataexceptionprogresspongendofstreamprofiletotalsextremestablesstatuslogtablecolumnsserverpartuuidsserverreadtaskrequestserverprofileevents"
+const _ServerCodeLowerName = "hellodataexceptionprogresspongendofstreamprofiletotalsextremestablesstatuslogtablecolumnsserverpartuuidsserverreadtaskrequestserverprofileeventsservermergetreeallrangesannouncementservermergetreereadtaskrequestservertimezoneupdate"
 
 func (i ServerCode) String() string {
 	if i >= ServerCode(len(_ServerCodeIndex)-1) {
@@ -39,9 +39,12 @@ func _ServerCodeNoOp() {
 	_ = x[ServerPartUUIDs-(12)]
 	_ = x[ServerReadTaskRequest-(13)]
 	_ = x[ServerProfileEvents-(14)]
+	_ = x[ServerMergeTreeAllRangesAnnouncement-(15)]
+	_ = x[ServerMergeTreeReadTaskRequest-(16)]
+	_ = x[ServerTimezoneUpdate-(17)]
 }
 
-var _ServerCodeValues = []ServerCode{ServerCodeHello, ServerCodeData, ServerCodeException, ServerCodeProgress, ServerCodePong, ServerCodeEndOfStream, ServerCodeProfile, ServerCodeTotals, ServerCodeExtremes, ServerCodeTablesStatus, ServerCodeLog, ServerCodeTableColumns, ServerPartUUIDs, ServerReadTaskRequest, ServerProfileEvents}
+var _ServerCodeValues = []ServerCode{ServerCodeHello, ServerCodeData, ServerCodeException, ServerCodeProgress, ServerCodePong, ServerCodeEndOfStream, ServerCodeProfile, ServerCodeTotals, ServerCodeExtremes, ServerCodeTablesStatus, ServerCodeLog, ServerCodeTableColumns, ServerPartUUIDs, ServerReadTaskRequest, ServerProfileEvents, ServerMergeTreeAllRangesAnnouncement, ServerMergeTreeReadTaskRequest, ServerTimezoneUpdate}
 
 var _ServerCodeNameToValueMap = map[string]ServerCode{
 	_ServerCodeName[0:5]:          ServerCodeHello,
@@ -74,6 +77,12 @@ var _ServerCodeNameToValueMap = map[string]ServerCode{
 	_ServerCodeLowerName[104:125]: ServerReadTaskRequest,
 	_ServerCodeName[125:144]:      ServerProfileEvents,
 	_ServerCodeLowerName[125:144]: ServerProfileEvents,
+	_ServerCodeName[144:180]:      ServerMergeTreeAllRangesAnnouncement,
+	_ServerCodeLowerName[144:180]: ServerMergeTreeAllRangesAnnouncement,
+	_ServerCodeName[180:210]:      ServerMergeTreeReadTaskRequest,
+	_ServerCodeLowerName[180:210]: ServerMergeTreeReadTaskRequest,
+	_ServerCodeName[210:230]:      ServerTimezoneUpdate,
+	_ServerCodeLowerName[210:230]: ServerTimezoneUpdate,
 }
 
 var _ServerCodeNames = []string{
@@ -92,6 +101,8 @@ var _ServerCodeNames = []string{
 	_ServerCodeName[89:104],
 	_ServerCodeName[104:125],
 	_ServerCodeName[125:144],
+	_ServerCodeName[144:180],
+	_ServerCodeName[180:210],
 }
 
 // ServerCodeString retrieves an enum value from the enum constants string name.
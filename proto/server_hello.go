@@ -3,6 +3,7 @@ package proto
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-faster/errors"
 )
@@ -16,6 +17,24 @@ type ServerHello struct {
 	Timezone    string
 	DisplayName string
 	Patch       int
+
+	// ParallelReplicasVersion is version of parallel replicas protocol
+	// of server.
+	ParallelReplicasVersion uint64
+
+	// Chunked framing capabilities of server for sending and receiving.
+	ChunkedSend ChunkedMode
+	ChunkedRecv ChunkedMode
+	// PasswordRules are password complexity rules of server.
+	PasswordRules []PasswordComplexityRule
+	// Nonce for inter-server secret.
+	Nonce uint64
+}
+
+// PasswordComplexityRule is server rule for new passwords.
+type PasswordComplexityRule struct {
+	Pattern string
+	Message string
 }
 
 // Features implemented by server.
@@ -34,6 +53,30 @@ func (s ServerHello) Has(f Feature) bool {
 	return f.In(s.Revision)
 }
 
+// Version returns server version triple, like "24.3.1".
+//
+// Patch is omitted if not supported by server revision.
+func (s ServerHello) Version() string {
+	if s.Has(FeatureVersionPatch) {
+		return fmt.Sprintf("%d.%d.%d", s.Major, s.Minor, s.Patch)
+	}
+	return fmt.Sprintf("%d.%d", s.Major, s.Minor)
+}
+
+// Location loads server time zone.
+//
+// Returns time.UTC if time zone is not reported by server.
+func (s ServerHello) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load %q", s.Timezone)
+	}
+	return loc, nil
+}
+
 func (s ServerHello) String() string {
 	var b strings.Builder
 	b.WriteString(s.Name)
@@ -76,6 +119,17 @@ func (s *ServerHello) DecodeAware(r *Reader, v int) error {
 	}
 
 	s.Major, s.Minor, s.Revision = major, minor, revision
+	if revision < v {
+		// Server does not send fields of newer revisions.
+		v = revision
+	}
+	if FeatureVersionedParallelReplicas.In(v) {
+		version, err := r.UVarInt()
+		if err != nil {
+			return errors.Wrap(err, "parallel replicas version")
+		}
+		s.ParallelReplicasVersion = version
+	}
 
 	if FeatureTimezone.In(v) {
 		v, err := r.Str()
@@ -98,6 +152,41 @@ func (s *ServerHello) DecodeAware(r *Reader, v int) error {
 		}
 		s.Patch = path
 	}
+	if FeatureChunkedPackets.In(v) {
+		send, err := r.Str()
+		if err != nil {
+			return errors.Wrap(err, "chunked send")
+		}
+		recv, err := r.Str()
+		if err != nil {
+			return errors.Wrap(err, "chunked recv")
+		}
+		s.ChunkedSend, s.ChunkedRecv = ChunkedMode(send), ChunkedMode(recv)
+	}
+	if FeaturePasswordComplexityRules.In(v) {
+		n, err := r.Int()
+		if err != nil {
+			return errors.Wrap(err, "password rules")
+		}
+		s.PasswordRules = s.PasswordRules[:0]
+		for i := 0; i < n; i++ {
+			var rule PasswordComplexityRule
+			if rule.Pattern, err = r.Str(); err != nil {
+				return errors.Wrapf(err, "password rule [%d] pattern", i)
+			}
+			if rule.Message, err = r.Str(); err != nil {
+				return errors.Wrapf(err, "password rule [%d] message", i)
+			}
+			s.PasswordRules = append(s.PasswordRules, rule)
+		}
+	}
+	if FeatureInterServerSecretV2.In(v) {
+		nonce, err := r.UInt64()
+		if err != nil {
+			return errors.Wrap(err, "nonce")
+		}
+		s.Nonce = nonce
+	}
 
 	return nil
 }
@@ -108,6 +197,12 @@ func (s *ServerHello) EncodeAware(b *Buffer, v int) {
 	b.PutInt(s.Major)
 	b.PutInt(s.Minor)
 	b.PutInt(s.Revision)
+	if s.Revision < v {
+		v = s.Revision
+	}
+	if FeatureVersionedParallelReplicas.In(v) {
+		b.PutUVarInt(s.ParallelReplicasVersion)
+	}
 	if FeatureTimezone.In(v) {
 		b.PutString(s.Timezone)
 	}
@@ -117,4 +212,18 @@ func (s *ServerHello) EncodeAware(b *Buffer, v int) {
 	if FeatureVersionPatch.In(v) {
 		b.PutInt(s.Patch)
 	}
+	if FeatureChunkedPackets.In(v) {
+		b.PutString(string(s.ChunkedSend))
+		b.PutString(string(s.ChunkedRecv))
+	}
+	if FeaturePasswordComplexityRules.In(v) {
+		b.PutInt(len(s.PasswordRules))
+		for _, rule := range s.PasswordRules {
+			b.PutString(rule.Pattern)
+			b.PutString(rule.Message)
+		}
+	}
+	if FeatureInterServerSecretV2.In(v) {
+		b.PutUInt64(s.Nonce)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -141,3 +142,63 @@ func BenchmarkServerHello_Decode(b *testing.B) {
 		}
 	})
 }
+
+func TestServerHello_chunked(t *testing.T) {
+	v := ServerHello{
+		Name:        "ClickHouse server",
+		Major:       24,
+		Minor:       10,
+		Patch:       1,
+		Revision:    Version,
+		Timezone:    "UTC",
+		DisplayName: "alpha",
+
+		ParallelReplicasVersion: ParallelReplicasVersion,
+		ChunkedSend:             NotChunkedOptional,
+		ChunkedRecv:             Chunked,
+		PasswordRules: []PasswordComplexityRule{
+			{Pattern: ".{12}", Message: "be at least 12 characters long"},
+		},
+		Nonce: 1234567890,
+	}
+	var b Buffer
+	v.EncodeAware(&b, Version)
+
+	var dec ServerHello
+	buf := skipCode(t, b.Buf, int(ServerCodeHello))
+	requireDecode(t, buf, aware(&dec))
+	require.Equal(t, v, dec)
+	requireNoShortRead(t, buf, aware(&dec))
+
+	// Older server does not send new fields.
+	v.Revision = int(FeatureChunkedPackets) - 1
+	b.Reset()
+	v.EncodeAware(&b, Version)
+	buf = skipCode(t, b.Buf, int(ServerCodeHello))
+	dec = ServerHello{}
+	requireDecode(t, buf, aware(&dec))
+	require.Empty(t, dec.ChunkedSend)
+	require.Zero(t, dec.ParallelReplicasVersion)
+	require.Equal(t, v.PasswordRules, dec.PasswordRules)
+	require.Equal(t, v.Nonce, dec.Nonce)
+}
+
+func TestServerHello_Version(t *testing.T) {
+	v := ServerHello{Major: 24, Minor: 3, Patch: 1, Revision: int(FeatureVersionPatch)}
+	require.Equal(t, "24.3.1", v.Version())
+	v.Revision = int(FeatureVersionPatch) - 1
+	require.Equal(t, "24.3", v.Version())
+}
+
+func TestServerHello_Location(t *testing.T) {
+	loc, err := ServerHello{}.Location()
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, loc)
+
+	loc, err = ServerHello{Timezone: "Europe/Moscow"}.Location()
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Moscow", loc.String())
+
+	_, err = ServerHello{Timezone: "Bad/Zone"}.Location()
+	require.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package textformat
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Decoder decodes TSV or CSV rows into columns, parsing values as
+// column types, e.g. "1" is parsed as 1 for Int32 and as '1' for String.
+type Decoder struct {
+	format  Format
+	tsv     *bufio.Reader
+	csv     *csv.Reader
+	columns []proto.ColInfo
+	nodes   []node
+	cols    []proto.Column
+	order   []int // column index of field
+	input   proto.Input
+	native  proto.Buffer
+	line    int
+}
+
+// NewDecoder returns new Decoder of data in format f with provided
+// columns.
+//
+// If format is WithNames one, header is read and fields are matched to
+// columns by name, so order of fields in data can differ from columns.
+func NewDecoder(r io.Reader, f Format, columns []proto.ColInfo) (*Decoder, error) {
+	if f == JSONEachRow {
+		return nil, errors.Errorf("decoding of %s is not supported", f)
+	}
+	d := &Decoder{format: f}
+	if f.isCSV() {
+		d.csv = csv.NewReader(r)
+		d.csv.FieldsPerRecord = len(columns)
+		d.csv.ReuseRecord = true
+	} else {
+		d.tsv = bufio.NewReader(r)
+	}
+	for i, c := range columns {
+		t, err := c.Type.Parse()
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		t = nativeType(t)
+		n, err := newNode(t, time.UTC)
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		col, err := proto.NewColumn(t.Type())
+		if err != nil {
+			return nil, errors.Wrap(err, c.Name)
+		}
+		d.columns = append(d.columns, c)
+		d.nodes = append(d.nodes, n)
+		d.cols = append(d.cols, col)
+		d.order = append(d.order, i)
+		d.input = append(d.input, proto.InputColumn{Name: c.Name, Data: col})
+	}
+	if f.withNames() {
+		if err := d.readHeader(); err != nil {
+			return nil, errors.Wrap(err, "header")
+		}
+	}
+	return d, nil
+}
+
+func (d *Decoder) readHeader() error {
+	names, err := d.record()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if len(names) != len(d.columns) {
+		return errors.Errorf("%d names, expected %d", len(names), len(d.columns))
+	}
+	seen := make(map[int]bool, len(names))
+	for i, name := range names {
+		if !d.format.isCSV() {
+			name = unescape(name)
+		}
+		idx := -1
+		for j, c := range d.columns {
+			if c.Name == name {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 || seen[idx] {
+			return errors.Errorf("unexpected column %q", name)
+		}
+		seen[idx] = true
+		d.order[i] = idx
+	}
+	return nil
+}
+
+// record reads fields of next row.
+func (d *Decoder) record() ([]string, error) {
+	d.line++
+	if d.csv != nil {
+		return d.csv.Read()
+	}
+	line, err := d.tsv.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return nil, io.EOF
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	fields := strings.Split(line, "\t")
+	if len(fields) != len(d.columns) {
+		return nil, errors.Errorf("line %d: %d fields, expected %d", d.line, len(fields), len(d.columns))
+	}
+	return fields, nil
+}
+
+// Columns returns names and types of columns.
+func (d *Decoder) Columns() []proto.ColInfo {
+	return d.columns
+}
+
+// Decode decodes up to maxRows rows (or all rows if maxRows is zero)
+// and returns them as columns, returning io.EOF if there are no rows left.
+//
+// LowCardinality(T) columns are decoded as T. Returned input is valid
+// until next call to Decode.
+func (d *Decoder) Decode(maxRows int) (proto.Input, error) {
+	for _, n := range d.nodes {
+		n.reset()
+	}
+	var rows int
+	for maxRows <= 0 || rows < maxRows {
+		fields, err := d.record()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "row %d", rows)
+		}
+		for i, v := range fields {
+			idx := d.order[i]
+			if err := d.parseField(d.nodes[idx], v); err != nil {
+				return nil, errors.Wrapf(err, "row %d: %s", rows, d.columns[idx].Name)
+			}
+		}
+		rows++
+	}
+	if rows == 0 {
+		return nil, io.EOF
+	}
+	for i, n := range d.nodes {
+		d.native.Reset()
+		n.nativeState(&d.native)
+		n.native(&d.native)
+		col := d.cols[i]
+		col.Reset()
+		r := d.native.Reader()
+		if s, ok := col.(proto.StateDecoder); ok {
+			if err := s.DecodeState(r); err != nil {
+				return nil, errors.Wrapf(err, "%s: state", d.columns[i].Name)
+			}
+		}
+		if err := col.DecodeColumn(r, rows); err != nil {
+			return nil, errors.Wrap(err, d.columns[i].Name)
+		}
+	}
+	return d.input, nil
+}
+
+// parseField parses top-level field value.
+func (d *Decoder) parseField(n node, v string) error {
+	if v == `\N` {
+		if _, ok := n.(*nullableNode); !ok {
+			return errors.New("unexpected NULL")
+		}
+		n.appendDefault()
+		return nil
+	}
+	if !d.format.isCSV() && n.kind() != kindCompound {
+		// Nested values are unescaped by scanner.
+		v = unescape(v)
+	}
+	return parseText(n, v)
+}
@@ -0,0 +1,237 @@
+package textformat
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// escapes are characters escaped with backslash in TSV and in quoted
+// values.
+var escapes = [256]byte{
+	'\b': 'b',
+	'\f': 'f',
+	'\n': 'n',
+	'\r': 'r',
+	'\t': 't',
+	0:    '0',
+	'\\': '\\',
+}
+
+// appendEscaped appends v to b escaping special characters, as well as q
+// if it is not zero.
+func appendEscaped(b []byte, v []byte, q byte) []byte {
+	for _, ch := range v {
+		switch {
+		case escapes[ch] != 0:
+			b = append(b, '\\', escapes[ch])
+		case q != 0 && ch == q:
+			b = append(b, '\\', ch)
+		default:
+			b = append(b, ch)
+		}
+	}
+	return b
+}
+
+// quote replaces b[start:] with its quoted representation, e.g. 'a\'b'.
+func quote(b []byte, start int) []byte {
+	v := append([]byte(nil), b[start:]...)
+	b = append(b[:start], '\'')
+	b = appendEscaped(b, v, '\'')
+	return append(b, '\'')
+}
+
+// quoteCSV replaces b[start:] with its CSV representation, e.g. "a""b".
+func quoteCSV(b []byte, start int) []byte {
+	v := append([]byte(nil), b[start:]...)
+	b = append(b[:start], '"')
+	for _, ch := range v {
+		if ch == '"' {
+			b = append(b, '"')
+		}
+		b = append(b, ch)
+	}
+	return append(b, '"')
+}
+
+// unescape reverts appendEscaped.
+func unescape(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch != '\\' || i+1 == len(s) {
+			b.WriteByte(ch)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '0':
+			b.WriteByte(0)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// scanner reads nested values, e.g. elements of [1, 'a\'b', NULL].
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (s *scanner) skipSpace() {
+	for s.pos < len(s.s) && (s.s[s.pos] == ' ' || s.s[s.pos] == '\t' || s.s[s.pos] == '\n') {
+		s.pos++
+	}
+}
+
+// peek returns next non-space character or 0 on end of input.
+func (s *scanner) peek() byte {
+	s.skipSpace()
+	if s.pos >= len(s.s) {
+		return 0
+	}
+	return s.s[s.pos]
+}
+
+func (s *scanner) expect(ch byte) error {
+	if s.peek() != ch {
+		return errors.Errorf("expected %q at %d", ch, s.pos)
+	}
+	s.pos++
+	return nil
+}
+
+// end returns error if there is unread input.
+func (s *scanner) end() error {
+	if s.peek() != 0 {
+		return errors.Errorf("unexpected %q at %d", s.s[s.pos:], s.pos)
+	}
+	return nil
+}
+
+// list reads values enclosed in open and close, calling f for each.
+func (s *scanner) list(open, close byte, f func() error) error {
+	if err := s.expect(open); err != nil {
+		return err
+	}
+	if s.peek() == close {
+		s.pos++
+		return nil
+	}
+	for {
+		if err := f(); err != nil {
+			return err
+		}
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case close:
+			s.pos++
+			return nil
+		default:
+			return errors.Errorf("expected ',' or %q at %d", close, s.pos)
+		}
+	}
+}
+
+// null reads NULL if it is next value.
+func (s *scanner) null() bool {
+	s.skipSpace()
+	const null = "NULL"
+	if !strings.HasPrefix(s.s[s.pos:], null) {
+		return false
+	}
+	end := s.pos + len(null)
+	if end < len(s.s) && !isDelimiter(s.s[end]) {
+		return false
+	}
+	s.pos = end
+	return true
+}
+
+func isDelimiter(ch byte) bool {
+	switch ch {
+	case ',', ']', '}', ')', ':', ' ', '\t', '\n':
+		return true
+	default:
+		return false
+	}
+}
+
+// value reads quoted or bare scalar value.
+func (s *scanner) value() (string, error) {
+	switch s.peek() {
+	case 0:
+		return "", errors.New("unexpected end")
+	case '\'':
+		start := s.pos
+		s.pos++
+		for s.pos < len(s.s) {
+			switch s.s[s.pos] {
+			case '\\':
+				s.pos += 2
+			case '\'':
+				s.pos++
+				return unescape(s.s[start+1 : s.pos-1]), nil
+			default:
+				s.pos++
+			}
+		}
+		return "", errors.Errorf("unterminated quote at %d", start)
+	default:
+		start := s.pos
+		for s.pos < len(s.s) && !isDelimiter(s.s[s.pos]) {
+			s.pos++
+		}
+		if s.pos == start {
+			return "", errors.Errorf("expected value at %d", start)
+		}
+		return s.s[start:s.pos], nil
+	}
+}
+
+// appendJSONString appends v as JSON string to b. Invalid UTF-8 is
+// written as is, like ClickHouse does.
+func appendJSONString[S string | []byte](b []byte, v S) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for i := 0; i < len(v); i++ {
+		switch ch := v[i]; ch {
+		case '"', '\\':
+			b = append(b, '\\', ch)
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\f':
+			b = append(b, '\\', 'f')
+		default:
+			if ch < 0x20 {
+				b = append(b, '\\', 'u', '0', '0', hex[ch>>4], hex[ch&0xf])
+				continue
+			}
+			b = append(b, ch)
+		}
+	}
+	return append(b, '"')
+}
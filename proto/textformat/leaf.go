@@ -0,0 +1,595 @@
+package textformat
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// leafNode is scalar value, e.g. Int32, String or DateTime.
+type leafNode struct {
+	// size of value in Native layout, zero for String.
+	size int
+	// numeric values are not quoted.
+	numeric bool
+	// quoteJSON is set for 64-bit and larger integers, which are quoted in
+	// JSON as they can't be represented by float64.
+	quoteJSON bool
+	// format appends text of Native value v to b.
+	format func(b, v []byte) []byte
+	// parse appends Native value of text s to b.
+	parse func(b []byte, s string) ([]byte, error)
+	// def is default value, zeroes if nil.
+	def []byte
+
+	data []byte
+	pos  [][2]int // of String values, if size is zero
+}
+
+func (n *leafNode) kind() kind {
+	if n.numeric {
+		return kindNumeric
+	}
+	return kindString
+}
+
+func (n *leafNode) value(i int) []byte {
+	if n.size == 0 {
+		p := n.pos[i]
+		return n.data[p[0]:p[1]]
+	}
+	return n.data[i*n.size : (i+1)*n.size]
+}
+
+func (n *leafNode) parseState(data []byte) ([]byte, error) { return data, nil }
+
+func (n *leafNode) parseData(data []byte, rows int) ([]byte, error) {
+	if n.size > 0 {
+		size := rows * n.size
+		if len(data) < size {
+			return nil, errors.Errorf("not enough data: %d < %d", len(data), size)
+		}
+		n.data = data[:size]
+		return data[size:], nil
+	}
+	n.pos = n.pos[:0]
+	var offset int
+	for i := 0; i < rows; i++ {
+		l, size := binary.Uvarint(data[offset:])
+		if size <= 0 {
+			return nil, errors.Errorf("row %d: invalid length", i)
+		}
+		start := offset + size
+		offset = start + int(l)
+		if offset > len(data) || offset < start {
+			return nil, errors.Errorf("row %d: not enough data", i)
+		}
+		n.pos = append(n.pos, [2]int{start, offset})
+	}
+	n.data = data[:offset]
+	return data[offset:], nil
+}
+
+func (n *leafNode) isNull(int) bool { return false }
+
+func (n *leafNode) text(b []byte, i int, nested bool) []byte {
+	if !nested || n.numeric {
+		return n.format(b, n.value(i))
+	}
+	start := len(b)
+	b = n.format(b, n.value(i))
+	return quote(b, start)
+}
+
+func (n *leafNode) json(b []byte, i int) []byte {
+	if !n.numeric {
+		return appendJSONString(b, n.format(nil, n.value(i)))
+	}
+	start := len(b)
+	b = n.format(b, n.value(i))
+	switch string(b[start:]) {
+	case "inf", "-inf", "nan":
+		return append(b[:start], "null"...)
+	}
+	if n.quoteJSON {
+		v := append([]byte(nil), b[start:]...)
+		return appendJSONString(b[:start], v)
+	}
+	return b
+}
+
+func (n *leafNode) parseText(s string) error {
+	data, err := n.parse(n.data, s)
+	if err != nil {
+		return err
+	}
+	n.data = data
+	return nil
+}
+
+func (n *leafNode) scan(s *scanner) error {
+	v, err := s.value()
+	if err != nil {
+		return err
+	}
+	return n.parseText(v)
+}
+
+func (n *leafNode) appendDefault() {
+	switch {
+	case n.def != nil:
+		n.data = append(n.data, n.def...)
+	case n.size == 0:
+		n.data = append(n.data, 0)
+	default:
+		n.data = append(n.data, make([]byte, n.size)...)
+	}
+}
+
+func (n *leafNode) native(b *proto.Buffer) { b.PutRaw(n.data) }
+
+func (n *leafNode) nativeState(*proto.Buffer) {}
+
+func (n *leafNode) reset() {
+	n.data = n.data[:0]
+	n.pos = n.pos[:0]
+}
+
+// newLeaf returns leafNode for scalar type t, or nil if t is not scalar.
+func newLeaf(t *proto.ColumnTypeNode, loc *time.Location) (*leafNode, error) {
+	switch t.Base {
+	case proto.ColumnTypeInt8, proto.ColumnTypeInt16, proto.ColumnTypeInt32, proto.ColumnTypeInt64:
+		size, _ := t.Type().FixedWidth()
+		return &leafNode{size: size, numeric: true, quoteJSON: size == 8, format: formatInt, parse: parseInt(size)}, nil
+	case proto.ColumnTypeUInt8, proto.ColumnTypeUInt16, proto.ColumnTypeUInt32, proto.ColumnTypeUInt64:
+		size, _ := t.Type().FixedWidth()
+		return &leafNode{size: size, numeric: true, quoteJSON: size == 8, format: formatUint, parse: parseUint(size)}, nil
+	case proto.ColumnTypeInt128, proto.ColumnTypeInt256, proto.ColumnTypeUInt128, proto.ColumnTypeUInt256:
+		size, _ := t.Type().FixedWidth()
+		signed := t.Base == proto.ColumnTypeInt128 || t.Base == proto.ColumnTypeInt256
+		return &leafNode{size: size, numeric: true, quoteJSON: true, format: formatBig(signed, 0), parse: parseBig(size, signed, 0)}, nil
+	case proto.ColumnTypeFloat32:
+		return &leafNode{size: 4, numeric: true, format: formatFloat32, parse: parseFloat32}, nil
+	case proto.ColumnTypeFloat64:
+		return &leafNode{size: 8, numeric: true, format: formatFloat64, parse: parseFloat64}, nil
+	case proto.ColumnTypeDecimal, proto.ColumnTypeDecimal32, proto.ColumnTypeDecimal64,
+		proto.ColumnTypeDecimal128, proto.ColumnTypeDecimal256:
+		size, ok := t.Type().FixedWidth()
+		if !ok {
+			return nil, errors.Errorf("%s: invalid precision", t)
+		}
+		// Scale is last parameter, e.g. Decimal(P, S) or Decimal32(S).
+		p := t.Elem(len(t.Params) - 1)
+		if p == nil {
+			return nil, errors.Errorf("%s: no scale", t)
+		}
+		scale, err := strconv.Atoi(p.Value)
+		if err != nil || scale < 0 {
+			return nil, errors.Errorf("%s: invalid scale", t)
+		}
+		return &leafNode{size: size, numeric: true, format: formatBig(true, scale), parse: parseBig(size, true, scale)}, nil
+	case proto.ColumnTypeBool:
+		return &leafNode{size: 1, numeric: true, format: formatBool, parse: parseBool}, nil
+	case proto.ColumnTypeString:
+		return &leafNode{format: formatStr, parse: parseStr}, nil
+	case proto.ColumnTypeFixedString:
+		size, ok := t.Type().FixedWidth()
+		if !ok {
+			return nil, errors.Errorf("%s: invalid size", t)
+		}
+		return &leafNode{size: size, format: formatStr, parse: parseFixedStr(size)}, nil
+	case proto.ColumnTypeDate:
+		return &leafNode{size: 2, format: formatDate, parse: parseDate(2)}, nil
+	case proto.ColumnTypeDate32:
+		return &leafNode{size: 4, format: formatDate, parse: parseDate(4)}, nil
+	case proto.ColumnTypeDateTime:
+		if p := t.Elem(0); p != nil {
+			v, err := time.LoadLocation(p.Value)
+			if err != nil {
+				return nil, errors.Wrap(err, "location")
+			}
+			loc = v
+		}
+		return &leafNode{size: 4, format: formatDateTime(loc, 0), parse: parseDateTime(loc, 0)}, nil
+	case proto.ColumnTypeDateTime64:
+		p := t.Elem(0)
+		if p == nil {
+			return nil, errors.Errorf("%s: no precision", t)
+		}
+		precision, err := strconv.Atoi(p.Value)
+		if err != nil || precision < 0 || precision > 9 {
+			return nil, errors.Errorf("%s: invalid precision", t)
+		}
+		if p := t.Elem(1); p != nil {
+			v, err := time.LoadLocation(p.Value)
+			if err != nil {
+				return nil, errors.Wrap(err, "location")
+			}
+			loc = v
+		}
+		return &leafNode{size: 8, format: formatDateTime(loc, precision), parse: parseDateTime(loc, precision)}, nil
+	case proto.ColumnTypeUUID:
+		return &leafNode{size: 16, format: formatUUID, parse: parseUUID}, nil
+	case proto.ColumnTypeIPv4:
+		return &leafNode{size: 4, format: formatIPv4, parse: parseIPv4}, nil
+	case proto.ColumnTypeIPv6:
+		return &leafNode{size: 16, format: formatIPv6, parse: parseIPv6}, nil
+	case proto.ColumnTypeEnum8, proto.ColumnTypeEnum16:
+		return newEnum(t)
+	default:
+		return nil, nil
+	}
+}
+
+// signed returns signed integer value of little-endian v.
+func signed(v []byte) int64 {
+	switch len(v) {
+	case 1:
+		return int64(int8(v[0]))
+	case 2:
+		return int64(int16(binary.LittleEndian.Uint16(v)))
+	case 4:
+		return int64(int32(binary.LittleEndian.Uint32(v)))
+	default:
+		return int64(binary.LittleEndian.Uint64(v))
+	}
+}
+
+// unsigned returns unsigned integer value of little-endian v.
+func unsigned(v []byte) uint64 {
+	switch len(v) {
+	case 1:
+		return uint64(v[0])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(v))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(v))
+	default:
+		return binary.LittleEndian.Uint64(v)
+	}
+}
+
+// appendUnsigned appends size bytes of little-endian v to b.
+func appendUnsigned(b []byte, size int, v uint64) []byte {
+	for i := 0; i < size; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+func formatInt(b, v []byte) []byte  { return strconv.AppendInt(b, signed(v), 10) }
+func formatUint(b, v []byte) []byte { return strconv.AppendUint(b, unsigned(v), 10) }
+
+func parseInt(size int) func(b []byte, s string) ([]byte, error) {
+	return func(b []byte, s string) ([]byte, error) {
+		v, err := strconv.ParseInt(s, 10, size*8)
+		if err != nil {
+			return nil, err
+		}
+		return appendUnsigned(b, size, uint64(v)), nil
+	}
+}
+
+func parseUint(size int) func(b []byte, s string) ([]byte, error) {
+	return func(b []byte, s string) ([]byte, error) {
+		v, err := strconv.ParseUint(s, 10, size*8)
+		if err != nil {
+			return nil, err
+		}
+		return appendUnsigned(b, size, v), nil
+	}
+}
+
+// formatBig formats little-endian integer of any size, with scale digits
+// after point for decimals.
+func formatBig(signed bool, scale int) func(b, v []byte) []byte {
+	return func(b, v []byte) []byte {
+		be := make([]byte, len(v))
+		for i := range v {
+			be[len(v)-1-i] = v[i]
+		}
+		x := new(big.Int).SetBytes(be)
+		if signed && len(v) > 0 && v[len(v)-1]&0x80 != 0 {
+			// Two's complement.
+			x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(len(v)*8)))
+		}
+		if scale == 0 {
+			return x.Append(b, 10)
+		}
+		if x.Sign() < 0 {
+			b = append(b, '-')
+			x.Neg(x)
+		}
+		s := x.String()
+		if len(s) <= scale {
+			s = strings.Repeat("0", scale-len(s)+1) + s
+		}
+		b = append(b, s[:len(s)-scale]...)
+		b = append(b, '.')
+		return append(b, s[len(s)-scale:]...)
+	}
+}
+
+func parseBig(size int, signed bool, scale int) func(b []byte, s string) ([]byte, error) {
+	return func(b []byte, s string) ([]byte, error) {
+		digits := s
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			frac := s[i+1:]
+			if len(frac) > scale {
+				return nil, errors.Errorf("too many digits after point: %q", s)
+			}
+			digits = s[:i] + frac + strings.Repeat("0", scale-len(frac))
+		} else {
+			digits += strings.Repeat("0", scale)
+		}
+		x, ok := new(big.Int).SetString(digits, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid number %q", s)
+		}
+		bits := size * 8
+		if signed {
+			bits--
+		}
+		if x.Sign() < 0 && !signed || new(big.Int).Abs(x).BitLen() > bits {
+			return nil, errors.Errorf("%q is out of range", s)
+		}
+		if x.Sign() < 0 {
+			// Two's complement.
+			x.Add(x, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+		}
+		be := x.FillBytes(make([]byte, size))
+		for i := len(be) - 1; i >= 0; i-- {
+			b = append(b, be[i])
+		}
+		return b, nil
+	}
+}
+
+// appendFloat formats float as ClickHouse does, e.g. inf instead of +Inf.
+func appendFloat(b []byte, v float64, bits int) []byte {
+	switch {
+	case math.IsInf(v, 1):
+		return append(b, "inf"...)
+	case math.IsInf(v, -1):
+		return append(b, "-inf"...)
+	case math.IsNaN(v):
+		return append(b, "nan"...)
+	default:
+		return strconv.AppendFloat(b, v, 'g', -1, bits)
+	}
+}
+
+func formatFloat32(b, v []byte) []byte {
+	return appendFloat(b, float64(math.Float32frombits(binary.LittleEndian.Uint32(v))), 32)
+}
+
+func formatFloat64(b, v []byte) []byte {
+	return appendFloat(b, math.Float64frombits(binary.LittleEndian.Uint64(v)), 64)
+}
+
+func parseFloat32(b []byte, s string) ([]byte, error) {
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return nil, err
+	}
+	return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v))), nil
+}
+
+func parseFloat64(b []byte, s string) ([]byte, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(v)), nil
+}
+
+func formatBool(b, v []byte) []byte { return strconv.AppendBool(b, v[0] != 0) }
+
+func parseBool(b []byte, s string) ([]byte, error) {
+	switch s {
+	case "true", "1":
+		return append(b, 1), nil
+	case "false", "0":
+		return append(b, 0), nil
+	default:
+		return nil, errors.Errorf("invalid bool %q", s)
+	}
+}
+
+func formatStr(b, v []byte) []byte { return append(b, v...) }
+
+func parseStr(b []byte, s string) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...), nil
+}
+
+func parseFixedStr(size int) func(b []byte, s string) ([]byte, error) {
+	return func(b []byte, s string) ([]byte, error) {
+		if len(s) > size {
+			return nil, errors.Errorf("%d bytes is too large for FixedString(%d)", len(s), size)
+		}
+		b = append(b, s...)
+		return append(b, make([]byte, size-len(s))...), nil
+	}
+}
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05"
+	secInDay       = 24 * 60 * 60
+)
+
+func formatDate(b, v []byte) []byte {
+	days := signed(v)
+	if len(v) == 2 {
+		days = int64(unsigned(v))
+	}
+	return time.Unix(days*secInDay, 0).UTC().AppendFormat(b, dateLayout)
+}
+
+func parseDate(size int) func(b []byte, s string) ([]byte, error) {
+	return func(b []byte, s string) ([]byte, error) {
+		t, err := time.Parse(dateLayout, s)
+		if err != nil {
+			return nil, err
+		}
+		days := t.Unix() / secInDay
+		if size == 2 && (days < 0 || days > math.MaxUint16) {
+			return nil, errors.Errorf("%q is out of range", s)
+		}
+		return appendUnsigned(b, size, uint64(days)), nil
+	}
+}
+
+var pow10 = [...]int64{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+
+// formatDateTime formats DateTime if precision is zero, or DateTime64.
+func formatDateTime(loc *time.Location, precision int) func(b, v []byte) []byte {
+	return func(b, v []byte) []byte {
+		if len(v) == 4 {
+			return time.Unix(int64(unsigned(v)), 0).In(loc).AppendFormat(b, dateTimeLayout)
+		}
+		ticks := signed(v)
+		sec, frac := ticks/pow10[precision], ticks%pow10[precision]
+		if frac < 0 {
+			sec--
+			frac += pow10[precision]
+		}
+		b = time.Unix(sec, 0).In(loc).AppendFormat(b, dateTimeLayout)
+		if precision == 0 {
+			return b
+		}
+		b = append(b, '.')
+		s := strconv.FormatInt(frac, 10)
+		b = append(b, strings.Repeat("0", precision-len(s))...)
+		return append(b, s...)
+	}
+}
+
+func parseDateTime(loc *time.Location, precision int) func(b []byte, s string) ([]byte, error) {
+	size := 8
+	if precision == 0 {
+		size = 4
+	}
+	return func(b []byte, s string) ([]byte, error) {
+		var ticks int64
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			// Unix timestamp.
+			ticks = v * pow10[precision]
+		} else {
+			t, err := time.ParseInLocation(dateTimeLayout, s, loc)
+			if err != nil {
+				return nil, err
+			}
+			ticks = t.Unix()*pow10[precision] + int64(t.Nanosecond())/pow10[9-precision]
+		}
+		if size == 4 && (ticks < 0 || ticks > math.MaxUint32) {
+			return nil, errors.Errorf("%q is out of range", s)
+		}
+		return appendUnsigned(b, size, uint64(ticks)), nil
+	}
+}
+
+// UUID is two little-endian 64-bit halves in Native layout.
+func uuidSwap(dst, src []byte) {
+	for i := 0; i < 8; i++ {
+		dst[i] = src[7-i]
+		dst[8+i] = src[15-i]
+	}
+}
+
+func formatUUID(b, v []byte) []byte {
+	var u uuid.UUID
+	uuidSwap(u[:], v)
+	return append(b, u.String()...)
+}
+
+func parseUUID(b []byte, s string) ([]byte, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	var v [16]byte
+	uuidSwap(v[:], u[:])
+	return append(b, v[:]...), nil
+}
+
+func formatIPv4(b, v []byte) []byte {
+	x := uint32(unsigned(v))
+	return netip.AddrFrom4([4]byte{byte(x >> 24), byte(x >> 16), byte(x >> 8), byte(x)}).AppendTo(b)
+}
+
+func parseIPv4(b []byte, s string) ([]byte, error) {
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	if !ip.Is4() {
+		return nil, errors.Errorf("%q is not IPv4", s)
+	}
+	v := ip.As4()
+	return binary.LittleEndian.AppendUint32(b, binary.BigEndian.Uint32(v[:])), nil
+}
+
+func formatIPv6(b, v []byte) []byte {
+	return netip.AddrFrom16([16]byte(v)).AppendTo(b)
+}
+
+func parseIPv6(b []byte, s string) ([]byte, error) {
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	v := ip.As16()
+	return append(b, v[:]...), nil
+}
+
+func newEnum(t *proto.ColumnTypeNode) (*leafNode, error) {
+	size := 1
+	if t.Base == proto.ColumnTypeEnum16 {
+		size = 2
+	}
+	var (
+		names  = map[int64]string{}
+		values = map[string]int64{}
+		n      = &leafNode{size: size}
+	)
+	for _, p := range t.Params {
+		if p.Kind != proto.ColumnTypeNodeEnum {
+			return nil, errors.Errorf("%s: invalid element %s", t, p)
+		}
+		names[int64(p.EnumValue)] = p.Value
+		values[p.Value] = int64(p.EnumValue)
+	}
+	if len(t.Params) > 0 {
+		// Zero may be not a valid value of enum, so first value is default.
+		n.def = appendUnsigned(nil, size, uint64(t.Params[0].EnumValue))
+	}
+	n.format = func(b, v []byte) []byte {
+		x := signed(v)
+		if name, ok := names[x]; ok {
+			return append(b, name...)
+		}
+		return strconv.AppendInt(b, x, 10)
+	}
+	n.parse = func(b []byte, s string) ([]byte, error) {
+		v, ok := values[s]
+		if !ok {
+			var err error
+			if v, err = strconv.ParseInt(s, 10, size*8); err != nil {
+				return nil, errors.Errorf("unknown element %q", s)
+			}
+		}
+		return appendUnsigned(b, size, uint64(v)), nil
+	}
+	return n, nil
+}
@@ -0,0 +1,590 @@
+package textformat
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// kind of value text.
+type kind byte
+
+const (
+	kindNumeric  kind = iota // not quoted, e.g. 1 or true
+	kindString               // quoted if nested or in CSV, e.g. 'a' or '2006-01-02'
+	kindCompound             // e.g. [1,2] or {'a':1}
+)
+
+// node transcodes values of single type between Native layout and text.
+type node interface {
+	kind() kind
+
+	// parseState splits Native state, returning remaining data.
+	parseState(data []byte) ([]byte, error)
+	// parseData splits Native data of rows values, returning remaining data.
+	parseData(data []byte, rows int) ([]byte, error)
+	// isNull reports whether i-th parsed value is NULL.
+	isNull(i int) bool
+	// text appends text of i-th parsed value to b. Nested values, e.g.
+	// elements of arrays, are quoted.
+	text(b []byte, i int, nested bool) []byte
+	// json appends JSON of i-th parsed value to b.
+	json(b []byte, i int) []byte
+
+	// scan reads nested value and appends it in Native layout.
+	scan(s *scanner) error
+	// appendDefault appends default value, e.g. for NULL of Nullable(T).
+	appendDefault()
+	// native appends accumulated Native data to b.
+	native(b *proto.Buffer)
+	// nativeState appends Native state to b.
+	nativeState(b *proto.Buffer)
+	reset()
+}
+
+// newNode returns node for type. DateTime values without time zone are
+// in loc.
+func newNode(t *proto.ColumnTypeNode, loc *time.Location) (node, error) {
+	if t.Kind != proto.ColumnTypeNodeType {
+		return nil, errors.Errorf("%s is not a type", t)
+	}
+	if t.Name != "" {
+		// Element of named Tuple, name is not part of the type.
+		v := *t
+		v.Name = ""
+		t = &v
+	}
+	elem := func(i int) (node, error) {
+		e := t.Elem(i)
+		if e == nil {
+			return nil, errors.Errorf("%s: no element %d", t, i)
+		}
+		return newNode(e, loc)
+	}
+	switch t.Base {
+	case proto.ColumnTypeLowCardinality:
+		e := t.Elem(0)
+		if e == nil {
+			return nil, errors.Errorf("%s: no element 0", t)
+		}
+		n := &lowCardinalityNode{}
+		if e.Base == proto.ColumnTypeNullable {
+			// Dictionary of LowCardinality(Nullable(T)) is T, NULL is
+			// first key.
+			n.nullable = true
+			e = e.Elem(0)
+			if e == nil {
+				return nil, errors.Errorf("%s: no element", t)
+			}
+		}
+		v, err := newNode(e, loc)
+		if err != nil {
+			return nil, err
+		}
+		n.elem = v
+		return n, nil
+	case proto.ColumnTypeNullable:
+		e, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		return &nullableNode{elem: e}, nil
+	case proto.ColumnTypeArray:
+		e, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		return &arrNode{elem: e}, nil
+	case proto.ColumnTypeMap:
+		k, err := elem(0)
+		if err != nil {
+			return nil, err
+		}
+		v, err := elem(1)
+		if err != nil {
+			return nil, err
+		}
+		return &arrNode{elem: &tupleNode{elems: []node{k, v}, kv: true}, kv: true}, nil
+	case proto.ColumnTypeTuple:
+		n := &tupleNode{}
+		named := len(t.Params) > 0
+		for i, p := range t.Params {
+			e, err := elem(i)
+			if err != nil {
+				return nil, err
+			}
+			n.elems = append(n.elems, e)
+			n.names = append(n.names, p.Name)
+			named = named && p.Name != ""
+		}
+		if !named {
+			n.names = nil
+		}
+		return n, nil
+	case proto.ColumnTypePoint:
+		float64Node := func() node {
+			return &leafNode{size: 8, numeric: true, format: formatFloat64, parse: parseFloat64}
+		}
+		return &tupleNode{elems: []node{float64Node(), float64Node()}}, nil
+	case proto.ColumnTypeRing, proto.ColumnTypePolygon, proto.ColumnTypeMultiPolygon:
+		inner := map[proto.ColumnType]proto.ColumnType{
+			proto.ColumnTypeRing:         proto.ColumnTypePoint,
+			proto.ColumnTypePolygon:      proto.ColumnTypeRing,
+			proto.ColumnTypeMultiPolygon: proto.ColumnTypePolygon,
+		}[t.Base]
+		e, err := newNode(&proto.ColumnTypeNode{Base: inner}, loc)
+		if err != nil {
+			return nil, err
+		}
+		return &arrNode{elem: e}, nil
+	}
+	n, err := newLeaf(t, loc)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, errors.Errorf("type %s is not supported", t)
+	}
+	return n, nil
+}
+
+// nativeType returns type of column that holds values of t decoded from
+// text, i.e. t with LowCardinality(T) replaced by T.
+func nativeType(t *proto.ColumnTypeNode) *proto.ColumnTypeNode {
+	if t.Kind != proto.ColumnTypeNodeType {
+		return t
+	}
+	if t.Base == proto.ColumnTypeLowCardinality && len(t.Params) == 1 {
+		inner := nativeType(t.Params[0])
+		if inner.Name == "" {
+			v := *inner
+			v.Name = t.Name
+			return &v
+		}
+		return inner
+	}
+	v := *t
+	v.Params = nil
+	for _, p := range t.Params {
+		v.Params = append(v.Params, nativeType(p))
+	}
+	if t.Params != nil && v.Params == nil {
+		v.Params = []*proto.ColumnTypeNode{}
+	}
+	return &v
+}
+
+// nullableNode is Nullable(T).
+//
+// Native: null flags of all rows, then values (default ones for nulls).
+type nullableNode struct {
+	nulls []byte
+	elem  node
+}
+
+func (n *nullableNode) kind() kind { return n.elem.kind() }
+
+func (n *nullableNode) parseState(data []byte) ([]byte, error) { return n.elem.parseState(data) }
+
+func (n *nullableNode) parseData(data []byte, rows int) ([]byte, error) {
+	if len(data) < rows {
+		return nil, errors.Errorf("not enough data for nulls: %d < %d", len(data), rows)
+	}
+	n.nulls = data[:rows]
+	return n.elem.parseData(data[rows:], rows)
+}
+
+func (n *nullableNode) isNull(i int) bool { return n.nulls[i] != 0 }
+
+func (n *nullableNode) text(b []byte, i int, nested bool) []byte {
+	if n.isNull(i) {
+		return append(b, "NULL"...)
+	}
+	return n.elem.text(b, i, nested)
+}
+
+func (n *nullableNode) json(b []byte, i int) []byte {
+	if n.isNull(i) {
+		return append(b, "null"...)
+	}
+	return n.elem.json(b, i)
+}
+
+func (n *nullableNode) scan(s *scanner) error {
+	if s.null() {
+		n.appendDefault()
+		return nil
+	}
+	n.nulls = append(n.nulls, 0)
+	return n.elem.scan(s)
+}
+
+// parseText parses top-level value, i.e. not NULL.
+func (n *nullableNode) parseText(s string) error {
+	n.nulls = append(n.nulls, 0)
+	return parseText(n.elem, s)
+}
+
+func (n *nullableNode) appendDefault() {
+	n.nulls = append(n.nulls, 1)
+	n.elem.appendDefault()
+}
+
+func (n *nullableNode) native(b *proto.Buffer) {
+	b.PutRaw(n.nulls)
+	n.elem.native(b)
+}
+
+func (n *nullableNode) nativeState(b *proto.Buffer) { n.elem.nativeState(b) }
+
+func (n *nullableNode) reset() {
+	n.nulls = n.nulls[:0]
+	n.elem.reset()
+}
+
+// arrNode is Array(T) or Map(K, V) as Array(Tuple(K, V)).
+//
+// Native: offsets of all rows, then values.
+type arrNode struct {
+	offsets []uint64
+	elem    node
+	kv      bool // Map, formatted as {k:v}
+}
+
+func (n *arrNode) kind() kind { return kindCompound }
+
+func (n *arrNode) brackets() (open, close byte) {
+	if n.kv {
+		return '{', '}'
+	}
+	return '[', ']'
+}
+
+func (n *arrNode) last() uint64 {
+	if len(n.offsets) == 0 {
+		return 0
+	}
+	return n.offsets[len(n.offsets)-1]
+}
+
+func (n *arrNode) parseState(data []byte) ([]byte, error) { return n.elem.parseState(data) }
+
+func (n *arrNode) parseData(data []byte, rows int) ([]byte, error) {
+	if len(data) < rows*8 {
+		return nil, errors.Errorf("not enough data for offsets: %d < %d", len(data), rows*8)
+	}
+	n.offsets = n.offsets[:0]
+	var prev uint64
+	for i := 0; i < rows; i++ {
+		o := binary.LittleEndian.Uint64(data[i*8:])
+		if o < prev {
+			return nil, errors.Errorf("row %d: invalid offset %d", i, o)
+		}
+		prev = o
+		n.offsets = append(n.offsets, o)
+	}
+	return n.elem.parseData(data[rows*8:], int(n.last()))
+}
+
+func (n *arrNode) isNull(int) bool { return false }
+
+func (n *arrNode) text(b []byte, i int, _ bool) []byte {
+	var start uint64
+	if i > 0 {
+		start = n.offsets[i-1]
+	}
+	open, close := n.brackets()
+	b = append(b, open)
+	for k := start; k < n.offsets[i]; k++ {
+		if k > start {
+			b = append(b, ',')
+		}
+		b = n.elem.text(b, int(k), true)
+	}
+	return append(b, close)
+}
+
+func (n *arrNode) json(b []byte, i int) []byte {
+	var start uint64
+	if i > 0 {
+		start = n.offsets[i-1]
+	}
+	open, close := n.brackets()
+	b = append(b, open)
+	for k := start; k < n.offsets[i]; k++ {
+		if k > start {
+			b = append(b, ',')
+		}
+		b = n.elem.json(b, int(k))
+	}
+	return append(b, close)
+}
+
+func (n *arrNode) scan(s *scanner) error {
+	open, close := n.brackets()
+	l := n.last()
+	if err := s.list(open, close, func() error {
+		l++
+		return n.elem.scan(s)
+	}); err != nil {
+		return err
+	}
+	n.offsets = append(n.offsets, l)
+	return nil
+}
+
+func (n *arrNode) appendDefault() { n.offsets = append(n.offsets, n.last()) }
+
+func (n *arrNode) native(b *proto.Buffer) {
+	for _, o := range n.offsets {
+		b.PutUInt64(o)
+	}
+	n.elem.native(b)
+}
+
+func (n *arrNode) nativeState(b *proto.Buffer) { n.elem.nativeState(b) }
+
+func (n *arrNode) reset() {
+	n.offsets = n.offsets[:0]
+	n.elem.reset()
+}
+
+// tupleNode is Tuple(T1, T2, ...), or key and value of Map(K, V).
+//
+// Native: columns of elements, one after another.
+type tupleNode struct {
+	elems []node
+	names []string // of named Tuple, formatted as JSON object
+	kv    bool     // Map element, formatted as k:v
+}
+
+func (n *tupleNode) kind() kind { return kindCompound }
+
+func (n *tupleNode) parseState(data []byte) ([]byte, error) {
+	for i, e := range n.elems {
+		var err error
+		if data, err = e.parseState(data); err != nil {
+			return nil, errors.Wrapf(err, "element %d", i)
+		}
+	}
+	return data, nil
+}
+
+func (n *tupleNode) parseData(data []byte, rows int) ([]byte, error) {
+	for i, e := range n.elems {
+		var err error
+		if data, err = e.parseData(data, rows); err != nil {
+			return nil, errors.Wrapf(err, "element %d", i)
+		}
+	}
+	return data, nil
+}
+
+func (n *tupleNode) isNull(int) bool { return false }
+
+func (n *tupleNode) text(b []byte, i int, _ bool) []byte {
+	if n.kv {
+		b = n.elems[0].text(b, i, true)
+		b = append(b, ':')
+		return n.elems[1].text(b, i, true)
+	}
+	b = append(b, '(')
+	for k, e := range n.elems {
+		if k > 0 {
+			b = append(b, ',')
+		}
+		b = e.text(b, i, true)
+	}
+	return append(b, ')')
+}
+
+func (n *tupleNode) json(b []byte, i int) []byte {
+	if n.kv {
+		// Keys of JSON object are strings, e.g. {"1":2} for Map(Int8, Int8).
+		start := len(b)
+		b = n.elems[0].json(b, i)
+		if len(b) == start || b[start] != '"' {
+			b = append(b, '"')
+			copy(b[start+1:], b[start:])
+			b[start] = '"'
+			b = append(b, '"')
+		}
+		b = append(b, ':')
+		return n.elems[1].json(b, i)
+	}
+	open, close := byte('['), byte(']')
+	if n.names != nil {
+		open, close = '{', '}'
+	}
+	b = append(b, open)
+	for k, e := range n.elems {
+		if k > 0 {
+			b = append(b, ',')
+		}
+		if n.names != nil {
+			b = appendJSONString(b, n.names[k])
+			b = append(b, ':')
+		}
+		b = e.json(b, i)
+	}
+	return append(b, close)
+}
+
+func (n *tupleNode) scan(s *scanner) error {
+	if n.kv {
+		if err := n.elems[0].scan(s); err != nil {
+			return errors.Wrap(err, "key")
+		}
+		if err := s.expect(':'); err != nil {
+			return err
+		}
+		if err := n.elems[1].scan(s); err != nil {
+			return errors.Wrap(err, "value")
+		}
+		return nil
+	}
+	var i int
+	if err := s.list('(', ')', func() error {
+		if i >= len(n.elems) {
+			return errors.Errorf("too many elements")
+		}
+		i++
+		if err := n.elems[i-1].scan(s); err != nil {
+			return errors.Wrapf(err, "element %d", i-1)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if i != len(n.elems) {
+		return errors.Errorf("%d elements, expected %d", i, len(n.elems))
+	}
+	return nil
+}
+
+func (n *tupleNode) appendDefault() {
+	for _, e := range n.elems {
+		e.appendDefault()
+	}
+}
+
+func (n *tupleNode) native(b *proto.Buffer) {
+	for _, e := range n.elems {
+		e.native(b)
+	}
+}
+
+func (n *tupleNode) nativeState(b *proto.Buffer) {
+	for _, e := range n.elems {
+		e.nativeState(b)
+	}
+}
+
+func (n *tupleNode) reset() {
+	for _, e := range n.elems {
+		e.reset()
+	}
+}
+
+// lowCardinalityNode is LowCardinality(T), only for encoding: decoded
+// values are T, see nativeType.
+//
+// Native state: key serialization version.
+// Native data: meta with keys type, dictionary of T, keys.
+type lowCardinalityNode struct {
+	elem     node
+	nullable bool // first key is NULL
+	keys     []int
+}
+
+func (n *lowCardinalityNode) kind() kind { return n.elem.kind() }
+
+func (n *lowCardinalityNode) parseState(data []byte) ([]byte, error) {
+	if len(data) < 8 {
+		return nil, errors.New("not enough data for version")
+	}
+	return n.elem.parseState(data[8:])
+}
+
+func (n *lowCardinalityNode) parseData(data []byte, rows int) ([]byte, error) {
+	n.keys = n.keys[:0]
+	if rows == 0 {
+		// Column with no rows is empty.
+		return data, nil
+	}
+	if len(data) < 16 {
+		return nil, errors.New("not enough data for meta")
+	}
+	meta := binary.LittleEndian.Uint64(data)
+	dictRows := binary.LittleEndian.Uint64(data[8:])
+	if dictRows > uint64(len(data)) {
+		return nil, errors.Errorf("invalid dictionary size %d", dictRows)
+	}
+	data, err := n.elem.parseData(data[16:], int(dictRows))
+	if err != nil {
+		return nil, errors.Wrap(err, "dictionary")
+	}
+	if len(data) < 8 {
+		return nil, errors.New("not enough data for keys")
+	}
+	if v := binary.LittleEndian.Uint64(data); v != uint64(rows) {
+		return nil, errors.Errorf("%d keys, expected %d", v, rows)
+	}
+	data = data[8:]
+	size := 1 << (meta & 0xff)
+	if size > 8 || len(data) < rows*size {
+		return nil, errors.Errorf("invalid keys of size %d", size)
+	}
+	for i := 0; i < rows; i++ {
+		k := unsigned(data[i*size : (i+1)*size])
+		if k >= dictRows {
+			return nil, errors.Errorf("row %d: key %d out of range", i, k)
+		}
+		n.keys = append(n.keys, int(k))
+	}
+	return data[rows*size:], nil
+}
+
+func (n *lowCardinalityNode) isNull(i int) bool { return n.nullable && n.keys[i] == 0 }
+
+func (n *lowCardinalityNode) text(b []byte, i int, nested bool) []byte {
+	if n.isNull(i) {
+		return append(b, "NULL"...)
+	}
+	return n.elem.text(b, n.keys[i], nested)
+}
+
+func (n *lowCardinalityNode) json(b []byte, i int) []byte {
+	if n.isNull(i) {
+		return append(b, "null"...)
+	}
+	return n.elem.json(b, n.keys[i])
+}
+
+func (n *lowCardinalityNode) scan(*scanner) error {
+	return errors.New("LowCardinality is decoded as T")
+}
+
+func (n *lowCardinalityNode) appendDefault()            {}
+func (n *lowCardinalityNode) native(*proto.Buffer)      {}
+func (n *lowCardinalityNode) nativeState(*proto.Buffer) {}
+func (n *lowCardinalityNode) reset()                    { n.keys = n.keys[:0] }
+
+// parseText parses top-level value, which is not NULL.
+func parseText(n node, s string) error {
+	switch n := n.(type) {
+	case *leafNode:
+		return n.parseText(s)
+	case *nullableNode:
+		return n.parseText(s)
+	default:
+		sc := &scanner{s: s}
+		if err := n.scan(sc); err != nil {
+			return err
+		}
+		return sc.end()
+	}
+}
@@ -0,0 +1,241 @@
+// Package textformat implements TSV and CSV formats, optionally with
+// names header, and JSONEachRow (NDJSON) encoding using proto columns.
+//
+// Values are formatted as ClickHouse does, e.g. strings are escaped in
+// TSV and quoted in CSV, arrays are [1,2], maps are {'a':1}, NULL is \N.
+// In JSONEachRow each row is JSON object with column names as keys,
+// 64-bit and larger integers are quoted, NULL, inf and nan are null.
+// DateTime values without time zone are formatted and parsed in UTC.
+//
+// LowCardinality(T) is decoded as T, like in rowbinary package.
+//
+// See https://clickhouse.com/docs/en/interfaces/formats#tabseparated,
+// https://clickhouse.com/docs/en/interfaces/formats#csv and
+// https://clickhouse.com/docs/en/interfaces/formats#jsoneachrow.
+package textformat
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Format is text format.
+type Format byte
+
+// Supported formats.
+const (
+	TSV Format = iota
+	TSVWithNames
+	CSV
+	CSVWithNames
+	JSONEachRow // only encoding
+)
+
+func (f Format) String() string {
+	switch f {
+	case TSV:
+		return "TSV"
+	case TSVWithNames:
+		return "TSVWithNames"
+	case CSV:
+		return "CSV"
+	case CSVWithNames:
+		return "CSVWithNames"
+	case JSONEachRow:
+		return "JSONEachRow"
+	default:
+		return fmt.Sprintf("Format(%d)", byte(f))
+	}
+}
+
+func (f Format) isCSV() bool     { return f == CSV || f == CSVWithNames }
+func (f Format) withNames() bool { return f == TSVWithNames || f == CSVWithNames }
+
+func (f Format) separator() byte {
+	if f.isCSV() {
+		return ','
+	}
+	return '\t'
+}
+
+func parseType(t proto.ColumnType) (node, error) {
+	n, err := t.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return newNode(n, time.UTC)
+}
+
+// EncodeNames appends header with names of input columns to b, if format
+// is WithNames one.
+func EncodeNames(b *proto.Buffer, f Format, input proto.Input) {
+	if !f.withNames() {
+		return
+	}
+	for i, c := range input {
+		if i > 0 {
+			b.Buf = append(b.Buf, f.separator())
+		}
+		start := len(b.Buf)
+		b.Buf = append(b.Buf, c.Name...)
+		if f.isCSV() {
+			b.Buf = quoteCSV(b.Buf, start)
+		} else {
+			b.Buf = escapeTSV(b.Buf, start)
+		}
+	}
+	b.Buf = append(b.Buf, '\n')
+}
+
+// Encode appends rows of input to b in format f, without header.
+func Encode(b *proto.Buffer, f Format, input proto.Input) error {
+	if len(input) == 0 {
+		return nil
+	}
+	var (
+		nodes = make([]node, len(input))
+		bufs  = make([]proto.Buffer, len(input))
+		rows  = input[0].Data.Rows()
+	)
+	for i, c := range input {
+		n, err := parseType(c.Data.Type())
+		if err != nil {
+			return errors.Wrap(err, c.Name)
+		}
+		if c.Data.Rows() != rows {
+			return errors.Errorf("%s: %d (rows) != %d (expected)", c.Name, c.Data.Rows(), rows)
+		}
+		if p, ok := c.Data.(proto.Preparable); ok {
+			if err := p.Prepare(); err != nil {
+				return errors.Wrapf(err, "%s: prepare", c.Name)
+			}
+		}
+		if s, ok := c.Data.(proto.StateEncoder); ok {
+			s.EncodeState(&bufs[i])
+		}
+		data, err := n.parseState(bufs[i].Buf)
+		if err != nil {
+			return errors.Wrapf(err, "%s: state", c.Name)
+		}
+		if len(data) != 0 {
+			return errors.Errorf("%s: column with state is not supported", c.Name)
+		}
+		bufs[i].Reset()
+		c.Data.EncodeColumn(&bufs[i])
+		rest, err := n.parseData(bufs[i].Buf, rows)
+		if err != nil {
+			return errors.Wrap(err, c.Name)
+		}
+		if len(rest) != 0 {
+			return errors.Errorf("%s: %d unexpected trailing bytes", c.Name, len(rest))
+		}
+		nodes[i] = n
+	}
+	if f == JSONEachRow {
+		encodeJSON(b, input, nodes, rows)
+		return nil
+	}
+	for i := 0; i < rows; i++ {
+		for j, n := range nodes {
+			if j > 0 {
+				b.Buf = append(b.Buf, f.separator())
+			}
+			b.Buf = appendValue(b.Buf, f, n, i)
+		}
+		b.Buf = append(b.Buf, '\n')
+	}
+	return nil
+}
+
+// appendValue appends top-level value of i-th row of n.
+func appendValue(b []byte, f Format, n node, i int) []byte {
+	if n.isNull(i) {
+		return append(b, `\N`...)
+	}
+	start := len(b)
+	b = n.text(b, i, false)
+	switch {
+	case n.kind() == kindNumeric:
+		return b
+	case f.isCSV():
+		return quoteCSV(b, start)
+	case n.kind() == kindString:
+		return escapeTSV(b, start)
+	default:
+		// Nested values are already escaped.
+		return b
+	}
+}
+
+// encodeJSON appends rows as JSON objects, one per line.
+func encodeJSON(b *proto.Buffer, input proto.Input, nodes []node, rows int) {
+	keys := make([][]byte, len(input))
+	for i, c := range input {
+		keys[i] = append(appendJSONString(nil, c.Name), ':')
+	}
+	for i := 0; i < rows; i++ {
+		b.Buf = append(b.Buf, '{')
+		for j, n := range nodes {
+			if j > 0 {
+				b.Buf = append(b.Buf, ',')
+			}
+			b.Buf = append(b.Buf, keys[j]...)
+			b.Buf = n.json(b.Buf, i)
+		}
+		b.Buf = append(b.Buf, '}', '\n')
+	}
+}
+
+// escapeTSV replaces b[start:] with its escaped representation.
+func escapeTSV(b []byte, start int) []byte {
+	v := append([]byte(nil), b[start:]...)
+	return appendEscaped(b[:start], v, '\'')
+}
+
+// Writer writes blocks in text format, writing header before first block
+// if format is WithNames one.
+//
+// Rows are written as soon as block is written, so results can be
+// streamed, e.g. to HTTP response, without buffering all rows.
+type Writer struct {
+	w      io.Writer
+	f      Format
+	buf    proto.Buffer
+	header bool
+}
+
+// NewWriter returns new Writer of format f to w.
+func NewWriter(w io.Writer, f Format) *Writer {
+	return &Writer{w: w, f: f}
+}
+
+// Write writes rows of input.
+func (w *Writer) Write(input proto.Input) error {
+	w.buf.Reset()
+	if !w.header {
+		EncodeNames(&w.buf, w.f, input)
+		w.header = true
+	}
+	if err := Encode(&w.buf, w.f, input); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(w.buf.Buf); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+// WriteResults writes current block of results, e.g. from OnResult of
+// ch.Query.
+func (w *Writer) WriteResults(results proto.Results) error {
+	input, err := results.Input()
+	if err != nil {
+		return errors.Wrap(err, "input")
+	}
+	return w.Write(input)
+}
@@ -0,0 +1,198 @@
+package textformat
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func testInput() proto.Input {
+	var (
+		ints = proto.ColInt32{1, -2}
+		str  = new(proto.ColStr)
+		null = new(proto.ColFloat64).Nullable()
+		arr  = new(proto.ColStr).Array()
+		m    = proto.NewMap[string, string](new(proto.ColStr), new(proto.ColStr))
+		dt   = &proto.ColDateTime{Location: time.UTC}
+		enum = new(proto.ColEnum).WithValues(map[string]int8{"a": 1, "b": 2})
+		dec  = proto.ColDecimal64{12345, -5}
+		id   = new(proto.ColUUID)
+		lc   = new(proto.ColStr).LowCardinality()
+	)
+	str.AppendArr([]string{"a\tb\n'c'", `say "hi"`})
+	null.AppendArr([]proto.Nullable[float64]{proto.NewNullable(1.5), proto.Null[float64]()})
+	arr.AppendArr([][]string{{"x", "y'z"}, {}})
+	m.AppendArr([]map[string]string{{"k": "v"}, {}})
+	dt.AppendArr([]time.Time{time.Unix(0, 0), time.Unix(1700000000, 0)})
+	enum.AppendArr([]string{"a", "b"})
+	id.AppendArr([]uuid.UUID{uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), {}})
+	lc.AppendArr([]string{"foo", "foo"})
+	return proto.Input{
+		{Name: "ints", Data: &ints},
+		{Name: "str", Data: str},
+		{Name: "null", Data: null},
+		{Name: "arr", Data: arr},
+		{Name: "m", Data: m},
+		{Name: "dt", Data: dt},
+		{Name: "enum", Data: enum},
+		{Name: "dec", Data: proto.Alias(&dec, "Decimal(18, 2)")},
+		{Name: "id", Data: id},
+		{Name: "lc", Data: lc},
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range []struct {
+		Format Format
+		Output string
+	}{
+		{
+			Format: TSVWithNames,
+			Output: "ints\tstr\tnull\tarr\tm\tdt\tenum\tdec\tid\tlc\n" +
+				"1\ta\\tb\\n\\'c\\'\t1.5\t['x','y\\'z']\t{'k':'v'}\t1970-01-01 00:00:00\ta\t123.45\tf47ac10b-58cc-4372-a567-0e02b2c3d479\tfoo\n" +
+				"-2\tsay \"hi\"\t\\N\t[]\t{}\t2023-11-14 22:13:20\tb\t-0.05\t00000000-0000-0000-0000-000000000000\tfoo\n",
+		},
+		{
+			Format: CSVWithNames,
+			Output: `"ints","str","null","arr","m","dt","enum","dec","id","lc"` + "\n" +
+				"1,\"a\tb\n'c'\",1.5,\"['x','y\\'z']\",\"{'k':'v'}\",\"1970-01-01 00:00:00\",\"a\",123.45,\"f47ac10b-58cc-4372-a567-0e02b2c3d479\",\"foo\"\n" +
+				`-2,"say ""hi""",\N,"[]","{}","2023-11-14 22:13:20","b",-0.05,"00000000-0000-0000-0000-000000000000","foo"` + "\n",
+		},
+	} {
+		t.Run(tt.Format.String(), func(t *testing.T) {
+			var b proto.Buffer
+			input := testInput()
+			EncodeNames(&b, tt.Format, input)
+			require.NoError(t, Encode(&b, tt.Format, input))
+			require.Equal(t, tt.Output, string(b.Buf))
+		})
+	}
+	t.Run("Rows", func(t *testing.T) {
+		var b proto.Buffer
+		require.Error(t, Encode(&b, TSV, proto.Input{
+			{Name: "a", Data: proto.ColUInt8{1}},
+			{Name: "b", Data: proto.ColUInt8{1, 2}},
+		}))
+	})
+}
+
+func TestEncodeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, JSONEachRow)
+	require.NoError(t, w.Write(testInput()))
+	require.Equal(t, `{"ints":1,"str":"a\tb\n'c'","null":1.5,"arr":["x","y'z"],"m":{"k":"v"},"dt":"1970-01-01 00:00:00","enum":"a","dec":123.45,"id":"f47ac10b-58cc-4372-a567-0e02b2c3d479","lc":"foo"}`+"\n"+
+		`{"ints":-2,"str":"say \"hi\"","null":null,"arr":[],"m":{},"dt":"2023-11-14 22:13:20","enum":"b","dec":-0.05,"id":"00000000-0000-0000-0000-000000000000","lc":"foo"}`+"\n",
+		buf.String())
+
+	var (
+		i64   = proto.ColInt64{1 << 60}
+		f64   = proto.ColFloat64{math.Inf(1)}
+		m     = proto.NewMap[string, string](new(proto.ColStr), new(proto.ColStr))
+		tuple = proto.ColTuple{&proto.ColUInt8{1}, &proto.ColUInt8{2}}
+		named = proto.ColTuple{proto.Named[uint8](&proto.ColUInt8{3}, "a")}
+		keys  = proto.NewMap[uint8, string](new(proto.ColUInt8), new(proto.ColStr))
+	)
+	m.Append(map[string]string{"\x01": ""})
+	keys.Append(map[uint8]string{1: "b"})
+	buf.Reset()
+	require.NoError(t, NewWriter(&buf, JSONEachRow).Write(proto.Input{
+		{Name: "i64", Data: &i64},
+		{Name: "f64", Data: &f64},
+		{Name: "m", Data: m},
+		{Name: "tuple", Data: tuple},
+		{Name: "named", Data: named},
+		{Name: "keys", Data: keys},
+	}))
+	require.Equal(t, `{"i64":"1152921504606846976","f64":null,"m":{"\u0001":""},"tuple":[1,2],"named":{"a":3},"keys":{"1":"b"}}`+"\n", buf.String())
+
+	_, err := NewDecoder(&buf, JSONEachRow, nil)
+	require.Error(t, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{TSV, TSVWithNames, CSV, CSVWithNames} {
+		t.Run(f.String(), func(t *testing.T) {
+			var (
+				buf     bytes.Buffer
+				input   = testInput()
+				columns []proto.ColInfo
+			)
+			w := NewWriter(&buf, f)
+			require.NoError(t, w.Write(input))
+			require.NoError(t, w.Write(input))
+			for _, c := range input {
+				columns = append(columns, proto.ColInfo{Name: c.Name, Type: c.Data.Type()})
+			}
+			d, err := NewDecoder(bytes.NewReader(buf.Bytes()), f, columns)
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				got, err := d.Decode(2)
+				require.NoError(t, err)
+				var b proto.Buffer
+				require.NoError(t, Encode(&b, TSV, got))
+				var expected proto.Buffer
+				require.NoError(t, Encode(&expected, TSV, testInput()))
+				require.Equal(t, string(expected.Buf), string(b.Buf))
+			}
+			_, err = d.Decode(0)
+			require.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	const data = `id,name,at,tags
+"7",alice,1700000000,"['a', 'b']"
+8,"bob, jr",2023-11-14 22:13:20,[]
+`
+	d, err := NewDecoder(strings.NewReader(data), CSVWithNames, []proto.ColInfo{
+		{Name: "name", Type: "LowCardinality(String)"},
+		{Name: "id", Type: "UInt64"},
+		{Name: "tags", Type: "Array(Nullable(String))"},
+		{Name: "at", Type: "DateTime('UTC')"},
+	})
+	require.NoError(t, err)
+	input, err := d.Decode(0)
+	require.NoError(t, err)
+	require.Equal(t, "name", input[0].Name)
+	require.Equal(t, proto.ColumnType("String"), input[0].Data.Type())
+
+	name := input[0].Data.(proto.ColumnOf[string])
+	require.Equal(t, "bob, jr", name.Row(1))
+	id := input[1].Data.(proto.ColumnOf[uint64])
+	require.Equal(t, uint64(7), id.Row(0))
+	tags := input[2].Data.(proto.ColumnOf[[]proto.Nullable[string]])
+	require.Equal(t, []proto.Nullable[string]{proto.NewNullable("a"), proto.NewNullable("b")}, tags.Row(0))
+	at := input[3].Data.(proto.ColumnOf[time.Time])
+	require.True(t, at.Row(0).Equal(at.Row(1)))
+
+	_, err = d.Decode(0)
+	require.ErrorIs(t, err, io.EOF)
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, data := range []string{
+			"x\n",
+			"\\N\n",
+			"1\t2\n",
+		} {
+			d, err := NewDecoder(strings.NewReader(data), TSV, []proto.ColInfo{
+				{Name: "v", Type: "Int8"},
+			})
+			require.NoError(t, err)
+			_, err = d.Decode(0)
+			require.Error(t, err, data)
+		}
+		_, err := NewDecoder(strings.NewReader("a\n"), TSVWithNames, []proto.ColInfo{
+			{Name: "b", Type: "Int8"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+package prototest
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ch-go/internal/gold"
+)
+
+// InitGolden registers -update and -clean flags of golden files, should
+// be called in TestMain.
+//
+// Golden files are stored in _golden directory of package. Run tests with
+// -update to write them, also removing all existing files unless
+// -clean=false is set.
+func InitGolden() {
+	gold.Init()
+}
+
+// GoldenBytes checks that data is equal to binary golden file with name,
+// writing it if it does not exist or update is requested. Hex dump is
+// written next to golden file to make diffs readable.
+func GoldenBytes(t testing.TB, data []byte, name ...string) {
+	t.Helper()
+	gold.Bytes(t, data, name...)
+}
+
+// GoldenStr checks that s is equal to text golden file with name, like
+// GoldenBytes.
+func GoldenStr(t testing.TB, s string, name ...string) {
+	t.Helper()
+	gold.Str(t, s, name...)
+}
@@ -0,0 +1,132 @@
+// Package prototest implements conformance tests for proto.Column
+// implementations, e.g. custom columns of third-party packages.
+package prototest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// RequireNoShortRead checks that v fails to decode every prefix of buf.
+func RequireNoShortRead(t testing.TB, buf []byte, v proto.Decoder) {
+	t.Helper()
+
+	for i := 0; i < len(buf); i++ {
+		b := buf[:i]
+		r := proto.NewReader(bytes.NewReader(b))
+		require.Error(t, v.Decode(r), "decode on short buffer should fail")
+	}
+}
+
+// RequireDecode checks that v decodes buf without error.
+func RequireDecode(t testing.TB, buf []byte, v proto.Decoder) {
+	t.Helper()
+
+	r := proto.NewReader(bytes.NewReader(buf))
+	require.NoError(t, v.Decode(r))
+}
+
+type columnDecoder struct {
+	col  proto.ColResult
+	rows int
+}
+
+func (c columnDecoder) Decode(r *proto.Reader) error {
+	if s, ok := c.col.(proto.StateDecoder); ok {
+		if err := s.DecodeState(r); err != nil {
+			return err
+		}
+	}
+	return c.col.DecodeColumn(r, c.rows)
+}
+
+// ColumnDecoder returns Decoder of rows of column, decoding state first
+// if column is proto.StateDecoder.
+func ColumnDecoder(col proto.ColResult, rows int) proto.Decoder {
+	return columnDecoder{col: col, rows: rows}
+}
+
+// EncodeColumn encodes column, with state first if column is
+// proto.StateEncoder. Column is prepared if it is proto.Preparable.
+func EncodeColumn(col proto.ColInput) ([]byte, error) {
+	if p, ok := col.(proto.Preparable); ok {
+		if err := p.Prepare(); err != nil {
+			return nil, errors.Wrap(err, "prepare")
+		}
+	}
+	var buf proto.Buffer
+	if s, ok := col.(proto.StateEncoder); ok {
+		s.EncodeState(&buf)
+	}
+	col.EncodeColumn(&buf)
+	return buf.Buf, nil
+}
+
+// CheckColumn checks that data is decoded by column created by newColumn
+// and encoded back to same bytes, and that decoding fails on short or
+// empty input. Returns encoded data, e.g. to check with GoldenBytes.
+func CheckColumn(t *testing.T, data proto.Column, newColumn func() proto.Column) []byte {
+	t.Helper()
+
+	rows := data.Rows()
+	buf, err := EncodeColumn(data)
+	require.NoError(t, err)
+	t.Run("Ok", func(t *testing.T) {
+		dec := newColumn()
+		RequireDecode(t, buf, ColumnDecoder(dec, rows))
+		require.Equal(t, rows, dec.Rows())
+		encoded, err := EncodeColumn(dec)
+		require.NoError(t, err)
+		require.Equal(t, buf, encoded, "round trip")
+		require.Equal(t, data.Type(), dec.Type())
+
+		dec.Reset()
+		require.Equal(t, 0, dec.Rows())
+	})
+	t.Run("ZeroRows", func(t *testing.T) {
+		r := proto.NewReader(bytes.NewReader(nil))
+		require.NoError(t, newColumn().DecodeColumn(r, 0))
+	})
+	t.Run("ZeroRowsEncode", func(t *testing.T) {
+		// Should be no-op.
+		newColumn().EncodeColumn(nil)
+	})
+	if rows == 0 {
+		return buf
+	}
+	t.Run("EOF", func(t *testing.T) {
+		r := proto.NewReader(bytes.NewReader(nil))
+		require.ErrorIs(t, ColumnDecoder(newColumn(), rows).Decode(r), io.EOF)
+	})
+	t.Run("NoShortRead", func(t *testing.T) {
+		RequireNoShortRead(t, buf, ColumnDecoder(newColumn(), rows))
+	})
+	return buf
+}
+
+// CheckColumnOf appends values to column created by newColumn, checks it
+// with CheckColumn and checks that rows of decoded column are equal to
+// values.
+func CheckColumnOf[T any](t *testing.T, newColumn func() proto.ColumnOf[T], values ...T) []byte {
+	t.Helper()
+
+	data := newColumn()
+	for _, v := range values {
+		data.Append(v)
+	}
+	buf := CheckColumn(t, data, func() proto.Column { return newColumn() })
+	t.Run("Rows", func(t *testing.T) {
+		dec := newColumn()
+		RequireDecode(t, buf, ColumnDecoder(dec, len(values)))
+		for i, v := range values {
+			require.Equal(t, v, dec.Row(i), "row %d", i)
+		}
+	})
+	return buf
+}
@@ -0,0 +1,48 @@
+package prototest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/ch-go/prototest"
+)
+
+func TestMain(m *testing.M) {
+	prototest.InitGolden()
+
+	os.Exit(m.Run())
+}
+
+func TestCheckColumn(t *testing.T) {
+	var data proto.ColUInt8
+	for i := 0; i < 10; i++ {
+		data.Append(uint8(i))
+	}
+	buf := prototest.CheckColumn(t, &data, func() proto.Column { return new(proto.ColUInt8) })
+	prototest.GoldenBytes(t, buf, "col_uint8")
+}
+
+func TestCheckColumnOf(t *testing.T) {
+	t.Run("Str", func(t *testing.T) {
+		buf := prototest.CheckColumnOf[string](t, func() proto.ColumnOf[string] {
+			return new(proto.ColStr)
+		}, "foo", "bar", "baz")
+		prototest.GoldenBytes(t, buf, "col_str")
+	})
+	t.Run("LowCardinality", func(t *testing.T) {
+		buf := prototest.CheckColumnOf[string](t, func() proto.ColumnOf[string] {
+			return new(proto.ColStr).LowCardinality()
+		}, "foo", "bar", "foo")
+		prototest.GoldenBytes(t, buf, "col_low_cardinality_str")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		prototest.CheckColumnOf[string](t, func() proto.ColumnOf[string] {
+			return new(proto.ColStr)
+		})
+	})
+}
+
+func TestGoldenStr(t *testing.T) {
+	prototest.GoldenStr(t, "Hello, world!\n", "hello.txt")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/go-faster/city"
@@ -52,14 +54,53 @@ func (c *Client) cancelQuery() error {
 
 func (c *Client) querySettings(q Query) []proto.Setting {
 	var result []proto.Setting
-	for _, s := range c.settings {
+	if p := q.prepared; p != nil {
+		// Capacity is limited to copy on append.
+		result = p.settings[:len(p.settings):len(p.settings)]
+	} else {
+		result = c.clientSettings()
+	}
+	if v := q.logComment(); v != "" {
+		result = append(result, proto.Setting{
+			Key:   "log_comment",
+			Value: v,
+		})
+	}
+	if d := q.PartialResultInterval; d > 0 {
+		result = append(result, proto.Setting{
+			Key:       "partial_result_update_duration_ms",
+			Value:     strconv.FormatInt(d.Milliseconds(), 10),
+			Important: true,
+		})
+	}
+	for _, s := range q.Settings {
 		result = append(result, proto.Setting{
 			Key:       s.Key,
 			Value:     s.Value,
 			Important: s.Important,
 		})
 	}
-	for _, s := range q.Settings {
+	// Last value of setting is used by server.
+	result = append(result, c.guards...)
+	if d := q.executionTime; d > 0 {
+		for _, s := range []Setting{
+			SettingMaxExecutionTime(d),
+			SettingSeconds("timeout_before_checking_execution_speed", d),
+		} {
+			result = append(result, proto.Setting{
+				Key:       s.Key,
+				Value:     s.Value,
+				Important: true,
+			})
+		}
+	}
+	return result
+}
+
+// clientSettings returns settings from Options.Settings.
+func (c *Client) clientSettings() []proto.Setting {
+	var result []proto.Setting
+	for _, s := range c.settings {
 		result = append(result, proto.Setting{
 			Key:       s.Key,
 			Value:     s.Value,
@@ -80,14 +121,25 @@ func (c *Client) sendQuery(ctx context.Context, q Query) error {
 	if c.IsClosed() {
 		return ErrClosed
 	}
-	c.encode(proto.Query{
+	body, params := q.Body, q.Parameters
+	if c.bindParameters && len(params) > 0 && !c.Supports(proto.FeatureParameters) {
+		if q.BodyReader != nil {
+			return errors.New("parameters of BodyReader can't be bound")
+		}
+		v, err := BindParameters(body, params)
+		if err != nil {
+			return errors.Wrap(err, "bind parameters")
+		}
+		body, params = v, nil
+	}
+	query := proto.Query{
 		ID:          q.QueryID,
-		Body:        q.Body,
+		Body:        body,
 		Secret:      q.Secret,
 		Stage:       proto.StageComplete,
 		Compression: c.compression,
 		Settings:    c.querySettings(q),
-		Parameters:  q.Parameters,
+		Parameters:  params,
 		Info: proto.ClientInfo{
 			ProtocolVersion: c.protocolVersion,
 			Major:           c.version.Major,
@@ -99,14 +151,29 @@ func (c *Client) sendQuery(ctx context.Context, q Query) error {
 			InitialUser:    q.InitialUser,
 			InitialQueryID: q.QueryID,
 			InitialAddress: c.conn.LocalAddr().String(),
-			OSUser:         "",
-			ClientHostname: "",
+			OSUser:         c.osUser,
+			ClientHostname: c.hostname,
 			ClientName:     c.version.Name,
 
 			Span:     trace.SpanContextFromContext(ctx),
 			QuotaKey: q.QuotaKey,
 		},
-	})
+	}
+	if c.clusterSecret != "" {
+		var nonce *uint64
+		if proto.FeatureInterServerSecretV2.In(c.protocolVersion) {
+			nonce = &c.server.Nonce
+		}
+		query.Secret = query.InterserverHash(c.info.Salt, c.clusterSecret, nonce)
+	}
+	if q.BodyReader != nil {
+		if err := c.sendQueryBody(ctx, query, q); err != nil {
+			return errors.Wrap(err, "body")
+		}
+	} else {
+		c.encode(query)
+		c.endPacket()
+	}
 
 	// Encoding external data if provided.
 	if len(q.ExternalData) > 0 {
@@ -130,11 +197,41 @@ func (c *Client) sendQuery(ctx context.Context, q Query) error {
 type Query struct {
 	// Body of query, like "SELECT 1".
 	Body string
+	// BodyReader is alternative to Body for very large queries, like
+	// INSERT ... VALUES generated elsewhere, that is streamed to server
+	// without building single string.
+	//
+	// Only Body or BodyReader can be set. Parameters are not bound on
+	// client side for BodyReader, see Options.BindParameters.
+	BodyReader io.Reader
+	// BodySize is size of BodyReader in bytes, required if reader has no
+	// Len() int method like *bytes.Reader or *strings.Reader.
+	BodySize int
 	// QueryID is ID of query, defaults to new UUIDv4.
 	QueryID string
-	// QuotaKey of query, optional.
+	// QuotaKey of query, optional, defaults to Options.QuotaKey.
 	QuotaKey string
 
+	// ReadTimeout limits waiting for each packet of query from server,
+	// overriding Options.ReadTimeout, use NoTimeout to disable.
+	//
+	// Unlike Options.ReadTimeout, which is retried for long-running
+	// queries, expired timeout fails query with net.Error, so hung server
+	// is detected even if context has no deadline.
+	ReadTimeout time.Duration
+	// WriteTimeout limits each write of query to connection, like query
+	// or input blocks, regardless of context deadline.
+	WriteTimeout time.Duration
+	// StallTimeout cancels query with ErrQueryStalled if nothing, like
+	// progress, logs or data, is received from server for this duration
+	// after query is sent, e.g. from replica that accepted query but
+	// stopped making progress.
+	//
+	// Defaults to Options.StallTimeout, use NoTimeout to disable. Should be
+	// larger than interactive_delay setting, which is interval of progress
+	// packets.
+	StallTimeout time.Duration
+
 	// Input columns for INSERT operations.
 	Input proto.Input
 	// OnInput is called to allow ingesting more data to Input.
@@ -151,11 +248,74 @@ type Query struct {
 	//
 	// Optional, but query will fail of more than one block is received
 	// and no OnResult is provided.
+	//
+	// Long-running queries like WATCH deliver each new result as a block,
+	// and heartbeats as blocks with zero rows. Return ErrStop to stop
+	// receiving result without closing connection.
 	OnResult func(ctx context.Context, block proto.Block) error
+	// OnResultBlock is alternative to OnResult that is also called with
+	// metadata of block, e.g. to distinguish totals or overflows rows of
+	// GROUP BY WITH TOTALS, or bucket of two-level aggregation.
+	//
+	// Can't be used with OnResult.
+	OnResultBlock func(ctx context.Context, meta proto.BlockMeta, block proto.Block) error
+
+	// OnPartialResult is called when Result is filled with intermediate
+	// result block, e.g. with partial aggregation state, if partial
+	// results are enabled by PartialResultInterval.
+	//
+	// Partial results are sent by server before final result, which is
+	// passed to OnResult as usual.
+	OnPartialResult func(ctx context.Context, block proto.Block) error
+	// PartialResultInterval is interval of partial result updates, sent as
+	// partial_result_update_duration_ms setting. Requires OnPartialResult.
+	PartialResultInterval time.Duration
+
+	// MaxResultRows limits number of result rows received by client,
+	// optional. Query is canceled once limit is exceeded and Do returns
+	// *ResultLimitError, preventing unbounded results from exhausting memory.
+	//
+	// Unlike max_result_rows setting, limit is checked on client side for
+	// each received block before passing it to OnResult or OnRawBlock.
+	MaxResultRows int
+	// MaxResultBytes limits number of bytes read from connection while
+	// receiving result, optional, same as MaxResultRows.
+	//
+	// Limit is checked after each block is read, so whole block that
+	// exceeds it is received and decoded first. It limits accumulated
+	// result, not memory used by single block.
+	MaxResultBytes int
+
+	// OnRawBlock is called for each data block instead of decoding it into
+	// Result, including blocks with zero rows, e.g. INSERT table header.
+	//
+	// Block data is valid only until OnRawBlock returns.
+	OnRawBlock func(ctx context.Context, block RawBlock) error
+	// OnRawInput is called to get next data block for INSERT, which is
+	// sent as is, compressing or decompressing it if needed. The io.EOF
+	// reports that there are no more blocks.
+	//
+	// Can't be used with Input. Block protocol version must match
+	// ProtocolVersion of client, e.g. blocks can be passed from OnRawBlock
+	// of client connected to server of the same version.
+	OnRawInput func(ctx context.Context) (RawBlock, error)
+
+	// OnReadTask is called when server requests next task of distributed
+	// processing, e.g. next file to read for s3Cluster. Empty task means
+	// that there are no more tasks.
+	//
+	// Optional, empty task is sent if not provided.
+	OnReadTask func(ctx context.Context) (string, error)
 
 	// OnProgress is optional progress handler. The progress value contain
 	// difference, so progress should be accumulated if needed.
 	OnProgress func(ctx context.Context, p proto.Progress) error
+	// ProgressInterval coalesces progress updates, so OnProgress is called
+	// at most once per interval with sum of increments, optional.
+	//
+	// Remaining progress is passed to OnProgress at the end of query. Use
+	// proto.ProgressAccumulator to get total progress.
+	ProgressInterval time.Duration
 	// OnProfile is optional handler for profiling data.
 	OnProfile func(ctx context.Context, p proto.Profile) error
 	// OnProfileEvent is optional handler for profiling event stream data.
@@ -174,13 +334,29 @@ type Query struct {
 	OnLogs func(ctx context.Context, l []Log) error
 
 	// Settings are optional query-scoped settings. Can override client settings.
+	//
+	// Values of known settings are validated, see Setting.Validate.
+	//
+	// Settings of context are added before them, see WithSettings.
 	Settings []Setting
 
+	// Comment is optional query comment, sent as log_comment setting and
+	// available in system.query_log.
+	Comment string
+	// Tags are optional query tags, sent as log_comment setting encoded
+	// as JSON object, e.g. {"service":"api"}.
+	//
+	// If Comment is also set, it is added to tags with "comment" key.
+	// Explicit log_comment in Settings takes precedence.
+	Tags map[string]string
+
 	// EXPERIMENTAL: parameters for query.
 	Parameters []proto.Parameter
 
 	// Secret is optional inter-server per-cluster secret for Distributed queries.
 	//
+	// Sent as is and ignored if Options.ClusterSecret is set, prefer it.
+	//
 	// See https://clickhouse.com/docs/en/engines/table-engines/special/distributed/#distributed-clusters
 	Secret string
 
@@ -196,6 +372,13 @@ type Query struct {
 
 	// Logger for query, optional, defaults to client logger with `query_id` field.
 	Logger *zap.Logger
+	// SlogLogger is alternative to Logger, used if Logger is nil.
+	SlogLogger *slog.Logger
+
+	// prepared is template of query, if any.
+	prepared *PreparedQuery
+	// executionTime derived from context deadline.
+	executionTime time.Duration
 }
 
 // CorruptedDataErr means that provided hash mismatch with calculated.
@@ -204,19 +387,53 @@ type CorruptedDataErr struct {
 	Reference city.U128
 	RawSize   int
 	DataSize  int
+
+	// Block is index of block in query response, including blocks of
+	// logs or profile events.
+	Block int
+	// QueryID of query with corrupted response.
+	QueryID string
 }
 
 func (c *CorruptedDataErr) Error() string {
-	return fmt.Sprintf("corrupted data: %s (actual), %s (reference), compressed size: %d, data size: %d",
-		compress.FormatU128(c.Actual), compress.FormatU128(c.Reference), c.RawSize, c.DataSize,
+	return fmt.Sprintf("corrupted data: %s (actual), %s (reference), compressed size: %d, data size: %d, block: %d, query id: %s",
+		compress.FormatU128(c.Actual), compress.FormatU128(c.Reference), c.RawSize, c.DataSize, c.Block, c.QueryID,
 	)
 }
 
+// corruptedData returns exported error of current block with query
+// details.
+func (c *Client) corruptedData(err *compress.CorruptedDataErr) *CorruptedDataErr {
+	return &CorruptedDataErr{
+		Actual:    err.Actual,
+		Reference: err.Reference,
+		RawSize:   err.RawSize,
+		DataSize:  err.DataSize,
+		Block:     c.queryBlock,
+		QueryID:   c.stats.queryID.Load(),
+	}
+}
+
+// onCorruptedData handles checksum mismatch with ChecksumWarn policy.
+func (c *Client) onCorruptedData(err *compress.CorruptedDataErr) {
+	e := c.corruptedData(err)
+	c.lg.Warn("Corrupted data",
+		zap.String("query_id", e.QueryID),
+		zap.Int("block", e.Block),
+		zap.Error(e),
+	)
+	if f := c.onCorrupted; f != nil {
+		f(e)
+	}
+}
+
 type decodeOptions struct {
 	Handler         func(ctx context.Context, b proto.Block) error
 	Result          proto.Result
 	ProtocolVersion int
 	Compressible    bool
+	// OnEnd is optional handler of blank "end of data" block.
+	OnEnd func(ctx context.Context) error
 }
 
 func (c *Client) decodeBlock(ctx context.Context, opt decodeOptions) error {
@@ -229,9 +446,10 @@ func (c *Client) decodeBlock(ctx context.Context, opt decodeOptions) error {
 			return errors.Wrap(err, "temp table")
 		}
 		if v != "" {
-			return errors.Errorf("unexpected temp table %q", v)
+			return errors.Wrapf(ErrProtocol, "unexpected temp table %q", v)
 		}
 	}
+	c.queryBlock++
 	var block proto.Block
 	if c.compression == proto.CompressionEnabled && opt.Compressible {
 		c.reader.EnableCompression()
@@ -241,8 +459,7 @@ func (c *Client) decodeBlock(ctx context.Context, opt decodeOptions) error {
 		var badData *compress.CorruptedDataErr
 		if errors.As(err, &badData) {
 			// Returning wrapped exported error to allow user matching.
-			exportedErr := CorruptedDataErr(*badData)
-			return errors.Wrap(&exportedErr, "bad block")
+			return errors.Wrap(c.corruptedData(badData), "bad block")
 		}
 		return errors.Wrap(err, "decode block")
 	}
@@ -253,8 +470,16 @@ func (c *Client) decodeBlock(ctx context.Context, opt decodeOptions) error {
 		)
 	}
 	if block.End() {
+		if opt.OnEnd != nil {
+			return opt.OnEnd(ctx)
+		}
 		return nil
 	}
+	if s, ok := opt.Result.(proto.DefaultLocationSetter); ok && c.location != nil {
+		s.SetDefaultLocation(c.location)
+	}
+	c.stats.blocksReceived.Inc()
+	c.stats.rowsReceived.Add(uint64(block.Rows))
 	c.metricsInc(ctx, queryMetrics{
 		BlocksReceived:  1,
 		RowsReceived:    block.Rows,
@@ -287,6 +512,8 @@ func (c *Client) encodeBlock(ctx context.Context, tableName string, input []prot
 	if len(input) > 0 {
 		c.metricsInc(ctx, queryMetrics{BlocksSent: 1})
 		b.Rows = input[0].Data.Rows()
+		c.stats.blocksSent.Inc()
+		c.stats.rowsSent.Add(uint64(b.Rows))
 		b.Info = proto.BlockInfo{
 			// TODO(ernado): investigate and document
 			BucketNum: -1,
@@ -305,8 +532,11 @@ func (c *Client) encodeBlock(ctx context.Context, tableName string, input []prot
 		if err := c.compressor.Compress(c.compressionMethod, data); err != nil {
 			return errors.Wrap(err, "compress")
 		}
+		c.stats.uncompressedBytesSent.Add(uint64(len(data)))
+		c.stats.compressedBytesSent.Add(uint64(len(c.compressor.Data)))
 		c.buf.Buf = append(c.buf.Buf[:start], c.compressor.Data...)
 	}
+	c.endPacket()
 
 	return nil
 }
@@ -449,6 +679,17 @@ func (c *Client) handlePacket(ctx context.Context, p proto.ServerCode, q Query)
 			return errors.Wrap(err, "progress")
 		}
 		c.metricsInc(ctx, queryMetrics{Rows: int(p.Rows), Bytes: int(p.Bytes)})
+		c.metrics.progress(ctx, p.Rows, p.Bytes)
+		if c.events {
+			trace.SpanFromContext(ctx).AddEvent(otelch.ProgressEvent, trace.WithAttributes(
+				otelch.Rows(int(p.Rows)),
+				otelch.Bytes(int(p.Bytes)),
+				otelch.TotalRows(p.TotalRows),
+				otelch.WroteRows(p.WroteRows),
+				otelch.WroteBytes(p.WroteBytes),
+				otelch.Elapsed(p.ElapsedNs),
+			))
+		}
 		if ce := c.lg.Check(zap.DebugLevel, "Progress"); ce != nil {
 			ce.Write(
 				zap.Uint64("rows", p.Rows),
@@ -476,12 +717,90 @@ func (c *Client) handlePacket(ctx context.Context, p proto.ServerCode, q Query)
 				zap.Uint64("blocks", p.Blocks),
 			)
 		}
+		if c.events {
+			trace.SpanFromContext(ctx).AddEvent(otelch.ProfileEvent, trace.WithAttributes(
+				otelch.Rows(int(p.Rows)),
+				otelch.Bytes(int(p.Bytes)),
+				otelch.Blocks(p.Blocks),
+				otelch.RowsBeforeLimit(p.RowsBeforeLimit),
+			))
+		}
 		if f := q.OnProfile; f != nil {
 			if err := f(ctx, p); err != nil {
 				return errors.Wrap(err, "profile")
 			}
 		}
 		return nil
+	case proto.ServerReadTaskRequest:
+		// Distributed processing, e.g. s3Cluster, request has no payload.
+		var task string
+		if f := q.OnReadTask; f != nil {
+			v, err := f(ctx)
+			if err != nil {
+				return errors.Wrap(err, "read task")
+			}
+			task = v
+		}
+		if ce := c.lg.Check(zap.DebugLevel, "Read task request"); ce != nil {
+			ce.Write(zap.String("task", task))
+		}
+		// Not using c.buf to prevent data race.
+		var b proto.Buffer
+		proto.ReadTaskResponse{
+			Version: proto.ClusterProcessingVersion,
+			Task:    task,
+		}.Encode(&b)
+		if err := c.flushBuf(ctx, &b); err != nil {
+			return errors.Wrap(err, "read task response")
+		}
+		return nil
+	case proto.ServerMergeTreeAllRangesAnnouncement:
+		// Parallel replicas: client is not a coordinator, ignoring.
+		var a proto.MergeTreeAllRangesAnnouncement
+		if err := c.reader.Decode(&a); err != nil {
+			return errors.Wrap(err, "all ranges announcement")
+		}
+		if ce := c.lg.Check(zap.DebugLevel, "All ranges announcement"); ce != nil {
+			ce.Write(
+				zap.Uint64("replica", a.ReplicaNum),
+				zap.Int("parts", len(a.Description)),
+			)
+		}
+		return nil
+	case proto.ServerMergeTreeReadTaskRequest:
+		var t proto.MergeTreeReadTaskRequest
+		if err := c.reader.Decode(&t); err != nil {
+			return errors.Wrap(err, "read task request")
+		}
+		if ce := c.lg.Check(zap.DebugLevel, "MergeTree read task request"); ce != nil {
+			ce.Write(zap.Uint64("replica", t.ReplicaNum))
+		}
+		// Client is not a coordinator, so there are no ranges to assign.
+		//
+		// Not using c.buf to prevent data race.
+		var b proto.Buffer
+		proto.MergeTreeReadTaskResponse{Version: t.Version, Finish: true}.Encode(&b)
+		if err := c.flushBuf(ctx, &b); err != nil {
+			return errors.Wrap(err, "read task response")
+		}
+		return nil
+	case proto.ServerTimezoneUpdate:
+		// Session time zone is changed, e.g. by SET session_timezone.
+		tz, err := c.reader.Str()
+		if err != nil {
+			return errors.Wrap(err, "timezone update")
+		}
+		c.lg.Debug("Timezone update", zap.String("timezone", tz))
+		if c.fixedLocation {
+			return nil
+		}
+		loc, err := proto.ServerHello{Timezone: tz}.Location()
+		if err != nil {
+			c.lg.Warn("Failed to load session time zone", zap.Error(err))
+			return nil
+		}
+		c.location = loc
+		return nil
 	case proto.ServerCodeTableColumns:
 		// Ignoring for now.
 		var info proto.TableColumns
@@ -566,28 +885,115 @@ func (c *Client) handlePacket(ctx context.Context, p proto.ServerCode, q Query)
 		}
 		return nil
 	default:
-		return errors.Errorf("unexpected packet %q", p)
+		return errors.Wrapf(ErrProtocol, "unexpected packet %q", p)
 	}
 }
 
 // Do performs Query on ClickHouse server.
-func (c *Client) Do(ctx context.Context, q Query) (err error) {
+//
+// Query is passed through Options.QueryInterceptor if set.
+func (c *Client) Do(ctx context.Context, q Query) error {
+	if c.interceptor != nil {
+		return c.interceptor(c.doClassified)(ctx, q)
+	}
+	return c.doClassified(ctx, q)
+}
+
+// doClassified executes query, adding category to error, see ErrNetwork.
+func (c *Client) doClassified(ctx context.Context, q Query) error {
+	if w := c.takeWake(); w != nil && canRetryWake(q) {
+		return classifyError(c.doWake(ctx, q, w))
+	}
+	return classifyError(c.do(ctx, q))
+}
+
+func (c *Client) do(ctx context.Context, q Query) (err error) {
 	if c.IsClosed() {
 		return ErrClosed
 	}
-	if len(q.Parameters) > 0 && !proto.FeatureParameters.In(c.protocolVersion) {
+	if len(q.Parameters) > 0 && !proto.FeatureParameters.In(c.protocolVersion) && !c.bindParameters {
 		return errors.Errorf("query parameters are not supported in protocol version %d, upgrade server %q",
 			c.protocolVersion, c.server,
 		)
 	}
+	if q.PartialResultInterval > 0 && q.OnPartialResult == nil {
+		return errors.New("PartialResultInterval requires OnPartialResult")
+	}
+	if q.OnRawInput != nil && len(q.Input) > 0 {
+		return errors.New("Input and OnRawInput can't be used together")
+	}
+	if q.OnResult != nil && q.OnResultBlock != nil {
+		return errors.New("OnResult and OnResultBlock can't be used together")
+	}
+	if q.ProgressInterval > 0 && q.OnProgress == nil {
+		return errors.New("ProgressInterval requires OnProgress")
+	}
+	if q.Body != "" && q.BodyReader != nil {
+		return errors.New("Body and BodyReader can't be used together")
+	}
+	if s := ContextSettings(ctx); len(s) > 0 {
+		q.Settings = append(s[:len(s):len(s)], q.Settings...)
+	}
+	if err := validateSettings(q.Settings); err != nil {
+		return errors.Wrap(err, "settings")
+	}
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	ctx = context.WithValue(ctx, runningQueryKey{}, c)
+	c.queryReadTimeout, c.writeTimeout = q.ReadTimeout, q.WriteTimeout
+	c.stalled.Store(false)
+	defer func() {
+		c.queryReadTimeout, c.writeTimeout = 0, 0
+	}()
+	if c.maxResultMemory > 0 {
+		// Not retaining buffers of large results between queries.
+		defer c.reader.Release()
+	}
+	if c.deadlineExecutionTime {
+		q.executionTime = c.executionTimeFromDeadline(ctx, q)
+	}
 	if q.QueryID == "" {
 		q.QueryID = uuid.New().String()
 	}
+	c.stats.queryID.Store(q.QueryID)
+	c.queryBlock = -1
+	if q.QuotaKey == "" {
+		q.QuotaKey = c.quotaKey
+	}
+	{
+		queryEnd := c.metrics.queryStart(ctx)
+		c.stats.queries.Inc()
+		defer func() {
+			queryEnd(err)
+			if err != nil {
+				c.stats.queryErrors.Inc()
+			}
+		}()
+	}
+	timings := QueryTimings{Start: time.Now()}
+	if f := c.onQueryStart; f != nil {
+		f(ctx, q)
+	}
+	if f := c.onQueryEnd; f != nil {
+		// Query can be modified during execution, so copying it.
+		original := q
+		defer func() {
+			timings.Total = time.Since(timings.Start)
+			f(ctx, original, timings, err)
+		}()
+	}
+	var progress *progressThrottle
+	if q.ProgressInterval > 0 {
+		progress = newProgressThrottle(q.ProgressInterval, q.OnProgress)
+		q.OnProgress = progress.Handle
+	}
 	{
 		// Setup query logger.
 		//
-		// Since Do is not goroutine-safe, we can safely reuse client logger,
-		// so next calls will utilize changed c.lg.
+		// Since queries are serialized by acquire, we can safely reuse
+		// client logger, so next calls will utilize changed c.lg.
 		lg := c.lg
 		defer func(v *zap.Logger) {
 			// Set logger back after query is done.
@@ -596,6 +1002,11 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 		if q.Logger != nil {
 			// Using provided query logger.
 			lg = q.Logger
+		} else if q.SlogLogger != nil {
+			lg = NewSlogLogger(q.SlogLogger)
+		} else if q.prepared != nil {
+			// Using cached logger of prepared query.
+			lg = q.prepared.lg
 		} else {
 			// Using client logger.
 			// Allow correlation of queries by query_id.
@@ -653,6 +1064,7 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 	done := make(chan struct{})
 	var (
 		gotException atomic.Bool
+		stopped      atomic.Bool
 		colInfo      chan proto.ColInfoInput
 	)
 	if q.Result == nil && len(q.Input) > 0 {
@@ -660,6 +1072,7 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 		result := proto.ColInfoInput{}
 		q.Result = &result
 		colInfo = make(chan proto.ColInfoInput, 1)
+		q.OnResultBlock = nil
 		q.OnResult = func(ctx context.Context, block proto.Block) error {
 			if ce := c.lg.Check(zap.DebugLevel, "Received column info"); ce != nil {
 				info := make(map[string]proto.ColumnType, len(result))
@@ -676,11 +1089,21 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 			}
 		}
 	}
+	sent := make(chan struct{})
+	// Sender goroutine owns state until query is sent, receiver does not
+	// change it. State is switched before last flush, as server can't
+	// respond with result before it.
+	c.stats.setState(ConnSending)
+	hasInput := len(q.Input) > 0 || q.OnRawInput != nil
 	g.Go(func() error {
 		// Sending data.
+		defer close(sent)
 		if err := c.sendQuery(ctx, q); err != nil {
 			return errors.Wrap(err, "send query")
 		}
+		if !hasInput {
+			c.stats.setState(ConnReceiving)
+		}
 		if err := c.flush(ctx); err != nil {
 			return errors.Wrap(err, "flush")
 		}
@@ -694,12 +1117,18 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 				info = v
 			}
 		}
-		if err := c.sendInput(ctx, info, q); err != nil {
+		if q.OnRawInput != nil {
+			if err := c.sendRawInput(ctx, q); err != nil {
+				return errors.Wrap(err, "send raw input")
+			}
+		} else if err := c.sendInput(ctx, info, q); err != nil {
 			return errors.Wrap(err, "send input")
 		}
+		c.stats.setState(ConnReceiving)
 		if err := c.flush(ctx); err != nil {
 			return errors.Wrap(err, "flush")
 		}
+		timings.Send = time.Since(timings.Start)
 		return nil
 	})
 	g.Go(func() error {
@@ -708,29 +1137,114 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 		if colInfo != nil {
 			defer close(colInfo)
 		}
-		onResult := c.resultHandler(q)
+		var (
+			onResult = c.resultHandler(q)
+			onEnd    func(ctx context.Context) error
+			// Code of current data packet.
+			dataCode proto.ServerCode
+		)
+		if f := q.OnResultBlock; f != nil {
+			onResult = func(ctx context.Context, block proto.Block) error {
+				return f(ctx, proto.BlockMeta{Code: dataCode, Info: block.Info}, block)
+			}
+		}
+		if q.OnPartialResult != nil {
+			p := newPartialResult(q.OnPartialResult, onResult)
+			onResult, onEnd = p.Handle, p.End
+		}
+		onRawBlock := q.OnRawBlock
+		if limit := c.newResultLimit(q); limit != nil {
+			onResult = limit.Handler(onResult)
+			if onRawBlock != nil {
+				onRawBlock = limit.RawHandler(onRawBlock)
+			}
+		}
+		stop := func() error {
+			// Waiting for sender to finish, so cancel packet is not
+			// interleaved with data.
+			select {
+			case <-sent:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if err := c.sendCancel(ctx); err != nil {
+				return errors.Wrap(err, "cancel")
+			}
+			if err := c.drain(ctx); err != nil {
+				return errors.Wrap(err, "drain")
+			}
+			return nil
+		}
+		// stopOn stops query if err requires it, returning final error.
+		stopOn := func(err error) (bool, error) {
+			if errors.Is(err, ErrStop) {
+				if err := stop(); err != nil {
+					return true, err
+				}
+				stopped.Store(true)
+				return true, nil
+			}
+			var limitErr *ResultLimitError
+			if errors.As(err, &limitErr) {
+				if err := stop(); err != nil {
+					return true, errors.Wrap(err, "stop")
+				}
+				// Query is already canceled and drained, so connection
+				// can be reused.
+				stopped.Store(true)
+				return true, limitErr
+			}
+			return false, nil
+		}
 		for {
 			if ctx.Err() != nil {
 				return ctx.Err()
 			}
 			code, err := c.packet(ctx)
 			if err != nil {
+				if err := c.stallErr(); err != nil {
+					return err
+				}
 				var opErr *net.OpError
-				if errors.As(err, &opErr) && opErr.Timeout() {
+				if errors.As(err, &opErr) && opErr.Timeout() && q.ReadTimeout <= 0 {
+					// Long-running queries like WATCH can have no packets
+					// for a long time, so waiting for next one.
 					continue
 				}
 				return errors.Wrap(err, "packet")
 			}
 			switch code {
 			case proto.ServerCodeData, proto.ServerCodeTotals:
+				dataCode = code
+				if timings.FirstBlock == 0 {
+					timings.FirstBlock = time.Since(timings.Start)
+				}
+				if onRawBlock != nil {
+					if err := c.decodeRawBlock(ctx, code.Compressible(), onRawBlock); err != nil {
+						if ok, err := stopOn(err); ok {
+							return err
+						}
+						return errors.Wrap(err, "decode raw block")
+					}
+					continue
+				}
 				if err := c.decodeBlock(ctx, decodeOptions{
 					Handler:      onResult,
+					OnEnd:        onEnd,
 					Result:       q.Result,
 					Compressible: code.Compressible(),
 				}); err != nil {
+					if ok, err := stopOn(err); ok {
+						return err
+					}
 					return errors.Wrap(err, "decode block")
 				}
 			case proto.ServerCodeEndOfStream:
+				if progress != nil {
+					if err := progress.Flush(ctx); err != nil {
+						return errors.Wrap(err, "progress")
+					}
+				}
 				return nil
 			default:
 				if err := c.handlePacket(ctx, code, q); err != nil {
@@ -743,11 +1257,18 @@ func (c *Client) Do(ctx context.Context, q Query) (err error) {
 			}
 		}
 	})
+	if d := c.stallTimeout(q); d > 0 {
+		g.Go(func() error {
+			return c.watchStall(ctx, sent, done, d)
+		})
+	}
 	g.Go(func() error {
 		<-done
 		// Handling query cancellation if needed.
-		if ctx.Err() != nil && !gotException.Load() {
+		if ctx.Err() != nil && !gotException.Load() && !stopped.Load() {
+			cancelStart := time.Now()
 			err := multierr.Append(ctx.Err(), c.cancelQuery())
+			timings.Cancel = time.Since(cancelStart)
 			return errors.Wrap(err, "canceled")
 		}
 		return nil
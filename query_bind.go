@@ -0,0 +1,207 @@
+package ch
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// BindParameters substitutes {name:Type} placeholders in query with
+// literals of parameter values, like CAST('value' AS Type), resembling
+// server side parameters substitution.
+//
+// Placeholders in string literals, quoted identifiers and comments are
+// not substituted. Parameter values are expected to be in the format of
+// proto.Param, i.e. quoted text representation.
+//
+// Used as fallback for servers without proto.FeatureParameters if
+// Options.BindParameters is set.
+func BindParameters(query string, params []proto.Parameter) (string, error) {
+	if len(params) == 0 {
+		return query, nil
+	}
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.Key] = p.Value
+	}
+
+	return replacePlaceholders(query, func(name, typ string) (string, error) {
+		value, ok := values[name]
+		if !ok {
+			return "", errors.Errorf("parameter %q not found", name)
+		}
+		literal, err := bindLiteral(value, proto.ColumnType(typ))
+		if err != nil {
+			return "", errors.Wrapf(err, "parameter %q", name)
+		}
+		return literal, nil
+	})
+}
+
+// replacePlaceholders replaces {name:Type} placeholders in query with
+// result of f, skipping string literals, quoted identifiers and comments.
+func replacePlaceholders(query string, f func(name, typ string) (string, error)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end, _ := skipQuoted(query, i)
+			b.WriteString(query[i:end])
+			i = end
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case c == '{':
+			name, typ, end, ok := placeholder(query, i)
+			if !ok {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			v, err := f(name, typ)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// skipQuoted returns position after quoted string that starts at i and
+// whether it is terminated. Position is end of s if not.
+func skipQuoted(s string, i int) (int, bool) {
+	q := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case q:
+			return j + 1, true
+		}
+	}
+	return len(s), false
+}
+
+// placeholder parses {name:Type} at i, returning position after it.
+func placeholder(s string, i int) (name, typ string, end int, ok bool) {
+	j := i + 1
+	for j < len(s) && (s[j] == '_' || isAlpha(s[j]) || (j > i+1 && isDigit(s[j]))) {
+		j++
+	}
+	if j == i+1 || j == len(s) || s[j] != ':' {
+		return "", "", 0, false
+	}
+	name = s[i+1 : j]
+	start := j + 1
+	for k := start; k < len(s); k++ {
+		switch s[k] {
+		case '\'':
+			end, _ := skipQuoted(s, k)
+			k = end - 1
+		case '}':
+			typ = strings.TrimSpace(s[start:k])
+			if typ == "" {
+				return "", "", 0, false
+			}
+			return name, typ, k + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func isAlpha(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// bindLiteral returns SQL literal of parameter value with type t.
+func bindLiteral(value string, t proto.ColumnType) (string, error) {
+	if len(value) < 2 || value[0] != '\'' || value[len(value)-1] != '\'' {
+		return "", errors.Errorf("value %s is not quoted", value)
+	}
+	// Text representation of value in escaped format.
+	text := unescapeParam(value[1 : len(value)-1])
+	if t == "Identifier" {
+		return quoteIdentifier(unescapeParam(text)), nil
+	}
+	if text == `\N` {
+		return "CAST(NULL AS " + string(t) + ")", nil
+	}
+	if isStringType(t) {
+		// Strings are escaped in text representation, while other types
+		// are parsed from it by CAST.
+		text = unescapeParam(text)
+	}
+	return "CAST(" + string(proto.LitString(text)) + " AS " + string(t) + ")", nil
+}
+
+// quoteIdentifier returns back quoted identifier.
+func quoteIdentifier(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+// isStringType reports whether text representation of t is escaped string.
+func isStringType(t proto.ColumnType) bool {
+	for {
+		switch t.Base() {
+		case proto.ColumnTypeNullable, proto.ColumnTypeLowCardinality:
+			t = t.Elem()
+		case proto.ColumnTypeString, proto.ColumnTypeFixedString,
+			proto.ColumnTypeEnum8, proto.ColumnTypeEnum16:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// unescapeParam reverts backslash escaping of quoted or escaped format.
+func unescapeParam(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '0':
+			b.WriteByte(0)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestBindParameters(t *testing.T) {
+	params := []proto.Parameter{
+		proto.ParamString("s", "it's\n"),
+		proto.ParamInt("n", 10),
+		proto.ParamArray("a", proto.LitString("x'y")),
+		proto.ParamIdentifier("table", "my`table"),
+		proto.Param("null", proto.LitNull),
+		proto.ParamDateTime("t", time.Unix(1700000000, 0)),
+	}
+	for _, tt := range []struct {
+		Query  string
+		Result string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT {s:String}", `SELECT CAST('it\'s\n' AS String)`},
+		{"SELECT {s: LowCardinality(Nullable(String)) }", `SELECT CAST('it\'s\n' AS LowCardinality(Nullable(String)))`},
+		{"SELECT {n:UInt8} + {n:UInt8}", `SELECT CAST('10' AS UInt8) + CAST('10' AS UInt8)`},
+		{"SELECT {a:Array(String)}", `SELECT CAST('[\'x\\\'y\']' AS Array(String))`},
+		{"SELECT * FROM {table:Identifier}", "SELECT * FROM `my\\`table`"},
+		{"SELECT {null:Nullable(Int8)}", `SELECT CAST(NULL AS Nullable(Int8))`},
+		{"SELECT {t:DateTime('UTC')}", `SELECT CAST('1700000000' AS DateTime('UTC'))`},
+		{"SELECT '{s:String}', `{s:String}` -- {s:String}", "SELECT '{s:String}', `{s:String}` -- {s:String}"},
+		{"SELECT /* {s:String} */ {'k':1}, {}", "SELECT /* {s:String} */ {'k':1}, {}"},
+		{"SELECT 'it\\'s {s:String}'", "SELECT 'it\\'s {s:String}'"},
+	} {
+		v, err := BindParameters(tt.Query, params)
+		require.NoError(t, err, tt.Query)
+		require.Equal(t, tt.Result, v, tt.Query)
+	}
+
+	_, err := BindParameters("SELECT {missing:String}", params)
+	require.Error(t, err)
+	_, err = BindParameters("SELECT {s:String}", []proto.Parameter{{Key: "s", Value: "unquoted"}})
+	require.Error(t, err)
+}
+
+func TestClient_BindParameters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		ProtocolVersion: int(proto.FeatureParameters) - 1,
+		BindParameters:  true,
+	})
+	var (
+		str proto.ColStr
+		arr = proto.NewArray[string](new(proto.ColStr))
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT {str:String} s, {arr:Array(String)} a",
+		Parameters: []proto.Parameter{
+			proto.ParamString("str", "it's"),
+			proto.ParamArray("arr", proto.LitString("a'b")),
+		},
+		Result: proto.Results{
+			{Name: "s", Data: &str},
+			{Name: "a", Data: arr},
+		},
+	}))
+	require.Equal(t, "it's", str.Row(0))
+	require.Equal(t, []string{"a'b"}, arr.Row(0))
+}
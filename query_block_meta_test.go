@@ -0,0 +1,55 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_Do_resultBlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	t.Run("Totals", func(t *testing.T) {
+		var (
+			k, c   proto.ColUInt64
+			rows   []uint64
+			totals []uint64
+		)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT number % 2 AS k, count() AS c FROM numbers(10) GROUP BY k WITH TOTALS ORDER BY k",
+			Result: proto.Results{
+				{Name: "k", Data: &k},
+				{Name: "c", Data: &c},
+			},
+			OnResultBlock: func(ctx context.Context, meta proto.BlockMeta, block proto.Block) error {
+				require.Equal(t, meta.Info, block.Info)
+				for i := 0; i < c.Rows(); i++ {
+					if meta.Totals() {
+						totals = append(totals, c.Row(i))
+					} else {
+						rows = append(rows, c.Row(i))
+					}
+				}
+				return nil
+			},
+		}))
+		require.Equal(t, []uint64{5, 5}, rows)
+		require.Equal(t, []uint64{10}, totals)
+	})
+	t.Run("WithOnResult", func(t *testing.T) {
+		require.Error(t, conn.Do(ctx, Query{
+			Body: "SELECT 1",
+			OnResult: func(ctx context.Context, block proto.Block) error {
+				return nil
+			},
+			OnResultBlock: func(ctx context.Context, meta proto.BlockMeta, block proto.Block) error {
+				return nil
+			},
+		}))
+	})
+}
@@ -0,0 +1,126 @@
+package ch
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// queryBodyChunk is maximum size of body part that is read from
+// Query.BodyReader and written to connection at once.
+const queryBodyChunk = 1 << 20
+
+// bodySize returns size of Query.BodyReader.
+func (q Query) bodySize() (int, error) {
+	if q.BodySize > 0 {
+		return q.BodySize, nil
+	}
+	if l, ok := q.BodyReader.(interface{ Len() int }); ok && l.Len() > 0 {
+		return l.Len(), nil
+	}
+	return 0, errors.New("BodySize is required")
+}
+
+// sendQueryBody writes query packet with body streamed from
+// Query.BodyReader directly to connection.
+//
+// Connection is closed if packet is partially written, as it can't be
+// used anymore.
+func (c *Client) sendQueryBody(ctx context.Context, query proto.Query, q Query) (rErr error) {
+	size, err := q.bodySize()
+	if err != nil {
+		return err
+	}
+	// Previous packets are written as is.
+	if err := c.flush(ctx); err != nil {
+		return errors.Wrap(err, "flush")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetWriteDeadline(deadline); err != nil {
+			return errors.Wrap(err, "set write deadline")
+		}
+		defer func() { _ = c.conn.SetWriteDeadline(time.Time{}) }()
+	}
+	defer func() {
+		if rErr != nil {
+			_ = c.Close()
+		}
+	}()
+
+	query.EncodeBeforeBody(c.buf, c.protocolVersion)
+	c.buf.PutUVarInt(uint64(size))
+	if err := c.writePart(ctx, c.buf.Buf, false); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	c.buf.Reset()
+
+	chunk := make([]byte, min(size, queryBodyChunk))
+	for left := size; left > 0; {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "context")
+		}
+		n, err := io.ReadFull(q.BodyReader, chunk[:min(left, len(chunk))])
+		if err != nil {
+			return errors.Wrap(err, "read")
+		}
+		if err := c.writePart(ctx, chunk[:n], false); err != nil {
+			return errors.Wrap(err, "write")
+		}
+		left -= n
+	}
+
+	query.EncodeAfterBody(c.buf, c.protocolVersion)
+	if err := c.writePart(ctx, c.buf.Buf, true); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	c.buf.Reset()
+	return nil
+}
+
+// writePart writes part of packet to connection, as separate chunk if
+// chunked framing is enabled.
+func (c *Client) writePart(ctx context.Context, data []byte, last bool) error {
+	if c.chunkedSend && len(data) > 0 {
+		var header [4]byte
+		binary.LittleEndian.PutUint32(header[:], uint32(len(data)))
+		if err := c.write(ctx, header[:]); err != nil {
+			return err
+		}
+	}
+	if err := c.write(ctx, data); err != nil {
+		return err
+	}
+	if c.chunkedSend && last {
+		// End of packet.
+		return c.write(ctx, make([]byte, 4))
+	}
+	return nil
+}
+
+// write writes data to connection until earliest of context deadline and
+// write timeout, restoring context deadline after that.
+func (c *Client) write(ctx context.Context, data []byte) error {
+	if deadline, ok := c.writeDeadline(ctx); ok {
+		if err := c.conn.SetWriteDeadline(deadline); err != nil {
+			return errors.Wrap(err, "set write deadline")
+		}
+		defer func() {
+			deadline, _ := ctx.Deadline()
+			_ = c.conn.SetWriteDeadline(deadline)
+		}()
+	}
+	n, err := c.conn.Write(data)
+	c.stats.bytesSent.Add(uint64(n))
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return io.ErrShortWrite
+	}
+	return nil
+}
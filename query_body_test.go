@@ -0,0 +1,127 @@
+package ch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// recordConn records written data.
+type recordConn struct {
+	net.Conn
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (c *recordConn) Write(b []byte) (int, error) { return c.buf.Write(b) }
+func (c *recordConn) Close() error                { c.closed = true; return nil }
+
+func TestClient_sendQueryBody(t *testing.T) {
+	ctx := context.Background()
+	query := proto.Query{
+		ID:         "id",
+		Body:       strings.Repeat("SELECT 1 UNION ALL ", queryBodyChunk/10) + "SELECT 2",
+		Settings:   []proto.Setting{{Key: "foo", Value: "bar"}},
+		Parameters: []proto.Parameter{{Key: "p", Value: "'v'"}},
+	}
+	var expected proto.Buffer
+	query.EncodeAware(&expected, proto.Version)
+
+	for _, chunked := range []bool{false, true} {
+		conn := new(recordConn)
+		c := &Client{
+			conn:            conn,
+			buf:             new(proto.Buffer),
+			protocolVersion: proto.Version,
+			chunkedSend:     chunked,
+			stats:           new(clientStats),
+		}
+		body := query
+		body.Body = ""
+		require.NoError(t, c.sendQueryBody(ctx, body, Query{
+			BodyReader: iotest.HalfReader(strings.NewReader(query.Body)),
+			BodySize:   len(query.Body),
+		}))
+		require.Empty(t, c.buf.Buf)
+		require.False(t, conn.closed)
+		require.Equal(t, uint64(conn.buf.Len()), c.stats.bytesSent.Load())
+
+		var data []byte
+		if chunked {
+			r := proto.NewChunkedReader(&conn.buf)
+			r.Enable()
+			var err error
+			data, err = io.ReadAll(r)
+			require.NoError(t, err)
+		} else {
+			data = conn.buf.Bytes()
+		}
+		require.Equal(t, expected.Buf, data, "chunked: %v", chunked)
+	}
+
+	t.Run("Size", func(t *testing.T) {
+		size, err := Query{BodyReader: strings.NewReader("SELECT 1")}.bodySize()
+		require.NoError(t, err)
+		require.Equal(t, 8, size)
+
+		_, err = Query{BodyReader: iotest.HalfReader(strings.NewReader("SELECT 1"))}.bodySize()
+		require.Error(t, err)
+	})
+	t.Run("Short", func(t *testing.T) {
+		conn := new(recordConn)
+		c := &Client{
+			conn:            conn,
+			buf:             new(proto.Buffer),
+			protocolVersion: proto.Version,
+			stats:           new(clientStats),
+		}
+		err := c.sendQueryBody(ctx, proto.Query{}, Query{
+			BodyReader: strings.NewReader("SELECT"),
+			BodySize:   100,
+		})
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		require.True(t, conn.closed, "partially written packet should close connection")
+	})
+}
+
+func TestClient_Do_bodyReader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_body_reader (v UInt64) ENGINE = Memory",
+	}))
+
+	// INSERT with large VALUES list.
+	var b bytes.Buffer
+	b.WriteString("INSERT INTO test_body_reader VALUES ")
+	const rows = 200_000
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("(1)")
+	}
+	require.NoError(t, conn.Do(ctx, Query{BodyReader: &b}))
+
+	var count proto.ColUInt64
+	require.NoError(t, conn.Do(ctx, Query{
+		BodyReader: strings.NewReader("SELECT count() AS c FROM test_body_reader"),
+		Result:     proto.Results{{Name: "c", Data: &count}},
+	}))
+	require.Equal(t, uint64(rows), count.Row(0))
+
+	require.Error(t, conn.Do(ctx, Query{
+		Body:       "SELECT 1",
+		BodyReader: strings.NewReader("SELECT 1"),
+	}))
+}
@@ -0,0 +1,25 @@
+package ch
+
+import "encoding/json"
+
+// logComment returns value of log_comment setting for query.
+func (q Query) logComment() string {
+	if len(q.Tags) == 0 {
+		return q.Comment
+	}
+	tags := q.Tags
+	if q.Comment != "" {
+		tags = make(map[string]string, len(q.Tags)+1)
+		for k, v := range q.Tags {
+			tags[k] = v
+		}
+		tags["comment"] = q.Comment
+	}
+	// Map keys are sorted by encoding/json, so output is deterministic.
+	data, err := json.Marshal(tags)
+	if err != nil {
+		// Not possible for map[string]string.
+		return q.Comment
+	}
+	return string(data)
+}
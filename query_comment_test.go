@@ -0,0 +1,55 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestQuery_logComment(t *testing.T) {
+	for _, tt := range []struct {
+		Name  string
+		Query Query
+		Value string
+	}{
+		{Name: "Blank"},
+		{Name: "Comment", Query: Query{Comment: "hello"}, Value: "hello"},
+		{
+			Name:  "Tags",
+			Query: Query{Tags: map[string]string{"service": "api", "endpoint": "/users"}},
+			Value: `{"endpoint":"/users","service":"api"}`,
+		},
+		{
+			Name:  "Both",
+			Query: Query{Comment: "hello", Tags: map[string]string{"service": "api"}},
+			Value: `{"comment":"hello","service":"api"}`,
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Value, tt.Query.logComment())
+
+			settings := new(Client).querySettings(tt.Query)
+			if tt.Value == "" {
+				require.Empty(t, settings)
+			} else {
+				require.Equal(t, []proto.Setting{{Key: "log_comment", Value: tt.Value}}, settings)
+			}
+		})
+	}
+}
+
+func TestClient_Do_logComment(t *testing.T) {
+	ctx := context.Background()
+	conn := Conn(t)
+
+	var comment proto.ColStr
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT getSetting('log_comment') as comment",
+		Tags:   map[string]string{"service": "test"},
+		Result: proto.Results{{Name: "comment", Data: &comment}},
+	}))
+	require.Equal(t, `{"service":"test"}`, comment.Row(0))
+}
@@ -0,0 +1,30 @@
+package ch
+
+import (
+	"context"
+	"time"
+)
+
+// executionTimeFromDeadline returns execution time of query derived from
+// context deadline or zero if it should not be set.
+func (c *Client) executionTimeFromDeadline(ctx context.Context, q Query) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	for _, s := range q.Settings {
+		if s.Key == "max_execution_time" {
+			// Explicitly set.
+			return 0
+		}
+	}
+	d := time.Until(deadline).Truncate(time.Millisecond)
+	if d <= 0 {
+		// Context will be done before server is reached.
+		return 0
+	}
+	if c.maxExecutionTime > 0 && c.maxExecutionTime <= d {
+		return 0
+	}
+	return d
+}
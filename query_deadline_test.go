@@ -0,0 +1,51 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_executionTimeFromDeadline(t *testing.T) {
+	c := &Client{}
+	require.Zero(t, c.executionTimeFromDeadline(context.Background(), Query{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	d := c.executionTimeFromDeadline(ctx, Query{})
+	require.Greater(t, d, 59*time.Second)
+	require.LessOrEqual(t, d, time.Minute)
+
+	require.Zero(t, c.executionTimeFromDeadline(ctx, Query{
+		Settings: []Setting{SettingMaxExecutionTime(time.Hour)},
+	}), "explicit setting")
+
+	c.maxExecutionTime = time.Second
+	require.Zero(t, c.executionTimeFromDeadline(ctx, Query{}), "guard is shorter")
+	c.maxExecutionTime = time.Hour
+	require.NotZero(t, c.executionTimeFromDeadline(ctx, Query{}))
+
+	settings := c.querySettings(Query{executionTime: 1500 * time.Millisecond})
+	require.Equal(t, []proto.Setting{
+		{Key: "max_execution_time", Value: "1.5", Important: true},
+		{Key: "timeout_before_checking_execution_speed", Value: "1.5", Important: true},
+	}, settings)
+}
+
+func TestClient_Do_deadlineExecutionTime(t *testing.T) {
+	t.Parallel()
+	conn := ConnOpt(t, Options{DeadlineExecutionTime: true})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var value proto.ColStr
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT toString(getSetting('max_execution_time')) AS v",
+		Result: proto.Results{{Name: "v", Data: &value}},
+	}))
+	require.NotEqual(t, "0", value.Row(0))
+}
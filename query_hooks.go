@@ -0,0 +1,35 @@
+package ch
+
+import (
+	"context"
+	"time"
+)
+
+// QueryTimings is timing breakdown of query execution.
+type QueryTimings struct {
+	// Start of query execution.
+	Start time.Time
+	// Send is duration from Start until query and all input is sent.
+	//
+	// Zero if sending failed.
+	Send time.Duration
+	// FirstBlock is duration from Start until first data block received.
+	//
+	// Zero if no data blocks were received.
+	FirstBlock time.Duration
+	// Cancel is duration of query cancellation.
+	//
+	// Zero if query was not canceled.
+	Cancel time.Duration
+	// Total duration of query execution.
+	Total time.Duration
+}
+
+// OnQueryStart is called before query is sent to server.
+//
+// Query.QueryID is always set.
+type OnQueryStart func(ctx context.Context, q Query)
+
+// OnQueryEnd is called when query is done, with err being the result
+// of query execution.
+type OnQueryEnd func(ctx context.Context, q Query, t QueryTimings, err error)
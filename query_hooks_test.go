@@ -0,0 +1,48 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_Do_hooks(t *testing.T) {
+	ctx := context.Background()
+	var (
+		started  []string
+		ended    []string
+		timings  QueryTimings
+		endedErr error
+	)
+	conn := ConnOpt(t, Options{
+		OnQueryStart: func(ctx context.Context, q Query) {
+			started = append(started, q.QueryID)
+		},
+		OnQueryEnd: func(ctx context.Context, q Query, t QueryTimings, err error) {
+			ended = append(ended, q.QueryID)
+			timings = t
+			endedErr = err
+		},
+	})
+
+	var data proto.ColUInt8
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:    "SELECT 1 as v",
+		QueryID: "hooks",
+		Result:  proto.Results{{Name: "v", Data: &data}},
+	}))
+	require.Equal(t, []string{"hooks"}, started)
+	require.Equal(t, []string{"hooks"}, ended)
+	require.NoError(t, endedErr)
+	require.Positive(t, timings.Send)
+	require.Positive(t, timings.FirstBlock)
+	require.GreaterOrEqual(t, timings.Total, timings.FirstBlock)
+	require.Zero(t, timings.Cancel)
+
+	require.Error(t, conn.Do(ctx, Query{Body: "SELECT invalid"}))
+	require.Len(t, ended, 2)
+	require.True(t, IsErr(endedErr, proto.ErrUnknownIdentifier))
+}
@@ -0,0 +1,128 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/atomic"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ErrResultLimit means that query result exceeds Query.MaxResultRows,
+// Query.MaxResultBytes or Options.MaxResultMemory, can be matched by
+// errors.Is.
+var ErrResultLimit = errors.New("result limit exceeded")
+
+// ResultLimitError is returned by Client.Do if query result exceeds
+// Query.MaxResultRows, Query.MaxResultBytes or Options.MaxResultMemory.
+//
+// Query is canceled on server, so client can be used for next queries.
+type ResultLimitError struct {
+	Rows  int // rows received, including block that exceeded limit
+	Bytes int // bytes received, including block that exceeded limit
+
+	Memory int // bytes retained by client, if MaxMemory is set
+
+	MaxRows   int
+	MaxBytes  int
+	MaxMemory int
+}
+
+func (e *ResultLimitError) Error() string {
+	if e.MaxRows > 0 && e.Rows > e.MaxRows {
+		return fmt.Sprintf("result limit exceeded: %d rows (max %d)", e.Rows, e.MaxRows)
+	}
+	if e.MaxMemory > 0 && e.Memory > e.MaxMemory {
+		return fmt.Sprintf("result memory limit exceeded: %d bytes (max %d)", e.Memory, e.MaxMemory)
+	}
+	return fmt.Sprintf("result limit exceeded: %d bytes (max %d)", e.Bytes, e.MaxBytes)
+}
+
+// Is reports whether err is ErrResultLimit.
+func (e *ResultLimitError) Is(err error) bool {
+	return err == ErrResultLimit
+}
+
+// resultLimit tracks size of query result.
+type resultLimit struct {
+	maxRows   int
+	maxBytes  int
+	maxMemory int
+
+	rows int
+	// Bytes are counted as read from connection since query start.
+	received *atomic.Uint64
+	start    uint64
+
+	// Memory is counted as retained buffers of reader and largest decoded
+	// block, as capacity of result columns is kept between blocks.
+	reader    *proto.Reader
+	read      int64
+	peakBlock int
+}
+
+// newResultLimit returns limit of query result, or nil if not limited.
+func (c *Client) newResultLimit(q Query) *resultLimit {
+	if q.MaxResultRows <= 0 && q.MaxResultBytes <= 0 && c.maxResultMemory <= 0 {
+		return nil
+	}
+	return &resultLimit{
+		maxRows:   q.MaxResultRows,
+		maxBytes:  q.MaxResultBytes,
+		maxMemory: c.maxResultMemory,
+		received:  &c.stats.bytesReceived,
+		start:     c.stats.bytesReceived.Load(),
+		reader:    c.reader,
+		read:      c.reader.BytesRead(),
+	}
+}
+
+// add accounts block of rows and checks limits.
+func (l *resultLimit) add(rows int) error {
+	l.rows += rows
+	bytes := int(l.received.Load() - l.start)
+	var memory int
+	if l.maxMemory > 0 {
+		read := l.reader.BytesRead()
+		if block := int(read - l.read); block > l.peakBlock {
+			l.peakBlock = block
+		}
+		l.read = read
+		memory = l.reader.Retained() + l.peakBlock
+	}
+	if (l.maxRows > 0 && l.rows > l.maxRows) ||
+		(l.maxBytes > 0 && bytes > l.maxBytes) ||
+		(l.maxMemory > 0 && memory > l.maxMemory) {
+		return &ResultLimitError{
+			Rows:      l.rows,
+			Bytes:     bytes,
+			Memory:    memory,
+			MaxRows:   l.maxRows,
+			MaxBytes:  l.maxBytes,
+			MaxMemory: l.maxMemory,
+		}
+	}
+	return nil
+}
+
+// Handler wraps result handler, checking limits before calling it.
+func (l *resultLimit) Handler(f func(ctx context.Context, b proto.Block) error) func(ctx context.Context, b proto.Block) error {
+	return func(ctx context.Context, b proto.Block) error {
+		if err := l.add(b.Rows); err != nil {
+			return err
+		}
+		return f(ctx, b)
+	}
+}
+
+// RawHandler wraps raw block handler, checking limits before calling it.
+func (l *resultLimit) RawHandler(f func(ctx context.Context, b RawBlock) error) func(ctx context.Context, b RawBlock) error {
+	return func(ctx context.Context, b RawBlock) error {
+		if err := l.add(b.Header.Rows); err != nil {
+			return err
+		}
+		return f(ctx, b)
+	}
+}
@@ -0,0 +1,163 @@
+package ch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestResultLimit(t *testing.T) {
+	t.Run("Rows", func(t *testing.T) {
+		l := &resultLimit{maxRows: 10, received: new(atomic.Uint64)}
+		require.NoError(t, l.add(5))
+		require.NoError(t, l.add(5))
+
+		err := l.add(1)
+		require.ErrorIs(t, err, ErrResultLimit)
+		var limitErr *ResultLimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, 11, limitErr.Rows)
+		require.EqualError(t, err, "result limit exceeded: 11 rows (max 10)")
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		received := atomic.NewUint64(100)
+		l := &resultLimit{maxBytes: 50, received: received, start: received.Load()}
+		received.Add(50)
+		require.NoError(t, l.add(1))
+		received.Add(1)
+		err := l.add(0)
+		require.ErrorIs(t, err, ErrResultLimit)
+		require.EqualError(t, err, "result limit exceeded: 51 bytes (max 50)")
+	})
+	t.Run("Memory", func(t *testing.T) {
+		var b proto.Buffer
+		b.PutString(strings.Repeat("x", 1024))
+		r := b.Reader()
+		l := &resultLimit{
+			maxMemory: r.Retained() + 512,
+			received:  new(atomic.Uint64),
+			reader:    r,
+		}
+		require.NoError(t, l.add(0))
+		_, err := r.Str()
+		require.NoError(t, err)
+
+		err = l.add(1)
+		require.ErrorIs(t, err, ErrResultLimit)
+		var limitErr *ResultLimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.Greater(t, limitErr.Memory, limitErr.MaxMemory)
+		require.Contains(t, err.Error(), "result memory limit exceeded")
+	})
+	t.Run("Handler", func(t *testing.T) {
+		l := &resultLimit{maxRows: 1, received: new(atomic.Uint64)}
+		var called int
+		h := l.Handler(func(ctx context.Context, b proto.Block) error {
+			called++
+			return nil
+		})
+		require.NoError(t, h(context.Background(), proto.Block{Rows: 1}))
+		require.ErrorIs(t, h(context.Background(), proto.Block{Rows: 1}), ErrResultLimit)
+		require.Equal(t, 1, called, "handler should not be called after limit")
+	})
+}
+
+func TestClient_Do_maxResultRows(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	var data proto.ColUInt64
+	err := conn.Do(ctx, Query{
+		Body:          "SELECT number FROM system.numbers",
+		Settings:      []Setting{SettingInt("max_block_size", 100)},
+		Result:        proto.Results{{Name: "number", Data: &data}},
+		MaxResultRows: 250,
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, ErrResultLimit)
+	var limitErr *ResultLimitError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, 300, limitErr.Rows)
+
+	// Connection is still usable.
+	require.False(t, conn.IsClosed())
+	var one proto.ColUInt8
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT 1 AS one",
+		Result: proto.Results{{Name: "one", Data: &one}},
+	}))
+	require.Equal(t, uint8(1), one.Row(0))
+}
+
+func TestClient_Do_maxResultBytes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	var data proto.ColStr
+	err := conn.Do(ctx, Query{
+		Body:           "SELECT repeat('x', 1024) AS s FROM system.numbers",
+		Result:         proto.Results{{Name: "s", Data: &data}},
+		MaxResultBytes: 1 << 20,
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			data.Reset()
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, ErrResultLimit)
+	require.False(t, conn.IsClosed())
+	require.NoError(t, conn.Ping(ctx))
+}
+
+func TestClient_Do_maxResultMemory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		MaxResultMemory: 1 << 20,
+	})
+
+	var data proto.ColStr
+	err := conn.Do(ctx, Query{
+		Body:   "SELECT repeat('x', 1024 * 1024) AS s FROM system.numbers LIMIT 10",
+		Result: proto.Results{{Name: "s", Data: &data}},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, ErrResultLimit)
+	require.False(t, conn.IsClosed())
+
+	// Small results fit into budget.
+	var one proto.ColUInt8
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT 1 AS one",
+		Result: proto.Results{{Name: "one", Data: &one}},
+	}))
+	require.Equal(t, uint8(1), one.Row(0))
+}
+
+func TestClient_Do_decodeLimits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := ConnOpt(t, Options{
+		DecodeLimits: proto.Limits{MaxStrLen: 1024},
+	})
+
+	var data proto.ColStr
+	err := conn.Do(ctx, Query{
+		Body:   "SELECT repeat('x', 2048) AS s",
+		Result: proto.Results{{Name: "s", Data: &data}},
+	})
+	var limitErr *proto.LimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "MaxStrLen", limitErr.Limit)
+}
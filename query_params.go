@@ -9,14 +9,14 @@ import (
 
 // Parameters is helper for building Query.Parameters.
 //
+// Values are formatted by fmt.Sprint as strings, use proto.Param and
+// related constructors for typed values, like arrays or maps.
+//
 // EXPERIMENTAL.
 func Parameters(m map[string]any) []proto.Parameter {
 	var out []proto.Parameter
 	for k, v := range m {
-		out = append(out, proto.Parameter{
-			Key:   k,
-			Value: fmt.Sprintf("'%v'", v),
-		})
+		out = append(out, proto.ParamString(k, fmt.Sprint(v)))
 	}
 	// Sorting to make output deterministic.
 	sort.Slice(out, func(i, j int) bool {
@@ -3,6 +3,7 @@ package ch
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -22,3 +23,37 @@ func TestQueryParameters(t *testing.T) {
 		Result: discardResult(),
 	}))
 }
+
+func TestQueryParameters_typed(t *testing.T) {
+	conn := Conn(t)
+	SkipNoFeature(t, conn, proto.FeatureParameters)
+	ctx := context.Background()
+	ts := time.Unix(1700000000, 123_000_000)
+
+	var (
+		str   proto.ColStr
+		arr   = proto.NewArray[string](new(proto.ColStr))
+		dt    proto.ColDateTime64
+		count proto.ColUInt64
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT {str:String} s, {arr:Array(String)} a, {dt:DateTime64(3)} dt, " +
+			"(SELECT count() FROM system.{table:Identifier}) c",
+		Parameters: []proto.Parameter{
+			proto.ParamString("str", "it's\\n"),
+			proto.ParamArray("arr", proto.LitString("a'b"), proto.LitString("c")),
+			proto.ParamDateTime64("dt", ts, proto.PrecisionMilli),
+			proto.ParamIdentifier("table", "one"),
+		},
+		Result: proto.Results{
+			{Name: "s", Data: &str},
+			{Name: "a", Data: arr},
+			{Name: "dt", Data: &dt},
+			{Name: "c", Data: &count},
+		},
+	}))
+	require.Equal(t, "it's\\n", str.Row(0))
+	require.Equal(t, []string{"a'b", "c"}, arr.Row(0))
+	require.True(t, ts.Equal(dt.Row(0)))
+	require.Equal(t, uint64(1), count.Row(0))
+}
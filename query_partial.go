@@ -0,0 +1,50 @@
+package ch
+
+import (
+	"context"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// partialResult dispatches blocks of query with partial results.
+//
+// Server sends header block, then partial result blocks, then block with
+// zero rows that separates them from final result.
+type partialResult struct {
+	onPartial func(ctx context.Context, block proto.Block) error
+	onResult  func(ctx context.Context, block proto.Block) error
+
+	header bool // header block received
+	final  bool // partial results are done
+}
+
+func newPartialResult(onPartial, onResult func(ctx context.Context, block proto.Block) error) *partialResult {
+	return &partialResult{
+		onPartial: onPartial,
+		onResult:  onResult,
+	}
+}
+
+// Handle handles data block.
+func (p *partialResult) Handle(ctx context.Context, block proto.Block) error {
+	switch {
+	case p.final:
+		return p.onResult(ctx, block)
+	case !p.header && block.Rows == 0:
+		p.header = true
+		return p.onResult(ctx, block)
+	case block.Rows == 0:
+		p.final = true
+		return nil
+	default:
+		p.header = true
+		return p.onPartial(ctx, block)
+	}
+}
+
+// End handles blank block.
+func (p *partialResult) End(ctx context.Context) error {
+	p.header = true
+	p.final = true
+	return nil
+}
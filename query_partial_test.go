@@ -0,0 +1,50 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestPartialResult(t *testing.T) {
+	ctx := context.Background()
+	var partial, final []int
+	p := newPartialResult(
+		func(ctx context.Context, block proto.Block) error {
+			partial = append(partial, block.Rows)
+			return nil
+		},
+		func(ctx context.Context, block proto.Block) error {
+			final = append(final, block.Rows)
+			return nil
+		},
+	)
+	for _, rows := range []int{0, 1, 2, 0, 3, 4} {
+		require.NoError(t, p.Handle(ctx, proto.Block{Columns: 1, Rows: rows}))
+	}
+	require.Equal(t, []int{1, 2}, partial)
+	require.Equal(t, []int{0, 3, 4}, final)
+
+	t.Run("End", func(t *testing.T) {
+		partial, final = nil, nil
+		p := newPartialResult(p.onPartial, p.onResult)
+		require.NoError(t, p.Handle(ctx, proto.Block{Columns: 1, Rows: 0}))
+		require.NoError(t, p.Handle(ctx, proto.Block{Columns: 1, Rows: 5}))
+		require.NoError(t, p.End(ctx))
+		require.NoError(t, p.Handle(ctx, proto.Block{Columns: 1, Rows: 6}))
+		require.Equal(t, []int{5}, partial)
+		require.Equal(t, []int{0, 6}, final)
+	})
+}
+
+func TestClient_Do_partialResult(t *testing.T) {
+	t.Parallel()
+	conn := Conn(t)
+	require.Error(t, conn.Do(context.Background(), Query{
+		Body:                  "SELECT 1",
+		PartialResultInterval: 1,
+	}), "OnPartialResult is required")
+}
@@ -0,0 +1,84 @@
+package ch
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// PreparedQuery is reusable template of query, see Client.Prepare.
+//
+// Can be executed repeatedly with different parameters, reusing validated
+// placeholders, settings and logger of query.
+type PreparedQuery struct {
+	client *Client
+	body   string
+	// Types of {name:Type} placeholders.
+	params   map[string]string
+	settings []proto.Setting
+	lg       *zap.Logger
+}
+
+// Prepare validates {name:Type} placeholders of query body and returns
+// query template that can be executed by PreparedQuery.Do.
+//
+// Template is bound to client and is not valid after client is closed.
+func (c *Client) Prepare(body string) (*PreparedQuery, error) {
+	if body == "" {
+		return nil, errors.New("empty query")
+	}
+	params := make(map[string]string)
+	if _, err := replacePlaceholders(body, func(name, typ string) (string, error) {
+		if v, ok := params[name]; ok && v != typ {
+			return "", errors.Errorf("parameter %q has conflicting types %s and %s", name, v, typ)
+		}
+		params[name] = typ
+		return "", nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "placeholders")
+	}
+	if len(params) > 0 && !c.Supports(proto.FeatureParameters) && !c.bindParameters {
+		return nil, errors.Errorf("query parameters are not supported in protocol version %d, upgrade server %q",
+			c.protocolVersion, c.server,
+		)
+	}
+	return &PreparedQuery{
+		client:   c,
+		body:     body,
+		params:   params,
+		settings: c.clientSettings(),
+		lg:       c.lg.With(zap.String("query", body)),
+	}, nil
+}
+
+// Body of prepared query.
+func (p *PreparedQuery) Body() string { return p.body }
+
+// Do executes prepared query with parameters and handlers from q, which
+// should not have Body.
+//
+// All placeholders should be bound by q.Parameters.
+func (p *PreparedQuery) Do(ctx context.Context, q Query) error {
+	if q.Body != "" && q.Body != p.body {
+		return errors.New("query body is already prepared")
+	}
+	if len(q.Parameters) != len(p.params) {
+		return errors.Errorf("got %d parameters instead of %d", len(q.Parameters), len(p.params))
+	}
+	for i, v := range q.Parameters {
+		if _, ok := p.params[v.Key]; !ok {
+			return errors.Errorf("unknown parameter %q", v.Key)
+		}
+		for _, prev := range q.Parameters[:i] {
+			if prev.Key == v.Key {
+				return errors.Errorf("duplicate parameter %q", v.Key)
+			}
+		}
+	}
+	q.Body = p.body
+	q.prepared = p
+	return p.client.Do(ctx, q)
+}
@@ -0,0 +1,52 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_Prepare(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+	SkipNoFeature(t, conn, proto.FeatureParameters)
+
+	_, err := conn.Prepare("SELECT {v:UInt8}, {v:String}")
+	require.Error(t, err)
+
+	q, err := conn.Prepare("SELECT {v:UInt64} + 1 AS v")
+	require.NoError(t, err)
+	for i := uint64(0); i < 3; i++ {
+		var data proto.ColUInt64
+		require.NoError(t, q.Do(ctx, Query{
+			Parameters: []proto.Parameter{proto.ParamUInt("v", i)},
+			Result:     proto.Results{{Name: "v", Data: &data}},
+		}))
+		require.Equal(t, i+1, data.Row(0))
+	}
+
+	require.Error(t, q.Do(ctx, Query{}), "missing parameter")
+	require.Error(t, q.Do(ctx, Query{
+		Parameters: []proto.Parameter{proto.ParamUInt("x", 1)},
+	}), "unknown parameter")
+	require.Error(t, q.Do(ctx, Query{
+		Body:       "SELECT 1",
+		Parameters: []proto.Parameter{proto.ParamUInt("v", 1)},
+	}), "body")
+}
+
+func TestClient_querySettings_prepared(t *testing.T) {
+	c := &Client{settings: []Setting{SettingInt("max_threads", 1)}}
+	p := &PreparedQuery{settings: c.clientSettings()}
+	s := c.querySettings(Query{
+		prepared: p,
+		Settings: []Setting{SettingInt("max_block_size", 10)},
+	})
+	require.Len(t, s, 2)
+	require.Len(t, p.settings, 1, "cached settings are not changed")
+	require.Equal(t, c.querySettings(Query{Settings: []Setting{SettingInt("max_block_size", 10)}}), s)
+}
@@ -0,0 +1,51 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// progressThrottle coalesces progress increments, calling handler at most
+// once per interval, see Query.ProgressInterval.
+type progressThrottle struct {
+	interval time.Duration
+	handler  func(ctx context.Context, p proto.Progress) error
+	now      func() time.Time
+
+	last    time.Time
+	pending proto.Progress
+	hasData bool
+}
+
+func newProgressThrottle(interval time.Duration, handler func(ctx context.Context, p proto.Progress) error) *progressThrottle {
+	return &progressThrottle{
+		interval: interval,
+		handler:  handler,
+		now:      time.Now,
+	}
+}
+
+// Handle accumulates progress increment, calling handler if interval
+// since last call is elapsed.
+func (t *progressThrottle) Handle(ctx context.Context, p proto.Progress) error {
+	t.pending = t.pending.Add(p)
+	t.hasData = true
+	if now := t.now(); t.last.IsZero() || now.Sub(t.last) >= t.interval {
+		t.last = now
+		return t.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush calls handler with accumulated progress, if any.
+func (t *progressThrottle) Flush(ctx context.Context) error {
+	if !t.hasData {
+		return nil
+	}
+	p := t.pending
+	t.pending = proto.Progress{}
+	t.hasData = false
+	return t.handler(ctx, p)
+}
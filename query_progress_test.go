@@ -0,0 +1,72 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestProgressThrottle(t *testing.T) {
+	ctx := context.Background()
+	var got []proto.Progress
+	p := newProgressThrottle(time.Second, func(ctx context.Context, p proto.Progress) error {
+		got = append(got, p)
+		return nil
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	// First update is passed as is.
+	require.NoError(t, p.Handle(ctx, proto.Progress{Rows: 1}))
+	require.Len(t, got, 1)
+
+	// Updates within interval are coalesced.
+	now = now.Add(100 * time.Millisecond)
+	require.NoError(t, p.Handle(ctx, proto.Progress{Rows: 2, TotalRows: 10}))
+	now = now.Add(100 * time.Millisecond)
+	require.NoError(t, p.Handle(ctx, proto.Progress{Rows: 3}))
+	require.Len(t, got, 1)
+
+	now = now.Add(time.Second)
+	require.NoError(t, p.Handle(ctx, proto.Progress{Rows: 4}))
+	require.Len(t, got, 2)
+	require.Equal(t, proto.Progress{Rows: 9, TotalRows: 10}, got[1])
+
+	// Remaining progress is flushed.
+	require.NoError(t, p.Flush(ctx))
+	require.Len(t, got, 2)
+	require.NoError(t, p.Handle(ctx, proto.Progress{Rows: 5}))
+	require.NoError(t, p.Flush(ctx))
+	require.Len(t, got, 3)
+	require.Equal(t, proto.Progress{Rows: 5}, got[2])
+}
+
+func TestClient_Do_progressInterval(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	var (
+		acc   proto.ProgressAccumulator
+		calls int
+		data  proto.ColUInt64
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT sum(number) AS s FROM numbers(10000000)",
+		Result: proto.Results{{Name: "s", Data: &data}},
+		OnProgress: func(ctx context.Context, p proto.Progress) error {
+			calls++
+			acc.Add(p)
+			return nil
+		},
+		ProgressInterval: time.Hour,
+	}))
+	// First update and remaining progress at the end of query.
+	require.LessOrEqual(t, calls, 2)
+	require.Equal(t, uint64(10000000), acc.Total.Rows)
+	require.Equal(t, float64(100), acc.Percent())
+}
@@ -0,0 +1,77 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+// ErrQueryStalled means that nothing was received from server during
+// stall timeout, so query was canceled, see Query.StallTimeout.
+//
+// Classified as ErrNetwork, so query can be retried on other replica.
+var ErrQueryStalled = errors.New("query stalled")
+
+// stallTimeout returns stall timeout of query, zero if disabled.
+func (c *Client) stallTimeout(q Query) time.Duration {
+	d := c.defaultStallTimeout
+	if q.StallTimeout != 0 {
+		d = q.StallTimeout
+	}
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// watchStall returns ErrQueryStalled if nothing is received from server
+// for d after query is sent, until done is closed.
+//
+// Any received data, like progress, logs or part of block, is activity,
+// so slow but steady query is not stalled.
+func (c *Client) watchStall(ctx context.Context, sent, done <-chan struct{}, d time.Duration) error {
+	select {
+	case <-sent:
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+
+	ticker := time.NewTicker(d / 4)
+	defer ticker.Stop()
+	var (
+		received = c.stats.bytesReceived.Load()
+		last     = time.Now()
+	)
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if n := c.stats.bytesReceived.Load(); n != received {
+				received, last = n, now
+				continue
+			}
+			if stalled := now.Sub(last); stalled >= d {
+				// Interrupting blocked read, so query is canceled without
+				// waiting for read timeout. Flag is set before deadline, so
+				// packet restores deadline if it resets it concurrently.
+				c.stalled.Store(true)
+				_ = c.conn.SetReadDeadline(now)
+				return errors.Wrapf(ErrQueryStalled, "nothing received for %s", stalled.Round(time.Millisecond))
+			}
+		}
+	}
+}
+
+// stallErr returns ErrQueryStalled if current query is stalled.
+func (c *Client) stallErr() error {
+	if c.stalled.Load() {
+		return errors.Wrap(ErrQueryStalled, "canceled by watchdog")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package ch
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_stallTimeout(t *testing.T) {
+	c := &Client{defaultStallTimeout: time.Second}
+	require.Equal(t, time.Second, c.stallTimeout(Query{}))
+	require.Equal(t, time.Minute, c.stallTimeout(Query{StallTimeout: time.Minute}))
+	require.Zero(t, c.stallTimeout(Query{StallTimeout: NoTimeout}))
+	require.Zero(t, (&Client{}).stallTimeout(Query{}))
+}
+
+func TestClient_watchStall(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(t *testing.T) *Client {
+		conn, server := net.Pipe()
+		t.Cleanup(func() {
+			_ = conn.Close()
+			_ = server.Close()
+		})
+		return &Client{conn: conn, stats: new(clientStats)}
+	}
+	const d = time.Millisecond * 40
+	t.Run("Stalled", func(t *testing.T) {
+		c := newClient(t)
+		sent := make(chan struct{})
+		close(sent)
+		require.ErrorIs(t, c.watchStall(ctx, sent, make(chan struct{}), d), ErrQueryStalled)
+	})
+	t.Run("Packet", func(t *testing.T) {
+		// Stall is detected before receiver sets its read deadline, so
+		// deadline of watchdog is overwritten.
+		c := newClient(t)
+		c.reader = proto.NewReader(c.conn)
+		c.queryReadTimeout = time.Minute
+		c.stalled.Store(true)
+		_, err := c.packet(ctx)
+		require.ErrorIs(t, err, ErrQueryStalled)
+
+		// Deadline is restored after reset, so reads of block are
+		// interrupted too.
+		_, err = c.conn.Read(make([]byte, 1))
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		require.True(t, netErr.Timeout())
+	})
+	t.Run("Active", func(t *testing.T) {
+		c := newClient(t)
+		sent, done := make(chan struct{}), make(chan struct{})
+		close(sent)
+		go func() {
+			defer close(done)
+			for i := 0; i < 20; i++ {
+				c.stats.bytesReceived.Add(1)
+				time.Sleep(d / 4)
+			}
+		}()
+		require.NoError(t, c.watchStall(ctx, sent, done, d))
+	})
+	t.Run("Sending", func(t *testing.T) {
+		// Not watching until query is sent.
+		c := newClient(t)
+		done := make(chan struct{})
+		time.AfterFunc(d*2, func() { close(done) })
+		require.NoError(t, c.watchStall(ctx, make(chan struct{}), done, d))
+	})
+}
+
+func TestClient_Do_stallTimeout(t *testing.T) {
+	t.Parallel()
+	conn := Conn(t)
+
+	// No packets are sent during sleep, as progress is delayed.
+	err := conn.Do(context.Background(), Query{
+		Body:         "SELECT sleep(2) AS v",
+		Settings:     []Setting{{Key: "interactive_delay", Value: "10000000"}},
+		Result:       discardResult(),
+		StallTimeout: time.Millisecond * 200,
+	})
+	require.ErrorIs(t, err, ErrQueryStalled)
+	require.ErrorIs(t, err, ErrNetwork)
+}
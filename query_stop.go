@@ -0,0 +1,78 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ErrStop can be returned from OnResult or OnRawBlock to stop receiving
+// result without closing connection, e.g. to unsubscribe from WATCH query.
+//
+// Query is canceled on server and remaining packets are discarded, then
+// Do returns nil and client can be used for next queries.
+var ErrStop = errors.New("stop query")
+
+// sendCancel sends query cancel packet to server.
+func (c *Client) sendCancel(ctx context.Context) error {
+	// Not using c.buf to prevent data race.
+	var b proto.Buffer
+	proto.ClientCodeCancel.Encode(&b)
+	if err := c.flushBuf(ctx, &b); err != nil {
+		return errors.Wrap(err, "flush")
+	}
+	return nil
+}
+
+// drainTimeout limits time spent on discarding packets of canceled query.
+const drainTimeout = time.Second * 10
+
+// drain discards packets of canceled query until end of stream.
+//
+// Blocks are skipped without decoding to query result, so last block
+// received by caller is not overwritten.
+//
+// Connection is closed if query can't be drained, because stream can be
+// left in the middle of packet.
+func (c *Client) drain(ctx context.Context) error {
+	c.lg.Debug("Draining canceled query")
+	ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+	defer cancel()
+	if err := c.drainPackets(ctx); err != nil {
+		_ = c.Close()
+		return err
+	}
+	return nil
+}
+
+func (c *Client) drainPackets(ctx context.Context) error {
+	discard := func(ctx context.Context, b RawBlock) error { return nil }
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		code, err := c.packet(ctx)
+		if err != nil {
+			return errors.Wrap(err, "packet")
+		}
+		switch code {
+		case proto.ServerCodeData, proto.ServerCodeTotals:
+			if err := c.decodeRawBlock(ctx, code.Compressible(), discard); err != nil {
+				return errors.Wrap(err, "decode raw block")
+			}
+		case proto.ServerCodeEndOfStream:
+			return nil
+		default:
+			// Not calling handlers of query after stop.
+			if err := c.handlePacket(ctx, code, Query{}); err != nil {
+				if IsErr(err, proto.ErrQueryWasCancelled) {
+					return nil
+				}
+				return errors.Wrap(err, "handle packet")
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_sendCancel(t *testing.T) {
+	ctx := context.Background()
+	conn, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = server.Close()
+	})
+	c := &Client{
+		lg:    zap.NewNop(),
+		conn:  conn,
+		buf:   new(proto.Buffer),
+		stats: new(clientStats),
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.sendCancel(ctx)
+		_ = conn.Close()
+	}()
+	data, err := io.ReadAll(server)
+	require.NoError(t, err)
+	require.NoError(t, <-errs)
+	require.Equal(t, []byte{byte(proto.ClientCodeCancel)}, data)
+}
+
+func TestClient_drain(t *testing.T) {
+	ctx := context.Background()
+	conn, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = server.Close()
+	})
+	c := &Client{
+		lg:              zap.NewNop(),
+		conn:            conn,
+		buf:             new(proto.Buffer),
+		reader:          proto.NewReader(conn),
+		stats:           new(clientStats),
+		protocolVersion: proto.Version,
+	}
+
+	var b proto.Buffer
+	for i := 0; i < 2; i++ {
+		proto.ServerCodeData.Encode(&b)
+		b.PutString("") // temp table
+		require.NoError(t, proto.Block{Columns: 1, Rows: 2}.EncodeBlock(&b, proto.Version, []proto.InputColumn{
+			{Name: "v", Data: proto.ColUInt8{1, 2}},
+		}))
+	}
+	proto.ServerCodeEndOfStream.Encode(&b)
+	go func() { _, _ = server.Write(b.Buf) }()
+
+	require.NoError(t, c.drain(ctx))
+}
+
+func TestClient_drain_timeout(t *testing.T) {
+	conn, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = server.Close()
+	})
+	c := &Client{
+		lg:              zap.NewNop(),
+		conn:            conn,
+		buf:             new(proto.Buffer),
+		reader:          proto.NewReader(conn),
+		stats:           new(clientStats),
+		protocolVersion: proto.Version,
+	}
+
+	// Server never answers, so drain should give up instead of spinning.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	require.Error(t, c.drain(ctx))
+	require.True(t, c.IsClosed())
+}
+
+func TestClient_Do_stop(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	var (
+		data   proto.ColUInt64
+		blocks int
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:     "SELECT number FROM system.numbers",
+		Settings: []Setting{SettingInt("max_block_size", 100)},
+		Result:   proto.Results{{Name: "number", Data: &data}},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			if blocks++; blocks == 3 {
+				return ErrStop
+			}
+			return nil
+		},
+	}))
+	require.Equal(t, 3, blocks)
+
+	// Connection is still usable.
+	require.False(t, conn.IsClosed())
+	require.NoError(t, conn.Ping(ctx))
+	var one proto.ColUInt8
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT 1 AS one",
+		Result: proto.Results{{Name: "one", Data: &one}},
+	}))
+	require.Equal(t, uint8(1), one.Row(0))
+}
+
+func TestClient_Do_watch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+	if err := conn.Do(ctx, Query{
+		Body:     "CREATE LIVE VIEW lv AS SELECT sum(v) AS total FROM (SELECT 1 AS v)",
+		Settings: []Setting{{Key: "allow_experimental_live_view", Value: "1"}},
+	}); err != nil {
+		t.Skipf("Live view not supported: %v", err)
+	}
+
+	var (
+		total   proto.ColUInt64
+		version proto.ColUInt64
+		updates int
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "WATCH lv",
+		Settings: []Setting{
+			{Key: "allow_experimental_live_view", Value: "1"},
+			SettingInt("live_view_heartbeat_interval", 1),
+		},
+		Result: proto.Results{
+			{Name: "total", Data: &total},
+			{Name: "_version", Data: &version},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			if block.Rows == 0 {
+				// Heartbeat.
+				return nil
+			}
+			updates++
+			require.Equal(t, uint64(1), total.Row(0))
+			return ErrStop
+		},
+	}))
+	require.Equal(t, 1, updates)
+	require.NoError(t, conn.Ping(ctx))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/big"
 	"math/rand"
 	"net/netip"
 	"testing"
@@ -474,6 +475,85 @@ func TestClient_Query(t *testing.T) {
 		expected := netip.MustParseAddr("2001:db8:ac10:fe01:feed:babe:cafe:f00d")
 		require.Equal(t, expected, data[0].ToIP())
 	})
+	t.Run("InsertIPv6Addr", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "CREATE TABLE test_table (v IPv6) ENGINE = Memory",
+		}), "create table")
+
+		values := []netip.Addr{
+			netip.MustParseAddr("2001:db8::1"),
+			netip.MustParseAddr("10.0.0.1"),
+		}
+		data := new(proto.ColIPv6).Addr()
+		data.AppendArr(values)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "INSERT INTO test_table VALUES",
+			Input: []proto.InputColumn{
+				{Name: "v", Data: data},
+			},
+		}), "insert")
+
+		var (
+			got proto.ColIPv6
+			str proto.ColStr
+		)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT v, toString(v) s FROM test_table",
+			Result: proto.Results{
+				{Name: "v", Data: &got},
+				{Name: "s", Data: &str},
+			},
+		}), "select")
+		require.Equal(t, 2, got.Rows())
+		addr := got.Addr().WithUnmap(true)
+		for i, v := range values {
+			require.Equal(t, v, addr.Row(i))
+		}
+		require.Equal(t, "::ffff:10.0.0.1", str.Row(1))
+	})
+	t.Run("InsertNewColumn", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "CREATE TABLE test_table (a Array(Nullable(Int32)), s LowCardinality(String)) ENGINE = Memory",
+		}), "create table")
+
+		var describe proto.Results
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT name, type FROM system.columns WHERE table = 'test_table' AND database = currentDatabase() ORDER BY position",
+			Result: describe.Auto(),
+		}), "describe")
+		var (
+			names = describe[0].Data.(*proto.ColStr)
+			types = describe[1].Data.(*proto.ColStr)
+			input proto.Input
+		)
+		for i := 0; i < names.Rows(); i++ {
+			col, err := proto.NewColumn(proto.ColumnType(types.Row(i)))
+			require.NoError(t, err)
+			input = append(input, proto.InputColumn{Name: names.Row(i), Data: col})
+		}
+		require.Len(t, input, 2)
+		input[0].Data.(proto.ColumnOf[[]proto.Nullable[int32]]).Append([]proto.Nullable[int32]{
+			proto.NewNullable[int32](1), proto.Null[int32](),
+		})
+		input[1].Data.(proto.ColumnOf[string]).Append("foo")
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:  input.Into("test_table"),
+			Input: input,
+		}), "insert")
+
+		var str proto.ColStr
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT toString(a) || s AS v FROM test_table",
+			Result: proto.Results{
+				{Name: "v", Data: &str},
+			},
+		}), "select")
+		require.Equal(t, "[1,NULL]foo", str.Row(0))
+	})
 	t.Run("SelectDateTime", func(t *testing.T) {
 		t.Parallel()
 		const (
@@ -613,6 +693,40 @@ func TestClient_Query(t *testing.T) {
 			},
 		}), "insert")
 	})
+	t.Run("FixedStrInferSize", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "CREATE TABLE test_table (v FixedString(34)) ENGINE = Memory",
+		}), "create table")
+
+		v := proto.NewFixedStr(34)
+		v.Append(bytes.Repeat([]byte("a"), 34))
+		v.Append(bytes.Repeat([]byte("b"), 34))
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "INSERT INTO test_table VALUES",
+			Input: []proto.InputColumn{
+				{Name: "v", Data: v},
+			},
+		}), "insert")
+
+		var (
+			got  proto.ColFixedStr // size is inferred
+			auto = proto.AutoResult("v")
+		)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT v FROM test_table",
+			Result: proto.Results{
+				{Name: "v", Data: &got},
+			},
+		}), "select")
+		requireEqual[[]byte](t, v, &got)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT v FROM test_table",
+			Result: auto,
+		}), "select auto")
+		require.Equal(t, proto.ColumnType("FixedString(34)"), auto.Data.Type())
+	})
 	t.Run("ArrayLowCardinality", func(t *testing.T) {
 		t.Parallel()
 		conn := Conn(t)
@@ -673,6 +787,37 @@ func TestClient_Query(t *testing.T) {
 		}), "select")
 		requireEqual[string](t, data, gotData)
 	})
+	t.Run("InsertLowCardinalityNullableString", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "CREATE TABLE test_table (v LowCardinality(Nullable(String))) ENGINE = Memory",
+		}), "create table")
+
+		data := proto.NewLowCardinality[proto.Nullable[string]](new(proto.ColStr).Nullable())
+		data.AppendArr([]proto.Nullable[string]{
+			proto.NewNullable("One"),
+			proto.Null[string](),
+			proto.NewNullable(""),
+			proto.NewNullable("One"),
+			proto.Null[string](),
+		})
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "INSERT INTO test_table VALUES",
+			Input: []proto.InputColumn{
+				{Name: "v", Data: data},
+			},
+		}), "insert")
+
+		gotData := proto.NewLowCardinality[proto.Nullable[string]](new(proto.ColStr).Nullable())
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT * FROM test_table",
+			Result: proto.Results{
+				{Name: "v", Data: gotData},
+			},
+		}), "select")
+		requireEqual[proto.Nullable[string]](t, data, gotData)
+	})
 	t.Run("InsertArrayLowCardinalityString", func(t *testing.T) {
 		t.Parallel()
 		conn := Conn(t)
@@ -879,6 +1024,45 @@ func TestClient_Query(t *testing.T) {
 			require.Equal(t, data.Row(i), gotData.Row(i))
 		}
 	})
+	t.Run("InsertDecimalString", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		createTable := Query{
+			Body: "CREATE TABLE test_table (v Decimal(38, 10)) ENGINE = Memory",
+		}
+		require.NoError(t, conn.Do(ctx, createTable), "create table")
+
+		values := []string{"123.4500000000", "-0.0000000001", "1234567890123456789012345678.0000000000"}
+		data := proto.NewDecimal(38, 10)
+		for _, v := range values {
+			require.NoError(t, data.AppendDecimalString(v))
+		}
+		insertQuery := Query{
+			Body: "INSERT INTO test_table VALUES",
+			Input: []proto.InputColumn{
+				{Name: "v", Data: data},
+			},
+		}
+		require.NoError(t, conn.Do(ctx, insertQuery), "insert")
+
+		var gotData proto.ColDecimal
+		var gotStr proto.ColStr
+		selectData := Query{
+			Body: "SELECT v, toString(v) s FROM test_table",
+			Result: proto.Results{
+				{Name: "v", Data: &gotData},
+				{Name: "s", Data: &gotStr},
+			},
+		}
+		require.NoError(t, conn.Do(ctx, selectData), "select")
+		require.Equal(t, len(values), gotData.Rows())
+		for i, v := range values {
+			require.Equal(t, v, gotData.RowString(i))
+			expected, ok := new(big.Rat).SetString(gotStr.Row(i))
+			require.True(t, ok)
+			require.Zero(t, expected.Cmp(gotData.RowBigRat(i)))
+		}
+	})
 	t.Run("InsertGeoPoint", func(t *testing.T) {
 		t.Parallel()
 		conn := ConnOpt(t, Options{
@@ -919,6 +1103,54 @@ func TestClient_Query(t *testing.T) {
 			require.Equal(t, data.Row(i), gotData.Row(i))
 		}
 	})
+	t.Run("InsertGeoMultiPolygon", func(t *testing.T) {
+		t.Parallel()
+		conn := ConnOpt(t, Options{
+			Settings: []Setting{
+				SettingInt("allow_experimental_geo_types", 1),
+			},
+		})
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "CREATE TABLE test_table (r Ring, p Polygon, m MultiPolygon) ENGINE = Memory",
+		}), "create table")
+
+		var (
+			ring    = proto.Ring{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+			hole    = proto.Ring{{X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 4}}
+			polygon = proto.Polygon{ring, hole}
+			rings   = new(proto.ColRing)
+			polys   = new(proto.ColPolygon)
+			multi   = new(proto.ColMultiPolygon)
+		)
+		rings.Append(ring)
+		polys.Append(polygon)
+		multi.Append(proto.MultiPolygon{polygon, {ring}})
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "INSERT INTO test_table VALUES",
+			Input: []proto.InputColumn{
+				{Name: "r", Data: rings},
+				{Name: "p", Data: polys},
+				{Name: "m", Data: multi},
+			},
+		}), "insert")
+
+		var (
+			gotRings = new(proto.ColRing)
+			gotPolys = new(proto.ColPolygon)
+			gotMulti = new(proto.ColMultiPolygon)
+		)
+		require.NoError(t, conn.Do(ctx, Query{
+			Body: "SELECT * FROM test_table",
+			Result: proto.Results{
+				{Name: "r", Data: gotRings},
+				{Name: "p", Data: gotPolys},
+				{Name: "m", Data: gotMulti},
+			},
+		}), "select")
+		require.Equal(t, rings.Row(0), gotRings.Row(0))
+		require.Equal(t, polys.Row(0), gotPolys.Row(0))
+		require.Equal(t, multi.Row(0), gotMulti.Row(0))
+	})
 	t.Run("SelectInterval", func(t *testing.T) {
 		t.Parallel()
 		conn := Conn(t)
@@ -932,6 +1164,21 @@ func TestClient_Query(t *testing.T) {
 		}), "select table")
 		require.Equal(t, proto.Interval{Scale: proto.IntervalWeek, Value: 1}, data.Row(0))
 	})
+	t.Run("SelectIntervalAuto", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+
+		results := proto.Results{
+			{Name: "q", Data: new(proto.ColInterval)},
+			{Name: "ms", Data: new(proto.ColInterval)},
+		}
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT INTERVAL 2 QUARTER AS q, toIntervalMillisecond(150) AS ms",
+			Result: results,
+		}), "select")
+		require.Equal(t, proto.Interval{Scale: proto.IntervalQuarter, Value: 2}, results[0].Data.(*proto.ColInterval).Row(0))
+		require.Equal(t, proto.Interval{Scale: proto.IntervalMillisecond, Value: 150}, results[1].Data.(*proto.ColInterval).Row(0))
+	})
 	t.Run("SelectNothing", func(t *testing.T) {
 		t.Parallel()
 		conn := Conn(t)
@@ -945,6 +1192,23 @@ func TestClient_Query(t *testing.T) {
 		}), "select table")
 		require.False(t, data.Row(0).Set)
 	})
+	t.Run("SelectNothingAsOtherType", func(t *testing.T) {
+		t.Parallel()
+		var (
+			str = new(proto.ColStr).Nullable()
+			arr = new(proto.ColInt64).Array()
+		)
+		require.NoError(t, Conn(t).Do(ctx, Query{
+			Body: "SELECT NULL AS s, [] AS a",
+			Result: proto.Results{
+				{Name: "s", Data: str},
+				{Name: "a", Data: arr},
+			},
+		}))
+		require.Equal(t, 1, str.Rows())
+		require.False(t, str.Row(0).Set)
+		require.Empty(t, arr.Row(0))
+	})
 	t.Run("NotUTF8", func(t *testing.T) {
 		// https://github.com/ClickHouse/ch-go/issues/226
 		t.Parallel()
@@ -1154,6 +1418,26 @@ func TestClient_ExternalData(t *testing.T) {
 		require.NoError(t, Conn(t).Do(ctx, selectStr))
 		require.Equal(t, 3, data.Rows())
 	})
+	t.Run("Enum", func(t *testing.T) {
+		t.Parallel()
+		values := new(proto.ColEnum).WithValues(map[string]int8{
+			"foo": 1,
+			"bar": 2,
+		})
+		values.AppendArr([]string{"foo", "bar", "foo"})
+		var data proto.ColEnum
+		selectStr := Query{
+			Body: "SELECT * FROM _data",
+			ExternalData: []proto.InputColumn{
+				{Name: "v", Data: values},
+			},
+			Result: proto.Results{
+				{Name: "v", Data: &data},
+			},
+		}
+		require.NoError(t, Conn(t).Do(ctx, selectStr))
+		require.Equal(t, []string{"foo", "bar", "foo"}, data.Values)
+	})
 }
 
 func TestClient_ServerProfile(t *testing.T) {
@@ -1464,3 +1748,29 @@ func TestClientQueryCancellation(t *testing.T) {
 	// Connection should be closed after query cancellation.
 	require.True(t, c.IsClosed())
 }
+
+func TestClient_Do_dateTimeLocation(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Server", func(t *testing.T) {
+		conn := Conn(t)
+		loc, err := conn.ServerInfo().Location()
+		require.NoError(t, err)
+
+		var data proto.ColDateTime
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT toDateTime(0) as v",
+			Result: proto.Results{{Name: "v", Data: &data}},
+		}))
+		require.Equal(t, loc.String(), data.Row(0).Location().String())
+	})
+	t.Run("UTC", func(t *testing.T) {
+		conn := ConnOpt(t, Options{Location: time.UTC})
+
+		var data proto.ColDateTime64
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT toDateTime64(0, 3) as v",
+			Result: proto.Results{{Name: "v", Data: &data}},
+		}))
+		require.Equal(t, time.UTC, data.Row(0).Location())
+	})
+}
@@ -0,0 +1,87 @@
+package ch
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_queryTimeouts(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(t *testing.T) *Client {
+		// Server side of pipe never reads or writes, like hung server.
+		conn, server := net.Pipe()
+		t.Cleanup(func() {
+			_ = conn.Close()
+			_ = server.Close()
+		})
+		return &Client{
+			lg:     zap.NewNop(),
+			conn:   conn,
+			buf:    new(proto.Buffer),
+			reader: proto.NewReader(conn),
+			stats:  new(clientStats),
+		}
+	}
+	t.Run("Read", func(t *testing.T) {
+		c := newClient(t)
+		c.queryReadTimeout = time.Millisecond * 10
+		_, err := c.packet(ctx)
+		require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	})
+	t.Run("Write", func(t *testing.T) {
+		c := newClient(t)
+		c.writeTimeout = time.Millisecond * 10
+		c.buf.PutString("data")
+		require.ErrorIs(t, c.flush(ctx), os.ErrDeadlineExceeded)
+		require.ErrorIs(t, c.write(ctx, []byte("data")), os.ErrDeadlineExceeded)
+	})
+	t.Run("Deadline", func(t *testing.T) {
+		c := newClient(t)
+		_, ok := c.writeDeadline(ctx)
+		require.False(t, ok)
+
+		c.writeTimeout = time.Minute
+		d, ok := c.writeDeadline(ctx)
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(time.Minute), d, time.Second)
+
+		// Context deadline is earlier.
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		expected, _ := deadlineCtx.Deadline()
+		d, ok = c.writeDeadline(deadlineCtx)
+		require.True(t, ok)
+		require.Equal(t, expected, d)
+	})
+	t.Run("WriteContextDeadline", func(t *testing.T) {
+		c := newClient(t)
+		c.writeTimeout = time.Minute
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+		defer cancel()
+		require.ErrorIs(t, c.write(deadlineCtx, []byte("data")), os.ErrDeadlineExceeded)
+	})
+}
+
+func TestClient_Do_readTimeout(t *testing.T) {
+	t.Parallel()
+	conn := Conn(t)
+
+	// No packets are sent during sleep, as progress is delayed.
+	err := conn.Do(context.Background(), Query{
+		Body:        "SELECT sleep(2) AS v",
+		Settings:    []Setting{{Key: "interactive_delay", Value: "10000000"}},
+		Result:      discardResult(),
+		ReadTimeout: time.Millisecond * 200,
+	})
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+}
@@ -0,0 +1,150 @@
+package ch
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/compress"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// RawBlock is data block in Native format that is passed through without
+// decoding columns, e.g. from one server to another.
+type RawBlock struct {
+	// Header of block: info, rows, names and types of columns.
+	Header proto.BlockHeader
+	// Data of block as on the wire, including header, compressed if
+	// Compressed is set.
+	Data []byte
+	// Compressed reports whether Data consists of compressed frames.
+	Compressed bool
+	// ProtocolVersion of Data, as Native block layout depends on it.
+	ProtocolVersion int
+}
+
+// decodeRawBlock reads data block as is and passes it to f.
+func (c *Client) decodeRawBlock(ctx context.Context, compressible bool, f func(ctx context.Context, b RawBlock) error) error {
+	if proto.FeatureTempTables.In(c.protocolVersion) {
+		v, err := c.reader.Str()
+		if err != nil {
+			return errors.Wrap(err, "temp table")
+		}
+		if v != "" {
+			return errors.Wrapf(ErrProtocol, "unexpected temp table %q", v)
+		}
+	}
+	c.queryBlock++
+	block := RawBlock{
+		Compressed:      c.compression == proto.CompressionEnabled && compressible,
+		ProtocolVersion: c.protocolVersion,
+	}
+	if block.Compressed {
+		c.reader.EnableCompression()
+		defer c.reader.DisableCompression()
+	}
+	var data proto.Buffer
+	c.reader.Tee(&data)
+	defer c.reader.Tee(nil)
+	if err := block.Header.DecodeBlock(c.reader, c.protocolVersion); err != nil {
+		var badData *compress.CorruptedDataErr
+		if errors.As(err, &badData) {
+			return errors.Wrap(c.corruptedData(badData), "bad block")
+		}
+		return errors.Wrap(err, "decode block")
+	}
+	block.Data = data.Buf
+	if ce := c.lg.Check(zap.DebugLevel, "Raw block"); ce != nil {
+		ce.Write(
+			zap.Int("rows", block.Header.Rows),
+			zap.Int("columns", len(block.Header.Columns)),
+			zap.Int("size", len(block.Data)),
+		)
+	}
+	if block.Header.End() {
+		return nil
+	}
+	c.stats.blocksReceived.Inc()
+	c.stats.rowsReceived.Add(uint64(block.Header.Rows))
+	c.metricsInc(ctx, queryMetrics{
+		BlocksReceived:  1,
+		RowsReceived:    block.Header.Rows,
+		ColumnsReceived: len(block.Header.Columns),
+	})
+	if err := f(ctx, block); err != nil {
+		return errors.Wrap(err, "handler")
+	}
+	return nil
+}
+
+// encodeRawBlock encodes data block as is, compressing or decompressing
+// it if needed.
+func (c *Client) encodeRawBlock(ctx context.Context, b RawBlock) error {
+	if b.ProtocolVersion != c.protocolVersion {
+		return errors.Errorf("block protocol version %d does not match connection protocol version %d",
+			b.ProtocolVersion, c.protocolVersion,
+		)
+	}
+	proto.ClientCodeData.Encode(c.buf)
+	proto.ClientData{}.EncodeAware(c.buf, c.protocolVersion)
+
+	compression := c.compression == proto.CompressionEnabled
+	switch {
+	case b.Compressed == compression:
+		c.buf.PutRaw(b.Data)
+	case compression:
+		if err := c.compressor.Compress(c.compressionMethod, b.Data); err != nil {
+			return errors.Wrap(err, "compress")
+		}
+		c.stats.uncompressedBytesSent.Add(uint64(len(b.Data)))
+		c.stats.compressedBytesSent.Add(uint64(len(c.compressor.Data)))
+		c.buf.PutRaw(c.compressor.Data)
+	default:
+		r := compress.NewReader(bytes.NewReader(b.Data))
+		var buf [4096]byte
+		for {
+			n, err := r.Read(buf[:])
+			c.buf.PutRaw(buf[:n])
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "decompress")
+			}
+		}
+	}
+	c.endPacket()
+	c.metricsInc(ctx, queryMetrics{BlocksSent: 1})
+	c.stats.blocksSent.Inc()
+	c.stats.rowsSent.Add(uint64(b.Header.Rows))
+	return nil
+}
+
+// sendRawInput streams blocks from q.OnRawInput to server.
+func (c *Client) sendRawInput(ctx context.Context, q Query) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "context")
+		}
+		b, err := q.OnRawInput(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "next input")
+		}
+		if err := c.encodeRawBlock(ctx, b); err != nil {
+			return errors.Wrap(err, "write block")
+		}
+		if err := c.flush(ctx); err != nil {
+			return errors.Wrap(err, "flush")
+		}
+	}
+	if err := c.encodeBlankBlock(ctx); err != nil {
+		return errors.Wrap(err, "write end of data")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestClient_Do_rawBlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for _, tt := range []struct {
+		Name        string
+		Source      Compression
+		Destination Compression
+	}{
+		{Name: "Disabled", Source: CompressionDisabled, Destination: CompressionDisabled},
+		{Name: "LZ4", Source: CompressionLZ4, Destination: CompressionLZ4},
+		{Name: "Compress", Source: CompressionDisabled, Destination: CompressionZSTD},
+		{Name: "Decompress", Source: CompressionLZ4, Destination: CompressionDisabled},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				src = ConnOpt(t, Options{Compression: tt.Source})
+				dst = ConnOpt(t, Options{Compression: tt.Destination})
+			)
+			const schema = "(id UInt64, name LowCardinality(String), tags Array(Nullable(String)), m Map(String, UInt8)) ENGINE = Memory"
+			require.NoError(t, src.Do(ctx, Query{Body: "CREATE TABLE src " + schema}))
+			require.NoError(t, dst.Do(ctx, Query{Body: "CREATE TABLE dst " + schema}))
+			require.NoError(t, src.Do(ctx, Query{
+				Body: "INSERT INTO src SELECT number, toString(number % 3), [toString(number), NULL], map('k', number % 256) FROM numbers(10000)",
+			}))
+
+			// Copying blocks, as data is valid only during OnRawBlock.
+			var blocks []RawBlock
+			require.NoError(t, src.Do(ctx, Query{
+				Body:     "SELECT * FROM src ORDER BY id",
+				Settings: []Setting{SettingInt("max_block_size", 1000)},
+				OnRawBlock: func(ctx context.Context, b RawBlock) error {
+					if b.Header.Rows == 0 {
+						return nil
+					}
+					b.Data = append([]byte(nil), b.Data...)
+					blocks = append(blocks, b)
+					return nil
+				},
+			}))
+			require.Greater(t, len(blocks), 1)
+			require.Equal(t, []proto.ColInfo{
+				{Name: "id", Type: "UInt64"},
+				{Name: "name", Type: "LowCardinality(String)"},
+				{Name: "tags", Type: "Array(Nullable(String))"},
+				{Name: "m", Type: "Map(String, UInt8)"},
+			}, blocks[0].Header.Columns)
+
+			require.NoError(t, dst.Do(ctx, Query{
+				Body: "INSERT INTO dst VALUES",
+				OnRawInput: func(ctx context.Context) (RawBlock, error) {
+					if len(blocks) == 0 {
+						return RawBlock{}, io.EOF
+					}
+					b := blocks[0]
+					blocks = blocks[1:]
+					return b, nil
+				},
+			}))
+
+			var count proto.ColUInt64
+			require.NoError(t, dst.Do(ctx, Query{
+				Body:   "SELECT count() FROM dst WHERE tags[1] = toString(id) AND m['k'] = id % 256",
+				Result: proto.Results{{Name: "count()", Data: &count}},
+			}))
+			require.Equal(t, uint64(10000), count.Row(0))
+		})
+	}
+	t.Run("Input", func(t *testing.T) {
+		t.Parallel()
+		conn := Conn(t)
+		require.Error(t, conn.Do(ctx, Query{
+			Body:  "INSERT INTO t VALUES",
+			Input: proto.Input{{Name: "v", Data: proto.ColUInt8{1}}},
+			OnRawInput: func(ctx context.Context) (RawBlock, error) {
+				return RawBlock{}, io.EOF
+			},
+		}))
+	})
+}
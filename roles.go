@@ -0,0 +1,20 @@
+package ch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// setRoles activates roles for session.
+func (c *Client) setRoles(ctx context.Context, roles []string) error {
+	quoted := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r == "" {
+			return errors.New("empty role")
+		}
+		quoted = append(quoted, quoteIdentifier(r))
+	}
+	return c.Do(ctx, Query{Body: "SET ROLE " + strings.Join(quoted, ", ")})
+}
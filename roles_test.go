@@ -0,0 +1,29 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestOptions_Roles(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+	if err := conn.Do(ctx, Query{Body: "CREATE ROLE IF NOT EXISTS `ch-go role`"}); err != nil {
+		t.Skipf("Roles are not supported: %v", err)
+	}
+	require.NoError(t, conn.Do(ctx, Query{Body: "GRANT `ch-go role` TO default"}))
+
+	conn = ConnOpt(t, Options{Roles: []string{"ch-go role"}})
+	var roles proto.ColStr
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT role_name FROM system.current_roles",
+		Result: proto.Results{{Name: "role_name", Data: &roles}},
+	}))
+	require.Equal(t, 1, roles.Rows())
+	require.Equal(t, "ch-go role", roles.Row(0))
+}
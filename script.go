@@ -0,0 +1,143 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// Statement is statement of SQL script.
+type Statement struct {
+	Body string
+	Line int // line of statement start, starting from 1
+}
+
+// Script is sequence of SQL statements, see ParseScript.
+type Script struct {
+	Statements []Statement
+	// Settings of each statement.
+	Settings []Setting
+	// Transaction executes statements in transaction, which is rolled back
+	// on error, see Client.Begin.
+	Transaction bool
+}
+
+// ScriptError is returned by Script.Run if statement failed.
+type ScriptError struct {
+	Index     int // of statement, starting from 0
+	Statement Statement
+	Err       error
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("statement %d at line %d: %s", e.Index+1, e.Statement.Line, e.Err)
+}
+
+func (e *ScriptError) Unwrap() error { return e.Err }
+
+// ParseScript splits SQL script into statements by semicolons, respecting
+// string literals, quoted identifiers and comments.
+//
+// Empty statements and statements with only comments are skipped.
+func ParseScript(sql string) (*Script, error) {
+	s := &Script{}
+	var (
+		start   = 0
+		line    = 1
+		content bool // statement has tokens other than comments
+	)
+	flush := func(end int) {
+		body := strings.TrimSpace(sql[start:end])
+		if content && body != "" {
+			// Line of first non-space character.
+			offset := start + strings.Index(sql[start:end], body)
+			s.Statements = append(s.Statements, Statement{
+				Body: body,
+				Line: line + strings.Count(sql[start:offset], "\n"),
+			})
+		}
+		line += strings.Count(sql[start:end], "\n")
+		content = false
+	}
+	for i := 0; i < len(sql); {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end, ok := skipQuoted(sql, i)
+			if !ok {
+				return nil, errors.Errorf("unterminated quote at line %d", line+strings.Count(sql[start:i], "\n"))
+			}
+			content = true
+			i = end
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			i += end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated comment at line %d", line+strings.Count(sql[start:i], "\n"))
+			}
+			i += end + 4
+		case c == ';':
+			flush(i)
+			i++
+			start = i
+		default:
+			if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				content = true
+			}
+			i++
+		}
+	}
+	flush(len(sql))
+	return s, nil
+}
+
+// Run executes statements of script sequentially, stopping on first error.
+//
+// Returns *ScriptError if statement failed.
+func (s *Script) Run(ctx context.Context, c *Client) (rErr error) {
+	do := c.Do
+	if s.Transaction {
+		tx, err := c.Begin(ctx)
+		if err != nil {
+			return errors.Wrap(err, "begin transaction")
+		}
+		defer func() {
+			if rErr == nil {
+				if err := tx.Commit(ctx); err != nil {
+					rErr = errors.Wrap(err, "commit")
+				}
+				return
+			}
+			if c.IsClosed() {
+				// Transaction is rolled back by server on disconnect.
+				return
+			}
+			if err := tx.Rollback(ctx); err != nil {
+				rErr = errors.Join(rErr, errors.Wrap(err, "rollback"))
+			}
+		}()
+		do = tx.Do
+	}
+	for i, stmt := range s.Statements {
+		if err := do(ctx, Query{Body: stmt.Body, Settings: s.Settings}); err != nil {
+			return &ScriptError{Index: i, Statement: stmt, Err: err}
+		}
+	}
+	return nil
+}
+
+// RunScript parses SQL script and executes its statements sequentially,
+// see ParseScript and Script.Run.
+func RunScript(ctx context.Context, c *Client, sql string) error {
+	s, err := ParseScript(sql)
+	if err != nil {
+		return errors.Wrap(err, "parse")
+	}
+	return s.Run(ctx, c)
+}
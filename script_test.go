@@ -0,0 +1,83 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestParseScript(t *testing.T) {
+	s, err := ParseScript(`-- create table
+CREATE TABLE t (s String) ENGINE = Memory;
+
+/* insert; with semicolon */
+INSERT INTO t VALUES ('a;b'), ('it''s'), ('\';');
+SELECT "x;y", ` + "`a;b`" + ` FROM t -- trailing; comment
+;;
+-- only comment
+;
+-- comment; with semicolon
+SELECT 1`)
+	require.NoError(t, err)
+	require.Equal(t, []Statement{
+		{Body: "-- create table\nCREATE TABLE t (s String) ENGINE = Memory", Line: 1},
+		{Body: "/* insert; with semicolon */\nINSERT INTO t VALUES ('a;b'), ('it''s'), ('\\';')", Line: 4},
+		{Body: "SELECT \"x;y\", `a;b` FROM t -- trailing; comment", Line: 6},
+		{Body: "-- comment; with semicolon\nSELECT 1", Line: 10},
+	}, s.Statements)
+
+	for _, sql := range []string{
+		"SELECT 'unterminated",
+		"SELECT 'escaped\\'",
+		"SELECT `a",
+		"SELECT 1 /* unterminated",
+	} {
+		_, err := ParseScript(sql)
+		require.Error(t, err, sql)
+	}
+
+	s, err = ParseScript("  \n-- nothing\n;")
+	require.NoError(t, err)
+	require.Empty(t, s.Statements)
+}
+
+func TestScriptError(t *testing.T) {
+	err := error(&ScriptError{
+		Index:     1,
+		Statement: Statement{Body: "SELECT x", Line: 3},
+		Err:       &Exception{Code: proto.ErrUnknownIdentifier, Name: "DB::Exception", Message: "boom"},
+	})
+	require.True(t, HasCode(err, proto.ErrUnknownIdentifier))
+	require.EqualError(t, err, "statement 2 at line 3: UNKNOWN_IDENTIFIER (47): DB::Exception: boom")
+}
+
+func TestRunScript(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	require.NoError(t, RunScript(ctx, conn, `
+CREATE TABLE test_script (id UInt8) ENGINE = Memory;
+INSERT INTO test_script VALUES (1), (2);
+-- done`))
+
+	err := RunScript(ctx, conn, `
+INSERT INTO test_script VALUES (3);
+SELECT unknown_column FROM test_script;
+INSERT INTO test_script VALUES (4);`)
+	var scriptErr *ScriptError
+	require.ErrorAs(t, err, &scriptErr)
+	require.Equal(t, 1, scriptErr.Index)
+	require.Equal(t, 3, scriptErr.Statement.Line)
+	require.True(t, HasCode(err, proto.ErrUnknownIdentifier))
+
+	var count proto.ColUInt64
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT count() FROM test_script",
+		Result: proto.Results{{Name: "count()", Data: &count}},
+	}))
+	require.Equal(t, uint64(3), count.Row(0))
+}
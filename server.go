@@ -251,6 +251,12 @@ func (s *Server) handle(conn net.Conn) error {
 		info: proto.ServerHello{
 			Name:     "CH",
 			Revision: s.ver,
+
+			ParallelReplicasVersion: proto.ParallelReplicasVersion,
+
+			// Chunked packets are not implemented.
+			ChunkedSend: proto.NotChunked,
+			ChunkedRecv: proto.NotChunked,
 		},
 		tz:         time.UTC,
 		compressor: compress.NewWriter(),
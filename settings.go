@@ -0,0 +1,168 @@
+package ch
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+//go:generate go run ./internal/cmd/ch-gen-settings
+
+// SettingKind is kind of setting value.
+type SettingKind string
+
+// Possible setting kinds.
+const (
+	SettingKindBool         SettingKind = "Bool"
+	SettingKindUInt         SettingKind = "UInt"
+	SettingKindFloat        SettingKind = "Float"
+	SettingKindString       SettingKind = "String"
+	SettingKindSeconds      SettingKind = "Seconds"
+	SettingKindMilliseconds SettingKind = "Milliseconds"
+	SettingKindEnum         SettingKind = "Enum"
+	SettingKindMaxThreads   SettingKind = "MaxThreads"
+)
+
+// SettingInfo describes known setting.
+type SettingInfo struct {
+	Name        string
+	Kind        SettingKind
+	Values      []string // possible values of SettingKindEnum
+	Description string
+}
+
+// LookupSetting returns description of known setting.
+func LookupSetting(name string) (SettingInfo, bool) {
+	s, ok := settingsCatalog[name]
+	return s, ok
+}
+
+// SettingBool returns Setting with boolean value v.
+func SettingBool(k string, v bool) Setting {
+	value := "0"
+	if v {
+		value = "1"
+	}
+	return Setting{
+		Key:       k,
+		Value:     value,
+		Important: true,
+	}
+}
+
+// SettingUInt returns Setting with unsigned integer value v.
+func SettingUInt(k string, v uint64) Setting {
+	return Setting{
+		Key:       k,
+		Value:     strconv.FormatUint(v, 10),
+		Important: true,
+	}
+}
+
+// SettingFloat returns Setting with floating point value v.
+func SettingFloat(k string, v float64) Setting {
+	return Setting{
+		Key:       k,
+		Value:     strconv.FormatFloat(v, 'f', -1, 64),
+		Important: true,
+	}
+}
+
+// SettingString returns Setting with string value v.
+func SettingString(k, v string) Setting {
+	return Setting{
+		Key:       k,
+		Value:     v,
+		Important: true,
+	}
+}
+
+// SettingSeconds returns Setting with duration value v in seconds.
+func SettingSeconds(k string, v time.Duration) Setting {
+	return SettingFloat(k, v.Seconds())
+}
+
+// SettingMilliseconds returns Setting with duration value v in
+// milliseconds.
+func SettingMilliseconds(k string, v time.Duration) Setting {
+	return SettingUInt(k, uint64(v.Milliseconds()))
+}
+
+// Validate checks value of known setting.
+//
+// Unknown settings are not validated, so custom settings or settings of
+// newer server versions can be used.
+func (s Setting) Validate() error {
+	info, ok := settingsCatalog[s.Key]
+	if !ok {
+		return nil
+	}
+	if !info.valid(s.Value) {
+		if len(info.Values) > 0 {
+			return errors.Errorf("invalid value %q of setting %s, expected one of %s",
+				s.Value, s.Key, strings.Join(info.Values, ", "),
+			)
+		}
+		return errors.Errorf("invalid value %q of %s setting %s", s.Value, info.Kind, s.Key)
+	}
+	return nil
+}
+
+func (i SettingInfo) valid(v string) bool {
+	switch i.Kind {
+	case SettingKindBool:
+		switch strings.ToLower(v) {
+		case "0", "1", "true", "false":
+			return true
+		}
+		return false
+	case SettingKindUInt, SettingKindMilliseconds:
+		_, err := strconv.ParseUint(v, 10, 64)
+		return err == nil || isSizeWithSuffix(v)
+	case SettingKindMaxThreads:
+		if strings.HasPrefix(v, "auto") {
+			return true
+		}
+		_, err := strconv.ParseUint(v, 10, 64)
+		return err == nil
+	case SettingKindFloat, SettingKindSeconds:
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	case SettingKindEnum:
+		for _, e := range i.Values {
+			if strings.EqualFold(e, v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// isSizeWithSuffix reports whether v is size like 10G or 1.5Gi.
+func isSizeWithSuffix(v string) bool {
+	v = strings.TrimSuffix(strings.TrimSuffix(v, "B"), "i")
+	if v == "" {
+		return false
+	}
+	switch v[len(v)-1] {
+	case 'K', 'k', 'M', 'G', 'T', 'P', 'E':
+	default:
+		return false
+	}
+	_, err := strconv.ParseFloat(strings.TrimSpace(v[:len(v)-1]), 64)
+	return err == nil
+}
+
+// validateSettings checks values of known settings.
+func validateSettings(settings []Setting) error {
+	for _, s := range settings {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
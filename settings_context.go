@@ -0,0 +1,26 @@
+package ch
+
+import "context"
+
+type ctxSettingsKey struct{}
+
+// WithSettings returns copy of ctx with settings, which are added to
+// settings of each query executed with this context, after client
+// settings and before Query.Settings, so query can override them.
+//
+// Settings of parent context are preserved and can be overridden.
+func WithSettings(ctx context.Context, settings ...Setting) context.Context {
+	if len(settings) == 0 {
+		return ctx
+	}
+	parent := ContextSettings(ctx)
+	// Capacity is limited to copy on append, as parent can be shared.
+	merged := append(parent[:len(parent):len(parent)], settings...)
+	return context.WithValue(ctx, ctxSettingsKey{}, merged)
+}
+
+// ContextSettings returns settings attached to ctx by WithSettings.
+func ContextSettings(ctx context.Context) []Setting {
+	v, _ := ctx.Value(ctxSettingsKey{}).([]Setting)
+	return v
+}
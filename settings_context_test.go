@@ -0,0 +1,44 @@
+package ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSettings(t *testing.T) {
+	ctx := context.Background()
+	require.Empty(t, ContextSettings(ctx))
+	require.Equal(t, ctx, WithSettings(ctx))
+
+	parent := WithSettings(ctx, SettingMaxExecutionTime(time.Second))
+	a := WithSettings(parent, SettingMaxResultRows(10))
+	b := WithSettings(parent, SettingMaxThreads(1))
+	require.Equal(t, []Setting{SettingMaxExecutionTime(time.Second)}, ContextSettings(parent))
+	require.Equal(t, []Setting{SettingMaxExecutionTime(time.Second), SettingMaxResultRows(10)}, ContextSettings(a))
+	require.Equal(t, []Setting{SettingMaxExecutionTime(time.Second), SettingMaxThreads(1)}, ContextSettings(b))
+
+	// Context settings are validated as query ones.
+	err := new(Client).Do(WithSettings(ctx, Setting{Key: "max_threads", Value: "many"}), Query{Body: "SELECT 1"})
+	require.ErrorContains(t, err, "max_threads")
+}
+
+func TestClient_Do_contextSettings(t *testing.T) {
+	t.Parallel()
+	conn := Conn(t)
+	ctx := WithSettings(context.Background(), SettingMaxResultRows(1), SettingResultOverflowMode("throw"))
+	err := conn.Do(ctx, Query{
+		Body:   "SELECT number FROM system.numbers LIMIT 10",
+		Result: discardResult(),
+	})
+	require.Error(t, err)
+
+	// Query settings override context ones.
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:     "SELECT number FROM system.numbers LIMIT 10",
+		Settings: []Setting{SettingMaxResultRows(100)},
+		Result:   discardResult(),
+	}))
+}
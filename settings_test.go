@@ -0,0 +1,53 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetting_Validate(t *testing.T) {
+	for _, s := range []Setting{
+		SettingMaxThreads(8),
+		SettingMaxThreads(0),
+		{Key: "max_threads", Value: "auto(4)"},
+		SettingBool("optimize_read_in_order", true),
+		{Key: "optimize_read_in_order", Value: "True"},
+		SettingMaxMemoryUsage(1 << 30),
+		{Key: "max_memory_usage", Value: "10G"},
+		{Key: "max_memory_usage", Value: "1.5GiB"},
+		SettingMaxExecutionTime(1500 * time.Millisecond),
+		SettingAsyncInsertBusyTimeoutMs(time.Second),
+		SettingLoadBalancing("in_order"),
+		SettingLogComment("anything"),
+		{Key: "unknown_setting", Value: "anything"},
+	} {
+		require.NoError(t, s.Validate(), "%s=%s", s.Key, s.Value)
+	}
+	for _, s := range []Setting{
+		{Key: "max_threads", Value: "-1"},
+		{Key: "optimize_read_in_order", Value: "maybe"},
+		{Key: "max_memory_usage", Value: "much"},
+		{Key: "max_execution_time", Value: "1m"},
+		SettingLoadBalancing("best"),
+	} {
+		require.Error(t, s.Validate(), "%s=%s", s.Key, s.Value)
+	}
+}
+
+func TestSettingConstructors(t *testing.T) {
+	require.Equal(t, Setting{Key: "max_threads", Value: "8", Important: true}, SettingMaxThreads(8))
+	require.Equal(t, "1", SettingBool("final", true).Value)
+	require.Equal(t, "0", SettingFinal(false).Value)
+	require.Equal(t, "1.5", SettingMaxExecutionTime(1500*time.Millisecond).Value)
+	require.Equal(t, "250", SettingAsyncInsertBusyTimeoutMs(250*time.Millisecond).Value)
+	require.Equal(t, "0.25", SettingFloat("f", 0.25).Value)
+
+	info, ok := LookupSetting("result_overflow_mode")
+	require.True(t, ok)
+	require.Equal(t, SettingKindEnum, info.Kind)
+	require.Equal(t, []string{"throw", "break"}, info.Values)
+	_, ok = LookupSetting("unknown_setting")
+	require.False(t, ok)
+}
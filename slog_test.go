@@ -0,0 +1,33 @@
+package ch
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	lg := NewSlogLogger(slog.New(h)).With(zap.String("query_id", "1"))
+
+	lg.Debug("Skipped")
+	require.Nil(t, lg.Check(zap.DebugLevel, "Skipped"))
+	lg.Warn("Hello", zap.Int("rows", 10))
+
+	var entry struct {
+		Level   string `json:"level"`
+		Msg     string `json:"msg"`
+		QueryID string `json:"query_id"`
+		Rows    int    `json:"rows"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "WARN", entry.Level)
+	require.Equal(t, "Hello", entry.Msg)
+	require.Equal(t, "1", entry.QueryID)
+	require.Equal(t, 10, entry.Rows)
+}
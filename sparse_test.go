@@ -0,0 +1,56 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestSparseSerialization(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: `CREATE TABLE test_sparse (id UInt64, v UInt64, s String)
+ENGINE = MergeTree ORDER BY id
+SETTINGS ratio_of_defaults_for_sparse_serialization = 0.5`,
+	}))
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_sparse SELECT number, if(number % 100 = 0, number, 0), if(number % 100 = 1, 'x', '') FROM numbers(1000)",
+	}))
+
+	var (
+		id    proto.ColUInt64
+		v     proto.ColUInt64
+		s     proto.ColStr
+		total int
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT id, v, s FROM test_sparse ORDER BY id",
+		Result: proto.Results{
+			{Name: "id", Data: &id},
+			{Name: "v", Data: &v},
+			{Name: "s", Data: &s},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			total += id.Rows()
+			for i := 0; i < id.Rows(); i++ {
+				n := id.Row(i)
+				switch n % 100 {
+				case 0:
+					require.Equal(t, n, v.Row(i))
+				case 1:
+					require.Equal(t, "x", s.Row(i))
+				default:
+					require.Zero(t, v.Row(i))
+					require.Empty(t, s.Row(i))
+				}
+			}
+			return nil
+		},
+	}))
+	require.Equal(t, 1000, total)
+}
@@ -0,0 +1,171 @@
+package ch
+
+import (
+	"fmt"
+	"io"
+
+	"go.uber.org/atomic"
+)
+
+// ConnState is state of Client connection.
+type ConnState byte
+
+// Possible connection states.
+//
+// State is changed only by goroutine that sends query, so it does not
+// flip while server packets are received during sending of input.
+const (
+	// ConnConnecting means that handshake is not finished yet.
+	ConnConnecting ConnState = iota
+	// ConnIdle means that no query is executed.
+	ConnIdle
+	// ConnSending means that query or its input is being sent. Server
+	// packets, like progress, can be received concurrently.
+	ConnSending
+	// ConnReceiving means that query is sent and client waits for or
+	// reads result.
+	ConnReceiving
+	// ConnClosed means that connection is closed and Client is unusable.
+	ConnClosed
+)
+
+// String implements fmt.Stringer.
+func (s ConnState) String() string {
+	switch s {
+	case ConnConnecting:
+		return "connecting"
+	case ConnIdle:
+		return "idle"
+	case ConnSending:
+		return "sending"
+	case ConnReceiving:
+		return "receiving"
+	case ConnClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("ConnState(%d)", byte(s))
+	}
+}
+
+// Stats are cumulative client statistics.
+//
+// State and QueryID are current values, so they are zero in result of
+// Stats.Add.
+type Stats struct {
+	State   ConnState
+	QueryID string // current query, blank if none
+
+	Queries     uint64 // total queries executed
+	QueryErrors uint64 // total queries failed
+
+	BlocksSent     uint64
+	BlocksReceived uint64
+	RowsSent       uint64
+	RowsReceived   uint64
+
+	BytesSent     uint64 // written to connection
+	BytesReceived uint64 // read from connection
+
+	// UncompressedBytesSent and CompressedBytesSent are total sizes of
+	// sent blocks before and after compression.
+	//
+	// Zero if compression is disabled.
+	UncompressedBytesSent uint64
+	CompressedBytesSent   uint64
+}
+
+// CompressionRatio returns ratio of uncompressed to compressed size of
+// sent blocks, or zero if nothing was compressed.
+func (s Stats) CompressionRatio() float64 {
+	if s.CompressedBytesSent == 0 {
+		return 0
+	}
+	return float64(s.UncompressedBytesSent) / float64(s.CompressedBytesSent)
+}
+
+// Add returns sum of s and other.
+func (s Stats) Add(other Stats) Stats {
+	return Stats{
+		Queries:               s.Queries + other.Queries,
+		QueryErrors:           s.QueryErrors + other.QueryErrors,
+		BlocksSent:            s.BlocksSent + other.BlocksSent,
+		BlocksReceived:        s.BlocksReceived + other.BlocksReceived,
+		RowsSent:              s.RowsSent + other.RowsSent,
+		RowsReceived:          s.RowsReceived + other.RowsReceived,
+		BytesSent:             s.BytesSent + other.BytesSent,
+		BytesReceived:         s.BytesReceived + other.BytesReceived,
+		UncompressedBytesSent: s.UncompressedBytesSent + other.UncompressedBytesSent,
+		CompressedBytesSent:   s.CompressedBytesSent + other.CompressedBytesSent,
+	}
+}
+
+// clientStats is goroutine-safe storage for Stats.
+type clientStats struct {
+	queries               atomic.Uint64
+	queryErrors           atomic.Uint64
+	blocksSent            atomic.Uint64
+	blocksReceived        atomic.Uint64
+	rowsSent              atomic.Uint64
+	rowsReceived          atomic.Uint64
+	bytesSent             atomic.Uint64
+	bytesReceived         atomic.Uint64
+	uncompressedBytesSent atomic.Uint64
+	compressedBytesSent   atomic.Uint64
+
+	state   atomic.Uint32 // ConnState
+	queryID atomic.String
+}
+
+func (s *clientStats) setState(v ConnState) {
+	s.state.Store(uint32(v))
+}
+
+func (s *clientStats) Load() Stats {
+	return Stats{
+		State:                 ConnState(s.state.Load()),
+		QueryID:               s.queryID.Load(),
+		Queries:               s.queries.Load(),
+		QueryErrors:           s.queryErrors.Load(),
+		BlocksSent:            s.blocksSent.Load(),
+		BlocksReceived:        s.blocksReceived.Load(),
+		RowsSent:              s.rowsSent.Load(),
+		RowsReceived:          s.rowsReceived.Load(),
+		BytesSent:             s.bytesSent.Load(),
+		BytesReceived:         s.bytesReceived.Load(),
+		UncompressedBytesSent: s.uncompressedBytesSent.Load(),
+		CompressedBytesSent:   s.compressedBytesSent.Load(),
+	}
+}
+
+// countingReader counts bytes read from connection.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Uint64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(uint64(n))
+	return n, err
+}
+
+// Stats returns cumulative client statistics and current state.
+//
+// Safe to call concurrently with Do.
+func (c *Client) Stats() Stats {
+	s := c.stats.Load()
+	if c.IsClosed() {
+		s.State = ConnClosed
+	}
+	return s
+}
+
+// State returns current state of connection.
+//
+// Safe to call concurrently with Do.
+func (c *Client) State() ConnState {
+	if c.IsClosed() {
+		return ConnClosed
+	}
+	return ConnState(c.stats.state.Load())
+}
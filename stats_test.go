@@ -0,0 +1,109 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestConnState_String(t *testing.T) {
+	for s, v := range map[ConnState]string{
+		ConnConnecting: "connecting",
+		ConnIdle:       "idle",
+		ConnSending:    "sending",
+		ConnReceiving:  "receiving",
+		ConnClosed:     "closed",
+		ConnState(10):  "ConnState(10)",
+	} {
+		require.Equal(t, v, s.String())
+	}
+}
+
+func TestClient_State(t *testing.T) {
+	c := &Client{stats: new(clientStats)}
+	// Handshake is not finished.
+	require.Equal(t, ConnConnecting, c.State())
+
+	c.stats.setState(ConnReceiving)
+	c.stats.queryID.Store("query")
+	require.Equal(t, ConnReceiving, c.State())
+	require.Equal(t, "query", c.Stats().QueryID)
+
+	c.closed = true
+	require.Equal(t, ConnClosed, c.State())
+	require.Equal(t, ConnClosed, c.Stats().State)
+
+	// Current values are not summed.
+	require.Equal(t, Stats{Queries: 2}, Stats{State: ConnSending, QueryID: "a", Queries: 1}.Add(Stats{Queries: 1}))
+}
+
+func TestClient_State_owner(t *testing.T) {
+	ctx := context.Background()
+	conn, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = server.Close()
+	})
+	c := &Client{
+		lg:     zap.NewNop(),
+		conn:   conn,
+		buf:    new(proto.Buffer),
+		reader: proto.NewReader(conn),
+		stats:  new(clientStats),
+	}
+	go func() {
+		_, _ = server.Write([]byte{byte(proto.ServerCodeProgress)})
+		_, _ = io.Copy(io.Discard, server)
+	}()
+
+	// Receiving packets while sending input does not change state.
+	c.stats.setState(ConnSending)
+	code, err := c.packet(ctx)
+	require.NoError(t, err)
+	require.Equal(t, proto.ServerCodeProgress, code)
+	require.Equal(t, ConnSending, c.State())
+
+	c.stats.setState(ConnReceiving)
+	c.buf.PutString("data")
+	require.NoError(t, c.flush(ctx))
+	require.Equal(t, ConnReceiving, c.State())
+}
+
+func TestClient_Stats(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+
+	s := conn.Stats()
+	require.Equal(t, ConnIdle, s.State)
+	require.Empty(t, s.QueryID)
+	require.NotZero(t, s.BytesSent)
+	require.NotZero(t, s.BytesReceived)
+
+	var (
+		data  proto.ColUInt8
+		state Stats
+	)
+	require.NoError(t, conn.Do(ctx, Query{
+		QueryID: "stats-query",
+		Body:    "SELECT 1 AS v",
+		Result:  proto.Results{{Name: "v", Data: &data}},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			state = conn.Stats()
+			return nil
+		},
+	}))
+	require.Equal(t, ConnReceiving, state.State)
+	require.Equal(t, "stats-query", state.QueryID)
+	require.Greater(t, state.BytesReceived, s.BytesReceived)
+
+	s = conn.Stats()
+	require.Equal(t, ConnIdle, s.State)
+	require.Empty(t, s.QueryID)
+}
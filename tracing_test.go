@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/ClickHouse/ch-go/otelch"
 	"github.com/ClickHouse/ch-go/proto"
 )
 
@@ -110,3 +111,32 @@ func TestClient_Do_tracing(t *testing.T) {
 	}))
 	require.Equal(t, traceIDs[0][:], traceID[:])
 }
+
+func TestClient_Do_tracingEvents(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(exporter))
+	conn := ConnOpt(t, Options{
+		OpenTelemetryInstrumentation: true,
+		OpenTelemetrySpanEvents:      true,
+		TracerProvider:               tp,
+	})
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:     "SELECT number FROM system.numbers LIMIT 100",
+		Result:   discardResult(),
+		OnResult: func(ctx context.Context, block proto.Block) error { return nil },
+	}))
+
+	var progress int
+	for _, s := range exporter.GetSpans() {
+		if s.Name != "Do" {
+			continue
+		}
+		for _, e := range s.Events {
+			if e.Name == otelch.ProgressEvent {
+				progress++
+			}
+		}
+	}
+	require.Positive(t, progress, "progress events should be recorded")
+}
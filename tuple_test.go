@@ -68,3 +68,27 @@ func TestNamedTuples(t *testing.T) {
 		},
 	}))
 }
+
+func TestNamedTuples_Infer(t *testing.T) {
+	conn := Conn(t)
+	if v := conn.ServerInfo(); (v.Major < 22) || (v.Major == 22 && v.Minor < 5) {
+		t.Skip("Skipping (not supported)")
+	}
+	ctx := context.Background()
+	results := proto.Results{
+		proto.AutoResult("t"),
+		{Name: "p", Data: proto.NewTuple2[string, int64](new(proto.ColStr), new(proto.ColInt64))},
+	}
+	require.NoError(t, conn.Do(ctx, Query{
+		Body:   "SELECT CAST(('foo', 1), 'Tuple(s String, i Int64)') AS t, ('bar', toInt64(2)) AS p",
+		Result: results,
+	}))
+	tuple := results[0].Data.(*proto.ColAuto).Data.(proto.ColTuple)
+	require.Equal(t, []string{"s", "i"}, tuple.Names())
+	s, ok := tuple.Field("s")
+	require.True(t, ok)
+	require.Equal(t, 1, s.Rows())
+
+	p := results[1].Data.(*proto.ColTuple2[string, int64])
+	require.Equal(t, proto.Tuple2[string, int64]{V1: "bar", V2: 2}, p.Row(0))
+}
@@ -0,0 +1,158 @@
+package ch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ErrTxDone is returned by Tx methods if transaction is already committed
+// or rolled back.
+var ErrTxDone = errors.New("transaction is already committed or rolled back")
+
+// TxID is identifier of transaction, returned by transactionID() function.
+type TxID struct {
+	StartCSN uint64 // commit sequence number of snapshot
+	LocalTID uint64
+	HostID   uuid.UUID
+}
+
+func (id TxID) String() string {
+	return fmt.Sprintf("(%d, %d, %s)", id.StartCSN, id.LocalTID, id.HostID)
+}
+
+// Tx is experimental ClickHouse transaction, see Client.Begin.
+//
+// Transaction is bound to connection, so all queries of client are
+// executed in transaction until it is committed or rolled back. If
+// connection is closed, transaction is rolled back by server.
+type Tx struct {
+	client *Client
+	id     TxID
+	done   bool // guarded by client.mux
+}
+
+// ID of transaction.
+func (tx *Tx) ID() TxID { return tx.id }
+
+// Do executes query in transaction.
+func (tx *Tx) Do(ctx context.Context, q Query) error {
+	if tx.isDone() {
+		return ErrTxDone
+	}
+	return tx.client.Do(ctx, q)
+}
+
+func (tx *Tx) isDone() bool {
+	tx.client.mux.Lock()
+	defer tx.client.mux.Unlock()
+	return tx.done
+}
+
+// finish executes COMMIT or ROLLBACK, finishing transaction regardless of
+// result.
+func (tx *Tx) finish(ctx context.Context, body string) error {
+	c := tx.client
+	c.mux.Lock()
+	if tx.done {
+		c.mux.Unlock()
+		return ErrTxDone
+	}
+	tx.done = true
+	c.tx = nil
+	c.mux.Unlock()
+	return c.Do(ctx, Query{Body: body})
+}
+
+// Commit commits transaction.
+//
+// Transaction can't be committed if some of its queries failed, so it
+// should be rolled back.
+func (tx *Tx) Commit(ctx context.Context) error {
+	return tx.finish(ctx, "COMMIT")
+}
+
+// Rollback rolls back transaction.
+func (tx *Tx) Rollback(ctx context.Context) error {
+	return tx.finish(ctx, "ROLLBACK")
+}
+
+// Begin starts transaction with BEGIN TRANSACTION query and returns it.
+//
+// Transactions are experimental and supported only by MergeTree tables
+// on servers with allow_experimental_transactions enabled. Single query
+// can also be executed in separate transaction with
+// SettingImplicitTransaction instead of Begin.
+func (c *Client) Begin(ctx context.Context) (*Tx, error) {
+	// Reserving transaction slot, so concurrent Begin fails instead of
+	// starting second transaction on the same connection.
+	tx := &Tx{client: c}
+	c.mux.Lock()
+	if c.tx != nil {
+		id := c.tx.id
+		c.mux.Unlock()
+		return nil, errors.Errorf("transaction %s is already started", id)
+	}
+	c.tx = tx
+	c.mux.Unlock()
+
+	id, err := c.begin(ctx)
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if err != nil {
+		c.tx = nil
+		return nil, err
+	}
+	tx.id = id
+	return tx, nil
+}
+
+// begin executes BEGIN TRANSACTION and returns identifier of transaction.
+func (c *Client) begin(ctx context.Context) (TxID, error) {
+	if err := c.Do(ctx, Query{Body: "BEGIN TRANSACTION"}); err != nil {
+		return TxID{}, errors.Wrap(err, "begin")
+	}
+	id, err := c.transactionID(ctx)
+	if err != nil {
+		if !c.IsClosed() {
+			_ = c.Do(ctx, Query{Body: "ROLLBACK"})
+		}
+		return TxID{}, errors.Wrap(err, "transaction id")
+	}
+	return id, nil
+}
+
+// transactionID returns identifier of current transaction.
+func (c *Client) transactionID(ctx context.Context) (TxID, error) {
+	var (
+		startCSN proto.ColUInt64
+		localTID proto.ColUInt64
+		hostID   proto.ColUUID
+		id       TxID
+	)
+	if err := c.Do(ctx, Query{
+		Body: "WITH transactionID() AS tid SELECT tid.1 AS start_csn, tid.2 AS local_tid, tid.3 AS host_id",
+		Result: proto.Results{
+			{Name: "start_csn", Data: &startCSN},
+			{Name: "local_tid", Data: &localTID},
+			{Name: "host_id", Data: &hostID},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			if startCSN.Rows() > 0 {
+				id = TxID{
+					StartCSN: startCSN.Row(0),
+					LocalTID: localTID.Row(0),
+					HostID:   hostID.Row(0),
+				}
+			}
+			return nil
+		},
+	}); err != nil {
+		return TxID{}, err
+	}
+	return id, nil
+}
@@ -0,0 +1,128 @@
+package ch
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestTxID_String(t *testing.T) {
+	id := TxID{
+		StartCSN: 10,
+		LocalTID: 2,
+		HostID:   uuid.MustParse("9d8bcd7a-2b3b-4a6e-a0a4-2d1d3b3e9a3f"),
+	}
+	require.Equal(t, "(10, 2, 9d8bcd7a-2b3b-4a6e-a0a4-2d1d3b3e9a3f)", id.String())
+}
+
+func TestClient_Begin_concurrent(t *testing.T) {
+	ctx := context.Background()
+	// Queries on closed client fail immediately.
+	c := &Client{closed: true, sem: make(chan struct{}, 1), stats: new(clientStats)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Begin(ctx)
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Nil(t, c.tx)
+
+	t.Run("Finish", func(t *testing.T) {
+		tx := &Tx{client: c}
+		c.tx = tx
+
+		var (
+			mux  sync.Mutex
+			done int
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var err error
+				switch i % 3 {
+				case 0:
+					err = tx.Commit(ctx)
+				case 1:
+					err = tx.Rollback(ctx)
+				default:
+					err = tx.Do(ctx, Query{Body: "SELECT 1"})
+				}
+				if errors.Is(err, ErrTxDone) {
+					mux.Lock()
+					done++
+					mux.Unlock()
+				}
+			}(i)
+		}
+		wg.Wait()
+		require.Nil(t, c.tx)
+		// Only one of Commit or Rollback finishes transaction.
+		require.GreaterOrEqual(t, done, 6)
+	})
+}
+
+// beginOrSkip starts transaction or skips test if transactions are not
+// enabled on server.
+func beginOrSkip(t *testing.T, conn *Client) *Tx {
+	t.Helper()
+	tx, err := conn.Begin(context.Background())
+	if HasCode(err, proto.ErrNotImplemented) || HasCode(err, proto.ErrUnsupportedMethod) {
+		t.Skip("Transactions are not supported")
+	}
+	require.NoError(t, err)
+	return tx
+}
+
+func TestTx(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := Conn(t)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_tx (id UInt8) ENGINE = MergeTree ORDER BY id",
+	}))
+	count := func(t *testing.T) uint64 {
+		var v proto.ColUInt64
+		require.NoError(t, conn.Do(ctx, Query{
+			Body:   "SELECT count() FROM test_tx",
+			Result: proto.Results{{Name: "count()", Data: &v}},
+		}))
+		return v.Row(0)
+	}
+	insert := Query{Body: "INSERT INTO test_tx VALUES (1), (2)"}
+
+	tx := beginOrSkip(t, conn)
+	require.NotZero(t, tx.ID().LocalTID)
+	_, err := conn.Begin(ctx)
+	require.Error(t, err, "nested transaction")
+
+	require.NoError(t, tx.Do(ctx, insert))
+	require.NoError(t, tx.Rollback(ctx))
+	require.ErrorIs(t, tx.Commit(ctx), ErrTxDone)
+	require.ErrorIs(t, tx.Do(ctx, insert), ErrTxDone)
+	require.Zero(t, count(t))
+
+	tx = beginOrSkip(t, conn)
+	require.NoError(t, tx.Do(ctx, insert))
+	require.NoError(t, tx.Commit(ctx))
+	require.Equal(t, uint64(2), count(t))
+
+	t.Run("Implicit", func(t *testing.T) {
+		q := insert
+		q.Settings = []Setting{SettingImplicitTransaction(true)}
+		require.NoError(t, conn.Do(ctx, q))
+		require.Equal(t, uint64(4), count(t))
+	})
+}
@@ -0,0 +1,49 @@
+package ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+func TestVariant(t *testing.T) {
+	conn := ConnOpt(t, Options{
+		Settings: []Setting{
+			SettingInt("allow_experimental_variant_type", 1),
+		},
+	})
+	if v := conn.ServerInfo(); v.Major < 24 {
+		t.Skip("Skipping (not supported)")
+	}
+	ctx := context.Background()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "CREATE TABLE test_variant (v Variant(String, UInt64)) ENGINE = Memory",
+	}))
+	data := proto.NewVariant(new(proto.ColStr), new(proto.ColUInt64))
+	require.NoError(t, proto.AppendVariant[string](data, 0, "foo"))
+	require.NoError(t, proto.AppendVariant[uint64](data, 1, 100))
+	data.AppendNull()
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "INSERT INTO test_variant VALUES",
+		Input: proto.Input{
+			{Name: "v", Data: data},
+		},
+	}))
+
+	got := new(proto.ColVariant)
+	require.NoError(t, conn.Do(ctx, Query{
+		Body: "SELECT v FROM test_variant",
+		Result: proto.Results{
+			{Name: "v", Data: got},
+		},
+	}))
+	require.Equal(t, data.Rows(), got.Rows())
+	require.Equal(t, data.Discriminators, got.Discriminators)
+	col, idx := got.Variant(0)
+	require.Equal(t, "foo", col.(*proto.ColAuto).Data.(*proto.ColStr).Row(idx))
+	col, idx = got.Variant(1)
+	require.Equal(t, uint64(100), col.(*proto.ColAuto).Data.(*proto.ColUInt64).Row(idx))
+}
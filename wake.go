@@ -0,0 +1,124 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-faster/errors"
+	"go.uber.org/zap"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// dialWake retries dial while server is possibly waking up, see
+// Options.WakeTimeout.
+//
+// Idle ClickHouse Cloud service is suspended, and connections to it are
+// refused, reset or time out until it is resumed, which can take tens of
+// seconds. Such failures are network errors, while other ones, like
+// authentication exception, are returned immediately.
+//
+// Attempt is limited by deadline passed to dial, so it does not outlast
+// timeout. Only first query is retried, see wakeRetry.
+func dialWake(
+	ctx context.Context, timeout time.Duration, lg *zap.Logger,
+	dial func(ctx context.Context, deadline time.Time) (*Client, error),
+) (*Client, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 100 * time.Millisecond
+	b.MaxInterval = 5 * time.Second
+	b.MaxElapsedTime = timeout
+
+	var (
+		client   *Client
+		start    = time.Now()
+		deadline = start.Add(timeout)
+	)
+	if err := backoff.RetryNotify(func() error {
+		c, err := dial(ctx, deadline)
+		if err != nil {
+			if !isNetworkError(err) || ctx.Err() != nil {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		client = c
+		return nil
+	}, backoff.WithContext(b, ctx), func(err error, d time.Duration) {
+		lg.Info("Waiting for server to wake up",
+			zap.Error(err),
+			zap.Duration("elapsed", time.Since(start)),
+			zap.Duration("backoff", d),
+		)
+	}); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// wakeRetry retries first query of Client dialed with Options.WakeTimeout
+// on new connection, as server can drop connection that was established
+// while it was still waking up.
+type wakeRetry struct {
+	deadline time.Time
+	redial   func(ctx context.Context, timeout time.Duration) (*Client, error)
+}
+
+// takeWake returns wakeRetry of first query, so it is used only once.
+func (c *Client) takeWake() *wakeRetry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	w := c.wake
+	c.wake = nil
+	return w
+}
+
+// canRetryWake reports whether q can be executed again, i.e. its input is
+// not consumed by failed attempt.
+func canRetryWake(q Query) bool {
+	return q.OnInput == nil && q.OnRawInput == nil && q.BodyReader == nil
+}
+
+// doWake executes first query, retrying it on new connection if it fails
+// with network error before anything is received from server.
+func (c *Client) doWake(ctx context.Context, q Query, w *wakeRetry) error {
+	received := c.stats.bytesReceived.Load()
+	err := c.do(ctx, q)
+	if err == nil || !isNetworkError(err) || ctx.Err() != nil ||
+		c.stats.bytesReceived.Load() != received {
+		return err
+	}
+	left := time.Until(w.deadline)
+	if left <= 0 {
+		return err
+	}
+	c.lg.Info("Retrying first query on new connection", zap.Error(err))
+	n, dialErr := w.redial(ctx, left)
+	if dialErr != nil {
+		return errors.Join(err, errors.Wrap(dialErr, "redial"))
+	}
+	if err := c.acquire(ctx); err != nil {
+		_ = n.Close()
+		return err
+	}
+	c.adopt(n)
+	c.release()
+	return c.do(ctx, q)
+}
+
+// adopt replaces connection of c with connection of n, which shares stats
+// with c and is not used after that.
+func (c *Client) adopt(n *Client) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if !c.closed {
+		_ = c.conn.Close()
+	}
+	c.closed = false
+	c.addr, c.conn, c.buf, c.reader = n.addr, n.conn, n.buf, n.reader
+	c.server, c.protocolVersion, c.location = n.server, n.protocolVersion, n.location
+	c.compressor = n.compressor
+	c.chunked, c.chunkedSend, c.packets = n.chunked, n.chunkedSend, n.packets
+	c.chunkedBuffer = proto.Buffer{}
+}
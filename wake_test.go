@@ -0,0 +1,134 @@
+package ch
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/ch-go/cht"
+)
+
+// wakeDialer fails first dials with connection reset, like suspended
+// service that is waking up, then returns err.
+type wakeDialer struct {
+	resets int
+	dials  int
+	err    error
+}
+
+func (d *wakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.dials++
+	if d.dials <= d.resets {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNRESET}
+	}
+	return nil, d.err
+}
+
+func TestDial_wake(t *testing.T) {
+	ctx := context.Background()
+	errAuth := errors.New("authentication failed")
+
+	t.Run("Retry", func(t *testing.T) {
+		d := &wakeDialer{resets: 3, err: errAuth}
+		_, err := Dial(ctx, Options{Dialer: d, WakeTimeout: time.Minute})
+		require.ErrorIs(t, err, errAuth)
+		require.Equal(t, 4, d.dials)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		d := &wakeDialer{resets: 3, err: errAuth}
+		_, err := Dial(ctx, Options{Dialer: d})
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		require.Equal(t, 1, d.dials)
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		d := &wakeDialer{resets: 1000}
+		_, err := Dial(ctx, Options{Dialer: d, WakeTimeout: time.Millisecond * 300})
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		require.Greater(t, d.dials, 1)
+	})
+	t.Run("Context", func(t *testing.T) {
+		d := &wakeDialer{resets: 1000}
+		cancelCtx, cancel := context.WithTimeout(ctx, time.Millisecond*300)
+		defer cancel()
+		_, err := Dial(cancelCtx, Options{Dialer: d, WakeTimeout: time.Hour})
+		require.Error(t, err)
+		require.Greater(t, d.dials, 1)
+	})
+	t.Run("Deadline", func(t *testing.T) {
+		var deadlines []time.Time
+		d := dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			deadline, _ := ctx.Deadline()
+			deadlines = append(deadlines, deadline)
+			return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNRESET}
+		})
+		start := time.Now()
+		_, err := Dial(ctx, Options{Dialer: d, WakeTimeout: time.Millisecond * 300})
+		require.Error(t, err)
+		require.NotEmpty(t, deadlines)
+		for _, deadline := range deadlines {
+			require.WithinDuration(t, start.Add(time.Millisecond*300), deadline, time.Millisecond*100)
+		}
+	})
+	t.Run("Handshake", func(t *testing.T) {
+		// Server never responds to handshake.
+		d := dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, server := net.Pipe()
+			t.Cleanup(func() { _ = server.Close() })
+			go func() { _, _ = io.Copy(io.Discard, server) }()
+			return conn, nil
+		})
+		start := time.Now()
+		_, err := Dial(ctx, Options{Dialer: d, WakeTimeout: time.Millisecond * 300})
+		require.Error(t, err)
+		// Default handshake timeout is limited by wake timeout.
+		require.Less(t, time.Since(start), DefaultHandshakeTimeout/10)
+	})
+}
+
+func TestClient_Do_wake(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := cht.New(t).TCP
+
+	var conns []net.Conn
+	d := dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := new(net.Dialer).DialContext(ctx, network, address)
+		if err == nil {
+			conns = append(conns, conn)
+		}
+		return conn, err
+	})
+	c, err := Dial(ctx, Options{Address: server, Dialer: d, WakeTimeout: time.Minute})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	// Connection is dropped by server that was waking up.
+	require.NoError(t, conns[0].Close())
+	require.NoError(t, c.Do(ctx, Query{Body: "SELECT 1", Result: discardResult()}))
+	require.Len(t, conns, 2)
+	require.False(t, c.IsClosed())
+
+	// Only first query is retried.
+	require.NoError(t, conns[1].Close())
+	require.Error(t, c.Do(ctx, Query{Body: "SELECT 1", Result: discardResult()}))
+	require.Len(t, conns, 2)
+}
+
+func TestCanRetryWake(t *testing.T) {
+	require.True(t, canRetryWake(Query{Body: "SELECT 1"}))
+	require.False(t, canRetryWake(Query{OnInput: func(ctx context.Context) error { return nil }}))
+	require.False(t, canRetryWake(Query{BodyReader: strings.NewReader("SELECT 1")}))
+}
+
+type dialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+func (f dialerFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f(ctx, network, address)
+}